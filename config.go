@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"time"
 
+	"github.com/mikeschinkel/scout-mcp/mcptools"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
 )
 
@@ -107,15 +109,18 @@ end:
 	return err
 }
 
-var _ mcputil.Config = (*Config)(nil)
+var _ mcputil.SourcedConfig = (*Config)(nil)
 
 // Config represents the Scout-MCP server configuration, containing
 // allowed paths, port settings, and runtime validation state.
 // It embeds JSONConfig for serialization while maintaining private runtime data.
 type Config struct {
-	JSONConfig                     // Embedded for JSON operations
-	validPaths map[string]struct{} // Private runtime index
-	path       string
+	JSONConfig                           // Embedded for JSON operations
+	validPaths       map[string]struct{} // Private runtime index
+	path             string
+	fileKeys         map[string]struct{} // Settings given in the config file
+	filePaths        []string            // Allowed paths given in the config file
+	commandLinePaths []string            // Allowed paths given on the command line
 }
 
 // ServerName returns the name of the MCP server.
@@ -142,9 +147,18 @@ end:
 // JSONConfig contains the serializable configuration fields that are
 // written to and read from the configuration file.
 type JSONConfig struct {
-	AllowedPaths   []string `json:"allowed_paths"`
-	Port           string   `json:"port"`
-	AllowedOrigins []string `json:"allowed_origins"`
+	AllowedPaths          []string              `json:"allowed_paths"`
+	Port                  string                `json:"port"`
+	AllowedOrigins        []string              `json:"allowed_origins"`
+	FileLockMode          string                `json:"file_lock_mode,omitempty"`
+	SafeMode              bool                  `json:"safe_mode,omitempty"`
+	ConfirmableOperations []string              `json:"confirmable_operations,omitempty"`
+	SecretRules           []mcptools.SecretRule `json:"secret_rules,omitempty"`
+	MaxFileSize           int64                 `json:"max_file_size,omitempty"`
+	EditBackupCount       int                   `json:"edit_backup_count,omitempty"`
+	SessionTTLMinutes     int                   `json:"session_ttl_minutes,omitempty"`
+	MaxSessionTTLMinutes  int                   `json:"max_session_ttl_minutes,omitempty"`
+	DisableLogRedaction   bool                  `json:"disable_log_redaction,omitempty"`
 }
 
 // ConfigArgs contains the arguments needed to create a new Config instance,
@@ -209,6 +223,113 @@ func (c *Config) AllowedOrigins() []string {
 	return c.JSONConfig.AllowedOrigins
 }
 
+// FileLockMode returns how writes react to files locked by another session,
+// either "warn" (the default) or "refuse".
+func (c *Config) FileLockMode() mcputil.FileLockMode {
+	return mcputil.FileLockMode(c.JSONConfig.FileLockMode)
+}
+
+// SafeMode returns whether destructive operations require confirmation tokens.
+func (c *Config) SafeMode() bool {
+	return c.JSONConfig.SafeMode
+}
+
+// ConfirmableOperations returns the operations safe mode requires
+// confirmation for. An empty slice means every confirmable operation.
+func (c *Config) ConfirmableOperations() (ops []mcputil.ConfirmableOperation) {
+	ops = make([]mcputil.ConfirmableOperation, len(c.JSONConfig.ConfirmableOperations))
+	for i, op := range c.JSONConfig.ConfirmableOperations {
+		ops[i] = mcputil.ConfirmableOperation(op)
+	}
+	return ops
+}
+
+// SecretRules returns the secret detection rules that extend or override
+// the built-in rules of the scan_secrets tool.
+func (c *Config) SecretRules() []mcptools.SecretRule {
+	return c.JSONConfig.SecretRules
+}
+
+// MaxFileSize returns the largest file, in bytes, that tools will write. Zero
+// means mcptools.DefaultMaxFileSize.
+func (c *Config) MaxFileSize() int64 {
+	return c.JSONConfig.MaxFileSize
+}
+
+// EditBackupCount returns how many backups of each file a session keeps for
+// undo_edit. Zero means mcputil.DefaultEditBackupCount.
+func (c *Config) EditBackupCount() int {
+	return c.JSONConfig.EditBackupCount
+}
+
+// SessionTTL returns how long session tokens remain valid unless
+// start_session requests otherwise. Zero means mcputil.DefaultSessionTTL.
+func (c *Config) SessionTTL() time.Duration {
+	return time.Duration(c.JSONConfig.SessionTTLMinutes) * time.Minute
+}
+
+// MaxSessionTTL returns the longest TTL start_session may request. Zero
+// means mcputil.DefaultMaxSessionTTL.
+func (c *Config) MaxSessionTTL() time.Duration {
+	return time.Duration(c.JSONConfig.MaxSessionTTLMinutes) * time.Minute
+}
+
+// LogRedaction returns whether secrets are masked in log records, which is
+// true unless the config disables it.
+func (c *Config) LogRedaction() bool {
+	return !c.JSONConfig.DisableLogRedaction
+}
+
+// RecordFileSettings notes which settings the config file data set, along
+// with its allowed paths, so that Source and PathSource can report them.
+func (c *Config) RecordFileSettings(data []byte) (err error) {
+	var settings map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &settings)
+	if err != nil {
+		goto end
+	}
+
+	c.fileKeys = make(map[string]struct{}, len(settings))
+	for key := range settings {
+		c.fileKeys[key] = struct{}{}
+	}
+	c.filePaths = slices.Clone(c.JSONConfig.AllowedPaths)
+
+end:
+	return err
+}
+
+// SetCommandLinePaths records the allowed paths given on the command line so
+// that PathSource can report them.
+func (c *Config) SetCommandLinePaths(paths []string) {
+	c.commandLinePaths = slices.Clone(paths)
+}
+
+// Source returns where the effective value of the config file setting key
+// came from: the config file, or the default when the file does not set it.
+func (c *Config) Source(key string) mcputil.ConfigSource {
+	_, ok := c.fileKeys[key]
+	if ok {
+		return mcputil.FileConfigSource
+	}
+	return mcputil.DefaultConfigSource
+}
+
+// PathSource returns where the allowed path came from: the config file, the
+// command line, or the default when neither gave any paths.
+func (c *Config) PathSource(path string) (source mcputil.ConfigSource) {
+	switch {
+	case slices.Contains(c.filePaths, path):
+		source = mcputil.FileConfigSource
+	case slices.Contains(c.commandLinePaths, path):
+		source = mcputil.CommandLineConfigSource
+	default:
+		source = mcputil.DefaultConfigSource
+	}
+	return source
+}
+
 // Reset initializes the config's runtime state including default paths and origins.
 func (c *Config) Reset() {
 	c.validPaths = make(map[string]struct{})
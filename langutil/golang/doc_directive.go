@@ -0,0 +1,103 @@
+package golang
+
+import (
+	"go/ast"
+	"strings"
+)
+
+const (
+	// NoDocDirective is the comment that suppresses documentation exceptions
+	// for the declaration it is attached to. It may be written on the line
+	// above the declaration, as part of its doc comment, or at the end of the
+	// declaration's line:
+	//
+	//	//scout:nodoc
+	//	func helper() {}
+	//
+	// Placed above a grouped const, var or type declaration it suppresses the
+	// exceptions of the group and all of its names, and placed above a spec
+	// within the group, those of that spec's names only. Spaces after the
+	// slashes are allowed, and text after the directive, such as the reason
+	// for it, is ignored.
+	NoDocDirective = "scout:nodoc"
+
+	// NoDocFileDirective is the comment that suppresses every documentation
+	// exception of a file, including its missing file comment, when written
+	// anywhere above the file's package clause:
+	//
+	//	//scout:nodoc-file
+	//	package main
+	NoDocFileDirective = "scout:nodoc-file"
+)
+
+// isDirective reports whether the comment c is the directive, allowing
+// spaces between the slashes and the directive, and text after it.
+func isDirective(c *ast.Comment, directive string) bool {
+	text, ok := strings.CutPrefix(c.Text, "//")
+	if !ok {
+		return false
+	}
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, directive) {
+		return false
+	}
+	rest := text[len(directive):]
+	return rest == "" || rest[0] == ' ' || rest[0] == '\t'
+}
+
+// hasDirective reports whether any comment of groups is the directive.
+func hasDirective(groups []*ast.CommentGroup, directive string) bool {
+	for _, cg := range groups {
+		for _, c := range cg.List {
+			if isDirective(c, directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CommentMap returns the map associating the file's comments with the
+// declarations, specs and other nodes they belong to, built on first use.
+func (gf *GoFile) CommentMap() ast.CommentMap {
+	if gf.commentMap == nil {
+		gf.commentMap = ast.NewCommentMap(gf.FileSet(), gf.astFile, gf.astFile.Comments)
+	}
+	return gf.commentMap
+}
+
+// HasNoDoc reports whether a NoDocDirective comment is attached to node, a
+// declaration or a spec, so that its documentation exceptions are skipped.
+func (gf *GoFile) HasNoDoc(node ast.Node) bool {
+	return hasDirective(gf.CommentMap()[node], NoDocDirective)
+}
+
+// HasNoDocFile reports whether a NoDocFileDirective comment precedes the
+// file's package clause, so that none of its documentation exceptions are
+// reported.
+func (gf *GoFile) HasNoDocFile() bool {
+	var groups []*ast.CommentGroup
+	for _, cg := range gf.CommentMap()[gf.astFile] {
+		if cg.Pos() < gf.astFile.Package {
+			groups = append(groups, cg)
+		}
+	}
+	return hasDirective(groups, NoDocFileDirective)
+}
+
+// documentedGenDecl returns gen without the specs that a NoDocDirective is
+// attached to, or gen itself when there are none.
+func (gf *GoFile) documentedGenDecl(gen *ast.GenDecl) *ast.GenDecl {
+	var specs []ast.Spec
+	for _, spec := range gen.Specs {
+		if !gf.HasNoDoc(spec) {
+			specs = append(specs, spec)
+		}
+	}
+	if len(specs) == len(gen.Specs) {
+		return gen
+	}
+	documented := *gen
+	documented.Specs = specs
+	return &documented
+}
@@ -16,6 +16,7 @@ const (
 	ConstException
 	VarException
 	GroupException
+	PackageException
 )
 
 type DocExceptionType int
@@ -40,6 +41,8 @@ func (et DocExceptionType) String() (s string) {
 		s = "Missing var group comment"
 	case GroupException:
 		s = "Missing group comment"
+	case PackageException:
+		s = "Missing package comment"
 	case InvalidDocException:
 		fallthrough
 	default:
@@ -204,11 +207,30 @@ func NewDocException(file string, Type DocExceptionType, args *DocExceptionArgs)
 //
 // The Exclude and ExcludeMode parameters provide flexible control over which
 // files and directories are skipped during traversal, improving performance and relevance.
+//
+// # Exported Identifiers Only
+//
+// Go convention only requires doc comments on exported identifiers. With
+// ExportedOnly set, FuncException, TypeException, ConstException and
+// VarException are not reported for identifiers whose names start in lower
+// case, while package, file and README exceptions still are.
+//
+// # Package Comments
+//
+// Go convention requires a package doc comment in only one file of each
+// package, often doc.go. By default a package is documented when any of its
+// non-test files has a package comment, and otherwise a single
+// PackageException is reported for it, attributed to a doc.go file in its
+// directory. With
+// PerFilePackageDoc set, a FileException is reported for every file that
+// lacks a package comment instead.
 type DocsExceptionsArgs struct {
-	Path        string           // File or directory path to analyze (supports "..." for recursive)
-	Recursive   RecurseDirective // Whether to process directories recursively
-	Exclude     []string         // File and directory names to exclude (used with ExcludeMode)
-	ExcludeMode ExcludeMode      // How to interpret Exclude (default: UseDefaults)
+	Path              string           // File or directory path to analyze (supports "..." for recursive)
+	Recursive         RecurseDirective // Whether to process directories recursively
+	Exclude           []string         // File and directory names to exclude (used with ExcludeMode)
+	ExcludeMode       ExcludeMode      // How to interpret Exclude (default: UseDefaults)
+	ExportedOnly      bool             // Whether to check only exported identifiers
+	PerFilePackageDoc bool             // Whether to require a package comment in every file
 }
 
 // parse parses and update both Path and Recursive, although recursion is
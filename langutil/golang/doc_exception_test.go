@@ -180,11 +180,13 @@ use (
 	setupSubmodule(t, ee, submodule1)
 	tf.Setup(t)
 
-	// Test recursive analysis of entire workspace
+	// Test recursive analysis of entire workspace, requiring a package
+	// comment in every file as the expected exceptions do
 	t.Run("recursive_workspace", func(t *testing.T) {
 		gotExceptions, err := golang.DocExceptions(context.Background(), &golang.DocsExceptionsArgs{
-			Path:      tf.TempDir(),
-			Recursive: golang.DoRecurse})
+			Path:              tf.TempDir(),
+			Recursive:         golang.DoRecurse,
+			PerFilePackageDoc: true})
 
 		if err != nil {
 			t.Errorf("DocExceptions() recursive error = %v", err)
@@ -658,3 +660,181 @@ func TempFunction() {
 		}
 	})
 }
+
+// TestDocExceptionsExportedOnly tests that ExportedOnly suppresses exceptions
+// for unexported identifiers while still reporting exported identifiers and
+// missing file comments and README.md files.
+func TestDocExceptionsExportedOnly(t *testing.T) {
+	fixture := fsfix.NewRootFixture("doc-exceptions-exported-only-test")
+	defer fixture.Cleanup()
+
+	pkgDir := fixture.AddDirFixture("pkg", &fsfix.DirFixtureArgs{})
+	pkgDir.AddFileFixture("pkg.go", &fsfix.FileFixtureArgs{
+		Content: `package pkg
+
+func Exported() {}
+
+func helper() {}
+
+type config struct{}
+
+type Server struct{}
+
+const (
+	maxRetries = 3
+	timeout    = 10
+)
+
+var (
+	Default = 1
+	limit   = 2
+)
+
+var cache map[string]string
+`,
+	})
+
+	fixture.Setup(t)
+
+	exceptions, err := golang.DocExceptions(context.Background(), &golang.DocsExceptionsArgs{
+		Path:         fixture.Dir(),
+		Recursive:    golang.DoRecurse,
+		ExportedOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("DocExceptions() error = %v", err)
+	}
+
+	var got []string
+	for _, exception := range exceptions {
+		got = append(got, filepath.Base(exception.File)+":"+exception.Issue()+":"+exception.Element)
+	}
+	want := []string{
+		"README.md:Missing README.md file:",
+		"doc.go:Missing package comment:pkg",
+		"pkg.go:Missing func comment:Exported",
+		"pkg.go:Missing type comment:Server",
+		"pkg.go:Missing var group comment:",
+		"pkg.go:Missing var comment:Default",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DocExceptions() with ExportedOnly =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestDocExceptionsNoDocDirective tests that the //scout:nodoc directive
+// suppresses the exceptions of the func, type or spec it is attached to, with
+// or without spaces after the slashes, and that //scout:nodoc-file
+// suppresses every exception in its file.
+func TestDocExceptionsNoDocDirective(t *testing.T) {
+	fixture := fsfix.NewRootFixture("doc-exceptions-nodoc-test")
+	defer fixture.Cleanup()
+
+	fixture.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "# Root"})
+	fixture.AddFileFixture("nodoc.go", &fsfix.FileFixtureArgs{
+		Content: `package main
+
+//scout:nodoc
+func helper() {}
+
+func Undocumented() {}
+
+// scout:nodoc generated by a tool
+type Generated struct{}
+
+type Config struct{}
+
+var (
+	// Suppressed is not reported.
+	Suppressed = 1 //scout:nodoc
+
+	Reported = 2
+)
+
+//scout:nodoc
+const (
+	A = 1
+	B = 2
+)
+`,
+	})
+	fixture.AddFileFixture("generated.go", &fsfix.FileFixtureArgs{
+		Content: `//scout:nodoc-file
+
+package main
+
+func Generated() {}
+
+type Unreported struct{}
+`,
+	})
+
+	fixture.Setup(t)
+
+	exceptions, err := golang.DocExceptions(context.Background(), &golang.DocsExceptionsArgs{
+		Path:      fixture.Dir(),
+		Recursive: golang.DoNotRecurse,
+	})
+	if err != nil {
+		t.Fatalf("DocExceptions() error = %v", err)
+	}
+
+	var got []string
+	for _, exception := range exceptions {
+		got = append(got, filepath.Base(exception.File)+":"+exception.Issue()+":"+exception.Element)
+	}
+	want := []string{
+		"doc.go:Missing package comment:main",
+		"nodoc.go:Missing func comment:Undocumented",
+		"nodoc.go:Missing type comment:Config",
+		"nodoc.go:Missing var group comment:",
+		"nodoc.go:Missing var comment:Reported",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DocExceptions() with directives =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestDocExceptionsPackageComment tests that, by default, a package is
+// documented by a package comment in any one of its files, and that a
+// package without one is reported once, attributed to doc.go, rather than
+// once per file, while test files need no package comment.
+func TestDocExceptionsPackageComment(t *testing.T) {
+	fixture := fsfix.NewRootFixture("doc-exceptions-package-comment-test")
+	defer fixture.Cleanup()
+
+	documented := fixture.AddDirFixture("documented", &fsfix.DirFixtureArgs{})
+	documented.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "# Documented"})
+	documented.AddFileFixture("doc.go", &fsfix.FileFixtureArgs{
+		Content: "// Package documented is documented in doc.go.\npackage documented\n",
+	})
+	documented.AddFileFixture("other.go", &fsfix.FileFixtureArgs{Content: "package documented\n"})
+	documented.AddFileFixture("other_test.go", &fsfix.FileFixtureArgs{Content: "package documented_test\n"})
+
+	undocumented := fixture.AddDirFixture("undocumented", &fsfix.DirFixtureArgs{})
+	undocumented.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "# Undocumented"})
+	undocumented.AddFileFixture("a.go", &fsfix.FileFixtureArgs{Content: "package undocumented\n"})
+	undocumented.AddFileFixture("b.go", &fsfix.FileFixtureArgs{Content: "package undocumented\n"})
+
+	fixture.Setup(t)
+
+	exceptions, err := golang.DocExceptions(context.Background(), &golang.DocsExceptionsArgs{
+		Path:      fixture.Dir(),
+		Recursive: golang.DoRecurse,
+	})
+	if err != nil {
+		t.Fatalf("DocExceptions() error = %v", err)
+	}
+
+	var got []string
+	for _, exception := range exceptions {
+		rel, _ := filepath.Rel(fixture.Dir(), exception.File)
+		got = append(got, rel+":"+exception.Issue()+":"+exception.Element)
+	}
+	want := []string{
+		"undocumented/doc.go:Missing package comment:undocumented",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DocExceptions() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,206 @@
+package golang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// GoCallSite is a call to a function or method declared in the same file.
+type GoCallSite struct {
+	Callee string `json:"callee"` // Called function, qualified by receiver type for methods
+	Line   int    `json:"line"`   // Line of the call
+}
+
+// GoFuncCalls lists the calls a function or method makes to the functions
+// and methods declared in the same file.
+type GoFuncCalls struct {
+	Func  string       `json:"func"`  // Function name, qualified by receiver type for methods
+	Line  int          `json:"line"`  // Line of the func keyword
+	Calls []GoCallSite `json:"calls"` // Calls in source order, including those in function literals
+}
+
+// callGraph resolves the calls in a file to the functions declared in it.
+type callGraph struct {
+	fset    *token.FileSet
+	methods map[string]map[string]*ast.FuncDecl // Methods by receiver base type, then name
+}
+
+// ParseCallGraph returns, for every function and method declared in the Go
+// source, the calls it makes to functions and methods declared in the same
+// file. Without type checking, a method call is only resolved when the value
+// it is called on is the method's receiver, or a variable or parameter whose
+// type is evident from its declaration, such as p *Parser or p := &Parser{}.
+func ParseCallGraph(filename string, source []byte) (graph []GoFuncCalls, err error) {
+	var file *ast.File
+	var g callGraph
+	var fd *ast.FuncDecl
+	var ok bool
+
+	g = callGraph{
+		fset:    token.NewFileSet(),
+		methods: make(map[string]map[string]*ast.FuncDecl),
+	}
+	file, err = parser.ParseFile(g.fset, filename, source, 0)
+	if err != nil {
+		goto end
+	}
+
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+			continue
+		}
+		g.addMethod(fd)
+	}
+
+	graph = make([]GoFuncCalls, 0)
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		graph = append(graph, GoFuncCalls{
+			Func:  funcPartName(fd),
+			Line:  g.fset.Position(fd.Pos()).Line,
+			Calls: g.calls(fd.Body),
+		})
+	}
+
+end:
+	return graph, err
+}
+
+// addMethod indexes fd by its receiver's base type and name.
+func (g callGraph) addMethod(fd *ast.FuncDecl) {
+	var typeName string
+
+	typeName = StripTypeParams(recvPartName(baseTypeExpr(fd.Recv.List[0].Type)))
+	if g.methods[typeName] == nil {
+		g.methods[typeName] = make(map[string]*ast.FuncDecl)
+	}
+	g.methods[typeName][fd.Name.Name] = fd
+}
+
+// calls returns the calls within body to functions declared in the file.
+func (g callGraph) calls(body *ast.BlockStmt) (calls []GoCallSite) {
+	calls = make([]GoCallSite, 0)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		callee, ident := g.callee(call.Fun)
+		if callee != nil {
+			calls = append(calls, GoCallSite{
+				Callee: funcPartName(callee),
+				Line:   g.fset.Position(ident.Pos()).Line,
+			})
+		}
+		return true
+	})
+	return calls
+}
+
+// callee returns the declaration of the function fun calls, if it is declared
+// in the file, along with the identifier naming it in the call.
+func (g callGraph) callee(fun ast.Expr) (fd *ast.FuncDecl, ident *ast.Ident) {
+	var sel *ast.SelectorExpr
+	var recv *ast.Ident
+	var ok bool
+
+	fun = ast.Unparen(fun)
+	switch x := fun.(type) {
+	case *ast.IndexExpr:
+		fun = x.X
+	case *ast.IndexListExpr:
+		fun = x.X
+	}
+
+	ident, ok = fun.(*ast.Ident)
+	if ok {
+		if ident.Obj != nil {
+			fd, _ = ident.Obj.Decl.(*ast.FuncDecl)
+		}
+		goto end
+	}
+
+	sel, ok = fun.(*ast.SelectorExpr)
+	if !ok {
+		goto end
+	}
+	ident = sel.Sel
+	recv, ok = ast.Unparen(sel.X).(*ast.Ident)
+	if !ok || recv.Obj == nil {
+		goto end
+	}
+	fd = g.methods[objTypeName(recv.Obj)][sel.Sel.Name]
+
+end:
+	return fd, ident
+}
+
+// objTypeName returns the base type name of the variable obj when its
+// declaration makes it evident: a receiver or parameter, a var with a type,
+// or a variable initialized with a composite literal or its address.
+func objTypeName(obj *ast.Object) (name string) {
+	var expr ast.Expr
+
+	switch decl := obj.Decl.(type) {
+	case *ast.Field:
+		expr = decl.Type
+	case *ast.ValueSpec:
+		expr = decl.Type
+		if expr == nil {
+			expr = initializerOf(obj, decl.Names, decl.Values)
+		}
+	case *ast.AssignStmt:
+		expr = initializerOf(obj, identExprs(decl.Lhs), decl.Rhs)
+	}
+
+	expr = baseTypeExpr(expr)
+	if expr != nil {
+		name = StripTypeParams(recvPartName(expr))
+	}
+	return name
+}
+
+// initializerOf returns the type of the composite literal that initializes
+// obj, the name at the same position in names, or nil if there is none.
+func initializerOf(obj *ast.Object, names []*ast.Ident, values []ast.Expr) (expr ast.Expr) {
+	var unary *ast.UnaryExpr
+	var lit *ast.CompositeLit
+	var ok bool
+
+	if len(names) != len(values) {
+		goto end
+	}
+	for i, name := range names {
+		if name.Obj != obj {
+			continue
+		}
+		expr = ast.Unparen(values[i])
+		unary, ok = expr.(*ast.UnaryExpr)
+		if ok && unary.Op == token.AND {
+			expr = unary.X
+		}
+		lit, ok = expr.(*ast.CompositeLit)
+		expr = nil
+		if ok {
+			expr = lit.Type
+		}
+		goto end
+	}
+
+end:
+	return expr
+}
+
+// baseTypeExpr strips a pointer from a type expression.
+func baseTypeExpr(expr ast.Expr) ast.Expr {
+	star, ok := expr.(*ast.StarExpr)
+	if ok {
+		return star.X
+	}
+	return expr
+}
@@ -119,6 +119,17 @@ func (decl *GoDeclaration) Filename() string {
 //   - Checks for appropriate comment placement (leading vs. end-of-line)
 //   - Handles multi-name declarations appropriately
 //
+// # Suppression Directives
+//
+// Declarations and specs with a NoDocDirective comment attached, according
+// to the file's comment map, are not checked.
+//
+// # Exported Identifiers Only
+//
+// When args.ExportedOnly is set, unexported functions, methods, types,
+// constants and variables are not checked, so a group declaring only
+// unexported names is not reported either.
+//
 // # Context Awareness
 //
 // The analysis is context-aware and considers:
@@ -137,15 +148,34 @@ func (decl *GoDeclaration) Filename() string {
 //
 // The method is designed for efficient batch processing and can be called
 // repeatedly across large codebases without performance concerns.
-func (decl *GoDeclaration) Exceptions(ctx context.Context) (exceptions []DocException) {
+func (decl *GoDeclaration) Exceptions(ctx context.Context, args *DocsExceptionsArgs) (exceptions []DocException) {
 	f := decl.File
+	exportedOnly := args != nil && args.ExportedOnly
+	if f.HasNoDoc(decl.Decl) {
+		goto end
+	}
 	switch d := decl.Decl.(type) {
 	case *ast.FuncDecl:
+		if exportedOnly && !ast.IsExported(d.Name.Name) {
+			break
+		}
 		missing := f.FuncException(d)
 		if missing != nil {
 			exceptions = append(exceptions, *missing)
 		}
 	case *ast.GenDecl:
+		if len(d.Specs) > 0 {
+			// Drop the specs that are suppressed, or unexported when only
+			// exported identifiers are checked, and skip the declaration
+			// when none remain
+			d = f.documentedGenDecl(d)
+			if exportedOnly {
+				d = exportedGenDecl(d)
+			}
+			if len(d.Specs) == 0 {
+				break
+			}
+		}
 		switch d.Tok {
 		case token.TYPE:
 			exceptions = f.TypeExceptions(d)
@@ -155,5 +185,37 @@ func (decl *GoDeclaration) Exceptions(ctx context.Context) (exceptions []DocExce
 			// Not other tokens matter here
 		}
 	}
+
+end:
 	return exceptions
 }
+
+// exportedGenDecl returns a copy of gen declaring only its exported types,
+// constants and variables, with any specs left without names dropped, so
+// that documentation checks pass over unexported identifiers.
+func exportedGenDecl(gen *ast.GenDecl) *ast.GenDecl {
+	exported := *gen
+	exported.Specs = nil
+	for _, spec := range gen.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			if ast.IsExported(s.Name.Name) {
+				exported.Specs = append(exported.Specs, s)
+			}
+		case *ast.ValueSpec:
+			vs := *s
+			vs.Names = nil
+			for _, name := range s.Names {
+				if ast.IsExported(name.Name) {
+					vs.Names = append(vs.Names, name)
+				}
+			}
+			if len(vs.Names) > 0 {
+				exported.Specs = append(exported.Specs, &vs)
+			}
+		default:
+			exported.Specs = append(exported.Specs, spec)
+		}
+	}
+	return &exported
+}
@@ -54,9 +54,13 @@ func (dir *GoDirectory) Exceptions(ctx context.Context, args *DocsExceptionsArgs
 		exceptions = append(exceptions, NewDocException(dir.Path+"/README.md", ReadmeException, nil))
 	}
 
+	if !args.PerFilePackageDoc {
+		exceptions = append(exceptions, dir.PackageExceptions()...)
+	}
+
 	// Check exceptions for all files in the directory
 	for _, f := range dir.files {
-		exceptions = append(exceptions, f.Exceptions(ctx)...)
+		exceptions = append(exceptions, f.Exceptions(ctx, args)...)
 	}
 	if args.Recursive == DoNotRecurse {
 		goto end
@@ -71,6 +75,37 @@ end:
 	return exceptions
 }
 
+// PackageExceptions returns a PackageException, attributed to the first line
+// of a doc.go file in the directory, for each package in the directory none
+// of whose files has a package comment. Test files, which godoc does not
+// show, and files with a NoDocFileDirective are not considered.
+func (dir *GoDirectory) PackageExceptions() (exceptions []DocException) {
+	var names []string
+
+	documented := make(map[string]bool)
+	for _, f := range dir.files {
+		if strings.HasSuffix(f.Name(), "_test.go") || f.HasNoDocFile() {
+			continue
+		}
+		name := f.getPackageName()
+		if _, seen := documented[name]; !seen {
+			names = append(names, name)
+		}
+		documented[name] = documented[name] || f.PackageException() == nil
+	}
+
+	for _, name := range names {
+		if documented[name] {
+			continue
+		}
+		exceptions = append(exceptions, NewDocException(filepath.Join(dir.Path, "doc.go"), PackageException, &DocExceptionArgs{
+			Line:    1,
+			Element: name,
+		}))
+	}
+	return exceptions
+}
+
 type RecurseDirective int
 
 const (
@@ -0,0 +1,364 @@
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// AddImport returns content with importPath imported under alias, or under
+// its package name when alias is empty. An alias of "_" or "." adds a blank
+// or dot import.
+//
+// The import is inserted into the file's parenthesized import declaration,
+// in the blank-line separated group holding imports of the same kind
+// (standard library or not) at the position that keeps the group sorted by
+// path. A new group is added for it if there is none. When the file has only
+// single-line import declarations the first is turned into a parenthesized
+// block holding both imports, and when it has no imports at all a new block
+// is added after the package clause. Only the inserted text changes; the
+// rest of the file keeps its formatting.
+//
+// Content that already imports importPath under the same alias is returned
+// unchanged.
+//
+// Returns an error if:
+//   - importPath is empty or alias is not a valid package name
+//   - content cannot be parsed as Go source
+//   - importPath is already imported under a different alias
+//   - the result is not syntactically valid Go
+func (g *GoProcessor) AddImport(content, importPath, alias string) (result string, err error) {
+	var source []byte
+	var block importBlock
+	var decl *ast.GenDecl
+	var spec string
+	var edit sourceEdit
+
+	if importPath == "" {
+		err = errors.New("import path must not be empty")
+		goto end
+	}
+	if alias != "" && alias != "_" && alias != "." && !token.IsIdentifier(alias) {
+		err = fmt.Errorf("import alias '%s' is not a valid package name", alias)
+		goto end
+	}
+
+	source = []byte(content)
+	block, err = parseImportBlock("", source, "")
+	if err != nil {
+		err = fmt.Errorf("failed to parse Go source: %w", err)
+		goto end
+	}
+
+	for _, group := range block.Groups {
+		for _, existing := range group {
+			if existing.Path != importPath {
+				continue
+			}
+			if existing.Name != alias {
+				err = fmt.Errorf("import %q is already present as %s", importPath, existing.String())
+				goto end
+			}
+			result = content
+			goto end
+		}
+	}
+
+	spec = strconv.Quote(importPath)
+	if alias != "" {
+		spec = alias + " " + spec
+	}
+
+	for _, d := range block.Decls {
+		if d.Lparen.IsValid() {
+			decl = d
+			break
+		}
+	}
+
+	switch {
+	case decl != nil:
+		edit = block.groupedImportEdit(source, decl, importPath, spec)
+	case len(block.Decls) > 0:
+		edit = block.singleImportEdit(source, block.Decls[0], importPath, spec)
+	default:
+		edit = block.newImportEdit(source, spec)
+	}
+
+	result = string(applySourceEdits(source, []sourceEdit{edit}))
+	err = g.ValidateSyntax(result)
+	if err != nil {
+		result = ""
+		err = fmt.Errorf("adding import %q produced invalid Go: %w", importPath, err)
+	}
+
+end:
+	return result, err
+}
+
+// String returns the import as it appears in source, without comments.
+func (s importSpecInfo) String() string {
+	if s.Name == "" {
+		return strconv.Quote(s.Path)
+	}
+	return s.Name + " " + strconv.Quote(s.Path)
+}
+
+// groupedImportEdit returns the edit inserting spec into the parenthesized
+// import declaration decl, within the group of the same import kind or in a
+// new group placed in importKindOrder.
+func (b importBlock) groupedImportEdit(source []byte, decl *ast.GenDecl, importPath, spec string) (edit sourceEdit) {
+	var groups [][]importSpecInfo
+	var kind GoImportKind
+	var rparen int
+
+	groups = b.declGroups(decl)
+	kind = ClassifyImport(importPath, "")
+	rparen = b.fset.Position(decl.Rparen).Offset
+
+	if len(groups) == 0 {
+		edit = sourceEdit{start: rparen, end: rparen, text: "\n\t" + spec + "\n"}
+		goto end
+	}
+
+	for _, group := range groups {
+		if group[0].Kind != kind {
+			continue
+		}
+		for _, existing := range group {
+			if existing.Path > importPath {
+				edit = specInsertBefore(source, existing, spec)
+				goto end
+			}
+		}
+		edit = specInsertAfter(source, group[len(group)-1], spec)
+		goto end
+	}
+
+	for _, group := range groups {
+		if importKindRank(group[0].Kind) > importKindRank(kind) {
+			edit = specInsertBefore(source, group[0], spec)
+			edit.text += "\n"
+			goto end
+		}
+	}
+	edit = specInsertAfter(source, groups[len(groups)-1][len(groups[len(groups)-1])-1], spec)
+	edit.text = "\n" + edit.text
+
+end:
+	return edit
+}
+
+// declGroups returns the groups of import specs belonging to decl.
+func (b importBlock) declGroups(decl *ast.GenDecl) (groups [][]importSpecInfo) {
+	var lparen, rparen int
+
+	lparen = b.fset.Position(decl.Lparen).Offset
+	rparen = b.fset.Position(decl.Rparen).Offset
+	for _, group := range b.Groups {
+		if group[0].Start > lparen && group[0].End <= rparen {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// singleImportEdit returns the edit turning the single-line import
+// declaration decl into a parenthesized block holding both its import and
+// spec, sorted by path.
+func (b importBlock) singleImportEdit(source []byte, decl *ast.GenDecl, importPath, spec string) (edit sourceEdit) {
+	var existing importSpecInfo
+	var specs []string
+
+	existing, _ = b.specInfo(decl.Specs[0].(*ast.ImportSpec), "")
+	specs = []string{string(source[existing.Start:existing.End]), spec}
+	if importPath < existing.Path {
+		specs[0], specs[1] = specs[1], specs[0]
+	}
+
+	return sourceEdit{
+		start: b.fset.Position(decl.Pos()).Offset,
+		end:   existing.End,
+		text:  "import (\n\t" + strings.Join(specs, "\n\t") + "\n)",
+	}
+}
+
+// newImportEdit returns the edit adding an import block holding spec on its
+// own after the package clause.
+func (b importBlock) newImportEdit(source []byte, spec string) (edit sourceEdit) {
+	var offset int
+
+	offset = lineEnd(source, b.fset.Position(b.file.Name.End()).Offset)
+	edit = sourceEdit{start: offset, end: offset, text: "\nimport (\n\t" + spec + "\n)\n"}
+	if offset == len(source) && !bytes.HasSuffix(source, []byte("\n")) {
+		edit.text = "\n" + edit.text
+	}
+	return edit
+}
+
+// specInsertBefore returns the edit inserting spec on its own line before
+// existing, indented as existing is.
+func specInsertBefore(source []byte, existing importSpecInfo, spec string) sourceEdit {
+	start := lineStart(source, existing.Start)
+	indent := string(source[start:existing.Start])
+	if strings.TrimSpace(indent) != "" {
+		// existing shares its line with the opening parenthesis
+		return sourceEdit{start: existing.Start, end: existing.Start, text: spec + "\n\t"}
+	}
+	return sourceEdit{start: start, end: start, text: indent + spec + "\n"}
+}
+
+// specInsertAfter returns the edit inserting spec on its own line after
+// existing, indented as existing is.
+func specInsertAfter(source []byte, existing importSpecInfo, spec string) sourceEdit {
+	end := lineEnd(source, existing.End)
+	if len(bytes.TrimSpace(source[existing.End:end])) > 0 {
+		// existing shares its line with the closing parenthesis
+		return sourceEdit{start: existing.End, end: existing.End, text: "\n\t" + spec}
+	}
+	start := lineStart(source, existing.Start)
+	indent := string(source[start:existing.Start])
+	if strings.TrimSpace(indent) != "" {
+		indent = "\t"
+	}
+	return sourceEdit{start: end, end: end, text: indent + spec + "\n"}
+}
+
+// RemoveImport returns content without the import of importPath, whether it
+// is one spec of a parenthesized import declaration or a single-line import
+// declaration of its own. The spec's doc and line comments are removed with
+// it, as is a blank line left dangling where its group was. A declaration
+// left with no imports, including an emptied "import ()" block, is removed
+// entirely. The rest of the file keeps its formatting.
+//
+// Returns an error if:
+//   - content cannot be parsed as Go source
+//   - importPath is not imported, so there is nothing to remove
+//   - the result is not syntactically valid Go
+func (g *GoProcessor) RemoveImport(content, importPath string) (result string, err error) {
+	var source []byte
+	var block importBlock
+	var decl *ast.GenDecl
+	var spec importSpecInfo
+	var edit sourceEdit
+
+	source = []byte(content)
+	block, err = parseImportBlock("", source, "")
+	if err != nil {
+		err = fmt.Errorf("failed to parse Go source: %w", err)
+		goto end
+	}
+
+	decl, spec, err = block.findImportSpec(importPath)
+	if err != nil {
+		goto end
+	}
+
+	if len(decl.Specs) == 1 {
+		edit = block.declRemoval(source, decl)
+	} else {
+		edit = lineRemoval(source, spec.Start, spec.End)
+	}
+
+	result = string(applySourceEdits(source, []sourceEdit{edit}))
+	err = g.ValidateSyntax(result)
+	if err != nil {
+		result = ""
+		err = fmt.Errorf("removing import %q produced invalid Go: %w", importPath, err)
+	}
+
+end:
+	return result, err
+}
+
+// findImportSpec returns the first import of importPath and the declaration
+// holding it.
+func (b importBlock) findImportSpec(importPath string) (decl *ast.GenDecl, spec importSpecInfo, err error) {
+	for _, d := range b.Decls {
+		for _, s := range d.Specs {
+			spec, err = b.specInfo(s.(*ast.ImportSpec), "")
+			if err != nil {
+				goto end
+			}
+			if spec.Path == importPath {
+				decl = d
+				goto end
+			}
+		}
+	}
+	err = fmt.Errorf("import %q is not present", importPath)
+
+end:
+	return decl, spec, err
+}
+
+// declRemoval returns the edit removing the import declaration decl with its
+// doc comment, along with the lines it occupied when nothing else shares
+// them.
+func (b importBlock) declRemoval(source []byte, decl *ast.GenDecl) (edit sourceEdit) {
+	var start, end token.Pos
+	var is *ast.ImportSpec
+
+	start, end = decl.Pos(), decl.End()
+	if decl.Doc != nil {
+		start = decl.Doc.Pos()
+	}
+	is = decl.Specs[0].(*ast.ImportSpec)
+	if !decl.Lparen.IsValid() && is.Comment != nil {
+		// A single import's line comment belongs to its spec
+		end = is.Comment.End()
+	}
+	return lineRemoval(source, b.fset.Position(start).Offset, b.fset.Position(end).Offset)
+}
+
+// lineRemoval returns the edit removing source[start:end] together with the
+// lines it occupies when nothing else shares them. A blank line left
+// doubled, or left next to a parenthesis, by the removal is removed too.
+func lineRemoval(source []byte, start, end int) (edit sourceEdit) {
+	var lineFrom, lineTo int
+
+	lineFrom = lineStart(source, start)
+	lineTo = lineEnd(source, end)
+	if !isBlank(source[lineFrom:start]) || !isBlank(source[end:lineTo]) {
+		edit = sourceEdit{start: start, end: end}
+		goto end
+	}
+
+	switch {
+	case isBlankLineBefore(source, lineFrom) && (isBlankLineAt(source, lineTo) || isClosingLineAt(source, lineTo)):
+		lineFrom = lineStart(source, lineFrom-1)
+	case isBlankLineAt(source, lineTo) && (lineFrom == 0 || isBlankLineBefore(source, lineFrom) || source[lineFrom-2] == '('):
+		lineTo = lineEnd(source, lineTo)
+	}
+	edit = sourceEdit{start: lineFrom, end: lineTo}
+
+end:
+	return edit
+}
+
+// isBlank reports whether text holds only whitespace.
+func isBlank(text []byte) bool {
+	return len(bytes.TrimSpace(text)) == 0
+}
+
+// isBlankLineBefore reports whether the line ending just before offset, the
+// start of a line, is blank.
+func isBlankLineBefore(source []byte, offset int) bool {
+	return offset > 0 && isBlank(source[lineStart(source, offset-1):offset])
+}
+
+// isBlankLineAt reports whether the line starting at offset is blank and not
+// the end of source.
+func isBlankLineAt(source []byte, offset int) bool {
+	return offset < len(source) && isBlank(source[offset:lineEnd(source, offset)])
+}
+
+// isClosingLineAt reports whether the line starting at offset begins with the
+// closing parenthesis of a declaration.
+func isClosingLineAt(source []byte, offset int) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(source[offset:], " \t"), []byte(")"))
+}
@@ -0,0 +1,174 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+// TestGoProcessor_AddImport verifies that imports are inserted in sorted
+// position within the matching group, that single imports and files without
+// imports gain an import block, and that duplicates are not inserted twice.
+func TestGoProcessor_AddImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		importPath string
+		alias      string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "InsertSortedInStdlibGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n\n\t\"github.com/x/y\"\n)\n",
+			importPath: "os",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n\n\t\"github.com/x/y\"\n)\n",
+		},
+		{
+			name:       "AppendToThirdPartyGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n)\n",
+			importPath: "github.com/c/d",
+			alias:      "cd",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n\tcd \"github.com/c/d\"\n)\n",
+		},
+		{
+			name:       "NewThirdPartyGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n)\n",
+			importPath: "github.com/a/b",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n)\n",
+		},
+		{
+			name:       "NewStdlibGroup",
+			content:    "package main\n\nimport (\n\t\"github.com/a/b\"\n)\n",
+			importPath: "fmt",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n)\n",
+		},
+		{
+			name:       "ConvertSingleImport",
+			content:    "package main\n\nimport \"os\" // for exit\n\nfunc main() { os.Exit(0) }\n",
+			importPath: "fmt",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\t\"os\" // for exit\n)\n\nfunc main() { os.Exit(0) }\n",
+		},
+		{
+			name:       "NoImports",
+			content:    "package main\n\nfunc main() {}\n",
+			importPath: "fmt",
+			alias:      "_",
+			want:       "package main\n\nimport (\n\t_ \"fmt\"\n)\n\nfunc main() {}\n",
+		},
+		{
+			name:       "AlreadyPresent",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n)\n",
+			importPath: "fmt",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n)\n",
+		},
+		{
+			name:       "PresentUnderOtherAlias",
+			content:    "package main\n\nimport (\n\tf \"fmt\"\n)\n",
+			importPath: "fmt",
+			wantErr:    `already present as f "fmt"`,
+		},
+		{
+			name:       "InvalidAlias",
+			content:    "package main\n",
+			importPath: "fmt",
+			alias:      "1x",
+			wantErr:    "not a valid package name",
+		},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.AddImport(tt.content, tt.importPath, tt.alias)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("AddImport() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddImport() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AddImport() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGoProcessor_RemoveImport verifies that a spec is removed with its
+// comments, that an emptied group or declaration leaves no stray blank lines
+// or empty import block, and that removing a missing import is an error.
+func TestGoProcessor_RemoveImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		importPath string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "SpecFromGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\t// needed for exit\n\t\"os\" // exit\n\t\"strings\"\n)\n",
+			importPath: "os",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n",
+		},
+		{
+			name:       "LastGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n)\n",
+			importPath: "github.com/a/b",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n)\n",
+		},
+		{
+			name:       "FirstGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n)\n",
+			importPath: "fmt",
+			want:       "package main\n\nimport (\n\t\"github.com/a/b\"\n)\n",
+		},
+		{
+			name:       "MiddleGroup",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/a/b\"\n\n\t\"example.com/mod/pkg\"\n)\n",
+			importPath: "github.com/a/b",
+			want:       "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/mod/pkg\"\n)\n",
+		},
+		{
+			name:       "EmptiedBlock",
+			content:    "package main\n\nimport (\n\t\"fmt\"\n)\n\nfunc main() {}\n",
+			importPath: "fmt",
+			want:       "package main\n\nfunc main() {}\n",
+		},
+		{
+			name:       "SingleImport",
+			content:    "package main\n\nimport \"fmt\" // printing\nimport \"os\"\n\nfunc main() { os.Exit(0) }\n",
+			importPath: "fmt",
+			want:       "package main\n\nimport \"os\"\n\nfunc main() { os.Exit(0) }\n",
+		},
+		{
+			name:       "NotPresent",
+			content:    "package main\n\nimport \"fmt\"\n",
+			importPath: "os",
+			wantErr:    `import "os" is not present`,
+		},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.RemoveImport(tt.content, tt.importPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RemoveImport() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RemoveImport() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RemoveImport() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
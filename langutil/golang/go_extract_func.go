@@ -0,0 +1,317 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// ExtractFunction moves the statements spanning startLine through endLine of
+// the Go source into a new function named name, declared after the function
+// containing them, and replaces them with a call to it. The new function
+// takes no parameters and returns nothing, so the lines must be whole
+// statements of a single block that neither reference locals declared
+// outside them nor declare locals used after them, and must contain no
+// return, defer or branch statement whose meaning would change once moved.
+// The result is gofmt-formatted.
+func ExtractFunction(filename string, source []byte, startLine, endLine int, name string) (result []byte, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var fd *ast.FuncDecl
+	var stmts []ast.Stmt
+	var lines []string
+	var indent string
+	var declLine int
+	var buf bytes.Buffer
+
+	if !token.IsIdentifier(name) || name == "_" {
+		err = fmt.Errorf("invalid function name '%s'", name)
+		goto end
+	}
+	if startLine < 1 || endLine < startLine {
+		err = fmt.Errorf("invalid line range %d-%d", startLine, endLine)
+		goto end
+	}
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		goto end
+	}
+
+	if file.Scope.Lookup(name) != nil {
+		err = fmt.Errorf("'%s' is already declared in %s", name, filename)
+		goto end
+	}
+
+	fd = enclosingFuncDecl(fset, file, startLine, endLine)
+	if fd == nil {
+		err = fmt.Errorf("lines %d-%d are not inside the body of a function", startLine, endLine)
+		goto end
+	}
+
+	stmts = selectedStmts(fset, fd.Body, startLine, endLine)
+	if stmts == nil || !stmtsFillLines(fset, source, stmts) {
+		err = fmt.Errorf("lines %d-%d do not span whole statements of a single block", startLine, endLine)
+		goto end
+	}
+
+	err = checkExtractable(fd, stmts, name)
+	if err != nil {
+		goto end
+	}
+
+	lines = strings.SplitAfter(string(source), "\n")
+	indent = leadingWhitespace(lines[startLine-1])
+	declLine = fset.Position(fd.End()).Line
+	for i, line := range lines {
+		switch {
+		case i == startLine-1:
+			buf.WriteString(indent + name + "()\n")
+		case i >= startLine && i < endLine:
+		default:
+			buf.WriteString(line)
+		}
+		if i != declLine-1 {
+			continue
+		}
+		if !strings.HasSuffix(line, "\n") {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\nfunc " + name + "() {\n")
+		buf.WriteString(strings.Join(lines[startLine-1:endLine], ""))
+		buf.WriteString("}\n")
+	}
+
+	result, err = format.Source(buf.Bytes())
+	if err != nil {
+		err = fmt.Errorf("extracted source does not format: %w", err)
+	}
+
+end:
+	return result, err
+}
+
+// enclosingFuncDecl returns the function declaration whose body strictly
+// contains the lines, or nil when there is none.
+func enclosingFuncDecl(fset *token.FileSet, file *ast.File, startLine, endLine int) (fd *ast.FuncDecl) {
+	var candidate *ast.FuncDecl
+	var ok bool
+
+	for _, decl := range file.Decls {
+		candidate, ok = decl.(*ast.FuncDecl)
+		if !ok || candidate.Body == nil {
+			continue
+		}
+		if fset.Position(candidate.Body.Lbrace).Line >= startLine {
+			continue
+		}
+		if fset.Position(candidate.Body.Rbrace).Line <= endLine {
+			continue
+		}
+		fd = candidate
+		break
+	}
+	return fd
+}
+
+// selectedStmts returns the statements of the outermost block in body that
+// begin on startLine and end on endLine, or nil when no block has such a run.
+func selectedStmts(fset *token.FileSet, body *ast.BlockStmt, startLine, endLine int) (stmts []ast.Stmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.BlockStmt:
+			stmts = stmtRun(fset, x.List, startLine, endLine)
+		case *ast.CaseClause:
+			stmts = stmtRun(fset, x.Body, startLine, endLine)
+		case *ast.CommClause:
+			stmts = stmtRun(fset, x.Body, startLine, endLine)
+		}
+		return stmts == nil
+	})
+	return stmts
+}
+
+// stmtRun returns the statements of list touching the lines when they begin
+// exactly on startLine and end exactly on endLine.
+func stmtRun(fset *token.FileSet, list []ast.Stmt, startLine, endLine int) (run []ast.Stmt) {
+	var first, last int
+
+	first = -1
+	for i, stmt := range list {
+		if fset.Position(stmt.End()).Line < startLine {
+			continue
+		}
+		if fset.Position(stmt.Pos()).Line > endLine {
+			break
+		}
+		if first == -1 {
+			first = i
+		}
+		last = i
+	}
+	if first == -1 {
+		goto end
+	}
+	if fset.Position(list[first].Pos()).Line != startLine {
+		goto end
+	}
+	if fset.Position(list[last].End()).Line != endLine {
+		goto end
+	}
+	run = list[first : last+1]
+
+end:
+	return run
+}
+
+// stmtsFillLines reports whether the lines holding stmts contain nothing but
+// the statements, whitespace and trailing comments, so moving the lines
+// moves exactly the statements.
+func stmtsFillLines(fset *token.FileSet, source []byte, stmts []ast.Stmt) (ok bool) {
+	var start, stop int
+	var before, after string
+
+	start = fset.Position(stmts[0].Pos()).Offset
+	stop = fset.Position(stmts[len(stmts)-1].End()).Offset
+
+	before = string(source[:start])
+	before = before[strings.LastIndexByte(before, '\n')+1:]
+
+	after = string(source[stop:])
+	after, _, _ = strings.Cut(after, "\n")
+	after = strings.TrimSpace(after)
+
+	ok = strings.TrimSpace(before) == "" && (after == "" || strings.HasPrefix(after, "//"))
+	return ok
+}
+
+// checkExtractable returns an error describing why stmts, within fd, cannot
+// be moved into a parameterless function named name.
+func checkExtractable(fd *ast.FuncDecl, stmts []ast.Stmt, name string) (err error) {
+	var start, stop token.Pos
+	var labels map[string]bool
+
+	start = stmts[0].Pos()
+	stop = stmts[len(stmts)-1].End()
+	inRegion := func(pos token.Pos) bool {
+		return pos >= start && pos < stop
+	}
+
+	ast.Inspect(fd, func(n ast.Node) bool {
+		var ident *ast.Ident
+		var decl ast.Node
+		var ok bool
+
+		ident, ok = n.(*ast.Ident)
+		if err != nil || !ok || ident.Obj == nil || ident.Obj.Kind == ast.Lbl {
+			return err == nil
+		}
+		decl, ok = ident.Obj.Decl.(ast.Node)
+		if !ok || decl == ast.Node(fd) || decl.Pos() < fd.Pos() || decl.Pos() >= fd.End() {
+			return true
+		}
+		switch {
+		case ident.Name == name:
+			err = fmt.Errorf("'%s' is shadowed by a local declaration in %s", name, funcPartName(fd))
+		case inRegion(ident.Pos()) && !inRegion(decl.Pos()):
+			err = fmt.Errorf("lines reference '%s', which is declared outside them", ident.Name)
+		case !inRegion(ident.Pos()) && inRegion(decl.Pos()):
+			err = fmt.Errorf("lines declare '%s', which is used after them", ident.Name)
+		}
+		return err == nil
+	})
+	if err != nil {
+		goto end
+	}
+
+	labels = make(map[string]bool)
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			var ls *ast.LabeledStmt
+			var ok bool
+
+			ls, ok = n.(*ast.LabeledStmt)
+			if ok {
+				labels[ls.Label.Name] = true
+			}
+			return true
+		})
+	}
+
+	for _, stmt := range stmts {
+		err = checkStmtControlFlow(stmt, labels)
+		if err != nil {
+			goto end
+		}
+	}
+
+end:
+	return err
+}
+
+// checkStmtControlFlow returns an error when stmt contains a return, defer,
+// or a branch statement whose target lies outside stmt and is not one of
+// labels. Function literals are skipped since their control flow is their own.
+func checkStmtControlFlow(stmt ast.Stmt, labels map[string]bool) (err error) {
+	var stack []ast.Node
+
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if err != nil {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			err = fmt.Errorf("lines contain a return statement")
+		case *ast.DeferStmt:
+			err = fmt.Errorf("lines contain a defer statement")
+		case *ast.BranchStmt:
+			if !branchTargetInside(x, stack, labels) {
+				err = fmt.Errorf("lines contain a %s statement whose target is outside them", x.Tok)
+			}
+		}
+		if err != nil {
+			return false
+		}
+		stack = append(stack, n)
+		return true
+	})
+	return err
+}
+
+// branchTargetInside reports whether the target of branch is one of labels
+// or, for an unlabeled branch, one of the statements enclosing it in stack.
+func branchTargetInside(branch *ast.BranchStmt, stack []ast.Node, labels map[string]bool) (inside bool) {
+	if branch.Label != nil {
+		inside = labels[branch.Label.Name]
+		goto end
+	}
+	for _, n := range stack {
+		switch n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			inside = true
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			inside = inside || branch.Tok == token.BREAK
+		case *ast.CaseClause:
+			inside = inside || branch.Tok == token.FALLTHROUGH
+		}
+	}
+
+end:
+	return inside
+}
+
+// leadingWhitespace returns the spaces and tabs that begin line.
+func leadingWhitespace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
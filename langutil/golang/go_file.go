@@ -17,6 +17,7 @@ type GoFile struct {
 	dirEntry     os.DirEntry
 	declarations []GoDeclaration
 	packageName  string // Package name when using Directory
+	commentMap   ast.CommentMap
 }
 
 func (gf *GoFile) Parse(ctx context.Context) (err error) {
@@ -65,13 +66,18 @@ func (gf *GoFile) Declarations() []GoDeclaration {
 	return gf.declarations
 }
 
-func (gf *GoFile) Exceptions(ctx context.Context) (exceptions []DocException) {
+func (gf *GoFile) Exceptions(ctx context.Context, args *DocsExceptionsArgs) (exceptions []DocException) {
+	if gf.HasNoDocFile() {
+		return nil
+	}
+	// Without PerFilePackageDoc, package comments are checked per package
+	// by GoDirectory.PackageExceptions instead
 	exception := gf.PackageException()
-	if exception != nil {
+	if exception != nil && (args == nil || args.PerFilePackageDoc) {
 		exceptions = append(exceptions, *exception)
 	}
 	for _, d := range gf.Declarations() {
-		exceptions = append(exceptions, d.Exceptions(ctx)...)
+		exceptions = append(exceptions, d.Exceptions(ctx, args)...)
 	}
 	return exceptions
 }
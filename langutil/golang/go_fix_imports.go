@@ -0,0 +1,187 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// stdlibPackages maps the names of commonly used standard library packages
+// to their import paths. Names shared by several packages, such as rand and
+// template, are left out as there is no telling which one is meant.
+var stdlibPackages = map[string]string{
+	"adler32":   "hash/adler32",
+	"aes":       "crypto/aes",
+	"ast":       "go/ast",
+	"atomic":    "sync/atomic",
+	"base32":    "encoding/base32",
+	"base64":    "encoding/base64",
+	"big":       "math/big",
+	"binary":    "encoding/binary",
+	"bits":      "math/bits",
+	"bufio":     "bufio",
+	"build":     "go/build",
+	"bytes":     "bytes",
+	"cipher":    "crypto/cipher",
+	"cmp":       "cmp",
+	"constant":  "go/constant",
+	"context":   "context",
+	"crc32":     "hash/crc32",
+	"csv":       "encoding/csv",
+	"debug":     "runtime/debug",
+	"ecdsa":     "crypto/ecdsa",
+	"ed25519":   "crypto/ed25519",
+	"embed":     "embed",
+	"errors":    "errors",
+	"exec":      "os/exec",
+	"filepath":  "path/filepath",
+	"flag":      "flag",
+	"fmt":       "fmt",
+	"fnv":       "hash/fnv",
+	"format":    "go/format",
+	"fs":        "io/fs",
+	"gzip":      "compress/gzip",
+	"heap":      "container/heap",
+	"hex":       "encoding/hex",
+	"hmac":      "crypto/hmac",
+	"html":      "html",
+	"http":      "net/http",
+	"httptest":  "net/http/httptest",
+	"httputil":  "net/http/httputil",
+	"io":        "io",
+	"iter":      "iter",
+	"json":      "encoding/json",
+	"list":      "container/list",
+	"log":       "log",
+	"maps":      "maps",
+	"math":      "math",
+	"md5":       "crypto/md5",
+	"mime":      "mime",
+	"multipart": "mime/multipart",
+	"net":       "net",
+	"netip":     "net/netip",
+	"os":        "os",
+	"parser":    "go/parser",
+	"path":      "path",
+	"pem":       "encoding/pem",
+	"printer":   "go/printer",
+	"reflect":   "reflect",
+	"regexp":    "regexp",
+	"rsa":       "crypto/rsa",
+	"runtime":   "runtime",
+	"sha1":      "crypto/sha1",
+	"sha256":    "crypto/sha256",
+	"sha512":    "crypto/sha512",
+	"signal":    "os/signal",
+	"slices":    "slices",
+	"slog":      "log/slog",
+	"sort":      "sort",
+	"sql":       "database/sql",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"sync":      "sync",
+	"syscall":   "syscall",
+	"tabwriter": "text/tabwriter",
+	"tar":       "archive/tar",
+	"testing":   "testing",
+	"time":      "time",
+	"tls":       "crypto/tls",
+	"token":     "go/token",
+	"types":     "go/types",
+	"unicode":   "unicode",
+	"unsafe":    "unsafe",
+	"url":       "net/url",
+	"user":      "os/user",
+	"utf16":     "unicode/utf16",
+	"utf8":      "unicode/utf8",
+	"x509":      "crypto/x509",
+	"xml":       "encoding/xml",
+	"zip":       "archive/zip",
+}
+
+// fixImports returns source with the imports it needs after a replacement
+// changed original into it: imports of standard library packages it refers
+// to but does not import are added, and imports that original used but
+// source no longer does are removed. Imports that were already unused are
+// left alone, so an import whose package name differs from its path is never
+// removed by mistake.
+//
+// Package names are taken from import paths rather than from the packages
+// themselves, and only the standard library packages in stdlibPackages can
+// be added; nothing is downloaded or looked up on disk, so third-party
+// imports are only ever removed, never added.
+func (g *GoProcessor) fixImports(original, source string) (result string, err error) {
+	var before, after *ast.File
+	var usedBefore, usedAfter map[string]bool
+	var imported map[string]bool
+	var missing []string
+	var name, importPath string
+
+	result = source
+
+	before, err = parser.ParseFile(token.NewFileSet(), "", original, 0)
+	if err != nil {
+		goto end
+	}
+	after, err = parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		goto end
+	}
+	usedBefore = packageRefs(before)
+	usedAfter = packageRefs(after)
+
+	imported = make(map[string]bool)
+	for _, is := range after.Imports {
+		name = importName(is)
+		imported[name] = true
+		if name == "_" || name == "." || usedAfter[name] || !usedBefore[name] {
+			continue
+		}
+		importPath, _ = strconv.Unquote(is.Path.Value)
+		result, err = g.RemoveImport(result, importPath)
+		if err != nil {
+			goto end
+		}
+	}
+
+	for name = range usedAfter {
+		if !imported[name] && stdlibPackages[name] != "" {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name = range missing {
+		result, err = g.AddImport(result, stdlibPackages[name], "")
+		if err != nil {
+			goto end
+		}
+	}
+
+end:
+	if err != nil {
+		err = fmt.Errorf("failed to organize imports: %w", err)
+	}
+	return result, err
+}
+
+// packageRefs returns the names file uses as the package of a qualified
+// identifier, such as fmt in fmt.Println: identifiers before a dot that the
+// parser could not resolve to a declaration in the file.
+func packageRefs(file *ast.File) (names map[string]bool) {
+	names = make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if ok && ident.Obj == nil {
+			names[ident.Name] = true
+		}
+		return true
+	})
+	return names
+}
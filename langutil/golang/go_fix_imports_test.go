@@ -0,0 +1,73 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+// TestGoProcessor_ReplacePart_OrganizeImports verifies that a replacement
+// with OrganizeImports set gains the standard library imports it needs and
+// loses the ones it stopped using, while imports that were already unused
+// and third-party imports are left alone.
+func TestGoProcessor_ReplacePart_OrganizeImports(t *testing.T) {
+	const content = "package main\n\n" +
+		"import (\n\t\"fmt\"\n\t\"os\"\n\n\tyaml \"gopkg.in/yaml.v3\"\n)\n\n" +
+		"func main() {\n\tfmt.Println(\"hi\")\n}\n\n" +
+		"func exit() {\n\tos.Exit(1)\n}\n"
+
+	tests := []struct {
+		name            string
+		newContent      string
+		organizeImports bool
+		want            string
+	}{
+		{
+			name:            "AddsAndRemoves",
+			newContent:      "func main() {\n\tlog.Println(strings.ToUpper(\"hi\"))\n}",
+			organizeImports: true,
+			want: "package main\n\n" +
+				"import (\n\t\"log\"\n\t\"os\"\n\t\"strings\"\n\n\tyaml \"gopkg.in/yaml.v3\"\n)\n\n" +
+				"func main() {\n\tlog.Println(strings.ToUpper(\"hi\"))\n}\n\n" +
+				"func exit() {\n\tos.Exit(1)\n}\n",
+		},
+		{
+			name:            "LocalNamesAreNotPackages",
+			newContent:      "func main() {\n\tvar log struct{ Name string }\n\tfmt.Println(log.Name)\n}",
+			organizeImports: true,
+			want: "package main\n\n" +
+				"import (\n\t\"fmt\"\n\t\"os\"\n\n\tyaml \"gopkg.in/yaml.v3\"\n)\n\n" +
+				"func main() {\n\tvar log struct{ Name string }\n\tfmt.Println(log.Name)\n}\n\n" +
+				"func exit() {\n\tos.Exit(1)\n}\n",
+		},
+		{
+			name:       "Disabled",
+			newContent: "func main() {\n\tlog.Println(\"hi\")\n}",
+			want: "package main\n\n" +
+				"import (\n\t\"fmt\"\n\t\"os\"\n\n\tyaml \"gopkg.in/yaml.v3\"\n)\n\n" +
+				"func main() {\n\tlog.Println(\"hi\")\n}\n\n" +
+				"func exit() {\n\tos.Exit(1)\n}\n",
+		},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.ReplacePart(langutil.PartArgs{
+				Language:        langutil.GoLanguage,
+				Content:         content,
+				PartType:        golang.FuncGoPart,
+				PartName:        "main",
+				NewContent:      tt.newContent,
+				OrganizeImports: tt.organizeImports,
+			})
+			if err != nil {
+				t.Fatalf("ReplacePart() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReplacePart() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// FindFuncBody returns the body of the function or method in file named by
+// funcName, as FuncGoPart names it, or nil if there is no such function.
+//
+// The text between body.Lbrace+1 and body.Rbrace is the function's
+// statements, so replacing just that range leaves its doc comment, receiver
+// and signature untouched.
+//
+// Returns an error if the function is declared without a body, as functions
+// implemented in assembly are.
+func FindFuncBody(file *ast.File, funcName string) (body *ast.BlockStmt, err error) {
+	funcDecl := FindFunc(file, funcName)
+	if funcDecl == nil {
+		goto end
+	}
+	body = funcDecl.Body
+	if body == nil {
+		err = fmt.Errorf("function '%s' has no body", funcName)
+	}
+
+end:
+	return body, err
+}
+
+// ValidateFuncBody returns an error unless content is a sequence of
+// statements that can stand as a function body on its own, without the
+// braces around it.
+func ValidateFuncBody(content string) (err error) {
+	var file *ast.File
+
+	file, err = parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+content+"\n}\n", parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("func_body replacement is not a valid function body: %w", err)
+		goto end
+	}
+
+	if len(file.Decls) != 1 {
+		err = errors.New("func_body replacement must be only the statements between the function's braces")
+	}
+
+end:
+	return err
+}
+
+// FuncBodyReplacement returns the text to put between a function's braces
+// for the statements in content: content on lines of its own, so that
+// "return nil" yields "{\nreturn nil\n}" rather than "{return nil}". Content
+// that is empty or blank yields an empty body.
+func FuncBodyReplacement(content string) string {
+	if strings.TrimSpace(content) == "" {
+		return ""
+	}
+	return "\n" + strings.Trim(content, "\n") + "\n"
+}
@@ -0,0 +1,76 @@
+package golang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// GoFuncMetrics describes the size and branching complexity of a single
+// function or method declaration in a Go source file.
+type GoFuncMetrics struct {
+	Name       string `json:"func"`       // Function name, qualified by receiver type for methods
+	Line       int    `json:"line"`       // Line of the func keyword
+	Lines      int    `json:"lines"`      // Number of lines spanned by the declaration
+	Complexity int    `json:"complexity"` // Estimated cyclomatic complexity
+}
+
+// ParseFuncMetrics returns size and complexity metrics for every function
+// and method declared in the Go source. Function literals are counted as
+// part of the declaration that contains them.
+func ParseFuncMetrics(filename string, source []byte) (metrics []GoFuncMetrics, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var fd *ast.FuncDecl
+	var ok bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	metrics = make([]GoFuncMetrics, 0)
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		metrics = append(metrics, GoFuncMetrics{
+			Name:       funcPartName(fd),
+			Line:       fset.Position(fd.Pos()).Line,
+			Lines:      fset.Position(fd.End()).Line - fset.Position(fd.Pos()).Line + 1,
+			Complexity: CyclomaticComplexity(fd.Body),
+		})
+	}
+
+end:
+	return metrics, err
+}
+
+// CyclomaticComplexity estimates the cyclomatic complexity of node by
+// starting at one and adding one for each branch point: if, for, range,
+// non-default case and select clauses, and each && or || operator.
+func CyclomaticComplexity(node ast.Node) (complexity int) {
+	complexity = 1
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if x.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if x.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if x.Op == token.LAND || x.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
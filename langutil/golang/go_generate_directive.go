@@ -0,0 +1,55 @@
+package golang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// generatePrefix begins every go:generate directive.
+const generatePrefix = "//go:generate"
+
+// GoGenerateDirective describes a //go:generate directive in a Go source file.
+type GoGenerateDirective struct {
+	Line    int    `json:"line"`    // Line of the directive
+	Command string `json:"command"` // Command and arguments following //go:generate
+}
+
+// ParseGenerateDirectives returns the //go:generate directives in the Go
+// source, in source order. As with go generate, a directive must be a line
+// comment starting in the first column and followed by a space or tab.
+func ParseGenerateDirectives(filename string, source []byte) (directives []GoGenerateDirective, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var pos token.Position
+	var command string
+	var ok bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	directives = make([]GoGenerateDirective, 0)
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			command, ok = strings.CutPrefix(c.Text, generatePrefix)
+			if !ok || command == "" || (command[0] != ' ' && command[0] != '\t') {
+				continue
+			}
+			pos = fset.Position(c.Slash)
+			if pos.Column != 1 {
+				continue
+			}
+			directives = append(directives, GoGenerateDirective{
+				Line:    pos.Line,
+				Command: strings.TrimSpace(command),
+			})
+		}
+	}
+
+end:
+	return directives, err
+}
@@ -0,0 +1,506 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// interfaceMethod is a method required by an interface, with the file that
+// declares it so that the package qualifiers in its signature can be
+// resolved against that file's imports.
+type interfaceMethod struct {
+	name string
+	ft   *ast.FuncType
+	file *ast.File
+}
+
+// errorMethod is the method of the predeclared error interface.
+var errorMethod = &ast.FuncType{
+	Params:  &ast.FieldList{},
+	Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("string")}}},
+}
+
+// majorVersionRegexp matches the major version element that ends some import
+// paths, such as the v2 in example.com/mod/v2.
+var majorVersionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+// ImplementInterface adds to the Go source a stub, with a
+// panic("not implemented") body, for each method of the interface named
+// interfaceName that the type typeName lacks, returning the gofmt-formatted
+// result and the names of the methods added. The type must be declared in the
+// source; the interface may be declared in it or in pkgFiles, the source of
+// the other files of the package keyed by filename, whose methods on the type
+// are also taken into account. Interfaces embedded in the interface are
+// followed if they are declared in the package or are the predeclared error.
+// Stubs are placed after the type's last method in the file, or after the
+// type when it has none, use the receiver of its existing methods, and bring
+// in any imports their signatures need. A method the type already has with a
+// different signature is an error, since the type could not then implement
+// the interface. Methods promoted from embedded fields are not seen.
+func ImplementInterface(filename string, source []byte, typeName, interfaceName string, pkgFiles map[string][]byte) (result []byte, added []string, err error) {
+	var fset *token.FileSet
+	var file, other, ifaceFile *ast.File
+	var files []*ast.File
+	var typeSpec, ifaceSpec *ast.TypeSpec
+	var methods []interfaceMethod
+	var existing map[string]*ast.FuncDecl
+	var fd *ast.FuncDecl
+	var decl *ast.GenDecl
+	var insertAt token.Pos
+	var recvName, recvType string
+	var stubs strings.Builder
+	var imports []*ast.ImportSpec
+	var edits []sourceEdit
+	var ok bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		goto end
+	}
+	files = append(files, file)
+	for _, name := range slices.Sorted(maps.Keys(pkgFiles)) {
+		other, err = parser.ParseFile(fset, name, pkgFiles[name], 0)
+		if err != nil {
+			goto end
+		}
+		if other.Name.Name == file.Name.Name {
+			files = append(files, other)
+		}
+	}
+
+	typeSpec, _ = packageTypeSpec([]*ast.File{file}, typeName)
+	switch {
+	case typeSpec == nil:
+		err = fmt.Errorf("type '%s' is not declared in %s", typeName, filename)
+	case typeSpec.Assign.IsValid():
+		err = fmt.Errorf("'%s' is an alias, and methods must be declared on the aliased type", typeName)
+	default:
+		_, ok = typeSpec.Type.(*ast.InterfaceType)
+		if ok {
+			err = fmt.Errorf("'%s' is an interface type and cannot have methods", typeName)
+		}
+	}
+	if err != nil {
+		goto end
+	}
+
+	ifaceSpec, ifaceFile = packageTypeSpec(files, interfaceName)
+	if ifaceSpec == nil {
+		err = fmt.Errorf("interface '%s' is not declared in the package", interfaceName)
+		goto end
+	}
+	if ifaceSpec.TypeParams != nil {
+		err = fmt.Errorf("interface '%s' has type parameters, which are not supported", interfaceName)
+		goto end
+	}
+	methods, err = interfaceMethods(files, ifaceSpec, ifaceFile, make(map[string]bool))
+	if err != nil {
+		goto end
+	}
+
+	existing, insertAt = typeMethods(files, file, typeName)
+	if !insertAt.IsValid() {
+		decl = typeGenDecl(file, typeSpec)
+		insertAt = decl.End()
+	}
+	recvName, recvType = stubReceiver(existing, typeSpec)
+
+	added = make([]string, 0)
+	for _, m := range methods {
+		fd, ok = existing[m.name]
+		if ok {
+			if signatureString(fd.Type, "") != signatureString(m.ft, "") {
+				err = fmt.Errorf("'%s' already has a method %s%s, but '%s' requires %s%s",
+					typeName, m.name, signatureString(fd.Type, ""), interfaceName, m.name, signatureString(m.ft, ""))
+				goto end
+			}
+			continue
+		}
+		if hasStructField(typeSpec, m.name) {
+			err = fmt.Errorf("'%s' has a field named %s, so it cannot have a method of that name", typeName, m.name)
+			goto end
+		}
+		fmt.Fprintf(&stubs, "\n// %s implements %s.\nfunc (%s %s) %s%s {\n\tpanic(\"not implemented\")\n}\n",
+			m.name, interfaceName, recvName, recvType, m.name, signatureString(m.ft, recvName))
+		if m.file != file {
+			imports = appendMissingImports(imports, file, m)
+		}
+		added = append(added, m.name)
+	}
+
+	if len(added) == 0 {
+		result = source
+		goto end
+	}
+
+	edits = append(edits, sourceEdit{
+		start: lineEnd(source, fset.Position(insertAt).Offset),
+		end:   lineEnd(source, fset.Position(insertAt).Offset),
+		text:  stubs.String(),
+	})
+	if len(imports) > 0 {
+		edits = append(edits, importInsertion(fset, source, file, imports))
+	}
+
+	result, err = format.Source(applySourceEdits(source, edits))
+	if err != nil {
+		err = fmt.Errorf("implementing '%s' produced invalid Go: %w", interfaceName, err)
+	}
+
+end:
+	return result, added, err
+}
+
+// packageTypeSpec returns the top-level declaration of the type name in files,
+// and the file declaring it.
+func packageTypeSpec(files []*ast.File, name string) (spec *ast.TypeSpec, file *ast.File) {
+	var gd *ast.GenDecl
+	var ts *ast.TypeSpec
+	var ok bool
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok = decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gd.Specs {
+				ts = s.(*ast.TypeSpec)
+				if ts.Name.Name == name {
+					spec, file = ts, f
+					goto end
+				}
+			}
+		}
+	}
+
+end:
+	return spec, file
+}
+
+// typeGenDecl returns the declaration in file holding spec.
+func typeGenDecl(file *ast.File, spec *ast.TypeSpec) (decl *ast.GenDecl) {
+	var gd *ast.GenDecl
+	var ok bool
+
+	for _, d := range file.Decls {
+		gd, ok = d.(*ast.GenDecl)
+		if ok && slices.Contains(gd.Specs, ast.Spec(spec)) {
+			decl = gd
+			break
+		}
+	}
+	return decl
+}
+
+// interfaceMethods returns the methods of the interface declared by spec in
+// file, in declaration order, following embedded interfaces declared in
+// files. Names already in visiting are interfaces being expanded, so an
+// embedding cycle is reported rather than followed forever.
+func interfaceMethods(files []*ast.File, spec *ast.TypeSpec, file *ast.File, visiting map[string]bool) (methods []interfaceMethod, err error) {
+	var it *ast.InterfaceType
+	var embedded *ast.TypeSpec
+	var embeddedFile *ast.File
+	var more []interfaceMethod
+	var ok bool
+
+	it, ok = spec.Type.(*ast.InterfaceType)
+	if !ok {
+		err = fmt.Errorf("'%s' is not an interface type", spec.Name.Name)
+		goto end
+	}
+	if visiting[spec.Name.Name] {
+		err = fmt.Errorf("interface '%s' embeds itself", spec.Name.Name)
+		goto end
+	}
+	visiting[spec.Name.Name] = true
+	defer delete(visiting, spec.Name.Name)
+
+	for _, field := range it.Methods.List {
+		switch x := field.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range field.Names {
+				methods = appendInterfaceMethod(methods, interfaceMethod{name: name.Name, ft: x, file: file})
+			}
+		case *ast.Ident:
+			if x.Name == "error" {
+				methods = appendInterfaceMethod(methods, interfaceMethod{name: "Error", ft: errorMethod, file: file})
+				continue
+			}
+			embedded, embeddedFile = packageTypeSpec(files, x.Name)
+			if embedded == nil {
+				err = fmt.Errorf("'%s' embeds %s, which is not declared in the package", spec.Name.Name, x.Name)
+				goto end
+			}
+			more, err = interfaceMethods(files, embedded, embeddedFile, visiting)
+			if err != nil {
+				goto end
+			}
+			for _, m := range more {
+				methods = appendInterfaceMethod(methods, m)
+			}
+		default:
+			err = fmt.Errorf("'%s' embeds %s, which is not an interface declared in the package", spec.Name.Name, types.ExprString(field.Type))
+			goto end
+		}
+	}
+
+end:
+	return methods, err
+}
+
+// appendInterfaceMethod appends m to methods unless a method of that name,
+// which embedded interfaces may share, is already present.
+func appendInterfaceMethod(methods []interfaceMethod, m interfaceMethod) []interfaceMethod {
+	if slices.ContainsFunc(methods, func(e interfaceMethod) bool { return e.name == m.name }) {
+		return methods
+	}
+	return append(methods, m)
+}
+
+// typeMethods returns the methods declared on typeName in files by name, and
+// the end of the last of them declared in file, if any.
+func typeMethods(files []*ast.File, file *ast.File, typeName string) (methods map[string]*ast.FuncDecl, last token.Pos) {
+	var fd *ast.FuncDecl
+	var ok bool
+
+	methods = make(map[string]*ast.FuncDecl)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fd, ok = decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+				continue
+			}
+			if StripTypeParams(recvPartName(baseTypeExpr(fd.Recv.List[0].Type))) != typeName {
+				continue
+			}
+			methods[fd.Name.Name] = fd
+			if f == file && fd.End() > last {
+				last = fd.End()
+			}
+		}
+	}
+	return methods, last
+}
+
+// stubReceiver returns the receiver name and type for stubs on the type
+// declared by spec, matching those of its existing methods when it has any,
+// preferring pointer receivers, and otherwise a pointer receiver named by the
+// type's initial.
+func stubReceiver(existing map[string]*ast.FuncDecl, spec *ast.TypeSpec) (name, typ string) {
+	var params []string
+	var recv *ast.Field
+	var pointer bool
+
+	pointer = true
+	name = string(unicode.ToLower([]rune(spec.Name.Name)[0]))
+	for _, methodName := range slices.Sorted(maps.Keys(existing)) {
+		recv = existing[methodName].Recv.List[0]
+		if len(recv.Names) > 0 && recv.Names[0].Name != "_" {
+			name = recv.Names[0].Name
+		}
+		_, pointer = recv.Type.(*ast.StarExpr)
+		if pointer {
+			break
+		}
+	}
+
+	typ = spec.Name.Name
+	if spec.TypeParams != nil {
+		for _, field := range spec.TypeParams.List {
+			for _, ident := range field.Names {
+				params = append(params, ident.Name)
+			}
+		}
+		typ += "[" + strings.Join(params, ", ") + "]"
+	}
+	if pointer {
+		typ = "*" + typ
+	}
+	return name, typ
+}
+
+// hasStructField reports whether the type declared by spec is a struct with
+// a field, other than an embedded one, named name.
+func hasStructField(spec *ast.TypeSpec, name string) (has bool) {
+	var st *ast.StructType
+	var ok bool
+
+	st, ok = spec.Type.(*ast.StructType)
+	if !ok {
+		goto end
+	}
+	for _, field := range st.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				has = true
+				goto end
+			}
+		}
+	}
+
+end:
+	return has
+}
+
+// signatureString renders the parameters and results of ft, such as
+// "(p []byte) (n int, err error)". When recvName is empty the names are
+// omitted, giving a form that compares equal for identical signatures;
+// otherwise parameters named recvName are renamed _ so that they do not
+// collide with the receiver.
+func signatureString(ft *ast.FuncType, recvName string) (s string) {
+	var results []string
+
+	s = "(" + strings.Join(fieldStrings(ft.Params, recvName), ", ") + ")"
+	results = fieldStrings(ft.Results, recvName)
+	switch {
+	case len(results) == 0:
+	case len(results) == 1 && (recvName == "" || len(ft.Results.List[0].Names) == 0):
+		s += " " + results[0]
+	default:
+		s += " (" + strings.Join(results, ", ") + ")"
+	}
+	return s
+}
+
+// fieldStrings renders the fields of a parameter or result list, one entry
+// per field, naming them only when recvName is not empty.
+func fieldStrings(list *ast.FieldList, recvName string) (parts []string) {
+	var typ string
+	var names []string
+
+	if list == nil {
+		goto end
+	}
+	for _, field := range list.List {
+		typ = types.ExprString(field.Type)
+		if recvName == "" || len(field.Names) == 0 {
+			for range max(len(field.Names), 1) {
+				parts = append(parts, typ)
+			}
+			continue
+		}
+		names = names[:0]
+		for _, ident := range field.Names {
+			if ident.Name == recvName {
+				names = append(names, "_")
+				continue
+			}
+			names = append(names, ident.Name)
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+typ)
+	}
+
+end:
+	return parts
+}
+
+// appendMissingImports appends to imports the imports of m's file that its
+// signature uses but file does not already import.
+func appendMissingImports(imports []*ast.ImportSpec, file *ast.File, m interfaceMethod) []*ast.ImportSpec {
+	ast.Inspect(m.ft, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || fileImport(file.Imports, pkg.Name) != nil || fileImport(imports, pkg.Name) != nil {
+			return false
+		}
+		spec := fileImport(m.file.Imports, pkg.Name)
+		if spec != nil {
+			imports = append(imports, spec)
+		}
+		return false
+	})
+	return imports
+}
+
+// fileImport returns the import among imports that is referred to as name.
+func fileImport(imports []*ast.ImportSpec, name string) (spec *ast.ImportSpec) {
+	for _, is := range imports {
+		if importName(is) == name {
+			spec = is
+			break
+		}
+	}
+	return spec
+}
+
+// importName returns the name an import is referred to by: its explicit name,
+// or else the last element of its path, skipping a major version element and
+// dropping any suffix after a dot, as for gopkg.in/yaml.v3.
+func importName(is *ast.ImportSpec) (name string) {
+	var importPath, dir string
+
+	if is.Name != nil {
+		name = is.Name.Name
+		goto end
+	}
+	importPath, _ = strconv.Unquote(is.Path.Value)
+	dir, name = path.Split(importPath)
+	if majorVersionRegexp.MatchString(name) && dir != "" {
+		name = path.Base(dir)
+	}
+	name, _, _ = strings.Cut(name, ".")
+
+end:
+	return name
+}
+
+// importInsertion returns the edit adding imports to file: into its first
+// parenthesized import declaration if it has one, and otherwise as a new
+// declaration after its imports or package clause.
+func importInsertion(fset *token.FileSet, source []byte, file *ast.File, imports []*ast.ImportSpec) (edit sourceEdit) {
+	var specs strings.Builder
+	var gd *ast.GenDecl
+	var after token.Pos
+	var ok bool
+	var offset int
+
+	for _, is := range imports {
+		if is.Name != nil {
+			specs.WriteString(is.Name.Name + " ")
+		}
+		specs.WriteString(is.Path.Value + "\n")
+	}
+
+	after = file.Name.End()
+	for _, decl := range file.Decls {
+		gd, ok = decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			break
+		}
+		if gd.Lparen.IsValid() {
+			offset = fset.Position(gd.Rparen).Offset
+			edit = sourceEdit{start: offset, end: offset, text: "\n" + specs.String()}
+			if len(bytes.TrimSpace(source[lineStart(source, offset):offset])) == 0 {
+				// The ) is on its own line, so the specs go just before it
+				edit.start = lineStart(source, offset)
+				edit.end = edit.start
+				edit.text = specs.String()
+			}
+			goto end
+		}
+		after = gd.End()
+	}
+
+	offset = lineEnd(source, fset.Position(after).Offset)
+	edit = sourceEdit{start: offset, end: offset, text: "\nimport (\n" + specs.String() + ")\n"}
+
+end:
+	return edit
+}
@@ -0,0 +1,128 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// GoImportKind classifies where an imported package comes from.
+type GoImportKind string
+
+const (
+	StdlibImport     GoImportKind = "stdlib"      // Part of the Go standard library
+	ThirdPartyImport GoImportKind = "third_party" // Outside the importing file's module
+	ModuleImport     GoImportKind = "module"      // Within the importing file's module
+)
+
+// GoImport describes a single import spec in a Go source file.
+type GoImport struct {
+	Path  string       `json:"path"`            // Unquoted import path
+	Alias string       `json:"alias,omitempty"` // Import name, including "_" and "."
+	Kind  GoImportKind `json:"kind"`            // Where the imported package comes from
+	Line  int          `json:"line"`            // Line of the import spec
+}
+
+// ParseImports returns the imports declared in the Go source, classifying
+// each against modulePath. Only the import section is parsed, so this is
+// cheap even for large files. An empty modulePath means the file is not in
+// a module, in which case non-stdlib imports are always third-party.
+func ParseImports(filename string, source []byte, modulePath string) (imports []GoImport, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var imp GoImport
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ImportsOnly)
+	if err != nil {
+		goto end
+	}
+
+	imports = make([]GoImport, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		imp = GoImport{
+			Line: fset.Position(spec.Pos()).Line,
+		}
+		imp.Path, err = strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			err = fmt.Errorf("invalid import path %s: %w", spec.Path.Value, err)
+			goto end
+		}
+		if spec.Name != nil {
+			imp.Alias = spec.Name.Name
+		}
+		imp.Kind = ClassifyImport(imp.Path, modulePath)
+		imports = append(imports, imp)
+	}
+
+end:
+	return imports, err
+}
+
+// ClassifyImport reports whether importPath is a standard library package,
+// a package within modulePath, or a third-party package. Standard library
+// packages are recognized by the absence of a dot in their first path
+// element, the same rule the go command uses.
+func ClassifyImport(importPath, modulePath string) (kind GoImportKind) {
+	var first string
+
+	if modulePath != "" && (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) {
+		kind = ModuleImport
+		goto end
+	}
+
+	first, _, _ = strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		kind = StdlibImport
+		goto end
+	}
+
+	kind = ThirdPartyImport
+
+end:
+	return kind
+}
+
+// FindModulePath searches dir and its parents for a go.mod file and returns
+// the module path it declares along with the directory containing it. If no
+// go.mod is found both return values are empty and err is nil.
+func FindModulePath(dir string) (modulePath, modDir string, err error) {
+	var content []byte
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		goto end
+	}
+
+	for {
+		content, err = os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			modDir = dir
+			break
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			goto end
+		}
+		err = nil
+		if filepath.Dir(dir) == dir {
+			goto end
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	modulePath = modfile.ModulePath(content)
+	if modulePath == "" {
+		err = fmt.Errorf("no module directive found in %s", filepath.Join(modDir, "go.mod"))
+	}
+
+end:
+	return modulePath, modDir, err
+}
@@ -0,0 +1,315 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importKindOrder lists the import groups in the order goimports conventions
+// expect them: standard library, then third-party, then the file's own module.
+var importKindOrder = []GoImportKind{StdlibImport, ThirdPartyImport, ModuleImport}
+
+// GoImportOrderIssue describes a way in which a Go file's imports deviate
+// from goimports grouping and sorting conventions.
+type GoImportOrderIssue struct {
+	Line    int    `json:"line"`    // Line of the import spec or declaration at fault
+	Message string `json:"message"` // Description of the problem
+}
+
+// importSpecInfo is an import spec with its classification and the source
+// range it occupies, including its doc and line comments.
+type importSpecInfo struct {
+	Path      string
+	Name      string
+	Kind      GoImportKind
+	StartLine int
+	EndLine   int
+	Start     int // Byte offset of the spec or its doc comment
+	End       int // Byte offset just past the spec or its line comment
+}
+
+// importBlock is the parsed import section of a Go file.
+type importBlock struct {
+	Decls  []*ast.GenDecl
+	Groups [][]importSpecInfo // Specs split at blank lines, in source order
+	fset   *token.FileSet
+	file   *ast.File
+}
+
+// CheckImportOrder reports the ways the imports in the Go source deviate from
+// goimports conventions: a single import declaration whose specs are split
+// by blank lines into standard library, third-party, and modulePath groups,
+// in that order, each sorted by import path. An empty modulePath means every
+// non-stdlib import is third-party. Only the import section is parsed.
+func CheckImportOrder(filename string, source []byte, modulePath string) (issues []GoImportOrderIssue, err error) {
+	var block importBlock
+	var seen map[GoImportKind]int
+	var rank, prevRank int
+	var kind GoImportKind
+	var ok bool
+
+	block, err = parseImportBlock(filename, source, modulePath)
+	if err != nil {
+		goto end
+	}
+
+	issues = make([]GoImportOrderIssue, 0)
+	if len(block.Decls) > 1 {
+		issues = append(issues, GoImportOrderIssue{
+			Line:    block.fset.Position(block.Decls[1].Pos()).Line,
+			Message: fmt.Sprintf("imports are split across %d import declarations", len(block.Decls)),
+		})
+	}
+
+	seen = make(map[GoImportKind]int)
+	prevRank = -1
+	for _, group := range block.Groups {
+		issues = append(issues, checkImportGroup(group)...)
+
+		kind = group[0].Kind
+		_, ok = seen[kind]
+		if ok {
+			issues = append(issues, GoImportOrderIssue{
+				Line:    group[0].StartLine,
+				Message: fmt.Sprintf("%s imports are split across multiple groups", kind),
+			})
+			continue
+		}
+		seen[kind] = group[0].StartLine
+
+		rank = importKindRank(kind)
+		if rank < prevRank {
+			issues = append(issues, GoImportOrderIssue{
+				Line:    group[0].StartLine,
+				Message: fmt.Sprintf("%s group should come before %s group", kind, importKindOrder[prevRank]),
+			})
+			continue
+		}
+		prevRank = rank
+	}
+
+end:
+	return issues, err
+}
+
+// checkImportGroup reports a group that mixes import kinds or whose specs are
+// not sorted by path.
+func checkImportGroup(group []importSpecInfo) (issues []GoImportOrderIssue) {
+	for i := 1; i < len(group); i++ {
+		if group[i].Kind != group[0].Kind {
+			issues = append(issues, GoImportOrderIssue{
+				Line:    group[i].StartLine,
+				Message: fmt.Sprintf("%s import %q is grouped with %s imports", group[i].Kind, group[i].Path, group[0].Kind),
+			})
+		}
+		if importSpecLess(group[i], group[i-1]) {
+			issues = append(issues, GoImportOrderIssue{
+				Line:    group[i].StartLine,
+				Message: fmt.Sprintf("import %q should sort before %q", group[i].Path, group[i-1].Path),
+			})
+		}
+	}
+	return issues
+}
+
+// OrganizeImports rewrites the import declaration of the Go source so that it
+// satisfies CheckImportOrder, keeping each spec's doc and line comments with
+// it, and formats the result with gofmt. Source without a parenthesized
+// import declaration is returned unchanged. Files with several import
+// declarations, or with comments in the import block not attached to a spec,
+// are rejected rather than risk losing or misplacing their content.
+func OrganizeImports(filename string, source []byte, modulePath string) (organized []byte, changed bool, err error) {
+	var block importBlock
+	var decl *ast.GenDecl
+	var byKind map[GoImportKind][]importSpecInfo
+	var sections []string
+	var specs []string
+	var lparen, rparen int
+	var buf bytes.Buffer
+
+	organized = source
+
+	block, err = parseImportBlock(filename, source, modulePath)
+	if err != nil {
+		goto end
+	}
+
+	if len(block.Decls) > 1 {
+		err = fmt.Errorf("cannot organize imports split across %d import declarations; merge them first", len(block.Decls))
+		goto end
+	}
+	if len(block.Decls) == 0 || !block.Decls[0].Lparen.IsValid() {
+		goto end
+	}
+	decl = block.Decls[0]
+
+	if block.hasFloatingComments() {
+		err = fmt.Errorf("cannot organize imports with comments not attached to an import spec")
+		goto end
+	}
+
+	byKind = make(map[GoImportKind][]importSpecInfo)
+	for _, group := range block.Groups {
+		for _, spec := range group {
+			byKind[spec.Kind] = append(byKind[spec.Kind], spec)
+		}
+	}
+
+	for _, kind := range importKindOrder {
+		if len(byKind[kind]) == 0 {
+			continue
+		}
+		sort.SliceStable(byKind[kind], func(i, j int) bool {
+			return importSpecLess(byKind[kind][i], byKind[kind][j])
+		})
+		specs = specs[:0]
+		for _, spec := range byKind[kind] {
+			specs = append(specs, string(source[spec.Start:spec.End]))
+		}
+		sections = append(sections, strings.Join(specs, "\n\t"))
+	}
+
+	lparen = block.fset.Position(decl.Lparen).Offset
+	rparen = block.fset.Position(decl.Rparen).Offset
+	buf.Write(source[:lparen])
+	buf.WriteString("(\n\t")
+	buf.WriteString(strings.Join(sections, "\n\n\t"))
+	buf.WriteString("\n)")
+	buf.Write(source[rparen+1:])
+
+	organized, err = format.Source(buf.Bytes())
+	if err != nil {
+		organized = source
+		err = fmt.Errorf("failed to format organized imports: %w", err)
+		goto end
+	}
+	changed = !bytes.Equal(organized, source)
+
+end:
+	return organized, changed, err
+}
+
+// parseImportBlock parses the import section of source and splits the specs
+// of its import declarations into blank-line separated groups.
+func parseImportBlock(filename string, source []byte, modulePath string) (block importBlock, err error) {
+	var gd *ast.GenDecl
+	var ok bool
+	var spec importSpecInfo
+	var group []importSpecInfo
+
+	block.fset = token.NewFileSet()
+	block.file, err = parser.ParseFile(block.fset, filename, source, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		goto end
+	}
+
+	for _, decl := range block.file.Decls {
+		gd, ok = decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		block.Decls = append(block.Decls, gd)
+		group = nil
+		for _, s := range gd.Specs {
+			spec, err = block.specInfo(s.(*ast.ImportSpec), modulePath)
+			if err != nil {
+				goto end
+			}
+			if len(group) > 0 && spec.StartLine-group[len(group)-1].EndLine > 1 {
+				block.Groups = append(block.Groups, group)
+				group = nil
+			}
+			group = append(group, spec)
+		}
+		if len(group) > 0 {
+			block.Groups = append(block.Groups, group)
+		}
+	}
+
+end:
+	return block, err
+}
+
+// specInfo returns the classification and source range of an import spec.
+func (b importBlock) specInfo(is *ast.ImportSpec, modulePath string) (spec importSpecInfo, err error) {
+	var start, end token.Pos
+
+	spec.Path, err = strconv.Unquote(is.Path.Value)
+	if err != nil {
+		err = fmt.Errorf("invalid import path %s: %w", is.Path.Value, err)
+		goto end
+	}
+	if is.Name != nil {
+		spec.Name = is.Name.Name
+	}
+	spec.Kind = ClassifyImport(spec.Path, modulePath)
+
+	start, end = is.Pos(), is.End()
+	if is.Doc != nil {
+		start = is.Doc.Pos()
+	}
+	if is.Comment != nil {
+		end = is.Comment.End()
+	}
+	spec.Start = b.fset.Position(start).Offset
+	spec.End = b.fset.Position(end).Offset
+	spec.StartLine = b.fset.Position(start).Line
+	spec.EndLine = b.fset.Position(end).Line
+
+end:
+	return spec, err
+}
+
+// hasFloatingComments reports whether any comment inside a parenthesized
+// import declaration is neither a spec's doc comment nor its line comment.
+func (b importBlock) hasFloatingComments() (floating bool) {
+	var attached map[*ast.CommentGroup]bool
+	var is *ast.ImportSpec
+
+	attached = make(map[*ast.CommentGroup]bool)
+	for _, decl := range b.Decls {
+		for _, s := range decl.Specs {
+			is = s.(*ast.ImportSpec)
+			attached[is.Doc] = true
+			attached[is.Comment] = true
+		}
+	}
+	for _, decl := range b.Decls {
+		for _, cg := range b.file.Comments {
+			if cg.Pos() < decl.Lparen || cg.End() > decl.Rparen || attached[cg] {
+				continue
+			}
+			floating = true
+			goto end
+		}
+	}
+
+end:
+	return floating
+}
+
+// importSpecLess orders import specs by path, then by name, as gofmt does.
+func importSpecLess(a, b importSpecInfo) bool {
+	if a.Path != b.Path {
+		return a.Path < b.Path
+	}
+	return a.Name < b.Name
+}
+
+// importKindRank returns the position of kind in importKindOrder.
+func importKindRank(kind GoImportKind) (rank int) {
+	for i, k := range importKindOrder {
+		if k == kind {
+			rank = i
+			break
+		}
+	}
+	return rank
+}
@@ -0,0 +1,268 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+)
+
+// sourceEdit replaces the source bytes from start up to end with text.
+type sourceEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// InlineSymbol replaces every reference in the Go source to the package-level
+// constant or variable name with its initializer and removes its declaration,
+// returning the gofmt-formatted result and the number of references replaced.
+// The initializer must be a simple literal such as 42, -1, "text" or true,
+// and is converted to the declared type when there is one. Variables must
+// never be assigned or have their address taken. Exported names may be used
+// by other packages, and other files of the package are not updated, so
+// exported names are refused unless force is set.
+func InlineSymbol(filename string, source []byte, name string, force bool) (result []byte, count int, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var obj *ast.Object
+	var decl *ast.GenDecl
+	var spec *ast.ValueSpec
+	var value string
+	var uses []*ast.Ident
+	var edits []sourceEdit
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		goto end
+	}
+
+	obj = file.Scope.Lookup(name)
+	if obj == nil || (obj.Kind != ast.Con && obj.Kind != ast.Var) {
+		err = fmt.Errorf("'%s' is not a package-level constant or variable in %s", name, filename)
+		goto end
+	}
+
+	if ast.IsExported(name) && !force {
+		err = fmt.Errorf("'%s' is exported and may be used outside %s; set force to inline it anyway", name, filename)
+		goto end
+	}
+
+	decl, spec = valueSpecDecl(file, obj)
+	if decl == nil {
+		err = fmt.Errorf("declaration of '%s' not found", name)
+		goto end
+	}
+
+	value, err = inlineValue(fset, source, decl, spec, name)
+	if err != nil {
+		goto end
+	}
+
+	uses = identUses(file, obj, spec.Names[0])
+	if obj.Kind == ast.Var && isModified(file, obj) {
+		err = fmt.Errorf("'%s' is assigned or has its address taken, so it cannot be inlined", name)
+		goto end
+	}
+
+	for _, ident := range uses {
+		edits = append(edits, sourceEdit{
+			start: fset.Position(ident.Pos()).Offset,
+			end:   fset.Position(ident.End()).Offset,
+			text:  value,
+		})
+	}
+	edits = append(edits, declRemoval(fset, source, decl, spec))
+	count = len(uses)
+
+	result, err = format.Source(applySourceEdits(source, edits))
+	if err != nil {
+		err = fmt.Errorf("inlining '%s' produced invalid Go: %w", name, err)
+	}
+
+end:
+	return result, count, err
+}
+
+// valueSpecDecl returns the top-level declaration and spec declaring obj.
+func valueSpecDecl(file *ast.File, obj *ast.Object) (decl *ast.GenDecl, spec *ast.ValueSpec) {
+	var gd *ast.GenDecl
+	var ok bool
+
+	spec, ok = obj.Decl.(*ast.ValueSpec)
+	if !ok {
+		goto end
+	}
+	for _, d := range file.Decls {
+		gd, ok = d.(*ast.GenDecl)
+		if ok && slices.Contains(gd.Specs, ast.Spec(spec)) {
+			decl = gd
+			goto end
+		}
+	}
+	spec = nil
+
+end:
+	return decl, spec
+}
+
+// inlineValue returns the source text to substitute for each reference to
+// the symbol declared by spec: its literal initializer, converted to the
+// declared type if there is one.
+func inlineValue(fset *token.FileSet, source []byte, decl *ast.GenDecl, spec *ast.ValueSpec, name string) (value string, err error) {
+	var expr ast.Expr
+	var i int
+	var literal string
+
+	if len(spec.Names) != 1 {
+		err = fmt.Errorf("'%s' is declared together with other names", name)
+		goto end
+	}
+	if len(spec.Values) != 1 {
+		err = fmt.Errorf("'%s' has no initializer of its own", name)
+		goto end
+	}
+
+	// In a const group, specs without values repeat the previous expression
+	i = slices.Index(decl.Specs, ast.Spec(spec))
+	if decl.Tok == token.CONST && i+1 < len(decl.Specs) && len(decl.Specs[i+1].(*ast.ValueSpec).Values) == 0 {
+		err = fmt.Errorf("the constants following '%s' repeat its initializer", name)
+		goto end
+	}
+
+	expr = spec.Values[0]
+	if !isSimpleLiteral(expr) {
+		err = fmt.Errorf("initializer of '%s' is not a simple literal", name)
+		goto end
+	}
+
+	literal = nodeSource(fset, source, expr)
+	switch {
+	case spec.Type != nil:
+		value = nodeSource(fset, source, spec.Type) + "(" + literal + ")"
+	case literal[0] == '-' || literal[0] == '+':
+		// Parenthesized so that x-N cannot become x--1
+		value = "(" + literal + ")"
+	default:
+		value = literal
+	}
+
+end:
+	return value, err
+}
+
+// isSimpleLiteral reports whether expr is a basic literal, optionally signed,
+// or the predeclared true or false.
+func isSimpleLiteral(expr ast.Expr) (simple bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		simple = true
+	case *ast.Ident:
+		simple = x.Obj == nil && (x.Name == "true" || x.Name == "false")
+	case *ast.UnaryExpr:
+		_, simple = x.X.(*ast.BasicLit)
+		simple = simple && (x.Op == token.SUB || x.Op == token.ADD)
+	}
+	return simple
+}
+
+// identUses returns the identifiers in file that refer to obj, other than
+// decl, which declares it.
+func identUses(file *ast.File, obj *ast.Object, decl *ast.Ident) (uses []*ast.Ident) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if ok && ident != decl && ident.Obj == obj {
+			uses = append(uses, ident)
+		}
+		return true
+	})
+	return uses
+}
+
+// isModified reports whether file assigns to, increments, decrements or takes
+// the address of the variable obj.
+func isModified(file *ast.File, obj *ast.Object) (modified bool) {
+	refersTo := func(expr ast.Expr) bool {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && ident.Obj == obj
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			modified = modified || slices.ContainsFunc(x.Lhs, refersTo)
+		case *ast.IncDecStmt:
+			modified = modified || refersTo(x.X)
+		case *ast.RangeStmt:
+			modified = modified || (x.Tok == token.ASSIGN && (refersTo(x.Key) || refersTo(x.Value)))
+		case *ast.UnaryExpr:
+			modified = modified || (x.Op == token.AND && refersTo(x.X))
+		}
+		return !modified
+	})
+	return modified
+}
+
+// declRemoval returns the edit deleting the whole lines holding spec, with
+// its comments, or all of decl when spec is its only spec.
+func declRemoval(fset *token.FileSet, source []byte, decl *ast.GenDecl, spec *ast.ValueSpec) sourceEdit {
+	var start, end token.Pos
+
+	start, end = decl.Pos(), decl.End()
+	if decl.Doc != nil {
+		start = decl.Doc.Pos()
+	}
+	if len(decl.Specs) > 1 {
+		start, end = spec.Pos(), spec.End()
+		if spec.Doc != nil {
+			start = spec.Doc.Pos()
+		}
+		if spec.Comment != nil {
+			end = spec.Comment.End()
+		}
+	}
+	return sourceEdit{
+		start: lineStart(source, fset.Position(start).Offset),
+		end:   lineEnd(source, fset.Position(end).Offset),
+	}
+}
+
+// lineStart returns the offset of the start of the line holding offset.
+func lineStart(source []byte, offset int) int {
+	for offset > 0 && source[offset-1] != '\n' {
+		offset--
+	}
+	return offset
+}
+
+// lineEnd returns the offset just past the newline ending the line holding
+// offset, or the end of source.
+func lineEnd(source []byte, offset int) int {
+	for offset < len(source) && source[offset] != '\n' {
+		offset++
+	}
+	return min(offset+1, len(source))
+}
+
+// nodeSource returns the source text of node.
+func nodeSource(fset *token.FileSet, source []byte, node ast.Node) string {
+	return string(source[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset])
+}
+
+// applySourceEdits returns source with the non-overlapping edits applied.
+func applySourceEdits(source []byte, edits []sourceEdit) (result []byte) {
+	var last int
+
+	slices.SortFunc(edits, func(a, b sourceEdit) int {
+		return a.start - b.start
+	})
+	for _, e := range edits {
+		result = append(result, source[last:e.start]...)
+		result = append(result, e.text...)
+		last = e.end
+	}
+	result = append(result, source[last:]...)
+	return result
+}
@@ -0,0 +1,91 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// FindInterfaceMethod returns the method of a package-level interface type
+// in file named by methodPath, the interface's name and the method's joined
+// by a dot, such as "UserService.GetUser".
+//
+// The method's own range, from field.Pos() to field.End(), covers its name
+// and signature but not its doc or line comment, so replacing just that
+// range leaves the interface's other methods and all comments untouched.
+//
+// Returns an error if methodPath is not of the form "Interface.Method", no
+// type of that name is declared in file, the type is not an interface, or
+// the interface declares no such method. Methods the interface only gains
+// by embedding another interface are not found, as they have no signature
+// of their own to replace.
+func FindInterfaceMethod(file *ast.File, methodPath string) (field *ast.Field, err error) {
+	var typeName, methodName string
+	var ok bool
+	var spec *ast.TypeSpec
+	var iface *ast.InterfaceType
+
+	typeName, methodName, ok = strings.Cut(methodPath, ".")
+	if !ok || typeName == "" || methodName == "" || strings.Contains(methodName, ".") {
+		err = fmt.Errorf("method name '%s' must have the form Interface.Method", methodPath)
+		goto end
+	}
+
+	spec, _ = packageTypeSpec([]*ast.File{file}, typeName)
+	if spec == nil {
+		err = fmt.Errorf("interface '%s' is not declared in the file", typeName)
+		goto end
+	}
+
+	iface, ok = spec.Type.(*ast.InterfaceType)
+	if !ok {
+		err = fmt.Errorf("'%s' is not an interface type", typeName)
+		goto end
+	}
+
+	for _, method := range iface.Methods.List {
+		if len(method.Names) == 1 && method.Names[0].Name == methodName {
+			field = method
+			goto end
+		}
+	}
+	err = fmt.Errorf("interface '%s' has no method '%s'", typeName, methodName)
+
+end:
+	return field, err
+}
+
+// ValidateInterfaceMethod returns an error unless content is exactly one
+// interface method signature, such as
+// "GetUser(ctx context.Context, id string) (*User, error)", optionally with
+// comments.
+func ValidateInterfaceMethod(content string) (err error) {
+	var file *ast.File
+	var spec *ast.TypeSpec
+	var methods *ast.FieldList
+	var isFunc bool
+
+	file, err = parser.ParseFile(token.NewFileSet(), "", "package p\ntype _ interface {\n"+content+"\n}\n", parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("method replacement is not a valid method signature: %w", err)
+		goto end
+	}
+
+	spec, _ = packageTypeSpec([]*ast.File{file}, "_")
+	methods = spec.Type.(*ast.InterfaceType).Methods
+	if len(file.Decls) != 1 || len(methods.List) != 1 {
+		err = errors.New("method replacement must declare exactly one method signature")
+		goto end
+	}
+
+	_, isFunc = methods.List[0].Type.(*ast.FuncType)
+	if len(methods.List[0].Names) != 1 || !isFunc {
+		err = errors.New("method replacement must be a method signature, not an embedded interface or type constraint")
+	}
+
+end:
+	return err
+}
@@ -0,0 +1,195 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+// ListParts returns every construct of partType in the Go source content, in
+// source order, each with its name set to the name FindPart finds it by and
+// the same range FindPart would return for that name. Methods are named
+// "ReceiverType.MethodName", with a leading "*" for pointer receivers, and
+// imports by their unquoted path.
+//
+// Constants, variables and types declared together in one declaration each
+// get an entry of their own, but share the declaration's range, as replacing
+// one of them replaces the whole declaration. Fields are listed for every
+// package-level struct type, including fields of nested anonymous structs,
+// and methods for every package-level interface type.
+//
+// Returns an error if content cannot be parsed or partType is not one of
+// SupportedPartTypes. A file with no constructs of partType yields an empty
+// list, not an error.
+func (g *GoProcessor) ListParts(content string, partType langutil.PartType) (parts []langutil.PartInfo, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("failed to parse Go file: %w", err)
+		goto end
+	}
+
+	parts, err = listGoParts(fset, file, content, partType)
+
+end:
+	return parts, err
+}
+
+// listGoParts returns every construct of partType in file, parsed from
+// content, as ListParts describes.
+func listGoParts(fset *token.FileSet, file *ast.File, content string, partType langutil.PartType) (parts []langutil.PartInfo, err error) {
+	var add func(name string, start, end token.Pos)
+
+	parts = make([]langutil.PartInfo, 0)
+	add = func(name string, start, end token.Pos) {
+		startPos, endPos := fset.Position(start), fset.Position(end)
+		parts = append(parts, langutil.PartInfo{
+			Name:        name,
+			StartLine:   startPos.Line,
+			EndLine:     endPos.Line,
+			StartOffset: startPos.Offset,
+			EndOffset:   endPos.Offset,
+			Content:     content[startPos.Offset:endPos.Offset],
+			Found:       true,
+		})
+	}
+
+	switch partType {
+	case PackageGoPart:
+		add(file.Name.Name, file.Name.Pos(), file.Name.End())
+	case ImportGoPart:
+		for _, imp := range file.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			add(importPath, imp.Pos(), imp.End())
+		}
+	case ConstGoPart, VarGoPart, TypeGoPart:
+		listGenDeclParts(file, partType, add)
+	case FieldGoPart:
+		listMemberParts(file, func(spec *ast.TypeSpec) {
+			listStructFields(spec.Name.Name, structFields(spec.Type), add)
+		})
+	case MethodGoPart:
+		listMemberParts(file, func(spec *ast.TypeSpec) {
+			iface, ok := spec.Type.(*ast.InterfaceType)
+			if !ok {
+				return
+			}
+			for _, method := range iface.Methods.List {
+				if len(method.Names) == 1 {
+					add(spec.Name.Name+"."+method.Names[0].Name, method.Pos(), method.End())
+				}
+			}
+		})
+	case FuncGoPart:
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				add(funcPartName(funcDecl), funcDecl.Pos(), funcDecl.End())
+			}
+		}
+	case FuncBodyGoPart:
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				add(funcPartName(funcDecl), funcDecl.Body.Lbrace+1, funcDecl.Body.Rbrace)
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported part type: %s", partType)
+	}
+
+	return parts, err
+}
+
+// findGoPartAtLine returns the innermost construct of partType in file whose
+// range includes line, such as the function whose body holds it, or a
+// PartInfo with Found=false if there is none.
+func findGoPartAtLine(fset *token.FileSet, file *ast.File, content string, partType langutil.PartType, line int) (pi *langutil.PartInfo, err error) {
+	var parts []langutil.PartInfo
+
+	pi = &langutil.PartInfo{Found: false}
+
+	parts, err = listGoParts(fset, file, content, partType)
+	if err != nil {
+		goto end
+	}
+
+	for i, part := range parts {
+		if line < part.StartLine || line > part.EndLine {
+			continue
+		}
+		if !pi.Found || part.EndOffset-part.StartOffset < pi.EndOffset-pi.StartOffset {
+			pi = &parts[i]
+		}
+	}
+
+end:
+	return pi, err
+}
+
+// listGenDeclParts adds an entry for each name declared by the package-level
+// const, var or type declarations of file, as partType selects.
+func listGenDeclParts(file *ast.File, partType langutil.PartType, add func(name string, start, end token.Pos)) {
+	tok := map[langutil.PartType]token.Token{
+		ConstGoPart: token.CONST,
+		VarGoPart:   token.VAR,
+		TypeGoPart:  token.TYPE,
+	}[partType]
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != tok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					add(name.Name, genDecl.Pos(), genDecl.End())
+				}
+			case *ast.TypeSpec:
+				add(s.Name.Name, genDecl.Pos(), genDecl.End())
+			}
+		}
+	}
+}
+
+// listMemberParts calls list for each package-level type spec of file.
+func listMemberParts(file *ast.File, list func(spec *ast.TypeSpec)) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			list(spec.(*ast.TypeSpec))
+		}
+	}
+}
+
+// listStructFields adds an entry for each field in fields, named by prefix
+// and the field's name, and for the fields of any anonymous struct types
+// nested within them.
+func listStructFields(prefix string, fields *ast.FieldList, add func(name string, start, end token.Pos)) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		names := []string{embeddedFieldName(field.Type)}
+		if len(field.Names) > 0 {
+			names = names[:0]
+			for _, ident := range field.Names {
+				names = append(names, ident.Name)
+			}
+		}
+		for _, name := range names {
+			add(prefix+"."+name, field.Pos(), field.End())
+			listStructFields(prefix+"."+name, structFields(field.Type), add)
+		}
+	}
+}
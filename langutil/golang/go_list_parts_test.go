@@ -0,0 +1,132 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+const listPartsContent = `package demo
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	A = 1
+	B = 2
+)
+
+var x, y int
+
+type Config struct {
+	Port   int
+	Server struct {
+		Host string
+	}
+	fmt.Stringer
+}
+
+type Store interface {
+	Get(id string) string
+}
+
+func main() {}
+
+func (c *Config) Start() { fmt.Println(os.Args) }
+
+func (c Config) String() string { return "" }
+`
+
+// TestGoProcessor_ListParts verifies that ListParts returns every construct
+// of a type in source order, named and ranged so FindPart finds the same part.
+func TestGoProcessor_ListParts(t *testing.T) {
+	tests := []struct {
+		partType langutil.PartType
+		want     []string
+	}{
+		{golang.PackageGoPart, []string{"demo"}},
+		{golang.ImportGoPart, []string{"fmt", "os"}},
+		{golang.ConstGoPart, []string{"A", "B"}},
+		{golang.VarGoPart, []string{"x", "y"}},
+		{golang.TypeGoPart, []string{"Config", "Store"}},
+		{golang.FieldGoPart, []string{"Config.Port", "Config.Server", "Config.Server.Host", "Config.Stringer"}},
+		{golang.MethodGoPart, []string{"Store.Get"}},
+		{golang.FuncGoPart, []string{"main", "*Config.Start", "Config.String"}},
+		{golang.FuncBodyGoPart, []string{"main", "*Config.Start", "Config.String"}},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(string(tt.partType), func(t *testing.T) {
+			parts, err := g.ListParts(listPartsContent, tt.partType)
+			if err != nil {
+				t.Fatalf("ListParts() unexpected error: %v", err)
+			}
+			if len(parts) != len(tt.want) {
+				t.Fatalf("ListParts() returned %d parts, want %d: %+v", len(parts), len(tt.want), parts)
+			}
+			for i, part := range parts {
+				if part.Name != tt.want[i] {
+					t.Errorf("part %d name = %q, want %q", i, part.Name, tt.want[i])
+				}
+				found, err := g.FindPart(langutil.PartArgs{
+					Language: langutil.GoLanguage,
+					Content:  listPartsContent,
+					PartType: tt.partType,
+					PartName: part.Name,
+				})
+				if err != nil {
+					t.Fatalf("FindPart(%q) unexpected error: %v", part.Name, err)
+				}
+				if found.StartOffset != part.StartOffset || found.EndOffset != part.EndOffset || found.StartLine != part.StartLine {
+					t.Errorf("part %q range = %d-%d, FindPart range = %d-%d", part.Name, part.StartOffset, part.EndOffset, found.StartOffset, found.EndOffset)
+				}
+			}
+		})
+	}
+
+	_, err := g.ListParts(listPartsContent, "label")
+	if err == nil {
+		t.Error("ListParts() should reject an unsupported part type")
+	}
+}
+
+// TestGoProcessor_FindPart_ByLine verifies that FindPart given a line instead
+// of a name finds the innermost construct of the part type enclosing it.
+func TestGoProcessor_FindPart_ByLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		partType langutil.PartType
+		line     int
+		want     string
+		wantLine int
+	}{
+		{"FuncBody", golang.FuncGoPart, 29, "*Config.Start", 29},
+		{"TypeBody", golang.TypeGoPart, 18, "Config", 15},
+		{"NestedField", golang.FieldGoPart, 18, "Config.Server.Host", 18},
+		{"OuterField", golang.FieldGoPart, 17, "Config.Server", 17},
+		{"InterfaceMethod", golang.MethodGoPart, 24, "Store.Get", 24},
+		{"NothingThere", golang.FuncGoPart, 9, "", 0},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pi, err := g.FindPart(langutil.PartArgs{
+				Language: langutil.GoLanguage,
+				Content:  listPartsContent,
+				PartType: tt.partType,
+				Line:     tt.line,
+			})
+			if err != nil {
+				t.Fatalf("FindPart() unexpected error: %v", err)
+			}
+			if pi.Found != (tt.want != "") || pi.Name != tt.want || pi.StartLine != tt.wantLine {
+				t.Errorf("FindPart() = %q found=%v at line %d, want %q at line %d", pi.Name, pi.Found, pi.StartLine, tt.want, tt.wantLine)
+			}
+		})
+	}
+}
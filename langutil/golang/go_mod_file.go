@@ -0,0 +1,291 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// GoModFileKind identifies which kind of module file was parsed.
+type GoModFileKind string
+
+const (
+	GoModKind  GoModFileKind = "go.mod"  // Module definition file
+	GoWorkKind GoModFileKind = "go.work" // Workspace file
+)
+
+// GoModRequire is a single requirement from a require directive.
+type GoModRequire struct {
+	Path     string `json:"path"`     // Required module path
+	Version  string `json:"version"`  // Required module version
+	Indirect bool   `json:"indirect"` // Marked with an "// indirect" comment
+	Line     int    `json:"line"`     // Line of the requirement
+}
+
+// GoModReplace is a single replacement from a replace directive.
+type GoModReplace struct {
+	Old        string `json:"old"`                   // Module path being replaced
+	OldVersion string `json:"old_version,omitempty"` // Version being replaced, empty for all versions
+	New        string `json:"new"`                   // Replacement module path or directory
+	NewVersion string `json:"new_version,omitempty"` // Replacement version, empty for directories
+	Line       int    `json:"line"`                  // Line of the replacement
+}
+
+// GoModExclude is a single exclusion from an exclude directive.
+type GoModExclude struct {
+	Path    string `json:"path"`    // Excluded module path
+	Version string `json:"version"` // Excluded module version
+	Line    int    `json:"line"`    // Line of the exclusion
+}
+
+// GoModUse is a single module directory from a go.work use directive.
+type GoModUse struct {
+	Path string `json:"path"` // Module directory
+	Line int    `json:"line"` // Line of the use directive
+}
+
+// GoModProblem is a syntax error or issue found in a module file.
+type GoModProblem struct {
+	Line    int    `json:"line,omitempty"` // Line of the problem, omitted for problems with the whole file
+	Message string `json:"message"`
+}
+
+// GoModFile is the parsed content of a go.mod or go.work file.
+type GoModFile struct {
+	Kind         GoModFileKind  `json:"kind"`
+	Module       string         `json:"module,omitempty"`
+	GoVersion    string         `json:"go_version,omitempty"`
+	Toolchain    string         `json:"toolchain,omitempty"`
+	Requires     []GoModRequire `json:"requires"`
+	Replaces     []GoModReplace `json:"replaces"`
+	Excludes     []GoModExclude `json:"excludes"`
+	Uses         []GoModUse     `json:"uses,omitempty"`
+	SyntaxErrors []GoModProblem `json:"syntax_errors"`
+	Issues       []GoModProblem `json:"issues"`
+	syntax       *modfile.FileSyntax
+}
+
+// GoModFileKindOf returns the kind of module file named by filename, or
+// an empty kind if it is neither go.mod nor go.work.
+func GoModFileKindOf(filename string) (kind GoModFileKind) {
+	switch filepath.Base(filename) {
+	case string(GoModKind):
+		kind = GoModKind
+	case string(GoWorkKind):
+		kind = GoWorkKind
+	}
+	return kind
+}
+
+// ParseGoModFile parses go.mod or go.work content with golang.org/x/mod,
+// choosing the grammar by filename. Every error the go command would report
+// is collected on the result as a syntax error with its line number, and
+// issues the go command accepts, such as duplicate requires, are collected
+// separately. When the file has syntax errors only its module path is
+// reported.
+func ParseGoModFile(filename string, content []byte) (mf *GoModFile) {
+	var f *modfile.File
+	var wf *modfile.WorkFile
+	var err error
+
+	mf = &GoModFile{
+		Kind:         GoModFileKindOf(filename),
+		Requires:     make([]GoModRequire, 0),
+		Replaces:     make([]GoModReplace, 0),
+		Excludes:     make([]GoModExclude, 0),
+		SyntaxErrors: make([]GoModProblem, 0),
+		Issues:       make([]GoModProblem, 0),
+	}
+	if mf.Kind == "" {
+		mf.Kind = GoModKind
+	}
+
+	if mf.Kind == GoWorkKind {
+		mf.Uses = make([]GoModUse, 0)
+		wf, err = modfile.ParseWork(filename, content, nil)
+		if err != nil {
+			mf.syntaxErrors(err)
+			goto end
+		}
+		mf.setWorkFile(wf)
+		goto end
+	}
+
+	f, err = modfile.Parse(filename, content, nil)
+	if err != nil {
+		mf.Module = modfile.ModulePath(content)
+		mf.syntaxErrors(err)
+		goto end
+	}
+	mf.setFile(f)
+
+end:
+	if len(mf.SyntaxErrors) == 0 {
+		mf.checkDuplicates()
+	}
+	return mf
+}
+
+// Format returns the file laid out the way go mod edit -fmt writes it, using
+// golang.org/x/mod's printer. The result is empty when the file has syntax
+// errors.
+func (mf *GoModFile) Format() (formatted string) {
+	if mf.syntax == nil {
+		goto end
+	}
+	formatted = string(modfile.Format(mf.syntax))
+
+end:
+	return formatted
+}
+
+// setFile copies the directives of a parsed go.mod file onto mf.
+func (mf *GoModFile) setFile(f *modfile.File) {
+	mf.syntax = f.Syntax
+	if f.Module != nil {
+		mf.Module = f.Module.Mod.Path
+	}
+	if f.Go != nil {
+		mf.GoVersion = f.Go.Version
+	}
+	if f.Toolchain != nil {
+		mf.Toolchain = f.Toolchain.Name
+	}
+	for _, r := range f.Require {
+		mf.Requires = append(mf.Requires, GoModRequire{
+			Path:     r.Mod.Path,
+			Version:  r.Mod.Version,
+			Indirect: r.Indirect,
+			Line:     r.Syntax.Start.Line,
+		})
+	}
+	for _, e := range f.Exclude {
+		mf.Excludes = append(mf.Excludes, GoModExclude{
+			Path:    e.Mod.Path,
+			Version: e.Mod.Version,
+			Line:    e.Syntax.Start.Line,
+		})
+	}
+	mf.setReplaces(f.Replace)
+	if mf.Module == "" {
+		mf.issue(0, "missing module directive")
+	}
+}
+
+// setWorkFile copies the directives of a parsed go.work file onto mf.
+func (mf *GoModFile) setWorkFile(wf *modfile.WorkFile) {
+	mf.syntax = wf.Syntax
+	if wf.Go != nil {
+		mf.GoVersion = wf.Go.Version
+	}
+	if wf.Toolchain != nil {
+		mf.Toolchain = wf.Toolchain.Name
+	}
+	for _, u := range wf.Use {
+		mf.Uses = append(mf.Uses, GoModUse{
+			Path: u.Path,
+			Line: u.Syntax.Start.Line,
+		})
+	}
+	mf.setReplaces(wf.Replace)
+}
+
+// setReplaces copies replace directives onto mf.
+func (mf *GoModFile) setReplaces(replaces []*modfile.Replace) {
+	for _, r := range replaces {
+		mf.Replaces = append(mf.Replaces, GoModReplace{
+			Old:        r.Old.Path,
+			OldVersion: r.Old.Version,
+			New:        r.New.Path,
+			NewVersion: r.New.Version,
+			Line:       r.Syntax.Start.Line,
+		})
+	}
+}
+
+// syntaxErrors records the errors golang.org/x/mod reported for the file.
+func (mf *GoModFile) syntaxErrors(err error) {
+	var errList modfile.ErrorList
+	var msg string
+
+	if !errors.As(err, &errList) {
+		mf.syntaxError(0, err.Error())
+		goto end
+	}
+
+	for _, e := range errList {
+		msg = e.Err.Error()
+		switch {
+		case e.ModPath != "":
+			msg = fmt.Sprintf("%s %s: %s", e.Verb, e.ModPath, msg)
+		case e.Verb != "":
+			msg = fmt.Sprintf("%s: %s", e.Verb, msg)
+		}
+		mf.syntaxError(e.Pos.Line, msg)
+	}
+
+end:
+	return
+}
+
+// checkDuplicates reports requires, replaces and uses that repeat an earlier
+// entry, and modules that are both required and excluded at the same version.
+func (mf *GoModFile) checkDuplicates() {
+	var seen map[string]int
+	var key string
+
+	seen = make(map[string]int, len(mf.Requires))
+	for _, r := range mf.Requires {
+		first, dup := seen[r.Path]
+		if dup {
+			mf.issue(r.Line, fmt.Sprintf("duplicate require of %s (first required at line %d)", r.Path, first))
+			continue
+		}
+		seen[r.Path] = r.Line
+	}
+
+	seen = make(map[string]int, len(mf.Replaces))
+	for _, r := range mf.Replaces {
+		key = r.Old
+		if r.OldVersion != "" {
+			key += "@" + r.OldVersion
+		}
+		first, dup := seen[key]
+		if dup {
+			mf.issue(r.Line, fmt.Sprintf("duplicate replace of %s (first replaced at line %d)", key, first))
+			continue
+		}
+		seen[key] = r.Line
+	}
+
+	seen = make(map[string]int, len(mf.Uses))
+	for _, u := range mf.Uses {
+		key = filepath.Clean(u.Path)
+		first, dup := seen[key]
+		if dup {
+			mf.issue(u.Line, fmt.Sprintf("duplicate use of %s (first used at line %d)", u.Path, first))
+			continue
+		}
+		seen[key] = u.Line
+	}
+
+	for _, e := range mf.Excludes {
+		for _, r := range mf.Requires {
+			if r.Path == e.Path && r.Version == e.Version {
+				mf.issue(e.Line, fmt.Sprintf("%s %s is excluded but also required at line %d", e.Path, e.Version, r.Line))
+			}
+		}
+	}
+}
+
+// syntaxError records a syntax error on line.
+func (mf *GoModFile) syntaxError(line int, msg string) {
+	mf.SyntaxErrors = append(mf.SyntaxErrors, GoModProblem{Line: line, Message: msg})
+}
+
+// issue records a problem on line that does not prevent the file from parsing.
+func (mf *GoModFile) issue(line int, msg string) {
+	mf.Issues = append(mf.Issues, GoModProblem{Line: line, Message: msg})
+}
@@ -0,0 +1,263 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// GoNamingRule identifies a naming convention that an identifier violates.
+type GoNamingRule string
+
+const (
+	UnderscoreNamingRule GoNamingRule = "underscore" // Name contains an underscore instead of using MixedCaps
+	AllCapsNamingRule    GoNamingRule = "all_caps"   // Name is written entirely in capitals, like a C constant
+	AcronymNamingRule    GoNamingRule = "acronym"    // Name contains an acronym that is not consistently cased
+)
+
+// testFuncPrefixes begin the names of test functions, which may contain
+// underscores by convention, such as TestParse_EmptyInput.
+var testFuncPrefixes = []string{"Test", "Benchmark", "Example", "Fuzz"}
+
+// GoNamingIssue describes an identifier that violates Go naming conventions.
+type GoNamingIssue struct {
+	Line    int          `json:"line"`    // Line of the declared identifier
+	Name    string       `json:"name"`    // Declared identifier
+	Rule    GoNamingRule `json:"rule"`    // Convention violated
+	Message string       `json:"message"` // Description of the problem, with a suggested name where one is known
+}
+
+// DefaultAcronyms returns the initialisms that Go naming conventions expect
+// to be cased consistently, such as HTTP or ID rather than Http or Id.
+func DefaultAcronyms() []string {
+	return []string{
+		"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML",
+		"HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS",
+		"RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP",
+		"UI", "UID", "URI", "URL", "UTF8", "UUID", "VM", "XML", "XMPP",
+		"XSRF", "XSS",
+	}
+}
+
+// CheckNaming reports the identifiers declared in the Go source that violate
+// Go naming conventions: names containing underscores, names written
+// entirely in capitals, and names containing one of acronyms cased
+// inconsistently, such as Http or userId. Only declarations are checked, not
+// uses, and generated files yield no issues. In _test.go files, test,
+// benchmark, example and fuzz functions may contain underscores.
+func CheckNaming(filename string, source []byte, acronyms []string) (issues []GoNamingIssue, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var index map[string]string
+	var isTestFile bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	issues = make([]GoNamingIssue, 0)
+	if ast.IsGenerated(file) {
+		goto end
+	}
+
+	index = make(map[string]string, len(acronyms))
+	for _, a := range acronyms {
+		index[strings.ToLower(a)] = strings.ToUpper(a)
+	}
+	isTestFile = strings.HasSuffix(filename, "_test.go")
+
+	for _, ident := range declaredIdents(file) {
+		issues = append(issues, checkIdentName(fset, ident, index, isTestFile)...)
+	}
+
+end:
+	return issues, err
+}
+
+// declaredIdents returns the identifiers declared in file, in source order:
+// functions, methods, types, constants, variables, struct fields, interface
+// methods, parameters, results and short variable declarations.
+func declaredIdents(file *ast.File) (idents []*ast.Ident) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncDecl:
+			idents = append(idents, x.Name)
+		case *ast.TypeSpec:
+			idents = append(idents, x.Name)
+		case *ast.ValueSpec:
+			idents = append(idents, x.Names...)
+		case *ast.Field:
+			idents = append(idents, x.Names...)
+		case *ast.AssignStmt:
+			if x.Tok == token.DEFINE {
+				idents = append(idents, identExprs(x.Lhs)...)
+			}
+		case *ast.RangeStmt:
+			if x.Tok == token.DEFINE {
+				idents = append(idents, identExprs([]ast.Expr{x.Key, x.Value})...)
+			}
+		}
+		return true
+	})
+	return idents
+}
+
+// identExprs returns the identifiers among exprs, skipping nil expressions.
+func identExprs(exprs []ast.Expr) (idents []*ast.Ident) {
+	var ident *ast.Ident
+	var ok bool
+
+	for _, expr := range exprs {
+		ident, ok = expr.(*ast.Ident)
+		if ok {
+			idents = append(idents, ident)
+		}
+	}
+	return idents
+}
+
+// checkIdentName returns the naming issues for a declared identifier, at
+// most one per rule. The blank identifier has none.
+func checkIdentName(fset *token.FileSet, ident *ast.Ident, acronyms map[string]string, isTestFile bool) (issues []GoNamingIssue) {
+	var name string
+	var line int
+	var suggestion string
+
+	name = ident.Name
+	if name == "_" {
+		goto end
+	}
+	line = fset.Position(ident.Pos()).Line
+
+	if strings.Contains(strings.Trim(name, "_"), "_") && !(isTestFile && hasTestFuncPrefix(name)) {
+		issues = append(issues, GoNamingIssue{
+			Line:    line,
+			Name:    name,
+			Rule:    UnderscoreNamingRule,
+			Message: fmt.Sprintf("'%s' contains an underscore; use MixedCaps", name),
+		})
+	}
+
+	if isAllCaps(name) && acronyms[strings.ToLower(name)] == "" {
+		issues = append(issues, GoNamingIssue{
+			Line:    line,
+			Name:    name,
+			Rule:    AllCapsNamingRule,
+			Message: fmt.Sprintf("'%s' is written in all capitals; use MixedCaps", name),
+		})
+	}
+
+	suggestion = fixAcronyms(name, acronyms)
+	if suggestion != name {
+		issues = append(issues, GoNamingIssue{
+			Line:    line,
+			Name:    name,
+			Rule:    AcronymNamingRule,
+			Message: fmt.Sprintf("'%s' should be '%s'", name, suggestion),
+		})
+	}
+
+end:
+	return issues
+}
+
+// hasTestFuncPrefix reports whether name begins like a test, benchmark,
+// example or fuzz function.
+func hasTestFuncPrefix(name string) bool {
+	for _, prefix := range testFuncPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllCaps reports whether name has more than one letter and no lowercase
+// letters, ignoring underscores and digits.
+func isAllCaps(name string) (allCaps bool) {
+	var letters int
+
+	for _, r := range name {
+		if unicode.IsLower(r) {
+			goto end
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	allCaps = letters > 1
+
+end:
+	return allCaps
+}
+
+// fixAcronyms returns name with each word that is one of acronyms but not
+// cased as one rewritten in capitals, or in lower case when it is the first
+// word of a name that starts in lower case, as in httpClient.
+func fixAcronyms(name string, acronyms map[string]string) string {
+	var words []string
+	var acronym string
+	var lowerFirst bool
+
+	words = splitNameWords(name)
+	lowerFirst = len(words) > 0 && unicode.IsLower([]rune(words[0])[0])
+	for i, word := range words {
+		acronym = acronyms[strings.ToLower(word)]
+		if acronym == "" {
+			continue
+		}
+		switch {
+		case i == 0 && lowerFirst:
+			words[i] = strings.ToLower(word)
+		default:
+			words[i] = acronym
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// splitNameWords splits a MixedCaps name into words at each change from a
+// lower case letter or digit to upper case, and before the last capital of
+// a run of capitals followed by a lower case letter, so "parseHTTPRequest"
+// yields "parse", "HTTP" and "Request". Underscores are kept as their own
+// words.
+func splitNameWords(name string) (words []string) {
+	var runes []rune
+	var start int
+	var prev, next rune
+	var boundary bool
+
+	runes = []rune(name)
+	for i, r := range runes {
+		if i == 0 {
+			continue
+		}
+		prev = runes[i-1]
+		next = 0
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		boundary = false
+		switch {
+		case r == '_' || prev == '_':
+			boundary = true
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			boundary = true
+		case unicode.IsUpper(r) && unicode.IsUpper(prev) && unicode.IsLower(next):
+			boundary = true
+		}
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if len(runes) > 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,121 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+// preserveDoc adjusts a replacement of the func, type, const or var partName
+// so that the construct's doc comment survives it, returning the offset the
+// replacement starts at and the text to replace with.
+//
+// A doc comment above a function or an ungrouped declaration lies outside
+// the part's range, so it is kept as is unless newContent brings a doc
+// comment of its own, in which case the range is extended to replace the old
+// one rather than leave both. A doc comment on a spec within a grouped
+// declaration lies inside the range, which spans the whole group, so it is
+// prepended to newContent unless newContent starts with a comment already.
+func (g *GoProcessor) preserveDoc(args langutil.PartArgs, partName string, start int, newContent string) (newStart int, text string, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var doc *ast.CommentGroup
+	var inside bool
+	var hasDoc bool
+
+	newStart, text = start, newContent
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, "", args.Content, parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("failed to parse Go file: %w", err)
+		goto end
+	}
+
+	doc, inside = partDoc(file, args.PartType, partName)
+	if doc == nil {
+		goto end
+	}
+
+	hasDoc = strings.HasPrefix(strings.TrimSpace(newContent), "//") || strings.HasPrefix(strings.TrimSpace(newContent), "/*")
+	switch {
+	case inside && !hasDoc:
+		text = commentGroupSource(doc) + "\n" + newContent
+	case !inside && hasDoc:
+		newStart = fset.Position(doc.Pos()).Offset
+	}
+
+end:
+	return newStart, text, err
+}
+
+// partDoc returns the doc comment of the func, type, const or var partName
+// in file, if it has one, and whether it lies inside the range FindPart
+// returns for the part, as the doc comment of a spec within a grouped
+// declaration does.
+func partDoc(file *ast.File, partType langutil.PartType, partName string) (doc *ast.CommentGroup, inside bool) {
+	var tok token.Token
+
+	switch partType {
+	case FuncGoPart:
+		if funcDecl := FindFunc(file, partName); funcDecl != nil {
+			return funcDecl.Doc, false
+		}
+		return nil, false
+	case TypeGoPart:
+		tok = token.TYPE
+	case ConstGoPart:
+		tok = token.CONST
+	case VarGoPart:
+		tok = token.VAR
+	default:
+		return nil, false
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != tok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			doc, ok = specDoc(spec, partName)
+			if !ok {
+				continue
+			}
+			if !genDecl.Lparen.IsValid() {
+				return genDecl.Doc, false
+			}
+			return doc, true
+		}
+	}
+	return nil, false
+}
+
+// specDoc returns the doc comment of spec and whether spec declares name.
+func specDoc(spec ast.Spec, name string) (doc *ast.CommentGroup, declares bool) {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return s.Doc, s.Name.Name == name
+	case *ast.ValueSpec:
+		for _, ident := range s.Names {
+			if ident.Name == name {
+				return s.Doc, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// commentGroupSource returns the comments of doc as source lines, without
+// the indentation they had within a grouped declaration.
+func commentGroupSource(doc *ast.CommentGroup) string {
+	lines := make([]string, len(doc.List))
+	for i, c := range doc.List {
+		lines[i] = c.Text
+	}
+	return strings.Join(lines, "\n")
+}
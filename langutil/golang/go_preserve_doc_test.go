@@ -0,0 +1,94 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+const preserveDocContent = `package demo
+
+// Greet says hello.
+func Greet() string { return "hi" }
+
+// Config holds settings.
+type Config struct{}
+
+const (
+	// Port is the default port.
+	Port = 80
+
+	Host = "localhost"
+)
+`
+
+// TestGoProcessor_ReplacePart_PreserveDoc verifies that PreserveDoc keeps
+// the doc comment of functions, ungrouped declarations and specs within a
+// grouped declaration, and that a replacement bringing its own doc comment
+// replaces the old one instead of duplicating it.
+func TestGoProcessor_ReplacePart_PreserveDoc(t *testing.T) {
+	tests := []struct {
+		name       string
+		partType   langutil.PartType
+		partName   string
+		newContent string
+		replaced   string // Text of preserveDocContent the replacement changes
+		want       string // What it becomes
+	}{
+		{
+			name:       "FuncKeepsDoc",
+			partType:   golang.FuncGoPart,
+			partName:   "Greet",
+			newContent: `func Greet() string { return "hello" }`,
+			replaced:   "// Greet says hello.\nfunc Greet() string { return \"hi\" }\n",
+			want:       "// Greet says hello.\nfunc Greet() string { return \"hello\" }\n",
+		},
+		{
+			name:       "FuncReplacesDoc",
+			partType:   golang.FuncGoPart,
+			partName:   "Greet",
+			newContent: "// Greet says hello politely.\nfunc Greet() string { return \"hello\" }",
+			replaced:   "// Greet says hello.\nfunc Greet() string { return \"hi\" }\n",
+			want:       "// Greet says hello politely.\nfunc Greet() string { return \"hello\" }\n",
+		},
+		{
+			name:       "TypeKeepsDoc",
+			partType:   golang.TypeGoPart,
+			partName:   "Config",
+			newContent: "type Config struct{ Debug bool }",
+			replaced:   "// Config holds settings.\ntype Config struct{}\n",
+			want:       "// Config holds settings.\ntype Config struct{ Debug bool }\n",
+		},
+		{
+			name:       "GroupedSpecKeepsDoc",
+			partType:   golang.ConstGoPart,
+			partName:   "Port",
+			newContent: "const Port = 8080",
+			replaced:   "const (\n\t// Port is the default port.\n\tPort = 80\n\n\tHost = \"localhost\"\n)\n",
+			want:       "// Port is the default port.\nconst Port = 8080\n",
+		},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.ReplacePart(langutil.PartArgs{
+				Language:    langutil.GoLanguage,
+				Content:     preserveDocContent,
+				PartType:    tt.partType,
+				PartName:    tt.partName,
+				NewContent:  tt.newContent,
+				PreserveDoc: true,
+			})
+			if err != nil {
+				t.Fatalf("ReplacePart() unexpected error: %v", err)
+			}
+			want := strings.Replace(preserveDocContent, tt.replaced, tt.want, 1)
+			if got != want {
+				t.Errorf("ReplacePart() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
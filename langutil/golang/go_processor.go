@@ -3,6 +3,7 @@ package golang
 import (
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
 	"strings"
@@ -19,7 +20,10 @@ import (
 //
 // The constants cover the major Go language constructs that developers commonly need to find or replace:
 //   - Function and method declarations
+//   - Function and method bodies alone
 //   - Type definitions (structs, interfaces, aliases)
+//   - Individual fields of struct types
+//   - Individual method signatures of interface types
 //   - Constant declarations (both individual and grouped)
 //   - Variable declarations (both individual and grouped)
 //   - Import statements (both individual and grouped)
@@ -49,11 +53,27 @@ const (
 	//   - "MyStruct.Method" (method on value receiver)
 	//   - "*MyStruct.Method" (method on pointer receiver)
 	//   - "String" (could match multiple types with String methods)
+	//   - "Map" or "Map[T, U]" (generic function)
+	//   - "*Stack[T].Push" or "*Stack.Push" (method on a generic receiver)
+	//
+	// Type parameters are ignored when matching, so generic functions and
+	// methods on generic receivers are found by their base names as well as
+	// with type parameters named as the declaration or ListParts names them.
 	//
 	// The AST search examines both the receiver type and method name to provide
 	// precise matching for method lookups.
 	FuncGoPart langutil.PartType = "func"
 
+	// FuncBodyGoPart represents just the body of a function or method, named
+	// as for FuncGoPart, such as "oldFunction" or "*MyStruct.Method".
+	//
+	// The part spans the text between the body's braces, not the braces
+	// themselves, so a replacement is only the function's statements and the
+	// doc comment, receiver and signature are kept exactly as they were. A
+	// replacement is put on lines of its own between the braces and must
+	// parse as the statements of a single function body.
+	FuncBodyGoPart langutil.PartType = "func_body"
+
 	// TypeGoPart represents Go type declarations including structs, interfaces, and type aliases.
 	// This part type can find any kind of type definition in Go source code, including:
 	//   - Struct definitions with fields and methods
@@ -66,6 +86,31 @@ const (
 	// as the part name.
 	TypeGoPart langutil.PartType = "type"
 
+	// FieldGoPart represents a single field of a package-level struct type.
+	// The part name is the struct's name and the field's name joined by a dot,
+	// such as "Config.Port", extended for fields of anonymous nested structs,
+	// such as "Config.Server.Host". Embedded fields are named by their type
+	// name, such as "Config.Mutex".
+	//
+	// The part spans only the field's names, type and tag, not its doc or line
+	// comment, so a replacement changes just that field while the surrounding
+	// fields, tags and comments are preserved. A replacement must be exactly
+	// one field declaration, such as "Port int `json:\"port\"`".
+	FieldGoPart langutil.PartType = "field"
+
+	// MethodGoPart represents a single method signature of a package-level
+	// interface type. The part name is the interface's name and the method's
+	// joined by a dot, such as "UserService.GetUser". Method declarations with
+	// bodies are found with FuncGoPart instead.
+	//
+	// The part spans only the method's name and signature, not its doc or line
+	// comment, so a replacement changes just that signature while the other
+	// methods and all comments are preserved. A replacement must be exactly
+	// one method signature, such as "GetUser(id string) (*User, error)".
+	// Unlike other part types, a missing interface or method is reported as
+	// an error explaining which is missing.
+	MethodGoPart langutil.PartType = "method"
+
 	// ConstGoPart represents Go constant declarations.
 	// This part type can find both individual constant declarations and constants within
 	// grouped constant blocks. The search targets the constant name and will match both:
@@ -115,6 +160,7 @@ const (
 // if GoProcessor doesn't implement all required methods, providing early detection of
 // interface compatibility issues.
 var _ langutil.Processor = (*GoProcessor)(nil)
+var _ langutil.Formatter = (*GoProcessor)(nil)
 
 // GoProcessor implements the langutil.Processor interface for Go language processing.
 // This processor provides comprehensive AST-based operations for Go source code including
@@ -126,10 +172,29 @@ var _ langutil.Processor = (*GoProcessor)(nil)
 // GoProcessor supports all major Go language constructs:
 //   - Functions and methods with receiver type handling
 //   - Type definitions (structs, interfaces, aliases)
+//   - Individual struct fields
+//   - Individual interface method signatures
 //   - Constant and variable declarations (individual and grouped)
 //   - Import statements with path and alias support
 //   - Package declarations
 //
+// # Listing Parts
+//
+// Where FindPart needs a construct's name, ListParts enumerates every construct
+// of a part type in a file, named as FindPart expects, for callers that let a
+// user pick one.
+//
+// # Renaming
+//
+// RenameSymbol renames a package-level declaration and the references to it
+// within one file, found through the AST rather than by matching text.
+//
+// # Import Editing
+//
+// Beyond replacing import parts, AddImport inserts a single import into the
+// file's grouped import block at its sorted position and RemoveImport deletes
+// one, dropping any declaration it leaves empty, touching nothing else.
+//
 // # AST Integration
 //
 // The processor leverages Go's standard AST libraries:
@@ -186,7 +251,10 @@ func (g *GoProcessor) Language() langutil.Language {
 //
 // The method returns all Go part type constants defined in this package:
 //   - FuncGoPart: Functions and methods
+//   - FuncBodyGoPart: Function and method bodies
 //   - TypeGoPart: Type definitions
+//   - FieldGoPart: Struct fields
+//   - MethodGoPart: Interface method signatures
 //   - ConstGoPart: Constant declarations
 //   - VarGoPart: Variable declarations
 //   - ImportGoPart: Import statements
@@ -205,7 +273,10 @@ func (g *GoProcessor) Language() langutil.Language {
 func (g *GoProcessor) SupportedPartTypes() []langutil.PartType {
 	return []langutil.PartType{
 		FuncGoPart,
+		FuncBodyGoPart,
 		TypeGoPart,
+		FieldGoPart,
+		MethodGoPart,
 		ConstGoPart,
 		VarGoPart,
 		ImportGoPart,
@@ -230,11 +301,22 @@ func (g *GoProcessor) SupportedPartTypes() []langutil.PartType {
 //
 // Different construct types use different matching strategies:
 //   - Functions: Matches function names and handles method receiver types
+//   - Function bodies: Matches as for functions, but spans only the body
 //   - Types: Matches type names in type declarations
+//   - Fields: Matches "Struct.Field" paths within struct type declarations
+//   - Methods: Matches "Interface.Method" signatures within interface declarations
 //   - Constants/Variables: Matches identifier names in declarations
 //   - Imports: Matches import paths with flexible quote handling
 //   - Packages: Matches package names in package declarations
 //
+// # Finding by Line
+//
+// When args.PartName is empty and args.Line is set, the construct is found by
+// position instead of name: the innermost construct of args.PartType whose
+// range includes the line, such as the function whose body the line is in.
+// The returned PartInfo's Name is set to the name the construct would be
+// found by, so callers can report or reuse it.
+//
 // # Position Information
 //
 // The method provides both line-based and byte-based position information:
@@ -247,6 +329,7 @@ func (g *GoProcessor) SupportedPartTypes() []langutil.PartType {
 // Returns errors for:
 //   - Syntax errors in the source content
 //   - Unsupported part types
+//   - A method part whose interface or method does not exist
 //   - AST parsing failures
 //   - Internal processing errors
 //
@@ -269,6 +352,11 @@ func (g *GoProcessor) FindPart(args langutil.PartArgs) (pi *langutil.PartInfo, e
 		goto end
 	}
 
+	if args.PartName == "" && args.Line > 0 {
+		pi, err = findGoPartAtLine(fs, file, args.Content, args.PartType, args.Line)
+		goto end
+	}
+
 	// Find the part
 	start, end, found, err = g.findGoPart(file, args)
 	if err != nil {
@@ -306,7 +394,9 @@ end:
 //  2. Validates that the construct was found
 //  3. Performs text replacement at the exact AST boundaries
 //  4. Validates that the resulting source code is syntactically correct
-//  5. Returns the complete modified source code
+//  5. Fixes the imports of the result when args.OrganizeImports is set
+//  6. Formats the result with gofmt unless args.SkipFormat is set
+//  7. Returns the complete modified source code
 //
 // # Content Validation
 //
@@ -324,12 +414,44 @@ end:
 // syntactic correctness. This prevents the method from returning invalid Go code
 // that would cause compilation errors.
 //
+// # Formatting
+//
+// Replacement content rarely matches the indentation and alignment of the
+// file around it, so the result is formatted with go/format, as gofmt would,
+// and is ready to commit. Set args.SkipFormat to get the result exactly as
+// spliced instead. As the result's syntax is validated first, invalid
+// replacements report the syntax error rather than a formatting failure.
+//
+// # Preserving Doc Comments
+//
+// Replacement content often omits the construct's doc comment. With
+// args.PreserveDoc set, a function, type, constant or variable keeps its doc
+// comment when the new content starts without one, including the doc comment
+// of a spec inside a grouped declaration, which the replaced range covers.
+// New content that starts with a comment replaces the old doc comment
+// instead of being added alongside it.
+//
+// # Organizing Imports
+//
+// A replacement may refer to packages the file does not import, or stop
+// using ones it does. With args.OrganizeImports set, imports used before the
+// replacement but unused after it are removed, and standard library packages
+// the result refers to but does not import are added, each at its sorted
+// position in the import block. Resolving imports is best-effort: package
+// names are inferred from import paths, only well-known standard library
+// packages can be added, and nothing is looked up on disk or over the
+// network, so third-party imports must still be added explicitly.
+//
 // # Error Conditions
 //
 // Returns errors for:
 //   - Construct not found in the source
 //   - Invalid replacement content for the construct type
 //   - Replacement results in syntactically invalid Go code
+//   - Replacement results in unsound type parameters, such as a duplicated
+//     type parameter, or a method receiver or instantiation whose number of
+//     type parameters no longer matches its generic type (see
+//     ValidateTypeParams)
 //   - AST parsing or processing failures
 //
 // # Example Usage
@@ -345,6 +467,8 @@ end:
 //	newSource, err := processor.ReplacePart(args)
 func (g *GoProcessor) ReplacePart(args langutil.PartArgs) (result string, err error) {
 	var partInfo *langutil.PartInfo
+	var newContent, partName string
+	var start int
 
 	// Find the part first
 	partInfo, err = g.FindPart(args)
@@ -352,13 +476,33 @@ func (g *GoProcessor) ReplacePart(args langutil.PartArgs) (result string, err er
 		goto end
 	}
 
+	if !partInfo.Found && args.PartName == "" && args.Line > 0 {
+		err = fmt.Errorf("no %s found at line %d", args.PartType, args.Line)
+		goto end
+	}
+
 	if !partInfo.Found {
 		err = fmt.Errorf("%s '%s' not found in file", args.PartType, args.PartName)
 		goto end
 	}
 
 	// Replace the content
-	result = args.Content[:partInfo.StartOffset] + args.NewContent + args.Content[partInfo.EndOffset:]
+	newContent = args.NewContent
+	start = partInfo.StartOffset
+	if args.PartType == FuncBodyGoPart {
+		newContent = FuncBodyReplacement(newContent)
+	}
+	if args.PreserveDoc {
+		partName = args.PartName
+		if partName == "" {
+			partName = partInfo.Name
+		}
+		start, newContent, err = g.preserveDoc(args, partName, start, newContent)
+		if err != nil {
+			goto end
+		}
+	}
+	result = args.Content[:start] + newContent + args.Content[partInfo.EndOffset:]
 
 	// Validate the result
 	err = g.ValidateSyntax(result)
@@ -367,6 +511,23 @@ func (g *GoProcessor) ReplacePart(args langutil.PartArgs) (result string, err er
 		goto end
 	}
 
+	err = ValidateTypeParams(result)
+	if err != nil {
+		err = fmt.Errorf("replacement resulted in invalid type parameters: %w", err)
+		goto end
+	}
+
+	if args.OrganizeImports {
+		result, err = g.fixImports(args.Content, result)
+		if err != nil {
+			goto end
+		}
+	}
+
+	if !args.SkipFormat {
+		result, err = gofmtSource(result)
+	}
+
 end:
 	return result, err
 }
@@ -384,10 +545,22 @@ end:
 //   - Content must start with "func "
 //   - Ensures basic function declaration syntax
 //
+// Function bodies (FuncBodyGoPart):
+//   - Content must parse as the statements of a function body
+//   - Content that would close the body and add declarations is rejected
+//
 // Types (TypeGoPart):
 //   - Content must start with "type "
 //   - Ensures basic type declaration syntax
 //
+// Fields (FieldGoPart):
+//   - Content must be exactly one struct field declaration
+//   - Parsed as a field, so invalid types or tags are rejected
+//
+// Methods (MethodGoPart):
+//   - Content must be exactly one interface method signature
+//   - Embedded interfaces and type constraints are rejected
+//
 // Constants (ConstGoPart):
 //   - Content must contain "=" or start with "const"
 //   - Handles both individual and grouped constant syntax
@@ -426,6 +599,12 @@ func (g *GoProcessor) ValidateContent(args langutil.PartArgs) (err error) {
 		if !strings.HasPrefix(content, "type ") {
 			err = fmt.Errorf("type replacement must start with 'type ', got: %s", content[:min(20, len(content))])
 		}
+	case FuncBodyGoPart:
+		err = ValidateFuncBody(content)
+	case FieldGoPart:
+		err = ValidateStructField(content)
+	case MethodGoPart:
+		err = ValidateInterfaceMethod(content)
 	case ConstGoPart:
 		if !strings.Contains(content, "=") && !strings.HasPrefix(content, "const") {
 			err = fmt.Errorf("const replacement must contain '=' or start with 'const', got: %s", content[:min(20, len(content))])
@@ -494,6 +673,23 @@ func (g *GoProcessor) ValidateSyntax(source string) (err error) {
 	return err
 }
 
+// Format returns Go source formatted as gofmt would, using go/format.
+// This method implements the langutil.Formatter interface and fails without
+// changing anything when source is not syntactically valid Go.
+func (g *GoProcessor) Format(source string) (formatted string, err error) {
+	var out []byte
+
+	out, err = format.Source([]byte(source))
+	if err != nil {
+		err = fmt.Errorf("failed to format Go source: %w", err)
+		goto end
+	}
+	formatted = string(out)
+
+end:
+	return formatted, err
+}
+
 // findGoPart locates a specific Go language construct within an AST.
 // This method serves as the central dispatch function for construct-specific search operations.
 // It examines the part type and delegates to specialized search functions that understand
@@ -507,7 +703,10 @@ func (g *GoProcessor) ValidateSyntax(source string) (err error) {
 //   - ConstGoPart: Searches constant declarations
 //   - VarGoPart: Searches variable declarations
 //   - TypeGoPart: Searches type definitions
+//   - FieldGoPart: Searches fields of struct type definitions
+//   - MethodGoPart: Searches method signatures of interface type definitions
 //   - FuncGoPart: Searches function and method declarations
+//   - FuncBodyGoPart: Searches the bodies of function and method declarations
 //
 // # Position Information
 //
@@ -517,7 +716,8 @@ func (g *GoProcessor) ValidateSyntax(source string) (err error) {
 //
 // # Error Handling
 //
-// Returns an error for unsupported part types. Individual search functions may
+// Returns an error for unsupported part types, and for method parts whose
+// interface or method does not exist. Individual search functions may
 // return additional errors for malformed AST structures or other processing issues.
 //
 // # Search Accuracy
@@ -542,8 +742,14 @@ func (g *GoProcessor) findGoPart(file *ast.File, args langutil.PartArgs) (startP
 		startPos, endPos, found = g.findGoVar(file, partName)
 	case TypeGoPart:
 		startPos, endPos, found = g.findGoType(file, partName)
+	case FieldGoPart:
+		startPos, endPos, found = g.findGoField(file, partName)
+	case MethodGoPart:
+		startPos, endPos, found, err = g.findGoInterfaceMethod(file, partName)
 	case FuncGoPart:
 		startPos, endPos, found = g.findGoFunc(file, partName)
+	case FuncBodyGoPart:
+		startPos, endPos, found, err = g.findGoFuncBody(file, partName)
 	default:
 		err = fmt.Errorf("unsupported part type: %s", args.PartType)
 	}
@@ -705,7 +911,8 @@ func (g *GoProcessor) findGoVar(file *ast.File, varName string) (startPos, endPo
 // The search process:
 //   - Examines all general declarations (ast.GenDecl) with token.TYPE
 //   - Iterates through all type specifications within each declaration
-//   - Compares type names for exact matches
+//   - Compares type names for exact matches, ignoring type parameters, so
+//     a generic type such as Stack[T any] is found as "Stack" or "Stack[T]"
 //   - Returns position of the entire type declaration when found
 //
 // # Position Scope
@@ -720,6 +927,7 @@ func (g *GoProcessor) findGoVar(file *ast.File, varName string) (startPos, endPo
 // defined within function scopes, as such type definitions are rare and require
 // different handling strategies.
 func (g *GoProcessor) findGoType(file *ast.File, typeName string) (startPos, endPos token.Pos, found bool) {
+	typeName = StripTypeParams(typeName)
 	for _, decl := range file.Decls {
 		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
 			for _, spec := range genDecl.Specs {
@@ -737,6 +945,56 @@ func (g *GoProcessor) findGoType(file *ast.File, typeName string) (startPos, end
 	return
 }
 
+// findGoField locates a single struct field by "Struct.Field" path.
+// This method delegates to FindStructField, which walks the named struct's
+// field list, and nested anonymous struct types for longer paths, to find the
+// ast.Field declaring the last name in the path.
+//
+// # Position Scope
+//
+// Returns the position of the field itself, from its first name (or embedded
+// type) through its tag. The field's doc comment and trailing line comment lie
+// outside this range, so replacing a field preserves its comments as well as
+// the neighboring fields.
+func (g *GoProcessor) findGoField(file *ast.File, fieldPath string) (startPos, endPos token.Pos, found bool) {
+	field := FindStructField(file, fieldPath)
+	if field != nil {
+		startPos = field.Pos()
+		endPos = field.End()
+		found = true
+	}
+	return startPos, endPos, found
+}
+
+// findGoInterfaceMethod locates a single interface method signature by
+// "Interface.Method" name. This method delegates to FindInterfaceMethod, which
+// finds the ast.Field declaring the method in the interface's method list.
+//
+// # Position Scope
+//
+// Returns the position of the method's name through the end of its signature,
+// excluding its doc and line comments, so replacing it preserves the comments
+// and the interface's other methods.
+//
+// # Error Handling
+//
+// Unlike the other search functions, a missing interface or method is an
+// error rather than a not-found result, so callers can report which is missing.
+func (g *GoProcessor) findGoInterfaceMethod(file *ast.File, methodPath string) (startPos, endPos token.Pos, found bool, err error) {
+	var field *ast.Field
+
+	field, err = FindInterfaceMethod(file, methodPath)
+	if err != nil {
+		goto end
+	}
+	startPos = field.Pos()
+	endPos = field.End()
+	found = true
+
+end:
+	return startPos, endPos, found, err
+}
+
 // findGoFunc locates function and method declarations by name with receiver type support.
 // This method provides comprehensive search capabilities for both standalone functions
 // and methods attached to types. It handles Go's method system by supporting receiver
@@ -756,6 +1014,10 @@ func (g *GoProcessor) findGoType(file *ast.File, typeName string) (startPos, end
 //   - Value receivers: "MyStruct.Method"
 //   - Pointer receivers: "*MyStruct.Method"
 //   - Interface methods: "MyInterface.Method"
+//   - Generic receivers: "*Stack[T].Method" or "*Stack.Method"
+//
+// Type parameters in funcName and in the declarations are ignored when
+// comparing, as FindFunc does.
 //
 // # Search Strategy
 //
@@ -779,36 +1041,71 @@ func (g *GoProcessor) findGoType(file *ast.File, typeName string) (startPos, end
 // associated documentation comments, receiver declarations, parameters, return
 // types, and function body.
 func (g *GoProcessor) findGoFunc(file *ast.File, funcName string) (startPos, endPos token.Pos, found bool) {
-	for _, decl := range file.Decls {
-		if funcDecl, ok := decl.(*ast.FuncDecl); ok {
-			var name string
-
-			// Handle regular functions
-			if funcDecl.Recv == nil {
-				name = funcDecl.Name.Name
-			} else {
-				// Handle methods - format as ReceiverType.MethodName
-				if len(funcDecl.Recv.List) > 0 {
-					var recvType string
-					switch recv := funcDecl.Recv.List[0].Type.(type) {
-					case *ast.StarExpr:
-						if ident, ok := recv.X.(*ast.Ident); ok {
-							recvType = "*" + ident.Name
-						}
-					case *ast.Ident:
-						recvType = recv.Name
-					}
-					name = recvType + "." + funcDecl.Name.Name
-				}
-			}
-
-			if name == funcName {
-				startPos = funcDecl.Pos()
-				endPos = funcDecl.End()
-				found = true
-				return
-			}
-		}
+	funcDecl := FindFunc(file, funcName)
+	if funcDecl != nil {
+		startPos = funcDecl.Pos()
+		endPos = funcDecl.End()
+		found = true
 	}
 	return
 }
+
+// findGoFuncBody locates the body of a function or method declaration by
+// name. This method delegates to FindFuncBody, which matches names exactly as
+// findGoFunc does.
+//
+// # Position Scope
+//
+// Returns the position just past the body's opening brace through its closing
+// brace, so the range holds only the statements and replacing it preserves
+// the function's doc comment, receiver and signature.
+//
+// # Error Handling
+//
+// A function declared without a body is an error rather than a not-found
+// result, as there is no range to return for it.
+func (g *GoProcessor) findGoFuncBody(file *ast.File, funcName string) (startPos, endPos token.Pos, found bool, err error) {
+	var body *ast.BlockStmt
+
+	body, err = FindFuncBody(file, funcName)
+	if err != nil || body == nil {
+		goto end
+	}
+	startPos = body.Lbrace + 1
+	endPos = body.Rbrace
+	found = true
+
+end:
+	return startPos, endPos, found, err
+}
+
+// funcPartName returns the name FuncGoPart knows a function declaration by:
+// its name for a function, or "ReceiverType.MethodName" for a method, with
+// a leading "*" for a pointer receiver.
+func funcPartName(funcDecl *ast.FuncDecl) (name string) {
+	// Handle regular functions
+	if funcDecl.Recv == nil {
+		return funcDecl.Name.Name
+	}
+
+	// Handle methods - format as ReceiverType.MethodName
+	if len(funcDecl.Recv.List) > 0 {
+		name = recvPartName(funcDecl.Recv.List[0].Type) + "." + funcDecl.Name.Name
+	}
+	return name
+}
+
+// gofmtSource formats Go source as gofmt would.
+func gofmtSource(source string) (formatted string, err error) {
+	var out []byte
+
+	out, err = format.Source([]byte(source))
+	if err != nil {
+		err = fmt.Errorf("failed to format replacement result: %w", err)
+		goto end
+	}
+	formatted = string(out)
+
+end:
+	return formatted, err
+}
@@ -0,0 +1,52 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+// TestGoProcessor_ReplacePart_Format verifies that ReplacePart formats its
+// result with gofmt unless SkipFormat is set, and that an invalid replacement
+// reports the syntax error rather than a formatting failure.
+func TestGoProcessor_ReplacePart_Format(t *testing.T) {
+	const content = "package demo\n\nfunc Greet() string {\n\treturn \"hi\"\n}\n"
+	const newContent = "func Greet() string {\n  name := \"world\"\n    return   \"hi \" + name\n}"
+
+	g := &golang.GoProcessor{}
+	args := langutil.PartArgs{
+		Language:   langutil.GoLanguage,
+		Content:    content,
+		PartType:   golang.FuncGoPart,
+		PartName:   "Greet",
+		NewContent: newContent,
+	}
+
+	got, err := g.ReplacePart(args)
+	if err != nil {
+		t.Fatalf("ReplacePart() unexpected error: %v", err)
+	}
+	want := "package demo\n\nfunc Greet() string {\n\tname := \"world\"\n\treturn \"hi \" + name\n}\n"
+	if got != want {
+		t.Errorf("ReplacePart() =\n%s\nwant:\n%s", got, want)
+	}
+
+	args.SkipFormat = true
+	got, err = g.ReplacePart(args)
+	if err != nil {
+		t.Fatalf("ReplacePart() with SkipFormat unexpected error: %v", err)
+	}
+	want = "package demo\n\n" + newContent + "\n"
+	if got != want {
+		t.Errorf("ReplacePart() with SkipFormat =\n%s\nwant:\n%s", got, want)
+	}
+
+	args.SkipFormat = false
+	args.NewContent = "func Greet() string {\n\treturn \"hi\"\n"
+	_, err = g.ReplacePart(args)
+	if err == nil || !strings.Contains(err.Error(), "invalid Go syntax") {
+		t.Errorf("ReplacePart() error = %v, want the syntax error", err)
+	}
+}
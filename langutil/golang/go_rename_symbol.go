@@ -0,0 +1,171 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+)
+
+// RenameSymbol returns content with the package-level function, type,
+// constant or variable oldName renamed to newName, along with every
+// identifier in the file that refers to it, formatted with gofmt.
+//
+// References are found through the parser's resolution of identifiers rather
+// than by matching text, so string literals, comments, struct fields,
+// methods and local variables that merely share the name are left alone.
+// Keys in struct composite literals are never renamed, as without type
+// information they cannot be told apart from field names, so a constant or
+// variable used as a map or array key is only renamed there when the literal
+// spells out its map, slice or array type.
+//
+// Only this file is updated. Other files of the package, and other packages
+// using an exported name, must be updated separately.
+//
+// Returns an error if:
+//   - content cannot be parsed as Go source
+//   - oldName is not a package-level function, type, constant or variable
+//   - newName is not a valid identifier
+//   - newName is already declared at package level or imported in the file
+//   - newName is declared locally anywhere in the file, where it could
+//     capture references to the renamed symbol
+func (g *GoProcessor) RenameSymbol(content, oldName, newName string) (result string, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var obj *ast.Object
+	var edits []sourceEdit
+	var out []byte
+
+	if !token.IsIdentifier(newName) || newName == "_" {
+		err = fmt.Errorf("'%s' is not a valid identifier", newName)
+		goto end
+	}
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("failed to parse Go file: %w", err)
+		goto end
+	}
+
+	obj = file.Scope.Lookup(oldName)
+	if obj == nil || obj.Kind == ast.Bad || obj.Kind == ast.Pkg || obj.Kind == ast.Lbl {
+		err = fmt.Errorf("'%s' is not a package-level function, type, constant or variable", oldName)
+		goto end
+	}
+
+	err = checkRenameCollision(file, newName)
+	if err != nil {
+		goto end
+	}
+
+	for _, ident := range renameTargets(file, obj) {
+		edits = append(edits, sourceEdit{
+			start: fset.Position(ident.Pos()).Offset,
+			end:   fset.Position(ident.End()).Offset,
+			text:  newName,
+		})
+	}
+
+	out, err = format.Source(applySourceEdits([]byte(content), edits))
+	if err != nil {
+		err = fmt.Errorf("renaming '%s' produced invalid Go: %w", oldName, err)
+		goto end
+	}
+	result = string(out)
+
+end:
+	return result, err
+}
+
+// checkRenameCollision returns an error if newName is already declared at
+// package level, imported, or declared locally anywhere in file.
+func checkRenameCollision(file *ast.File, newName string) (err error) {
+	var local bool
+	var members []ast.Node
+
+	if file.Scope.Lookup(newName) != nil {
+		err = fmt.Errorf("'%s' is already declared in the file", newName)
+		goto end
+	}
+
+	for _, is := range file.Imports {
+		if importName(is) == newName {
+			err = fmt.Errorf("'%s' is already the name of an imported package", newName)
+			goto end
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.StructType, *ast.InterfaceType:
+			// Field and method names live in their type, not in a scope
+			// where they could shadow the symbol
+			members = append(members, x)
+		case *ast.Ident:
+			local = local || x.Name == newName && x.Obj != nil && x.Obj.Kind != ast.Lbl && !isMember(members, x)
+		}
+		return !local
+	})
+	if local {
+		err = fmt.Errorf("'%s' is declared locally in the file and could capture references", newName)
+	}
+
+end:
+	return err
+}
+
+// renameTargets returns the identifiers in file that declare or refer to
+// obj, skipping the keys of composite literals whose type may be a struct.
+func renameTargets(file *ast.File, obj *ast.Object) (idents []*ast.Ident) {
+	var fieldKeys map[*ast.Ident]bool
+
+	fieldKeys = make(map[*ast.Ident]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.CompositeLit:
+			switch x.Type.(type) {
+			case *ast.MapType, *ast.ArrayType:
+				return true
+			}
+			for _, elt := range x.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fieldKeys[key] = true
+				}
+			}
+		case *ast.Ident:
+			if x.Obj == obj && !fieldKeys[x] {
+				idents = append(idents, x)
+			}
+		}
+		return true
+	})
+	return idents
+}
+
+// isMember reports whether ident names a field or method of one of the
+// struct or interface types in members.
+func isMember(members []ast.Node, ident *ast.Ident) bool {
+	var fields *ast.FieldList
+
+	for _, m := range members {
+		switch t := m.(type) {
+		case *ast.StructType:
+			fields = t.Fields
+		case *ast.InterfaceType:
+			fields = t.Methods
+		}
+		for _, field := range fields.List {
+			if slices.Contains(field.Names, ident) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+const renameSymbolContent = `package demo
+
+import "fmt"
+
+const limit = 10
+
+type Config struct {
+	limit int
+	Name  string
+}
+
+func (c *Config) Limit() int { return c.limit }
+
+func newConfig() *Config {
+	return &Config{limit: limit, Name: "limit"}
+}
+
+func use() {
+	fmt.Println(limit, newConfig().Limit())
+	values := map[int]string{limit: "max"}
+	_ = values
+	func() {
+		limit := 2
+		_ = limit
+	}()
+}
+`
+
+// TestGoProcessor_RenameSymbol verifies that RenameSymbol renames only the
+// declaration and references resolved to the symbol, and refuses names that
+// would collide with or be captured by existing declarations.
+func TestGoProcessor_RenameSymbol(t *testing.T) {
+	g := &golang.GoProcessor{}
+
+	got, err := g.RenameSymbol(renameSymbolContent, "limit", "maxItems")
+	if err != nil {
+		t.Fatalf("RenameSymbol() unexpected error: %v", err)
+	}
+	want := strings.NewReplacer(
+		"const limit = 10", "const maxItems = 10",
+		"Config{limit: limit,", "Config{limit: maxItems,",
+		"fmt.Println(limit,", "fmt.Println(maxItems,",
+		"map[int]string{limit:", "map[int]string{maxItems:",
+	).Replace(renameSymbolContent)
+	if got != want {
+		t.Errorf("RenameSymbol() =\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err = g.RenameSymbol(renameSymbolContent, "Config", "Settings")
+	if err != nil {
+		t.Fatalf("RenameSymbol() unexpected error: %v", err)
+	}
+	want = strings.NewReplacer(
+		"type Config struct", "type Settings struct",
+		"(c *Config)", "(c *Settings)",
+		"*Config {", "*Settings {",
+		"&Config{", "&Settings{",
+	).Replace(renameSymbolContent)
+	if got != want {
+		t.Errorf("RenameSymbol() =\n%s\nwant:\n%s", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		oldName string
+		newName string
+		wantErr string
+	}{
+		{"TopLevelCollision", "limit", "use", "already declared"},
+		{"ImportCollision", "limit", "fmt", "imported package"},
+		{"LocalCapture", "newConfig", "values", "declared locally"},
+		{"NotTopLevel", "values", "items", "not a package-level"},
+		{"InvalidName", "limit", "max-items", "not a valid identifier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.RenameSymbol(renameSymbolContent, tt.oldName, tt.newName)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RenameSymbol() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err = g.RenameSymbol(renameSymbolContent, "use", "Name")
+	if err != nil {
+		t.Errorf("RenameSymbol() should allow a name only used by a struct field: %v", err)
+	}
+}
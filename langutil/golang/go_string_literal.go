@@ -0,0 +1,75 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// GoStringLiteral describes a single string literal in a Go source file.
+type GoStringLiteral struct {
+	Line  int    `json:"line"`  // Line where the literal starts
+	Value string `json:"value"` // Unquoted value of the literal
+	Raw   bool   `json:"raw"`   // True for `raw` strings, false for "interpreted" strings
+}
+
+// StringLiteralOpts controls which string literals ParseStringLiterals skips.
+type StringLiteralOpts struct {
+	SkipStructTags bool // Skip struct field tags such as `json:"name"`
+	SkipImports    bool // Skip import paths
+}
+
+// ParseStringLiterals returns the string literals in the Go source in source
+// order, unquoted. Rune literals and comments are never included; struct
+// tags and import paths are included unless opts says to skip them.
+func ParseStringLiterals(filename string, source []byte, opts StringLiteralOpts) (literals []GoStringLiteral, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var skip map[*ast.BasicLit]bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	// ast.Inspect visits parents before children, so import specs and fields
+	// mark their literals as skipped before those literals are reached
+	skip = make(map[*ast.BasicLit]bool)
+	literals = make([]GoStringLiteral, 0)
+	ast.Inspect(file, func(n ast.Node) bool {
+		var value string
+
+		if err != nil {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.ImportSpec:
+			skip[x.Path] = opts.SkipImports
+		case *ast.Field:
+			if x.Tag != nil {
+				skip[x.Tag] = opts.SkipStructTags
+			}
+		case *ast.BasicLit:
+			if x.Kind != token.STRING || skip[x] {
+				break
+			}
+			value, err = strconv.Unquote(x.Value)
+			if err != nil {
+				err = fmt.Errorf("invalid string literal at line %d: %w", fset.Position(x.Pos()).Line, err)
+				break
+			}
+			literals = append(literals, GoStringLiteral{
+				Line:  fset.Position(x.Pos()).Line,
+				Value: value,
+				Raw:   x.Value[0] == '`',
+			})
+		}
+		return true
+	})
+
+end:
+	return literals, err
+}
@@ -0,0 +1,137 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// StripComments removes the comments from the Go source, returning the
+// gofmt-formatted result along with the number of comments removed and kept.
+// Comments are located by the parser, so text inside string literals that
+// looks like a comment is never touched. Lines left empty by a removed
+// comment are dropped. When keepDirectives is set, comments that change how
+// the file builds are kept: //go: directives such as //go:build and
+// //go:embed, // +build constraints, and the cgo preamble preceding import "C".
+func StripComments(filename string, source []byte, keepDirectives bool) (result []byte, removed, kept int, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var preamble map[*ast.CommentGroup]bool
+	var edits []sourceEdit
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.ParseComments)
+	if err != nil {
+		goto end
+	}
+
+	if keepDirectives {
+		preamble = cgoPreamble(file)
+	}
+
+	for _, group := range file.Comments {
+		if preamble[group] {
+			kept += len(group.List)
+			continue
+		}
+		for _, c := range group.List {
+			if keepDirectives && isBuildDirective(c.Text) {
+				kept++
+				continue
+			}
+			edits = append(edits, sourceEdit{
+				start: fset.Position(c.Pos()).Offset,
+				end:   fset.Position(c.End()).Offset,
+			})
+		}
+	}
+	removed = len(edits)
+
+	result, err = format.Source(removeComments(source, edits))
+	if err != nil {
+		err = fmt.Errorf("stripping comments from %s produced invalid Go: %w", filename, err)
+	}
+
+end:
+	return result, removed, kept, err
+}
+
+// isBuildDirective reports whether the comment text is a //go: directive or
+// a // +build constraint.
+func isBuildDirective(text string) bool {
+	return strings.HasPrefix(text, "//go:") || strings.HasPrefix(text, "// +build")
+}
+
+// cgoPreamble returns the comment groups documenting import "C", which cgo
+// compiles as C code.
+func cgoPreamble(file *ast.File) (groups map[*ast.CommentGroup]bool) {
+	var gd *ast.GenDecl
+	var spec *ast.ImportSpec
+	var path string
+	var ok bool
+
+	groups = make(map[*ast.CommentGroup]bool)
+	for _, decl := range file.Decls {
+		gd, ok = decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		for _, s := range gd.Specs {
+			spec = s.(*ast.ImportSpec)
+			path, _ = strconv.Unquote(spec.Path.Value)
+			if path != "C" {
+				continue
+			}
+			if spec.Doc != nil {
+				groups[spec.Doc] = true
+			}
+			if gd.Doc != nil && len(gd.Specs) == 1 {
+				groups[gd.Doc] = true
+			}
+		}
+	}
+	return groups
+}
+
+// removeComments returns source without the comments at the sorted edits.
+// A comment spanning lines is replaced by a newline, as the compiler treats
+// it, and any line that held a removed comment and is left blank is dropped.
+func removeComments(source []byte, edits []sourceEdit) (result []byte) {
+	var line []byte
+	var touched bool
+	var next int
+
+	emit := func() {
+		if !touched || len(bytes.TrimSpace(line)) > 0 {
+			result = append(result, line...)
+		}
+		line = nil
+		touched = false
+	}
+
+	for i := 0; i < len(source); {
+		if next < len(edits) && i == edits[next].start {
+			touched = true
+			if bytes.ContainsRune(source[i:edits[next].end], '\n') {
+				line = append(line, '\n')
+				emit()
+				touched = true
+			}
+			i = edits[next].end
+			next++
+			continue
+		}
+		line = append(line, source[i])
+		if source[i] == '\n' {
+			emit()
+		}
+		i++
+	}
+	emit()
+	return result
+}
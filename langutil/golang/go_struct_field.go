@@ -0,0 +1,122 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// FindStructField returns the field of a package-level struct type in file
+// named by fieldPath, such as "Config.Port", or nil if there is none. Fields
+// of anonymous struct types nested within a struct are reached by extending
+// the path, as in "Config.Server.Host". Embedded fields are named by their
+// type without its package or pointer, as Go names them, so "Config.Mutex"
+// finds an embedded sync.Mutex. A field declaring several names, such as
+// "X, Y int", is found by any of them.
+//
+// The field's own range, from field.Pos() to field.End(), covers its names,
+// type and tag but not its doc or line comment, so replacing just that range
+// leaves the surrounding fields and comments untouched.
+func FindStructField(file *ast.File, fieldPath string) (field *ast.Field) {
+	var names []string
+	var spec *ast.TypeSpec
+	var typ ast.Expr
+
+	names = strings.Split(fieldPath, ".")
+	if len(names) < 2 {
+		goto end
+	}
+
+	spec, _ = packageTypeSpec([]*ast.File{file}, names[0])
+	if spec == nil {
+		goto end
+	}
+
+	typ = spec.Type
+	for _, name := range names[1:] {
+		field = lookupField(structFields(typ), name)
+		if field == nil {
+			break
+		}
+		typ = field.Type
+	}
+
+end:
+	return field
+}
+
+// ValidateStructField returns an error unless content is exactly one struct
+// field declaration, such as "Port int `json:\"port\"`", optionally with
+// comments.
+func ValidateStructField(content string) (err error) {
+	var file *ast.File
+	var spec *ast.TypeSpec
+	var fields *ast.FieldList
+
+	file, err = parser.ParseFile(token.NewFileSet(), "", "package p\ntype _ struct {\n"+content+"\n}\n", parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("field replacement is not a valid struct field: %w", err)
+		goto end
+	}
+
+	spec, _ = packageTypeSpec([]*ast.File{file}, "_")
+	fields = structFields(spec.Type)
+	if len(file.Decls) != 1 || len(fields.List) != 1 {
+		err = errors.New("field replacement must declare exactly one struct field")
+	}
+
+end:
+	return err
+}
+
+// structFields returns the fields of expr if it is a struct type, or of the
+// struct it points to, and otherwise nil.
+func structFields(expr ast.Expr) *ast.FieldList {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if st, ok := expr.(*ast.StructType); ok {
+		return st.Fields
+	}
+	return nil
+}
+
+// lookupField returns the field in fields declaring name, or embedding a
+// type of that name, or nil if there is none.
+func lookupField(fields *ast.FieldList, name string) *ast.Field {
+	if fields == nil {
+		return nil
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 && embeddedFieldName(field.Type) == name {
+			return field
+		}
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedFieldName returns the name Go gives a field embedding expr: the
+// type's name without any pointer, package qualifier or type arguments.
+func embeddedFieldName(expr ast.Expr) (name string) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		name = e.Name
+	case *ast.StarExpr:
+		name = embeddedFieldName(e.X)
+	case *ast.SelectorExpr:
+		name = e.Sel.Name
+	case *ast.IndexExpr:
+		name = embeddedFieldName(e.X)
+	case *ast.IndexListExpr:
+		name = embeddedFieldName(e.X)
+	}
+	return name
+}
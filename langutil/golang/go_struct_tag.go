@@ -0,0 +1,218 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// GoStructTagIssue describes a struct field whose tag is malformed or
+// repeats a key, either of which reflect.StructTag silently ignores.
+type GoStructTagIssue struct {
+	Line    int    `json:"line"`          // Line of the field's tag
+	Struct  string `json:"struct"`        // Name of the declared struct type, empty for anonymous structs
+	Field   string `json:"field"`         // Field name, or the type name for embedded fields
+	Tag     string `json:"tag"`           // Tag contents without the enclosing quotes
+	Key     string `json:"key,omitempty"` // Offending key, when the issue concerns one key
+	Message string `json:"message"`       // Description of the problem
+}
+
+// ParseStructTagIssues returns the malformed and duplicate-key struct tags
+// in the Go source in source order. Tags are parsed with the same rules as
+// reflect.StructTag: space-separated key:"value" pairs where keys contain no
+// spaces, quotes, colons or control characters and values are Go string literals.
+func ParseStructTagIssues(filename string, source []byte) (issues []GoStructTagIssue, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var structNames map[*ast.StructType]string
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	// ast.Inspect visits a type spec before its struct type, so declared
+	// structs are named before their fields are checked
+	structNames = make(map[*ast.StructType]string)
+	issues = make([]GoStructTagIssue, 0)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.TypeSpec:
+			if st, ok := x.Type.(*ast.StructType); ok {
+				structNames[st] = x.Name.Name
+			}
+		case *ast.StructType:
+			for _, field := range x.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				issues = append(issues, checkFieldTag(fset, structNames[x], field)...)
+			}
+		}
+		return true
+	})
+
+end:
+	return issues, err
+}
+
+// checkFieldTag returns the issues found in the tag of field.
+func checkFieldTag(fset *token.FileSet, structName string, field *ast.Field) (issues []GoStructTagIssue) {
+	var tag string
+	var err error
+	var problems []structTagProblem
+	var fieldName string
+
+	tag, err = strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		problems = []structTagProblem{{Message: "struct tag is not a valid string literal"}}
+	} else {
+		problems = validateStructTag(tag)
+	}
+	if len(problems) == 0 {
+		goto end
+	}
+
+	fieldName = fieldDisplayName(field)
+	issues = make([]GoStructTagIssue, len(problems))
+	for i, p := range problems {
+		issues[i] = GoStructTagIssue{
+			Line:    fset.Position(field.Tag.Pos()).Line,
+			Struct:  structName,
+			Field:   fieldName,
+			Tag:     tag,
+			Key:     p.Key,
+			Message: p.Message,
+		}
+	}
+
+end:
+	return issues
+}
+
+// fieldDisplayName returns the names of field joined by commas, or the
+// name of its type if it is embedded.
+func fieldDisplayName(field *ast.Field) (name string) {
+	var names []string
+
+	if len(field.Names) == 0 {
+		name = strings.TrimPrefix(exprString(field.Type), "*")
+		goto end
+	}
+	names = make([]string, len(field.Names))
+	for i, ident := range field.Names {
+		names[i] = ident.Name
+	}
+	name = strings.Join(names, ", ")
+
+end:
+	return name
+}
+
+// exprString renders the type expressions that can appear as embedded fields.
+func exprString(expr ast.Expr) (s string) {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		s = x.Name
+	case *ast.StarExpr:
+		s = "*" + exprString(x.X)
+	case *ast.SelectorExpr:
+		s = exprString(x.X) + "." + x.Sel.Name
+	case *ast.IndexExpr:
+		s = exprString(x.X)
+	case *ast.IndexListExpr:
+		s = exprString(x.X)
+	default:
+		s = fmt.Sprintf("%T", expr)
+	}
+	return s
+}
+
+// structTagProblem is a single problem found by validateStructTag.
+type structTagProblem struct {
+	Key     string
+	Message string
+}
+
+// validateStructTag parses tag following reflect.StructTag's conventions and
+// returns its problems: syntax errors, which stop parsing as they do for
+// reflect.StructTag.Lookup, and keys that appear more than once, whose later
+// values Lookup never returns.
+func validateStructTag(tag string) (problems []structTagProblem) {
+	var seen map[string]bool
+	var i int
+	var key string
+	var value string
+	var err error
+
+	seen = make(map[string]bool)
+	for tag != "" {
+		// Skip leading space
+		i = 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// Scan to colon. A space, a quote or a control character is a syntax error
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 {
+			problems = append(problems, structTagProblem{Message: "bad syntax for struct tag key"})
+			goto end
+		}
+		key = tag[:i]
+		if i+1 >= len(tag) || tag[i] != ':' {
+			problems = append(problems, structTagProblem{Key: key, Message: fmt.Sprintf("bad syntax for struct tag pair %q: expected key:\"value\"", key)})
+			goto end
+		}
+		if tag[i+1] != '"' {
+			problems = append(problems, structTagProblem{Key: key, Message: fmt.Sprintf("bad syntax for struct tag value of %q: value must be double-quoted", key)})
+			goto end
+		}
+		tag = tag[i+1:]
+
+		// Scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			problems = append(problems, structTagProblem{Key: key, Message: fmt.Sprintf("bad syntax for struct tag value of %q: missing closing quote", key)})
+			goto end
+		}
+		value = tag[:i+1]
+		tag = tag[i+1:]
+
+		_, err = strconv.Unquote(value)
+		if err != nil {
+			problems = append(problems, structTagProblem{Key: key, Message: fmt.Sprintf("bad syntax for struct tag value of %q: %v", key, err)})
+			goto end
+		}
+
+		if seen[key] {
+			problems = append(problems, structTagProblem{Key: key, Message: fmt.Sprintf("duplicate struct tag key %q; only the first value is used", key)})
+		}
+		seen[key] = true
+
+		if tag != "" && tag[0] != ' ' {
+			problems = append(problems, structTagProblem{Key: key, Message: "struct tag key:\"value\" pairs not separated by spaces"})
+			goto end
+		}
+	}
+
+end:
+	return problems
+}
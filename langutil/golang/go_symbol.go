@@ -0,0 +1,150 @@
+package golang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+// GoSymbol describes a top-level declaration in a Go source file.
+type GoSymbol struct {
+	Name     string            `json:"name"`               // Declared identifier
+	Kind     langutil.PartType `json:"kind"`               // One of FuncGoPart, TypeGoPart, ConstGoPart or VarGoPart
+	Receiver string            `json:"receiver,omitempty"` // Receiver type name for methods
+	Line     int               `json:"line"`               // Line of the declared identifier
+}
+
+// Matches reports whether name refers to the symbol, either by its bare
+// identifier or, for methods, qualified by receiver as Type.Method.
+func (s GoSymbol) Matches(name string) bool {
+	return s.Name == name || s.QualifiedName() == name
+}
+
+// QualifiedName returns the symbol's name, qualified by receiver as
+// Type.Method for methods, which is unique among a package's declarations.
+func (s GoSymbol) QualifiedName() string {
+	if s.Receiver == "" {
+		return s.Name
+	}
+	return s.Receiver + "." + s.Name
+}
+
+// ParseSymbols returns the top-level functions, methods, types, constants and
+// variables declared in the Go source, in source order. Blank identifiers are
+// omitted.
+func ParseSymbols(filename string, source []byte) (symbols []GoSymbol, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	symbols = make([]GoSymbol, 0)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			symbols = append(symbols, funcSymbol(fset, d))
+		case *ast.GenDecl:
+			symbols = append(symbols, genDeclSymbols(fset, d)...)
+		}
+	}
+
+end:
+	return symbols, err
+}
+
+// funcSymbol returns the symbol for a function or method declaration.
+func funcSymbol(fset *token.FileSet, fd *ast.FuncDecl) (symbol GoSymbol) {
+	symbol = GoSymbol{
+		Name: fd.Name.Name,
+		Kind: FuncGoPart,
+		Line: fset.Position(fd.Name.Pos()).Line,
+	}
+	if fd.Recv != nil && len(fd.Recv.List) > 0 {
+		symbol.Receiver = StripTypeParams(recvPartName(derefExpr(fd.Recv.List[0].Type)))
+	}
+	return symbol
+}
+
+// genDeclSymbols returns the symbols for the specs of a type, const or var
+// declaration. Import declarations declare no symbols and yield none.
+func genDeclSymbols(fset *token.FileSet, gd *ast.GenDecl) (symbols []GoSymbol) {
+	var kind langutil.PartType
+
+	switch gd.Tok {
+	case token.TYPE:
+		kind = TypeGoPart
+	case token.CONST:
+		kind = ConstGoPart
+	case token.VAR:
+		kind = VarGoPart
+	default:
+		goto end
+	}
+
+	for _, spec := range gd.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			symbols = append(symbols, GoSymbol{
+				Name: s.Name.Name,
+				Kind: kind,
+				Line: fset.Position(s.Name.Pos()).Line,
+			})
+		case *ast.ValueSpec:
+			for _, ident := range s.Names {
+				if ident.Name == "_" {
+					continue
+				}
+				symbols = append(symbols, GoSymbol{
+					Name: ident.Name,
+					Kind: kind,
+					Line: fset.Position(ident.Pos()).Line,
+				})
+			}
+		}
+	}
+
+end:
+	return symbols
+}
+
+// HasExampleFunc reports whether exampleNames includes an example for the
+// symbol following the go doc naming convention: ExampleF for a function or
+// type F, and ExampleT_M for a method M of type T, each optionally followed
+// by an underscore and a suffix beginning with a lowercase letter. Constants
+// and variables cannot have examples.
+func (s GoSymbol) HasExampleFunc(exampleNames []string) (has bool) {
+	var want string
+	var suffix string
+	var ok bool
+
+	switch {
+	case s.Kind == FuncGoPart && s.Receiver != "":
+		want = "Example" + s.Receiver + "_" + s.Name
+	case s.Kind == FuncGoPart, s.Kind == TypeGoPart:
+		want = "Example" + s.Name
+	default:
+		goto end
+	}
+
+	for _, name := range exampleNames {
+		suffix, ok = strings.CutPrefix(name, want)
+		if !ok {
+			continue
+		}
+		if suffix == "" || (len(suffix) > 1 && suffix[0] == '_' && unicode.IsLower(rune(suffix[1]))) {
+			has = true
+			goto end
+		}
+	}
+
+end:
+	return has
+}
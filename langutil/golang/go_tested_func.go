@@ -0,0 +1,132 @@
+package golang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// GoExportedFunc describes an exported function, or an exported method of an
+// exported type, declared in a Go source file.
+type GoExportedFunc struct {
+	Name     string `json:"func"`               // Function name, qualified by receiver type for methods
+	Receiver string `json:"receiver,omitempty"` // Receiver type name without pointer or type parameters
+	Line     int    `json:"line"`               // Line of the func keyword
+	funcName string // Unqualified function or method name
+}
+
+// ParseExportedFuncs returns the exported functions, and the exported methods
+// of exported types, declared in the Go source in source order.
+func ParseExportedFuncs(filename string, source []byte) (funcs []GoExportedFunc, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var fd *ast.FuncDecl
+	var ok bool
+	var recv string
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	funcs = make([]GoExportedFunc, 0)
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if !ok || !fd.Name.IsExported() {
+			continue
+		}
+		recv = ""
+		if fd.Recv != nil && len(fd.Recv.List) > 0 {
+			recv = StripTypeParams(recvPartName(derefExpr(fd.Recv.List[0].Type)))
+			if !ast.IsExported(recv) {
+				continue
+			}
+		}
+		funcs = append(funcs, GoExportedFunc{
+			Name:     funcPartName(fd),
+			Receiver: recv,
+			Line:     fset.Position(fd.Pos()).Line,
+			funcName: fd.Name.Name,
+		})
+	}
+
+end:
+	return funcs, err
+}
+
+// ParseTestFuncNames returns the names of the top-level Test functions
+// declared in the Go test source.
+func ParseTestFuncNames(filename string, source []byte) (names []string, err error) {
+	return parseFuncNamesWithPrefix(filename, source, "Test")
+}
+
+// ParseExampleFuncNames returns the names of the top-level Example functions
+// declared in the Go test source.
+func ParseExampleFuncNames(filename string, source []byte) (names []string, err error) {
+	return parseFuncNamesWithPrefix(filename, source, "Example")
+}
+
+// parseFuncNamesWithPrefix returns the names of the top-level functions, not
+// methods, declared in the Go source whose names begin with prefix.
+func parseFuncNamesWithPrefix(filename string, source []byte, prefix string) (names []string, err error) {
+	var fset *token.FileSet
+	var file *ast.File
+	var fd *ast.FuncDecl
+	var ok bool
+
+	fset = token.NewFileSet()
+	file, err = parser.ParseFile(fset, filename, source, parser.SkipObjectResolution)
+	if err != nil {
+		goto end
+	}
+
+	names = make([]string, 0)
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || !strings.HasPrefix(fd.Name.Name, prefix) {
+			continue
+		}
+		names = append(names, fd.Name.Name)
+	}
+
+end:
+	return names, err
+}
+
+// HasTestFunc reports whether testNames includes a test named for fn. A
+// function Name is tested by TestName or TestName_Suffix; a method
+// Type.Name is tested by TestType_Name, TestTypeName, or the names for a
+// function Name, with or without a _Suffix.
+func (fn GoExportedFunc) HasTestFunc(testNames []string) (tested bool) {
+	var candidates []string
+
+	candidates = []string{"Test" + fn.funcName}
+	if fn.Receiver != "" {
+		candidates = append(candidates,
+			"Test"+fn.Receiver+"_"+fn.funcName,
+			"Test"+fn.Receiver+fn.funcName,
+		)
+	}
+	for _, name := range testNames {
+		for _, c := range candidates {
+			if name == c || strings.HasPrefix(name, c+"_") {
+				tested = true
+				goto end
+			}
+		}
+	}
+
+end:
+	return tested
+}
+
+// derefExpr strips a leading pointer from a receiver type expression.
+func derefExpr(expr ast.Expr) ast.Expr {
+	star, ok := expr.(*ast.StarExpr)
+	if ok {
+		return star.X
+	}
+	return expr
+}
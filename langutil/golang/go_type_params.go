@@ -0,0 +1,260 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// StripTypeParams returns a function, method or type part name without the
+// type parameters or arguments in square brackets, so that "Map[T, U any]"
+// yields "Map" and "*Stack[T].Push" yields "*Stack.Push". Generic functions,
+// types and methods on generic receivers are found by their names with or
+// without type parameters, whatever the parameters are named.
+func StripTypeParams(name string) (stripped string) {
+	var sb strings.Builder
+	var depth int
+
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			sb.WriteRune(r)
+		}
+	}
+	stripped = sb.String()
+	return stripped
+}
+
+// FindFunc returns the function or method in file named by funcName, as
+// FuncGoPart names it, or nil if there is no such function. Type parameters
+// are ignored in the comparison, so the method "*Stack[T].Push" is found as
+// "*Stack.Push" too.
+func FindFunc(file *ast.File, funcName string) (funcDecl *ast.FuncDecl) {
+	var fd *ast.FuncDecl
+	var ok bool
+
+	funcName = StripTypeParams(funcName)
+	for _, decl := range file.Decls {
+		fd, ok = decl.(*ast.FuncDecl)
+		if ok && StripTypeParams(funcPartName(fd)) == funcName {
+			funcDecl = fd
+			goto end
+		}
+	}
+
+end:
+	return funcDecl
+}
+
+// recvPartName returns the name of a method receiver's type as
+// FuncGoPart names it, such as "*Stack[T]" for a pointer to a generic type,
+// or an empty string for a receiver type it does not recognize.
+func recvPartName(expr ast.Expr) (name string) {
+	var args []ast.Expr
+	var names []string
+	var ident, argIdent *ast.Ident
+	var ok bool
+
+	switch x := expr.(type) {
+	case *ast.StarExpr:
+		name = recvPartName(x.X)
+		if name != "" {
+			name = "*" + name
+		}
+		goto end
+	case *ast.Ident:
+		name = x.Name
+		goto end
+	case *ast.IndexExpr:
+		expr, args = x.X, []ast.Expr{x.Index}
+	case *ast.IndexListExpr:
+		expr, args = x.X, x.Indices
+	default:
+		goto end
+	}
+
+	ident, ok = expr.(*ast.Ident)
+	if !ok {
+		goto end
+	}
+	for _, arg := range args {
+		argIdent, ok = arg.(*ast.Ident)
+		if !ok {
+			goto end
+		}
+		names = append(names, argIdent.Name)
+	}
+	name = ident.Name + "[" + strings.Join(names, ", ") + "]"
+
+end:
+	return name
+}
+
+// ValidateTypeParams returns an error if a type parameter list in the Go
+// source is unsound, or if a generic type the source declares is used with
+// the wrong number of type parameters or arguments, as replacing a generic
+// declaration can leave its methods and instantiations behind. It reports:
+//   - a type parameter name declared twice in one list
+//   - a method receiver that does not name one type parameter for each of
+//     its type's, or names any for a type that has none
+//   - a generic type instantiated with the wrong number of type arguments
+//
+// Types declared in other files of the package are not checked, and source
+// that does not parse is left for ValidateSyntax to report.
+func ValidateTypeParams(source string) (err error) {
+	var file *ast.File
+	var params map[string]int
+	var visit func(n ast.Node) bool
+
+	file, err = parser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		err = nil
+		goto end
+	}
+
+	params = make(map[string]int)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			params[typeSpec.Name.Name] = typeSpec.TypeParams.NumFields()
+		}
+	}
+
+	visit = func(n ast.Node) bool {
+		if err != nil {
+			// Inspect still visits the siblings of the node that failed
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.TypeSpec:
+			err = checkTypeParamList(x.Name.Name, x.TypeParams)
+		case *ast.FuncDecl:
+			err = checkTypeParamList(x.Name.Name, x.Type.TypeParams)
+			if err != nil || x.Recv == nil || len(x.Recv.List) != 1 {
+				break
+			}
+			// A receiver's type arguments declare the method's type
+			// parameters rather than instantiate its type, so the receiver
+			// is checked on its own and skipped by the walk
+			err = checkReceiverTypeParams(x, params)
+			if err == nil {
+				ast.Inspect(x.Type, visit)
+			}
+			if err == nil && x.Body != nil {
+				ast.Inspect(x.Body, visit)
+			}
+			return false
+		case *ast.IndexExpr:
+			err = checkTypeArgCount(x.X, 1)
+		case *ast.IndexListExpr:
+			err = checkTypeArgCount(x.X, len(x.Indices))
+		}
+		return err == nil
+	}
+	ast.Inspect(file, visit)
+
+end:
+	return err
+}
+
+// checkTypeParamList returns an error if a name is declared twice in the
+// type parameter list of the type or function named name.
+func checkTypeParamList(name string, list *ast.FieldList) (err error) {
+	var seen map[string]bool
+
+	if list == nil {
+		goto end
+	}
+	seen = make(map[string]bool)
+	for _, field := range list.List {
+		for _, ident := range field.Names {
+			if ident.Name != "_" && seen[ident.Name] {
+				err = fmt.Errorf("type parameter %s is declared more than once for %s", ident.Name, name)
+				goto end
+			}
+			seen[ident.Name] = true
+		}
+	}
+
+end:
+	return err
+}
+
+// checkReceiverTypeParams returns an error if the receiver of funcDecl does
+// not name distinct type parameters, one for each of those its type declares
+// when params records the type's count.
+func checkReceiverTypeParams(funcDecl *ast.FuncDecl, params map[string]int) (err error) {
+	var expr ast.Expr
+	var args []ast.Expr
+	var ident, argIdent *ast.Ident
+	var seen map[string]bool
+	var want int
+	var ok, declared bool
+
+	expr = baseTypeExpr(funcDecl.Recv.List[0].Type)
+	switch x := expr.(type) {
+	case *ast.IndexExpr:
+		expr, args = x.X, []ast.Expr{x.Index}
+	case *ast.IndexListExpr:
+		expr, args = x.X, x.Indices
+	}
+	ident, ok = expr.(*ast.Ident)
+	if !ok {
+		goto end
+	}
+
+	seen = make(map[string]bool)
+	for _, arg := range args {
+		argIdent, ok = arg.(*ast.Ident)
+		if !ok {
+			err = fmt.Errorf("receiver of method %s must name its type parameters, got %s", funcDecl.Name.Name, types.ExprString(arg))
+			goto end
+		}
+		if argIdent.Name != "_" && seen[argIdent.Name] {
+			err = fmt.Errorf("type parameter %s is declared more than once for %s", argIdent.Name, funcDecl.Name.Name)
+			goto end
+		}
+		seen[argIdent.Name] = true
+	}
+
+	want, declared = params[ident.Name]
+	if declared && len(args) != want {
+		err = fmt.Errorf("receiver of method %s has %d type parameters but %s has %d", funcDecl.Name.Name, len(args), ident.Name, want)
+	}
+
+end:
+	return err
+}
+
+// checkTypeArgCount returns an error if expr names a generic type declared
+// in the file that does not take count type arguments. Indexing of anything
+// else, such as slices and maps, is not an instantiation and is ignored.
+func checkTypeArgCount(expr ast.Expr, count int) (err error) {
+	var ident *ast.Ident
+	var typeSpec *ast.TypeSpec
+	var ok bool
+
+	ident, ok = expr.(*ast.Ident)
+	if !ok || ident.Obj == nil || ident.Obj.Kind != ast.Typ {
+		goto end
+	}
+	typeSpec, ok = ident.Obj.Decl.(*ast.TypeSpec)
+	if !ok || typeSpec.TypeParams.NumFields() == 0 || typeSpec.TypeParams.NumFields() == count {
+		goto end
+	}
+	err = fmt.Errorf("%s has %d type parameters but is instantiated with %d type arguments", ident.Name, typeSpec.TypeParams.NumFields(), count)
+
+end:
+	return err
+}
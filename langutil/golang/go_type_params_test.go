@@ -0,0 +1,210 @@
+package golang_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+)
+
+const typeParamsContent = `package demo
+
+// Stack is a generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Len returns the stack's size.
+func (s Stack[T]) Len() int { return len(s.items) }
+
+// Pair holds two values.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func (p Pair[K, V]) Swap() Pair[K, V] { return p }
+
+// Map applies f to each of items.
+func Map[T, U any](items []T, f func(T) U) []U {
+	var result []U
+	for _, item := range items {
+		result = append(result, f(item))
+	}
+	return result
+}
+
+func NewStack() *Stack[int] { return &Stack[int]{} }
+`
+
+// TestGoProcessor_FindPart_Generics verifies that generic types, generic
+// functions and methods on generic receivers are found by their base names
+// as well as with their type parameters.
+func TestGoProcessor_FindPart_Generics(t *testing.T) {
+	tests := []struct {
+		name      string
+		partType  langutil.PartType
+		partName  string
+		wantStart string
+	}{
+		{name: "GenericStruct", partType: golang.TypeGoPart, partName: "Stack", wantStart: "type Stack[T any] struct"},
+		{name: "GenericStructWithParams", partType: golang.TypeGoPart, partName: "Pair[K, V]", wantStart: "type Pair[K comparable, V any]"},
+		{name: "GenericFunc", partType: golang.FuncGoPart, partName: "Map", wantStart: "func Map[T, U any]("},
+		{name: "GenericFuncWithParams", partType: golang.FuncGoPart, partName: "Map[T, U]", wantStart: "func Map[T, U any]("},
+		{name: "PointerGenericReceiver", partType: golang.FuncGoPart, partName: "*Stack[T].Push", wantStart: "func (s *Stack[T]) Push("},
+		{name: "PointerGenericReceiverBaseName", partType: golang.FuncGoPart, partName: "*Stack.Push", wantStart: "func (s *Stack[T]) Push("},
+		{name: "ValueGenericReceiver", partType: golang.FuncGoPart, partName: "Stack[T].Len", wantStart: "func (s Stack[T]) Len()"},
+		{name: "MultipleTypeParamReceiver", partType: golang.FuncGoPart, partName: "Pair[K, V].Swap", wantStart: "func (p Pair[K, V]) Swap()"},
+		{name: "FuncBodyOnGenericReceiver", partType: golang.FuncBodyGoPart, partName: "*Stack[T].Push", wantStart: "\n\ts.items = append"},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pi, err := g.FindPart(langutil.PartArgs{
+				Language: langutil.GoLanguage,
+				Content:  typeParamsContent,
+				PartType: tt.partType,
+				PartName: tt.partName,
+			})
+			if err != nil {
+				t.Fatalf("FindPart() unexpected error: %v", err)
+			}
+			if !pi.Found {
+				t.Fatalf("FindPart() did not find %s %q", tt.partType, tt.partName)
+			}
+			if !strings.HasPrefix(pi.Content, tt.wantStart) {
+				t.Errorf("FindPart() Content =\n%s\nwant it to start with %q", pi.Content, tt.wantStart)
+			}
+		})
+	}
+}
+
+// TestGoProcessor_ListParts_Generics verifies that methods on generic
+// receivers are listed with the receiver's type parameters.
+func TestGoProcessor_ListParts_Generics(t *testing.T) {
+	want := []string{"*Stack[T].Push", "Stack[T].Len", "Pair[K, V].Swap", "Map", "NewStack"}
+
+	g := &golang.GoProcessor{}
+	parts, err := g.ListParts(typeParamsContent, golang.FuncGoPart)
+	if err != nil {
+		t.Fatalf("ListParts() unexpected error: %v", err)
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("ListParts() returned %d parts, want %d", len(parts), len(want))
+	}
+	for i, part := range parts {
+		if part.Name != want[i] {
+			t.Errorf("ListParts()[%d].Name = %q, want %q", i, part.Name, want[i])
+		}
+	}
+}
+
+// TestGoProcessor_ReplacePart_TypeParams verifies that generic declarations
+// can be replaced, and that replacements leaving a type parameter list
+// unsound, or out of step with its methods and instantiations, are rejected.
+func TestGoProcessor_ReplacePart_TypeParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		partType   langutil.PartType
+		partName   string
+		newContent string
+		wantText   string
+		wantErr    string
+	}{
+		{
+			name:       "GenericMethod",
+			partType:   golang.FuncGoPart,
+			partName:   "*Stack[T].Push",
+			newContent: "func (s *Stack[E]) Push(v E) {\n\ts.items = append([]E{v}, s.items...)\n}",
+			wantText:   "func (s *Stack[E]) Push(v E) {",
+		},
+		{
+			name:       "GenericFunc",
+			partType:   golang.FuncGoPart,
+			partName:   "Map",
+			newContent: "func Map[T any, U any](items []T, f func(T) U) (result []U) {\n\treturn result\n}",
+			wantText:   "func Map[T any, U any](items []T",
+		},
+		{
+			name:       "DuplicateFuncTypeParam",
+			partType:   golang.FuncGoPart,
+			partName:   "Map",
+			newContent: "func Map[T, T any](items []T, f func(T) T) []T { return nil }",
+			wantErr:    "type parameter T is declared more than once for Map",
+		},
+		{
+			name:       "DuplicateTypeTypeParam",
+			partType:   golang.TypeGoPart,
+			partName:   "Pair",
+			newContent: "type Pair[K comparable, K any] struct{}",
+			wantErr:    "type parameter K is declared more than once for Pair",
+		},
+		{
+			name:       "ReceiverCountMismatch",
+			partType:   golang.TypeGoPart,
+			partName:   "Stack",
+			newContent: "type Stack[T any, C comparable] struct {\n\titems []T\n}",
+			wantErr:    "receiver of method Push has 1 type parameters but Stack has 2",
+		},
+		{
+			name:       "ReceiverOnNonGenericType",
+			partType:   golang.TypeGoPart,
+			partName:   "Stack",
+			newContent: "type Stack struct {\n\titems []int\n}",
+			wantErr:    "receiver of method Push has 1 type parameters but Stack has 0",
+		},
+		{
+			name:       "ReceiverTypeArgument",
+			partType:   golang.FuncGoPart,
+			partName:   "*Stack.Push",
+			newContent: "func (s *Stack[[]int]) Push(v []int) {}",
+			wantErr:    "receiver of method Push must name its type parameters, got []int",
+		},
+		{
+			name:       "ReceiverDuplicateTypeParam",
+			partType:   golang.FuncGoPart,
+			partName:   "Pair.Swap",
+			newContent: "func (p Pair[K, K]) Swap() Pair[K, K] { return p }",
+			wantErr:    "type parameter K is declared more than once for Swap",
+		},
+		{
+			name:       "InstantiationCountMismatch",
+			partType:   golang.FuncGoPart,
+			partName:   "NewStack",
+			newContent: "func NewStack() *Stack[int, string] { return nil }",
+			wantErr:    "Stack has 1 type parameters but is instantiated with 2 type arguments",
+		},
+	}
+
+	g := &golang.GoProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.ReplacePart(langutil.PartArgs{
+				Language:   langutil.GoLanguage,
+				Content:    typeParamsContent,
+				PartType:   tt.partType,
+				PartName:   tt.partName,
+				NewContent: tt.newContent,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReplacePart() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplacePart() unexpected error: %v", err)
+			}
+			if !strings.Contains(got, tt.wantText) {
+				t.Errorf("ReplacePart() =\n%s\nwant it to contain %q", got, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+// Package javascript provides the langutil processors for JavaScript and
+// TypeScript source code.
+//
+// No JavaScript parser is available from the Go standard library, so the
+// processors find constructs with a tokenizer that understands strings,
+// template literals, regular expressions and comments, and a scanner that
+// recognizes declarations among the top-level statements it delimits, much
+// as automatic semicolon insertion would. TypeScript's type annotations are
+// passed over rather than parsed, so one scanner serves both languages.
+package javascript
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+// JavaScript and TypeScript part type constants define the constructs the
+// processors can find and replace.
+const (
+	// FuncJSPart represents functions: function declarations, const, let or
+	// var declarations initialized with an arrow function or function
+	// expression, and class methods. Methods are named by their class and
+	// method name joined by a dot, such as "ClassName.method", including
+	// constructors, accessors and class fields initialized with a function;
+	// a getter and setter of the same name are found as whichever comes
+	// first. An anonymous default export is named "default".
+	FuncJSPart langutil.PartType = "func"
+
+	// ClassJSPart represents class declarations, named by the class name, or
+	// "default" for an anonymous default export.
+	ClassJSPart langutil.PartType = "class"
+
+	// ImportJSPart represents import declarations, named by the module they
+	// import from without quotes, such as "./util" or "react".
+	ImportJSPart langutil.PartType = "import"
+
+	// ExportJSPart represents export statements that do not declare anything
+	// themselves. A re-export such as "export * from './x'" is named by its
+	// module, an export list such as "export { a, b as c }" by each name it
+	// exports, "a" and "c", and a default export of an expression by
+	// "default". Exported function and class declarations are found as
+	// FuncJSPart and ClassJSPart parts, with "export" in their range.
+	ExportJSPart langutil.PartType = "export"
+)
+
+// Compile-time verification that the processors implement the
+// langutil.Processor interface.
+var (
+	_ langutil.Processor = (*JavaScriptProcessor)(nil)
+	_ langutil.Processor = (*TypeScriptProcessor)(nil)
+)
+
+// JavaScriptProcessor implements the langutil.Processor interface for
+// JavaScript source code.
+//
+// # Part Ranges
+//
+// Only top-level constructs are found, along with the methods of top-level
+// classes. A part spans its decorators, its export and other modifiers, and
+// the construct itself up to its closing brace, or for a statement without
+// a body, its semicolon or the line break that ends it. Replacement content
+// is inserted in place of the range as given, without reformatting.
+//
+// # Syntax Validation
+//
+// JavaScriptProcessor's ValidateSyntax checks source with node --check when
+// Node.js is installed. Otherwise, and always for TypeScript, which Node.js
+// cannot parse, it reports unterminated comments, strings, template literals
+// and regular expressions, and unbalanced brackets, each with its line and
+// column, and errors in the grammar between balanced brackets go undetected.
+//
+// # Registration
+//
+// The JavaScript and TypeScript processors register themselves during
+// package initialization, when the javascript package is imported.
+type JavaScriptProcessor struct{}
+
+// TypeScriptProcessor implements the langutil.Processor interface for
+// TypeScript source code, handling it as JavaScriptProcessor does with type
+// annotations, generics, decorators and declaration modifiers passed over.
+type TypeScriptProcessor struct {
+	JavaScriptProcessor
+}
+
+// init registers the JavaScript and TypeScript processors with the langutil
+// processor registry.
+func init() {
+	langutil.RegisterProcessor(&JavaScriptProcessor{})
+	langutil.RegisterProcessor(&TypeScriptProcessor{})
+}
+
+// Language returns "javascript" to identify this processor as the
+// JavaScript language handler.
+func (p *JavaScriptProcessor) Language() langutil.Language {
+	return langutil.JavasScriptLanguage
+}
+
+// Language returns "typescript" to identify this processor as the
+// TypeScript language handler.
+func (p *TypeScriptProcessor) Language() langutil.Language {
+	return langutil.TypeScriptLanguage
+}
+
+// SupportedPartTypes returns the constructs this processor can find and
+// replace: FuncJSPart, ClassJSPart, ImportJSPart and ExportJSPart.
+func (p *JavaScriptProcessor) SupportedPartTypes() []langutil.PartType {
+	return []langutil.PartType{
+		FuncJSPart,
+		ClassJSPart,
+		ImportJSPart,
+		ExportJSPart,
+	}
+}
+
+// FindPart finds the construct of args.PartType named args.PartName in
+// args.Content.
+//
+// When args.PartName is empty and args.Line is set, the construct of
+// args.PartType whose range includes the line is found instead, and the
+// returned PartInfo's Name is set to the name it would be found by.
+//
+// Returns a PartInfo with Found=false if the construct is not found, and an
+// error if the source cannot be tokenized or the part type is not supported.
+func (p *JavaScriptProcessor) FindPart(args langutil.PartArgs) (pi *langutil.PartInfo, err error) {
+	var defs []jsDef
+	var match *jsDef
+
+	pi = &langutil.PartInfo{Found: false}
+
+	err = checkPartType(args.PartType)
+	if err != nil {
+		goto end
+	}
+
+	defs, err = scanJSDefs(args.Content)
+	if err != nil {
+		err = fmt.Errorf("failed to parse source: %w", err)
+		goto end
+	}
+
+	for i, def := range defs {
+		switch {
+		case def.kind != string(args.PartType):
+		case args.PartName != "":
+			if def.name == args.PartName && match == nil {
+				match = &defs[i]
+			}
+		case args.Line >= lineOf(args.Content, def.start) && args.Line <= lineOf(args.Content, def.end):
+			// Parts of one type never nest, so only parts sharing the line
+			// can both contain it, and the last of them is taken
+			match = &defs[i]
+		}
+	}
+	if match == nil {
+		goto end
+	}
+
+	pi = &langutil.PartInfo{
+		Found:       true,
+		StartLine:   lineOf(args.Content, match.start),
+		EndLine:     lineOf(args.Content, match.end),
+		StartOffset: match.start,
+		EndOffset:   match.end,
+		Content:     args.Content[match.start:match.end],
+	}
+	if args.PartName == "" {
+		pi.Name = match.name
+	}
+
+end:
+	return pi, err
+}
+
+// ReplacePart replaces the construct found as FindPart would with
+// args.NewContent, without its surrounding whitespace, and returns the
+// updated source.
+//
+// Returns an error if the construct is not found, args.NewContent is not a
+// single construct of the part type, or the result fails ValidateSyntax.
+func (p *JavaScriptProcessor) ReplacePart(args langutil.PartArgs) (result string, err error) {
+	return p.replacePart(args, p.ValidateSyntax)
+}
+
+// ReplacePart replaces the construct as JavaScriptProcessor.ReplacePart
+// does, validating the result with TypeScriptProcessor.ValidateSyntax.
+func (p *TypeScriptProcessor) ReplacePart(args langutil.PartArgs) (result string, err error) {
+	return p.replacePart(args, p.ValidateSyntax)
+}
+
+// replacePart implements ReplacePart, checking the updated source with
+// validate.
+func (p *JavaScriptProcessor) replacePart(args langutil.PartArgs, validate func(string) error) (result string, err error) {
+	var partInfo *langutil.PartInfo
+
+	partInfo, err = p.FindPart(args)
+	if err != nil {
+		goto end
+	}
+
+	if !partInfo.Found && args.PartName == "" && args.Line > 0 {
+		err = fmt.Errorf("no %s found at line %d", args.PartType, args.Line)
+		goto end
+	}
+
+	if !partInfo.Found {
+		err = fmt.Errorf("%s '%s' not found in file", args.PartType, args.PartName)
+		goto end
+	}
+
+	err = p.ValidateContent(langutil.PartArgs{
+		PartType: args.PartType,
+		Content:  args.NewContent,
+	})
+	if err != nil {
+		goto end
+	}
+
+	result = args.Content[:partInfo.StartOffset] + strings.TrimSpace(args.NewContent) + args.Content[partInfo.EndOffset:]
+
+	err = validate(result)
+	if err != nil {
+		err = fmt.Errorf("replacement resulted in invalid syntax: %w", err)
+	}
+
+end:
+	return result, err
+}
+
+// ValidateContent validates that args.Content, as replacement content, is a
+// single construct of args.PartType. A FuncJSPart replacement may be either
+// a function or a class method, as methods are found as functions.
+func (p *JavaScriptProcessor) ValidateContent(args langutil.PartArgs) (err error) {
+	var content string
+	var defs []jsDef
+
+	err = checkPartType(args.PartType)
+	if err != nil {
+		goto end
+	}
+
+	content = strings.TrimSpace(args.Content)
+	defs, err = scanJSDefs(content)
+	if err != nil {
+		err = fmt.Errorf("%s replacement cannot be parsed: %w", args.PartType, err)
+		goto end
+	}
+	if spansAll(defs, string(args.PartType), 0, len(content)) {
+		goto end
+	}
+
+	if args.PartType == FuncJSPart {
+		// A method only parses as one within a class body
+		defs, err = scanJSDefs(methodWrapper + content + "\n}")
+		if err == nil && spansAll(defs, string(FuncJSPart), len(methodWrapper), len(methodWrapper)+len(content)) {
+			goto end
+		}
+	}
+
+	err = fmt.Errorf("%s replacement must be a single %s, got: %s", args.PartType, args.PartType, content[:min(20, len(content))])
+
+end:
+	return err
+}
+
+// ValidateSyntax validates that source is syntactically valid JavaScript by
+// checking it with node --check, without running it. Source is valid if it
+// parses as either an ES module or a CommonJS script, and otherwise the error
+// reported for it as a module is returned. If Node.js is not installed, a
+// warning is logged and only the checks of the processor's own tokenizer are
+// made: that comments, strings, template literals and regular expressions are
+// terminated and brackets are balanced. Grammar errors between balanced
+// brackets, such as a missing operand, then go undetected.
+func (p *JavaScriptProcessor) ValidateSyntax(source string) (err error) {
+	var node string
+
+	node, err = exec.LookPath("node")
+	if err != nil {
+		logger.Warn("Node.js not found on PATH; checking syntax with the tokenizer only",
+			"error", err)
+		_, err = scanJSDefs(source)
+		goto end
+	}
+
+	err = nodeCheck(node, "module", source)
+	if err == nil {
+		goto end
+	}
+	if nodeCheck(node, "commonjs", source) == nil {
+		err = nil
+	}
+
+end:
+	return err
+}
+
+// ValidateSyntax validates that source tokenizes cleanly with balanced
+// brackets, reporting the line and column of the first error found. Node.js
+// cannot parse TypeScript, so the grammar of the tokens between brackets is
+// not checked.
+func (p *TypeScriptProcessor) ValidateSyntax(source string) (err error) {
+	_, err = scanJSDefs(source)
+	return err
+}
+
+// nodeCheckError matches the location node --check reports on the first
+// line of its output for source read from stdin.
+var nodeCheckError = regexp.MustCompile(`^\[stdin\]:(\d+)`)
+
+// nodeCheck checks the syntax of source with the node executable, parsing it
+// as inputType, "module" or "commonjs". A syntax error is returned as the line
+// and message node reports.
+func nodeCheck(node, inputType, source string) (err error) {
+	var cmd *exec.Cmd
+	var stderr bytes.Buffer
+	var output string
+	var match []string
+	var message string
+	var ok bool
+
+	cmd = exec.Command(node, "--check", "--input-type="+inputType)
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err == nil || stderr.Len() == 0 {
+		goto end
+	}
+
+	output = strings.TrimSpace(stderr.String())
+	err = errors.New(output)
+	match = nodeCheckError.FindStringSubmatch(output)
+	if match == nil {
+		goto end
+	}
+	for _, line := range strings.Split(output, "\n") {
+		message, ok = strings.CutPrefix(line, "SyntaxError: ")
+		if ok {
+			err = fmt.Errorf("line %s: %s", match[1], message)
+			break
+		}
+	}
+
+end:
+	return err
+}
+
+// methodWrapper is the class body opening that a method replacement is
+// scanned within.
+const methodWrapper = "class _ {\n"
+
+// spansAll reports whether defs includes a construct of kind spanning
+// exactly from offset start to offset end, so that nothing but that one
+// construct, and the constructs within it, lies in that range.
+func spansAll(defs []jsDef, kind string, start, end int) bool {
+	for _, def := range defs {
+		if def.kind == kind && def.start == start && def.end == end {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPartType returns an error unless partType is one of the part types
+// JavaScriptProcessor supports.
+func checkPartType(partType langutil.PartType) (err error) {
+	switch partType {
+	case FuncJSPart, ClassJSPart, ImportJSPart, ExportJSPart:
+	default:
+		err = fmt.Errorf("unsupported part type for JavaScript: %s", partType)
+	}
+	return err
+}
+
+// lineOf returns the 1-based line of source that offset falls on.
+func lineOf(source string, offset int) int {
+	return strings.Count(source[:offset], "\n") + 1
+}
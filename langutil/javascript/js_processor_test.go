@@ -0,0 +1,362 @@
+package javascript_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/javascript"
+)
+
+const tsContent = `import { readFile } from "fs/promises";
+import type { Config } from './config'
+
+type Handler = Array<string>
+
+/* function notAFunction() {} */
+export async function load(path: string): Promise<{ ok: boolean }> {
+  const text = await readFile(path, "utf8");
+  return { ok: /}/.test(text) };
+}
+
+export const greet = (name: string): string =>
+  ` + "`Hello, ${name.replace(/`/g, '')}!`" + `
+
+const count = items.length
+
+@Component({ selector: "app" })
+export default class Widget extends Base<Config> {
+  private static readonly max = 10;
+  #secret = "function hidden() {}";
+
+  constructor(private config: Config) {
+    super(config);
+  }
+
+  get size(): number {
+    return Widget.max
+  }
+
+  async render<T>(props: T): Promise<void> {
+    console.log(props)
+  }
+
+  handle = (event: Event) => {
+    this.render(event);
+  }
+
+  abstract dispose(): void;
+}
+
+export { load as loadFile, greet };
+export * from "./widgets";
+`
+
+// TestJavaScriptProcessor_FindPart verifies that functions, arrow function
+// consts, methods, classes, imports and exports are found with their full
+// ranges, while text in comments and strings is ignored.
+func TestJavaScriptProcessor_FindPart(t *testing.T) {
+	tests := []struct {
+		name      string
+		partType  langutil.PartType
+		partName  string
+		wantFound bool
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "ExportedAsyncFunction",
+			partType:  javascript.FuncJSPart,
+			partName:  "load",
+			wantFound: true,
+			wantStart: "export async function load(",
+			wantEnd:   "return { ok: /}/.test(text) };\n}",
+		},
+		{
+			name:      "ArrowFunctionConst",
+			partType:  javascript.FuncJSPart,
+			partName:  "greet",
+			wantFound: true,
+			wantStart: "export const greet =",
+			wantEnd:   "'')}!`",
+		},
+		{
+			name:      "Constructor",
+			partType:  javascript.FuncJSPart,
+			partName:  "Widget.constructor",
+			wantFound: true,
+			wantStart: "constructor(private config: Config) {",
+			wantEnd:   "super(config);\n  }",
+		},
+		{
+			name:      "Getter",
+			partType:  javascript.FuncJSPart,
+			partName:  "Widget.size",
+			wantFound: true,
+			wantStart: "get size(): number {",
+			wantEnd:   "return Widget.max\n  }",
+		},
+		{
+			name:      "GenericAsyncMethod",
+			partType:  javascript.FuncJSPart,
+			partName:  "Widget.render",
+			wantFound: true,
+			wantStart: "async render<T>(",
+			wantEnd:   "console.log(props)\n  }",
+		},
+		{
+			name:      "ArrowFunctionField",
+			partType:  javascript.FuncJSPart,
+			partName:  "Widget.handle",
+			wantFound: true,
+			wantStart: "handle = (event: Event) => {",
+			wantEnd:   "this.render(event);\n  }",
+		},
+		{
+			name:      "AbstractMethod",
+			partType:  javascript.FuncJSPart,
+			partName:  "Widget.dispose",
+			wantFound: true,
+			wantStart: "abstract dispose(): void;",
+			wantEnd:   "abstract dispose(): void;",
+		},
+		{
+			name:      "DecoratedDefaultExportClass",
+			partType:  javascript.ClassJSPart,
+			partName:  "Widget",
+			wantFound: true,
+			wantStart: "@Component({ selector: \"app\" })\nexport default class Widget",
+			wantEnd:   "abstract dispose(): void;\n}",
+		},
+		{
+			name:      "Import",
+			partType:  javascript.ImportJSPart,
+			partName:  "./config",
+			wantFound: true,
+			wantStart: "import type { Config }",
+			wantEnd:   "from './config'",
+		},
+		{
+			name:      "ExportListByName",
+			partType:  javascript.ExportJSPart,
+			partName:  "loadFile",
+			wantFound: true,
+			wantStart: "export { load as loadFile",
+			wantEnd:   "greet };",
+		},
+		{
+			name:      "ReExport",
+			partType:  javascript.ExportJSPart,
+			partName:  "./widgets",
+			wantFound: true,
+			wantStart: "export * from",
+			wantEnd:   "\"./widgets\";",
+		},
+		{name: "NonFunctionConst", partType: javascript.FuncJSPart, partName: "count"},
+		{name: "FunctionInComment", partType: javascript.FuncJSPart, partName: "notAFunction"},
+		{name: "FunctionInString", partType: javascript.FuncJSPart, partName: "hidden"},
+		{name: "NonFunctionField", partType: javascript.FuncJSPart, partName: "Widget.max"},
+		{name: "MethodWithoutClass", partType: javascript.FuncJSPart, partName: "render"},
+	}
+
+	p := &javascript.TypeScriptProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pi, err := p.FindPart(langutil.PartArgs{
+				Language: langutil.TypeScriptLanguage,
+				Content:  tsContent,
+				PartType: tt.partType,
+				PartName: tt.partName,
+			})
+			if err != nil {
+				t.Fatalf("FindPart() unexpected error: %v", err)
+			}
+			if pi.Found != tt.wantFound {
+				t.Fatalf("FindPart() Found = %v, want %v", pi.Found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if !strings.HasPrefix(pi.Content, tt.wantStart) || !strings.HasSuffix(pi.Content, tt.wantEnd) {
+				t.Errorf("FindPart() Content =\n%s\nwant it to start with %q and end with %q", pi.Content, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+// TestJavaScriptProcessor_ReplacePart verifies that functions and methods
+// are replaced in place and that content which is not a single construct of
+// the part type is rejected.
+func TestJavaScriptProcessor_ReplacePart(t *testing.T) {
+	const content = "class Greeter {\n" +
+		"  greet() {\n" +
+		"    return 'hello'\n" +
+		"  }\n" +
+		"}\n" +
+		"\n" +
+		"function main() {\n" +
+		"  new Greeter().greet()\n" +
+		"}\n"
+
+	tests := []struct {
+		name       string
+		partType   langutil.PartType
+		partName   string
+		newContent string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "Method",
+			partType:   javascript.FuncJSPart,
+			partName:   "Greeter.greet",
+			newContent: "greet(name) {\n    return `hello ${name}`\n  }\n",
+			want: "class Greeter {\n" +
+				"  greet(name) {\n" +
+				"    return `hello ${name}`\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"function main() {\n" +
+				"  new Greeter().greet()\n" +
+				"}\n",
+		},
+		{
+			name:       "FunctionWithArrowConst",
+			partType:   javascript.FuncJSPart,
+			partName:   "main",
+			newContent: "const main = () => new Greeter().greet('world')",
+			want: "class Greeter {\n" +
+				"  greet() {\n" +
+				"    return 'hello'\n" +
+				"  }\n" +
+				"}\n" +
+				"\n" +
+				"const main = () => new Greeter().greet('world')\n",
+		},
+		{
+			name:       "TwoFunctions",
+			partType:   javascript.FuncJSPart,
+			partName:   "main",
+			newContent: "function main() {}\nfunction other() {}",
+			wantErr:    "must be a single func",
+		},
+		{
+			name:       "ClassForFunction",
+			partType:   javascript.FuncJSPart,
+			partName:   "main",
+			newContent: "class Main {}",
+			wantErr:    "must be a single func",
+		},
+		{
+			name:       "UnbalancedBraces",
+			partType:   javascript.FuncJSPart,
+			partName:   "main",
+			newContent: "function main() {\n  if (x) {\n}",
+			wantErr:    "line 1, column 17: '{' is never closed",
+		},
+		{
+			name:       "NotFound",
+			partType:   javascript.ClassJSPart,
+			partName:   "Missing",
+			newContent: "class Missing {}",
+			wantErr:    "class 'Missing' not found in file",
+		},
+	}
+
+	p := &javascript.JavaScriptProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.ReplacePart(langutil.PartArgs{
+				Language:   langutil.JavasScriptLanguage,
+				Content:    content,
+				PartType:   tt.partType,
+				PartName:   tt.partName,
+				NewContent: tt.newContent,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReplacePart() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplacePart() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReplacePart() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestJavaScriptProcessor_ValidateSyntax verifies that grammar errors between
+// balanced brackets are reported with their line when Node.js is installed.
+func TestJavaScriptProcessor_ValidateSyntax(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("Node.js is not installed")
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		wantLine string
+	}{
+		{name: "ValidModule", source: "import { a } from './a.js'\nexport const b = a / 2\n"},
+		{name: "ValidScript", source: "const fs = require('fs')\nwith (fs) { readFileSync }\n"},
+		{name: "MissingOperand", source: "let a = 1\nlet x = ;\n", wantLine: "line 2: "},
+		{name: "FunctionMissingBody", source: "function f()\nconst y = (1)\n", wantLine: "line 2: "},
+		{name: "MissingComma", source: "const o = {\n  a: 1\n  b: 2\n}\n", wantLine: "line 3: "},
+		{name: "UnterminatedString", source: "let a = 1\nlet s = 'abc\n", wantLine: "line 2: "},
+	}
+
+	p := &javascript.JavaScriptProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.ValidateSyntax(tt.source)
+			if tt.wantLine == "" {
+				if err != nil {
+					t.Errorf("ValidateSyntax() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantLine) {
+				t.Errorf("ValidateSyntax() error = %v, want prefix %q", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+// TestTypeScriptProcessor_ValidateSyntax verifies that lexical errors and
+// unbalanced brackets are reported with their line and column.
+func TestTypeScriptProcessor_ValidateSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{name: "Valid", source: "const re = /[/]/g\nconst s = `a ${`b ${c}`} d`\nx = a / b / c\n"},
+		{name: "TypeAnnotations", source: "let n: Array<number> = []\nfunction f<T>(x: T): T { return x }\n"},
+		{name: "UnterminatedString", source: "let a = 1\nlet s = 'abc\n", wantErr: "line 2, column 9: unterminated string literal"},
+		{name: "UnterminatedTemplate", source: "let s = `abc ${x}", wantErr: "line 1, column 9: unterminated template literal"},
+		{name: "UnterminatedComment", source: "f()\n  /* never closed", wantErr: "line 2, column 3: unterminated comment"},
+		{name: "MismatchedBracket", source: "f(a, [b)", wantErr: "line 1, column 8: unexpected ')'"},
+	}
+
+	p := &javascript.TypeScriptProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.ValidateSyntax(tt.source)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSyntax() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateSyntax() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
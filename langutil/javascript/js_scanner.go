@@ -0,0 +1,386 @@
+package javascript
+
+import (
+	"slices"
+)
+
+// jsDef is a construct found in JavaScript or TypeScript source.
+type jsDef struct {
+	kind  string // "func", "class", "import" or "export"
+	name  string // Name the construct is found by, such as "ClassName.method"
+	start int    // Offset of the construct's first token, including decorators and modifiers
+	end   int    // Offset just past the construct's last token
+}
+
+// jsScanner finds the top-level constructs of tokenized source.
+type jsScanner struct {
+	tokens []jsToken
+	defs   []jsDef
+}
+
+// declModifiers are the keywords that may precede a function or class
+// declaration.
+var declModifiers = []string{"export", "default", "declare", "abstract", "async"}
+
+// memberModifiers are the keywords that may precede the name of a class
+// member.
+var memberModifiers = []string{
+	"static", "async", "get", "set", "public", "private", "protected",
+	"readonly", "abstract", "override", "declare", "accessor",
+}
+
+// scanJSDefs returns the functions, classes, methods, imports and exports
+// declared at the top level of source, in source order.
+//
+// Functions are function declarations and const, let or var declarations
+// initialized with an arrow function or function expression, and methods
+// are named "ClassName.method", as are class fields initialized with a
+// function. An export statement without a declaration, such as
+// "export { a, b as c } from './x'", is named by the module it exports from,
+// or when it has none, once for each name it exports. Imports are named by
+// the module they import.
+func scanJSDefs(source string) (defs []jsDef, err error) {
+	var s *jsScanner
+	var tokens []jsToken
+	var i int
+
+	tokens, err = tokenizeJS(source)
+	if err != nil {
+		goto end
+	}
+
+	s = &jsScanner{tokens: tokens}
+	for i < len(tokens) {
+		i = s.scanStatement(i) + 1
+	}
+	defs = s.defs
+
+end:
+	return defs, err
+}
+
+// scanStatement records the constructs declared by the top-level statement
+// starting at token i and returns the index of its last token.
+func (s *jsScanner) scanStatement(i int) (last int) {
+	var name string
+	var k, body int
+	var exported, isDefault bool
+
+	k = s.skipDecorators(i)
+	for k < len(s.tokens) && s.isIdent(k, declModifiers...) {
+		exported = exported || s.isIdent(k, "export")
+		isDefault = isDefault || s.isIdent(k, "default")
+		if s.isIdent(k, "async") && !s.isIdent(k+1, "function") {
+			break
+		}
+		k++
+	}
+	if k >= len(s.tokens) {
+		return len(s.tokens) - 1
+	}
+
+	switch {
+	case s.isIdent(k, "function"):
+		name = s.declName(k+1, isDefault)
+		last = s.scanFunction(k + 1)
+		s.add("func", name, i, last)
+	case s.isIdent(k, "class"):
+		name = s.declName(k+1, isDefault)
+		body = s.findBody(k+1, false)
+		if body < 0 {
+			return s.statementEnd(i)
+		}
+		last = s.tokens[body].match
+		s.add("class", name, i, last)
+		s.scanClassBody(name, body)
+	case s.isIdent(k, "const", "let", "var") && k+1 < len(s.tokens) && s.tokens[k+1].kind == jsIdent:
+		last = s.statementEnd(i)
+		if s.isFuncInitializer(k+2, last) {
+			s.add("func", s.tokens[k+1].text, i, last)
+		}
+	case s.isIdent(k, "import") && k == i && !s.isPunct(k+1, "(", "."):
+		last = s.statementEnd(i)
+		s.addModule("import", i, last)
+	case exported && isDefault:
+		last = s.statementEnd(i)
+		s.add("export", "default", i, last)
+	case exported && (s.isPunct(k, "{", "*") || s.isIdent(k, "type") && s.isPunct(k+1, "{", "*")):
+		last = s.statementEnd(i)
+		if !s.addModule("export", i, last) {
+			s.addExportedNames(i, last)
+		}
+	default:
+		last = s.statementEnd(i)
+	}
+	return last
+}
+
+// scanFunction returns the index of the last token of the function whose
+// name, or parameters when anonymous, start at token i: the closing brace
+// of its body, or the end of the statement for a declaration without one.
+func (s *jsScanner) scanFunction(i int) (last int) {
+	body := s.findBody(i, true)
+	if body < 0 {
+		return s.statementEnd(i)
+	}
+	return s.tokens[body].match
+}
+
+// scanClassBody records the methods, and fields initialized with functions,
+// of the class className whose body opens at token open.
+func (s *jsScanner) scanClassBody(className string, open int) {
+	var k, nameTok, last int
+
+	for k = open + 1; k < s.tokens[open].match; k = last + 1 {
+		if s.isPunct(k, ";") {
+			last = k
+			continue
+		}
+		start := k
+		k = s.skipDecorators(k)
+		for s.isIdent(k, memberModifiers...) && s.isMemberName(k+1) || s.isPunct(k, "*") {
+			k++
+		}
+		nameTok = k
+		k++
+		if s.tokens[nameTok].text == "[" {
+			k = s.tokens[nameTok].match + 1
+		}
+		if s.isPunct(k, "?", "!") {
+			k++
+		}
+
+		switch {
+		case s.isPunct(k, "(", "<"):
+			last = s.scanFunction(k)
+			s.add("func", className+"."+s.memberName(nameTok), start, last)
+		default:
+			last = s.statementEnd(start)
+			if eq := s.indexOf(k, last, "="); eq >= 0 && s.isFuncInitializer(eq, last) {
+				s.add("func", className+"."+s.memberName(nameTok), start, last)
+			}
+		}
+	}
+}
+
+// skipDecorators returns the index of the first token at or after i that is
+// not part of a decorator, such as "@Input()" or "@core.memo".
+func (s *jsScanner) skipDecorators(i int) int {
+	for s.isPunct(i, "@") {
+		i += 2
+		for s.isPunct(i, ".") {
+			i += 2
+		}
+		if s.isPunct(i, "(") && !s.tokens[i].newline {
+			i = s.tokens[i].match + 1
+		}
+	}
+	return i
+}
+
+// declName returns the name of the function or class declared by the
+// identifier at token i, or "default" for an anonymous default export.
+func (s *jsScanner) declName(i int, isDefault bool) string {
+	if s.isPunct(i, "*") {
+		i++
+	}
+	if i < len(s.tokens) && s.tokens[i].kind == jsIdent && !s.isIdent(i, "extends", "implements") {
+		return s.tokens[i].text
+	}
+	if isDefault {
+		return "default"
+	}
+	return ""
+}
+
+// memberName returns the name of the class member whose name is token i:
+// an identifier, private name, number, the value of a string, or the source
+// of a computed name including its brackets.
+func (s *jsScanner) memberName(i int) string {
+	tok := s.tokens[i]
+	switch {
+	case tok.kind == jsString:
+		return tok.text[1 : len(tok.text)-1]
+	case s.isPunct(i, "["):
+		return joinTokenText(s.tokens[i : tok.match+1])
+	}
+	return tok.text
+}
+
+// isMemberName reports whether token i can name a class member, so that a
+// modifier keyword before it is a modifier rather than the name itself.
+func (s *jsScanner) isMemberName(i int) bool {
+	if i >= len(s.tokens) || s.tokens[i].newline && s.isIdent(i-1, "async") {
+		return false
+	}
+	switch s.tokens[i].kind {
+	case jsIdent, jsPrivate, jsString, jsNumber:
+		return true
+	}
+	return s.isPunct(i, "[", "*")
+}
+
+// findBody returns the index of the brace opening the body of the function
+// or class whose declaration continues at token i, or -1 if the declaration
+// ends without a body. With params set, the body must follow a parameter
+// list. Braces of TypeScript type literals, such as a return type of
+// "{ ok: boolean }", are skipped.
+func (s *jsScanner) findBody(i int, params bool) int {
+	if i >= len(s.tokens) {
+		return -1
+	}
+	depth := s.tokens[i].depth
+	for k := i; k < len(s.tokens); k++ {
+		switch {
+		case s.tokens[k].depth < depth:
+			return -1
+		case k > i && s.tokens[k].newline && !s.isPunct(k, "{") && !continuesJS(s.tokens[k-1], s.tokens[k]):
+			return -1
+		case s.isPunct(k, ";"):
+			return -1
+		case s.isPunct(k, "{") && !params && !s.isPunct(k-1, ":", "|", "&", "<", ",", "(", "=>"):
+			return k
+		case s.isPunct(k, "("):
+			params = false
+		}
+		if s.tokens[k].match > k {
+			k = s.tokens[k].match
+		}
+	}
+	return -1
+}
+
+// statementEnd returns the index of the last token of the statement or class
+// member starting at token i: its semicolon, or the last token before a line
+// break at which automatic semicolon insertion would end it, or before the
+// end of the enclosing block.
+func (s *jsScanner) statementEnd(i int) int {
+	depth := s.tokens[i].depth
+	for k := i; ; k++ {
+		if s.tokens[k].match > k {
+			k = s.tokens[k].match
+		}
+		switch {
+		case s.isPunct(k, ";"):
+			return k
+		case k+1 >= len(s.tokens) || s.tokens[k+1].depth < depth:
+			return k
+		case s.tokens[k+1].newline && !continuesJS(s.tokens[k], s.tokens[k+1]):
+			return k
+		}
+	}
+}
+
+// isFuncInitializer reports whether the tokens from i to last, starting at
+// or after an initializer's "=", are an arrow function or function
+// expression, possibly async or generic.
+func (s *jsScanner) isFuncInitializer(i, last int) bool {
+	if s.isPunct(i, "=") {
+		i++
+	} else if eq := s.indexOf(i, last, "="); eq >= 0 {
+		i = eq + 1
+	} else {
+		return false
+	}
+	if s.isIdent(i, "async") && i < last {
+		i++
+	}
+	switch {
+	case s.isIdent(i, "function"):
+		return true
+	case i < last && s.tokens[i].kind == jsIdent && s.isPunct(i+1, "=>"):
+		return true
+	case s.isPunct(i, "(", "<"):
+		arrow := s.indexOf(i, last, "=>")
+		comma := s.indexOf(i, last, ",")
+		return arrow >= 0 && (comma < 0 || arrow < comma)
+	}
+	return false
+}
+
+// indexOf returns the index of the first punctuator text among tokens i to
+// last that is not within brackets nested deeper than token i, or -1.
+func (s *jsScanner) indexOf(i, last int, text string) int {
+	if i > last || i >= len(s.tokens) {
+		return -1
+	}
+	depth := s.tokens[i].depth
+	for k := i; k <= last; k++ {
+		if s.tokens[k].depth == depth && s.isPunct(k, text) {
+			return k
+		}
+		if s.tokens[k].match > k {
+			k = s.tokens[k].match - 1
+		}
+	}
+	return -1
+}
+
+// addModule records a construct of kind named by the first string among
+// tokens i to last, the module an import or export statement refers to, and
+// reports whether it had one.
+func (s *jsScanner) addModule(kind string, i, last int) bool {
+	for k := i; k <= last; k++ {
+		if s.tokens[k].kind == jsString {
+			s.add(kind, s.tokens[k].text[1:len(s.tokens[k].text)-1], i, last)
+			return true
+		}
+	}
+	return false
+}
+
+// addExportedNames records an export construct for each name exported by
+// the export list among tokens i to last, such as "c" for "b as c".
+func (s *jsScanner) addExportedNames(i, last int) {
+	for k := i; k <= last; k++ {
+		if s.tokens[k].kind == jsIdent && s.isPunct(k+1, ",", "}") && s.tokens[k].depth > s.tokens[i].depth {
+			s.add("export", s.tokens[k].text, i, last)
+		}
+	}
+}
+
+// add records a construct of kind named name spanning tokens first to last.
+func (s *jsScanner) add(kind, name string, first, last int) {
+	if name == "" {
+		return
+	}
+	s.defs = append(s.defs, jsDef{
+		kind:  kind,
+		name:  name,
+		start: s.tokens[first].start,
+		end:   s.tokens[last].end,
+	})
+}
+
+// isIdent reports whether token i is an identifier spelled as one of names.
+func (s *jsScanner) isIdent(i int, names ...string) bool {
+	return i >= 0 && i < len(s.tokens) && s.tokens[i].kind == jsIdent && slices.Contains(names, s.tokens[i].text)
+}
+
+// isPunct reports whether token i is a punctuator spelled as one of puncts.
+func (s *jsScanner) isPunct(i int, puncts ...string) bool {
+	return i >= 0 && i < len(s.tokens) && s.tokens[i].kind == jsPunct && slices.Contains(puncts, s.tokens[i].text)
+}
+
+// continuesJS reports whether a statement continues across the line break
+// between tokens prev and next, as when prev is a binary operator or next
+// starts a member access, rather than being ended by automatic semicolon
+// insertion. A ">" ending a line is taken to close TypeScript type
+// arguments, as in "type List = Array<string>", rather than to compare.
+func continuesJS(prev, next jsToken) bool {
+	if prev.kind == jsPunct && !slices.Contains([]string{")", "]", "}", ">", "++", "--"}, prev.text) {
+		return true
+	}
+	if next.kind == jsIdent {
+		return slices.Contains([]string{"in", "instanceof", "as", "satisfies", "extends", "implements"}, next.text)
+	}
+	return next.kind == jsPunct && !slices.Contains([]string{"{", "!", "~", "++", "--", "@", ";"}, next.text)
+}
+
+// joinTokenText returns the text of tokens joined without whitespace.
+func joinTokenText(tokens []jsToken) (text string) {
+	for _, tok := range tokens {
+		text += tok.text
+	}
+	return text
+}
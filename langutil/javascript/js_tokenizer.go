@@ -0,0 +1,288 @@
+package javascript
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jsTokenKind classifies the tokens produced by tokenizeJS.
+type jsTokenKind int
+
+const (
+	jsPunct    jsTokenKind = iota // Operators, brackets and other punctuation
+	jsIdent                       // Identifiers and keywords
+	jsPrivate                     // Private class member names, such as #count
+	jsNumber                      // Numeric literals
+	jsString                      // Single and double quoted string literals
+	jsTemplate                    // A template literal, or its part up to or after a substitution
+	jsRegex                       // Regular expression literals with their flags
+)
+
+// jsToken is a token of JavaScript or TypeScript source.
+type jsToken struct {
+	kind    jsTokenKind
+	text    string
+	start   int  // Offset of the token's first byte
+	end     int  // Offset just past the token's last byte
+	depth   int  // Number of brackets open around the token; an opener and its closer share the depth outside them
+	match   int  // Index of the matching closer for an opening bracket, or -1
+	newline bool // Whether a line break precedes the token
+}
+
+// regexKeywords are the keywords after which a slash starts a regular
+// expression rather than a division.
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// multiCharPuncts are the punctuators the scanner needs to see whole; all
+// other punctuation is tokenized one character at a time.
+var multiCharPuncts = []string{"...", "=>", "?.", "++", "--"}
+
+// tokenizeJS splits JavaScript or TypeScript source into tokens, dropping
+// comments and whitespace, and matches up its brackets.
+//
+// Returns an error reporting the line and column of an unterminated comment,
+// string, template literal or regular expression, or of a bracket that is
+// unmatched or closes the wrong kind of bracket.
+func tokenizeJS(source string) (tokens []jsToken, err error) {
+	var open []int // Indexes of the open brackets' tokens, or -1-offset for a substitution in a template literal starting at offset
+	var newline bool
+	var i int
+
+	for i < len(source) {
+		c := source[i]
+		start := i
+		tok := jsToken{start: i, depth: len(open), match: -1, newline: newline}
+
+		switch {
+		case c == '\n':
+			newline = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+			continue
+		case strings.HasPrefix(source[i:], "//"):
+			i += strings.IndexByte(source[i:]+"\n", '\n')
+			continue
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				err = jsError(source, start, "unterminated comment")
+				goto end
+			}
+			newline = newline || strings.Contains(source[i:i+2+end], "\n")
+			i += end + 4
+			continue
+		case c == '\'' || c == '"':
+			tok.kind = jsString
+			i, err = scanJSString(source, i)
+		case c == '`':
+			tok.kind = jsTemplate
+			i, err = scanJSTemplate(source, start, i+1, &open)
+		case c == '}' && len(open) > 0 && open[len(open)-1] < 0:
+			// The end of a template literal's substitution
+			literal := -1 - open[len(open)-1]
+			open = open[:len(open)-1]
+			tok.kind, tok.depth = jsTemplate, len(open)
+			i, err = scanJSTemplate(source, literal, i+1, &open)
+		case isJSIdentStart(source[i:]):
+			tok.kind = jsIdent
+			i = scanJSIdent(source, i)
+		case c == '#' && isJSIdentStart(source[i+1:]):
+			tok.kind = jsPrivate
+			i = scanJSIdent(source, i+1)
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(source) && source[i+1] >= '0' && source[i+1] <= '9':
+			tok.kind = jsNumber
+			for i++; i < len(source) && (isJSIdentChar(source[i]) || source[i] == '.'); i++ {
+			}
+		case c == '/' && regexAllowed(tokens):
+			tok.kind = jsRegex
+			i, err = scanJSRegex(source, i)
+		default:
+			tok.kind = jsPunct
+			i++
+			for _, p := range multiCharPuncts {
+				if strings.HasPrefix(source[start:], p) {
+					i = start + len(p)
+					break
+				}
+			}
+		}
+		if err != nil {
+			goto end
+		}
+
+		tok.end = i
+		tok.text = source[start:i]
+		newline = false
+
+		if tok.kind == jsPunct {
+			switch c {
+			case '(', '[', '{':
+				open = append(open, len(tokens))
+			case ')', ']', '}':
+				if len(open) == 0 || open[len(open)-1] < 0 || closerOf(tokens[open[len(open)-1]].text) != tok.text {
+					err = jsError(source, start, fmt.Sprintf("unexpected '%s'", tok.text))
+					goto end
+				}
+				tokens[open[len(open)-1]].match = len(tokens)
+				open = open[:len(open)-1]
+				tok.depth = len(open)
+			}
+		}
+		tokens = append(tokens, tok)
+	}
+
+	if len(open) > 0 {
+		if open[len(open)-1] < 0 {
+			err = jsError(source, -1-open[len(open)-1], "unterminated template literal")
+			goto end
+		}
+		opener := tokens[open[len(open)-1]]
+		err = jsError(source, opener.start, fmt.Sprintf("'%s' is never closed", opener.text))
+	}
+
+end:
+	return tokens, err
+}
+
+// scanJSString returns the offset just past the string literal starting at
+// offset i of source.
+func scanJSString(source string, i int) (end int, err error) {
+	quote := source[i]
+	for end = i + 1; end < len(source); end++ {
+		switch source[end] {
+		case '\\':
+			end++
+		case '\n':
+			return end, jsError(source, i, "unterminated string literal")
+		case quote:
+			return end + 1, nil
+		}
+	}
+	return end, jsError(source, i, "unterminated string literal")
+}
+
+// scanJSTemplate returns the offset just past the end of the template
+// literal that started at offset start of source, scanning from offset i,
+// or just past the "${" of its next substitution, which is then recorded in
+// open as an open bracket, by the literal's start.
+func scanJSTemplate(source string, start, i int, open *[]int) (end int, err error) {
+	for end = i; end < len(source); end++ {
+		switch {
+		case source[end] == '\\':
+			end++
+		case source[end] == '`':
+			return end + 1, nil
+		case strings.HasPrefix(source[end:], "${"):
+			*open = append(*open, -1-start)
+			return end + 2, nil
+		}
+	}
+	return end, jsError(source, start, "unterminated template literal")
+}
+
+// scanJSRegex returns the offset just past the regular expression literal,
+// including its flags, starting at offset i of source.
+func scanJSRegex(source string, i int) (end int, err error) {
+	var inClass bool
+
+	for end = i + 1; end < len(source); end++ {
+		switch source[end] {
+		case '\\':
+			end++
+		case '\n':
+			return end, jsError(source, i, "unterminated regular expression")
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				return scanJSIdent(source, end+1), nil
+			}
+		}
+	}
+	return end, jsError(source, i, "unterminated regular expression")
+}
+
+// scanJSIdent returns the offset just past the identifier characters
+// starting at offset i of source.
+func scanJSIdent(source string, i int) int {
+	for i < len(source) {
+		if source[i] < utf8.RuneSelf {
+			if !isJSIdentChar(source[i]) {
+				break
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(source[i:])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// isJSIdentStart reports whether s starts with a character that can start
+// an identifier.
+func isJSIdentStart(s string) bool {
+	if s == "" {
+		return false
+	}
+	if s[0] < utf8.RuneSelf {
+		return s[0] == '_' || s[0] == '$' || s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z'
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLetter(r)
+}
+
+// isJSIdentChar reports whether the ASCII character c can appear within an
+// identifier.
+func isJSIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// regexAllowed reports whether a slash following tokens starts a regular
+// expression, which it does where an expression may start, rather than a
+// division.
+func regexAllowed(tokens []jsToken) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	prev := tokens[len(tokens)-1]
+	switch prev.kind {
+	case jsIdent:
+		return regexKeywords[prev.text]
+	case jsPunct:
+		return prev.text != ")" && prev.text != "]" && prev.text != "++" && prev.text != "--"
+	}
+	return false
+}
+
+// closerOf returns the bracket that closes the opening bracket opener.
+func closerOf(opener string) string {
+	switch opener {
+	case "(":
+		return ")"
+	case "[":
+		return "]"
+	}
+	return "}"
+}
+
+// jsError returns an error for offset of source, reported by line and
+// column, both 1-based, with columns counted in characters.
+func jsError(source string, offset int, msg string) error {
+	line := strings.Count(source[:offset], "\n") + 1
+	column := utf8.RuneCountInString(source[strings.LastIndexByte(source[:offset], '\n')+1:offset]) + 1
+	return fmt.Errorf("line %d, column %d: %s", line, column, msg)
+}
@@ -0,0 +1,27 @@
+package javascript
+
+import (
+	"log/slog"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+var logger *slog.Logger
+
+func SetLogger(l *slog.Logger) {
+	logger = l
+	ensureLogger()
+}
+
+func ensureLogger() {
+	if logger == nil {
+		panic("Must set logger with javascript.SetLogger() before using javascript package")
+	}
+}
+
+func init() {
+	langutil.RegisterInitializerFunc(func(args langutil.Args) error {
+		SetLogger(args.Logger)
+		return nil
+	})
+}
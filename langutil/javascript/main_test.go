@@ -0,0 +1,17 @@
+// Package javascript_test provides test setup for the javascript package test suite.
+package javascript_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/javascript"
+	"github.com/mikeschinkel/scout-mcp/testutil"
+)
+
+// TestMain configures the javascript package logger, which ValidateSyntax
+// uses when Node.js is not installed, and then runs the tests.
+func TestMain(m *testing.M) {
+	javascript.SetLogger(testutil.NewTestLogger())
+	os.Exit(m.Run())
+}
@@ -30,6 +30,12 @@ const (
 	// The NoProcessor handles files of this type with minimal processing capabilities.
 	NoLanguage Language = "none"
 
+	// AutoLanguage requests that each file's language be detected from its
+	// extension rather than one language being applied to every file.
+	// ValidateFilesByExtension uses it to validate mixed-language file sets,
+	// skipping files whose language has no registered processor.
+	AutoLanguage Language = "auto"
+
 	// CLanguage represents the C programming language.
 	// Files with .c and .h extensions are typically detected as C language.
 	// Full AST support for C is not yet implemented.
@@ -53,12 +59,15 @@ const (
 
 	// JavasScriptLanguage represents the JavaScript programming language.
 	// Files with .js and .mjs extensions are detected as JavaScript.
-	// Full AST support for JavaScript is not yet implemented.
+	// Functions, classes, methods, imports and exports are supported by the
+	// javascript package's processor, which scans tokens rather than
+	// building a full AST.
 	JavasScriptLanguage Language = "javascript"
 
 	// PythonLanguage represents the Python programming language.
 	// Files with .py extensions are detected as Python language.
-	// Full AST support for Python is not yet implemented.
+	// Functions, methods and classes are supported by the python package's
+	// processor, which scans indentation rather than building a full AST.
 	PythonLanguage Language = "python"
 
 	// RustLanguage represents the Rust programming language.
@@ -68,7 +77,7 @@ const (
 
 	// TypeScriptLanguage represents the TypeScript programming language.
 	// Files with .ts extensions are detected as TypeScript.
-	// Full AST support for TypeScript is not yet implemented.
+	// It is supported as JavaScript is, by the javascript package.
 	TypeScriptLanguage Language = "typescript"
 
 	// MarkdownLanguage represents Markdown markup language.
@@ -21,6 +21,8 @@
 //
 // Currently supported languages include:
 //   - Go: Full AST support for functions, types, constants, variables, imports, and packages
+//   - Python: Functions, methods, and classes, found by an indentation-aware scanner
+//   - JavaScript and TypeScript: Functions, classes, methods, imports, and exports, found by a token scanner
 //   - Plain text: Basic support for files with no programming language structure
 //
 // Additional language processors can be registered using the RegisterProcessor function.
@@ -69,7 +71,10 @@ package langutil
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
+	"runtime"
+	"sync"
 )
 
 // Args contains initialization arguments for the langutil package.
@@ -149,28 +154,116 @@ type ValidationResult struct {
 // errors for comprehensive reporting.
 //
 // The language parameter applies to all files in the batch. If language detection is desired
-// on a per-file basis, use ValidateFileAs for each file individually.
+// on a per-file basis, use ValidateFilesByExtension.
 //
 // The function returns a ValidationResult for every input file path, allowing callers to
 // inspect both successful and failed validations. The aggregated error return value
 // contains all individual file errors joined together, or nil if all files validated successfully.
 //
-// This function is optimized for batch processing and will be more efficient than calling
-// ValidateFileAs repeatedly for large numbers of files.
-func ValidateFilesAs(filepaths []string, language Language) (results []ValidationResult, _ error) {
+// Files are validated concurrently as described for ValidateFiles.
+func ValidateFilesAs(filepaths []string, language Language) (results []ValidationResult, err error) {
+	results, _, err = ValidateFiles(filepaths, ValidateFilesArgs{Language: language})
+	return results, err
+}
+
+// ValidateFilesByExtension validates each file as the language detected from
+// its extension, for validating a mixed-language set of files such as a whole
+// repository. Files whose detected language has no registered processor are
+// not validated; they are returned in skipped rather than as failed results.
+//
+// Like ValidateFilesAs, it validates every file even if some fail, returning a
+// ValidationResult for each file validated and the individual file errors
+// joined together, or nil if all validated files are valid.
+func ValidateFilesByExtension(filepaths []string) (results []ValidationResult, skipped []string, err error) {
+	return ValidateFiles(filepaths, ValidateFilesArgs{Language: AutoLanguage})
+}
+
+// ValidateFilesArgs configures ValidateFiles.
+type ValidateFilesArgs struct {
+	Language Language // Language to validate every file as, or AutoLanguage to detect each file's from its extension
+	Workers  int      // Number of files to validate at once; zero selects runtime.GOMAXPROCS(0)
+}
+
+// ValidateFiles validates filepaths with a pool of args.Workers goroutines and
+// returns a ValidationResult for each file validated, in the order of
+// filepaths regardless of the order in which validations finish. With
+// AutoLanguage, files whose detected language has no registered processor
+// are returned in skipped instead. A file whose validation fails, or whose
+// processor panics, gets the failure as its result's Error without affecting
+// the other files. The returned error joins the individual file errors, or is
+// nil if every validated file is valid.
+func ValidateFiles(filepaths []string, args ValidateFilesArgs) (results []ValidationResult, skipped []string, _ error) {
+	var all []ValidationResult
+	var skip []bool
 	var errs []error
+	var jobs chan int
+	var wg sync.WaitGroup
+	var workers int
+
+	workers = args.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(filepaths))
+
+	all = make([]ValidationResult, len(filepaths))
+	skip = make([]bool, len(filepaths))
+	jobs = make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker writes only the elements for the indexes it receives
+			for i := range jobs {
+				all[i], skip[i] = validateFile(filepaths[i], args.Language)
+			}
+		}()
+	}
+	for i := range filepaths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
 	results = make([]ValidationResult, 0, len(filepaths))
-	// Validate each file
-	for _, fp := range filepaths {
-		lang, err := ValidateFileAs(fp, language)
-		errs = append(errs, err)
-
-		results = append(results, ValidationResult{
-			FilePath: fp,
-			Language: lang,
-			Error:    err,
-		})
+	skipped = make([]string, 0)
+	for i, result := range all {
+		if skip[i] {
+			skipped = append(skipped, result.FilePath)
+			continue
+		}
+		errs = append(errs, result.Error)
+		results = append(results, result)
+	}
+	return results, skipped, errors.Join(errs...)
+}
+
+// validateFile validates fp as language, or with AutoLanguage as the language
+// detected from its extension, reporting skip when that language has no
+// registered processor. A panic while validating is recovered and returned as
+// the result's Error.
+func validateFile(fp string, language Language) (result ValidationResult, skip bool) {
+	var err error
+
+	result.FilePath = fp
+	defer func() {
+		r := recover()
+		if r != nil {
+			result.Error = fmt.Errorf("panic validating %s: %v", fp, r)
+		}
+	}()
+
+	if language == AutoLanguage {
+		language = DetectLanguage(fp)
+		_, err = GetProcessor(language)
+		if err != nil {
+			skip = true
+			goto end
+		}
 	}
-	return results, errors.Join(errs...)
+
+	result.Language, result.Error = ValidateFileAs(fp, language)
+
+end:
+	return result, skip
 }
@@ -0,0 +1,63 @@
+package langutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panicLanguage is handled by panicProcessor, whose validation panics.
+const panicLanguage langutil.Language = "panic-test"
+
+// panicProcessor is a langutil.Processor whose ValidateSyntax panics on the
+// content "panic" and accepts anything else.
+type panicProcessor struct{}
+
+func (panicProcessor) Language() langutil.Language             { return panicLanguage }
+func (panicProcessor) SupportedPartTypes() []langutil.PartType { return nil }
+func (panicProcessor) FindPart(langutil.PartArgs) (*langutil.PartInfo, error) {
+	return nil, nil
+}
+func (panicProcessor) ReplacePart(langutil.PartArgs) (string, error) { return "", nil }
+func (panicProcessor) ValidateContent(langutil.PartArgs) error       { return nil }
+func (panicProcessor) ValidateSyntax(source string) error {
+	if source == "panic" {
+		panic("processor failure")
+	}
+	return nil
+}
+
+func TestValidateFiles_ShouldRecoverPanicsPerFile(t *testing.T) {
+	langutil.RegisterProcessor(panicProcessor{})
+
+	dir := t.TempDir()
+	files := make([]string, 0, 3)
+	for _, name := range []string{"a", "b", "c"} {
+		content := "ok"
+		if name == "b" {
+			content = "panic"
+		}
+		fp := filepath.Join(dir, name+".txt")
+		require.NoError(t, os.WriteFile(fp, []byte(content), 0644), "Should write %s", fp)
+		files = append(files, fp)
+	}
+
+	results, skipped, err := langutil.ValidateFiles(files, langutil.ValidateFilesArgs{
+		Language: panicLanguage,
+		Workers:  2,
+	})
+	require.Error(t, err, "Should report the panic as an error")
+	assert.Empty(t, skipped, "No file should be skipped")
+	require.Len(t, results, 3, "Should have a result per file")
+
+	for i, result := range results {
+		assert.Equal(t, files[i], result.FilePath, "Results should be in input order")
+	}
+	assert.NoError(t, results[0].Error, "a.txt should be valid")
+	assert.ErrorContains(t, results[1].Error, "processor failure", "b.txt should have the panic as its error")
+	assert.NoError(t, results[2].Error, "c.txt should be valid")
+}
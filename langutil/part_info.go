@@ -29,6 +29,10 @@ type PartInfo struct {
 	EndOffset   int    `json:"end_offset"`   // Byte offset where the construct ends (0-based, exclusive)
 	Content     string `json:"content"`      // The actual content of the construct including formatting
 	Found       bool   `json:"found"`        // Whether the construct was found (false indicates search failure)
+
+	// Name is the name the construct is found by, such as "*Server.Start"
+	// for a method. It is set by listing operations, which return many parts.
+	Name string `json:"name,omitempty"`
 }
 
 // PartArgs contains arguments for finding or replacing language constructs.
@@ -43,10 +47,14 @@ type PartInfo struct {
 // For FindPart operations:
 //   - Language, Content, PartType, PartName, and Filepath are required
 //   - NewContent is ignored
+//   - Line may be given instead of PartName to find the construct at a line
 //
 // For ReplacePart operations:
 //   - All fields are required
 //   - NewContent specifies the replacement text
+//   - SkipFormat optionally leaves the result unformatted
+//   - PreserveDoc optionally keeps the construct's doc comment
+//   - OrganizeImports optionally fixes the imports of the result
 //
 // # Content Requirements
 //
@@ -64,6 +72,19 @@ type PartArgs struct {
 	PartName   string   // Name of the specific construct to find (identifier name)
 	NewContent string   // New content to replace with (for replacement operations only)
 	Filepath   string   // Path to the source file (for error reporting and context)
+	Line       int      // Line (1-based) to find the enclosing construct at when PartName is empty
+
+	// PreserveDoc has ReplacePart keep the doc comment of a replaced
+	// function, type, constant or variable when NewContent has none.
+	PreserveDoc bool
+
+	// SkipFormat has ReplacePart return its result as spliced, where the
+	// language processor would otherwise format it canonically.
+	SkipFormat bool
+
+	// OrganizeImports has ReplacePart add and remove imports the replacement
+	// made necessary or unnecessary, where the language processor supports it.
+	OrganizeImports bool
 }
 
 // FindPart finds a language construct in source code using the appropriate language processor.
@@ -15,7 +15,10 @@ package langutil
 //
 // Common part types include:
 //   - "func" for functions and methods
+//   - "func_body" for just the statements of a function or method
 //   - "type" for type definitions (structs, interfaces, aliases)
+//   - "field" for individual struct fields
+//   - "method" for individual interface method signatures
 //   - "const" for constant declarations
 //   - "var" for variable declarations
 //   - "import" for import statements
@@ -19,6 +19,10 @@ var (
 	//
 	// The error message includes details about the unsupported language and lists available alternatives.
 	ErrLanguageNotSupported = errors.New("language/file type not supported")
+
+	// ErrFormattingNotSupported is returned by FormatSource when the language's
+	// processor does not implement Formatter.
+	ErrFormattingNotSupported = errors.New("formatting not supported")
 )
 
 // Processor represents a programming language handler that provides AST-based operations for a specific language.
@@ -154,6 +158,42 @@ type Processor interface {
 // during startup before concurrent access begins.
 var processors = make(map[string]Processor)
 
+// Formatter is implemented by processors that can rewrite source in their
+// language's canonical format, as gofmt does for Go. It is separate from
+// Processor because most languages have no formatter in the standard library,
+// so callers check for it with a type assertion or use FormatSource.
+type Formatter interface {
+	// Format returns source in the language's canonical format, or an error
+	// when source is not syntactically valid.
+	Format(source string) (string, error)
+}
+
+// FormatSource formats source with the formatter of the processor registered
+// for language. It returns an error wrapping ErrLanguageNotSupported when no
+// processor is registered, and ErrFormattingNotSupported when the processor
+// has no formatter.
+func FormatSource(language Language, source string) (formatted string, err error) {
+	var p Processor
+	var f Formatter
+	var ok bool
+
+	p, err = GetProcessor(language)
+	if err != nil {
+		goto end
+	}
+
+	f, ok = p.(Formatter)
+	if !ok {
+		err = fmt.Errorf("%w for language %s", ErrFormattingNotSupported, language)
+		goto end
+	}
+
+	formatted, err = f.Format(source)
+
+end:
+	return formatted, err
+}
+
 // RegisterProcessor registers a language handler in the global processor registry.
 // This function adds a new processor to the available set of language handlers,
 // making it available for use by GetProcessor and related functions. Registration
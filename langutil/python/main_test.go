@@ -0,0 +1,17 @@
+// Package python_test provides test setup for the python package test suite.
+package python_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/python"
+	"github.com/mikeschinkel/scout-mcp/testutil"
+)
+
+// TestMain configures the python package logger, which ValidateSyntax uses
+// when no Python interpreter is installed, and then runs the tests.
+func TestMain(m *testing.M) {
+	python.SetLogger(testutil.NewTestLogger())
+	os.Exit(m.Run())
+}
@@ -0,0 +1,324 @@
+// Package python provides the langutil processor for Python source code.
+//
+// Python's grammar is not available from the Go standard library, so the
+// processor finds functions, classes and methods with an indentation-aware
+// scanner that splits source into logical lines the way Python's tokenizer
+// does, honoring strings, brackets, comments and line continuations. Full
+// syntax validation is delegated to a Python interpreter when one is
+// installed.
+package python
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+)
+
+// Python language part type constants define the constructs PythonProcessor
+// can find and replace.
+const (
+	// FuncPythonPart represents function and method definitions, including
+	// async functions. Methods are named by their class and method name
+	// joined by a dot, such as "ClassName.method", and methods of nested
+	// classes by the full path, such as "Outer.Inner.method". Functions
+	// defined within other functions cannot be found.
+	FuncPythonPart langutil.PartType = "func"
+
+	// ClassPythonPart represents class definitions, named as for methods
+	// when nested within another class, such as "Outer.Inner".
+	ClassPythonPart langutil.PartType = "class"
+)
+
+// Compile-time verification that PythonProcessor implements the
+// langutil.Processor interface.
+var _ langutil.Processor = (*PythonProcessor)(nil)
+
+// PythonProcessor implements the langutil.Processor interface for Python
+// source code.
+//
+// # Part Ranges
+//
+// A function or class spans its decorators, its header and its body, up to
+// the last non-blank character of the body, so trailing comments and blank
+// lines are left in place. The range starts at the beginning of the first
+// line, so a part's content includes the indentation of a method or nested
+// class.
+//
+// # Indentation
+//
+// Replacement content may be written at any indentation, such as a method
+// written flush left. It is dedented and then indented to the column of the
+// construct it replaces, so content from FindPart can be edited and passed
+// back as is.
+//
+// # Registration
+//
+// The processor registers itself during package initialization, making
+// Python support available when the python package is imported.
+type PythonProcessor struct{}
+
+// init registers the PythonProcessor with the langutil processor registry.
+func init() {
+	langutil.RegisterProcessor(&PythonProcessor{})
+}
+
+// Language returns "python" to identify this processor as the Python
+// language handler.
+func (p *PythonProcessor) Language() langutil.Language {
+	return langutil.PythonLanguage
+}
+
+// SupportedPartTypes returns the Python constructs this processor can find
+// and replace: FuncPythonPart and ClassPythonPart.
+func (p *PythonProcessor) SupportedPartTypes() []langutil.PartType {
+	return []langutil.PartType{
+		FuncPythonPart,
+		ClassPythonPart,
+	}
+}
+
+// FindPart finds the function, method or class named args.PartName in
+// args.Content.
+//
+// When args.PartName is empty and args.Line is set, the innermost construct
+// of args.PartType whose range includes the line is found instead, and the
+// returned PartInfo's Name is set to the name it would be found by.
+//
+// Returns a PartInfo with Found=false if the construct is not found, and an
+// error if the source cannot be scanned or the part type is not supported.
+func (p *PythonProcessor) FindPart(args langutil.PartArgs) (pi *langutil.PartInfo, err error) {
+	var defs []pyDef
+	var kind string
+	var match *pyDef
+
+	pi = &langutil.PartInfo{Found: false}
+
+	kind, err = defKind(args.PartType)
+	if err != nil {
+		goto end
+	}
+
+	defs, err = scanPythonDefs(args.Content)
+	if err != nil {
+		err = fmt.Errorf("failed to parse Python file: %w", err)
+		goto end
+	}
+
+	for i, def := range defs {
+		switch {
+		case def.kind != kind:
+		case args.PartName != "":
+			if def.name == args.PartName && match == nil {
+				match = &defs[i]
+			}
+		case args.Line >= def.startLine && args.Line <= def.endLine:
+			// Definitions are in source order, so a later one containing the
+			// line is nested within an earlier one
+			match = &defs[i]
+		}
+	}
+	if match == nil {
+		goto end
+	}
+
+	pi = &langutil.PartInfo{
+		Found:       true,
+		StartLine:   match.startLine,
+		EndLine:     match.endLine,
+		StartOffset: match.start,
+		EndOffset:   match.end,
+		Content:     args.Content[match.start:match.end],
+	}
+	if args.PartName == "" {
+		pi.Name = match.name
+	}
+
+end:
+	return pi, err
+}
+
+// ReplacePart replaces the function, method or class found as FindPart
+// would with args.NewContent, indented to the column of the construct it
+// replaces, and returns the updated source.
+//
+// Returns an error if the construct is not found, args.NewContent is not a
+// single definition of the part type, or the result is not valid Python.
+func (p *PythonProcessor) ReplacePart(args langutil.PartArgs) (result string, err error) {
+	var partInfo *langutil.PartInfo
+	var indent string
+
+	partInfo, err = p.FindPart(args)
+	if err != nil {
+		goto end
+	}
+
+	if !partInfo.Found && args.PartName == "" && args.Line > 0 {
+		err = fmt.Errorf("no %s found at line %d", args.PartType, args.Line)
+		goto end
+	}
+
+	if !partInfo.Found {
+		err = fmt.Errorf("%s '%s' not found in file", args.PartType, args.PartName)
+		goto end
+	}
+
+	err = p.ValidateContent(langutil.PartArgs{
+		PartType: args.PartType,
+		Content:  args.NewContent,
+	})
+	if err != nil {
+		goto end
+	}
+
+	indent = partInfo.Content[:len(partInfo.Content)-len(strings.TrimLeft(partInfo.Content, " \t"))]
+	result = args.Content[:partInfo.StartOffset] + indentLines(dedent(args.NewContent), indent) + args.Content[partInfo.EndOffset:]
+
+	err = p.ValidateSyntax(result)
+	if err != nil {
+		err = fmt.Errorf("replacement resulted in invalid Python syntax: %w", err)
+	}
+
+end:
+	return result, err
+}
+
+// ValidateContent validates that args.Content, as replacement content, is
+// exactly one definition of args.PartType, optionally decorated: a function
+// for FuncPythonPart or a class for ClassPythonPart.
+func (p *PythonProcessor) ValidateContent(args langutil.PartArgs) (err error) {
+	var kind, content string
+	var defs []pyDef
+	var rest []pyLine
+
+	kind, err = defKind(args.PartType)
+	if err != nil {
+		goto end
+	}
+
+	content = dedent(args.Content)
+	defs, err = scanPythonDefs(content)
+	if err != nil {
+		err = fmt.Errorf("%s replacement cannot be parsed: %w", args.PartType, err)
+		goto end
+	}
+
+	if len(defs) > 0 {
+		rest, _ = scanPythonLines(content[defs[0].end:])
+	}
+	if len(defs) == 0 || defs[0].kind != kind || defs[0].start != 0 || len(rest) > 0 {
+		err = fmt.Errorf("%s replacement must be a single '%s' definition, got: %s", args.PartType, kind, content[:min(20, len(content))])
+	}
+
+end:
+	return err
+}
+
+// ValidateSyntax validates that source is syntactically valid Python by
+// compiling it with the python3 or python interpreter found on PATH, without
+// running it. If no interpreter is installed, a warning is logged and only
+// the checks of the processor's own scanner are made: that strings are
+// terminated and brackets are balanced. Invalid statements, such as a
+// misspelled keyword, then go undetected.
+func (p *PythonProcessor) ValidateSyntax(source string) (err error) {
+	var interpreter string
+	var cmd *exec.Cmd
+	var stderr bytes.Buffer
+
+	for _, name := range []string{"python3", "python"} {
+		interpreter, err = exec.LookPath(name)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		logger.Warn("No Python interpreter found on PATH; checking syntax with the indentation scanner only",
+			"interpreters", "python3, python",
+			"error", err)
+		_, err = scanPythonDefs(source)
+		goto end
+	}
+
+	cmd = exec.Command(interpreter, "-c", compileScript)
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err == nil {
+		goto end
+	}
+
+	if stderr.Len() > 0 {
+		err = errors.New(strings.TrimSpace(stderr.String()))
+	}
+
+end:
+	return err
+}
+
+// compileScript compiles the Python source on stdin and, for a syntax error,
+// writes its line and message to stderr and exits with a non-zero status.
+const compileScript = `import sys
+try:
+    compile(sys.stdin.read(), "<source>", "exec", dont_inherit=True)
+except (SyntaxError, ValueError) as e:
+    sys.stderr.write("line %s: %s" % (getattr(e, "lineno", "?"), getattr(e, "msg", e)))
+    sys.exit(1)
+`
+
+// defKind returns the definition keyword scanPythonDefs reports for the
+// constructs of partType.
+func defKind(partType langutil.PartType) (kind string, err error) {
+	switch partType {
+	case FuncPythonPart:
+		kind = "def"
+	case ClassPythonPart:
+		kind = "class"
+	default:
+		err = fmt.Errorf("unsupported part type for Python: %s", partType)
+	}
+	return kind, err
+}
+
+// dedent returns content without its surrounding blank lines and without the
+// indentation common to all of its non-blank lines.
+func dedent(content string) string {
+	var lines []string
+	var common string
+	var first = true
+
+	lines = strings.Split(strings.Trim(strings.ReplaceAll(content, "\r\n", "\n"), "\n"), "\n")
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		switch {
+		case first:
+			common, first = indent, false
+		default:
+			for !strings.HasPrefix(indent, common) {
+				common = common[:len(common)-1]
+			}
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, common)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines returns content with indent added to the start of each of its
+// non-blank lines.
+func indentLines(content, indent string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,289 @@
+package python_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/python"
+)
+
+const pythonContent = `"""Shapes module."""
+import math
+
+
+@dataclass
+class Circle:
+    radius: float = 1.0
+
+    def area(self):
+        return math.pi * self.radius ** 2
+
+    async def describe(self, label="""circle
+def not_a_function():"""):
+        text = (
+            label,
+            self.radius,
+        )
+        return text
+
+    class Unit:
+        def name(self):
+            return "cm"
+
+
+def helper(x):
+    def inner():
+        return x
+    return inner()  # not the end of helper
+`
+
+// TestPythonProcessor_FindPart verifies that functions, methods of nested
+// classes and classes are found with their decorators and full bodies, and
+// that definitions inside strings and nested functions are not.
+func TestPythonProcessor_FindPart(t *testing.T) {
+	tests := []struct {
+		name      string
+		partType  langutil.PartType
+		partName  string
+		wantFound bool
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "DecoratedClass",
+			partType:  python.ClassPythonPart,
+			partName:  "Circle",
+			wantFound: true,
+			wantStart: "@dataclass\nclass Circle:",
+			wantEnd:   `return "cm"`,
+		},
+		{
+			name:      "Method",
+			partType:  python.FuncPythonPart,
+			partName:  "Circle.area",
+			wantFound: true,
+			wantStart: "    def area(self):",
+			wantEnd:   "self.radius ** 2",
+		},
+		{
+			name:      "AsyncMethodWithBracketsAndTripleQuotes",
+			partType:  python.FuncPythonPart,
+			partName:  "Circle.describe",
+			wantFound: true,
+			wantStart: "    async def describe(",
+			wantEnd:   "return text",
+		},
+		{
+			name:      "NestedClassMethod",
+			partType:  python.FuncPythonPart,
+			partName:  "Circle.Unit.name",
+			wantFound: true,
+			wantStart: "        def name(self):",
+			wantEnd:   `return "cm"`,
+		},
+		{
+			name:      "FunctionWithNestedFunction",
+			partType:  python.FuncPythonPart,
+			partName:  "helper",
+			wantFound: true,
+			wantStart: "def helper(x):",
+			wantEnd:   "return inner()",
+		},
+		{
+			name:     "DefinitionInString",
+			partType: python.FuncPythonPart,
+			partName: "not_a_function",
+		},
+		{
+			name:     "FunctionInFunction",
+			partType: python.FuncPythonPart,
+			partName: "inner",
+		},
+		{
+			name:     "MethodWithoutClass",
+			partType: python.FuncPythonPart,
+			partName: "area",
+		},
+	}
+
+	p := &python.PythonProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pi, err := p.FindPart(langutil.PartArgs{
+				Language: langutil.PythonLanguage,
+				Content:  pythonContent,
+				PartType: tt.partType,
+				PartName: tt.partName,
+			})
+			if err != nil {
+				t.Fatalf("FindPart() unexpected error: %v", err)
+			}
+			if pi.Found != tt.wantFound {
+				t.Fatalf("FindPart() Found = %v, want %v", pi.Found, tt.wantFound)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if !strings.HasPrefix(pi.Content, tt.wantStart) || !strings.HasSuffix(pi.Content, tt.wantEnd) {
+				t.Errorf("FindPart() Content =\n%s\nwant it to start with %q and end with %q", pi.Content, tt.wantStart, tt.wantEnd)
+			}
+			if pi.Content != pythonContent[pi.StartOffset:pi.EndOffset] {
+				t.Errorf("FindPart() Content does not match offsets %d-%d", pi.StartOffset, pi.EndOffset)
+			}
+		})
+	}
+}
+
+// TestPythonProcessor_FindPart_ByLine verifies that a line finds the
+// innermost definition enclosing it, named as it would be found by name.
+func TestPythonProcessor_FindPart_ByLine(t *testing.T) {
+	p := &python.PythonProcessor{}
+	pi, err := p.FindPart(langutil.PartArgs{
+		Language: langutil.PythonLanguage,
+		Content:  pythonContent,
+		PartType: python.FuncPythonPart,
+		Line:     22,
+	})
+	if err != nil {
+		t.Fatalf("FindPart() unexpected error: %v", err)
+	}
+	if !pi.Found || pi.Name != "Circle.Unit.name" {
+		t.Errorf("FindPart() = Found %v, Name %q, want Found true, Name %q", pi.Found, pi.Name, "Circle.Unit.name")
+	}
+}
+
+// TestPythonProcessor_ReplacePart verifies that replacements are indented to
+// the replaced definition and that content which is not a single definition
+// of the part type is rejected.
+func TestPythonProcessor_ReplacePart(t *testing.T) {
+	const content = "class Greeter:\n" +
+		"    def greet(self):\n" +
+		"        return 'hello'\n" +
+		"\n" +
+		"    def leave(self):\n" +
+		"        return 'bye'\n"
+
+	tests := []struct {
+		name       string
+		partType   langutil.PartType
+		partName   string
+		newContent string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "FlushLeftMethod",
+			partType:   python.FuncPythonPart,
+			partName:   "Greeter.greet",
+			newContent: "def greet(self, name):\n    return 'hello ' + name\n",
+			want: "class Greeter:\n" +
+				"    def greet(self, name):\n" +
+				"        return 'hello ' + name\n" +
+				"\n" +
+				"    def leave(self):\n" +
+				"        return 'bye'\n",
+		},
+		{
+			name:       "AlreadyIndentedMethod",
+			partType:   python.FuncPythonPart,
+			partName:   "Greeter.leave",
+			newContent: "    @property\n    def leave(self):\n        return 'goodbye'",
+			want: "class Greeter:\n" +
+				"    def greet(self):\n" +
+				"        return 'hello'\n" +
+				"\n" +
+				"    @property\n" +
+				"    def leave(self):\n" +
+				"        return 'goodbye'\n",
+		},
+		{
+			name:       "ClassForFunction",
+			partType:   python.FuncPythonPart,
+			partName:   "Greeter.greet",
+			newContent: "class Greet:\n    pass",
+			wantErr:    "must be a single 'def' definition",
+		},
+		{
+			name:       "TwoFunctions",
+			partType:   python.FuncPythonPart,
+			partName:   "Greeter.greet",
+			newContent: "def greet(self):\n    pass\n\ndef other(self):\n    pass",
+			wantErr:    "must be a single 'def' definition",
+		},
+		{
+			name:       "InvalidSyntax",
+			partType:   python.FuncPythonPart,
+			partName:   "Greeter.greet",
+			newContent: "def greet(self)\n    return 'hello'",
+			wantErr:    "invalid Python syntax",
+		},
+		{
+			name:       "NotFound",
+			partType:   python.ClassPythonPart,
+			partName:   "Missing",
+			newContent: "class Missing:\n    pass",
+			wantErr:    "class 'Missing' not found in file",
+		},
+	}
+
+	p := &python.PythonProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.ReplacePart(langutil.PartArgs{
+				Language:   langutil.PythonLanguage,
+				Content:    content,
+				PartType:   tt.partType,
+				PartName:   tt.partName,
+				NewContent: tt.newContent,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReplacePart() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplacePart() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReplacePart() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPythonProcessor_ValidateSyntax verifies that valid source passes and
+// that syntax errors are reported.
+func TestPythonProcessor_ValidateSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{name: "Valid", source: "def f(x):\n    return [x,\n        x]\n"},
+		{name: "UnterminatedString", source: "x = 'abc\n", wantErr: true},
+		{name: "UnclosedBracket", source: "x = (1,\n", wantErr: true},
+	}
+
+	p := &python.PythonProcessor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.ValidateSyntax(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSyntax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Without an interpreter the scanner still catches these errors
+	for _, tt := range tests {
+		t.Run(tt.name+"_NoInterpreter", func(t *testing.T) {
+			t.Setenv("PATH", "")
+			err := p.ValidateSyntax(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSyntax() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
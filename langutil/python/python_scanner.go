@@ -0,0 +1,269 @@
+package python
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// pyLine is a logical line of Python source: one statement header or simple
+// statement, which may span several physical lines through brackets, triple
+// quoted strings or backslash continuations.
+type pyLine struct {
+	start     int    // Offset of the first character of the line's first physical line
+	end       int    // Offset just past the last non-blank character of the logical line
+	indent    int    // Column of the first non-blank character, with tabs to multiples of 8
+	text      string // Source of the logical line, without its indentation
+	startLine int    // 1-based line number of the first physical line
+	endLine   int    // 1-based line number of the last physical line
+}
+
+// pyDef is a function, method or class definition found in Python source.
+type pyDef struct {
+	kind      string // "def" or "class"
+	name      string // Qualified name, such as "ClassName.method"
+	indent    int    // Indentation of the def or class statement
+	start     int    // Offset of the first decorator, or of the statement without one
+	end       int    // Offset just past the last non-blank character of the body
+	startLine int
+	endLine   int
+}
+
+// scanPythonLines splits source into logical lines, skipping blank and
+// comment-only lines, as the Python tokenizer would.
+//
+// Returns an error for an unterminated string, an unclosed or unbalanced
+// bracket, or a backslash continuation at the end of the source, as those
+// leave the rest of the source impossible to split reliably.
+func scanPythonLines(source string) (lines []pyLine, err error) {
+	var closers []byte
+	var quote string
+	var line pyLine
+	var inLine bool
+	var lineNo, quoteLine int
+
+	lineNo = 1
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+
+		if quote != "" {
+			switch {
+			case c == '\\':
+				if i+1 < len(source) && source[i+1] == '\n' {
+					lineNo++
+				}
+				i++
+			case strings.HasPrefix(source[i:], quote):
+				i += len(quote) - 1
+				quote = ""
+				line.end = i + 1
+			case c == '\n':
+				if len(quote) == 1 {
+					err = fmt.Errorf("line %d: unterminated string literal", quoteLine)
+					goto end
+				}
+				lineNo++
+			}
+			continue
+		}
+
+		switch {
+		case c == '\n':
+			if inLine && len(closers) == 0 {
+				line.endLine = lineNo
+				lines = append(lines, line)
+				inLine = false
+			}
+			lineNo++
+			continue
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			continue
+		case c == '#':
+			for i+1 < len(source) && source[i+1] != '\n' {
+				i++
+			}
+			continue
+		case c == '\\' && i+1 < len(source) && source[i+1] == '\n':
+			if i+2 >= len(source) {
+				err = fmt.Errorf("line %d: unexpected end of source after line continuation", lineNo)
+				goto end
+			}
+			i++
+			lineNo++
+			continue
+		}
+
+		if !inLine {
+			inLine = true
+			line = pyLine{
+				start:     strings.LastIndexByte(source[:i], '\n') + 1,
+				indent:    indentWidth(source[strings.LastIndexByte(source[:i], '\n')+1 : i]),
+				startLine: lineNo,
+			}
+		}
+		line.end = i + 1
+
+		switch c {
+		case '\'', '"':
+			quote = string(c)
+			if strings.HasPrefix(source[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+				i += 2
+				line.end = i + 1
+			}
+			quoteLine = lineNo
+		case '(':
+			closers = append(closers, ')')
+		case '[':
+			closers = append(closers, ']')
+		case '{':
+			closers = append(closers, '}')
+		case ')', ']', '}':
+			if len(closers) == 0 || closers[len(closers)-1] != c {
+				err = fmt.Errorf("line %d: unmatched '%c'", lineNo, c)
+				goto end
+			}
+			closers = closers[:len(closers)-1]
+		}
+	}
+
+	switch {
+	case quote != "":
+		err = fmt.Errorf("line %d: unterminated string literal", quoteLine)
+	case len(closers) > 0:
+		err = fmt.Errorf("line %d: '%c' expected before end of source", lineNo, closers[len(closers)-1])
+	case inLine:
+		line.endLine = lineNo
+		lines = append(lines, line)
+	}
+
+end:
+	for i := range lines {
+		lines[i].text = strings.TrimLeft(source[lines[i].start:lines[i].end], " \t\f")
+	}
+	return lines, err
+}
+
+// indentWidth returns the column that the whitespace prefix ws reaches,
+// with tabs advancing to the next multiple of 8 as in Python's tokenizer.
+func indentWidth(ws string) (width int) {
+	for _, c := range ws {
+		switch c {
+		case '\t':
+			width += 8 - width%8
+		case '\f':
+			width = 0
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// scanPythonDefs returns the function, method and class definitions of
+// source in source order, each spanning its decorators, header and body.
+// Methods and nested classes are named after their enclosing classes, such
+// as "ClassName.method", while functions nested within functions are not
+// returned, as they cannot be named from outside their enclosing function.
+func scanPythonDefs(source string) (defs []pyDef, err error) {
+	var lines []pyLine
+	var scopes []pyDef
+	var decorator int
+
+	lines, err = scanPythonLines(source)
+	if err != nil {
+		goto end
+	}
+
+	decorator = -1
+	for i, line := range lines {
+		for len(scopes) > 0 && scopes[len(scopes)-1].indent >= line.indent {
+			scopes = scopes[:len(scopes)-1]
+		}
+
+		kind, name := defHeader(line.text)
+		if kind == "" {
+			if strings.HasPrefix(line.text, "@") {
+				if decorator < 0 || lines[decorator].indent != line.indent {
+					decorator = i
+				}
+				continue
+			}
+			decorator = -1
+			continue
+		}
+
+		def := pyDef{
+			kind:      kind,
+			name:      name,
+			indent:    line.indent,
+			start:     line.start,
+			startLine: line.startLine,
+			end:       line.end,
+			endLine:   line.endLine,
+		}
+		if decorator >= 0 && lines[decorator].indent == line.indent {
+			def.start = lines[decorator].start
+			def.startLine = lines[decorator].startLine
+		}
+		decorator = -1
+
+		for _, body := range lines[i+1:] {
+			if body.indent <= line.indent {
+				break
+			}
+			def.end = body.end
+			def.endLine = body.endLine
+		}
+
+		if len(scopes) > 0 {
+			parent := scopes[len(scopes)-1]
+			def.name = parent.name + "." + name
+			if parent.kind != "class" {
+				def.kind = ""
+			}
+		}
+		scopes = append(scopes, def)
+		if def.kind != "" {
+			defs = append(defs, def)
+		}
+	}
+
+end:
+	return defs, err
+}
+
+// defHeader returns "def" or "class" and the name defined if text is the
+// header of a function or class definition, or empty strings otherwise.
+// Async functions are returned as "def".
+func defHeader(text string) (kind, name string) {
+	switch {
+	case strings.HasPrefix(text, "async "):
+		rest := strings.TrimLeft(text[len("async"):], " \t")
+		if !strings.HasPrefix(rest, "def") {
+			return "", ""
+		}
+		kind, text = "def", rest[len("def"):]
+	case strings.HasPrefix(text, "def"):
+		kind, text = "def", text[len("def"):]
+	case strings.HasPrefix(text, "class"):
+		kind, text = "class", text[len("class"):]
+	default:
+		return "", ""
+	}
+
+	if text == "" || (text[0] != ' ' && text[0] != '\t') {
+		return "", ""
+	}
+	text = strings.TrimLeft(text, " \t")
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		end = len(text)
+	}
+	if end == 0 {
+		return "", ""
+	}
+	return kind, text[:end]
+}
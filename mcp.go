@@ -12,7 +12,9 @@ import (
 	"maps"
 	"os"
 
+	"github.com/mikeschinkel/scout-mcp/mcptools"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 )
 
 // MCPServer represents a Model Context Protocol server instance that provides
@@ -137,6 +139,7 @@ func (s *MCPServer) loadConfig(opts Opts) (config *Config, err error) {
 			Port:           ConfigPort,
 			AllowedOrigins: []string{"https://claude.ai", "https://*.anthropic.com"},
 		})
+		config.SetCommandLinePaths(opts.AdditionalPaths)
 	} else {
 		// Try to load config file
 		configFile, err = os.Open(configPath)
@@ -151,6 +154,7 @@ func (s *MCPServer) loadConfig(opts Opts) (config *Config, err error) {
 				AllowedPaths: opts.AdditionalPaths,
 				Port:         ConfigPort,
 			})
+			config.SetCommandLinePaths(opts.AdditionalPaths)
 		} else {
 			defer mustClose(configFile)
 
@@ -164,6 +168,11 @@ func (s *MCPServer) loadConfig(opts Opts) (config *Config, err error) {
 				goto end
 			}
 
+			err = config.RecordFileSettings(fileData)
+			if err != nil {
+				goto end
+			}
+
 			config.Reset()
 
 			// Combine config paths with additional paths
@@ -171,6 +180,7 @@ func (s *MCPServer) loadConfig(opts Opts) (config *Config, err error) {
 			allPaths = append(allPaths, config.AllowedPaths()...)
 			allPaths = append(allPaths, opts.AdditionalPaths...)
 			config.SetAllowedPaths(allPaths)
+			config.SetCommandLinePaths(opts.AdditionalPaths)
 		}
 	}
 
@@ -194,6 +204,43 @@ func (s *MCPServer) loadConfig(opts Opts) (config *Config, err error) {
 		goto end
 	}
 
+	err = mcputil.SetFileLockMode(config.FileLockMode())
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.SetSafeMode(config.SafeMode(), config.ConfirmableOperations())
+	if err != nil {
+		goto end
+	}
+
+	err = mcptools.SetSecretRules(config.SecretRules())
+	if err != nil {
+		goto end
+	}
+
+	err = mcptools.SetMaxFileSize(config.MaxFileSize())
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.SetEditBackups(scoutcfg.NewFileStore(AppName), config.EditBackupCount())
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.SetSessionTTL(config.SessionTTL(), config.MaxSessionTTL())
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.SetSessionStore(scoutcfg.NewFileStore(AppName))
+	if err != nil {
+		goto end
+	}
+
+	mcptools.SetLogRedaction(config.LogRedaction())
+
 end:
 	return config, err
 }
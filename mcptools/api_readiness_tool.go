@@ -0,0 +1,226 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*APIReadinessTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&APIReadinessTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "api_readiness",
+			Description: "Report the documentation and example coverage of each exported identifier in a Go package, with a readiness score from 0 to 100, sorted worst first for release readiness checks",
+			QuickHelp:   "Score exported Go identifiers on docs and examples",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go package directory, or a Go file whose package to check"),
+			},
+		}),
+	})
+}
+
+// APIReadinessTool reports how release-ready each exported identifier of a Go package is.
+type APIReadinessTool struct {
+	*mcputil.ToolBase
+}
+
+// APIReadinessResult describes the documentation status of one exported identifier.
+type APIReadinessResult struct {
+	File string `json:"file"`
+	golang.GoSymbol
+	HasDoc     bool `json:"has_doc"`
+	HasExample bool `json:"has_example"`
+	Score      int  `json:"score"`
+}
+
+// Handle processes the api_readiness tool request and returns each exported
+// identifier's readiness, worst first.
+func (t *APIReadinessTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var info os.FileInfo
+	var files []string
+	var exceptions []golang.DocException
+	var identifiers []APIReadinessResult
+	var parseErrors []string
+	var documented, withExamples, total int
+
+	logger.Info("Tool called", "tool", "api_readiness")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "api_readiness", "path", path)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		goto end
+	}
+	if !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+
+	// A package is a single directory, so subdirectories are never scanned
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  false,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	exceptions, err = golang.DocExceptions(ctx, &golang.DocsExceptionsArgs{
+		Path:      path,
+		Recursive: golang.DoNotRecurse,
+	})
+	if err != nil {
+		goto end
+	}
+
+	identifiers, parseErrors = apiReadiness(files, exceptions)
+
+	for _, id := range identifiers {
+		if id.HasDoc {
+			documented++
+		}
+		if id.HasExample {
+			withExamples++
+		}
+		total += id.Score
+	}
+	if len(identifiers) > 0 {
+		total /= len(identifiers)
+	}
+
+	logger.Info("Tool completed", "tool", "api_readiness",
+		"identifier_count", len(identifiers),
+		"documented_count", documented,
+		"example_count", withExamples)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":             path,
+		"identifiers":      identifiers,
+		"identifier_count": len(identifiers),
+		"documented_count": documented,
+		"example_count":    withExamples,
+		"readiness_score":  total,
+		"errors":           parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// apiReadiness scores the exported identifiers declared in the non-test files
+// of files. An identifier is documented unless exceptions reports it, and has
+// an example when a _test.go file declares an Example function named for it.
+// Files that fail to parse are reported in parseErrors and skipped.
+func apiReadiness(files []string, exceptions []golang.DocException) (identifiers []APIReadinessResult, parseErrors []string) {
+	var undocumented map[string]bool
+	var content []byte
+	var names []string
+	var exampleNames []string
+	var symbols []golang.GoSymbol
+	var err error
+
+	undocumented = make(map[string]bool)
+	for _, e := range exceptions {
+		switch e.Type {
+		case golang.FuncException, golang.TypeException, golang.ConstException, golang.VarException:
+			undocumented[fmt.Sprintf("%s:%d", filepath.Clean(e.File), e.Line)] = true
+		}
+	}
+
+	parseErrors = make([]string, 0)
+	exampleNames = make([]string, 0)
+	identifiers = make([]APIReadinessResult, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		if strings.HasSuffix(fp, "_test.go") {
+			names, err = golang.ParseExampleFuncNames(fp, content)
+			exampleNames = append(exampleNames, names...)
+		} else {
+			symbols, err = golang.ParseSymbols(fp, content)
+			for _, sym := range symbols {
+				if !isExportedSymbol(sym) {
+					continue
+				}
+				identifiers = append(identifiers, APIReadinessResult{
+					File:     fp,
+					GoSymbol: sym,
+					HasDoc:   !undocumented[fmt.Sprintf("%s:%d", filepath.Clean(fp), sym.Line)],
+				})
+			}
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+		}
+	}
+
+	for i := range identifiers {
+		identifiers[i].HasExample = identifiers[i].HasExampleFunc(exampleNames)
+		identifiers[i].Score = readinessScore(identifiers[i])
+	}
+
+	sort.SliceStable(identifiers, func(i, j int) bool {
+		if identifiers[i].Score != identifiers[j].Score {
+			return identifiers[i].Score < identifiers[j].Score
+		}
+		if identifiers[i].File != identifiers[j].File {
+			return identifiers[i].File < identifiers[j].File
+		}
+		return identifiers[i].Line < identifiers[j].Line
+	})
+
+	return identifiers, parseErrors
+}
+
+// isExportedSymbol reports whether sym is part of a package's public API: an
+// exported identifier that, for methods, also has an exported receiver.
+func isExportedSymbol(sym golang.GoSymbol) bool {
+	if !ast.IsExported(sym.Name) {
+		return false
+	}
+	return sym.Receiver == "" || ast.IsExported(sym.Receiver)
+}
+
+// readinessScore rates an identifier from 0 to 100. Functions, methods and
+// types earn half for a doc comment and half for an example; constants and
+// variables cannot have examples, so their doc comment is worth the full score.
+func readinessScore(id APIReadinessResult) (score int) {
+	switch id.Kind {
+	case golang.FuncGoPart, golang.TypeGoPart:
+		if id.HasDoc {
+			score += 50
+		}
+		if id.HasExample {
+			score += 50
+		}
+	default:
+		if id.HasDoc {
+			score = 100
+		}
+	}
+	return score
+}
@@ -0,0 +1,149 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const APIReadinessDirPrefix = "api-readiness-tool-test"
+
+// API readiness tool result type
+type APIReadinessResult struct {
+	Path            string                        `json:"path"`
+	Identifiers     []mcptools.APIReadinessResult `json:"identifiers"`
+	IdentifierCount int                           `json:"identifier_count"`
+	DocumentedCount int                           `json:"documented_count"`
+	ExampleCount    int                           `json:"example_count"`
+	ReadinessScore  int                           `json:"readiness_score"`
+	Errors          []string                      `json:"errors"`
+}
+
+type apiReadinessResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedOrder    []string
+	ExpectedScores   []int
+	ExpectedDocs     int
+	ExpectedExamples int
+}
+
+func requireAPIReadinessResult(t *testing.T, result *APIReadinessResult, err error, opts apiReadinessResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	names := make([]string, len(result.Identifiers))
+	scores := make([]int, len(result.Identifiers))
+	for i, id := range result.Identifiers {
+		names[i] = id.Name
+		if id.Receiver != "" {
+			names[i] = id.Receiver + "." + id.Name
+		}
+		scores[i] = id.Score
+	}
+	assert.Equal(t, opts.ExpectedOrder, names, "Identifiers should be sorted worst first")
+	assert.Equal(t, opts.ExpectedScores, scores, "Scores should match")
+	assert.Equal(t, len(opts.ExpectedOrder), result.IdentifierCount, "Identifier count should match")
+	assert.Equal(t, opts.ExpectedDocs, result.DocumentedCount, "Documented count should match")
+	assert.Equal(t, opts.ExpectedExamples, result.ExampleCount, "Example count should match")
+}
+
+const apiReadinessSource = `// Package shop sells things.
+package shop
+
+// Cart holds items.
+type Cart struct{}
+
+// Add adds an item to the cart.
+func (c *Cart) Add(item string) {}
+
+func (c *Cart) Total() int { return 0 }
+
+// NewCart returns an empty cart.
+func NewCart() *Cart { return &Cart{} }
+
+func Checkout() {}
+
+// MaxItems caps the cart size.
+const MaxItems = 10
+
+var DefaultCurrency = "USD"
+
+func helper() {}
+`
+
+const apiReadinessTestSource = `package shop_test
+
+func ExampleNewCart() {}
+
+func ExampleCart_Add_twice() {}
+
+func ExampleCart_Add_multiple() {}
+
+func ExampleCheckout_Bad() {}
+`
+
+func TestAPIReadinessTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("api_readiness")
+	require.NotNil(t, tool, "api_readiness tool should be registered")
+
+	t.Run("Package_ShouldScoreExportedIdentifiersWorstFirst", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(APIReadinessDirPrefix)
+		defer tf.Cleanup()
+		tf.AddFileFixture("shop.go", &fsfix.FileFixtureArgs{Content: apiReadinessSource})
+		tf.AddFileFixture("shop_test.go", &fsfix.FileFixtureArgs{Content: apiReadinessTestSource})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+		})
+
+		result, err := mcputil.GetToolResult[APIReadinessResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking API readiness")
+		requireAPIReadinessResult(t, result, err, apiReadinessResultOpts{
+			ExpectedOrder:    []string{"Cart.Total", "Checkout", "DefaultCurrency", "Cart", "Cart.Add", "NewCart", "MaxItems"},
+			ExpectedScores:   []int{0, 0, 0, 50, 100, 100, 100},
+			ExpectedDocs:     4,
+			ExpectedExamples: 2,
+		})
+		assert.Equal(t, 50, result.ReadinessScore, "Readiness should be the average score")
+	})
+
+	t.Run("MissingPath_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(APIReadinessDirPrefix)
+		defer tf.Cleanup()
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir() + "/missing",
+		})
+
+		result, err := mcputil.GetToolResult[APIReadinessResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for missing path")
+		requireAPIReadinessResult(t, result, err, apiReadinessResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "no such file",
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*AppendToFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&AppendToFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "append_to_file",
+			Description: "Append text to the end of a file without reading or rewriting it, such as to add entries to a log. The file is opened in append mode, so concurrent appends are not lost",
+			QuickHelp:   "Append text to the end of a file",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to append to"),
+				AppendContentProperty.Required(),
+				CreateProperty,
+			},
+		}),
+	})
+}
+
+// AppendToFileTool appends text to the end of a file.
+type AppendToFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the append_to_file tool request and appends the content
+// to the file.
+func (t *AppendToFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var content string
+	var create bool
+	var existed bool
+	var info os.FileInfo
+	var size int64
+	var op mcputil.FileOperation
+
+	logger.Info("Tool called", "tool", "append_to_file")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	content, err = AppendContentProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	create, err = CreateProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "append_to_file",
+		"path", path,
+		"content_length", len(content),
+		"create", create)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && create:
+		err = nil
+	case errors.Is(err, os.ErrNotExist):
+		err = fmt.Errorf("file does not exist: %s (set create to create it)", path)
+		goto end
+	case err != nil:
+		err = fmt.Errorf("error checking file: %v", err)
+		goto end
+	case info.IsDir():
+		err = fmt.Errorf("cannot append to directory: %s", path)
+		goto end
+	default:
+		existed = true
+	}
+
+	size, err = mcputil.AppendFile(ctx, t.Config(), path, content, create)
+	if err != nil {
+		err = fmt.Errorf("failed to append to file: %v", err)
+		goto end
+	}
+
+	op = mcputil.CreatedFileOp
+	if existed {
+		op = mcputil.UpdatedFileOp
+	}
+	recordFileChange(ctx, req, op, path)
+
+	logger.Info("Tool completed", "tool", "append_to_file",
+		"path", path,
+		"bytes_appended", len(content),
+		"size", size)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":        true,
+		"path":           path,
+		"bytes_appended": len(content),
+		"size":           size,
+		"created":        !existed,
+		"message":        fmt.Sprintf("Appended %d bytes to %s, now %d bytes", len(content), path, size),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,148 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const AppendToFileDirPrefix = "append-to-file-tool-test"
+
+// Append to file tool result type
+type AppendToFileResult struct {
+	Success       bool   `json:"success"`
+	Path          string `json:"path"`
+	BytesAppended int    `json:"bytes_appended"`
+	Size          int64  `json:"size"`
+	Created       bool   `json:"created"`
+	Message       string `json:"message"`
+}
+
+type appendToFileResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedContent  string
+	ExpectedCreated  bool
+}
+
+func requireAppendToFileResult(t *testing.T, result *AppendToFileResult, err error, opts appendToFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, int64(len(opts.ExpectedContent)), result.Size, "Size should match the resulting content")
+	assert.Equal(t, opts.ExpectedCreated, result.Created, "Created should match")
+	requireFileContent(t, result.Path, opts.ExpectedContent)
+}
+
+func TestAppendToFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("append_to_file")
+	require.NotNil(t, tool, "append_to_file tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	appendToFile := func(params mcputil.Params) (*AppendToFileResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[AppendToFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call append_to_file")
+	}
+
+	t.Run("ExistingFile_ShouldAppendContent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(AppendToFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("log-project", nil)
+		logFile := pf.AddFileFixture("app.log", &fsfix.FileFixtureArgs{
+			Content: "first entry\n",
+		})
+		setup(t, tf)
+
+		result, err := appendToFile(mcputil.Params{
+			"path":    logFile.Filepath,
+			"content": "second entry\n",
+		})
+		requireAppendToFileResult(t, result, err, appendToFileResultOpts{
+			ExpectedContent: "first entry\nsecond entry\n",
+		})
+		assert.Equal(t, len("second entry\n"), result.BytesAppended, "Bytes appended should match")
+	})
+
+	t.Run("MissingFile_WithoutCreate_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(AppendToFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("missing-project", nil)
+		setup(t, tf)
+
+		path := filepath.Join(pf.Dir(), "new.log")
+		result, err := appendToFile(mcputil.Params{
+			"path":    path,
+			"content": "entry\n",
+		})
+		requireAppendToFileResult(t, result, err, appendToFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "set create to create it",
+		})
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "File should not be created")
+	})
+
+	t.Run("MissingFile_WithCreate_ShouldCreateFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(AppendToFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("create-project", nil)
+		setup(t, tf)
+
+		result, err := appendToFile(mcputil.Params{
+			"path":    filepath.Join(pf.Dir(), "new.log"),
+			"content": "entry\n",
+			"create":  true,
+		})
+		requireAppendToFileResult(t, result, err, appendToFileResultOpts{
+			ExpectedContent: "entry\n",
+			ExpectedCreated: true,
+		})
+	})
+
+	t.Run("DryRun_ShouldNotAppend", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(AppendToFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("preview-project", nil)
+		logFile := pf.AddFileFixture("app.log", &fsfix.FileFixtureArgs{
+			Content: "first entry\n",
+		})
+		setup(t, tf)
+
+		result, err := appendToFile(mcputil.Params{
+			"path":    logFile.Filepath,
+			"content": "second entry\n",
+			"dry_run": true,
+		})
+		require.NoError(t, err, "Should preview the append")
+		assert.Equal(t, int64(len("first entry\nsecond entry\n")), result.Size, "Size should reflect the previewed append")
+		requireFileContent(t, logFile.Filepath, "first entry\n")
+	})
+}
@@ -0,0 +1,313 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ApplyHeaderTool)(nil)
+
+// HeaderAction describes what apply_header did to a file.
+type HeaderAction string
+
+const (
+	InsertedHeader  HeaderAction = "inserted"
+	UpdatedHeader   HeaderAction = "updated"
+	UnchangedHeader HeaderAction = "unchanged"
+	FailedHeader    HeaderAction = "failed"
+)
+
+func init() {
+	mcputil.RegisterTool(&ApplyHeaderTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "apply_header",
+			Description: "Ensure every file with the given extensions under a directory starts with a header such as a copyright or license notice. Inserts the header where missing, after any shebang line or Go build constraints, and replaces an outdated header containing the marker text. Go files are validated before being written. Reports the action taken for each file",
+			QuickHelp:   "Insert or update file headers across a tree",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Root directory of the files to update"),
+				ExtensionsProperty.Required().Description("File extensions to apply the header to (e.g., ['.go'])"),
+				HeaderTemplateProperty.Required(),
+				HeaderMarkerProperty,
+				RecursiveProperty,
+				ExcludeProperty.Description("Glob patterns of files or directories to exclude in addition to the defaults (e.g., ['testdata'])"),
+			},
+		}),
+	})
+}
+
+// ApplyHeaderTool inserts or updates a standard header at the top of files.
+type ApplyHeaderTool struct {
+	*mcputil.ToolBase
+}
+
+// HeaderFileResult describes the action apply_header took for one file.
+type HeaderFileResult struct {
+	File   string       `json:"file"`
+	Action HeaderAction `json:"action"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// Handle processes the apply_header tool request and applies the header to
+// each matching file.
+func (t *ApplyHeaderTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var extensions []string
+	var template string
+	var marker string
+	var recursive bool
+	var excludes []string
+	var header string
+	var files []string
+	var results []HeaderFileResult
+	var counts map[HeaderAction]int
+	var fr HeaderFileResult
+	var skipped bool
+	var binaryCount int
+
+	logger.Info("Tool called", "tool", "apply_header")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.Required().StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	template, err = HeaderTemplateProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	marker, err = HeaderMarkerProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "apply_header",
+		"path", path,
+		"extensions", extensions,
+		"marker", marker,
+		"recursive", recursive,
+		"exclude", excludes)
+
+	header = renderHeader(template, time.Now())
+	if header == "" {
+		err = fmt.Errorf("header_template must not be empty")
+		goto end
+	}
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: extensions,
+		Excludes:   append(golang.DefaultExcludes(), excludes...),
+	})
+	if err != nil {
+		goto end
+	}
+
+	results = make([]HeaderFileResult, 0, len(files))
+	counts = make(map[HeaderAction]int)
+	for _, fp := range files {
+		fr, skipped, err = t.applyHeaderToFile(ctx, req, fp, header, marker)
+		if err != nil {
+			goto end
+		}
+		if skipped {
+			binaryCount++
+			continue
+		}
+		counts[fr.Action]++
+		results = append(results, fr)
+	}
+
+	logger.Info("Tool completed", "tool", "apply_header",
+		"files_scanned", len(results),
+		"inserted_count", counts[InsertedHeader],
+		"updated_count", counts[UpdatedHeader],
+		"failed_count", counts[FailedHeader])
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":            path,
+		"files":           results,
+		"files_scanned":   len(results),
+		"binary_skipped":  binaryCount,
+		"inserted_count":  counts[InsertedHeader],
+		"updated_count":   counts[UpdatedHeader],
+		"unchanged_count": counts[UnchangedHeader],
+		"failed_count":    counts[FailedHeader],
+	})
+
+end:
+	return result, err
+}
+
+// applyHeaderToFile applies header to the file at fp, reporting skipped for
+// binary files. Go files whose result fails syntax validation are left
+// unchanged and reported as failed; only read and write errors are returned.
+func (t *ApplyHeaderTool) applyHeaderToFile(ctx context.Context, req mcputil.ToolRequest, fp, header, marker string) (fr HeaderFileResult, skipped bool, err error) {
+	var content []byte
+	var updated string
+	var writeErr error
+
+	fr.File = fp
+
+	content, err = os.ReadFile(fp)
+	if err != nil {
+		err = fmt.Errorf("failed to read %s: %v", fp, err)
+		goto end
+	}
+	if isBinaryContent(content) {
+		skipped = true
+		goto end
+	}
+
+	updated, fr.Action = applyHeader(string(content), header, marker)
+	if fr.Action == UnchangedHeader {
+		goto end
+	}
+
+	if langutil.DetectLanguage(fp) == langutil.GoLanguage {
+		writeErr = WriteFile(ctx, t.Config(), fp, updated)
+		if writeErr != nil {
+			fr.Action = FailedHeader
+			fr.Error = writeErr.Error()
+			goto end
+		}
+	} else {
+		err = mcputil.WriteFile(ctx, t.Config(), fp, updated)
+		if err != nil {
+			err = fmt.Errorf("failed to write %s: %v", fp, err)
+			goto end
+		}
+	}
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, fp)
+
+end:
+	return fr, skipped, err
+}
+
+// renderHeader expands the {year} placeholder in template and trims trailing
+// newlines, since applyHeader adds its own line breaks around the header.
+func renderHeader(template string, now time.Time) string {
+	template = strings.ReplaceAll(template, "{year}", strconv.Itoa(now.Year()))
+	return strings.TrimRight(template, "\r\n")
+}
+
+// applyHeader returns content with header at its top, after any shebang line
+// and Go build constraints. A leading comment block containing marker that
+// differs from header is replaced; otherwise header is inserted, separated
+// from the rest of the file by a blank line.
+func applyHeader(content, header, marker string) (updated string, action HeaderAction) {
+	var lines []string
+	var prefix, rest []string
+	var first, last int
+	var body string
+	var block string
+
+	lines = strings.SplitAfter(content, "\n")
+	prefix, rest = splitHeaderPrefix(lines)
+
+	first = 0
+	for first < len(rest) && strings.TrimSpace(rest[first]) == "" {
+		first++
+	}
+
+	body = strings.Join(rest[first:], "")
+	if body == header || strings.HasPrefix(body, header+"\n") {
+		updated = content
+		action = UnchangedHeader
+		goto end
+	}
+
+	last = first
+	for last < len(rest) && isHeaderCommentLine(rest[last]) {
+		last++
+	}
+	block = strings.Join(rest[first:last], "")
+
+	if marker != "" && strings.Contains(block, marker) {
+		updated = strings.Join(prefix, "") +
+			strings.Join(rest[:first], "") +
+			header + "\n" +
+			strings.Join(rest[last:], "")
+		action = UpdatedHeader
+		goto end
+	}
+
+	updated = strings.Join(prefix, "")
+	if len(prefix) > 0 {
+		if !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		updated += "\n"
+	}
+	updated += header + "\n"
+	if body != "" {
+		updated += "\n" + body
+	}
+	action = InsertedHeader
+
+end:
+	return updated, action
+}
+
+// splitHeaderPrefix splits lines into the leading shebang line and Go build
+// constraint lines that must stay above a header, and the lines that follow.
+func splitHeaderPrefix(lines []string) (prefix, rest []string) {
+	var start, n int
+	var trimmed string
+
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "#!") {
+		start = 1
+	}
+	n = start
+	for i := start; i < len(lines); i++ {
+		trimmed = strings.TrimSpace(lines[i])
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "//go:build") && !strings.HasPrefix(trimmed, "// +build") {
+			break
+		}
+		n = i + 1
+	}
+	return lines[:n], lines[n:]
+}
+
+// isHeaderCommentLine reports whether line is a comment line in one of the
+// common comment syntaxes a header may be written in.
+func isHeaderCommentLine(line string) bool {
+	line = strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "/*", "*", "#", "--", "<!--", ";"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ApplyHeaderDirPrefix = "apply-header-tool-test"
+
+// Apply header tool result type
+type ApplyHeaderResult struct {
+	Path           string                      `json:"path"`
+	Files          []mcptools.HeaderFileResult `json:"files"`
+	FilesScanned   int                         `json:"files_scanned"`
+	BinarySkipped  int                         `json:"binary_skipped"`
+	InsertedCount  int                         `json:"inserted_count"`
+	UpdatedCount   int                         `json:"updated_count"`
+	UnchangedCount int                         `json:"unchanged_count"`
+	FailedCount    int                         `json:"failed_count"`
+}
+
+type applyHeaderResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedScanned   int
+	ExpectedInserted  int
+	ExpectedUpdated   int
+	ExpectedUnchanged int
+	ExpectedFailed    int
+}
+
+func requireApplyHeaderResult(t *testing.T, result *ApplyHeaderResult, err error, opts applyHeaderResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedScanned, result.FilesScanned, "Files scanned should match")
+	assert.Len(t, result.Files, opts.ExpectedScanned, "Files list should match files scanned")
+	assert.Equal(t, opts.ExpectedInserted, result.InsertedCount, "Inserted count should match")
+	assert.Equal(t, opts.ExpectedUpdated, result.UpdatedCount, "Updated count should match")
+	assert.Equal(t, opts.ExpectedUnchanged, result.UnchangedCount, "Unchanged count should match")
+	assert.Equal(t, opts.ExpectedFailed, result.FailedCount, "Failed count should match")
+}
+
+func TestApplyHeaderTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("apply_header")
+	require.NotNil(t, tool, "apply_header tool should be registered")
+
+	const header = "// Copyright 2024 Acme Inc.\n// SPDX-License-Identifier: MIT\n"
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	readFile := func(t *testing.T, fp string) string {
+		content, err := os.ReadFile(fp)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("MixedFiles_ShouldInsertUpdateAndSkip", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ApplyHeaderDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("header-project", nil)
+		missing := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: "// Package main runs the app.\npackage main\n",
+		})
+		tagged := pf.AddFileFixture("linux.go", &fsfix.FileFixtureArgs{
+			Content: "//go:build linux\n\npackage main\n",
+		})
+		outdated := pf.AddFileFixture("util.go", &fsfix.FileFixtureArgs{
+			Content: "// Copyright 2019 Acme Inc.\n\npackage main\n",
+		})
+		current := pf.AddFileFixture("done.go", &fsfix.FileFixtureArgs{
+			Content: header + "\npackage main\n",
+		})
+		notes := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{Content: "not a source file\n"})
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"extensions":      []any{".go"},
+			"header_template": header,
+		})
+
+		result, err := mcputil.GetToolResult[ApplyHeaderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error applying headers")
+		requireApplyHeaderResult(t, result, err, applyHeaderResultOpts{
+			ExpectedScanned:   4,
+			ExpectedInserted:  2,
+			ExpectedUpdated:   1,
+			ExpectedUnchanged: 1,
+		})
+
+		assert.Equal(t, header+"\n// Package main runs the app.\npackage main\n", readFile(t, missing.Filepath), "Should insert the header above the package doc comment")
+		assert.Equal(t, "//go:build linux\n\n"+header+"\npackage main\n", readFile(t, tagged.Filepath), "Should insert the header after the build constraint")
+		assert.Equal(t, header+"\npackage main\n", readFile(t, outdated.Filepath), "Should replace the outdated header")
+		assert.Equal(t, header+"\npackage main\n", readFile(t, current.Filepath), "Should leave a current header alone")
+		assert.Equal(t, "not a source file\n", readFile(t, notes.Filepath), "Should only touch files with the given extensions")
+	})
+
+	t.Run("ShebangAndYear_ShouldInsertAfterShebang", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ApplyHeaderDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("script-project", nil)
+		script := pf.AddFileFixture("run.sh", &fsfix.FileFixtureArgs{
+			Content: "#!/bin/sh\necho hello\n",
+		})
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"extensions":      []any{".sh"},
+			"header_template": "# Copyright {year} Acme Inc.",
+		})
+
+		result, err := mcputil.GetToolResult[ApplyHeaderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error applying headers")
+		requireApplyHeaderResult(t, result, err, applyHeaderResultOpts{
+			ExpectedScanned:  1,
+			ExpectedInserted: 1,
+		})
+
+		content := readFile(t, script.Filepath)
+		assert.Regexp(t, `^#!/bin/sh\n\n# Copyright \d{4} Acme Inc\.\n\necho hello\n$`, content, "Should insert the header after the shebang with the year filled in")
+	})
+
+	t.Run("DryRun_ShouldPreviewWithoutWriting", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ApplyHeaderDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("preview-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"extensions":      []any{".go"},
+			"header_template": header,
+			"dry_run":         true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing apply_header")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, header+"\npackage main\n", result.Files[0].Content, "Preview should hold the file with its header")
+		assert.Equal(t, "package main\n", readFile(t, ff.Filepath), "Dry run should not modify the file")
+	})
+
+	t.Run("InvalidGoHeader_ShouldReportFailure", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ApplyHeaderDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("invalid-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"extensions":      []any{".go"},
+			"header_template": "Copyright 2024 Acme Inc.",
+		})
+
+		result, err := mcputil.GetToolResult[ApplyHeaderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error when a file fails validation")
+		requireApplyHeaderResult(t, result, err, applyHeaderResultOpts{
+			ExpectedScanned: 1,
+			ExpectedFailed:  1,
+		})
+		assert.Contains(t, result.Files[0].Error, "validation failed", "Should report why the file failed")
+		assert.Equal(t, "package main\n", readFile(t, ff.Filepath), "Should not write a file that fails validation")
+	})
+
+	t.Run("MissingExtensions_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ApplyHeaderDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            tf.TempDir(),
+			"header_template": header,
+		})
+
+		result, err := mcputil.GetToolResult[ApplyHeaderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error without extensions")
+		requireApplyHeaderResult(t, result, err, applyHeaderResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "extensions",
+		})
+	})
+}
@@ -0,0 +1,189 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckAllowedPathsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckAllowedPathsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_allowed_paths",
+			Description: "Check each configured allowed path and report whether it exists, is a directory, is readable and writable, and where it resolves to",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+			},
+		}),
+	})
+}
+
+// CheckAllowedPathsTool reports the runtime health of every configured allowed path.
+type CheckAllowedPathsTool struct {
+	*mcputil.ToolBase
+}
+
+// AllowedPathHealth describes the runtime state of a single allowed path.
+type AllowedPathHealth struct {
+	Path           string `json:"path"`                    // Allowed path as configured
+	ResolvedPath   string `json:"resolved_path,omitempty"` // Absolute path with symlinks resolved
+	IsSymlink      bool   `json:"is_symlink"`              // Whether the configured path is a symlink
+	Exists         bool   `json:"exists"`                  // Whether the path exists
+	IsDirectory    bool   `json:"is_directory"`            // Whether the path is a directory
+	Readable       bool   `json:"readable"`                // Whether the directory can be listed
+	Writable       bool   `json:"writable"`                // Whether the directory permits creating files
+	Healthy        bool   `json:"healthy"`                 // Whether all of the above checks passed
+	Error          string `json:"error,omitempty"`         // Problems found, if any
+	AllowedByCheck bool   `json:"allowed_by_check"`        // Whether IsAllowedPath accepts the path
+}
+
+var _ ToolResult = (*AllowedPathsHealthResult)(nil)
+
+// AllowedPathsHealthResult contains the health of all configured allowed paths.
+type AllowedPathsHealthResult struct {
+	Paths          []AllowedPathHealth `json:"paths"`
+	PathCount      int                 `json:"path_count"`
+	HealthyCount   int                 `json:"healthy_count"`
+	UnhealthyCount int                 `json:"unhealthy_count"`
+	Summary        string              `json:"summary"`
+}
+
+// ToolResult implements the ToolResult interface.
+func (r *AllowedPathsHealthResult) ToolResult() {
+}
+
+// Value returns the JSON representation of the allowed paths health result.
+func (r *AllowedPathsHealthResult) Value() string {
+	jsonData, _ := json.Marshal(r)
+	return string(jsonData)
+}
+
+// Handle processes the check_allowed_paths tool request and checks every allowed path.
+func (t *CheckAllowedPathsTool) Handle(_ context.Context, _ mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var allowedPaths []string
+	var healthResult AllowedPathsHealthResult
+	var health AllowedPathHealth
+
+	logger.Info("Tool called", "tool", "check_allowed_paths")
+
+	allowedPaths = t.Config().AllowedPaths()
+	healthResult.Paths = make([]AllowedPathHealth, 0, len(allowedPaths))
+
+	for _, path := range allowedPaths {
+		health = t.checkPath(path)
+		if health.Healthy {
+			healthResult.HealthyCount++
+		} else {
+			healthResult.UnhealthyCount++
+		}
+		healthResult.Paths = append(healthResult.Paths, health)
+	}
+
+	healthResult.PathCount = len(allowedPaths)
+	healthResult.Summary = fmt.Sprintf("%d of %d allowed path(s) healthy",
+		healthResult.HealthyCount,
+		healthResult.PathCount,
+	)
+
+	logger.Info("Tool completed", "tool", "check_allowed_paths",
+		"path_count", healthResult.PathCount,
+		"unhealthy_count", healthResult.UnhealthyCount,
+	)
+	result = mcputil.NewToolResultJSON(healthResult)
+
+	return result, err
+}
+
+// checkPath runs each health check against a single allowed path, stopping
+// when the path does not exist or is not a directory since later checks
+// depend on it. Readability and writability are both checked, and both
+// reported, either way.
+func (t *CheckAllowedPathsTool) checkPath(path string) (health AllowedPathHealth) {
+	var absPath string
+	var linkInfo os.FileInfo
+	var info os.FileInfo
+	var readErr error
+	var writeErr error
+	var err error
+
+	health.Path = path
+	health.AllowedByCheck = t.IsAllowedPath(path)
+
+	absPath, err = filepath.Abs(path)
+	if err != nil {
+		goto end
+	}
+
+	linkInfo, err = os.Lstat(absPath)
+	if err != nil {
+		goto end
+	}
+	health.IsSymlink = linkInfo.Mode()&os.ModeSymlink != 0
+
+	info, err = os.Stat(absPath)
+	if err != nil {
+		goto end
+	}
+	health.Exists = true
+
+	health.ResolvedPath, err = filepath.EvalSymlinks(absPath)
+	if err != nil {
+		goto end
+	}
+
+	health.IsDirectory = info.IsDir()
+	if !health.IsDirectory {
+		err = fmt.Errorf("allowed path is not a directory: %s", absPath)
+		goto end
+	}
+
+	readErr = checkDirReadable(absPath)
+	health.Readable = readErr == nil
+
+	writeErr = checkDirWritable(absPath)
+	health.Writable = writeErr == nil
+
+	err = errors.Join(readErr, writeErr)
+	if err != nil {
+		goto end
+	}
+
+	health.Healthy = health.AllowedByCheck
+	if !health.Healthy {
+		err = fmt.Errorf("allowed path is rejected by path validation: %s", absPath)
+	}
+
+end:
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// checkDirReadable verifies that the directory can be opened and listed.
+func checkDirReadable(dir string) (err error) {
+	var f *os.File
+
+	f, err = os.Open(dir)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(f)
+
+	_, err = f.Readdirnames(1)
+	if errors.Is(err, io.EOF) {
+		// Empty directories are still readable
+		err = nil
+	}
+
+end:
+	return err
+}
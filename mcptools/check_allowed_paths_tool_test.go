@@ -0,0 +1,137 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckAllowedPathsDirPrefix = "check-allowed-paths-tool-test"
+
+type allowedPathsHealthResultOpts struct {
+	ExpectedPathCount      int
+	ExpectedHealthyCount   int
+	ExpectedUnhealthyCount int
+}
+
+func requireAllowedPathsHealthResult(t *testing.T, result *mcptools.AllowedPathsHealthResult, err error, opts allowedPathsHealthResultOpts) {
+	t.Helper()
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedPathCount, result.PathCount, "Path count should match")
+	assert.Len(t, result.Paths, opts.ExpectedPathCount, "Should report every allowed path")
+	assert.Equal(t, opts.ExpectedHealthyCount, result.HealthyCount, "Healthy count should match")
+	assert.Equal(t, opts.ExpectedUnhealthyCount, result.UnhealthyCount, "Unhealthy count should match")
+	assert.NotEmpty(t, result.Summary, "Summary should not be empty")
+}
+
+func TestCheckAllowedPathsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_allowed_paths")
+	require.NotNil(t, tool, "check_allowed_paths tool should be registered")
+
+	t.Run("HealthyDirectory", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckAllowedPathsDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.AllowedPathsHealthResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking allowed paths")
+		requireAllowedPathsHealthResult(t, result, err, allowedPathsHealthResultOpts{
+			ExpectedPathCount:    1,
+			ExpectedHealthyCount: 1,
+		})
+
+		health := result.Paths[0]
+		assert.True(t, health.Exists, "Path should exist")
+		assert.True(t, health.IsDirectory, "Path should be a directory")
+		assert.True(t, health.Readable, "Path should be readable")
+		assert.True(t, health.Writable, "Path should be writable")
+		assert.NotEmpty(t, health.ResolvedPath, "Resolved path should be reported")
+		assert.Empty(t, health.Error, "Healthy path should not report an error")
+
+		entries, err := os.ReadDir(tf.TempDir())
+		require.NoError(t, err, "Should list allowed path")
+		assert.Empty(t, entries, "Checking should not write to the allowed path")
+	})
+
+	t.Run("ReadOnlyDirectory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write to read-only directories")
+		}
+		tf := fsfix.NewRootFixture(CheckAllowedPathsDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		require.NoError(t, os.Chmod(tf.TempDir(), 0555), "Should make directory read-only")
+		defer func() {
+			_ = os.Chmod(tf.TempDir(), 0755)
+		}()
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.AllowedPathsHealthResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking allowed paths")
+		requireAllowedPathsHealthResult(t, result, err, allowedPathsHealthResultOpts{
+			ExpectedPathCount:      1,
+			ExpectedUnhealthyCount: 1,
+		})
+
+		health := result.Paths[0]
+		assert.True(t, health.Readable, "Path should be readable")
+		assert.False(t, health.Writable, "Path should not be writable")
+		assert.NotEmpty(t, health.Error, "Read-only path should report an error")
+	})
+
+	t.Run("MissingAndFilePaths", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckAllowedPathsDirPrefix)
+		defer tf.Cleanup()
+
+		ff := tf.AddFileFixture("not-a-dir.txt", &fsfix.FileFixtureArgs{
+			Content: "content\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{
+				filepath.Join(tf.TempDir(), "missing"),
+				ff.Filepath,
+			},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.AllowedPathsHealthResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking allowed paths")
+		requireAllowedPathsHealthResult(t, result, err, allowedPathsHealthResultOpts{
+			ExpectedPathCount:      2,
+			ExpectedUnhealthyCount: 2,
+		})
+
+		assert.False(t, result.Paths[0].Exists, "Missing path should not exist")
+		assert.NotEmpty(t, result.Paths[0].Error, "Missing path should report an error")
+		assert.True(t, result.Paths[1].Exists, "File path should exist")
+		assert.False(t, result.Paths[1].IsDirectory, "File path should not be a directory")
+		assert.Contains(t, result.Paths[1].Error, "not a directory", "File path should report it is not a directory")
+	})
+}
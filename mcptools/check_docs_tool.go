@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -26,6 +28,10 @@ func init() {
 				RequiredPathProperty,
 				RequiredLanguageProperty,
 				RecursiveProperty,
+				ExportedOnlyProperty,
+				PerFilePackageDocProperty,
+				IssueTypesProperty,
+				OutputFormatProperty,
 			},
 		}),
 	})
@@ -40,11 +46,14 @@ const TargetCharLimit = 75000 // 75K provided safety margin for 25k tokens max
 
 // Handle processes the check_docs tool request and returns documentation analysis results.
 func (t *CheckDocsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
-	var recursive bool
+	var recursive, exportedOnly, perFilePackageDoc bool
+	var issueTypeNames []string
+	var issueTypes golang.DocExceptionType
 	var exceptions []golang.DocException
 	var path string
 	var analysisResult *DocsAnalysisResult
 	var language string
+	var format OutputFormat
 
 	logger.Info("Tool called", "tool", t.Name())
 
@@ -67,15 +76,49 @@ func (t *CheckDocsTool) Handle(_ context.Context, req mcputil.ToolRequest) (resu
 		goto end
 	}
 
+	exportedOnly, err = ExportedOnlyProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	perFilePackageDoc, err = PerFilePackageDocProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	issueTypeNames, err = IssueTypesProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid issue_types array: %v", err)
+		goto end
+	}
+
+	issueTypes, err = parseIssueTypes(issueTypeNames)
+	if err != nil {
+		goto end
+	}
+
+	format, err = getOutputFormat(req)
+	if err != nil {
+		goto end
+	}
+
 	// Get all documentation exceptions (without offset first)
 	exceptions, err = golang.DocExceptions(context.Background(), &golang.DocsExceptionsArgs{
-		Path:      path,
-		Recursive: golang.GetRecurseDirective(recursive),
+		Path:              path,
+		Recursive:         golang.GetRecurseDirective(recursive),
+		ExportedOnly:      exportedOnly,
+		PerFilePackageDoc: perFilePackageDoc,
 	})
 	if err != nil {
 		goto end
 	}
 
+	// Filter before sizing so the counts and summary cover only the
+	// requested issue types
+	if issueTypes != golang.InvalidDocException {
+		exceptions = filterExceptionsByType(exceptions, issueTypes)
+	}
+
 	// Apply intelligent response sizing and prioritization
 	analysisResult = t.createSizedAnalysisResult(path, exceptions)
 
@@ -86,6 +129,11 @@ func (t *CheckDocsTool) Handle(_ context.Context, req mcputil.ToolRequest) (resu
 		"size_limited", analysisResult.SizeLimited,
 		"response_size", analysisResult.ResponseSize)
 
+	if format == NDJSONOutput {
+		result = mcputil.NewToolResultNDJSON(analysisResult.Issues())
+		goto end
+	}
+
 	result = mcputil.NewToolResultJSON(analysisResult)
 
 end:
@@ -121,6 +169,16 @@ type DocsAnalysisResult struct {
 	Message        string           `json:"message,omitempty"`
 }
 
+// Issues returns the returned issues of every file group as a single flat
+// list, in the same order as IssuesByFile.
+func (r *DocsAnalysisResult) Issues() (issues []DocsAnalysisIssue) {
+	issues = make([]DocsAnalysisIssue, 0, r.ReturnedCount)
+	for _, group := range r.IssuesByFile {
+		issues = append(issues, group.Issues...)
+	}
+	return issues
+}
+
 type DocsAnalysisResultArgs struct {
 	Path         string
 	Exceptions   []golang.DocException
@@ -326,6 +384,49 @@ end:
 	return result
 }
 
+// docIssueTypes maps the names accepted in issue_types to the DocException
+// types they select. Missing const and var group comments are selected by
+// "group" and also by "const" or "var" respectively.
+var docIssueTypes = map[string]golang.DocExceptionType{
+	"readme":  golang.ReadmeException,
+	"package": golang.PackageException,
+	"file":    golang.FileException,
+	"func":    golang.FuncException,
+	"type":    golang.TypeException,
+	"const":   golang.ConstException,
+	"var":     golang.VarException,
+	"group":   golang.GroupException,
+}
+
+// parseIssueTypes returns the DocException types named by names combined
+// into a mask, or InvalidDocException when names is empty so that every type
+// is reported.
+func parseIssueTypes(names []string) (mask golang.DocExceptionType, err error) {
+	for _, name := range names {
+		issueType, ok := docIssueTypes[strings.ToLower(name)]
+		if !ok {
+			err = fmt.Errorf("invalid issue type '%s': must be one of %s", name, strings.Join(slices.Sorted(maps.Keys(docIssueTypes)), ", "))
+			goto end
+		}
+		mask |= issueType
+	}
+
+end:
+	return mask, err
+}
+
+// filterExceptionsByType returns the exceptions whose type shares a bit with
+// mask, keeping their order.
+func filterExceptionsByType(exceptions []golang.DocException, mask golang.DocExceptionType) (filtered []golang.DocException) {
+	filtered = make([]golang.DocException, 0, len(exceptions))
+	for _, exception := range exceptions {
+		if exception.Type&mask != 0 {
+			filtered = append(filtered, exception)
+		}
+	}
+	return filtered
+}
+
 // getIssueSeverity returns priority level for different exception types
 func getIssueSeverity(docType golang.DocExceptionType) int {
 	switch docType {
@@ -333,7 +434,7 @@ func getIssueSeverity(docType golang.DocExceptionType) int {
 		golang.ConstException, golang.VarException,
 		golang.GroupException:
 		return 1 // High priority
-	case golang.FileException:
+	case golang.FileException, golang.PackageException:
 		return 2 // Medium priority
 	case golang.ReadmeException:
 		return 3 // Low priority
@@ -294,6 +294,118 @@ type Config struct {
 		})
 	})
 
+	t.Run("ExportedOnly_ShouldSkipUnexportedIdentifiers", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckDocsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("exported-only-project", nil)
+
+		pf.AddFileFixture("mixed.go", &fsfix.FileFixtureArgs{
+			Content: `package main
+
+func main() {}
+
+func helper() {}
+
+type Config struct {
+	Port string
+}
+`,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"language":      "go",
+			"exported_only": true,
+		})
+
+		result, err := mcputil.GetToolResult[CheckDocsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error analyzing Go file")
+
+		requireCheckDocsResult(t, result, err, checkDocsResultOpts{
+			ExpectValidStructure: true,
+			ExpectedPath:         pf.Dir(),
+			ExpectedIssueCount:   2, // Missing package comment and type comment, but not main or helper
+		})
+	})
+
+	t.Run("IssueTypes_ShouldReturnOnlyRequestedTypes", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckDocsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("issue-types-project", nil)
+
+		pf.AddFileFixture("undocumented.go", &fsfix.FileFixtureArgs{
+			Content: `package main
+
+func main() {}
+
+func helper() {}
+
+type Config struct{}
+
+var (
+	Debug = false
+)
+`,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"language":      "go",
+			"issue_types":   []any{"func", "var"},
+		})
+
+		result, err := mcputil.GetToolResult[CheckDocsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error filtering issues")
+
+		requireCheckDocsResult(t, result, err, checkDocsResultOpts{
+			ExpectValidStructure: true,
+			ExpectedPath:         pf.Dir(),
+			ExpectedIssueCount:   4, // main, helper, the var group and Debug, but not the package or Config
+		})
+		require.Len(t, result.Summary.FilesByIssueCount, 1, "Summary should list the one file")
+		assert.Equal(t, 4, result.Summary.FilesByIssueCount[0].IssueCount, "Summary should count only the filtered issues")
+		for _, issue := range result.IssuesByFile[0].Issues {
+			assert.NotContains(t, issue.Issue, "type", "Type issues should be filtered out")
+			assert.NotContains(t, issue.Issue, "package", "Package issues should be filtered out")
+		}
+	})
+
+	t.Run("IssueTypes_InvalidType_ShouldFail", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckDocsDirPrefix)
+		defer tf.Cleanup()
+		tf.Setup(t)
+
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"language":      "go",
+			"issue_types":   []any{"method"},
+		})
+
+		result, err := mcputil.GetToolResult[CheckDocsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject unknown issue type")
+
+		requireCheckDocsResult(t, result, err, checkDocsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid issue type 'method'",
+		})
+	})
+
 	t.Run("ValidDirectory_ShouldAnalyzeAllFiles", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(CheckDocsDirPrefix)
 		defer tf.Cleanup()
@@ -339,8 +451,50 @@ func UndocumentedFunc() {
 		requireCheckDocsResult(t, result, err, checkDocsResultOpts{
 			ExpectValidStructure: true,
 			ExpectedPath:         pf.Dir(),
-			ExpectedIssueCount:   2, // undocumented.go: missing file comment + missing func comment
+			ExpectedIssueCount:   1, // undocumented.go: missing func comment; documented.go documents the package
+		})
+	})
+
+	t.Run("PerFilePackageDoc_ShouldReportEachFileWithoutPackageComment", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckDocsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("per-file-project", nil)
+
+		pf.AddFileFixture("doc.go", &fsfix.FileFixtureArgs{
+			Content: `// Package main provides documented functionality.
+package main
+`,
+		})
+		pf.AddFileFixture("undocumented.go", &fsfix.FileFixtureArgs{
+			Content: `package main
+
+// UndocumentedFile has a doc comment but its file has no package comment.
+func UndocumentedFile() {}
+`,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":        testToken,
+			"path":                 pf.Dir(),
+			"language":             "go",
+			"recursive":            false,
+			"per_file_package_doc": true,
+		})
+
+		result, err := mcputil.GetToolResult[CheckDocsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error analyzing directory")
+
+		requireCheckDocsResult(t, result, err, checkDocsResultOpts{
+			ExpectValidStructure: true,
+			ExpectedPath:         pf.Dir(),
+			ExpectedIssueCount:   1, // undocumented.go: missing file comment
 		})
+		assert.Equal(t, "undocumented.go", result.IssuesByFile[0].File, "Issue should be reported for the file itself")
 	})
 
 	t.Run("ParameterCombinations_RecursiveTrue_ShouldFindNestedFiles", func(t *testing.T) {
@@ -0,0 +1,134 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckGoModuleTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckGoModuleTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_go_module",
+			Description: "Validate a go.mod or go.work file: report syntax errors with line numbers, list the module path, Go version, requires and replaces, flag duplicate requires, and return the file pretty-printed",
+			QuickHelp:   "Validate and pretty-print go.mod/go.work",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("go.mod or go.work file, or a directory in which to find the nearest go.mod"),
+			},
+		}),
+	})
+}
+
+// CheckGoModuleTool validates and pretty-prints Go module files.
+type CheckGoModuleTool struct {
+	*mcputil.ToolBase
+}
+
+// GoModuleCheckResult is the parsed module file along with its validity and
+// formatted content.
+type GoModuleCheckResult struct {
+	Path string `json:"path"`
+	*golang.GoModFile
+	Valid           bool   `json:"valid"`
+	NeedsFormatting bool   `json:"needs_formatting"`
+	Formatted       string `json:"formatted,omitempty"`
+}
+
+// Handle processes the check_go_module tool request and returns the parsed module file.
+func (t *CheckGoModuleTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var content string
+	var mf *golang.GoModFile
+	var r GoModuleCheckResult
+
+	logger.Info("Tool called", "tool", "check_go_module")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "check_go_module", "path", path)
+
+	path, err = resolveGoModulePath(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	mf = golang.ParseGoModFile(path, []byte(content))
+	r = GoModuleCheckResult{
+		Path:      path,
+		GoModFile: mf,
+		Valid:     len(mf.SyntaxErrors) == 0 && len(mf.Issues) == 0,
+	}
+	if len(mf.SyntaxErrors) == 0 {
+		r.Formatted = mf.Format()
+		r.NeedsFormatting = r.Formatted != content
+	}
+
+	logger.Info("Tool completed", "tool", "check_go_module",
+		"path", path,
+		"syntax_errors", len(mf.SyntaxErrors),
+		"issues", len(mf.Issues))
+
+	result = mcputil.NewToolResultJSON(r)
+
+end:
+	return result, err
+}
+
+// resolveGoModulePath returns path if it names a go.mod or go.work file, or
+// the nearest go.mod in path or its parents if path is a directory. Paths
+// outside cfg's allowed paths are rejected before they are looked at.
+func resolveGoModulePath(cfg mcputil.Config, path string) (modPath string, err error) {
+	var info os.FileInfo
+	var modDir string
+
+	if !cfg.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		goto end
+	}
+
+	if !info.IsDir() {
+		if golang.GoModFileKindOf(path) == "" {
+			err = fmt.Errorf("check_go_module requires a go.mod or go.work file, got %s", filepath.Base(path))
+			goto end
+		}
+		modPath = path
+		goto end
+	}
+
+	// A go.mod without a module directive is still found, and reported by the parser
+	_, modDir, err = golang.FindModulePath(path)
+	if modDir != "" {
+		err = nil
+	}
+	if err != nil {
+		goto end
+	}
+	if modDir == "" {
+		err = fmt.Errorf("no go.mod found in %s or its parents", path)
+		goto end
+	}
+	modPath = filepath.Join(modDir, "go.mod")
+
+end:
+	return modPath, err
+}
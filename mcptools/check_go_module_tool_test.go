@@ -0,0 +1,289 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckGoModuleDirPrefix = "check-go-module-tool-test"
+
+type goModuleCheckResultOpts struct {
+	ExpectError         bool
+	ExpectedErrorMsg    string
+	ExpectedKind        string
+	ExpectedModule      string
+	ExpectedGoVersion   string
+	ExpectedRequires    []string
+	ExpectedIndirect    []string
+	ExpectedUses        []string
+	ExpectedSyntaxLines []int
+	ExpectedIssueLines  []int
+	ExpectValid         bool
+	ExpectNeedsFormat   bool
+	ExpectedFormatted   string
+}
+
+func requireGoModuleCheckResult(t *testing.T, result *mcptools.GoModuleCheckResult, err error, opts goModuleCheckResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.NotNil(t, result.GoModFile, "Parsed file should not be nil")
+
+	assert.Equal(t, opts.ExpectedKind, string(result.Kind), "Kind should match")
+	assert.Equal(t, opts.ExpectedModule, result.Module, "Module path should match")
+	assert.Equal(t, opts.ExpectedGoVersion, result.GoVersion, "Go version should match")
+
+	requires := make([]string, 0)
+	indirect := make([]string, 0)
+	for _, r := range result.Requires {
+		requires = append(requires, r.Path+" "+r.Version)
+		if r.Indirect {
+			indirect = append(indirect, r.Path)
+		}
+	}
+	assert.Equal(t, opts.ExpectedRequires, requires, "Requires should match")
+	assert.Equal(t, opts.ExpectedIndirect, indirect, "Indirect requires should match")
+
+	if opts.ExpectedUses != nil {
+		uses := make([]string, len(result.Uses))
+		for i, u := range result.Uses {
+			uses[i] = u.Path
+		}
+		assert.Equal(t, opts.ExpectedUses, uses, "Uses should match")
+	}
+
+	syntaxLines := make([]int, len(result.SyntaxErrors))
+	for i, p := range result.SyntaxErrors {
+		syntaxLines[i] = p.Line
+	}
+	assert.Equal(t, opts.ExpectedSyntaxLines, syntaxLines, "Syntax error lines should match")
+
+	issueLines := make([]int, len(result.Issues))
+	for i, p := range result.Issues {
+		issueLines[i] = p.Line
+	}
+	assert.Equal(t, opts.ExpectedIssueLines, issueLines, "Issue lines should match")
+
+	assert.Equal(t, opts.ExpectValid, result.Valid, "Valid flag should match")
+	assert.Equal(t, opts.ExpectNeedsFormat, result.NeedsFormatting, "Needs formatting flag should match")
+	if opts.ExpectedFormatted != "" {
+		assert.Equal(t, opts.ExpectedFormatted, result.Formatted, "Formatted content should match")
+	}
+}
+
+const checkGoModuleValid = "module example.com/app\n" +
+	"\n" +
+	"go 1.24.0\n" +
+	"\n" +
+	"require (\n" +
+	"\tgithub.com/pkg/errors v0.9.1\n" +
+	"\tgolang.org/x/text v0.28.0 // indirect\n" +
+	")\n" +
+	"\n" +
+	"replace github.com/pkg/errors => ../errors\n"
+
+func TestCheckGoModuleTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_go_module")
+	require.NotNil(t, tool, "check_go_module tool should be registered")
+
+	setup := func(t *testing.T, name, content string) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(CheckGoModuleDirPrefix)
+		ff := tf.AddFileFixture(name, &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	checkGoModule := func(path string) (*mcptools.GoModuleCheckResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+		})
+		return mcputil.GetToolResult[mcptools.GoModuleCheckResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking go module")
+	}
+
+	t.Run("ValidGoMod", func(t *testing.T) {
+		tf, ff := setup(t, "go.mod", checkGoModuleValid)
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedGoVersion:   "1.24.0",
+			ExpectedRequires:    []string{"github.com/pkg/errors v0.9.1", "golang.org/x/text v0.28.0"},
+			ExpectedIndirect:    []string{"golang.org/x/text"},
+			ExpectedSyntaxLines: []int{},
+			ExpectedIssueLines:  []int{},
+			ExpectValid:         true,
+			ExpectedFormatted:   checkGoModuleValid,
+		})
+		require.Len(t, result.Replaces, 1, "Should have one replace")
+		assert.Equal(t, "../errors", result.Replaces[0].New, "Replacement should match")
+	})
+
+	t.Run("Directory_ShouldFindGoMod", func(t *testing.T) {
+		tf, _ := setup(t, "go.mod", checkGoModuleValid)
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(tf.TempDir())
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedGoVersion:   "1.24.0",
+			ExpectedRequires:    []string{"github.com/pkg/errors v0.9.1", "golang.org/x/text v0.28.0"},
+			ExpectedIndirect:    []string{"golang.org/x/text"},
+			ExpectedSyntaxLines: []int{},
+			ExpectedIssueLines:  []int{},
+			ExpectValid:         true,
+		})
+	})
+
+	t.Run("DuplicateRequire_ShouldReportIssue", func(t *testing.T) {
+		tf, ff := setup(t, "go.mod", "module   example.com/app\n"+
+			"go 1.24.0\n"+
+			"\n"+
+			"\n"+
+			"require github.com/pkg/errors v0.9.1\n"+
+			"require (\n"+
+			"    github.com/pkg/errors    v0.8.0\n"+
+			")\n")
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedGoVersion:   "1.24.0",
+			ExpectedRequires:    []string{"github.com/pkg/errors v0.9.1", "github.com/pkg/errors v0.8.0"},
+			ExpectedIndirect:    []string{},
+			ExpectedSyntaxLines: []int{},
+			ExpectedIssueLines:  []int{7},
+			ExpectNeedsFormat:   true,
+			ExpectedFormatted: "module example.com/app\n" +
+				"\n" +
+				"go 1.24.0\n" +
+				"\n" +
+				"require github.com/pkg/errors v0.9.1\n" +
+				"\n" +
+				"require (\n" +
+				"\tgithub.com/pkg/errors v0.8.0\n" +
+				")\n",
+		})
+		assert.Contains(t, result.Issues[0].Message, "first required at line 5", "Issue should point at the first require")
+	})
+
+	t.Run("SyntaxErrors_ShouldReportLines", func(t *testing.T) {
+		tf, ff := setup(t, "go.mod", "module example.com/app\n"+
+			"go one.two\n"+
+			"require github.com/pkg/errors 0.9.1\n"+
+			"frobnicate\n")
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedRequires:    []string{},
+			ExpectedIndirect:    []string{},
+			ExpectedSyntaxLines: []int{2, 3, 4},
+			ExpectedIssueLines:  []int{},
+		})
+		assert.Contains(t, result.SyntaxErrors[2].Message, "unknown directive: frobnicate", "Unknown directive should be reported")
+		assert.Empty(t, result.Formatted, "Files with syntax errors should not be formatted")
+	})
+
+	t.Run("UnterminatedBlock_ShouldReportLine", func(t *testing.T) {
+		tf, ff := setup(t, "go.mod", "module example.com/app\n"+
+			"require (\n")
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedRequires:    []string{},
+			ExpectedIndirect:    []string{},
+			ExpectedSyntaxLines: []int{3},
+			ExpectedIssueLines:  []int{},
+		})
+		assert.Contains(t, result.SyntaxErrors[0].Message, "unterminated block", "Unterminated block should be reported")
+	})
+
+	t.Run("NewerDirectives_ShouldParse", func(t *testing.T) {
+		content := "module example.com/app\n" +
+			"\n" +
+			"go 1.24.0\n" +
+			"\n" +
+			"godebug default=go1.21\n" +
+			"\n" +
+			"tool example.com/app/cmd/gen\n" +
+			"\n" +
+			"retract [v1.0.0, v1.0.5] // broken\n"
+		tf, ff := setup(t, "go.mod", content)
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.mod",
+			ExpectedModule:      "example.com/app",
+			ExpectedGoVersion:   "1.24.0",
+			ExpectedRequires:    []string{},
+			ExpectedIndirect:    []string{},
+			ExpectedSyntaxLines: []int{},
+			ExpectedIssueLines:  []int{},
+			ExpectValid:         true,
+			ExpectedFormatted:   content,
+		})
+	})
+
+	t.Run("GoWork_ShouldListUses", func(t *testing.T) {
+		tf, ff := setup(t, "go.work", "go 1.24.0\n"+
+			"\n"+
+			"use (\n"+
+			"\t.\n"+
+			"\t./test\n"+
+			"\t./test/\n"+
+			")\n")
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectedKind:        "go.work",
+			ExpectedGoVersion:   "1.24.0",
+			ExpectedRequires:    []string{},
+			ExpectedIndirect:    []string{},
+			ExpectedUses:        []string{".", "./test", "./test/"},
+			ExpectedSyntaxLines: []int{},
+			ExpectedIssueLines:  []int{6},
+		})
+	})
+
+	t.Run("NotModuleFile_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "main.go", "package main\n")
+		defer tf.Cleanup()
+
+		result, err := checkGoModule(ff.Filepath)
+		requireGoModuleCheckResult(t, result, err, goModuleCheckResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "requires a go.mod or go.work file",
+		})
+	})
+}
@@ -0,0 +1,146 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckGofmtTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckGofmtTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_gofmt",
+			Description: "Report the Go files that are not gofmt-clean, like 'gofmt -l' in a CI pipeline, with a unified diff of the formatting each needs. Files are never modified",
+			QuickHelp:   "List Go files that are not gofmt-clean",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				PathProperty.Description("Go file or directory to check"),
+				PathsProperty.Description("Go files or directories to check"),
+				RecursiveProperty,
+			},
+			Requires: []mcputil.Requirement{
+				mcputil.RequiresOneOf{
+					ParamNames: []string{"path", "paths"},
+				},
+			},
+		}),
+	})
+}
+
+// CheckGofmtTool reports Go files whose formatting differs from gofmt's.
+type CheckGofmtTool struct {
+	*mcputil.ToolBase
+}
+
+// GofmtFileResult is a Go file that is not gofmt-clean.
+type GofmtFileResult struct {
+	File      string `json:"file"`
+	Diff      string `json:"diff"`
+	DiffError string `json:"diff_error,omitempty"`
+}
+
+// Handle processes the check_gofmt tool request and checks the formatting of
+// the Go files at the given paths.
+func (t *CheckGofmtTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var paths []string
+	var recursive bool
+	var files []string
+	var unformatted []GofmtFileResult
+	var parseErrors []string
+
+	logger.Info("Tool called", "tool", "check_gofmt")
+
+	path, err = PathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	paths, err = PathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+	if path != "" {
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		err = fmt.Errorf("either 'path' or 'paths' is required")
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "check_gofmt",
+		"paths", paths,
+		"recursive", recursive)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+		Excludes:   golang.DefaultExcludes(),
+	})
+	if err != nil {
+		goto end
+	}
+
+	unformatted, parseErrors = checkGofmt(files)
+
+	logger.Info("Tool completed", "tool", "check_gofmt",
+		"files_checked", len(files),
+		"unformatted_count", len(unformatted))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"paths":             paths,
+		"clean":             len(unformatted) == 0 && len(parseErrors) == 0,
+		"files":             unformatted,
+		"unformatted_count": len(unformatted),
+		"files_checked":     len(files),
+		"errors":            parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// checkGofmt returns the files whose content differs from its gofmt
+// formatting, each with a diff from the current to the formatted content.
+// Files that cannot be read or parsed are reported in parseErrors.
+func checkGofmt(files []string) (unformatted []GofmtFileResult, parseErrors []string) {
+	var content, formatted []byte
+	var fr GofmtFileResult
+	var err error
+
+	unformatted = make([]GofmtFileResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			formatted, err = format.Source(content)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		if string(formatted) == string(content) {
+			continue
+		}
+		fr = GofmtFileResult{File: fp}
+		fr.Diff, err = mcputil.UnifiedDiff("a/"+fp, "b/"+fp, string(content), string(formatted))
+		if err != nil {
+			fr.DiffError = err.Error()
+		}
+		unformatted = append(unformatted, fr)
+	}
+	return unformatted, parseErrors
+}
@@ -0,0 +1,137 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckGofmtDirPrefix = "check-gofmt-tool-test"
+
+// Check gofmt tool result type
+type CheckGofmtResult struct {
+	Paths            []string                   `json:"paths"`
+	Clean            bool                       `json:"clean"`
+	Files            []mcptools.GofmtFileResult `json:"files"`
+	UnformattedCount int                        `json:"unformatted_count"`
+	FilesChecked     int                        `json:"files_checked"`
+	Errors           []string                   `json:"errors"`
+}
+
+type checkGofmtResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedClean        bool
+	ExpectedUnformatted  int
+	ExpectedFilesChecked int
+	ExpectedErrors       int
+}
+
+func requireCheckGofmtResult(t *testing.T, result *CheckGofmtResult, err error, opts checkGofmtResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectedClean, result.Clean, "Clean should match")
+	assert.Equal(t, opts.ExpectedUnformatted, result.UnformattedCount, "Unformatted count should match")
+	assert.Len(t, result.Files, opts.ExpectedUnformatted, "Should report each unformatted file")
+	assert.Equal(t, opts.ExpectedFilesChecked, result.FilesChecked, "Files checked should match")
+	assert.Len(t, result.Errors, opts.ExpectedErrors, "Error count should match")
+}
+
+func TestCheckGofmtTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_gofmt")
+	require.NotNil(t, tool, "check_gofmt tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.RepoFixture) {
+		tf := fsfix.NewRootFixture(CheckGofmtDirPrefix)
+		pf := tf.AddRepoFixture("gofmt-project", nil)
+		return tf, pf
+	}
+
+	configure := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	call := func(params mcputil.Params) (*CheckGofmtResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[CheckGofmtResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call check_gofmt")
+	}
+
+	t.Run("CleanFiles_ShouldPass", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: "package main\n\nfunc main() {}\n"})
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{"path": pf.Dir()})
+		requireCheckGofmtResult(t, result, err, checkGofmtResultOpts{
+			ExpectedClean:        true,
+			ExpectedFilesChecked: 1,
+		})
+	})
+
+	t.Run("UnformattedFile_ShouldReportDiff", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: "package main\n\nfunc main() {}\n"})
+		ff := pf.AddFileFixture("util.go", &fsfix.FileFixtureArgs{Content: "package main\n\nfunc add(a,b int) int {\n  return a+b\n}\n"})
+		pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "not go\n"})
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{"paths": []any{pf.Dir()}})
+		requireCheckGofmtResult(t, result, err, checkGofmtResultOpts{
+			ExpectedUnformatted:  1,
+			ExpectedFilesChecked: 2,
+		})
+		assert.Equal(t, ff.Filepath, result.Files[0].File, "Should report the unformatted file")
+		assert.Contains(t, result.Files[0].Diff, "+\treturn a + b\n", "Diff should show the formatting change")
+		requireFileContent(t, ff.Filepath, "package main\n\nfunc add(a,b int) int {\n  return a+b\n}\n")
+	})
+
+	t.Run("InvalidGo_ShouldReportError", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		pf.AddFileFixture("broken.go", &fsfix.FileFixtureArgs{Content: "package main\n\nfunc {\n"})
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{"path": pf.Dir()})
+		requireCheckGofmtResult(t, result, err, checkGofmtResultOpts{
+			ExpectedFilesChecked: 1,
+			ExpectedErrors:       1,
+		})
+	})
+
+	t.Run("NoPath_ShouldError", func(t *testing.T) {
+		tf, _ := setup(t)
+		defer tf.Cleanup()
+
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{})
+		requireCheckGofmtResult(t, result, err, checkGofmtResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "'path' or 'paths' is required",
+		})
+	})
+}
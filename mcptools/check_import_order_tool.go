@@ -0,0 +1,201 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckImportOrderTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckImportOrderTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_import_order",
+			Description: "Find Go files whose imports are not grouped and sorted per goimports conventions: standard library, third-party, then the file's own module (from go.mod), each group sorted and separated by a blank line. Optionally rewrites the import blocks to comply",
+			QuickHelp:   "Check (and fix) Go import grouping order",
+			Previewable: true,
+			ReportOnly:  mcputil.WhenBool(FixProperty, false),
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file or directory to check"),
+				RecursiveProperty,
+				FixProperty.Description("Regroup and sort the imports of each reported file"),
+			},
+		}),
+	})
+}
+
+// CheckImportOrderTool reports, and optionally fixes, Go files with misordered imports.
+type CheckImportOrderTool struct {
+	*mcputil.ToolBase
+}
+
+// ImportOrderResult lists the import order issues of a single Go file.
+type ImportOrderResult struct {
+	File     string                      `json:"file"`
+	Issues   []golang.GoImportOrderIssue `json:"issues"`
+	Fixed    bool                        `json:"fixed"`
+	FixError string                      `json:"fix_error,omitempty"`
+}
+
+// Handle processes the check_import_order tool request and checks, and
+// optionally fixes, the import blocks of the Go files found.
+func (t *CheckImportOrderTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var fix bool
+	var files []string
+	var results []ImportOrderResult
+	var parseErrors []string
+	var fixedCount int
+
+	logger.Info("Tool called", "tool", "check_import_order")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	fix, err = FixProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "check_import_order",
+		"path", path,
+		"recursive", recursive,
+		"fix", fix)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	results, parseErrors = checkImportOrder(files)
+
+	if fix {
+		for i := range results {
+			err = t.fixImportOrder(ctx, req, &results[i])
+			if err != nil {
+				goto end
+			}
+			if results[i].Fixed {
+				fixedCount++
+			}
+		}
+	}
+
+	logger.Info("Tool completed", "tool", "check_import_order",
+		"files_scanned", len(files),
+		"file_count", len(results),
+		"fixed_count", fixedCount)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":          path,
+		"files":         results,
+		"file_count":    len(results),
+		"files_scanned": len(files),
+		"fixed":         fix,
+		"fixed_count":   fixedCount,
+		"errors":        parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// fixImportOrder rewrites the imports of the file in fr and marks it fixed.
+// A file whose imports cannot be organized safely is left unchanged and the
+// reason recorded in fr.FixError; only a failed write is returned as an error.
+func (t *CheckImportOrderTool) fixImportOrder(ctx context.Context, req mcputil.ToolRequest, fr *ImportOrderResult) (err error) {
+	var content []byte
+	var modulePath string
+	var organized []byte
+	var changed bool
+	var fixErr error
+
+	content, err = os.ReadFile(fr.File)
+	if err != nil {
+		goto end
+	}
+
+	modulePath, _, fixErr = golang.FindModulePath(filepath.Dir(fr.File))
+	if fixErr == nil {
+		organized, changed, fixErr = golang.OrganizeImports(fr.File, content, modulePath)
+	}
+	if fixErr != nil {
+		fr.FixError = fixErr.Error()
+		goto end
+	}
+	if !changed {
+		goto end
+	}
+
+	err = mcputil.WriteFile(ctx, t.Config(), fr.File, string(organized))
+	if err != nil {
+		err = fmt.Errorf("failed to write %s: %v", fr.File, err)
+		goto end
+	}
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, fr.File)
+	fr.Fixed = true
+
+end:
+	return err
+}
+
+// checkImportOrder returns the files whose imports deviate from goimports
+// conventions, classifying imports against the module each file belongs to.
+// Files that fail to read or parse are reported in parseErrors and skipped.
+func checkImportOrder(files []string) (results []ImportOrderResult, parseErrors []string) {
+	var modules map[string]string
+	var content []byte
+	var issues []golang.GoImportOrderIssue
+	var modulePath string
+	var dir string
+	var ok bool
+	var err error
+
+	modules = make(map[string]string)
+	results = make([]ImportOrderResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		dir = filepath.Dir(fp)
+		modulePath, ok = modules[dir]
+		if !ok {
+			modulePath, _, err = golang.FindModulePath(dir)
+			if err != nil {
+				logger.Warn("Unable to determine module", "dir", dir, "error", err)
+			}
+			modules[dir] = modulePath
+		}
+
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			issues, err = golang.CheckImportOrder(fp, content, modulePath)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		if len(issues) == 0 {
+			continue
+		}
+		results = append(results, ImportOrderResult{File: fp, Issues: issues})
+	}
+	return results, parseErrors
+}
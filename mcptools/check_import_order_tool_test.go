@@ -0,0 +1,198 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckImportOrderDirPrefix = "check-import-order-tool-test"
+
+// Check import order tool result type
+type CheckImportOrderResult struct {
+	Path         string                       `json:"path"`
+	Files        []mcptools.ImportOrderResult `json:"files"`
+	FileCount    int                          `json:"file_count"`
+	FilesScanned int                          `json:"files_scanned"`
+	FixedCount   int                          `json:"fixed_count"`
+	Errors       []string                     `json:"errors"`
+}
+
+type checkImportOrderResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedFiles    int
+	ExpectedScanned  int
+	ExpectedFixed    int
+	ExpectedMessages []string
+}
+
+func requireCheckImportOrderResult(t *testing.T, result *CheckImportOrderResult, err error, opts checkImportOrderResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedFiles, result.FileCount, "File count should match")
+	assert.Equal(t, opts.ExpectedScanned, result.FilesScanned, "Files scanned should match")
+	assert.Equal(t, opts.ExpectedFixed, result.FixedCount, "Fixed count should match")
+	if opts.ExpectedMessages != nil {
+		require.NotEmpty(t, result.Files, "Should report a file")
+		messages := make([]string, len(result.Files[0].Issues))
+		for i, issue := range result.Files[0].Issues {
+			messages[i] = issue.Message
+		}
+		assert.Equal(t, opts.ExpectedMessages, messages, "Issue messages should match in order")
+	}
+}
+
+const importOrderedSource = `package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stretchr/testify/assert"
+
+	"example.com/app/util"
+)
+
+var _ = fmt.Sprint
+`
+
+const importMisorderedSource = `package app
+
+import (
+	// util has helpers
+	"example.com/app/util"
+	"os"
+	"fmt"
+
+	"github.com/stretchr/testify/assert" // assertions
+)
+
+var _ = fmt.Sprint
+`
+
+const importOrganizedSource = `package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/stretchr/testify/assert" // assertions
+
+	// util has helpers
+	"example.com/app/util"
+)
+
+var _ = fmt.Sprint
+`
+
+func TestCheckImportOrderTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_import_order")
+	require.NotNil(t, tool, "check_import_order tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(CheckImportOrderDirPrefix)
+		tf.AddFileFixture("go.mod", &fsfix.FileFixtureArgs{Content: "module example.com/app\n\ngo 1.24\n"})
+		tf.AddFileFixture("ordered.go", &fsfix.FileFixtureArgs{Content: importOrderedSource})
+		ff := tf.AddFileFixture("misordered.go", &fsfix.FileFixtureArgs{Content: importMisorderedSource})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	t.Run("Directory_ShouldReportMisorderedFiles", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+		})
+
+		result, err := mcputil.GetToolResult[CheckImportOrderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking import order")
+		requireCheckImportOrderResult(t, result, err, checkImportOrderResultOpts{
+			ExpectedFiles:   1,
+			ExpectedScanned: 2,
+			ExpectedMessages: []string{
+				`stdlib import "os" is grouped with module imports`,
+				`stdlib import "fmt" is grouped with module imports`,
+				`import "fmt" should sort before "os"`,
+				"third_party group should come before module group",
+			},
+		})
+		assert.Equal(t, ff.Filepath, result.Files[0].File, "Misordered file should be reported")
+		assert.False(t, result.Files[0].Fixed, "File should not be fixed without fix")
+	})
+
+	t.Run("Fix_ShouldRegroupAndSortImports", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"fix":           true,
+		})
+
+		result, err := mcputil.GetToolResult[CheckImportOrderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error fixing import order")
+		requireCheckImportOrderResult(t, result, err, checkImportOrderResultOpts{
+			ExpectedFiles:   1,
+			ExpectedScanned: 2,
+			ExpectedFixed:   1,
+		})
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read fixed file")
+		assert.Equal(t, importOrganizedSource, string(content), "Imports should be regrouped with comments kept")
+	})
+
+	t.Run("FloatingComment_ShouldNotFix", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckImportOrderDirPrefix)
+		defer tf.Cleanup()
+		source := "package app\n\nimport (\n\t\"os\"\n\n\t// standalone note\n\n\t\"fmt\"\n)\n"
+		ff := tf.AddFileFixture("floating.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"fix":           true,
+		})
+
+		result, err := mcputil.GetToolResult[CheckImportOrderResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking import order")
+		requireCheckImportOrderResult(t, result, err, checkImportOrderResultOpts{
+			ExpectedFiles:   1,
+			ExpectedScanned: 1,
+			ExpectedMessages: []string{
+				"stdlib imports are split across multiple groups",
+			},
+		})
+		assert.Contains(t, result.Files[0].FixError, "comments not attached", "Fix should be refused")
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read file")
+		assert.Equal(t, source, string(content), "File should be unchanged")
+	})
+}
@@ -0,0 +1,203 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/tidwall/gjson"
+)
+
+var _ mcputil.Tool = (*CheckJSONConsistencyTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckJSONConsistencyTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_json_consistency",
+			Description: "Check that JSON files share the same key structure, such as per-environment configs, reporting keys present in some files but missing from others. Values are ignored; only the set of keys at each level is compared, with the keys of objects inside arrays merged under '#'",
+			QuickHelp:   "Find keys missing from some of a set of JSON files",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathsProperty.Description("JSON files to compare, or directories to search for .json files"),
+				RecursiveProperty,
+			},
+		}),
+	})
+}
+
+// CheckJSONConsistencyTool reports keys that appear in some JSON files but
+// not in others.
+type CheckJSONConsistencyTool struct {
+	*mcputil.ToolBase
+}
+
+// JSONKeyDifference is a key path present in some of the compared files but
+// missing from the rest.
+type JSONKeyDifference struct {
+	Key         string   `json:"key"`          // gjson path such as "server.port" or "hosts.#.name"
+	PresentIn   []string `json:"present_in"`   // Files that have the key
+	MissingFrom []string `json:"missing_from"` // Files that lack the key
+}
+
+// Handle processes the check_json_consistency tool request and compares the
+// key structure of the JSON files found at the given paths.
+func (t *CheckJSONConsistencyTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var paths []string
+	var recursive bool
+	var files []string
+	var keySets []map[string]string
+	var compared []string
+	var parseErrors []string
+	var differences []JSONKeyDifference
+	var content []byte
+
+	logger.Info("Tool called", "tool", "check_json_consistency")
+
+	paths, err = RequiredPathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "check_json_consistency",
+		"paths", paths,
+		"recursive", recursive)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: []string{".json"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		if !gjson.ValidBytes(content) {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: invalid JSON", fp))
+			continue
+		}
+		keySets = append(keySets, jsonKeyPaths(gjson.ParseBytes(content)))
+		compared = append(compared, fp)
+	}
+
+	if len(compared) < 2 {
+		err = fmt.Errorf("at least 2 valid JSON files are needed to compare, found %d", len(compared))
+		if len(parseErrors) > 0 {
+			err = fmt.Errorf("%w (invalid: %v)", err, parseErrors)
+		}
+		goto end
+	}
+
+	differences = compareJSONKeySets(compared, keySets)
+
+	logger.Info("Tool completed", "tool", "check_json_consistency",
+		"files_compared", len(compared),
+		"difference_count", len(differences),
+		"parse_errors", len(parseErrors))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"files":            compared,
+		"consistent":       len(differences) == 0,
+		"differences":      differences,
+		"difference_count": len(differences),
+		"errors":           parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// jsonKeyPaths returns the gjson paths of every key in value, each mapped to
+// the path of its parent key, or "" for top-level keys. The keys of objects
+// inside an array are merged under the array's path followed by '#', so
+// arrays of differing lengths have the same shape.
+func jsonKeyPaths(value gjson.Result) (keys map[string]string) {
+	keys = make(map[string]string)
+	addJSONKeyPaths(keys, "", "", value)
+	return keys
+}
+
+// addJSONKeyPaths adds to keys the paths of the keys in value, which is found
+// at prefix beneath the key parent.
+func addJSONKeyPaths(keys map[string]string, parent, prefix string, value gjson.Result) {
+	var path string
+
+	switch {
+	case value.IsObject():
+		value.ForEach(func(key, child gjson.Result) bool {
+			path = gjson.Escape(key.String())
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			keys[path] = parent
+			addJSONKeyPaths(keys, path, path, child)
+			return true
+		})
+	case value.IsArray():
+		path = "#"
+		if prefix != "" {
+			path = prefix + ".#"
+		}
+		for _, item := range value.Array() {
+			addJSONKeyPaths(keys, parent, path, item)
+		}
+	}
+}
+
+// compareJSONKeySets returns, sorted by key, every key found in some but not
+// all of keySets, where keySets[i] holds the keys of files[i]. A key missing
+// from exactly the files its parent is missing from is left out, so a missing
+// object is reported once rather than once per key inside it.
+func compareJSONKeySets(files []string, keySets []map[string]string) (differences []JSONKeyDifference) {
+	var parents map[string]string
+	var missing map[string][]string
+	var keys []string
+	var diff JSONKeyDifference
+	var ok bool
+
+	parents = make(map[string]string)
+	for _, set := range keySets {
+		for key, parent := range set {
+			parents[key] = parent
+		}
+	}
+	keys = make([]string, 0, len(parents))
+	for key := range parents {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	missing = make(map[string][]string, len(keys))
+	differences = make([]JSONKeyDifference, 0)
+	for _, key := range keys {
+		diff = JSONKeyDifference{Key: key}
+		for i, set := range keySets {
+			_, ok = set[key]
+			if ok {
+				diff.PresentIn = append(diff.PresentIn, files[i])
+				continue
+			}
+			diff.MissingFrom = append(diff.MissingFrom, files[i])
+		}
+		missing[key] = diff.MissingFrom
+		if len(diff.MissingFrom) == 0 || slices.Equal(diff.MissingFrom, missing[parents[key]]) {
+			continue
+		}
+		differences = append(differences, diff)
+	}
+	return differences
+}
@@ -0,0 +1,144 @@
+package mcptools_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckJSONConsistencyDirPrefix = "check-json-consistency-tool-test"
+
+// Check JSON consistency tool result type
+type CheckJSONConsistencyResult struct {
+	Files           []string                     `json:"files"`
+	Consistent      bool                         `json:"consistent"`
+	Differences     []mcptools.JSONKeyDifference `json:"differences"`
+	DifferenceCount int                          `json:"difference_count"`
+	Errors          []string                     `json:"errors"`
+}
+
+type checkJSONConsistencyResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedFiles    int
+	ExpectedKeys     []string
+}
+
+func requireCheckJSONConsistencyResult(t *testing.T, result *CheckJSONConsistencyResult, err error, opts checkJSONConsistencyResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Len(t, result.Files, opts.ExpectedFiles, "Compared file count should match")
+	assert.Equal(t, len(opts.ExpectedKeys) == 0, result.Consistent, "Consistent should match")
+	assert.Equal(t, len(opts.ExpectedKeys), result.DifferenceCount, "Difference count should match")
+
+	var keys []string
+	for _, diff := range result.Differences {
+		keys = append(keys, diff.Key)
+	}
+	assert.Equal(t, opts.ExpectedKeys, keys, "Differing keys should match")
+}
+
+func TestCheckJSONConsistencyTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_json_consistency")
+	require.NotNil(t, tool, "check_json_consistency tool should be registered")
+
+	setup := func(t *testing.T, files map[string]string) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(CheckJSONConsistencyDirPrefix)
+		pf := tf.AddRepoFixture("config", nil)
+		for name, content := range files {
+			pf.AddFileFixture(name, &fsfix.FileFixtureArgs{Content: content})
+		}
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, pf.Dir()
+	}
+
+	call := func(paths ...any) (*CheckJSONConsistencyResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         paths,
+		})
+		return mcputil.GetToolResult[CheckJSONConsistencyResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call check_json_consistency")
+	}
+
+	t.Run("SameShape_ShouldBeConsistent", func(t *testing.T) {
+		tf, dir := setup(t, map[string]string{
+			"dev.json":  `{"port": 8080, "db": {"host": "localhost"}, "hosts": [{"name": "a"}]}`,
+			"prod.json": `{"db": {"host": "db.example.com"}, "port": "443", "hosts": [{"name": "b"}, {"name": "c"}]}`,
+		})
+		defer tf.Cleanup()
+
+		result, err := call(dir)
+		requireCheckJSONConsistencyResult(t, result, err, checkJSONConsistencyResultOpts{
+			ExpectedFiles: 2,
+		})
+	})
+
+	t.Run("DriftedKeys_ShouldReportMissing", func(t *testing.T) {
+		tf, dir := setup(t, map[string]string{
+			"dev.json":     `{"port": 8080, "debug": true, "db": {"host": "localhost", "pool": 5}, "hosts": [{"name": "local"}]}`,
+			"staging.json": `{"port": 8080, "db": {"host": "staging", "pool": 5}, "cache": {"ttl": 60, "size": 100}}`,
+			"prod.json":    `{"port": 443, "db": {"host": "prod"}, "hosts": [{"name": "a"}, {"name": "b", "weight": 2}]}`,
+		})
+		defer tf.Cleanup()
+
+		result, err := call(dir)
+		requireCheckJSONConsistencyResult(t, result, err, checkJSONConsistencyResultOpts{
+			ExpectedFiles: 3,
+			ExpectedKeys:  []string{"cache", "db.pool", "debug", "hosts", "hosts.#.weight"},
+		})
+		prod := filepath.Join(dir, "prod.json")
+		staging := filepath.Join(dir, "staging.json")
+		assert.Equal(t, []string{staging}, result.Differences[0].PresentIn, "cache should be present in staging only")
+		assert.Equal(t, []string{prod}, result.Differences[1].MissingFrom, "db.pool should be missing from prod")
+		assert.Equal(t, []string{staging}, result.Differences[3].MissingFrom, "hosts should be missing from staging")
+		assert.Equal(t, []string{prod}, result.Differences[4].PresentIn, "hosts.#.weight should be present in prod only")
+	})
+
+	t.Run("InvalidJSON_ShouldReportError", func(t *testing.T) {
+		tf, dir := setup(t, map[string]string{
+			"a.json":   `{"x": 1}`,
+			"b.json":   `{"x": 2}`,
+			"bad.json": `{"x": `,
+		})
+		defer tf.Cleanup()
+
+		result, err := call(dir)
+		requireCheckJSONConsistencyResult(t, result, err, checkJSONConsistencyResultOpts{
+			ExpectedFiles: 2,
+		})
+		require.Len(t, result.Errors, 1, "Should report the invalid file")
+		assert.Contains(t, result.Errors[0], "bad.json", "Error should name the invalid file")
+	})
+
+	t.Run("SingleFile_ShouldError", func(t *testing.T) {
+		tf, dir := setup(t, map[string]string{
+			"only.json": `{"x": 1}`,
+		})
+		defer tf.Cleanup()
+
+		result, err := call(filepath.Join(dir, "only.json"))
+		requireCheckJSONConsistencyResult(t, result, err, checkJSONConsistencyResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "at least 2 valid JSON files",
+		})
+	})
+}
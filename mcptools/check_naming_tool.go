@@ -0,0 +1,143 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckNamingTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckNamingTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_naming",
+			Description: "Find declared Go identifiers that break naming conventions: underscores instead of MixedCaps, names in all capitals, and acronyms cased inconsistently such as Http or userId instead of HTTP or userID. Reports the file, line, name and rule of each, with a suggested name for acronyms",
+			QuickHelp:   "Find Go names breaking naming conventions",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file or directory to check"),
+				RequiredLanguageProperty.Description("Programming language of the files to check; only 'go' is supported"),
+				RecursiveProperty,
+				AcronymsProperty,
+			},
+		}),
+	})
+}
+
+// CheckNamingTool reports Go identifiers that violate naming conventions.
+type CheckNamingTool struct {
+	*mcputil.ToolBase
+}
+
+// NamingIssueResult describes a naming convention violation found in a file.
+type NamingIssueResult struct {
+	File string `json:"file"`
+	golang.GoNamingIssue
+}
+
+// Handle processes the check_naming tool request and returns the naming
+// issues found.
+func (t *CheckNamingTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var recursive bool
+	var acronyms []string
+	var files []string
+	var issues []NamingIssueResult
+	var parseErrors []string
+
+	logger.Info("Tool called", "tool", "check_naming")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	acronyms, err = AcronymsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid acronyms array: %v", err)
+		goto end
+	}
+	acronyms = append(golang.DefaultAcronyms(), acronyms...)
+
+	logger.Info("Tool arguments parsed",
+		"tool", "check_naming",
+		"path", path,
+		"language", language,
+		"recursive", recursive,
+		"acronym_count", len(acronyms))
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+		Excludes:   golang.DefaultExcludes(),
+	})
+	if err != nil {
+		goto end
+	}
+
+	issues, parseErrors = checkNaming(files, acronyms)
+
+	logger.Info("Tool completed", "tool", "check_naming",
+		"files_scanned", len(files),
+		"issue_count", len(issues))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":          path,
+		"issues":        issues,
+		"issue_count":   len(issues),
+		"files_scanned": len(files),
+		"errors":        parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// checkNaming collects the naming issues in files. Files that fail to parse
+// are reported in parseErrors and skipped.
+func checkNaming(files []string, acronyms []string) (issues []NamingIssueResult, parseErrors []string) {
+	var content []byte
+	var found []golang.GoNamingIssue
+	var err error
+
+	issues = make([]NamingIssueResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			found, err = golang.CheckNaming(fp, content, acronyms)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		for _, issue := range found {
+			issues = append(issues, NamingIssueResult{
+				File:          fp,
+				GoNamingIssue: issue,
+			})
+		}
+	}
+	return issues, parseErrors
+}
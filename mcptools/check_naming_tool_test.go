@@ -0,0 +1,162 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckNamingDirPrefix = "check-naming-tool-test"
+
+// Check naming tool result type
+type CheckNamingResult struct {
+	Path         string                       `json:"path"`
+	Issues       []mcptools.NamingIssueResult `json:"issues"`
+	IssueCount   int                          `json:"issue_count"`
+	FilesScanned int                          `json:"files_scanned"`
+	Errors       []string                     `json:"errors"`
+}
+
+type checkNamingResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedIssues       []string // "name:rule" in source order
+	ExpectedFilesScanned int
+}
+
+func requireCheckNamingResult(t *testing.T, result *CheckNamingResult, err error, opts checkNamingResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	issues := make([]string, len(result.Issues))
+	for i, issue := range result.Issues {
+		issues[i] = issue.Name + ":" + string(issue.Rule)
+	}
+	if opts.ExpectedIssues == nil {
+		opts.ExpectedIssues = []string{}
+	}
+	assert.Equal(t, opts.ExpectedIssues, issues, "Issues should match")
+	assert.Equal(t, len(opts.ExpectedIssues), result.IssueCount, "Issue count should match")
+	assert.Equal(t, opts.ExpectedFilesScanned, result.FilesScanned, "Files scanned should match")
+}
+
+func TestCheckNamingTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_naming")
+	require.NotNil(t, tool, "check_naming tool should be registered")
+
+	const source = `package server
+
+const MAX_RETRIES = 3
+
+const DefaultTTL = 30
+
+type HttpServer struct {
+	userId  string
+	BaseURL string
+	GrpcAddr string
+}
+
+func (s *HttpServer) ServeHTTP() {}
+
+func parseJsonBody(raw_body []byte) (err error) {
+	httpClient := 0
+	for _, idx := range raw_body {
+		_ = idx
+	}
+	_ = httpClient
+	return nil
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(CheckNamingDirPrefix)
+		pf := tf.AddRepoFixture("naming-project", nil)
+		pf.AddFileFixture("server.go", &fsfix.FileFixtureArgs{Content: source})
+		pf.AddFileFixture("server_test.go", &fsfix.FileFixtureArgs{
+			Content: "package server\n\nimport \"testing\"\n\nfunc TestParse_EmptyInput(t *testing.T) {}\n\nfunc helper_func() {}\n",
+		})
+		pf.AddFileFixture("zz_generated.go", &fsfix.FileFixtureArgs{
+			Content: "// Code generated by stringer. DO NOT EDIT.\n\npackage server\n\nvar _Color_name = \"RedGreen\"\n",
+		})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, pf.Dir()
+	}
+
+	call := func(params mcputil.Params) (*CheckNamingResult, error) {
+		params["session_token"] = testToken
+		if params["language"] == nil {
+			params["language"] = "go"
+		}
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[CheckNamingResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call check_naming")
+	}
+
+	t.Run("DefaultAcronyms_ShouldReportViolations", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir})
+		requireCheckNamingResult(t, result, err, checkNamingResultOpts{
+			ExpectedIssues: []string{
+				"MAX_RETRIES:underscore",
+				"MAX_RETRIES:all_caps",
+				"HttpServer:acronym",
+				"userId:acronym",
+				"parseJsonBody:acronym",
+				"raw_body:underscore",
+				"helper_func:underscore",
+			},
+			ExpectedFilesScanned: 3,
+		})
+
+		for _, issue := range result.Issues {
+			if issue.Name == "userId" {
+				assert.Contains(t, issue.Message, "'userID'", "Should suggest the corrected name")
+				assert.Equal(t, 8, issue.Line, "Should report the field's line")
+			}
+		}
+	})
+
+	t.Run("CustomAcronyms_ShouldAlsoBeEnforced", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "acronyms": []any{"GRPC"}})
+		require.NoError(t, err)
+
+		var names []string
+		for _, issue := range result.Issues {
+			names = append(names, issue.Name)
+		}
+		assert.Contains(t, names, "GrpcAddr", "Should enforce the custom acronym")
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "language": "python"})
+		requireCheckNamingResult(t, result, err, checkNamingResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "unsupported language",
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CheckStructTagsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CheckStructTagsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "check_struct_tags",
+			Description: "Find Go struct fields whose tags are malformed or repeat a key, using reflect.StructTag parsing rules, which otherwise silently break reflection-based libraries such as encoding/json",
+			QuickHelp:   "Find malformed or duplicate-key struct tags",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+				RecursiveProperty,
+			},
+		}),
+	})
+}
+
+// CheckStructTagsTool reports malformed and duplicate-key Go struct tags.
+type CheckStructTagsTool struct {
+	*mcputil.ToolBase
+}
+
+// StructTagIssueResult describes a struct tag problem found in a file.
+type StructTagIssueResult struct {
+	File string `json:"file"`
+	golang.GoStructTagIssue
+}
+
+// Handle processes the check_struct_tags tool request and returns the
+// struct tag problems found.
+func (t *CheckStructTagsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var files []string
+	var issues []StructTagIssueResult
+	var parseErrors []string
+
+	logger.Info("Tool called", "tool", "check_struct_tags")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "check_struct_tags",
+		"path", path,
+		"recursive", recursive)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	issues, parseErrors = checkStructTags(files)
+
+	logger.Info("Tool completed", "tool", "check_struct_tags",
+		"files_scanned", len(files),
+		"issue_count", len(issues))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":          path,
+		"issues":        issues,
+		"issue_count":   len(issues),
+		"files_scanned": len(files),
+		"errors":        parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// checkStructTags collects the struct tag issues in files. Files that fail
+// to parse are reported in parseErrors and skipped.
+func checkStructTags(files []string) (issues []StructTagIssueResult, parseErrors []string) {
+	var content []byte
+	var found []golang.GoStructTagIssue
+	var err error
+
+	issues = make([]StructTagIssueResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			found, err = golang.ParseStructTagIssues(fp, content)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		for _, issue := range found {
+			issues = append(issues, StructTagIssueResult{
+				File:             fp,
+				GoStructTagIssue: issue,
+			})
+		}
+	}
+	return issues, parseErrors
+}
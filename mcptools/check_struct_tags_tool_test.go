@@ -0,0 +1,137 @@
+package mcptools_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CheckStructTagsDirPrefix = "check-struct-tags-tool-test"
+
+// Check struct tags tool result type
+type CheckStructTagsResult struct {
+	Path         string                          `json:"path"`
+	Issues       []mcptools.StructTagIssueResult `json:"issues"`
+	IssueCount   int                             `json:"issue_count"`
+	FilesScanned int                             `json:"files_scanned"`
+	Errors       []string                        `json:"errors"`
+}
+
+type checkStructTagsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedIssues   []string
+	ExpectedErrors   int
+}
+
+func requireCheckStructTagsResult(t *testing.T, result *CheckStructTagsResult, err error, opts checkStructTagsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	issues := make([]string, len(result.Issues))
+	for i, issue := range result.Issues {
+		issues[i] = fmt.Sprintf("%d:%s.%s:%s", issue.Line, issue.Struct, issue.Field, issue.Key)
+	}
+	assert.Equal(t, opts.ExpectedIssues, issues, "Reported issues should match in order")
+	assert.Equal(t, len(opts.ExpectedIssues), result.IssueCount, "Issue count should match")
+	assert.Len(t, result.Errors, opts.ExpectedErrors, "Parse error count should match")
+}
+
+const structTagsSource = "package sample\n" +
+	"\n" +
+	"type Base struct{}\n" +
+	"\n" +
+	"type Config struct {\n" +
+	"\tBase    `yaml:\",inline\" yaml:\"base\"`\n" +
+	"\tName    string `json:\"name,omitempty\" yaml:\"name\"`\n" +
+	"\tPort    int    `json:\"port\" json:\"p\"`\n" +
+	"\tHost    string `json:host`\n" +
+	"\tDebug   bool   `json:\"debug\"yaml:\"debug\"`\n" +
+	"\tTimeout int    `json: \"timeout\"`\n" +
+	"}\n" +
+	"\n" +
+	"var anon = struct {\n" +
+	"\tX int `xml:\"x`\n" +
+	"}{}\n"
+
+func TestCheckStructTagsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("check_struct_tags")
+	require.NotNil(t, tool, "check_struct_tags tool should be registered")
+
+	setup := func(t *testing.T) *fsfix.RootFixture {
+		tf := fsfix.NewRootFixture(CheckStructTagsDirPrefix)
+		tf.AddFileFixture("sample.go", &fsfix.FileFixtureArgs{Content: structTagsSource})
+		tf.AddFileFixture("broken.go", &fsfix.FileFixtureArgs{Content: "package broken\n\ntype {\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf
+	}
+
+	t.Run("MalformedAndDuplicateTags_ShouldBeReported", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+		})
+
+		result, err := mcputil.GetToolResult[CheckStructTagsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking struct tags")
+		requireCheckStructTagsResult(t, result, err, checkStructTagsResultOpts{
+			ExpectedIssues: []string{
+				"6:Config.Base:yaml",
+				"8:Config.Port:json",
+				"9:Config.Host:json",
+				"10:Config.Debug:json",
+				"11:Config.Timeout:json",
+				"15:.X:xml",
+			},
+			ExpectedErrors: 1,
+		})
+		assert.Contains(t, result.Issues[1].Message, "duplicate struct tag key", "Port should report a duplicate key")
+		assert.Contains(t, result.Issues[2].Message, "double-quoted", "Host should report an unquoted value")
+		assert.Contains(t, result.Issues[3].Message, "not separated by spaces", "Debug should report missing separation")
+		assert.Contains(t, result.Issues[5].Message, "missing closing quote", "X should report an unterminated value")
+	})
+
+	t.Run("ValidTags_ShouldReportNothing", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CheckStructTagsDirPrefix)
+		ff := tf.AddFileFixture("valid.go", &fsfix.FileFixtureArgs{
+			Content: "package valid\n\ntype User struct {\n\tName string `json:\"name\" db:\"user_name\"`\n\tAge  int\n}\n",
+		})
+		tf.Setup(t)
+		defer tf.Cleanup()
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[CheckStructTagsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error checking valid struct tags")
+		requireCheckStructTagsResult(t, result, err, checkStructTagsResultOpts{
+			ExpectedIssues: []string{},
+		})
+		assert.Equal(t, 1, result.FilesScanned, "Should scan the single file")
+	})
+}
@@ -34,24 +34,79 @@ func (rp RelativePosition) Validate() (err error) {
 
 // ToolNamesMap contains all supported MCP tool names for validation purposes.
 var ToolNamesMap = map[string]NULL{
-	"start_session":          {},
-	"read_files":             {},
-	"search_files":           {},
-	"get_config":             {},
-	"help":                   {},
-	"create_file":            {},
-	"update_file":            {},
-	"delete_files":           {},
-	"update_file_lines":      {},
-	"delete_file_lines":      {},
-	"insert_file_lines":      {},
-	"insert_at_pattern":      {},
-	"replace_pattern":        {},
-	"find_file_part":         {},
-	"replace_file_part":      {},
-	"validate_files":         {},
-	"analyze_files":          {},
-	"request_approval":       {},
-	"detect_current_project": {},
-	"check_docs":             {},
+	"start_session":            {},
+	"refresh_session":          {},
+	"revoke_session":           {},
+	"read_files":               {},
+	"read_binary_file":         {},
+	"list_directories":         {},
+	"list_directory":           {},
+	"scan_secrets":             {},
+	"search_files":             {},
+	"fuzzy_find_files":         {},
+	"get_config":               {},
+	"get_effective_config":     {},
+	"help":                     {},
+	"create_file":              {},
+	"create_files":             {},
+	"write_binary_file":        {},
+	"append_to_file":           {},
+	"update_file":              {},
+	"delete_files":             {},
+	"rotate_file":              {},
+	"move_file":                {},
+	"copy_file":                {},
+	"create_directory":         {},
+	"update_file_lines":        {},
+	"delete_file_lines":        {},
+	"insert_file_lines":        {},
+	"insert_at_pattern":        {},
+	"replace_pattern":          {},
+	"find_file_part":           {},
+	"replace_file_part":        {},
+	"replace_file_parts":       {},
+	"undo_edit":                {},
+	"validate_files":           {},
+	"apply_header":             {},
+	"api_readiness":            {},
+	"analyze_files":            {},
+	"request_approval":         {},
+	"detect_current_project":   {},
+	"check_docs":               {},
+	"check_allowed_paths":      {},
+	"format_file":              {},
+	"find_no_final_newline":    {},
+	"find_long_lines":          {},
+	"find_duplicate_blocks":    {},
+	"replace_mappings":         {},
+	"keep_lines":               {},
+	"list_imports":             {},
+	"list_generate_directives": {},
+	"convert_line_endings":     {},
+	"convert_indentation":      {},
+	"diff_directories":         {},
+	"get_changed_files":        {},
+	"find_large_functions":     {},
+	"find_symbol":              {},
+	"file_call_graph":          {},
+	"diff_symbols":             {},
+	"find_untested_functions":  {},
+	"fill_config_defaults":     {},
+	"extract_function":         {},
+	"inline_symbol":            {},
+	"implement_interface":      {},
+	"strip_comments":           {},
+	"toggle_comment":           {},
+	"extract_strings":          {},
+	"lock_file":                {},
+	"unlock_file":              {},
+	"list_file_locks":          {},
+	"check_go_module":          {},
+	"check_import_order":       {},
+	"check_gofmt":              {},
+	"check_struct_tags":        {},
+	"check_naming":             {},
+	"check_json_consistency":   {},
+	"read_file_stream":         {},
+	"request_confirmation":     {},
 }
@@ -0,0 +1,250 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ConvertIndentationTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ConvertIndentationTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "convert_indentation",
+			Description: "Convert the leading indentation of a single file between tabs and spaces, leaving whitespace after the indentation untouched. Go files are formatted with gofmt instead",
+			QuickHelp:   "Swap leading tabs and spaces in a file",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File whose indentation to convert"),
+				IndentFromProperty.Required(),
+				IndentToProperty.Required(),
+				IndentWidthProperty,
+			},
+		}),
+	})
+}
+
+// ConvertIndentationTool swaps the leading indentation unit of a file.
+type ConvertIndentationTool struct {
+	*mcputil.ToolBase
+}
+
+// IndentUnit identifies the character used for leading indentation.
+type IndentUnit string
+
+const (
+	TabsIndent   IndentUnit = "tabs"   // One tab per indentation level
+	SpacesIndent IndentUnit = "spaces" // Width spaces per indentation level
+)
+
+// Validate checks if the IndentUnit has a valid value.
+func (iu IndentUnit) Validate() (err error) {
+	switch iu {
+	case TabsIndent:
+	case SpacesIndent:
+	default:
+		err = fmt.Errorf("indentation must be '%s' or '%s', got '%s'",
+			TabsIndent,
+			SpacesIndent,
+			iu,
+		)
+	}
+	return err
+}
+
+// Handle processes the convert_indentation tool request and rewrites the file's indentation.
+func (t *ConvertIndentationTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var from string
+	var to string
+	var width int
+	var content string
+	var converted string
+	var formatter string
+	var changedLines []int
+
+	logger.Info("Tool called", "tool", "convert_indentation")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	from, err = IndentFromProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	err = IndentUnit(from).Validate()
+	if err != nil {
+		err = fmt.Errorf("invalid from: %w", err)
+		goto end
+	}
+
+	to, err = IndentToProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	err = IndentUnit(to).Validate()
+	if err != nil {
+		err = fmt.Errorf("invalid to: %w", err)
+		goto end
+	}
+
+	if from == to {
+		err = fmt.Errorf("from and to must differ, both are '%s'", from)
+		goto end
+	}
+
+	width, err = IndentWidthProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if width < 1 {
+		err = fmt.Errorf("width must be at least 1, got %d", width)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "convert_indentation",
+		"path", path,
+		"from", from,
+		"to", to,
+		"width", width)
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	if isBinaryContent([]byte(content)) {
+		err = fmt.Errorf("cannot convert indentation of binary file: %s", path)
+		goto end
+	}
+
+	if langutil.DetectLanguage(path) == langutil.GoLanguage {
+		// gofmt owns Go indentation, and only ever indents with tabs
+		converted, err = gofmtIndentation(path, content, IndentUnit(to))
+		formatter = "gofmt"
+	} else {
+		converted = convertIndentation(content, IndentUnit(to), width)
+	}
+	if err != nil {
+		goto end
+	}
+
+	changedLines = diffLineNumbers(content, converted)
+	if len(changedLines) > 0 {
+		err = mcputil.WriteFile(ctx, t.Config(), path, converted)
+		if err != nil {
+			err = fmt.Errorf("failed to write %s: %v", path, err)
+			goto end
+		}
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+	}
+
+	logger.Info("Tool completed", "tool", "convert_indentation",
+		"path", path,
+		"lines_changed", len(changedLines))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":          path,
+		"from":          from,
+		"to":            to,
+		"width":         width,
+		"formatter":     formatter,
+		"lines_changed": len(changedLines),
+		"changed_lines": changedLines,
+	})
+
+end:
+	return result, err
+}
+
+// gofmtIndentation formats Go source with gofmt, which indents with tabs.
+func gofmtIndentation(path, content string, to IndentUnit) (formatted string, err error) {
+	var out []byte
+
+	if to != TabsIndent {
+		err = fmt.Errorf("gofmt indents Go files with tabs; cannot convert %s to %s", path, to)
+		goto end
+	}
+
+	out, err = format.Source([]byte(content))
+	if err != nil {
+		err = fmt.Errorf("failed to gofmt %s: %w", path, err)
+		goto end
+	}
+	formatted = string(out)
+
+end:
+	return formatted, err
+}
+
+// convertIndentation rewrites the leading whitespace of each line of content
+// to the indentation unit to, where width spaces make one level. Trailing
+// spaces that do not fill a whole level are kept as alignment. Everything
+// after the leading whitespace, and line endings, are left untouched.
+func convertIndentation(content string, to IndentUnit, width int) string {
+	var lines []string
+	var indentLen int
+	var spaces int
+	var sb strings.Builder
+
+	lines = strings.Split(content, "\n")
+	for i, line := range lines {
+		indentLen = len(line) - len(strings.TrimLeft(line, " \t"))
+		if indentLen == 0 {
+			continue
+		}
+		sb.Reset()
+		spaces = 0
+		for _, c := range line[:indentLen] {
+			switch {
+			case to == SpacesIndent && c == '\t':
+				sb.WriteString(strings.Repeat(" ", width))
+			case to == TabsIndent && c == ' ':
+				spaces++
+				if spaces == width {
+					sb.WriteByte('\t')
+					spaces = 0
+				}
+			case to == TabsIndent && c == '\t':
+				// Spaces short of a full level are absorbed by the tab that follows them
+				sb.WriteByte('\t')
+				spaces = 0
+			default:
+				sb.WriteRune(c)
+			}
+		}
+		sb.WriteString(strings.Repeat(" ", spaces))
+		lines[i] = sb.String() + line[indentLen:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffLineNumbers returns the 1-based numbers of the lines that differ
+// between before and after, which have the same number of lines when only
+// indentation changed. Lines added or removed at the end count as changed.
+func diffLineNumbers(before, after string) (numbers []int) {
+	var a, b []string
+	var n int
+
+	a = strings.Split(before, "\n")
+	b = strings.Split(after, "\n")
+	n = max(len(a), len(b))
+
+	numbers = make([]int, 0)
+	for i := 0; i < n; i++ {
+		if i < len(a) && i < len(b) && a[i] == b[i] {
+			continue
+		}
+		numbers = append(numbers, i+1)
+	}
+	return numbers
+}
@@ -0,0 +1,160 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ConvertIndentationDirPrefix = "convert-indentation-tool-test"
+
+// Convert indentation tool result type
+type ConvertIndentationResult struct {
+	Path         string `json:"path"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Width        int    `json:"width"`
+	Formatter    string `json:"formatter"`
+	LinesChanged int    `json:"lines_changed"`
+	ChangedLines []int  `json:"changed_lines"`
+}
+
+type convertIndentationResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedChangedLines []int
+	ExpectedFormatter    string
+	ExpectedContent      string
+}
+
+func requireConvertIndentationResult(t *testing.T, result *ConvertIndentationResult, err error, path string, opts convertIndentationResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectedChangedLines, result.ChangedLines, "Changed lines should match")
+	assert.Equal(t, len(opts.ExpectedChangedLines), result.LinesChanged, "Lines changed count should match")
+	assert.Equal(t, opts.ExpectedFormatter, result.Formatter, "Formatter should match")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "Should read converted file")
+	assert.Equal(t, opts.ExpectedContent, string(content), "File content should match")
+}
+
+func TestConvertIndentationTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("convert_indentation")
+	require.NotNil(t, tool, "convert_indentation tool should be registered")
+
+	setup := func(t *testing.T, name, content string) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(ConvertIndentationDirPrefix)
+		ff := tf.AddFileFixture(name, &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	convert := func(path, from, to string, extra mcputil.Params) (*ConvertIndentationResult, error) {
+		params := mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"from":          from,
+			"to":            to,
+		}
+		for k, v := range extra {
+			params[k] = v
+		}
+		return mcputil.GetToolResult[ConvertIndentationResult](mcputil.CallResult(mcputil.CallTool(tool, mcputil.NewMockRequest(params))), "Should not error converting indentation")
+	}
+
+	t.Run("SpacesToTabs_ShouldOnlyChangeLeadingIndentation", func(t *testing.T) {
+		tf, ff := setup(t, "script.sh", "if true; then\n    echo \"a    b\"\n      x=1\n\r\nfi\n")
+		defer tf.Cleanup()
+
+		result, err := convert(ff.Filepath, "spaces", "tabs", nil)
+		requireConvertIndentationResult(t, result, err, ff.Filepath, convertIndentationResultOpts{
+			ExpectedChangedLines: []int{2, 3},
+			ExpectedContent:      "if true; then\n\techo \"a    b\"\n\t  x=1\n\r\nfi\n",
+		})
+	})
+
+	t.Run("TabsToSpaces_ShouldUseWidth", func(t *testing.T) {
+		tf, ff := setup(t, "config.yaml", "root:\n\tchild:\n\t\tkey: \"a\tb\"\r\n")
+		defer tf.Cleanup()
+
+		result, err := convert(ff.Filepath, "tabs", "spaces", mcputil.Params{"width": 2})
+		requireConvertIndentationResult(t, result, err, ff.Filepath, convertIndentationResultOpts{
+			ExpectedChangedLines: []int{2, 3},
+			ExpectedContent:      "root:\n  child:\n    key: \"a\tb\"\r\n",
+		})
+	})
+
+	t.Run("GoFile_ShouldDeferToGofmt", func(t *testing.T) {
+		tf, ff := setup(t, "main.go", "package main\n\nfunc main() {\n    println(\"hi\")\n}\n")
+		defer tf.Cleanup()
+
+		result, err := convert(ff.Filepath, "spaces", "tabs", nil)
+		requireConvertIndentationResult(t, result, err, ff.Filepath, convertIndentationResultOpts{
+			ExpectedChangedLines: []int{4},
+			ExpectedFormatter:    "gofmt",
+			ExpectedContent:      "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+		})
+	})
+
+	t.Run("GoFileToSpaces_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "main.go", "package main\n")
+		defer tf.Cleanup()
+
+		result, err := convert(ff.Filepath, "tabs", "spaces", nil)
+		requireConvertIndentationResult(t, result, err, ff.Filepath, convertIndentationResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "gofmt indents Go files with tabs",
+		})
+	})
+
+	t.Run("SameUnits_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "notes.txt", "\tnote\n")
+		defer tf.Cleanup()
+
+		result, err := convert(ff.Filepath, "tabs", "tabs", nil)
+		requireConvertIndentationResult(t, result, err, ff.Filepath, convertIndentationResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "from and to must differ",
+		})
+	})
+
+	t.Run("DryRun_ShouldNotWrite", func(t *testing.T) {
+		tf, ff := setup(t, "notes.txt", "\tnote\n")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"from":          "tabs",
+			"to":            "spaces",
+			"dry_run":       true,
+		})
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing conversion")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Contains(t, result.Files[0].Diff, "-\tnote\n+    note\n", "Diff should show the new indentation")
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "\tnote\n", string(content), "Dry run should not modify the file")
+	})
+}
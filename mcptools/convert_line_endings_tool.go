@@ -0,0 +1,219 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ConvertLineEndingsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ConvertLineEndingsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "convert_line_endings",
+			Description: "Convert line endings of text files to LF or CRLF, skipping binary files",
+			QuickHelp:   "Force LF or CRLF line endings",
+			Mutating:    true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				PathProperty,
+				PathsProperty,
+				LineEndingProperty.Required(),
+				RecursiveProperty,
+				ExtensionsProperty,
+				DryRunProperty,
+			},
+			Requires: []mcputil.Requirement{
+				mcputil.RequiresOneOf{
+					ParamNames: []string{"path", "paths"},
+				},
+			},
+		}),
+	})
+}
+
+// ConvertLineEndingsTool rewrites the line endings of text files to a single style.
+type ConvertLineEndingsTool struct {
+	*mcputil.ToolBase
+}
+
+// LineEnding identifies a line ending style.
+type LineEnding string
+
+const (
+	LFLineEnding   LineEnding = "lf"   // Unix style, "\n"
+	CRLFLineEnding LineEnding = "crlf" // Windows style, "\r\n"
+)
+
+// Validate checks if the LineEnding has a valid value.
+func (le LineEnding) Validate() (err error) {
+	switch le {
+	case LFLineEnding:
+	case CRLFLineEnding:
+	default:
+		err = fmt.Errorf("to must be '%s' or '%s', got '%s'",
+			LFLineEnding,
+			CRLFLineEnding,
+			le,
+		)
+	}
+	return err
+}
+
+// lineEndingFileResult reports the outcome of converting a single file.
+type lineEndingFileResult struct {
+	Path    string `json:"path"`
+	Changed bool   `json:"changed"`
+	Binary  bool   `json:"binary,omitempty"`
+}
+
+// Handle processes the convert_line_endings tool request and converts each file.
+func (t *ConvertLineEndingsTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var paths []string
+	var to string
+	var recursive bool
+	var extensions []string
+	var dryRun bool
+	var files []string
+	var fileResults []lineEndingFileResult
+	var fr lineEndingFileResult
+	var changedCount int
+
+	logger.Info("Tool called", "tool", "convert_line_endings")
+
+	path, err = PathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	paths, err = PathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+	if path != "" {
+		paths = append(paths, path)
+	}
+
+	to, err = LineEndingProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	err = LineEnding(to).Validate()
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid extensions array: %v", err)
+		goto end
+	}
+
+	dryRun, err = DryRunProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "convert_line_endings",
+		"paths", paths,
+		"to", to,
+		"recursive", recursive,
+		"extensions", extensions,
+		"dry_run", dryRun)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: extensions,
+	})
+	if err != nil {
+		goto end
+	}
+
+	fileResults = make([]lineEndingFileResult, 0, len(files))
+	for _, fp := range files {
+		fr, err = t.convertFile(ctx, fp, LineEnding(to), dryRun)
+		if err != nil {
+			goto end
+		}
+		if fr.Changed {
+			changedCount++
+			if !dryRun {
+				recordFileChange(ctx, req, mcputil.UpdatedFileOp, fp)
+			}
+		}
+		fileResults = append(fileResults, fr)
+	}
+
+	logger.Info("Tool completed", "tool", "convert_line_endings",
+		"file_count", len(fileResults),
+		"changed_count", changedCount,
+		"dry_run", dryRun)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"to":            to,
+		"files":         fileResults,
+		"file_count":    len(fileResults),
+		"changed_count": changedCount,
+		"dry_run":       dryRun,
+	})
+
+end:
+	return result, err
+}
+
+// convertFile converts a single file, writing it back only if it changed and
+// this is not a dry run.
+func (t *ConvertLineEndingsTool) convertFile(ctx context.Context, fp string, to LineEnding, dryRun bool) (fr lineEndingFileResult, err error) {
+	var content []byte
+	var converted []byte
+
+	fr.Path = fp
+
+	content, err = os.ReadFile(fp)
+	if err != nil {
+		err = fmt.Errorf("failed to read %s: %v", fp, err)
+		goto end
+	}
+
+	if isBinaryContent(content) {
+		fr.Binary = true
+		goto end
+	}
+
+	converted = convertLineEndings(content, to)
+	fr.Changed = !bytes.Equal(content, converted)
+	if !fr.Changed || dryRun {
+		goto end
+	}
+
+	err = mcputil.WriteFile(ctx, t.Config(), fp, string(converted))
+	if err != nil {
+		err = fmt.Errorf("failed to write %s: %v", fp, err)
+	}
+
+end:
+	return fr, err
+}
+
+// convertLineEndings returns content with every LF or CRLF line ending
+// rewritten to the requested style. Lone CR characters are left as-is.
+func convertLineEndings(content []byte, to LineEnding) []byte {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if to == CRLFLineEnding {
+		content = bytes.ReplaceAll(content, []byte("\n"), []byte("\r\n"))
+	}
+	return content
+}
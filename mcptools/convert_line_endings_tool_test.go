@@ -0,0 +1,147 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ConvertLineEndingsDirPrefix = "convert-line-endings-tool-test"
+
+// Convert line endings tool result type
+type ConvertLineEndingsResult struct {
+	To    string `json:"to"`
+	Files []struct {
+		Path    string `json:"path"`
+		Changed bool   `json:"changed"`
+		Binary  bool   `json:"binary"`
+	} `json:"files"`
+	FileCount    int  `json:"file_count"`
+	ChangedCount int  `json:"changed_count"`
+	DryRun       bool `json:"dry_run"`
+}
+
+type convertLineEndingsResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedFileCount    int
+	ExpectedChangedCount int
+}
+
+func requireConvertLineEndingsResult(t *testing.T, result *ConvertLineEndingsResult, err error, opts convertLineEndingsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedFileCount, result.FileCount, "File count should match")
+	assert.Equal(t, opts.ExpectedChangedCount, result.ChangedCount, "Changed count should match")
+}
+
+func TestConvertLineEndingsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("convert_line_endings")
+	require.NotNil(t, tool, "convert_line_endings tool should be registered")
+
+	t.Run("ToCRLF_ShouldConvertTextAndSkipBinary", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ConvertLineEndingsDirPrefix)
+		defer tf.Cleanup()
+
+		lf := tf.AddFileFixture("lf.txt", &fsfix.FileFixtureArgs{Content: "one\ntwo\n"})
+		crlf := tf.AddFileFixture("crlf.txt", &fsfix.FileFixtureArgs{Content: "one\r\ntwo\r\n"})
+		bin := tf.AddFileFixture("data.bin", &fsfix.FileFixtureArgs{Content: "\x00\n\x01\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"to":            "crlf",
+		})
+
+		result, err := mcputil.GetToolResult[ConvertLineEndingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error converting line endings")
+		requireConvertLineEndingsResult(t, result, err, convertLineEndingsResultOpts{
+			ExpectedFileCount:    3,
+			ExpectedChangedCount: 1,
+		})
+
+		content, err := os.ReadFile(lf.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "one\r\ntwo\r\n", string(content), "LF file should be converted to CRLF")
+
+		content, err = os.ReadFile(crlf.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "one\r\ntwo\r\n", string(content), "CRLF file should be unchanged")
+
+		content, err = os.ReadFile(bin.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "\x00\n\x01\n", string(content), "Binary file should be unchanged")
+	})
+
+	t.Run("DryRun_ShouldNotModifyFiles", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ConvertLineEndingsDirPrefix)
+		defer tf.Cleanup()
+
+		crlf := tf.AddFileFixture("crlf.txt", &fsfix.FileFixtureArgs{Content: "one\r\ntwo\r\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         []any{crlf.Filepath},
+			"to":            "lf",
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[ConvertLineEndingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error on dry run")
+		requireConvertLineEndingsResult(t, result, err, convertLineEndingsResultOpts{
+			ExpectedFileCount:    1,
+			ExpectedChangedCount: 1,
+		})
+		assert.True(t, result.DryRun, "Result should report dry run")
+
+		content, err := os.ReadFile(crlf.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "one\r\ntwo\r\n", string(content), "Dry run should not modify the file")
+	})
+
+	t.Run("InvalidTarget_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ConvertLineEndingsDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"to":            "cr",
+		})
+
+		result, err := mcputil.GetToolResult[ConvertLineEndingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for invalid target")
+		requireConvertLineEndingsResult(t, result, err, convertLineEndingsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "'lf' or 'crlf'",
+		})
+	})
+}
@@ -0,0 +1,282 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CopyFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CopyFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "copy_file",
+			Description: "Copy a file, or with recursive a directory tree, within the allowed paths byte for byte without reading it through the model. Copies keep the permissions of the originals",
+			QuickHelp:   "Copy a file or directory tree",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				SourceProperty.Required().Description("File or directory to copy"),
+				DestinationProperty.Required().Description("Path to copy the file or directory to"),
+				OverwriteProperty.Description("Replace the destination, or for a directory the files in it, if it already exists"),
+				CreateDirsProperty.Description("Create the destination's parent directories if needed"),
+				CopyRecursiveProperty,
+				ConfirmationTokenProperty,
+			},
+		}),
+	})
+}
+
+// CopyFileTool copies files and directory trees within the allowed paths.
+type CopyFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the copy_file tool request and copies the source file or
+// directory to the destination.
+func (t *CopyFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var source string
+	var destination string
+	var overwrite bool
+	var createDirs bool
+	var recursive bool
+	var sourceAbs, destinationAbs string
+	var sourceInfo, info os.FileInfo
+	var existed bool
+	var stats copyStats
+
+	logger.Info("Tool called", "tool", "copy_file")
+
+	source, err = SourceProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	destination, err = DestinationProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	overwrite, err = OverwriteProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	createDirs, err = CreateDirsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = CopyRecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "copy_file",
+		"source", source,
+		"destination", destination,
+		"overwrite", overwrite,
+		"create_dirs", createDirs,
+		"recursive", recursive)
+
+	if !t.IsAllowedPath(source) {
+		err = fmt.Errorf("access denied: path not allowed: %s", source)
+		goto end
+	}
+	if !t.IsAllowedPath(destination) {
+		err = fmt.Errorf("access denied: path not allowed: %s", destination)
+		goto end
+	}
+
+	sourceAbs, err = filepath.Abs(source)
+	if err != nil {
+		goto end
+	}
+	destinationAbs, err = filepath.Abs(destination)
+	if err != nil {
+		goto end
+	}
+	if sourceAbs == destinationAbs {
+		err = fmt.Errorf("source and destination are the same path: %s", source)
+		goto end
+	}
+
+	sourceInfo, err = os.Stat(source)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = fmt.Errorf("source does not exist: %s", source)
+		goto end
+	case err != nil:
+		err = fmt.Errorf("error checking source: %v", err)
+		goto end
+	case !sourceInfo.IsDir():
+	case !recursive:
+		err = fmt.Errorf("source is a directory: %s (set recursive to copy it)", source)
+		goto end
+	case strings.HasPrefix(destinationAbs, sourceAbs+string(filepath.Separator)):
+		err = fmt.Errorf("cannot copy directory %s into itself", source)
+		goto end
+	}
+
+	info, err = os.Stat(destination)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = nil
+	case err != nil:
+		err = fmt.Errorf("error checking destination: %v", err)
+		goto end
+	case info.IsDir() && !sourceInfo.IsDir():
+		err = fmt.Errorf("destination is a directory: %s", destination)
+		goto end
+	case !info.IsDir() && sourceInfo.IsDir():
+		err = fmt.Errorf("destination is not a directory: %s", destination)
+		goto end
+	case !overwrite:
+		err = fmt.Errorf("destination already exists: %s (set overwrite to replace it)", destination)
+		goto end
+	default:
+		existed = true
+	}
+
+	if existed {
+		err = mcputil.ConfirmOperation(ctx, mcputil.OverwriteOperation, destination)
+		if err != nil {
+			goto end
+		}
+	}
+
+	// Create parent directories if requested, unless only previewing
+	switch {
+	case createDirs && !mcputil.IsPreview(ctx):
+		err = os.MkdirAll(filepath.Dir(destination), 0755)
+		if err != nil {
+			err = fmt.Errorf("failed to create directories: %v", err)
+			goto end
+		}
+	case !createDirs:
+		info, err = os.Stat(filepath.Dir(destination))
+		if err != nil || !info.IsDir() {
+			err = fmt.Errorf("destination directory does not exist: %s (set create_dirs to create it)", filepath.Dir(destination))
+			goto end
+		}
+	}
+
+	stats.skipped = make([]string, 0)
+	if sourceInfo.IsDir() {
+		err = t.copyTree(ctx, source, destination, &stats)
+	} else {
+		err = t.copyOne(ctx, source, destination, &stats)
+	}
+	recordFileChange(ctx, req, mcputil.CreatedFileOp, stats.created...)
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, stats.updated...)
+	if err != nil {
+		err = fmt.Errorf("failed to copy %s after copying %d files: %v", source, stats.files(), err)
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "copy_file",
+		"source", source,
+		"destination", destination,
+		"files_copied", stats.files(),
+		"bytes_copied", stats.bytes)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":      true,
+		"source":       source,
+		"destination":  destination,
+		"files_copied": stats.files(),
+		"bytes_copied": stats.bytes,
+		"overwritten":  existed,
+		"skipped":      stats.skipped,
+		"message":      fmt.Sprintf("Copied %d files (%d bytes) from %s to %s", stats.files(), stats.bytes, source, destination),
+	})
+
+end:
+	return result, err
+}
+
+// copyStats tallies what copy_file has copied so far.
+type copyStats struct {
+	created []string // Files created by the copy
+	updated []string // Existing files replaced by the copy
+	skipped []string // Entries that are not regular files and were not copied
+	bytes   int64    // Total bytes copied
+}
+
+// files returns the number of files copied.
+func (s *copyStats) files() int {
+	return len(s.created) + len(s.updated)
+}
+
+// copyOne copies the file at source to destination and adds it to stats.
+func (t *CopyFileTool) copyOne(ctx context.Context, source, destination string, stats *copyStats) (err error) {
+	var n int64
+	var existed bool
+
+	_, err = os.Stat(destination)
+	existed = err == nil
+
+	n, err = mcputil.CopyFile(ctx, t.Config(), source, destination)
+	if err != nil {
+		goto end
+	}
+
+	stats.bytes += n
+	if existed {
+		stats.updated = append(stats.updated, destination)
+	} else {
+		stats.created = append(stats.created, destination)
+	}
+
+end:
+	return err
+}
+
+// copyTree copies the directory tree at source to destination, creating
+// directories with the permissions of their originals, and adds each file
+// to stats. Symlinks and other entries that are not regular files or
+// directories are skipped rather than followed.
+func (t *CopyFileTool) copyTree(ctx context.Context, source, destination string, stats *copyStats) error {
+	return filepath.WalkDir(source, func(path string, d fs.DirEntry, walkErr error) (err error) {
+		var rel, target string
+		var info fs.FileInfo
+
+		if walkErr != nil {
+			err = walkErr
+			goto end
+		}
+
+		rel, err = filepath.Rel(source, path)
+		if err != nil {
+			goto end
+		}
+		target = filepath.Join(destination, rel)
+
+		switch {
+		case d.IsDir():
+			if mcputil.IsPreview(ctx) {
+				goto end
+			}
+			info, err = d.Info()
+			if err != nil {
+				goto end
+			}
+			err = os.MkdirAll(target, info.Mode().Perm())
+		case d.Type().IsRegular():
+			err = t.copyOne(ctx, path, target, stats)
+		default:
+			stats.skipped = append(stats.skipped, path)
+		}
+
+	end:
+		return err
+	})
+}
@@ -0,0 +1,229 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CopyFileDirPrefix = "copy-file-tool-test"
+
+// Copy file tool result type
+type CopyFileResult struct {
+	Success     bool     `json:"success"`
+	Source      string   `json:"source"`
+	Destination string   `json:"destination"`
+	FilesCopied int      `json:"files_copied"`
+	BytesCopied int64    `json:"bytes_copied"`
+	Overwritten bool     `json:"overwritten"`
+	Skipped     []string `json:"skipped"`
+	Message     string   `json:"message"`
+}
+
+type copyFileResultOpts struct {
+	ExpectError         bool
+	ExpectedErrorMsg    string
+	ExpectedFiles       int
+	ExpectedBytes       int64
+	ExpectedOverwritten bool
+}
+
+func requireCopyFileResult(t *testing.T, result *CopyFileResult, err error, opts copyFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedFiles, result.FilesCopied, "Files copied should match")
+	assert.Equal(t, opts.ExpectedBytes, result.BytesCopied, "Bytes copied should match")
+	assert.Equal(t, opts.ExpectedOverwritten, result.Overwritten, "Overwritten should match")
+}
+
+func TestCopyFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("copy_file")
+	require.NotNil(t, tool, "copy_file tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	copyFile := func(params mcputil.Params) (*CopyFileResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[CopyFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call copy_file")
+	}
+
+	t.Run("File_ShouldCopyContentAndMode", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("file-project", nil)
+		source := pf.AddFileFixture("run.sh", &fsfix.FileFixtureArgs{
+			Content: "#!/bin/sh\necho hi\n",
+		})
+		setup(t, tf)
+		require.NoError(t, os.Chmod(source.Filepath, 0755), "Should make source executable")
+
+		destination := filepath.Join(pf.Dir(), "run-copy.sh")
+		result, err := copyFile(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": destination,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectedFiles: 1,
+			ExpectedBytes: int64(len("#!/bin/sh\necho hi\n")),
+		})
+		requireFileContent(t, source.Filepath, "#!/bin/sh\necho hi\n")
+		requireFileContent(t, destination, "#!/bin/sh\necho hi\n")
+
+		info, err := os.Stat(destination)
+		require.NoError(t, err, "Should stat copy")
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), "Copy should keep the source permissions")
+	})
+
+	t.Run("Directory_WithoutRecursive_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("dir-project", nil)
+		pf.AddFileFixture("src/a.txt", &fsfix.FileFixtureArgs{Content: "a\n"})
+		setup(t, tf)
+
+		result, err := copyFile(mcputil.Params{
+			"source":      filepath.Join(pf.Dir(), "src"),
+			"destination": filepath.Join(pf.Dir(), "dst"),
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "set recursive to copy it",
+		})
+	})
+
+	t.Run("Directory_Recursive_ShouldCopyTree", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("tree-project", nil)
+		pf.AddFileFixture("src/a.txt", &fsfix.FileFixtureArgs{Content: "a\n"})
+		pf.AddFileFixture("src/nested/b.txt", &fsfix.FileFixtureArgs{Content: "bb\n"})
+		setup(t, tf)
+
+		destination := filepath.Join(pf.Dir(), "dst")
+		result, err := copyFile(mcputil.Params{
+			"source":      filepath.Join(pf.Dir(), "src"),
+			"destination": destination,
+			"recursive":   true,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectedFiles: 2,
+			ExpectedBytes: 5,
+		})
+		requireFileContent(t, filepath.Join(destination, "a.txt"), "a\n")
+		requireFileContent(t, filepath.Join(destination, "nested", "b.txt"), "bb\n")
+	})
+
+	t.Run("DirectoryIntoItself_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("self-project", nil)
+		pf.AddFileFixture("src/a.txt", &fsfix.FileFixtureArgs{Content: "a\n"})
+		setup(t, tf)
+
+		result, err := copyFile(mcputil.Params{
+			"source":      filepath.Join(pf.Dir(), "src"),
+			"destination": filepath.Join(pf.Dir(), "src", "copy"),
+			"recursive":   true,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "into itself",
+		})
+	})
+
+	t.Run("ExistingDestination_ShouldRequireOverwrite", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("overwrite-project", nil)
+		source := pf.AddFileFixture("source.txt", &fsfix.FileFixtureArgs{
+			Content: "new\n",
+		})
+		target := pf.AddFileFixture("target.txt", &fsfix.FileFixtureArgs{
+			Content: "old\n",
+		})
+		setup(t, tf)
+
+		result, err := copyFile(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": target.Filepath,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "destination already exists",
+		})
+		requireFileContent(t, target.Filepath, "old\n")
+
+		result, err = copyFile(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": target.Filepath,
+			"overwrite":   true,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectedFiles:       1,
+			ExpectedBytes:       4,
+			ExpectedOverwritten: true,
+		})
+		requireFileContent(t, target.Filepath, "new\n")
+	})
+
+	t.Run("CreateDirs_ShouldCreateDestinationParent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CopyFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("create-dirs-project", nil)
+		source := pf.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{
+			Content: "copied\n",
+		})
+		setup(t, tf)
+
+		destination := filepath.Join(pf.Dir(), "missing", "file.txt")
+		result, err := copyFile(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": destination,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "set create_dirs to create it",
+		})
+
+		result, err = copyFile(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": destination,
+			"create_dirs": true,
+		})
+		requireCopyFileResult(t, result, err, copyFileResultOpts{
+			ExpectedFiles: 1,
+			ExpectedBytes: 7,
+		})
+		requireFileContent(t, destination, "copied\n")
+	})
+}
@@ -0,0 +1,132 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CreateDirectoryTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CreateDirectoryTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "create_directory",
+			Description: "Create a directory, and any missing parents, within the allowed paths, such as to scaffold a project's structure before writing files into it. Succeeds without change if the directory already exists",
+			QuickHelp:   "Create an empty directory",
+			Mutating:    true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Directory to create"),
+				PermissionsProperty,
+			},
+		}),
+	})
+}
+
+// CreateDirectoryTool creates directories within the allowed paths.
+type CreateDirectoryTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the create_directory tool request and creates the
+// directory with its missing parents.
+func (t *CreateDirectoryTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var permissions string
+	var perm os.FileMode
+	var existed bool
+	var info os.FileInfo
+	var message string
+
+	logger.Info("Tool called", "tool", "create_directory")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	permissions, err = PermissionsProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	perm, err = parsePermissions(permissions)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "create_directory",
+		"path", path,
+		"permissions", permissions)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = nil
+	case err != nil:
+		err = fmt.Errorf("error checking directory: %v", err)
+		goto end
+	case !info.IsDir():
+		err = fmt.Errorf("path exists and is not a directory: %s", path)
+		goto end
+	default:
+		existed = true
+	}
+
+	message = fmt.Sprintf("Directory %s already exists", path)
+	if !existed {
+		err = os.MkdirAll(path, perm)
+		if err != nil {
+			err = fmt.Errorf("failed to create directory: %v", err)
+			goto end
+		}
+		// Apply the permissions exactly, as MkdirAll's are reduced by the umask
+		err = os.Chmod(path, perm)
+		if err != nil {
+			err = fmt.Errorf("failed to set directory permissions: %v", err)
+			goto end
+		}
+		message = fmt.Sprintf("Created directory %s", path)
+	}
+
+	logger.Info("Tool completed", "tool", "create_directory",
+		"path", path,
+		"already_existed", existed)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":         true,
+		"path":            path,
+		"already_existed": existed,
+		"permissions":     fmt.Sprintf("%04o", perm),
+		"message":         message,
+	})
+
+end:
+	return result, err
+}
+
+// parsePermissions parses an octal permission string such as "0755" or "755".
+func parsePermissions(s string) (perm os.FileMode, err error) {
+	var mode uint64
+
+	mode, err = strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		err = fmt.Errorf("permissions must be an octal mode from 0000 to 0777, got %q", s)
+		goto end
+	}
+	perm = os.FileMode(mode)
+
+end:
+	return perm, err
+}
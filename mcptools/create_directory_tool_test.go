@@ -0,0 +1,156 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CreateDirectoryDirPrefix = "create-directory-tool-test"
+
+// Create directory tool result type
+type CreateDirectoryResult struct {
+	Success        bool   `json:"success"`
+	Path           string `json:"path"`
+	AlreadyExisted bool   `json:"already_existed"`
+	Permissions    string `json:"permissions"`
+	Message        string `json:"message"`
+}
+
+type createDirectoryResultOpts struct {
+	ExpectError            bool
+	ExpectedErrorMsg       string
+	ExpectedAlreadyExisted bool
+	ExpectedPerm           os.FileMode
+}
+
+func requireCreateDirectoryResult(t *testing.T, result *CreateDirectoryResult, err error, opts createDirectoryResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedAlreadyExisted, result.AlreadyExisted, "Already existed should match")
+
+	info, err := os.Stat(result.Path)
+	require.NoError(t, err, "Directory should exist")
+	assert.True(t, info.IsDir(), "Path should be a directory")
+	if opts.ExpectedPerm != 0 {
+		assert.Equal(t, opts.ExpectedPerm, info.Mode().Perm(), "Permissions should match")
+	}
+}
+
+func TestCreateDirectoryTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("create_directory")
+	require.NotNil(t, tool, "create_directory tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	createDirectory := func(params mcputil.Params) (*CreateDirectoryResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[CreateDirectoryResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call create_directory")
+	}
+
+	t.Run("NewNestedDirectory_ShouldCreateWithDefaultPermissions", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CreateDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("scaffold-project", nil)
+		setup(t, tf)
+
+		result, err := createDirectory(mcputil.Params{
+			"path": filepath.Join(pf.Dir(), "internal", "api"),
+		})
+		requireCreateDirectoryResult(t, result, err, createDirectoryResultOpts{
+			ExpectedPerm: 0755,
+		})
+	})
+
+	t.Run("CustomPermissions_ShouldApplyThem", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CreateDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("perm-project", nil)
+		setup(t, tf)
+
+		result, err := createDirectory(mcputil.Params{
+			"path":        filepath.Join(pf.Dir(), "private"),
+			"permissions": "0700",
+		})
+		requireCreateDirectoryResult(t, result, err, createDirectoryResultOpts{
+			ExpectedPerm: 0700,
+		})
+	})
+
+	t.Run("ExistingDirectory_ShouldReportAlreadyExisted", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CreateDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("existing-project", nil)
+		setup(t, tf)
+
+		result, err := createDirectory(mcputil.Params{
+			"path": pf.Dir(),
+		})
+		requireCreateDirectoryResult(t, result, err, createDirectoryResultOpts{
+			ExpectedAlreadyExisted: true,
+		})
+	})
+
+	t.Run("ExistingFile_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CreateDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("file-project", nil)
+		file := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+			Content: "not a directory\n",
+		})
+		setup(t, tf)
+
+		result, err := createDirectory(mcputil.Params{
+			"path": file.Filepath,
+		})
+		requireCreateDirectoryResult(t, result, err, createDirectoryResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not a directory",
+		})
+	})
+
+	t.Run("InvalidPermissions_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(CreateDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("invalid-project", nil)
+		setup(t, tf)
+
+		result, err := createDirectory(mcputil.Params{
+			"path":        filepath.Join(pf.Dir(), "bad"),
+			"permissions": "0789",
+		})
+		requireCreateDirectoryResult(t, result, err, createDirectoryResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "permissions must be an octal mode",
+		})
+	})
+}
@@ -17,6 +17,7 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "create_file",
 			Description: "Create a new file in allowed directories",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				RequiredPathProperty,
@@ -33,7 +34,7 @@ type CreateFileTool struct {
 }
 
 // Handle processes the create_file tool request and creates a new file with the specified content.
-func (t *CreateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *CreateFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var content string
 	var createDirs bool
@@ -75,8 +76,8 @@ func (t *CreateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (res
 		goto end
 	}
 
-	// Create parent directories if requested
-	if createDirs {
+	// Create parent directories if requested, unless only previewing
+	if createDirs && !mcputil.IsPreview(ctx) {
 		fileDir = filepath.Dir(filePath)
 		err = os.MkdirAll(fileDir, 0755)
 	}
@@ -86,12 +87,14 @@ func (t *CreateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (res
 	}
 
 	// Create the file
-	err = os.WriteFile(filePath, []byte(content), 0644)
+	err = mcputil.WriteFile(ctx, t.Config(), filePath, content)
 	if err != nil {
 		err = fmt.Errorf("failed to create file: %v", err)
 		goto end
 	}
 
+	recordFileChange(ctx, req, mcputil.CreatedFileOp, filePath)
+
 	logger.Info("Tool completed", "tool", "create_file", "success", true, "path", filePath)
 	result = mcputil.NewToolResultJSON(map[string]any{
 		"success":   true,
@@ -0,0 +1,317 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*CreateFilesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&CreateFilesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "create_files",
+			Description: "Create many files in one call, such as when scaffolding a project. Every path is checked before anything is written, and if writing a file fails the files already written are removed or restored, so either all files are created or none are",
+			QuickHelp:   "Create several files at once, all or nothing",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				FilesProperty.Required().Description("Files to create, each a {\"path\": \"...\", \"content\": \"...\"} object"),
+				CreateDirsProperty,
+				OverwriteProperty.Description("Replace files that already exist instead of failing"),
+				ConfirmationTokenProperty,
+			},
+		}),
+	})
+}
+
+// CreateFilesTool creates a set of files as a single operation, undoing the
+// files it wrote when a later one fails.
+type CreateFilesTool struct {
+	*mcputil.ToolBase
+}
+
+// CreatedFileResult reports a file written by create_files.
+type CreatedFileResult struct {
+	Path        string `json:"path"`
+	Size        int    `json:"size"`
+	Overwritten bool   `json:"overwritten"`
+}
+
+// fileSpec is a file to create along with what is needed to undo writing it.
+type fileSpec struct {
+	Path     string
+	Content  string
+	existed  bool
+	original []byte
+}
+
+// Handle processes the create_files tool request and creates every file or none.
+func (t *CreateFilesTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var rawFiles []any
+	var createDirs bool
+	var overwrite bool
+	var specs []fileSpec
+	var existing []string
+	var results []CreatedFileResult
+	var op mcputil.FileOperation
+
+	logger.Info("Tool called", "tool", "create_files")
+
+	rawFiles, err = FilesProperty.AnySlice(req)
+	if err != nil {
+		goto end
+	}
+
+	createDirs, err = CreateDirsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	overwrite, err = OverwriteProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	specs, err = parseFileSpecs(rawFiles)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "create_files",
+		"file_count", len(specs),
+		"create_dirs", createDirs,
+		"overwrite", overwrite)
+
+	existing, err = t.checkFileSpecs(specs, createDirs, overwrite)
+	if err != nil {
+		goto end
+	}
+
+	if len(existing) > 0 {
+		err = mcputil.ConfirmOperation(ctx, mcputil.OverwriteOperation, existing...)
+		if err != nil {
+			goto end
+		}
+	}
+
+	err = t.writeFileSpecs(ctx, specs, createDirs)
+	if err != nil {
+		goto end
+	}
+
+	results = make([]CreatedFileResult, len(specs))
+	for i, spec := range specs {
+		op = mcputil.CreatedFileOp
+		if spec.existed {
+			op = mcputil.UpdatedFileOp
+		}
+		recordFileChange(ctx, req, op, spec.Path)
+		results[i] = CreatedFileResult{
+			Path:        spec.Path,
+			Size:        len(spec.Content),
+			Overwritten: spec.existed,
+		}
+	}
+
+	logger.Info("Tool completed", "tool", "create_files",
+		"file_count", len(results),
+		"overwritten_count", len(existing))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":    true,
+		"files":      results,
+		"file_count": len(results),
+		"message":    fmt.Sprintf("Created %d file(s)", len(results)),
+	})
+
+end:
+	return result, err
+}
+
+// parseFileSpecs converts the raw files parameter into fileSpecs, rejecting
+// entries without a path or content and paths given more than once.
+func parseFileSpecs(raw []any) (specs []fileSpec, err error) {
+	var obj map[string]any
+	var ok bool
+	var path, content string
+	var absPath string
+	var seen map[string]NULL
+
+	if len(raw) == 0 {
+		err = fmt.Errorf("files must contain at least one file")
+		goto end
+	}
+
+	seen = make(map[string]NULL, len(raw))
+	specs = make([]fileSpec, 0, len(raw))
+	for i, item := range raw {
+		obj, ok = item.(map[string]any)
+		if !ok {
+			err = fmt.Errorf("file %d must be an object with 'path' and 'content' strings", i+1)
+			goto end
+		}
+		path, ok = obj["path"].(string)
+		if !ok || path == "" {
+			err = fmt.Errorf("file %d must have a non-empty 'path' string", i+1)
+			goto end
+		}
+		content, ok = obj["content"].(string)
+		if !ok {
+			err = fmt.Errorf("file %d must have a 'content' string", i+1)
+			goto end
+		}
+		absPath, err = filepath.Abs(path)
+		if err != nil {
+			goto end
+		}
+		_, ok = seen[absPath]
+		if ok {
+			err = fmt.Errorf("file %d duplicates path %s", i+1, path)
+			goto end
+		}
+		seen[absPath] = NULL{}
+		specs = append(specs, fileSpec{Path: path, Content: content})
+	}
+
+end:
+	return specs, err
+}
+
+// checkFileSpecs verifies that every file may be written before any is, and
+// saves the content of existing files so they can be restored. It returns
+// the paths of the files that already exist.
+func (t *CreateFilesTool) checkFileSpecs(specs []fileSpec, createDirs, overwrite bool) (existing []string, err error) {
+	var info os.FileInfo
+	var spec *fileSpec
+
+	for i := range specs {
+		spec = &specs[i]
+		if !t.IsAllowedPath(spec.Path) {
+			err = fmt.Errorf("access denied: path not allowed: %s", spec.Path)
+			goto end
+		}
+
+		info, err = os.Stat(spec.Path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			err = nil
+		case err != nil:
+			err = fmt.Errorf("error checking file %s: %v", spec.Path, err)
+			goto end
+		case info.IsDir():
+			err = fmt.Errorf("cannot write directory: %s", spec.Path)
+			goto end
+		case !overwrite:
+			err = fmt.Errorf("file already exists: %s (set overwrite to replace it)", spec.Path)
+			goto end
+		default:
+			spec.existed = true
+			spec.original, err = os.ReadFile(spec.Path)
+			if err != nil {
+				err = fmt.Errorf("failed to read %s: %v", spec.Path, err)
+				goto end
+			}
+			existing = append(existing, spec.Path)
+			continue
+		}
+
+		if createDirs {
+			continue
+		}
+		info, err = os.Stat(filepath.Dir(spec.Path))
+		if err != nil || !info.IsDir() {
+			err = fmt.Errorf("parent directory does not exist: %s (set create_dirs to create it)", filepath.Dir(spec.Path))
+			goto end
+		}
+	}
+
+end:
+	return existing, err
+}
+
+// writeFileSpecs writes each file in turn. If one fails, the files already
+// written are removed or restored to their original content, along with any
+// directories created for them, and the error says what was undone.
+func (t *CreateFilesTool) writeFileSpecs(ctx context.Context, specs []fileSpec, createDirs bool) (err error) {
+	var dirs []string
+	var created []string
+	var written int
+	var spec fileSpec
+	var rollbackErr error
+
+	for _, spec = range specs {
+		if createDirs && !mcputil.IsPreview(ctx) {
+			dirs = missingDirs(filepath.Dir(spec.Path))
+			err = os.MkdirAll(filepath.Dir(spec.Path), 0755)
+			created = append(created, dirs...)
+			if err != nil {
+				err = fmt.Errorf("failed to create directories for %s: %v", spec.Path, err)
+				goto end
+			}
+		}
+
+		err = mcputil.WriteFileAtomic(ctx, t.Config(), spec.Path, spec.Content)
+		if err != nil {
+			err = fmt.Errorf("failed to create %s: %w", spec.Path, err)
+			goto end
+		}
+		written++
+	}
+
+end:
+	if err != nil {
+		rollbackErr = t.rollbackFileSpecs(ctx, specs[:written], created)
+		err = fmt.Errorf("%w; rolled back %d file(s) already written", err, written)
+		if rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback incomplete: %w", rollbackErr))
+		}
+	}
+	return err
+}
+
+// rollbackFileSpecs undoes writing specs, last first, by restoring files that
+// existed and removing the rest with mcputil.RestoreFile, which discards the
+// edit backups the writes took, then removes the directories in dirs, which
+// are listed in the order they were created.
+func (t *CreateFilesTool) rollbackFileSpecs(ctx context.Context, specs []fileSpec, dirs []string) (err error) {
+	var errs []error
+
+	for _, spec := range slices.Backward(specs) {
+		errs = append(errs, mcputil.RestoreFile(ctx, t.Config(), spec.Path, spec.existed, string(spec.original)))
+	}
+
+	for _, dir := range slices.Backward(dirs) {
+		err = mcputil.RemoveFile(ctx, t.Config(), dir, false)
+		if !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	err = errors.Join(errs...)
+	return err
+}
+
+// missingDirs returns dir and those of its ancestors that do not exist, from
+// the outermost inward, which is the order MkdirAll creates them in.
+func missingDirs(dir string) (dirs []string) {
+	var err error
+
+	for {
+		_, err = os.Stat(dir)
+		if err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dirs = append(dirs, dir)
+		dir = filepath.Dir(dir)
+	}
+	slices.Reverse(dirs)
+	return dirs
+}
@@ -0,0 +1,186 @@
+package mcptools_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const CreateFilesDirPrefix = "create-files-tool-test"
+
+// Create files tool result type
+type CreateFilesResult struct {
+	Success   bool                         `json:"success"`
+	Files     []mcptools.CreatedFileResult `json:"files"`
+	FileCount int                          `json:"file_count"`
+	Message   string                       `json:"message"`
+}
+
+type createFilesResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedFileCount int
+}
+
+func requireCreateFilesResult(t *testing.T, result *CreateFilesResult, err error, opts createFilesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should report success")
+	assert.Equal(t, opts.ExpectedFileCount, result.FileCount, "File count should match")
+	assert.Len(t, result.Files, opts.ExpectedFileCount, "Should report each file")
+}
+
+func TestCreateFilesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("create_files")
+	require.NotNil(t, tool, "create_files tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.RepoFixture) {
+		tf := fsfix.NewRootFixture(CreateFilesDirPrefix)
+		pf := tf.AddRepoFixture("scaffold", nil)
+		return tf, pf
+	}
+
+	configure := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	call := func(params mcputil.Params) (*CreateFilesResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[CreateFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call create_files")
+	}
+
+	file := func(path, content string) map[string]any {
+		return map[string]any{"path": path, "content": content}
+	}
+
+	t.Run("NewFiles_ShouldCreateAll", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		configure(t, tf)
+		dir := pf.Dir()
+
+		result, err := call(mcputil.Params{
+			"files": []any{
+				file(filepath.Join(dir, "go.mod"), "module example\n"),
+				file(filepath.Join(dir, "cmd", "app", "main.go"), "package main\n"),
+			},
+			"create_dirs": true,
+		})
+		requireCreateFilesResult(t, result, err, createFilesResultOpts{
+			ExpectedFileCount: 2,
+		})
+		requireFileContent(t, filepath.Join(dir, "go.mod"), "module example\n")
+		requireFileContent(t, filepath.Join(dir, "cmd", "app", "main.go"), "package main\n")
+	})
+
+	t.Run("ExistingFile_ShouldErrorWithoutWriting", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "original\n"})
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{
+			"files": []any{
+				file(filepath.Join(pf.Dir(), "LICENSE"), "MIT\n"),
+				file(ff.Filepath, "replaced\n"),
+			},
+		})
+		requireCreateFilesResult(t, result, err, createFilesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "file already exists",
+		})
+		assert.NoFileExists(t, filepath.Join(pf.Dir(), "LICENSE"), "No file should be written")
+		requireFileContent(t, ff.Filepath, "original\n")
+	})
+
+	t.Run("Overwrite_ShouldReplaceExisting", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "original\n"})
+		configure(t, tf)
+
+		result, err := call(mcputil.Params{
+			"files":     []any{file(ff.Filepath, "replaced\n")},
+			"overwrite": true,
+		})
+		requireCreateFilesResult(t, result, err, createFilesResultOpts{
+			ExpectedFileCount: 1,
+		})
+		assert.True(t, result.Files[0].Overwritten, "Should report the overwrite")
+		requireFileContent(t, ff.Filepath, "replaced\n")
+	})
+
+	t.Run("LaterFailure_ShouldRollBack", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "original\n"})
+		configure(t, tf)
+		setEditBackups(t, t.TempDir(), 0)
+		dir := pf.Dir()
+
+		result, err := call(mcputil.Params{
+			"files": []any{
+				file(ff.Filepath, "replaced\n"),
+				file(filepath.Join(dir, "pkg", "util", "util.go"), "package util\n"),
+				file(filepath.Join(dir, "gen"), "not a directory\n"),
+				file(filepath.Join(dir, "gen", "gen.go"), "package gen\n"),
+			},
+			"create_dirs": true,
+			"overwrite":   true,
+		})
+		requireCreateFilesResult(t, result, err, createFilesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "rolled back 3 file(s)",
+		})
+		requireFileContent(t, ff.Filepath, "original\n")
+		assert.NoDirExists(t, filepath.Join(dir, "pkg"), "Created directories should be removed")
+		assert.NoFileExists(t, filepath.Join(dir, "gen"), "Created files should be removed")
+
+		config := mcputil.NewMockConfig(mcputil.MockConfigArgs{AllowedPaths: []string{tf.TempDir()}})
+		for _, path := range []string{ff.Filepath, filepath.Join(dir, "gen")} {
+			_, _, err = mcputil.UndoEdit(context.Background(), config, testToken, path)
+			assert.ErrorIs(t, err, mcputil.ErrNoEditBackup, "Backups of rolled back writes should be discarded")
+		}
+	})
+
+	t.Run("DuplicatePath_ShouldError", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		configure(t, tf)
+		path := filepath.Join(pf.Dir(), "main.go")
+
+		result, err := call(mcputil.Params{
+			"files": []any{file(path, "package a\n"), file(path, "package b\n")},
+		})
+		requireCreateFilesResult(t, result, err, createFilesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "duplicates path",
+		})
+	})
+}
@@ -15,11 +15,14 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "delete_file_lines",
 			Description: "Delete specific lines from a file by line number range",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				FilepathProperty.Required(),
 				StartLineProperty.Required(),
 				EndLineProperty.Required(),
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -31,10 +34,13 @@ type DeleteFileLinesTool struct {
 }
 
 // Handle processes the delete_file_lines tool request and removes the specified line range from a file.
-func (t *DeleteFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *DeleteFileLinesTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var startLine, endLine int
 	var message string
+	var originalContent, updatedContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "delete_file_lines")
 
@@ -60,25 +66,34 @@ func (t *DeleteFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
-	err = t.deleteFileLines(filePath, startLine, endLine)
+	diffOpts, err = getDiffOptions(req)
 	if err != nil {
 		goto end
 	}
 
+	originalContent, updatedContent, err = t.deleteFileLines(ctx, filePath, startLine, endLine)
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
 	if startLine == endLine {
 		message = fmt.Sprintf("Successfully deleted line %d from %s", startLine, filePath)
 	} else {
 		message = fmt.Sprintf("Successfully deleted lines %d-%d from %s", startLine, endLine, filePath)
 	}
 
-	result = mcputil.NewToolResultJSON(map[string]any{
+	fields = map[string]any{
 		"success":       true,
 		"file_path":     filePath,
 		"start_line":    startLine,
 		"end_line":      endLine,
 		"lines_deleted": endLine - startLine + 1,
 		"message":       message,
-	})
+	}
+	diffOpts.addDiff(fields, filePath, originalContent, updatedContent)
+	result = mcputil.NewToolResultJSON(fields)
 
 	logger.Info("Tool completed", "tool", "delete_file_lines", "path", filePath, "start_line", startLine, "end_line", endLine)
 
@@ -101,10 +116,8 @@ end:
 	return err
 }
 
-func (t *DeleteFileLinesTool) deleteFileLines(filePath string, startLine, endLine int) (err error) {
-	var originalContent string
+func (t *DeleteFileLinesTool) deleteFileLines(ctx context.Context, filePath string, startLine, endLine int) (originalContent, updatedContent string, err error) {
 	var lines []string
-	var updatedContent string
 
 	if !t.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
@@ -125,10 +138,10 @@ func (t *DeleteFileLinesTool) deleteFileLines(filePath string, startLine, endLin
 
 	updatedContent = t.removeLines(lines, startLine, endLine)
 
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
 
 end:
-	return err
+	return originalContent, updatedContent, err
 }
 
 func (t *DeleteFileLinesTool) validateLineNumbers(lines []string, startLine, endLine int) (err error) {
@@ -15,10 +15,12 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "delete_files",
 			Description: "Delete file or directory from allowed directories",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				PathProperty.Required(),
 				RecursiveProperty,
+				ConfirmationTokenProperty,
 			},
 		}),
 	})
@@ -30,11 +32,12 @@ type DeleteFileTool struct {
 }
 
 // Handle processes the delete_files tool request and removes the specified file or directory.
-func (t *DeleteFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *DeleteFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var recursive bool
 	var fileInfo os.FileInfo
 	var fileType string
+	var op mcputil.ConfirmableOperation
 
 	logger.Info("Tool called", "tool", "delete_files")
 
@@ -70,23 +73,30 @@ func (t *DeleteFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (res
 	// Determine what we're deleting
 	if fileInfo.IsDir() {
 		fileType = "directory"
+		op = mcputil.RecursiveDeleteOperation
 		if !recursive {
 			err = fmt.Errorf("cannot delete directory without recursive flag: %s", filePath)
 			goto end
 		}
-		// Use RemoveAll for recursive directory deletion
-		err = os.RemoveAll(filePath)
 	} else {
 		fileType = "file"
-		// Use Remove for single file deletion
-		err = os.Remove(filePath)
+		op = mcputil.DeleteOperation
 	}
 
+	err = mcputil.ConfirmOperation(ctx, op, filePath)
+	if err != nil {
+		goto end
+	}
+
+	// Only directories are removed recursively
+	err = mcputil.RemoveFile(ctx, t.Config(), filePath, fileInfo.IsDir())
 	if err != nil {
 		err = fmt.Errorf("failed to delete %s: %v", fileType, err)
 		goto end
 	}
 
+	recordFileChange(ctx, req, mcputil.DeletedFileOp, filePath)
+
 	logger.Info("Tool completed", "tool", "delete_files", "success", true, "path", filePath, "type", fileType)
 	result = mcputil.NewToolResultJSON(map[string]any{
 		"success":      true,
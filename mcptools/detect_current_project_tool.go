@@ -219,7 +219,7 @@ func (t *DetectCurrentProjectTool) scanSinglePathForProjects(basePath string, ig
 	recentFileTime, fileCount, err = t.findMostRecentFileTimeAndCount(basePath)
 	if err == nil && fileCount >= 5 {
 		// Check if basePath has .git directory or ignore requirement
-		if ignoreGitRequirement || t.hasGitDirectory(basePath) {
+		if ignoreGitRequirement || hasGitDirectory(basePath) {
 			projectName := filepath.Base(basePath)
 			project := ProjectInfo{
 				Path:         basePath,
@@ -317,7 +317,7 @@ func (t *DetectCurrentProjectTool) isProjectDirectory(dirPath string, ignoreGitR
 	}
 
 	// Otherwise, check for .git directory
-	return t.hasGitDirectory(dirPath), nil
+	return hasGitDirectory(dirPath), nil
 }
 
 // isProjectDirectorySubdir checks if a subdirectory is a project (no file count requirement)
@@ -332,10 +332,11 @@ func (t *DetectCurrentProjectTool) isProjectDirectorySubdir(dirPath string, igno
 	}
 
 	// Otherwise, check for .git directory
-	return t.hasGitDirectory(dirPath), nil
+	return hasGitDirectory(dirPath), nil
 }
 
-func (t *DetectCurrentProjectTool) hasGitDirectory(dirPath string) bool {
+// hasGitDirectory reports whether dirPath contains a .git directory.
+func hasGitDirectory(dirPath string) bool {
 	gitPath := filepath.Join(dirPath, ".git")
 	if info, err := os.Stat(gitPath); err == nil {
 		return info.IsDir()
@@ -354,7 +355,7 @@ func (t *DetectCurrentProjectTool) isInsideGitRepository(dirPath string) bool {
 		}
 
 		// Check if parent has .git directory
-		if t.hasGitDirectory(parent) {
+		if hasGitDirectory(parent) {
 			return true
 		}
 
@@ -402,7 +403,7 @@ func (t *DetectCurrentProjectTool) findMostRecentFileTimeAndCount(dirPath string
 			continue
 		}
 
-		if !t.hasGitDirectory(filePath) {
+		if !hasGitDirectory(filePath) {
 			continue
 		}
 
@@ -0,0 +1,354 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*DiffDirectoriesTool)(nil)
+
+// defaultDiffMaxResults caps the number of reported entries when max_results is not given.
+const defaultDiffMaxResults = 200
+
+func init() {
+	mcputil.RegisterTool(&DiffDirectoriesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "diff_directories",
+			Description: "Compare two directory trees and report files only in either side and files whose content differs, optionally with unified diffs",
+			QuickHelp:   "Compare two directory trees",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				PathAProperty.Required(),
+				PathBProperty.Required(),
+				ExtensionsProperty,
+				ExcludeProperty,
+				IncludeDiffsProperty,
+				MaxResultsProperty.Description("Maximum number of differences to report (default: 200)"),
+			},
+		}),
+	})
+}
+
+// DiffDirectoriesTool compares two directory trees by relative path and content hash.
+type DiffDirectoriesTool struct {
+	*mcputil.ToolBase
+}
+
+// ChangedFileResult describes a file present in both trees with differing content.
+type ChangedFileResult struct {
+	Path      string `json:"path"`
+	HashA     string `json:"hash_a"`
+	HashB     string `json:"hash_b"`
+	Diff      string `json:"diff,omitempty"`
+	DiffError string `json:"diff_error,omitempty"`
+}
+
+// DirectoryDiffResult contains the differences between two directory trees.
+type DirectoryDiffResult struct {
+	PathA          string              `json:"path_a"`
+	PathB          string              `json:"path_b"`
+	OnlyInA        []string            `json:"only_in_a"`
+	OnlyInB        []string            `json:"only_in_b"`
+	Changed        []ChangedFileResult `json:"changed"`
+	IdenticalCount int                 `json:"identical_count"`
+	Truncated      bool                `json:"truncated"`
+	Summary        string              `json:"summary"`
+}
+
+// Handle processes the diff_directories tool request and compares the two trees.
+func (t *DiffDirectoriesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var pathA, pathB string
+	var extensions []string
+	var excludes []string
+	var includeDiffs bool
+	var maxResults int
+	var filesA, filesB map[string]NULL
+	var diffResult DirectoryDiffResult
+
+	logger.Info("Tool called", "tool", "diff_directories")
+
+	pathA, err = PathAProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	pathB, err = PathBProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid extensions array: %v", err)
+		goto end
+	}
+
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+
+	includeDiffs, err = IncludeDiffsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	maxResults, err = MaxResultsProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxResults <= 0 {
+		maxResults = defaultDiffMaxResults
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "diff_directories",
+		"path_a", pathA,
+		"path_b", pathB,
+		"extensions", extensions,
+		"exclude", excludes,
+		"include_diffs", includeDiffs,
+		"max_results", maxResults)
+
+	filesA, err = t.relativeFiles(pathA, extensions, excludes)
+	if err != nil {
+		goto end
+	}
+
+	filesB, err = t.relativeFiles(pathB, extensions, excludes)
+	if err != nil {
+		goto end
+	}
+
+	diffResult, err = compareTrees(pathA, pathB, filesA, filesB, includeDiffs, maxResults)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "diff_directories",
+		"only_in_a", len(diffResult.OnlyInA),
+		"only_in_b", len(diffResult.OnlyInB),
+		"changed", len(diffResult.Changed),
+		"truncated", diffResult.Truncated)
+
+	result = mcputil.NewToolResultJSON(diffResult)
+
+end:
+	return result, err
+}
+
+// relativeFiles returns the set of files under root, keyed by slash-separated
+// path relative to root, after applying the extension and exclude filters.
+func (t *DiffDirectoriesTool) relativeFiles(root string, extensions, excludes []string) (files map[string]NULL, err error) {
+	var info os.FileInfo
+
+	if !t.IsAllowedPath(root) {
+		err = fmt.Errorf("access denied: path not allowed: %s", root)
+		goto end
+	}
+
+	info, err = os.Stat(root)
+	if err != nil {
+		err = fmt.Errorf("cannot access %s: %v", root, err)
+		goto end
+	}
+	if !info.IsDir() {
+		err = fmt.Errorf("not a directory: %s", root)
+		goto end
+	}
+
+	files = make(map[string]NULL)
+	err = filepath.WalkDir(root, func(fp string, d fs.DirEntry, walkErr error) (err error) {
+		var rel string
+
+		if walkErr != nil {
+			err = walkErr
+			goto end
+		}
+		if fp == root {
+			goto end
+		}
+
+		rel, err = filepath.Rel(root, fp)
+		if err != nil {
+			goto end
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matchesAnyGlob(rel, excludes) {
+			if d.IsDir() {
+				err = filepath.SkipDir
+			}
+			goto end
+		}
+
+		if d.IsDir() || !d.Type().IsRegular() {
+			goto end
+		}
+
+		if !matchesExtensions(fp, extensions) {
+			goto end
+		}
+
+		files[rel] = NULL{}
+
+	end:
+		return err
+	})
+
+end:
+	return files, err
+}
+
+// compareTrees classifies every relative path in filesA and filesB, hashing
+// files present on both sides. Once maxResults differences have been
+// recorded the remaining differences are counted only via Truncated.
+func compareTrees(rootA, rootB string, filesA, filesB map[string]NULL, includeDiffs bool, maxResults int) (r DirectoryDiffResult, err error) {
+	var paths []string
+	var ok, inA, inB bool
+	var reported int
+	var diffBudget int
+	var cf ChangedFileResult
+
+	r = DirectoryDiffResult{
+		PathA:   rootA,
+		PathB:   rootB,
+		OnlyInA: []string{},
+		OnlyInB: []string{},
+		Changed: []ChangedFileResult{},
+	}
+	diffBudget = TargetCharLimit
+
+	paths = make([]string, 0, len(filesA)+len(filesB))
+	for rel := range filesA {
+		paths = append(paths, rel)
+	}
+	for rel := range filesB {
+		_, ok = filesA[rel]
+		if !ok {
+			paths = append(paths, rel)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, rel := range paths {
+		_, inA = filesA[rel]
+		_, inB = filesB[rel]
+
+		if inA && inB {
+			cf, err = compareFiles(rootA, rootB, rel)
+			if err != nil {
+				goto end
+			}
+			if cf.HashA == cf.HashB {
+				r.IdenticalCount++
+				continue
+			}
+		}
+
+		if reported >= maxResults {
+			r.Truncated = true
+			continue
+		}
+		reported++
+
+		switch {
+		case !inB:
+			r.OnlyInA = append(r.OnlyInA, rel)
+		case !inA:
+			r.OnlyInB = append(r.OnlyInB, rel)
+		default:
+			if includeDiffs && diffBudget > 0 {
+				addFileDiff(&cf, rootA, rootB, rel)
+				diffBudget -= len(cf.Diff)
+			}
+			r.Changed = append(r.Changed, cf)
+		}
+	}
+
+	r.Summary = fmt.Sprintf("%d only in A, %d only in B, %d changed, %d identical",
+		len(r.OnlyInA),
+		len(r.OnlyInB),
+		len(r.Changed),
+		r.IdenticalCount,
+	)
+	if r.Truncated {
+		r.Summary += fmt.Sprintf(" (truncated at %d differences)", maxResults)
+	}
+
+end:
+	return r, err
+}
+
+// compareFiles hashes the file at rel in both roots.
+func compareFiles(rootA, rootB, rel string) (cf ChangedFileResult, err error) {
+	cf.Path = rel
+
+	cf.HashA, err = hashFile(filepath.Join(rootA, filepath.FromSlash(rel)))
+	if err != nil {
+		goto end
+	}
+
+	cf.HashB, err = hashFile(filepath.Join(rootB, filepath.FromSlash(rel)))
+
+end:
+	return cf, err
+}
+
+// addFileDiff attaches a unified diff to cf when both sides are text files.
+// Problems producing the diff are recorded on cf rather than failing the
+// whole comparison.
+func addFileDiff(cf *ChangedFileResult, rootA, rootB, rel string) {
+	var a, b []byte
+	var err error
+
+	a, err = os.ReadFile(filepath.Join(rootA, filepath.FromSlash(rel)))
+	if err != nil {
+		goto end
+	}
+
+	b, err = os.ReadFile(filepath.Join(rootB, filepath.FromSlash(rel)))
+	if err != nil {
+		goto end
+	}
+
+	if isBinaryContent(a) || isBinaryContent(b) {
+		err = fmt.Errorf("binary files differ")
+		goto end
+	}
+
+	cf.Diff, err = mcputil.UnifiedDiff("a/"+rel, "b/"+rel, string(a), string(b))
+
+end:
+	if err != nil {
+		cf.DiffError = err.Error()
+	}
+}
+
+// matchesAnyGlob reports whether the slash-separated relative path, or its
+// base name, matches any of the glob patterns.
+func matchesAnyGlob(rel string, patterns []string) (matches bool) {
+	var base string
+
+	base = filepath.Base(rel)
+	for _, pattern := range patterns {
+		matches, _ = filepath.Match(pattern, rel)
+		if matches {
+			goto end
+		}
+		matches, _ = filepath.Match(pattern, base)
+		if matches {
+			goto end
+		}
+	}
+
+end:
+	return matches
+}
@@ -0,0 +1,140 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const DiffDirectoriesDirPrefix = "diff-directories-tool-test"
+
+type directoryDiffResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedOnlyInA   []string
+	ExpectedOnlyInB   []string
+	ExpectedChanged   []string
+	ExpectedIdentical int
+	ExpectTruncated   bool
+}
+
+func requireDirectoryDiffResult(t *testing.T, result *mcptools.DirectoryDiffResult, err error, opts directoryDiffResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedOnlyInA, result.OnlyInA, "Files only in A should match")
+	assert.Equal(t, opts.ExpectedOnlyInB, result.OnlyInB, "Files only in B should match")
+	changed := make([]string, len(result.Changed))
+	for i, c := range result.Changed {
+		changed[i] = c.Path
+	}
+	assert.Equal(t, opts.ExpectedChanged, changed, "Changed files should match")
+	assert.Equal(t, opts.ExpectedIdentical, result.IdenticalCount, "Identical count should match")
+	assert.Equal(t, opts.ExpectTruncated, result.Truncated, "Truncated flag should match")
+}
+
+func TestDiffDirectoriesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("diff_directories")
+	require.NotNil(t, tool, "diff_directories tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.DirFixture, *fsfix.DirFixture) {
+		tf := fsfix.NewRootFixture(DiffDirectoriesDirPrefix)
+
+		a := tf.AddDirFixture("a", nil)
+		a.AddFileFixture("same.txt", &fsfix.FileFixtureArgs{Content: "same\n"})
+		a.AddFileFixture("changed.txt", &fsfix.FileFixtureArgs{Content: "one\ntwo\n"})
+		a.AddFileFixture("only-a.txt", &fsfix.FileFixtureArgs{Content: "a\n"})
+		a.AddFileFixture("vendor/lib.txt", &fsfix.FileFixtureArgs{Content: "lib a\n"})
+
+		b := tf.AddDirFixture("b", nil)
+		b.AddFileFixture("same.txt", &fsfix.FileFixtureArgs{Content: "same\n"})
+		b.AddFileFixture("changed.txt", &fsfix.FileFixtureArgs{Content: "one\nTWO\n"})
+		b.AddFileFixture("sub/only-b.txt", &fsfix.FileFixtureArgs{Content: "b\n"})
+		b.AddFileFixture("vendor/lib.txt", &fsfix.FileFixtureArgs{Content: "lib b\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, a, b
+	}
+
+	t.Run("CompareWithExcludeAndDiffs", func(t *testing.T) {
+		tf, a, b := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path_a":        a.Dir(),
+			"path_b":        b.Dir(),
+			"exclude":       []any{"vendor"},
+			"include_diffs": true,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.DirectoryDiffResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error comparing directories")
+		requireDirectoryDiffResult(t, result, err, directoryDiffResultOpts{
+			ExpectedOnlyInA:   []string{"only-a.txt"},
+			ExpectedOnlyInB:   []string{"sub/only-b.txt"},
+			ExpectedChanged:   []string{"changed.txt"},
+			ExpectedIdentical: 1,
+		})
+
+		require.Len(t, result.Changed, 1)
+		assert.Contains(t, result.Changed[0].Diff, "-two\n+TWO\n", "Diff should show the changed line")
+		assert.NotEqual(t, result.Changed[0].HashA, result.Changed[0].HashB, "Hashes should differ")
+	})
+
+	t.Run("MaxResults_ShouldTruncate", func(t *testing.T) {
+		tf, a, b := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path_a":        a.Dir(),
+			"path_b":        b.Dir(),
+			"max_results":   1,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.DirectoryDiffResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error comparing directories")
+		requireDirectoryDiffResult(t, result, err, directoryDiffResultOpts{
+			ExpectedOnlyInA:   []string{},
+			ExpectedOnlyInB:   []string{},
+			ExpectedChanged:   []string{"changed.txt"},
+			ExpectedIdentical: 1,
+			ExpectTruncated:   true,
+		})
+		assert.Empty(t, result.Changed[0].Diff, "Diffs should only be included on request")
+	})
+
+	t.Run("NotADirectory_ShouldError", func(t *testing.T) {
+		tf, a, _ := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path_a":        a.Dir(),
+			"path_b":        a.Dir() + "/same.txt",
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.DirectoryDiffResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for file path")
+		requireDirectoryDiffResult(t, result, err, directoryDiffResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not a directory",
+		})
+	})
+}
@@ -0,0 +1,219 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*DiffSymbolsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&DiffSymbolsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "diff_symbols",
+			Description: "Compare the top-level symbols a file declares against an expected set and report those added, removed or renamed. Run it after a risky edit to catch unintended API changes; pass an empty expected_symbols to capture the current set as a baseline",
+			QuickHelp:   "Check an edit kept a file's top-level symbols",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File whose symbols to compare"),
+				RequiredLanguageProperty.Description("Programming language of the file; only 'go' is supported"),
+				ExpectedSymbolsProperty.Required(),
+			},
+		}),
+	})
+}
+
+// DiffSymbolsTool compares a file's top-level symbols against an expected set.
+type DiffSymbolsTool struct {
+	*mcputil.ToolBase
+}
+
+// SymbolRename pairs an expected symbol with the symbol that appears to replace it.
+type SymbolRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// SymbolDiff describes how a file's symbols differ from an expected set.
+type SymbolDiff struct {
+	Added   []string       `json:"added"`
+	Removed []string       `json:"removed"`
+	Renamed []SymbolRename `json:"renamed"`
+}
+
+// Handle processes the diff_symbols tool request and compares the file's
+// symbols against the expected set.
+func (t *DiffSymbolsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var expected []string
+	var content []byte
+	var symbols []golang.GoSymbol
+	var names []string
+	var diff SymbolDiff
+
+	logger.Info("Tool called", "tool", "diff_symbols")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	expected, err = ExpectedSymbolsProperty.Required().StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid expected_symbols array: %v", err)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "diff_symbols",
+		"path", path,
+		"language", language,
+		"expected_count", len(expected))
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = os.ReadFile(path)
+	if err != nil {
+		goto end
+	}
+
+	symbols, err = golang.ParseSymbols(path, content)
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %v", path, err)
+		goto end
+	}
+
+	names = symbolNames(symbols)
+	diff = diffSymbols(names, expected)
+
+	logger.Info("Tool completed", "tool", "diff_symbols",
+		"path", path,
+		"added_count", len(diff.Added),
+		"removed_count", len(diff.Removed),
+		"renamed_count", len(diff.Renamed))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":     path,
+		"language": language,
+		"symbols":  names,
+		"added":    diff.Added,
+		"removed":  diff.Removed,
+		"renamed":  diff.Renamed,
+		"matches":  len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Renamed) == 0,
+	})
+
+end:
+	return result, err
+}
+
+// symbolNames returns the qualified names of symbols in source order, each
+// listed once even when declared more than once, as init functions can be.
+func symbolNames(symbols []golang.GoSymbol) (names []string) {
+	var seen map[string]bool
+	var name string
+
+	seen = make(map[string]bool, len(symbols))
+	names = make([]string, 0, len(symbols))
+	for _, sym := range symbols {
+		name = sym.QualifiedName()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// diffSymbols compares the actual symbol names against the expected ones.
+// When the only symbol removed and the only symbol added for a receiver, or
+// among plain declarations, form a pair, they are reported as a rename.
+func diffSymbols(actual, expected []string) (diff SymbolDiff) {
+	var actualSet, expectedSet map[string]bool
+	var removedBy, addedBy map[string][]string
+	var renamed map[string]bool
+	var prefix string
+
+	actualSet = make(map[string]bool, len(actual))
+	for _, name := range actual {
+		actualSet[name] = true
+	}
+	expectedSet = make(map[string]bool, len(expected))
+	for _, name := range expected {
+		expectedSet[name] = true
+	}
+
+	removedBy = make(map[string][]string)
+	for _, name := range expected {
+		if !actualSet[name] {
+			prefix = symbolReceiverPrefix(name)
+			removedBy[prefix] = append(removedBy[prefix], name)
+		}
+	}
+	addedBy = make(map[string][]string)
+	for _, name := range actual {
+		if !expectedSet[name] {
+			prefix = symbolReceiverPrefix(name)
+			addedBy[prefix] = append(addedBy[prefix], name)
+		}
+	}
+
+	diff = SymbolDiff{
+		Added:   make([]string, 0),
+		Removed: make([]string, 0),
+		Renamed: make([]SymbolRename, 0),
+	}
+	renamed = make(map[string]bool)
+	for _, name := range expected {
+		prefix = symbolReceiverPrefix(name)
+		if len(removedBy[prefix]) != 1 || len(addedBy[prefix]) != 1 || removedBy[prefix][0] != name {
+			continue
+		}
+		diff.Renamed = append(diff.Renamed, SymbolRename{From: name, To: addedBy[prefix][0]})
+		renamed[name] = true
+		renamed[addedBy[prefix][0]] = true
+	}
+
+	for _, name := range expected {
+		if !actualSet[name] && !renamed[name] {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+	for _, name := range actual {
+		if !expectedSet[name] && !renamed[name] {
+			diff.Added = append(diff.Added, name)
+		}
+	}
+	return diff
+}
+
+// symbolReceiverPrefix returns the Type of a Type.Method name, or "" for an
+// unqualified name.
+func symbolReceiverPrefix(name string) (prefix string) {
+	var qualified bool
+
+	prefix, _, qualified = strings.Cut(name, ".")
+	if !qualified {
+		prefix = ""
+	}
+	return prefix
+}
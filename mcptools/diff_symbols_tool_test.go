@@ -0,0 +1,170 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const DiffSymbolsDirPrefix = "diff-symbols-tool-test"
+
+// Diff symbols tool result type
+type DiffSymbolsResult struct {
+	Path     string                  `json:"path"`
+	Language string                  `json:"language"`
+	Symbols  []string                `json:"symbols"`
+	Added    []string                `json:"added"`
+	Removed  []string                `json:"removed"`
+	Renamed  []mcptools.SymbolRename `json:"renamed"`
+	Matches  bool                    `json:"matches"`
+}
+
+type diffSymbolsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedAdded    []string
+	ExpectedRemoved  []string
+	ExpectedRenamed  []mcptools.SymbolRename
+	ExpectMatch      bool
+}
+
+func requireDiffSymbolsResult(t *testing.T, result *DiffSymbolsResult, err error, opts diffSymbolsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	if opts.ExpectedAdded == nil {
+		opts.ExpectedAdded = []string{}
+	}
+	if opts.ExpectedRemoved == nil {
+		opts.ExpectedRemoved = []string{}
+	}
+	if opts.ExpectedRenamed == nil {
+		opts.ExpectedRenamed = []mcptools.SymbolRename{}
+	}
+	assert.Equal(t, opts.ExpectedAdded, result.Added, "Added symbols should match")
+	assert.Equal(t, opts.ExpectedRemoved, result.Removed, "Removed symbols should match")
+	assert.Equal(t, opts.ExpectedRenamed, result.Renamed, "Renamed symbols should match")
+	assert.Equal(t, opts.ExpectMatch, result.Matches, "Matches should match")
+}
+
+func TestDiffSymbolsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("diff_symbols")
+	require.NotNil(t, tool, "diff_symbols tool should be registered")
+
+	const source = `package shapes
+
+const Pi = 3.14
+
+type Circle struct{ R float64 }
+
+func (c Circle) Area() float64 { return Pi * c.R * c.R }
+
+func (c Circle) Perimeter() float64 { return 2 * Pi * c.R }
+
+func NewCircle(r float64) Circle { return Circle{R: r} }
+
+func init() {}
+
+func init() {}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(DiffSymbolsDirPrefix)
+		pf := tf.AddRepoFixture("shapes-project", nil)
+		ff := pf.AddFileFixture("shapes.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(path string, expected []any) (*DiffSymbolsResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             path,
+			"language":         "go",
+			"expected_symbols": expected,
+		})
+		return mcputil.GetToolResult[DiffSymbolsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call diff_symbols")
+	}
+
+	t.Run("SameSymbols_ShouldMatch", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, []any{"Pi", "Circle", "Circle.Area", "Circle.Perimeter", "NewCircle", "init"})
+		requireDiffSymbolsResult(t, result, err, diffSymbolsResultOpts{
+			ExpectMatch: true,
+		})
+		assert.Equal(t, []string{"Pi", "Circle", "Circle.Area", "Circle.Perimeter", "NewCircle", "init"}, result.Symbols, "Should list each symbol once in source order")
+	})
+
+	t.Run("EmptyExpected_ShouldReportBaseline", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, []any{})
+		requireDiffSymbolsResult(t, result, err, diffSymbolsResultOpts{
+			ExpectedAdded: []string{"Pi", "Circle", "Circle.Area", "Circle.Perimeter", "NewCircle", "init"},
+		})
+	})
+
+	t.Run("AmbiguousChanges_ShouldReportAddedAndRemoved", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		// Two methods of Circle appeared where one disappeared, and two plain
+		// declarations disappeared where one appeared, so no rename is certain
+		result, err := call(fp, []any{"Pi", "E", "Tau", "Circle", "Circle.Size", "init"})
+		requireDiffSymbolsResult(t, result, err, diffSymbolsResultOpts{
+			ExpectedAdded:   []string{"Circle.Area", "Circle.Perimeter", "NewCircle"},
+			ExpectedRemoved: []string{"E", "Tau", "Circle.Size"},
+		})
+	})
+
+	t.Run("SingleRename_ShouldPairSymbols", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, []any{"Pi", "Circle", "Circle.Size", "Circle.Perimeter", "MakeCircle", "init"})
+		requireDiffSymbolsResult(t, result, err, diffSymbolsResultOpts{
+			ExpectedRenamed: []mcptools.SymbolRename{
+				{From: "Circle.Size", To: "Circle.Area"},
+				{From: "MakeCircle", To: "NewCircle"},
+			},
+		})
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             fp,
+			"language":         "python",
+			"expected_symbols": []any{},
+		})
+		result, err := mcputil.GetToolResult[DiffSymbolsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for an unsupported language")
+		requireDiffSymbolsResult(t, result, err, diffSymbolsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "unsupported language",
+		})
+	})
+}
@@ -0,0 +1,25 @@
+//go:build !unix
+
+package mcptools
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkDirWritable verifies that dir's mode permits writing, as this platform
+// has no access(2); a read-only directory has no write permission bits.
+func checkDirWritable(dir string) (err error) {
+	var info os.FileInfo
+
+	info, err = os.Stat(dir)
+	if err != nil {
+		goto end
+	}
+	if info.Mode().Perm()&0222 == 0 {
+		err = fmt.Errorf("directory is read-only: %s", dir)
+	}
+
+end:
+	return err
+}
@@ -0,0 +1,13 @@
+//go:build unix
+
+package mcptools
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// checkDirWritable verifies that the process may create files in dir, using
+// access(2) so nothing is written.
+func checkDirWritable(dir string) error {
+	return unix.Access(dir, unix.W_OK)
+}
@@ -0,0 +1,118 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ExtractFunctionTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ExtractFunctionTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "extract_function",
+			Description: "Move a range of statements in a Go function into a new parameterless function declared after it, replacing them with a call. The lines must be whole statements of one block and must not use locals declared outside them, declare locals used after them, or contain return, defer or branch statements leaving them; otherwise the tool errors without changing the file. The result is gofmt-formatted and validated",
+			QuickHelp:   "Extract Go statements into a new function",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file containing the statements"),
+				StartLineProperty.Required().Description("First line of the statements to extract"),
+				EndLineProperty.Required().Description("Last line of the statements to extract, inclusive"),
+				NewFuncNameProperty.Required(),
+			},
+		}),
+	})
+}
+
+// ExtractFunctionTool moves a range of Go statements into a new function.
+type ExtractFunctionTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the extract_function tool request and extracts the
+// statements into a new function.
+func (t *ExtractFunctionTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var startLine, endLine int
+	var name string
+	var content string
+	var extracted []byte
+
+	logger.Info("Tool called", "tool", "extract_function")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	startLine, err = StartLineProperty.Required().Int(req)
+	if err != nil {
+		err = fmt.Errorf("start_line must be a valid number: %w", err)
+		goto end
+	}
+
+	endLine, err = EndLineProperty.Required().Int(req)
+	if err != nil {
+		err = fmt.Errorf("end_line must be a valid number: %w", err)
+		goto end
+	}
+
+	name, err = NewFuncNameProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "extract_function",
+		"path", path,
+		"start_line", startLine,
+		"end_line", endLine,
+		"new_func_name", name)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	if langutil.DetectLanguage(path) != langutil.GoLanguage {
+		err = fmt.Errorf("extract_function supports only Go files: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	extracted, err = golang.ExtractFunction(path, []byte(content), startLine, endLine, name)
+	if err != nil {
+		err = fmt.Errorf("cannot extract lines %d-%d of %s: %w", startLine, endLine, path, err)
+		goto end
+	}
+
+	err = WriteFile(ctx, t.Config(), path, string(extracted))
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+
+	logger.Info("Tool completed", "tool", "extract_function", "path", path, "new_func_name", name)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":       true,
+		"path":          path,
+		"start_line":    startLine,
+		"end_line":      endLine,
+		"new_func_name": name,
+		"message":       fmt.Sprintf("Extracted lines %d-%d of %s into %s()", startLine, endLine, path, name),
+	})
+
+end:
+	return result, err
+}
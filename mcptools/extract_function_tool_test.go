@@ -0,0 +1,186 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ExtractFunctionDirPrefix = "extract-function-tool-test"
+
+// Extract function tool result type
+type ExtractFunctionResult struct {
+	Success     bool   `json:"success"`
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	NewFuncName string `json:"new_func_name"`
+	Message     string `json:"message"`
+}
+
+type extractFunctionResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedContent  string
+}
+
+func requireExtractFunctionResult(t *testing.T, result *ExtractFunctionResult, err error, path string, opts extractFunctionResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should succeed")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "Should read the updated file")
+	assert.Equal(t, opts.ExpectedContent, string(content), "File content should match")
+}
+
+func TestExtractFunctionTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("extract_function")
+	require.NotNil(t, tool, "extract_function tool should be registered")
+
+	const source = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("start")
+	fmt.Println("one")
+	fmt.Println("two")
+	x := 1
+	fmt.Println(x)
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			continue
+		}
+	}
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(ExtractFunctionDirPrefix)
+		pf := tf.AddRepoFixture("extract-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(path string, startLine, endLine int, name string) (*ExtractFunctionResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"start_line":    startLine,
+			"end_line":      endLine,
+			"new_func_name": name,
+		})
+		return mcputil.GetToolResult[ExtractFunctionResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call extract_function")
+	}
+
+	t.Run("SelfContainedStatements_ShouldExtract", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, 7, 8, "printNumbers")
+		requireExtractFunctionResult(t, result, err, fp, extractFunctionResultOpts{
+			ExpectedContent: `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("start")
+	printNumbers()
+	x := 1
+	fmt.Println(x)
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			continue
+		}
+	}
+}
+
+func printNumbers() {
+	fmt.Println("one")
+	fmt.Println("two")
+}
+`,
+		})
+	})
+
+	t.Run("WholeLoop_ShouldExtract", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, 11, 15, "loop")
+		requireExtractFunctionResult(t, result, err, fp, extractFunctionResultOpts{
+			ExpectedContent: `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("start")
+	fmt.Println("one")
+	fmt.Println("two")
+	x := 1
+	fmt.Println(x)
+	loop()
+}
+
+func loop() {
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			continue
+		}
+	}
+}
+`,
+		})
+	})
+
+	errorCases := []struct {
+		name      string
+		startLine int
+		endLine   int
+		funcName  string
+		errorMsg  string
+	}{
+		{"OuterLocalReference_ShouldError", 10, 10, "printX", "'x', which is declared outside them"},
+		{"LocalUsedAfter_ShouldError", 9, 9, "setX", "'x', which is used after them"},
+		{"BranchLeavingRegion_ShouldError", 13, 13, "skip", "continue statement whose target is outside them"},
+		{"PartialStatement_ShouldError", 11, 12, "partial", "do not span whole statements"},
+		{"ExistingName_ShouldError", 7, 8, "main", "'main' is already declared"},
+		{"OutsideFunction_ShouldError", 3, 3, "imports", "not inside the body of a function"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tf, fp := setup(t)
+			defer tf.Cleanup()
+
+			result, err := call(fp, tc.startLine, tc.endLine, tc.funcName)
+			requireExtractFunctionResult(t, result, err, fp, extractFunctionResultOpts{
+				ExpectError:      true,
+				ExpectedErrorMsg: tc.errorMsg,
+			})
+
+			content, err := os.ReadFile(fp)
+			require.NoError(t, err)
+			assert.Equal(t, source, string(content), "File should be unchanged")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ExtractStringsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ExtractStringsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "extract_strings",
+			Description: "Extract the string literals from a source file with their line numbers using the language's AST, e.g. for i18n",
+			QuickHelp:   "List string literals in a file",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+				LanguageProperty.Description("Programming language of the file (default: detected from extension)"),
+				MinLengthProperty,
+				SkipStructTagsProperty,
+				SkipImportsProperty,
+			},
+		}),
+	})
+}
+
+// ExtractStringsTool collects the string literals of a source file.
+type ExtractStringsTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the extract_strings tool request and returns the file's string literals.
+func (t *ExtractStringsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var minLength int
+	var opts golang.StringLiteralOpts
+	var content string
+	var literals []golang.GoStringLiteral
+	var extracted []golang.GoStringLiteral
+
+	logger.Info("Tool called", "tool", "extract_strings")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = LanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if language == "" {
+		language = string(langutil.DetectLanguage(path))
+	}
+
+	minLength, err = MinLengthProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	opts.SkipStructTags, err = SkipStructTagsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	opts.SkipImports, err = SkipImportsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "extract_strings",
+		"path", path,
+		"language", language,
+		"min_length", minLength,
+		"skip_struct_tags", opts.SkipStructTags,
+		"skip_imports", opts.SkipImports)
+
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("language '%s' not supported for extract_strings; supported: %s", language, langutil.GoLanguage)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	literals, err = golang.ParseStringLiterals(path, []byte(content), opts)
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %w", path, err)
+		goto end
+	}
+
+	extracted = make([]golang.GoStringLiteral, 0, len(literals))
+	for _, lit := range literals {
+		if utf8.RuneCountInString(lit.Value) < minLength {
+			continue
+		}
+		extracted = append(extracted, lit)
+	}
+
+	logger.Info("Tool completed", "tool", "extract_strings", "count", len(extracted))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":     path,
+		"language": language,
+		"strings":  extracted,
+		"count":    len(extracted),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,137 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ExtractStringsDirPrefix = "extract-strings-tool-test"
+
+// Extract strings tool result type
+type ExtractStringsResult struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Strings  []struct {
+		Line  int    `json:"line"`
+		Value string `json:"value"`
+		Raw   bool   `json:"raw"`
+	} `json:"strings"`
+	Count int `json:"count"`
+}
+
+type extractStringsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedValues   []string
+}
+
+func requireExtractStringsResult(t *testing.T, result *ExtractStringsResult, err error, opts extractStringsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	values := make([]string, len(result.Strings))
+	for i, s := range result.Strings {
+		values[i] = s.Value
+	}
+	assert.Equal(t, opts.ExpectedValues, values, "Extracted values should match")
+	assert.Equal(t, len(opts.ExpectedValues), result.Count, "Count should match")
+}
+
+const extractStringsSource = "package sample\n" +
+	"\n" +
+	"import \"fmt\"\n" +
+	"\n" +
+	"type User struct {\n" +
+	"\tName string `json:\"name\"`\n" +
+	"}\n" +
+	"\n" +
+	"// Greet says \"hello\" in a comment that should be ignored\n" +
+	"func Greet(u User) {\n" +
+	"\tfmt.Println(\"Hello, \\\"friend\\\"\", u.Name, 'x', \"\")\n" +
+	"\tfmt.Println(`Welcome back`, \"ok\")\n" +
+	"}\n"
+
+func TestExtractStringsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("extract_strings")
+	require.NotNil(t, tool, "extract_strings tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(ExtractStringsDirPrefix)
+		ff := tf.AddFileFixture("sample.go", &fsfix.FileFixtureArgs{Content: extractStringsSource})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	t.Run("Defaults_ShouldSkipTagsAndImports", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[ExtractStringsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error extracting strings")
+		requireExtractStringsResult(t, result, err, extractStringsResultOpts{
+			ExpectedValues: []string{`Hello, "friend"`, "Welcome back", "ok"},
+		})
+		assert.Equal(t, "go", result.Language, "Language should be detected from extension")
+		assert.Equal(t, 11, result.Strings[0].Line, "Line number should match")
+		assert.False(t, result.Strings[0].Raw, "Interpreted string should not be raw")
+		assert.True(t, result.Strings[1].Raw, "Backquoted string should be raw")
+	})
+
+	t.Run("IncludeTagsAndImportsWithMinLength", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             ff.Filepath,
+			"language":         "go",
+			"min_length":       3,
+			"skip_struct_tags": false,
+			"skip_imports":     false,
+		})
+
+		result, err := mcputil.GetToolResult[ExtractStringsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error extracting strings")
+		requireExtractStringsResult(t, result, err, extractStringsResultOpts{
+			ExpectedValues: []string{"fmt", `json:"name"`, `Hello, "friend"`, "Welcome back"},
+		})
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"language":      "python",
+		})
+
+		result, err := mcputil.GetToolResult[ExtractStringsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for unsupported language")
+		requireExtractStringsResult(t, result, err, extractStringsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not supported",
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FileCallGraphTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FileCallGraphTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "file_call_graph",
+			Description: "Report the call graph within a Go file: for each function and method, the functions and methods declared in the same file that it calls, with the line of each call. Method calls are resolved when made on the receiver or on a variable whose type is evident from its declaration. Useful for judging the impact of editing a function",
+			QuickHelp:   "Show which functions in a Go file call which",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file to analyze"),
+				RequiredLanguageProperty.Description("Programming language of the file; only 'go' is supported"),
+			},
+		}),
+	})
+}
+
+// FileCallGraphTool reports the calls between the functions of a Go file.
+type FileCallGraphTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the file_call_graph tool request and returns the call
+// graph of the file.
+func (t *FileCallGraphTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var content string
+	var graph []golang.GoFuncCalls
+	var callCount int
+
+	logger.Info("Tool called", "tool", "file_call_graph")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "file_call_graph",
+		"path", path,
+		"language", language)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	graph, err = golang.ParseCallGraph(path, []byte(content))
+	if err != nil {
+		err = fmt.Errorf("failed to parse %s: %w", path, err)
+		goto end
+	}
+
+	for _, fc := range graph {
+		callCount += len(fc.Calls)
+	}
+
+	logger.Info("Tool completed", "tool", "file_call_graph",
+		"path", path,
+		"function_count", len(graph),
+		"call_count", callCount)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":           path,
+		"functions":      graph,
+		"function_count": len(graph),
+		"call_count":     callCount,
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,144 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FileCallGraphDirPrefix = "file-call-graph-tool-test"
+
+// File call graph tool result type
+type FileCallGraphResult struct {
+	Path          string               `json:"path"`
+	Functions     []golang.GoFuncCalls `json:"functions"`
+	FunctionCount int                  `json:"function_count"`
+	CallCount     int                  `json:"call_count"`
+}
+
+type fileCallGraphResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedFunctions []golang.GoFuncCalls
+}
+
+func requireFileCallGraphResult(t *testing.T, result *FileCallGraphResult, err error, opts fileCallGraphResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectedFunctions, result.Functions, "Functions should match")
+	assert.Equal(t, len(opts.ExpectedFunctions), result.FunctionCount, "Function count should match")
+}
+
+func TestFileCallGraphTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("file_call_graph")
+	require.NotNil(t, tool, "file_call_graph tool should be registered")
+
+	const source = `package parser
+
+import "strings"
+
+type Parser struct{ input string }
+
+func NewParser(input string) *Parser {
+	return &Parser{input: normalize(input)}
+}
+
+func (p *Parser) Parse() []string {
+	p.reset()
+	return split(p.input)
+}
+
+func (p *Parser) reset() {}
+
+func normalize(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func split(s string) []string {
+	return strings.Fields(s)
+}
+
+func Run(input string) []string {
+	p := NewParser(input)
+	q := &Parser{}
+	q.reset()
+	each := func() { p.Parse() }
+	each()
+	normalize := func(s string) string { return s }
+	return split(normalize(input))
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(FileCallGraphDirPrefix)
+		pf := tf.AddRepoFixture("call-graph-project", nil)
+		ff := pf.AddFileFixture("parser.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(path, language string) (*FileCallGraphResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"language":      language,
+		})
+		return mcputil.GetToolResult[FileCallGraphResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call file_call_graph")
+	}
+
+	t.Run("GoFile_ShouldReportCallsWithinFile", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "go")
+		requireFileCallGraphResult(t, result, err, fileCallGraphResultOpts{
+			ExpectedFunctions: []golang.GoFuncCalls{
+				{Func: "NewParser", Line: 7, Calls: []golang.GoCallSite{
+					{Callee: "normalize", Line: 8},
+				}},
+				{Func: "*Parser.Parse", Line: 11, Calls: []golang.GoCallSite{
+					{Callee: "*Parser.reset", Line: 12},
+					{Callee: "split", Line: 13},
+				}},
+				{Func: "*Parser.reset", Line: 16, Calls: []golang.GoCallSite{}},
+				{Func: "normalize", Line: 18, Calls: []golang.GoCallSite{}},
+				{Func: "split", Line: 22, Calls: []golang.GoCallSite{}},
+				{Func: "Run", Line: 26, Calls: []golang.GoCallSite{
+					{Callee: "NewParser", Line: 27},
+					{Callee: "*Parser.reset", Line: 29},
+					{Callee: "split", Line: 33},
+				}},
+			},
+		})
+		assert.Equal(t, 6, result.CallCount, "Call count should match")
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "python")
+		requireFileCallGraphResult(t, result, err, fileCallGraphResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "unsupported language",
+		})
+	})
+}
@@ -1,17 +1,70 @@
 package mcptools
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	"github.com/mikeschinkel/scout-mcp/langutil"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
 )
 
+// DefaultMaxFileSize is the largest file, in bytes, that tools write when the
+// max_file_size config setting is not given.
+const DefaultMaxFileSize int64 = 10 << 20
+
+// Package-level file size limit used by tools that write files
+var (
+	maxFileSize      = DefaultMaxFileSize
+	maxFileSizeMutex sync.Mutex
+)
+
+// SetMaxFileSize sets the largest file, in bytes, that tools will write. Zero
+// selects DefaultMaxFileSize.
+func SetMaxFileSize(size int64) (err error) {
+	if size < 0 {
+		err = fmt.Errorf("max_file_size must not be negative, got %d", size)
+		goto end
+	}
+	if size == 0 {
+		size = DefaultMaxFileSize
+	}
+
+	maxFileSizeMutex.Lock()
+	maxFileSize = size
+	maxFileSizeMutex.Unlock()
+
+end:
+	return err
+}
+
+// MaxFileSize returns the largest file, in bytes, that tools will write.
+func MaxFileSize() int64 {
+	maxFileSizeMutex.Lock()
+	defer maxFileSizeMutex.Unlock()
+	return maxFileSize
+}
+
 // ReadFile is an alias for mcputil.ReadFile for convenience.
 var ReadFile = mcputil.ReadFile
 
 // WriteFile writes content to a file with syntax validation for supported languages.
-func WriteFile(c mcputil.Config, filePath string, content string) (err error) {
+// When ctx carries a preview the write is recorded instead of persisted.
+func WriteFile(ctx context.Context, c mcputil.Config, filePath string, content string) (err error) {
+	err = validateFileSyntax(filePath, content)
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.WriteFile(ctx, c, filePath, content)
+
+end:
+	return err
+}
+
+// validateFileSyntax returns an error if content is not valid syntax for the
+// language of filePath, when it is a language with a processor.
+func validateFileSyntax(filePath string, content string) (err error) {
 	var language string
 
 	lf := langutil.NewFile(filePath)
@@ -22,11 +75,8 @@ func WriteFile(c mcputil.Config, filePath string, content string) (err error) {
 	err = lf.ValidateSyntax(content)
 	if err != nil {
 		err = fmt.Errorf("validation failed - would result in invalid %s syntax: %w", language, err)
-		goto end
 	}
 
-	err = mcputil.WriteFile(c, filePath, content)
-
 end:
 	return err
 }
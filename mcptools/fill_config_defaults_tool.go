@@ -0,0 +1,182 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+)
+
+var _ mcputil.Tool = (*FillConfigDefaultsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FillConfigDefaultsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "fill_config_defaults",
+			Description: "Complete a JSON config file by deep-merging it over a JSON object of defaults, check that required fields are present, and write the result atomically. Creates the file from the defaults if it does not exist",
+			QuickHelp:   "Fill a JSON config's missing values from defaults",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("JSON config file to complete; created if it does not exist"),
+				DefaultsProperty.Required(),
+				RequiredFieldsProperty,
+			},
+		}),
+	})
+}
+
+// FillConfigDefaultsTool completes partial JSON config files from a set of defaults.
+type FillConfigDefaultsTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the fill_config_defaults tool request and writes the completed config.
+func (t *FillConfigDefaultsTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var defaultsContent string
+	var requiredFields []string
+	var defaults map[string]any
+	var config map[string]any
+	var created bool
+	var merged map[string]any
+	var applied []string
+	var missing []string
+	var written bool
+
+	logger.Info("Tool called", "tool", "fill_config_defaults")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	defaultsContent, err = DefaultsProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	requiredFields, err = RequiredFieldsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "fill_config_defaults",
+		"path", path,
+		"defaults_length", len(defaultsContent),
+		"required_fields", requiredFields)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	err = json.Unmarshal([]byte(defaultsContent), &defaults)
+	if err == nil && defaults == nil {
+		err = errors.New("not a JSON object")
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid defaults: %w", err)
+		goto end
+	}
+
+	config, created, err = t.loadConfig(path)
+	if err != nil {
+		goto end
+	}
+
+	merged, applied = scoutcfg.MergeDefaults(defaults, config)
+
+	missing = scoutcfg.MissingFields(merged, requiredFields)
+	if len(missing) > 0 {
+		err = fmt.Errorf("required fields missing from %s and not provided by defaults: %s", path, strings.Join(missing, ", "))
+		goto end
+	}
+
+	// An existing config that already has every default is left untouched
+	if created || len(applied) > 0 {
+		err = t.writeConfig(ctx, req, path, merged, created)
+		if err != nil {
+			goto end
+		}
+		written = true
+	}
+
+	logger.Info("Tool completed", "tool", "fill_config_defaults",
+		"path", path,
+		"created", created,
+		"applied_count", len(applied))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":             path,
+		"created":          created,
+		"written":          written,
+		"applied_defaults": applied,
+		"applied_count":    len(applied),
+	})
+
+end:
+	return result, err
+}
+
+// loadConfig decodes the JSON object in path, reporting created when the
+// file does not exist yet and so will be created from the defaults alone.
+func (t *FillConfigDefaultsTool) loadConfig(path string) (config map[string]any, created bool, err error) {
+	var content string
+
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		created = true
+		err = nil
+		goto end
+	}
+	if err != nil {
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	err = json.Unmarshal([]byte(content), &config)
+	if err != nil {
+		err = fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+
+end:
+	return config, created, err
+}
+
+// writeConfig atomically writes config to path as indented JSON and records
+// the change for the session.
+func (t *FillConfigDefaultsTool) writeConfig(ctx context.Context, req mcputil.ToolRequest, path string, config map[string]any, created bool) (err error) {
+	var output []byte
+	var op mcputil.FileOperation
+
+	output, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		goto end
+	}
+
+	err = mcputil.WriteFileAtomic(ctx, t.Config(), path, string(output)+"\n")
+	if err != nil {
+		err = fmt.Errorf("failed to write %s: %v", path, err)
+		goto end
+	}
+
+	op = mcputil.UpdatedFileOp
+	if created {
+		op = mcputil.CreatedFileOp
+	}
+	recordFileChange(ctx, req, op, path)
+
+end:
+	return err
+}
@@ -0,0 +1,168 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FillConfigDefaultsDirPrefix = "fill-config-defaults-tool-test"
+
+// Fill config defaults tool result type
+type FillConfigDefaultsResult struct {
+	Path            string   `json:"path"`
+	Created         bool     `json:"created"`
+	Written         bool     `json:"written"`
+	AppliedDefaults []string `json:"applied_defaults"`
+	AppliedCount    int      `json:"applied_count"`
+}
+
+type fillConfigDefaultsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectCreated    bool
+	ExpectWritten    bool
+	ExpectedApplied  []string
+	ExpectedContent  string
+}
+
+func requireFillConfigDefaultsResult(t *testing.T, result *FillConfigDefaultsResult, err error, path string, opts fillConfigDefaultsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectCreated, result.Created, "Created flag should match")
+	assert.Equal(t, opts.ExpectWritten, result.Written, "Written flag should match")
+	assert.Equal(t, opts.ExpectedApplied, result.AppliedDefaults, "Applied defaults should match")
+	assert.Equal(t, len(opts.ExpectedApplied), result.AppliedCount, "Applied count should match")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "Should read config file")
+	assert.Equal(t, opts.ExpectedContent, string(content), "Config content should match")
+}
+
+const configDefaults = `{"port": 8080, "server": {"host": "localhost", "timeout": 30}, "name": "scout"}`
+
+func TestFillConfigDefaultsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("fill_config_defaults")
+	require.NotNil(t, tool, "fill_config_defaults tool should be registered")
+
+	setup := func(t *testing.T, content string) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(FillConfigDefaultsDirPrefix)
+		if content != "" {
+			tf.AddFileFixture("config.json", &fsfix.FileFixtureArgs{Content: content})
+		}
+		tf.Setup(t)
+		path := filepath.Join(tf.TempDir(), "config.json")
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, path
+	}
+
+	fill := func(path string, extra mcputil.Params) (*FillConfigDefaultsResult, error) {
+		params := mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"defaults":      configDefaults,
+		}
+		for k, v := range extra {
+			params[k] = v
+		}
+		return mcputil.GetToolResult[FillConfigDefaultsResult](mcputil.CallResult(mcputil.CallTool(tool, mcputil.NewMockRequest(params))), "Should not error filling config defaults")
+	}
+
+	t.Run("PartialConfig_ShouldFillMissingDefaults", func(t *testing.T) {
+		tf, path := setup(t, `{"name": "custom", "server": {"host": "example.com"}}`)
+		defer tf.Cleanup()
+
+		result, err := fill(path, nil)
+		requireFillConfigDefaultsResult(t, result, err, path, fillConfigDefaultsResultOpts{
+			ExpectWritten:   true,
+			ExpectedApplied: []string{"port", "server.timeout"},
+			ExpectedContent: "{\n  \"name\": \"custom\",\n  \"port\": 8080,\n  \"server\": {\n    \"host\": \"example.com\",\n    \"timeout\": 30\n  }\n}\n",
+		})
+	})
+
+	t.Run("MissingConfig_ShouldBeCreatedFromDefaults", func(t *testing.T) {
+		tf, path := setup(t, "")
+		defer tf.Cleanup()
+
+		result, err := fill(path, nil)
+		requireFillConfigDefaultsResult(t, result, err, path, fillConfigDefaultsResultOpts{
+			ExpectCreated:   true,
+			ExpectWritten:   true,
+			ExpectedApplied: []string{"name", "port", "server"},
+			ExpectedContent: "{\n  \"name\": \"scout\",\n  \"port\": 8080,\n  \"server\": {\n    \"host\": \"localhost\",\n    \"timeout\": 30\n  }\n}\n",
+		})
+	})
+
+	t.Run("CompleteConfig_ShouldNotBeRewritten", func(t *testing.T) {
+		content := `{"name":"x","port":1,"server":{"host":"h","timeout":2}}`
+		tf, path := setup(t, content)
+		defer tf.Cleanup()
+
+		result, err := fill(path, nil)
+		requireFillConfigDefaultsResult(t, result, err, path, fillConfigDefaultsResultOpts{
+			ExpectedApplied: []string{},
+			ExpectedContent: content,
+		})
+	})
+
+	t.Run("MissingRequiredField_ShouldError", func(t *testing.T) {
+		content := `{"name": "custom"}`
+		tf, path := setup(t, content)
+		defer tf.Cleanup()
+
+		result, err := fill(path, mcputil.Params{"required_fields": []any{"name", "server.token"}})
+		requireFillConfigDefaultsResult(t, result, err, path, fillConfigDefaultsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "server.token",
+		})
+
+		unchanged, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(unchanged), "Config should not be written when required fields are missing")
+	})
+
+	t.Run("InvalidDefaults_ShouldError", func(t *testing.T) {
+		tf, path := setup(t, `{}`)
+		defer tf.Cleanup()
+
+		result, err := fill(path, mcputil.Params{"defaults": `[1, 2]`})
+		requireFillConfigDefaultsResult(t, result, err, path, fillConfigDefaultsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid defaults",
+		})
+	})
+
+	t.Run("DryRun_ShouldNotWrite", func(t *testing.T) {
+		tf, path := setup(t, "")
+		defer tf.Cleanup()
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"defaults":      configDefaults,
+			"dry_run":       true,
+		}))), "Should not error previewing config defaults")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, mcputil.CreatedFileOp, result.Files[0].Operation, "Config should be reported as created")
+		assert.NoFileExists(t, path, "Dry run should not create the config")
+	})
+}
@@ -0,0 +1,225 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindDuplicateBlocksTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FindDuplicateBlocksTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_duplicate_blocks",
+			Description: "Find blocks of consecutive lines repeated within a file, such as copy-pasted code that could be extracted into a function. Lines are compared with their whitespace normalized, blank lines are ignored, and each block is reported once with the line ranges of all its occurrences, largest first",
+			QuickHelp:   "Find repeated blocks of lines in a file",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to search for duplicated blocks"),
+				MinBlockLinesProperty,
+			},
+		}),
+	})
+}
+
+// FindDuplicateBlocksTool reports blocks of lines that are repeated within a file.
+type FindDuplicateBlocksTool struct {
+	*mcputil.ToolBase
+}
+
+// DuplicateBlockResult is a block of lines occurring more than once in a file.
+type DuplicateBlockResult struct {
+	Lines       int                   `json:"lines"`       // Non-blank lines in the block
+	Occurrences []LineRangeOccurrence `json:"occurrences"` // Each place the block occurs, in file order
+}
+
+// LineRangeOccurrence is the range of lines, inclusive, where a block occurs.
+type LineRangeOccurrence struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// normalizedLine is a non-blank line with its whitespace normalized.
+type normalizedLine struct {
+	text string
+	line int
+}
+
+// Handle processes the find_duplicate_blocks tool request and reports the
+// repeated blocks in the file.
+func (t *FindDuplicateBlocksTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var minLines int
+	var content string
+	var duplicates []DuplicateBlockResult
+
+	logger.Info("Tool called", "tool", "find_duplicate_blocks")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	minLines, err = MinBlockLinesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if minLines < 2 {
+		err = fmt.Errorf("min_lines must be at least 2, got %d", minLines)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "find_duplicate_blocks",
+		"path", path,
+		"min_lines", minLines)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	if isBinaryContent([]byte(content)) {
+		err = fmt.Errorf("cannot search binary file for duplicate blocks: %s", path)
+		goto end
+	}
+
+	duplicates = findDuplicateBlocks(normalizeLines(content), minLines)
+
+	logger.Info("Tool completed", "tool", "find_duplicate_blocks",
+		"path", path,
+		"duplicate_count", len(duplicates))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":            path,
+		"min_lines":       minLines,
+		"duplicates":      duplicates,
+		"duplicate_count": len(duplicates),
+	})
+
+end:
+	return result, err
+}
+
+// normalizeLines returns the non-blank lines of content with each run of
+// whitespace collapsed to a single space and leading and trailing whitespace
+// removed, along with their line numbers.
+func normalizeLines(content string) (lines []normalizedLine) {
+	var text string
+
+	for i, line := range strings.Split(content, "\n") {
+		text = strings.Join(strings.Fields(line), " ")
+		if text == "" {
+			continue
+		}
+		lines = append(lines, normalizedLine{text: text, line: i + 1})
+	}
+	return lines
+}
+
+// findDuplicateBlocks returns the blocks of at least minLines lines that occur
+// more than once in lines without overlapping themselves. Each pair of
+// occurrences is extended to the longest block they share, so a block is not
+// also reported as its sub-blocks. Blocks with no letters or digits, such as
+// runs of closing braces, are not reported. Results are sorted by size, then
+// by first occurrence.
+func findDuplicateBlocks(lines []normalizedLine, minLines int) (duplicates []DuplicateBlockResult) {
+	var windows map[string][]int
+	var starts map[string]map[int]int
+	var order []string
+	var key string
+	var length int
+	var dup DuplicateBlockResult
+
+	// Group the start of every window of minLines lines by its content
+	windows = make(map[string][]int)
+	for i := 0; i+minLines <= len(lines); i++ {
+		key = blockKey(lines[i : i+minLines])
+		windows[key] = append(windows[key], i)
+	}
+
+	// Extend each matching pair to the longest block it shares, skipping pairs
+	// that continue a match starting on the lines before them
+	starts = make(map[string]map[int]int)
+	for _, positions := range windows {
+		for a, i := range positions {
+			for _, j := range positions[a+1:] {
+				if i > 0 && lines[i-1].text == lines[j-1].text {
+					continue
+				}
+				length = minLines
+				for i+length < j && j+length < len(lines) && lines[i+length].text == lines[j+length].text {
+					length++
+				}
+				if i+length > j || !hasAlphanumeric(lines[i:i+length]) {
+					continue
+				}
+				key = blockKey(lines[i : i+length])
+				if starts[key] == nil {
+					starts[key] = make(map[int]int)
+					order = append(order, key)
+				}
+				starts[key][i] = length
+				starts[key][j] = length
+			}
+		}
+	}
+
+	duplicates = make([]DuplicateBlockResult, 0, len(order))
+	for _, key = range order {
+		dup = DuplicateBlockResult{}
+		for i, n := range starts[key] {
+			dup.Lines = n
+			dup.Occurrences = append(dup.Occurrences, LineRangeOccurrence{
+				StartLine: lines[i].line,
+				EndLine:   lines[i+n-1].line,
+			})
+		}
+		sort.Slice(dup.Occurrences, func(a, b int) bool {
+			return dup.Occurrences[a].StartLine < dup.Occurrences[b].StartLine
+		})
+		duplicates = append(duplicates, dup)
+	}
+
+	sort.Slice(duplicates, func(a, b int) bool {
+		if duplicates[a].Lines != duplicates[b].Lines {
+			return duplicates[a].Lines > duplicates[b].Lines
+		}
+		return duplicates[a].Occurrences[0].StartLine < duplicates[b].Occurrences[0].StartLine
+	})
+
+	return duplicates
+}
+
+// blockKey returns the content of lines as a single string for comparison.
+func blockKey(lines []normalizedLine) string {
+	var sb strings.Builder
+
+	for _, l := range lines {
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// hasAlphanumeric reports whether any of lines contains a letter or digit.
+func hasAlphanumeric(lines []normalizedLine) bool {
+	for _, l := range lines {
+		if strings.IndexFunc(l.text, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) >= 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindDuplicateBlocksDirPrefix = "find-duplicate-blocks-tool-test"
+
+// Find duplicate blocks tool result type
+type FindDuplicateBlocksResult struct {
+	Path           string                          `json:"path"`
+	MinLines       int                             `json:"min_lines"`
+	Duplicates     []mcptools.DuplicateBlockResult `json:"duplicates"`
+	DuplicateCount int                             `json:"duplicate_count"`
+}
+
+type findDuplicateBlocksResultOpts struct {
+	ExpectError        bool
+	ExpectedErrorMsg   string
+	ExpectedDuplicates []mcptools.DuplicateBlockResult
+}
+
+func requireFindDuplicateBlocksResult(t *testing.T, result *FindDuplicateBlocksResult, err error, opts findDuplicateBlocksResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, len(opts.ExpectedDuplicates), result.DuplicateCount, "Duplicate count should match")
+	assert.Equal(t, opts.ExpectedDuplicates, result.Duplicates, "Duplicates should match")
+}
+
+func TestFindDuplicateBlocksTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_duplicate_blocks")
+	require.NotNil(t, tool, "find_duplicate_blocks tool should be registered")
+
+	const source = `package main
+
+func a() {
+	x := load()
+	if x == nil {
+		return
+	}
+	save(x)
+}
+
+func b() {
+    x := load()
+
+    if x == nil {
+        return
+    }
+    save(x)
+    log(x)
+}
+
+func c() {
+	x := load()
+	if x == nil {
+		return
+	}
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(FindDuplicateBlocksDirPrefix)
+		pf := tf.AddRepoFixture("duplicate-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(params mcputil.Params) (*FindDuplicateBlocksResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[FindDuplicateBlocksResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call find_duplicate_blocks")
+	}
+
+	t.Run("NormalizedWhitespace_ShouldFindMaximalBlocks", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "min_lines": 4})
+		requireFindDuplicateBlocksResult(t, result, err, findDuplicateBlocksResultOpts{
+			ExpectedDuplicates: []mcptools.DuplicateBlockResult{
+				{Lines: 5, Occurrences: []mcptools.LineRangeOccurrence{
+					{StartLine: 4, EndLine: 8},
+					{StartLine: 12, EndLine: 17},
+				}},
+				{Lines: 4, Occurrences: []mcptools.LineRangeOccurrence{
+					{StartLine: 4, EndLine: 7},
+					{StartLine: 12, EndLine: 16},
+					{StartLine: 22, EndLine: 25},
+				}},
+			},
+		})
+	})
+
+	t.Run("HigherMinLines_ShouldReportFewer", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "min_lines": 6})
+		requireFindDuplicateBlocksResult(t, result, err, findDuplicateBlocksResultOpts{
+			ExpectedDuplicates: []mcptools.DuplicateBlockResult{},
+		})
+	})
+
+	t.Run("MinLinesTooSmall_ShouldError", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "min_lines": 1})
+		requireFindDuplicateBlocksResult(t, result, err, findDuplicateBlocksResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "min_lines must be at least 2",
+		})
+	})
+}
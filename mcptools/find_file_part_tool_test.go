@@ -284,6 +284,171 @@ func TestFindFilePartTool(t *testing.T) {
 		})
 	})
 
+	t.Run("FindGenericReceiverMethod_ShouldMatchBaseTypeName", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("find-generic-method-project", nil)
+		testFile := pf.AddFileFixture("find_generic_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "func",
+			"part_name":     "*Stack.Push",
+		})
+
+		result, err := mcputil.GetToolResult[FindFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding generic method")
+
+		requireFindFilePartResult(t, result, err, findFilePartResultOpts{
+			ExpectedFound:     true,
+			ExpectedFilePath:  testFile.Filepath,
+			ExpectedPartType:  "func",
+			ExpectedPartName:  "*Stack.Push",
+			ExpectedStartLine: 9,
+			ExpectedEndLine:   11,
+		})
+		assert.Contains(t, result.Content, "func (s *Stack[T]) Push(v T) {", "Content should be the generic method")
+	})
+
+	t.Run("FindField_ShouldLocateOnlyTheFieldLine", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("find-field-project", nil)
+		testFile := pf.AddFileFixture("find_field_test.go", &fsfix.FileFixtureArgs{
+			Content: GoTestContent})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "field",
+			"part_name":     "Config.Port",
+		})
+
+		result, err := mcputil.GetToolResult[FindFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding field")
+
+		requireFindFilePartResult(t, result, err, findFilePartResultOpts{
+			ExpectedFound:     true,
+			ExpectedFilePath:  testFile.Filepath,
+			ExpectedPartType:  "field",
+			ExpectedPartName:  "Config.Port",
+			ExpectedStartLine: 16,
+			ExpectedEndLine:   16,
+		})
+		assert.Equal(t, "Port string", result.Content, "Content should be just the field")
+	})
+
+	t.Run("FindFuncBody_ShouldLocateOnlyTheStatements", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("find-func-body-project", nil)
+		testFile := pf.AddFileFixture("find_func_body_test.go", &fsfix.FileFixtureArgs{
+			Content: GoTestContent})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "func_body",
+			"part_name":     "oldFunction",
+		})
+
+		result, err := mcputil.GetToolResult[FindFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding function body")
+
+		requireFindFilePartResult(t, result, err, findFilePartResultOpts{
+			ExpectedFound:     true,
+			ExpectedFilePath:  testFile.Filepath,
+			ExpectedPartType:  "func_body",
+			ExpectedPartName:  "oldFunction",
+			ExpectedStartLine: 28,
+			ExpectedEndLine:   30,
+		})
+		assert.Equal(t, "\n\treturn \"old implementation\"\n", result.Content, "Content should be just what is between the braces")
+	})
+
+	t.Run("FindInterfaceMethod_ShouldLocateOnlyTheSignature", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("find-interface-method-project", nil)
+		testFile := pf.AddFileFixture("find_interface_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoTestContent})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.GetUser",
+		})
+
+		result, err := mcputil.GetToolResult[FindFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding interface method")
+
+		requireFindFilePartResult(t, result, err, findFilePartResultOpts{
+			ExpectedFound:     true,
+			ExpectedFilePath:  testFile.Filepath,
+			ExpectedPartType:  "method",
+			ExpectedPartName:  "UserService.GetUser",
+			ExpectedStartLine: 21,
+			ExpectedEndLine:   21,
+		})
+		assert.Equal(t, "GetUser(id string) (*User, error)", result.Content, "Content should be just the signature")
+	})
+
+	t.Run("FindMissingInterfaceMethod_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("find-missing-method-project", nil)
+		testFile := pf.AddFileFixture("find_missing_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoTestContent})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.DeleteUser",
+		})
+
+		result, err := mcputil.GetToolResult[FindFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should handle missing interface method")
+
+		requireFindFilePartResult(t, result, err, findFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "interface 'UserService' has no method 'DeleteUser'",
+		})
+	})
+
 	t.Run("PartNotFound_ShouldReturnErrorWithMessage", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(FindFilePartDirPrefix)
 		defer tf.Cleanup()
@@ -0,0 +1,157 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindLargeFunctionsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FindLargeFunctionsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_large_functions",
+			Description: "Find Go functions that exceed a line threshold or, optionally, a cyclomatic complexity threshold, sorted largest first",
+			QuickHelp:   "Find oversized or complex Go functions",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+				RecursiveProperty,
+				MinLinesProperty,
+				MaxCyclomaticProperty,
+			},
+		}),
+	})
+}
+
+// FindLargeFunctionsTool reports Go functions that are candidates for refactoring.
+type FindLargeFunctionsTool struct {
+	*mcputil.ToolBase
+}
+
+// LargeFunctionResult describes a function that exceeded a reporting threshold.
+type LargeFunctionResult struct {
+	File       string `json:"file"`
+	Func       string `json:"func"`
+	Line       int    `json:"line"`
+	Lines      int    `json:"lines"`
+	Complexity int    `json:"complexity"`
+}
+
+// Handle processes the find_large_functions tool request and returns the
+// functions exceeding the thresholds.
+func (t *FindLargeFunctionsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var minLines int
+	var maxCyclomatic int
+	var files []string
+	var functions []LargeFunctionResult
+	var parseErrors []string
+
+	logger.Info("Tool called", "tool", "find_large_functions")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	minLines, err = MinLinesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	maxCyclomatic, err = MaxCyclomaticProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "find_large_functions",
+		"path", path,
+		"recursive", recursive,
+		"min_lines", minLines,
+		"max_cyclomatic", maxCyclomatic)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	functions, parseErrors = findLargeFunctions(files, minLines, maxCyclomatic)
+
+	logger.Info("Tool completed", "tool", "find_large_functions",
+		"files_scanned", len(files),
+		"function_count", len(functions))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":           path,
+		"min_lines":      minLines,
+		"max_cyclomatic": maxCyclomatic,
+		"functions":      functions,
+		"function_count": len(functions),
+		"files_scanned":  len(files),
+		"errors":         parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// findLargeFunctions collects the functions in files that span at least
+// minLines lines or, when maxCyclomatic is positive, whose complexity
+// exceeds it. Results are sorted by lines, then complexity, descending.
+// Files that fail to parse are reported in parseErrors and skipped.
+func findLargeFunctions(files []string, minLines, maxCyclomatic int) (functions []LargeFunctionResult, parseErrors []string) {
+	var content []byte
+	var metrics []golang.GoFuncMetrics
+	var err error
+
+	functions = make([]LargeFunctionResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			metrics, err = golang.ParseFuncMetrics(fp, content)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		for _, m := range metrics {
+			if m.Lines < minLines && (maxCyclomatic <= 0 || m.Complexity <= maxCyclomatic) {
+				continue
+			}
+			functions = append(functions, LargeFunctionResult{
+				File:       fp,
+				Func:       m.Name,
+				Line:       m.Line,
+				Lines:      m.Lines,
+				Complexity: m.Complexity,
+			})
+		}
+	}
+
+	sort.SliceStable(functions, func(i, j int) bool {
+		if functions[i].Lines != functions[j].Lines {
+			return functions[i].Lines > functions[j].Lines
+		}
+		return functions[i].Complexity > functions[j].Complexity
+	})
+
+	return functions, parseErrors
+}
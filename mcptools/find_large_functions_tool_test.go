@@ -0,0 +1,149 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindLargeFunctionsDirPrefix = "find-large-functions-tool-test"
+
+// Find large functions tool result type
+type FindLargeFunctionsResult struct {
+	Path          string                         `json:"path"`
+	Functions     []mcptools.LargeFunctionResult `json:"functions"`
+	FunctionCount int                            `json:"function_count"`
+	FilesScanned  int                            `json:"files_scanned"`
+	Errors        []string                       `json:"errors"`
+}
+
+type findLargeFunctionsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedFuncs    []string
+	ExpectedErrors   int
+}
+
+func requireFindLargeFunctionsResult(t *testing.T, result *FindLargeFunctionsResult, err error, opts findLargeFunctionsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	funcs := make([]string, len(result.Functions))
+	for i, f := range result.Functions {
+		funcs[i] = f.Func
+	}
+	assert.Equal(t, opts.ExpectedFuncs, funcs, "Reported functions should match in order")
+	assert.Equal(t, len(opts.ExpectedFuncs), result.FunctionCount, "Function count should match")
+	assert.Len(t, result.Errors, opts.ExpectedErrors, "Parse error count should match")
+}
+
+const largeFunctionsSource = `package sample
+
+func small() int {
+	return 1
+}
+
+func medium(n int) int {
+	total := 0
+	for i := 0; i < n; i++ {
+		total += i
+	}
+	return total
+}
+
+func branchy(a, b bool, n int) string {
+	if a && b {
+		return "both"
+	}
+	switch n {
+	case 1:
+		return "one"
+	case 2:
+		return "two"
+	default:
+		return "many"
+	}
+}
+
+type Parser struct{}
+
+func (p *Parser) Parse(items []string) int {
+	count := 0
+	for _, item := range items {
+		if item == "" || item == "-" {
+			continue
+		}
+		count++
+	}
+	return count
+}
+`
+
+func TestFindLargeFunctionsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_large_functions")
+	require.NotNil(t, tool, "find_large_functions tool should be registered")
+
+	setup := func(t *testing.T) *fsfix.RootFixture {
+		tf := fsfix.NewRootFixture(FindLargeFunctionsDirPrefix)
+		tf.AddFileFixture("sample.go", &fsfix.FileFixtureArgs{Content: largeFunctionsSource})
+		tf.AddFileFixture("broken.go", &fsfix.FileFixtureArgs{Content: "package broken\n\nfunc {\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf
+	}
+
+	t.Run("MinLines_ShouldSortLargestFirst", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"min_lines":     7,
+		})
+
+		result, err := mcputil.GetToolResult[FindLargeFunctionsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding large functions")
+		requireFindLargeFunctionsResult(t, result, err, findLargeFunctionsResultOpts{
+			ExpectedFuncs:  []string{"branchy", "*Parser.Parse", "medium"},
+			ExpectedErrors: 1,
+		})
+		assert.Equal(t, 13, result.Functions[0].Lines, "branchy should span 13 lines")
+		assert.Equal(t, 5, result.Functions[0].Complexity, "branchy should count if, &&, and two cases")
+		assert.Equal(t, 4, result.Functions[1].Complexity, "Parse should count range, if, and ||")
+	})
+
+	t.Run("MaxCyclomatic_ShouldReportComplexFunctions", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           tf.TempDir(),
+			"min_lines":      100,
+			"max_cyclomatic": 4,
+		})
+
+		result, err := mcputil.GetToolResult[FindLargeFunctionsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding complex functions")
+		requireFindLargeFunctionsResult(t, result, err, findLargeFunctionsResultOpts{
+			ExpectedFuncs:  []string{"branchy"},
+			ExpectedErrors: 1,
+		})
+	})
+}
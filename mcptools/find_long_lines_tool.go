@@ -0,0 +1,198 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindLongLinesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FindLongLinesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_long_lines",
+			Description: "Find lines longer than a maximum number of characters in text files, sorted longest first, for enforcing style guides that cap line width. Binary files are skipped, and lines containing a URL or matching a pattern can be excluded",
+			QuickHelp:   "Find lines exceeding a maximum length",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathsProperty,
+				MaxLengthProperty,
+				RecursiveProperty,
+				ExtensionsProperty,
+				IgnoreURLsProperty,
+				IgnorePatternProperty,
+			},
+		}),
+	})
+}
+
+// urlRegexp matches the scheme of a URL such as https://example.com.
+var urlRegexp = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.-]*://`)
+
+// FindLongLinesTool reports lines that exceed a maximum length.
+type FindLongLinesTool struct {
+	*mcputil.ToolBase
+}
+
+// LongLineResult is a line longer than the maximum length.
+type LongLineResult struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Length int    `json:"length"`
+}
+
+// longLineArgs holds the options for findLongLines.
+type longLineArgs struct {
+	MaxLength  int            // Lines longer than this many characters are reported
+	IgnoreURLs bool           // Whether to skip lines containing a URL
+	Ignore     *regexp.Regexp // Optional pattern of lines to skip
+}
+
+// Handle processes the find_long_lines tool request and scans the given paths.
+func (t *FindLongLinesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var paths []string
+	var recursive bool
+	var extensions []string
+	var ignorePattern string
+	var args longLineArgs
+	var files []string
+	var lines []LongLineResult
+	var binaryCount int
+
+	logger.Info("Tool called", "tool", "find_long_lines")
+
+	paths, err = RequiredPathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	args.MaxLength, err = MaxLengthProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if args.MaxLength < 1 {
+		err = fmt.Errorf("max_length must be at least 1, got %d", args.MaxLength)
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid extensions array: %v", err)
+		goto end
+	}
+
+	args.IgnoreURLs, err = IgnoreURLsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	ignorePattern, err = IgnorePatternProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if ignorePattern != "" {
+		args.Ignore, err = regexp.Compile(ignorePattern)
+		if err != nil {
+			err = fmt.Errorf("invalid ignore_pattern: %w", err)
+			goto end
+		}
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "find_long_lines",
+		"paths", paths,
+		"max_length", args.MaxLength,
+		"recursive", recursive,
+		"extensions", extensions,
+		"ignore_urls", args.IgnoreURLs,
+		"ignore_pattern", ignorePattern)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: extensions,
+	})
+	if err != nil {
+		goto end
+	}
+
+	lines, binaryCount, err = findLongLines(files, args)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "find_long_lines",
+		"files_checked", len(files)-binaryCount,
+		"line_count", len(lines))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"paths":          paths,
+		"max_length":     args.MaxLength,
+		"lines":          lines,
+		"line_count":     len(lines),
+		"files_checked":  len(files) - binaryCount,
+		"binary_skipped": binaryCount,
+	})
+
+end:
+	return result, err
+}
+
+// findLongLines returns the lines in files longer than args.MaxLength
+// characters, sorted by length descending and otherwise in file order,
+// along with the number of binary files skipped. Line endings are not
+// counted, and a tab counts as one character.
+func findLongLines(files []string, args longLineArgs) (lines []LongLineResult, binaryCount int, err error) {
+	var content []byte
+	var length int
+
+	lines = make([]LongLineResult, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		if isBinaryContent(content) {
+			binaryCount++
+			continue
+		}
+		for i, line := range bytes.Split(content, []byte("\n")) {
+			line = bytes.TrimSuffix(line, []byte("\r"))
+			length = utf8.RuneCount(line)
+			if length <= args.MaxLength {
+				continue
+			}
+			if args.IgnoreURLs && urlRegexp.Match(line) {
+				continue
+			}
+			if args.Ignore != nil && args.Ignore.Match(line) {
+				continue
+			}
+			lines = append(lines, LongLineResult{
+				File:   fp,
+				Line:   i + 1,
+				Length: length,
+			})
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Length > lines[j].Length
+	})
+
+end:
+	return lines, binaryCount, err
+}
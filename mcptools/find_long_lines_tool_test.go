@@ -0,0 +1,152 @@
+package mcptools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindLongLinesDirPrefix = "find-long-lines-tool-test"
+
+// Find long lines tool result type
+type FindLongLinesResult struct {
+	Paths         []string                  `json:"paths"`
+	MaxLength     int                       `json:"max_length"`
+	Lines         []mcptools.LongLineResult `json:"lines"`
+	LineCount     int                       `json:"line_count"`
+	FilesChecked  int                       `json:"files_checked"`
+	BinarySkipped int                       `json:"binary_skipped"`
+}
+
+type findLongLinesResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedLengths      []int
+	ExpectedFilesChecked int
+	ExpectedBinary       int
+}
+
+func requireFindLongLinesResult(t *testing.T, result *FindLongLinesResult, err error, opts findLongLinesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	var lengths []int
+	for _, line := range result.Lines {
+		lengths = append(lengths, line.Length)
+	}
+	assert.Equal(t, opts.ExpectedLengths, lengths, "Line lengths should match, longest first")
+	assert.Equal(t, len(opts.ExpectedLengths), result.LineCount, "Line count should match")
+	assert.Equal(t, opts.ExpectedFilesChecked, result.FilesChecked, "Files checked should match")
+	assert.Equal(t, opts.ExpectedBinary, result.BinarySkipped, "Binary skipped count should match")
+}
+
+func TestFindLongLinesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_long_lines")
+	require.NotNil(t, tool, "find_long_lines tool should be registered")
+
+	long := func(n int) string {
+		return strings.Repeat("x", n)
+	}
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.RepoFixture) {
+		tf := fsfix.NewRootFixture(FindLongLinesDirPrefix)
+		pf := tf.AddRepoFixture("long-lines-project", nil)
+		pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n" + long(30) + "\n// see https://example.com/" + long(20) + "\n",
+		})
+		pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+			Content: "short\r\n" + long(25) + "\r\n" + "TODO " + long(40) + "\r\n",
+		})
+		pf.AddFileFixture("image.bin", &fsfix.FileFixtureArgs{Content: "\x00" + long(100)})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, pf
+	}
+
+	call := func(params mcputil.Params) (*FindLongLinesResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[FindLongLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call find_long_lines")
+	}
+
+	t.Run("MaxLength_ShouldReportLongestFirst", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{
+			"paths":      []any{pf.Dir()},
+			"max_length": 24,
+		})
+		requireFindLongLinesResult(t, result, err, findLongLinesResultOpts{
+			ExpectedLengths:      []int{47, 45, 30, 25},
+			ExpectedFilesChecked: 2,
+			ExpectedBinary:       1,
+		})
+		assert.Equal(t, 3, result.Lines[0].Line, "URL line should be reported at line 3")
+		assert.Equal(t, 3, result.Lines[1].Line, "TODO line should be reported at line 3")
+	})
+
+	t.Run("IgnoreURLsAndPattern_ShouldSkipMatches", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{
+			"paths":          []any{pf.Dir()},
+			"max_length":     24,
+			"ignore_urls":    true,
+			"ignore_pattern": "^TODO",
+		})
+		requireFindLongLinesResult(t, result, err, findLongLinesResultOpts{
+			ExpectedLengths:      []int{30, 25},
+			ExpectedFilesChecked: 2,
+			ExpectedBinary:       1,
+		})
+	})
+
+	t.Run("Extensions_ShouldFilterFiles", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{
+			"paths":      []any{pf.Dir()},
+			"max_length": 24,
+			"extensions": []any{".txt"},
+		})
+		requireFindLongLinesResult(t, result, err, findLongLinesResultOpts{
+			ExpectedLengths:      []int{45, 25},
+			ExpectedFilesChecked: 1,
+		})
+	})
+
+	t.Run("InvalidPattern_ShouldError", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{
+			"paths":          []any{pf.Dir()},
+			"ignore_pattern": "(",
+		})
+		requireFindLongLinesResult(t, result, err, findLongLinesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid ignore_pattern",
+		})
+	})
+}
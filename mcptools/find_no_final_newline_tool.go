@@ -0,0 +1,152 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindNoFinalNewlineTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FindNoFinalNewlineTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_no_final_newline",
+			Description: "Find text files that do not end with a newline, optionally appending the missing newline",
+			QuickHelp:   "Find (and fix) files missing a trailing newline",
+			Mutating:    true,
+			ReportOnly:  mcputil.WhenBool(FixProperty, false),
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathsProperty,
+				RecursiveProperty,
+				ExtensionsProperty,
+				FixProperty.Description("Append the missing trailing newline to each reported file"),
+			},
+		}),
+	})
+}
+
+// FindNoFinalNewlineTool reports, and optionally fixes, text files lacking a trailing newline.
+type FindNoFinalNewlineTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the find_no_final_newline tool request and scans the given paths.
+func (t *FindNoFinalNewlineTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var paths []string
+	var recursive bool
+	var extensions []string
+	var fix bool
+	var files []string
+	var missing []string
+	var binaryCount int
+	var fixedCount int
+
+	logger.Info("Tool called", "tool", "find_no_final_newline")
+
+	paths, err = RequiredPathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid extensions array: %v", err)
+		goto end
+	}
+
+	fix, err = FixProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "find_no_final_newline",
+		"paths", paths,
+		"recursive", recursive,
+		"extensions", extensions,
+		"fix", fix)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: extensions,
+	})
+	if err != nil {
+		goto end
+	}
+
+	missing, binaryCount, err = findNoFinalNewline(files)
+	if err != nil {
+		goto end
+	}
+
+	if fix {
+		for _, fp := range missing {
+			_, err = mcputil.AppendFile(ctx, t.Config(), fp, "\n", false)
+			if err != nil {
+				err = fmt.Errorf("failed to append newline to %s: %v", fp, err)
+				goto end
+			}
+			recordFileChange(ctx, req, mcputil.UpdatedFileOp, fp)
+			fixedCount++
+		}
+	}
+
+	logger.Info("Tool completed", "tool", "find_no_final_newline",
+		"files_checked", len(files)-binaryCount,
+		"missing_count", len(missing),
+		"fixed_count", fixedCount)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"paths":          paths,
+		"files":          missing,
+		"missing_count":  len(missing),
+		"files_checked":  len(files) - binaryCount,
+		"binary_skipped": binaryCount,
+		"fixed":          fix,
+		"fixed_count":    fixedCount,
+	})
+
+end:
+	return result, err
+}
+
+// findNoFinalNewline returns the non-empty text files that do not end with
+// a newline, along with the number of binary files skipped.
+func findNoFinalNewline(files []string) (missing []string, binaryCount int, err error) {
+	var content []byte
+
+	missing = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		if isBinaryContent(content) {
+			binaryCount++
+			continue
+		}
+		if !hasFinalNewline(content) {
+			missing = append(missing, fp)
+		}
+	}
+
+end:
+	return missing, binaryCount, err
+}
+
+// hasFinalNewline reports whether content ends with a newline. Empty content
+// is considered compliant since there is no final line to terminate.
+func hasFinalNewline(content []byte) bool {
+	return len(content) == 0 || content[len(content)-1] == '\n'
+}
@@ -0,0 +1,129 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindNoFinalNewlineDirPrefix = "find-no-final-newline-tool-test"
+
+// Find no final newline tool result type
+type FindNoFinalNewlineResult struct {
+	Paths         []string `json:"paths"`
+	Files         []string `json:"files"`
+	MissingCount  int      `json:"missing_count"`
+	FilesChecked  int      `json:"files_checked"`
+	BinarySkipped int      `json:"binary_skipped"`
+	Fixed         bool     `json:"fixed"`
+	FixedCount    int      `json:"fixed_count"`
+}
+
+type findNoFinalNewlineResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedMissingCount int
+	ExpectedFilesChecked int
+	ExpectedBinary       int
+	ExpectedFixedCount   int
+}
+
+func requireFindNoFinalNewlineResult(t *testing.T, result *FindNoFinalNewlineResult, err error, opts findNoFinalNewlineResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedMissingCount, result.MissingCount, "Missing count should match")
+	assert.Len(t, result.Files, opts.ExpectedMissingCount, "Files list should match missing count")
+	assert.Equal(t, opts.ExpectedFilesChecked, result.FilesChecked, "Files checked should match")
+	assert.Equal(t, opts.ExpectedBinary, result.BinarySkipped, "Binary skipped count should match")
+	assert.Equal(t, opts.ExpectedFixedCount, result.FixedCount, "Fixed count should match")
+}
+
+func TestFindNoFinalNewlineTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_no_final_newline")
+	require.NotNil(t, tool, "find_no_final_newline tool should be registered")
+
+	t.Run("ReportOnly_ShouldListFilesWithoutNewline", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindNoFinalNewlineDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("newline-project", nil)
+		pf.AddFileFixture("good.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+		bad := pf.AddFileFixture("bad.go", &fsfix.FileFixtureArgs{Content: "package main"})
+		pf.AddFileFixture("empty.txt", &fsfix.FileFixtureArgs{Content: ""})
+		pf.AddFileFixture("image.bin", &fsfix.FileFixtureArgs{Content: "\x00\x01\x02"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         []any{pf.Dir()},
+		})
+
+		result, err := mcputil.GetToolResult[FindNoFinalNewlineResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding files")
+		requireFindNoFinalNewlineResult(t, result, err, findNoFinalNewlineResultOpts{
+			ExpectedMissingCount: 1,
+			ExpectedFilesChecked: 3,
+			ExpectedBinary:       1,
+		})
+		assert.Equal(t, bad.Filepath, result.Files[0], "Should report the file without a trailing newline")
+
+		content, err := os.ReadFile(bad.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "package main", string(content), "Report-only mode should not modify files")
+	})
+
+	t.Run("FixWithExtensions_ShouldAppendNewline", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FindNoFinalNewlineDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("fix-project", nil)
+		bad := pf.AddFileFixture("bad.go", &fsfix.FileFixtureArgs{Content: "package main"})
+		other := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{Content: "no newline"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         []any{pf.Dir()},
+			"extensions":    []any{".go"},
+			"fix":           true,
+		})
+
+		result, err := mcputil.GetToolResult[FindNoFinalNewlineResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error fixing files")
+		requireFindNoFinalNewlineResult(t, result, err, findNoFinalNewlineResultOpts{
+			ExpectedMissingCount: 1,
+			ExpectedFilesChecked: 1,
+			ExpectedFixedCount:   1,
+		})
+
+		content, err := os.ReadFile(bad.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(content), "Fix mode should append a newline")
+
+		content, err = os.ReadFile(other.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "no newline", string(content), "Files filtered out by extension should not be modified")
+	})
+}
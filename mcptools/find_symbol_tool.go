@@ -0,0 +1,235 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindSymbolTool)(nil)
+
+const (
+	// defaultSymbolMaxResults is the page size when max_results is not given.
+	defaultSymbolMaxResults = 100
+
+	// symbolMaxResultsCap is the largest page size find_symbol will return.
+	symbolMaxResultsCap = 500
+)
+
+func init() {
+	mcputil.RegisterTool(&FindSymbolTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_symbol",
+			Description: "Find every top-level declaration of a symbol across all allowed paths. Scans Go files recursively, skipping default excludes such as vendor and node_modules, and returns each declaration's file, line and kind. Results are paginated: pass the returned next_offset back as offset while has_more is true",
+			QuickHelp:   "Locate a symbol's declarations across allowed paths",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				SymbolNameProperty.Required(),
+				RequiredLanguageProperty.Description("Programming language of the files to scan; only 'go' is supported"),
+				SymbolKindProperty,
+				ExcludeProperty.Description("Glob patterns of files or directories to exclude in addition to the defaults (e.g., ['testdata'])"),
+				MaxResultsProperty.Description(fmt.Sprintf("Maximum number of declarations to return (default: %d, maximum: %d)", defaultSymbolMaxResults, symbolMaxResultsCap)),
+				OffsetProperty.Description("Number of declarations to skip, as returned in next_offset (default: 0)"),
+			},
+		}),
+	})
+}
+
+// FindSymbolTool locates the declarations of a named symbol across all allowed paths.
+type FindSymbolTool struct {
+	*mcputil.ToolBase
+}
+
+// SymbolMatchResult describes a declaration of the requested symbol.
+type SymbolMatchResult struct {
+	File string `json:"file"`
+	golang.GoSymbol
+}
+
+// Handle processes the find_symbol tool request and returns one page of matching declarations.
+func (t *FindSymbolTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var name string
+	var language string
+	var kind string
+	var excludes []string
+	var maxResults int
+	var offset int
+	var files []string
+	var scanErrors []string
+	var parseErrors []string
+	var matches []SymbolMatchResult
+	var page []SymbolMatchResult
+	var last int
+	var hasMore bool
+
+	logger.Info("Tool called", "tool", "find_symbol")
+
+	name, err = SymbolNameProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = LanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	kind, err = SymbolKindProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	err = validateSymbolKind(kind)
+	if err != nil {
+		goto end
+	}
+
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+
+	maxResults, err = MaxResultsProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxResults <= 0 {
+		maxResults = defaultSymbolMaxResults
+	}
+	maxResults = min(maxResults, symbolMaxResultsCap)
+
+	offset, err = OffsetProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if offset < 0 {
+		err = fmt.Errorf("offset must not be negative, got %d", offset)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "find_symbol",
+		"name", name,
+		"language", language,
+		"kind", kind,
+		"exclude", excludes,
+		"max_results", maxResults,
+		"offset", offset)
+
+	files, scanErrors = t.collectSymbolFiles(append(golang.DefaultExcludes(), excludes...))
+
+	matches, parseErrors = findSymbols(files, name, langutil.PartType(kind))
+
+	last = min(offset+maxResults, len(matches))
+	page = make([]SymbolMatchResult, 0)
+	if offset < len(matches) {
+		page = matches[offset:last]
+	}
+	hasMore = last < len(matches)
+
+	logger.Info("Tool completed", "tool", "find_symbol",
+		"name", name,
+		"total", len(matches),
+		"returned", len(page),
+		"has_more", hasMore)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"name":          name,
+		"language":      language,
+		"kind":          kind,
+		"matches":       page,
+		"total":         len(matches),
+		"offset":        offset,
+		"next_offset":   offset + len(page),
+		"has_more":      hasMore,
+		"files_scanned": len(files),
+		"errors":        append(scanErrors, parseErrors...),
+	})
+
+end:
+	return result, err
+}
+
+// collectSymbolFiles returns the Go files under every allowed path, skipping
+// entries that match excludes. An allowed path that cannot be scanned is
+// reported in scanErrors rather than failing the whole search.
+func (t *FindSymbolTool) collectSymbolFiles(excludes []string) (files []string, scanErrors []string) {
+	var found []string
+	var err error
+
+	files = make([]string, 0)
+	scanErrors = make([]string, 0)
+	for _, path := range t.Config().AllowedPaths() {
+		found, err = collectTreeFiles(t.Config(), treeScanArgs{
+			Paths:      []string{path},
+			Recursive:  true,
+			Extensions: []string{".go"},
+			Excludes:   excludes,
+		})
+		if err != nil {
+			scanErrors = append(scanErrors, err.Error())
+			continue
+		}
+		files = append(files, found...)
+	}
+	return files, scanErrors
+}
+
+// validateSymbolKind checks that kind is empty or a declaration kind that
+// find_symbol can match.
+func validateSymbolKind(kind string) (err error) {
+	switch langutil.PartType(kind) {
+	case "", golang.FuncGoPart, golang.TypeGoPart, golang.ConstGoPart, golang.VarGoPart:
+	default:
+		err = fmt.Errorf("kind must be '%s', '%s', '%s' or '%s', got '%s'",
+			golang.FuncGoPart,
+			golang.TypeGoPart,
+			golang.ConstGoPart,
+			golang.VarGoPart,
+			kind,
+		)
+	}
+	return err
+}
+
+// findSymbols returns the declarations named name in files, restricted to
+// kind unless it is empty. Files that fail to read or parse are reported in
+// parseErrors and skipped.
+func findSymbols(files []string, name string, kind langutil.PartType) (matches []SymbolMatchResult, parseErrors []string) {
+	var content []byte
+	var symbols []golang.GoSymbol
+	var err error
+
+	matches = make([]SymbolMatchResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		symbols, err = golang.ParseSymbols(fp, content)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		for _, sym := range symbols {
+			if !sym.Matches(name) {
+				continue
+			}
+			if kind != "" && sym.Kind != kind {
+				continue
+			}
+			matches = append(matches, SymbolMatchResult{File: fp, GoSymbol: sym})
+		}
+	}
+	return matches, parseErrors
+}
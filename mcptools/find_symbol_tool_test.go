@@ -0,0 +1,207 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindSymbolDirPrefix = "find-symbol-tool-test"
+
+// Find symbol tool result type
+type FindSymbolResult struct {
+	Name         string                       `json:"name"`
+	Kind         string                       `json:"kind"`
+	Matches      []mcptools.SymbolMatchResult `json:"matches"`
+	Total        int                          `json:"total"`
+	Offset       int                          `json:"offset"`
+	NextOffset   int                          `json:"next_offset"`
+	HasMore      bool                         `json:"has_more"`
+	FilesScanned int                          `json:"files_scanned"`
+	Errors       []string                     `json:"errors"`
+}
+
+type findSymbolResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedKinds    []string
+	ExpectedTotal    int
+	ExpectedHasMore  bool
+	ExpectedScanned  int
+}
+
+func requireFindSymbolResult(t *testing.T, result *FindSymbolResult, err error, opts findSymbolResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	kinds := make([]string, len(result.Matches))
+	for i, m := range result.Matches {
+		kinds[i] = string(m.Kind)
+	}
+	assert.Equal(t, opts.ExpectedKinds, kinds, "Match kinds should match in order")
+	assert.Equal(t, opts.ExpectedTotal, result.Total, "Total should match")
+	assert.Equal(t, opts.ExpectedHasMore, result.HasMore, "has_more should match")
+	if opts.ExpectedScanned > 0 {
+		assert.Equal(t, opts.ExpectedScanned, result.FilesScanned, "Files scanned should match")
+	}
+}
+
+const findSymbolAlphaSource = `package alpha
+
+type Widget struct{}
+
+func (w *Widget) Render() string { return "" }
+
+func Render() {}
+`
+
+const findSymbolBetaSource = `package beta
+
+const (
+	Render = "render"
+	_      = 1
+)
+
+var Widget, other = 1, 2
+`
+
+func TestFindSymbolTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_symbol")
+	require.NotNil(t, tool, "find_symbol tool should be registered")
+
+	setup := func(t *testing.T) *fsfix.RootFixture {
+		tf := fsfix.NewRootFixture(FindSymbolDirPrefix)
+		tf.AddFileFixture("alpha/alpha.go", &fsfix.FileFixtureArgs{Content: findSymbolAlphaSource})
+		tf.AddFileFixture("beta/beta.go", &fsfix.FileFixtureArgs{Content: findSymbolBetaSource})
+		tf.AddFileFixture("vendor/dep/dep.go", &fsfix.FileFixtureArgs{Content: "package dep\n\nfunc Render() {}\n"})
+		tf.AddFileFixture("testdata/td.go", &fsfix.FileFixtureArgs{Content: "package td\n\nfunc Render() {}\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf
+	}
+
+	t.Run("Name_ShouldFindDeclarationsOfEveryKind", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Render",
+			"language":      "go",
+			"exclude":       []any{"testdata"},
+		})
+
+		result, err := mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding symbol")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectedKinds:   []string{"func", "func", "const"},
+			ExpectedTotal:   3,
+			ExpectedScanned: 2,
+		})
+		assert.Equal(t, "Widget", result.Matches[0].Receiver, "Method should report its receiver")
+		assert.Equal(t, 5, result.Matches[0].Line, "Method should be reported at its line")
+		assert.Equal(t, 4, result.Matches[2].Line, "Grouped const should be reported at its own line")
+	})
+
+	t.Run("Kind_ShouldFilterDeclarations", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Widget",
+			"language":      "go",
+			"kind":          "var",
+		})
+
+		result, err := mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding symbol")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectedKinds: []string{"var"},
+			ExpectedTotal: 1,
+		})
+	})
+
+	t.Run("QualifiedMethod_ShouldMatchReceiver", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Widget.Render",
+			"language":      "go",
+		})
+
+		result, err := mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding symbol")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectedKinds: []string{"func"},
+			ExpectedTotal: 1,
+		})
+	})
+
+	t.Run("MaxResults_ShouldPaginate", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Render",
+			"language":      "go",
+			"max_results":   2,
+		})
+
+		result, err := mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding symbol")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectedKinds:   []string{"func", "func"},
+			ExpectedTotal:   4,
+			ExpectedHasMore: true,
+		})
+		require.Equal(t, 2, result.NextOffset, "Next offset should follow the page")
+
+		req = mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Render",
+			"language":      "go",
+			"max_results":   2,
+			"offset":        result.NextOffset,
+		})
+
+		result, err = mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding symbol")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectedKinds: []string{"const", "func"},
+			ExpectedTotal: 4,
+		})
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"name":          "Render",
+			"language":      "python",
+		})
+
+		result, err := mcputil.GetToolResult[FindSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for unsupported language")
+		requireFindSymbolResult(t, result, err, findSymbolResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "unsupported language",
+		})
+	})
+}
@@ -0,0 +1,153 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FindUntestedFunctionsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FindUntestedFunctionsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "find_untested_functions",
+			Description: "List the exported functions and methods of a Go package that have no Test<Name> function in the package's _test.go files. This is a name-based heuristic, not coverage",
+			QuickHelp:   "Find exported Go funcs without tests",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go package directory, or a Go file whose package to check"),
+			},
+		}),
+	})
+}
+
+// FindUntestedFunctionsTool reports exported Go functions lacking a test named for them.
+type FindUntestedFunctionsTool struct {
+	*mcputil.ToolBase
+}
+
+// UntestedFunctionResult describes an exported function with no test named for it.
+type UntestedFunctionResult struct {
+	File string `json:"file"`
+	golang.GoExportedFunc
+}
+
+// Handle processes the find_untested_functions tool request and returns the
+// exported functions that lack tests.
+func (t *FindUntestedFunctionsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var info os.FileInfo
+	var files []string
+	var untested []UntestedFunctionResult
+	var stats untestedFuncStats
+
+	logger.Info("Tool called", "tool", "find_untested_functions")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "find_untested_functions", "path", path)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		goto end
+	}
+	if !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+
+	// A package is a single directory, so subdirectories are never scanned
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  false,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	untested, stats = findUntestedFunctions(files)
+
+	logger.Info("Tool completed", "tool", "find_untested_functions",
+		"exported_count", stats.ExportedCount,
+		"untested_count", len(untested))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":           path,
+		"untested":       untested,
+		"untested_count": len(untested),
+		"exported_count": stats.ExportedCount,
+		"test_count":     stats.TestCount,
+		"errors":         stats.ParseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// untestedFuncStats summarizes what findUntestedFunctions examined.
+type untestedFuncStats struct {
+	ExportedCount int
+	TestCount     int
+	ParseErrors   []string
+}
+
+// findUntestedFunctions returns the exported functions declared in the
+// non-test files of files for which no Test function in the _test.go files
+// is named. Files that fail to parse are reported in stats and skipped.
+func findUntestedFunctions(files []string) (untested []UntestedFunctionResult, stats untestedFuncStats) {
+	var content []byte
+	var names []string
+	var testNames []string
+	var funcs []golang.GoExportedFunc
+	var exported []UntestedFunctionResult
+	var err error
+
+	stats.ParseErrors = make([]string, 0)
+	testNames = make([]string, 0)
+	exported = make([]UntestedFunctionResult, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			stats.ParseErrors = append(stats.ParseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		if strings.HasSuffix(fp, "_test.go") {
+			names, err = golang.ParseTestFuncNames(fp, content)
+			testNames = append(testNames, names...)
+		} else {
+			funcs, err = golang.ParseExportedFuncs(fp, content)
+			for _, fn := range funcs {
+				exported = append(exported, UntestedFunctionResult{File: fp, GoExportedFunc: fn})
+			}
+		}
+		if err != nil {
+			stats.ParseErrors = append(stats.ParseErrors, fmt.Sprintf("%s: %v", fp, err))
+		}
+	}
+
+	untested = make([]UntestedFunctionResult, 0)
+	for _, fn := range exported {
+		if fn.HasTestFunc(testNames) {
+			continue
+		}
+		untested = append(untested, fn)
+	}
+	stats.ExportedCount = len(exported)
+	stats.TestCount = len(testNames)
+
+	return untested, stats
+}
@@ -0,0 +1,163 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FindUntestedFunctionsDirPrefix = "find-untested-functions-tool-test"
+
+// Find untested functions tool result type
+type FindUntestedFunctionsResult struct {
+	Path          string                            `json:"path"`
+	Untested      []mcptools.UntestedFunctionResult `json:"untested"`
+	UntestedCount int                               `json:"untested_count"`
+	ExportedCount int                               `json:"exported_count"`
+	TestCount     int                               `json:"test_count"`
+	Errors        []string                          `json:"errors"`
+}
+
+type findUntestedFunctionsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedFuncs    []string
+	ExpectedExported int
+	ExpectedTests    int
+}
+
+func requireFindUntestedFunctionsResult(t *testing.T, result *FindUntestedFunctionsResult, err error, opts findUntestedFunctionsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	funcs := make([]string, len(result.Untested))
+	for i, f := range result.Untested {
+		funcs[i] = f.Name
+	}
+	assert.Equal(t, opts.ExpectedFuncs, funcs, "Untested functions should match in order")
+	assert.Equal(t, len(opts.ExpectedFuncs), result.UntestedCount, "Untested count should match")
+	assert.Equal(t, opts.ExpectedExported, result.ExportedCount, "Exported count should match")
+	assert.Equal(t, opts.ExpectedTests, result.TestCount, "Test count should match")
+}
+
+const untestedFunctionsSource = `package sample
+
+func Parse(s string) int { return len(s) }
+
+func Format(n int) string { return "" }
+
+func helper() {}
+
+type Client struct{}
+
+func NewClient() *Client { return &Client{} }
+
+func (c *Client) Send() error { return nil }
+
+func (c *Client) Close() error { return nil }
+
+func (c Client) String() string { return "" }
+
+type internal struct{}
+
+func (i internal) Exported() {}
+`
+
+const untestedFunctionsTestSource = `package sample_test
+
+import "testing"
+
+func TestParse(t *testing.T) {}
+
+func TestNewClient_NilOptions(t *testing.T) {}
+
+func TestClient_Send(t *testing.T) {}
+
+func TestClientClose(t *testing.T) {}
+
+func helperTest(t *testing.T) {}
+`
+
+func TestFindUntestedFunctionsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("find_untested_functions")
+	require.NotNil(t, tool, "find_untested_functions tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(FindUntestedFunctionsDirPrefix)
+		ff := tf.AddFileFixture("sample.go", &fsfix.FileFixtureArgs{Content: untestedFunctionsSource})
+		tf.AddFileFixture("sample_test.go", &fsfix.FileFixtureArgs{Content: untestedFunctionsTestSource})
+		tf.AddFileFixture("sub/other.go", &fsfix.FileFixtureArgs{Content: "package sub\n\nfunc Other() {}\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	t.Run("PackageDir_ShouldListUntestedExportedFuncs", func(t *testing.T) {
+		tf, _ := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+		})
+
+		result, err := mcputil.GetToolResult[FindUntestedFunctionsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding untested functions")
+		requireFindUntestedFunctionsResult(t, result, err, findUntestedFunctionsResultOpts{
+			ExpectedFuncs:    []string{"Format", "Client.String"},
+			ExpectedExported: 6,
+			ExpectedTests:    4,
+		})
+		assert.Equal(t, 5, result.Untested[0].Line, "Format should be reported at its line")
+		assert.Equal(t, "Client", result.Untested[1].Receiver, "String should report its receiver")
+	})
+
+	t.Run("GoFile_ShouldCheckItsPackage", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[FindUntestedFunctionsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error finding untested functions")
+		requireFindUntestedFunctionsResult(t, result, err, findUntestedFunctionsResultOpts{
+			ExpectedFuncs:    []string{"Format", "Client.String"},
+			ExpectedExported: 6,
+			ExpectedTests:    4,
+		})
+	})
+
+	t.Run("MissingPath_ShouldError", func(t *testing.T) {
+		tf, _ := setup(t)
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir() + "/missing",
+		})
+
+		result, err := mcputil.GetToolResult[FindUntestedFunctionsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for missing path")
+		requireFindUntestedFunctionsResult(t, result, err, findUntestedFunctionsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "no such file",
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FormatFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&FormatFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "format_file",
+			Description: "Format a file with its language processor's formatter, such as gofmt for Go, and write the canonical result back, such as after a series of granular edits. With check_only, report whether the file is already formatted without modifying it",
+			QuickHelp:   "Format a file in its language's canonical style",
+			Previewable: true,
+			ReportOnly:  mcputil.WhenBool(CheckOnlyProperty, true),
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to format"),
+				LanguageProperty.Description("Language of the file (default: detected from the file extension)"),
+				CheckOnlyProperty,
+			},
+		}),
+	})
+}
+
+// FormatFileTool formats a file with its language processor's formatter.
+type FormatFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the format_file tool request and formats the file, or
+// with check_only reports whether it is already formatted.
+func (t *FormatFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var checkOnly bool
+	var lang langutil.Language
+	var content string
+	var formatted string
+	var diff string
+	var changed bool
+	var message string
+
+	logger.Info("Tool called", "tool", "format_file")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = LanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	checkOnly, err = CheckOnlyProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	lang = langutil.Language(language)
+	if language == "" {
+		lang = langutil.DetectLanguage(path)
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "format_file",
+		"path", path,
+		"language", lang,
+		"check_only", checkOnly)
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	formatted, err = langutil.FormatSource(lang, content)
+	if errors.Is(err, langutil.ErrLanguageNotSupported) {
+		err = fmt.Errorf("%w for language %s", langutil.ErrFormattingNotSupported, lang)
+	}
+	if err != nil {
+		goto end
+	}
+
+	diff, err = mcputil.UnifiedDiff("a/"+path, "b/"+path, content, formatted)
+	if err != nil {
+		goto end
+	}
+
+	switch {
+	case formatted == content:
+		message = fmt.Sprintf("%s is already formatted", path)
+	case checkOnly:
+		message = fmt.Sprintf("%s is not formatted", path)
+	default:
+		err = WriteFile(ctx, t.Config(), path, formatted)
+		if err != nil {
+			goto end
+		}
+		changed = true
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+		message = fmt.Sprintf("Formatted %s", path)
+	}
+
+	logger.Info("Tool completed", "tool", "format_file",
+		"path", path,
+		"already_formatted", formatted == content,
+		"changed", changed)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":           true,
+		"path":              path,
+		"language":          lang,
+		"already_formatted": formatted == content,
+		"changed":           changed,
+		"diff":              diff,
+		"message":           message,
+	})
+
+end:
+	return result, err
+}
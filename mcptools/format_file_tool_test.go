@@ -0,0 +1,168 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FormatFileDirPrefix = "format-file-tool-test"
+
+// Format file tool result type
+type FormatFileResult struct {
+	Success          bool   `json:"success"`
+	Path             string `json:"path"`
+	Language         string `json:"language"`
+	AlreadyFormatted bool   `json:"already_formatted"`
+	Changed          bool   `json:"changed"`
+	Diff             string `json:"diff"`
+	Message          string `json:"message"`
+}
+
+type formatFileResultOpts struct {
+	ExpectError              bool
+	ExpectedErrorMsg         string
+	ExpectedAlreadyFormatted bool
+	ExpectedChanged          bool
+}
+
+func requireFormatFileResult(t *testing.T, result *FormatFileResult, err error, opts formatFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedAlreadyFormatted, result.AlreadyFormatted, "Already formatted should match")
+	assert.Equal(t, opts.ExpectedChanged, result.Changed, "Changed should match")
+	assert.Equal(t, opts.ExpectedAlreadyFormatted, result.Diff == "", "Diff should be empty only when already formatted")
+}
+
+func TestFormatFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("format_file")
+	require.NotNil(t, tool, "format_file tool should be registered")
+
+	const unformatted = "package main\n\nfunc main()  {\nx:=1\n_ = x\n}\n"
+	const formatted = "package main\n\nfunc main() {\n\tx := 1\n\t_ = x\n}\n"
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	formatFile := func(params mcputil.Params) (*FormatFileResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[FormatFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call format_file")
+	}
+
+	t.Run("UnformattedGo_ShouldWriteGofmtResult", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FormatFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("format-project", nil)
+		goFile := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: unformatted,
+		})
+		setup(t, tf)
+
+		result, err := formatFile(mcputil.Params{
+			"path": goFile.Filepath,
+		})
+		requireFormatFileResult(t, result, err, formatFileResultOpts{
+			ExpectedChanged: true,
+		})
+		assert.Equal(t, "go", result.Language, "Language should be detected from the extension")
+		requireFileContent(t, goFile.Filepath, formatted)
+	})
+
+	t.Run("CheckOnly_ShouldNotModifyFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FormatFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("check-project", nil)
+		goFile := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: unformatted,
+		})
+		setup(t, tf)
+
+		result, err := formatFile(mcputil.Params{
+			"path":       goFile.Filepath,
+			"language":   "go",
+			"check_only": true,
+		})
+		requireFormatFileResult(t, result, err, formatFileResultOpts{})
+		requireFileContent(t, goFile.Filepath, unformatted)
+	})
+
+	t.Run("FormattedGo_ShouldReportAlreadyFormatted", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FormatFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("clean-project", nil)
+		goFile := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: formatted,
+		})
+		setup(t, tf)
+
+		result, err := formatFile(mcputil.Params{
+			"path": goFile.Filepath,
+		})
+		requireFormatFileResult(t, result, err, formatFileResultOpts{
+			ExpectedAlreadyFormatted: true,
+		})
+	})
+
+	t.Run("InvalidGo_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FormatFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("invalid-project", nil)
+		goFile := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc main() {\n",
+		})
+		setup(t, tf)
+
+		result, err := formatFile(mcputil.Params{
+			"path": goFile.Filepath,
+		})
+		requireFormatFileResult(t, result, err, formatFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "failed to format Go source",
+		})
+		requireFileContent(t, goFile.Filepath, "package main\n\nfunc main() {\n")
+	})
+
+	t.Run("LanguageWithoutFormatter_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(FormatFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("python-project", nil)
+		pyFile := pf.AddFileFixture("main.py", &fsfix.FileFixtureArgs{
+			Content: "def main():\n    pass\n",
+		})
+		setup(t, tf)
+
+		result, err := formatFile(mcputil.Params{
+			"path": pyFile.Filepath,
+		})
+		requireFormatFileResult(t, result, err, formatFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "formatting not supported for language python",
+		})
+	})
+}
@@ -0,0 +1,271 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*FuzzyFindFilesTool)(nil)
+
+// defaultFuzzyMaxResults caps the number of matches when max_results is not given.
+const defaultFuzzyMaxResults = 20
+
+// Scores used by fuzzyScore. Each matched character earns fuzzyMatchScore
+// plus any bonuses; gaps between matched characters cost a penalty.
+const (
+	fuzzyMatchScore       = 16
+	fuzzyBoundaryBonus    = 8 // Match at the start of a path segment or word
+	fuzzyConsecutiveBonus = 4 // Match immediately after the previous match
+	fuzzyBaseNameBonus    = 2 // Match within the file's base name
+	fuzzyGapStartPenalty  = 3 // First character skipped between two matches
+	fuzzyGapExtendPenalty = 1 // Each further character skipped
+)
+
+func init() {
+	mcputil.RegisterTool(&FuzzyFindFilesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "fuzzy_find_files",
+			Description: "Find files whose path relative to a directory fuzzily matches a query, for when the exact file name is unknown. A file matches when the query's characters appear in its relative path in order, ignoring case; matches are ranked by a score that rewards characters at the start of path segments or words, consecutive characters and characters in the base name, and penalizes gaps",
+			QuickHelp:   "Find files by approximate name",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Directory to search"),
+				FuzzyQueryProperty.Required(),
+				MaxResultsProperty.Description(fmt.Sprintf("Maximum number of matches to return (default: %d)", defaultFuzzyMaxResults)),
+				ExcludeProperty.Description("Glob patterns of files or directories to exclude in addition to the defaults (e.g., ['testdata'])"),
+			},
+		}),
+	})
+}
+
+// FuzzyFindFilesTool ranks the files under a directory by how well their
+// relative paths match a fuzzy query.
+type FuzzyFindFilesTool struct {
+	*mcputil.ToolBase
+}
+
+// FuzzyFileMatch describes a file that matched a fuzzy query.
+type FuzzyFileMatch struct {
+	File  string `json:"file"`  // Full path of the file
+	Path  string `json:"path"`  // Path relative to the searched directory, with forward slashes
+	Score int    `json:"score"` // Higher is better
+}
+
+// Handle processes the fuzzy_find_files tool request and returns the best
+// matching files.
+func (t *FuzzyFindFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var query string
+	var maxResults int
+	var excludes []string
+	var files []string
+	var matches []FuzzyFileMatch
+	var matchCount int
+
+	logger.Info("Tool called", "tool", "fuzzy_find_files")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	query, err = FuzzyQueryProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		err = fmt.Errorf("query must not be empty")
+		goto end
+	}
+
+	maxResults, err = MaxResultsProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxResults <= 0 {
+		maxResults = defaultFuzzyMaxResults
+	}
+
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+	excludes = append(golang.DefaultExcludes(), excludes...)
+
+	logger.Info("Tool arguments parsed",
+		"tool", "fuzzy_find_files",
+		"path", path,
+		"query", query,
+		"max_results", maxResults,
+		"exclude", excludes)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:     []string{path},
+		Recursive: true,
+		Excludes:  excludes,
+	})
+	if err != nil {
+		goto end
+	}
+
+	matches, err = fuzzyMatchFiles(path, files, query)
+	if err != nil {
+		goto end
+	}
+	matchCount = len(matches)
+	if len(matches) > maxResults {
+		matches = matches[:maxResults]
+	}
+
+	logger.Info("Tool completed", "tool", "fuzzy_find_files",
+		"path", path,
+		"files_scanned", len(files),
+		"match_count", matchCount)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":          path,
+		"query":         query,
+		"matches":       matches,
+		"match_count":   matchCount,
+		"files_scanned": len(files),
+		"truncated":     matchCount > len(matches),
+	})
+
+end:
+	return result, err
+}
+
+// fuzzyMatchFiles scores each file's path relative to root against query
+// and returns the matches best first. Ties go to the shorter path, then to
+// the path that sorts first.
+func fuzzyMatchFiles(root string, files []string, query string) (matches []FuzzyFileMatch, err error) {
+	var rel string
+	var score int
+	var ok bool
+
+	matches = make([]FuzzyFileMatch, 0)
+	for _, fp := range files {
+		rel, err = filepath.Rel(root, fp)
+		if err != nil {
+			goto end
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			rel = filepath.Base(fp)
+		}
+		score, ok = fuzzyScore(query, rel)
+		if !ok {
+			continue
+		}
+		matches = append(matches, FuzzyFileMatch{File: fp, Path: rel, Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if len(matches[i].Path) != len(matches[j].Path) {
+			return len(matches[i].Path) < len(matches[j].Path)
+		}
+		return matches[i].Path < matches[j].Path
+	})
+
+end:
+	return matches, err
+}
+
+// fuzzyScore reports whether the characters of query appear in order in
+// candidate, ignoring case, and if so the score of the best such alignment.
+// Whitespace in query is ignored.
+func fuzzyScore(query, candidate string) (score int, ok bool) {
+	var q, c, lower []rune
+	var prev, cur []int
+	var baseStart int
+	var carry int
+	var best int
+
+	const none = -1 << 30
+
+	q = []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	c = []rune(candidate)
+	lower = []rune(strings.ToLower(candidate))
+	if len(q) == 0 || len(q) > len(c) {
+		goto end
+	}
+	baseStart = strings.LastIndexByte(candidate, '/') + 1
+	baseStart = len([]rune(candidate[:baseStart]))
+
+	// prev[j] holds the best score for the query so far with its last
+	// character matched at c[j], or none when no alignment ends there.
+	prev = make([]int, len(c))
+	cur = make([]int, len(c))
+	for j := range c {
+		prev[j] = none
+		if lower[j] == q[0] {
+			prev[j] = fuzzyCharScore(c, j, baseStart)
+		}
+	}
+
+	for i := 1; i < len(q); i++ {
+		carry = none
+		for j := range c {
+			cur[j] = none
+			if j >= 2 && prev[j-2] != none {
+				carry = max(carry, prev[j-2]-fuzzyGapStartPenalty)
+			}
+			if lower[j] == q[i] {
+				best = carry
+				if j >= 1 && prev[j-1] != none {
+					best = max(best, prev[j-1]+fuzzyConsecutiveBonus)
+				}
+				if best != none {
+					cur[j] = best + fuzzyCharScore(c, j, baseStart)
+				}
+			}
+			if carry != none {
+				carry -= fuzzyGapExtendPenalty
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	score = none
+	for _, s := range prev {
+		score = max(score, s)
+	}
+	ok = score != none
+
+end:
+	if !ok {
+		score = 0
+	}
+	return score, ok
+}
+
+// fuzzyCharScore returns the score for matching c[j], including bonuses for
+// a match at a segment or word boundary and for a match in the base name,
+// which starts at c[baseStart].
+func fuzzyCharScore(c []rune, j, baseStart int) (score int) {
+	score = fuzzyMatchScore
+	switch {
+	case j == 0:
+		score += fuzzyBoundaryBonus
+	case strings.ContainsRune("/_-. ", c[j-1]):
+		score += fuzzyBoundaryBonus
+	case unicode.IsLower(c[j-1]) && unicode.IsUpper(c[j]):
+		score += fuzzyBoundaryBonus
+	}
+	if j >= baseStart {
+		score += fuzzyBaseNameBonus
+	}
+	return score
+}
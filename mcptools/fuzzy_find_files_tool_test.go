@@ -0,0 +1,167 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const FuzzyFindFilesDirPrefix = "fuzzy-find-files-tool-test"
+
+// Fuzzy find files tool result type
+type FuzzyFindFilesResult struct {
+	Path         string                    `json:"path"`
+	Query        string                    `json:"query"`
+	Matches      []mcptools.FuzzyFileMatch `json:"matches"`
+	MatchCount   int                       `json:"match_count"`
+	FilesScanned int                       `json:"files_scanned"`
+	Truncated    bool                      `json:"truncated"`
+}
+
+type fuzzyFindFilesResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedPaths        []string
+	ExpectedMatchCount   int
+	ExpectedFilesScanned int
+	ExpectTruncated      bool
+}
+
+func requireFuzzyFindFilesResult(t *testing.T, result *FuzzyFindFilesResult, err error, opts fuzzyFindFilesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	paths := make([]string, len(result.Matches))
+	for i, m := range result.Matches {
+		paths[i] = m.Path
+	}
+	if opts.ExpectedPaths == nil {
+		opts.ExpectedPaths = []string{}
+	}
+	assert.Equal(t, opts.ExpectedPaths, paths, "Matched paths should be ranked as expected")
+	assert.Equal(t, opts.ExpectedMatchCount, result.MatchCount, "Match count should match")
+	assert.Equal(t, opts.ExpectedFilesScanned, result.FilesScanned, "Files scanned should match")
+	assert.Equal(t, opts.ExpectTruncated, result.Truncated, "Truncated should match")
+}
+
+func TestFuzzyFindFilesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("fuzzy_find_files")
+	require.NotNil(t, tool, "fuzzy_find_files tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(FuzzyFindFilesDirPrefix)
+		pf := tf.AddRepoFixture("fuzzy-project", nil)
+		pf.AddFileFixture("handlers/user_controller.go", &fsfix.FileFixtureArgs{Content: "package handlers\n"})
+		pf.AddFileFixture("handlers/user_controller_test.go", &fsfix.FileFixtureArgs{Content: "package handlers\n"})
+		pf.AddFileFixture("models/user.go", &fsfix.FileFixtureArgs{Content: "package models\n"})
+		pf.AddFileFixture("docs/UserGuide.md", &fsfix.FileFixtureArgs{Content: "# Users\n"})
+		pf.AddFileFixture("vendor/lib/user_controller.go", &fsfix.FileFixtureArgs{Content: "package lib\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, pf.Dir()
+	}
+
+	call := func(params mcputil.Params) (*FuzzyFindFilesResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[FuzzyFindFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call fuzzy_find_files")
+	}
+
+	t.Run("Abbreviation_ShouldRankBestMatchFirst", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "usrctl"})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectedPaths:        []string{"handlers/user_controller.go", "handlers/user_controller_test.go"},
+			ExpectedMatchCount:   2,
+			ExpectedFilesScanned: 4,
+		})
+		assert.Greater(t, result.Matches[0].Score, 0, "Score should be positive")
+	})
+
+	t.Run("BaseName_ShouldOutrankDirectoryMatch", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "user.go"})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectedPaths:        []string{"models/user.go", "handlers/user_controller.go", "handlers/user_controller_test.go"},
+			ExpectedMatchCount:   3,
+			ExpectedFilesScanned: 4,
+		})
+	})
+
+	t.Run("CamelCase_ShouldMatchWordStarts", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "UG"})
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Matches, "Should match")
+		assert.Equal(t, "docs/UserGuide.md", result.Matches[0].Path, "Word starts should rank first")
+	})
+
+	t.Run("MaxResults_ShouldTruncate", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "usrctl", "max_results": 1})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectedPaths:        []string{"handlers/user_controller.go"},
+			ExpectedMatchCount:   2,
+			ExpectedFilesScanned: 4,
+			ExpectTruncated:      true,
+		})
+	})
+
+	t.Run("Exclude_ShouldSkipMatchingFiles", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "usrctl", "exclude": []any{"*_test.go"}})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectedPaths:        []string{"handlers/user_controller.go"},
+			ExpectedMatchCount:   1,
+			ExpectedFilesScanned: 3,
+		})
+	})
+
+	t.Run("NoMatch_ShouldReturnEmpty", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "zzq"})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectedFilesScanned: 4,
+		})
+	})
+
+	t.Run("EmptyQuery_ShouldError", func(t *testing.T) {
+		tf, dir := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": dir, "query": "  "})
+		requireFuzzyFindFilesResult(t, result, err, fuzzyFindFilesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "query must not be empty",
+		})
+	})
+}
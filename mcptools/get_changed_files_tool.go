@@ -0,0 +1,62 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*GetChangedFilesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&GetChangedFilesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "get_changed_files",
+			Description: "List the files created, updated, or deleted by this session, grouped by operation, so a client can refresh only what changed",
+			QuickHelp:   "List files changed during this session",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+			},
+		}),
+	})
+}
+
+// GetChangedFilesTool reports the files the current session has created, updated, or deleted.
+type GetChangedFilesTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the get_changed_files tool request and returns the session's changed files.
+func (t *GetChangedFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var changed mcputil.ChangedFiles
+
+	logger.Info("Tool called", "tool", "get_changed_files")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "get_changed_files")
+
+	changed = mcputil.GetChangedFiles(token)
+
+	logger.Info("Tool completed", "tool", "get_changed_files", "count", changed.Count())
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"created": changed.Created,
+		"updated": changed.Updated,
+		"deleted": changed.Deleted,
+		"count":   changed.Count(),
+		"summary": fmt.Sprintf("%d created, %d updated, %d deleted",
+			len(changed.Created),
+			len(changed.Updated),
+			len(changed.Deleted),
+		),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,85 @@
+package mcptools_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const GetChangedFilesDirPrefix = "get-changed-files-tool-test"
+
+// Get changed files tool result type
+type GetChangedFilesResult struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+	Count   int      `json:"count"`
+	Summary string   `json:"summary"`
+}
+
+func TestGetChangedFilesTool(t *testing.T) {
+	// Get the tools
+	tool := mcputil.GetRegisteredTool("get_changed_files")
+	require.NotNil(t, tool, "get_changed_files tool should be registered")
+	createTool := mcputil.GetRegisteredTool("create_file")
+	require.NotNil(t, createTool, "create_file tool should be registered")
+	updateTool := mcputil.GetRegisteredTool("update_file")
+	require.NotNil(t, updateTool, "update_file tool should be registered")
+	deleteTool := mcputil.GetRegisteredTool("delete_files")
+	require.NotNil(t, deleteTool, "delete_files tool should be registered")
+
+	tf := fsfix.NewRootFixture(GetChangedFilesDirPrefix)
+	defer tf.Cleanup()
+
+	existing := tf.AddFileFixture("existing.txt", &fsfix.FileFixtureArgs{Content: "before\n"})
+	obsolete := tf.AddFileFixture("obsolete.txt", &fsfix.FileFixtureArgs{Content: "old\n"})
+
+	tf.Setup(t)
+	config := mcputil.NewMockConfig(mcputil.MockConfigArgs{
+		AllowedPaths: []string{tf.TempDir()},
+	})
+	tool.SetConfig(config)
+	createTool.SetConfig(config)
+	updateTool.SetConfig(config)
+	deleteTool.SetConfig(config)
+
+	// Start from a clean slate since other tests share the same token
+	mcputil.ClearChangedFiles(testToken)
+	defer mcputil.ClearChangedFiles(testToken)
+
+	newFile := filepath.Join(tf.TempDir(), "new.txt")
+	_, err := mcputil.CallTool(createTool, mcputil.NewMockRequest(mcputil.Params{
+		"session_token": testToken,
+		"filepath":      newFile,
+		"new_content":   "new\n",
+	}))
+	require.NoError(t, err, "Should create file")
+
+	_, err = mcputil.CallTool(updateTool, mcputil.NewMockRequest(mcputil.Params{
+		"session_token": testToken,
+		"filepath":      existing.Filepath,
+		"new_content":   "after\n",
+	}))
+	require.NoError(t, err, "Should update file")
+
+	_, err = mcputil.CallTool(deleteTool, mcputil.NewMockRequest(mcputil.Params{
+		"session_token": testToken,
+		"path":          obsolete.Filepath,
+	}))
+	require.NoError(t, err, "Should delete file")
+
+	req := mcputil.NewMockRequest(mcputil.Params{
+		"session_token": testToken,
+	})
+
+	result, err := mcputil.GetToolResult[GetChangedFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error getting changed files")
+	require.NoError(t, err, "Should not have error")
+	assert.Equal(t, []string{newFile}, result.Created, "Created files should match")
+	assert.Equal(t, []string{existing.Filepath}, result.Updated, "Updated files should match")
+	assert.Equal(t, []string{obsolete.Filepath}, result.Deleted, "Deleted files should match")
+	assert.Equal(t, 3, result.Count, "Count should match")
+}
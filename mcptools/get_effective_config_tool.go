@@ -0,0 +1,132 @@
+package mcptools
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*GetEffectiveConfigTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&GetEffectiveConfigTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "get_effective_config",
+			Description: "Get the fully resolved configuration Scout MCP is operating under: allowed paths, origins, file lock mode, safe mode and its confirmable operations, secret rules, file size limit and log redaction, after defaults, the config file and command-line paths are merged. Each value notes its source: 'default', 'file' or 'command_line'",
+			QuickHelp:   "Show the resolved config and where each value came from",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+			},
+		}),
+	})
+}
+
+// GetEffectiveConfigTool reports the resolved server configuration and the
+// source of each setting.
+type GetEffectiveConfigTool struct {
+	*mcputil.ToolBase
+}
+
+// EffectiveSetting is the resolved value of a config setting and where it
+// came from.
+type EffectiveSetting struct {
+	Value  any                  `json:"value"`
+	Source mcputil.ConfigSource `json:"source"`
+}
+
+// EffectivePath is an allowed path and where it came from.
+type EffectivePath struct {
+	Path   string               `json:"path"`
+	Source mcputil.ConfigSource `json:"source"`
+}
+
+// Handle processes the get_effective_config tool request and returns the
+// resolved configuration.
+func (t *GetEffectiveConfigTool) Handle(_ context.Context, _ mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var cfg mcputil.Config
+	var paths []EffectivePath
+	var settings map[string]EffectiveSetting
+
+	logger.Info("Tool called", "tool", "get_effective_config")
+
+	cfg = t.Config()
+	paths = effectivePaths(cfg)
+	settings = effectiveSettings(cfg)
+
+	logger.Info("Tool completed", "tool", "get_effective_config",
+		"path_count", len(paths),
+		"setting_count", len(settings))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"config_file":   cfg.Path(),
+		"server_name":   cfg.ServerName(),
+		"allowed_paths": paths,
+		"settings":      settings,
+	})
+
+	return result, err
+}
+
+// effectivePaths returns the allowed paths of cfg with the source of each.
+func effectivePaths(cfg mcputil.Config) (paths []EffectivePath) {
+	var sourced mcputil.SourcedConfig
+	var ok bool
+	var source mcputil.ConfigSource
+
+	sourced, ok = cfg.(mcputil.SourcedConfig)
+	paths = make([]EffectivePath, 0, len(cfg.AllowedPaths()))
+	for _, path := range cfg.AllowedPaths() {
+		source = mcputil.DefaultConfigSource
+		if ok {
+			source = sourced.PathSource(path)
+		}
+		paths = append(paths, EffectivePath{
+			Path:   path,
+			Source: source,
+		})
+	}
+	slices.SortFunc(paths, func(a, b EffectivePath) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return paths
+}
+
+// effectiveSettings returns the value in effect for each config file setting
+// other than allowed_paths, keyed by the setting's name in the config file.
+// Values come from the running server rather than cfg so that they include
+// the defaults applied at startup.
+func effectiveSettings(cfg mcputil.Config) map[string]EffectiveSetting {
+	var ops []mcputil.ConfirmableOperation
+
+	ops = mcputil.SafeModeOperations()
+	return map[string]EffectiveSetting{
+		"port":                    {Value: cfg.ServerPort(), Source: settingSource(cfg, "port")},
+		"allowed_origins":         {Value: cfg.AllowedOrigins(), Source: settingSource(cfg, "allowed_origins")},
+		"file_lock_mode":          {Value: mcputil.CurrentFileLockMode(), Source: settingSource(cfg, "file_lock_mode")},
+		"safe_mode":               {Value: len(ops) > 0, Source: settingSource(cfg, "safe_mode")},
+		"confirmable_operations":  {Value: ops, Source: settingSource(cfg, "confirmable_operations")},
+		"secret_rules":            {Value: currentSecretRules(), Source: settingSource(cfg, "secret_rules")},
+		"max_file_size":           {Value: MaxFileSize(), Source: settingSource(cfg, "max_file_size")},
+		"edit_backup_count":       {Value: mcputil.EditBackupCount(), Source: settingSource(cfg, "edit_backup_count")},
+		"session_ttl_minutes":     {Value: int(mcputil.SessionTTL() / time.Minute), Source: settingSource(cfg, "session_ttl_minutes")},
+		"max_session_ttl_minutes": {Value: int(mcputil.MaxSessionTTL() / time.Minute), Source: settingSource(cfg, "max_session_ttl_minutes")},
+		"disable_log_redaction":   {Value: !logRedactionEnabled(), Source: settingSource(cfg, "disable_log_redaction")},
+	}
+}
+
+// settingSource returns where the setting key of cfg came from, or
+// DefaultConfigSource when cfg cannot tell.
+func settingSource(cfg mcputil.Config, key string) (source mcputil.ConfigSource) {
+	var sourced mcputil.SourcedConfig
+	var ok bool
+
+	source = mcputil.DefaultConfigSource
+	sourced, ok = cfg.(mcputil.SourcedConfig)
+	if ok {
+		source = sourced.Source(key)
+	}
+	return source
+}
@@ -0,0 +1,110 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const GetEffectiveConfigDirPrefix = "get-effective-config-tool-test"
+
+// Get effective config tool result type
+type EffectiveConfigResult struct {
+	ConfigFile   string                               `json:"config_file"`
+	ServerName   string                               `json:"server_name"`
+	AllowedPaths []mcptools.EffectivePath             `json:"allowed_paths"`
+	Settings     map[string]mcptools.EffectiveSetting `json:"settings"`
+}
+
+type effectiveConfigResultOpts struct {
+	ExpectedPaths    []mcptools.EffectivePath
+	ExpectedSettings map[string]mcptools.EffectiveSetting
+}
+
+func requireEffectiveConfigResult(t *testing.T, result *EffectiveConfigResult, err error, opts effectiveConfigResultOpts) {
+	t.Helper()
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectedPaths, result.AllowedPaths, "Allowed paths should match")
+	for key, expected := range opts.ExpectedSettings {
+		require.Contains(t, result.Settings, key, "Setting %s should be reported", key)
+		assert.Equal(t, expected, result.Settings[key], "Setting %s should match", key)
+	}
+}
+
+func TestGetEffectiveConfigTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("get_effective_config")
+	require.NotNil(t, tool, "get_effective_config tool should be registered")
+
+	call := func() (*EffectiveConfigResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+		})
+		return mcputil.GetToolResult[EffectiveConfigResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call get_effective_config")
+	}
+
+	t.Run("Defaults_ShouldReportDefaultSource", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(GetEffectiveConfigDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		result, err := call()
+		requireEffectiveConfigResult(t, result, err, effectiveConfigResultOpts{
+			ExpectedPaths: []mcptools.EffectivePath{
+				{Path: tf.TempDir(), Source: mcputil.DefaultConfigSource},
+			},
+			ExpectedSettings: map[string]mcptools.EffectiveSetting{
+				"safe_mode":               {Value: false, Source: mcputil.DefaultConfigSource},
+				"file_lock_mode":          {Value: "warn", Source: mcputil.DefaultConfigSource},
+				"max_file_size":           {Value: float64(mcptools.DefaultMaxFileSize), Source: mcputil.DefaultConfigSource},
+				"edit_backup_count":       {Value: float64(mcputil.DefaultEditBackupCount), Source: mcputil.DefaultConfigSource},
+				"session_ttl_minutes":     {Value: float64(mcputil.DefaultSessionTTL / time.Minute), Source: mcputil.DefaultConfigSource},
+				"max_session_ttl_minutes": {Value: float64(mcputil.DefaultMaxSessionTTL / time.Minute), Source: mcputil.DefaultConfigSource},
+				"disable_log_redaction":   {Value: false, Source: mcputil.DefaultConfigSource},
+			},
+		})
+	})
+
+	t.Run("ConfiguredValues_ShouldReportTheirSource", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(GetEffectiveConfigDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+			Sources: map[string]mcputil.ConfigSource{
+				"allowed_paths":          mcputil.CommandLineConfigSource,
+				"safe_mode":              mcputil.FileConfigSource,
+				"confirmable_operations": mcputil.FileConfigSource,
+			},
+		}))
+
+		err := mcputil.SetSafeMode(true, []mcputil.ConfirmableOperation{mcputil.DeleteOperation})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, mcputil.SetSafeMode(false, nil))
+		}()
+
+		result, err := call()
+		requireEffectiveConfigResult(t, result, err, effectiveConfigResultOpts{
+			ExpectedPaths: []mcptools.EffectivePath{
+				{Path: tf.TempDir(), Source: mcputil.CommandLineConfigSource},
+			},
+			ExpectedSettings: map[string]mcptools.EffectiveSetting{
+				"safe_mode":              {Value: true, Source: mcputil.FileConfigSource},
+				"confirmable_operations": {Value: []any{"delete"}, Source: mcputil.FileConfigSource},
+			},
+		})
+	})
+}
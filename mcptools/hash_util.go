@@ -0,0 +1,34 @@
+package mcptools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// hashFile returns the hex-encoded SHA-256 digest of the file at fp.
+func hashFile(fp string) (hash string, err error) {
+	var f *os.File
+
+	f, err = os.Open(fp)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(f)
+
+	hash, err = hashReader(f)
+
+end:
+	return hash, err
+}
+
+// hashReader returns the hex-encoded SHA-256 digest of everything read from r.
+func hashReader(r io.Reader) (hash string, err error) {
+	h := sha256.New()
+	_, err = io.Copy(h, r)
+	if err == nil {
+		hash = hex.EncodeToString(h.Sum(nil))
+	}
+	return hash, err
+}
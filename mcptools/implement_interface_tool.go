@@ -0,0 +1,170 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ImplementInterfaceTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ImplementInterfaceTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "implement_interface",
+			Description: "Add stub methods with panic(\"not implemented\") bodies for each method of a Go interface that a type lacks, placed after the type's existing methods. The type must be declared in the file and the interface in the same package; the other files of the package are searched for the interface and for methods the type already has. A method the type already has with a different signature is an error. Any imports the stubs need are added, and the result is gofmt-formatted and validated",
+			QuickHelp:   "Add stubs for the interface methods a Go type lacks",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file declaring the type"),
+				TypeNameProperty.Required(),
+				InterfaceNameProperty.Required(),
+				RequiredLanguageProperty.Description("Programming language of the file; only 'go' is supported"),
+			},
+		}),
+	})
+}
+
+// ImplementInterfaceTool adds stubs for the methods of an interface that a
+// Go type does not yet have.
+type ImplementInterfaceTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the implement_interface tool request and adds the missing
+// method stubs.
+func (t *ImplementInterfaceTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var typeName string
+	var interfaceName string
+	var language string
+	var content string
+	var pkgFiles map[string][]byte
+	var implemented []byte
+	var added []string
+	var message string
+
+	logger.Info("Tool called", "tool", "implement_interface")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	typeName, err = TypeNameProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	interfaceName, err = InterfaceNameProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "implement_interface",
+		"path", path,
+		"type_name", typeName,
+		"interface_name", interfaceName,
+		"language", language)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	pkgFiles, err = packageSiblingFiles(path)
+	if err != nil {
+		goto end
+	}
+
+	implemented, added, err = golang.ImplementInterface(path, []byte(content), typeName, interfaceName, pkgFiles)
+	if err != nil {
+		err = fmt.Errorf("cannot implement '%s' on '%s' in %s: %w", interfaceName, typeName, path, err)
+		goto end
+	}
+
+	message = fmt.Sprintf("%s already has every method of %s", typeName, interfaceName)
+	if len(added) > 0 {
+		err = WriteFile(ctx, t.Config(), path, string(implemented))
+		if err != nil {
+			goto end
+		}
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+		message = fmt.Sprintf("Added %d method stub(s) to %s in %s: %s", len(added), typeName, path, strings.Join(added, ", "))
+	}
+
+	logger.Info("Tool completed", "tool", "implement_interface", "path", path, "methods_added", len(added))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":        true,
+		"path":           path,
+		"type_name":      typeName,
+		"interface_name": interfaceName,
+		"methods_added":  added,
+		"message":        message,
+	})
+
+end:
+	return result, err
+}
+
+// packageSiblingFiles returns the contents of the other Go files in the
+// directory of path, keyed by their paths. Test files are only included when
+// path is itself a test file, since their methods do not exist otherwise.
+func packageSiblingFiles(path string) (files map[string][]byte, err error) {
+	var entries []os.DirEntry
+	var fp string
+	var content []byte
+	var testFile bool
+
+	entries, err = os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		goto end
+	}
+
+	testFile = strings.HasSuffix(path, "_test.go")
+	files = make(map[string][]byte)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		if !testFile && strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		fp = filepath.Join(filepath.Dir(path), entry.Name())
+		if fp == filepath.Clean(path) {
+			continue
+		}
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		files[fp] = content
+	}
+
+end:
+	return files, err
+}
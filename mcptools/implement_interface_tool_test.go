@@ -0,0 +1,285 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ImplementInterfaceDirPrefix = "implement-interface-tool-test"
+
+// Implement interface tool result type
+type ImplementInterfaceResult struct {
+	Success       bool     `json:"success"`
+	Path          string   `json:"path"`
+	TypeName      string   `json:"type_name"`
+	InterfaceName string   `json:"interface_name"`
+	MethodsAdded  []string `json:"methods_added"`
+	Message       string   `json:"message"`
+}
+
+type implementInterfaceResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedMethods  []string
+	ExpectedContent  string
+}
+
+func requireImplementInterfaceResult(t *testing.T, result *ImplementInterfaceResult, err error, path string, opts implementInterfaceResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedMethods, result.MethodsAdded, "Methods added should match")
+	requireFileContent(t, path, opts.ExpectedContent)
+}
+
+func TestImplementInterfaceTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("implement_interface")
+	require.NotNil(t, tool, "implement_interface tool should be registered")
+
+	const shapes = `package shapes
+
+import (
+	"fmt"
+)
+
+// Circle is a round shape.
+type Circle struct {
+	Radius float64
+	Label  string
+}
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+// Stack holds items.
+type Stack[T any] struct {
+	items []T
+}
+
+// Named has a field that clashes with an interface method.
+type Named struct {
+	Name string
+}
+
+func describe(c *Circle) string {
+	return fmt.Sprint(c.Radius)
+}
+`
+
+	const interfaces = `package shapes
+
+import (
+	"context"
+	"io"
+)
+
+// Shape is implemented by all shapes.
+type Shape interface {
+	Area() float64
+	Render(ctx context.Context, w io.Writer) (n int, err error)
+}
+
+// Closer closes things and reports failures.
+type Closer interface {
+	error
+	Close(s string) error
+}
+
+// Sizer reports sizes.
+type Sizer interface {
+	Area() int
+}
+
+// Namer reports names.
+type Namer interface {
+	Name() string
+}
+
+// External embeds a foreign interface.
+type External interface {
+	io.Reader
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(ImplementInterfaceDirPrefix)
+		pf := tf.AddRepoFixture("implement-project", nil)
+		ff := pf.AddFileFixture("shapes.go", &fsfix.FileFixtureArgs{Content: shapes})
+		pf.AddFileFixture("interfaces.go", &fsfix.FileFixtureArgs{Content: interfaces})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(path, typeName, interfaceName, language string) (*ImplementInterfaceResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           path,
+			"type_name":      typeName,
+			"interface_name": interfaceName,
+			"language":       language,
+		})
+		return mcputil.GetToolResult[ImplementInterfaceResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call implement_interface")
+	}
+
+	t.Run("MissingMethods_ShouldAddStubsAndImports", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "Circle", "Shape", "go")
+		requireImplementInterfaceResult(t, result, err, fp, implementInterfaceResultOpts{
+			ExpectedMethods: []string{"Render"},
+			ExpectedContent: `package shapes
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Circle is a round shape.
+type Circle struct {
+	Radius float64
+	Label  string
+}
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+// Render implements Shape.
+func (c *Circle) Render(ctx context.Context, w io.Writer) (n int, err error) {
+	panic("not implemented")
+}
+
+// Stack holds items.
+type Stack[T any] struct {
+	items []T
+}
+
+// Named has a field that clashes with an interface method.
+type Named struct {
+	Name string
+}
+
+func describe(c *Circle) string {
+	return fmt.Sprint(c.Radius)
+}
+`,
+		})
+	})
+
+	t.Run("GenericTypeWithEmbeddedError_ShouldAddStubsAfterType", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "Stack", "Closer", "go")
+		requireImplementInterfaceResult(t, result, err, fp, implementInterfaceResultOpts{
+			ExpectedMethods: []string{"Error", "Close"},
+			ExpectedContent: `package shapes
+
+import (
+	"fmt"
+)
+
+// Circle is a round shape.
+type Circle struct {
+	Radius float64
+	Label  string
+}
+
+// Area returns the area of the circle.
+func (c *Circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+// Stack holds items.
+type Stack[T any] struct {
+	items []T
+}
+
+// Error implements Closer.
+func (s *Stack[T]) Error() string {
+	panic("not implemented")
+}
+
+// Close implements Closer.
+func (s *Stack[T]) Close(_ string) error {
+	panic("not implemented")
+}
+
+// Named has a field that clashes with an interface method.
+type Named struct {
+	Name string
+}
+
+func describe(c *Circle) string {
+	return fmt.Sprint(c.Radius)
+}
+`,
+		})
+	})
+
+	t.Run("AlreadyImplemented_ShouldLeaveFileUnchanged", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "Circle", "Shape", "go")
+		require.NoError(t, err)
+		require.Equal(t, []string{"Render"}, result.MethodsAdded)
+
+		result, err = call(fp, "Circle", "Shape", "go")
+		require.NoError(t, err)
+		assert.Empty(t, result.MethodsAdded, "No methods should be added the second time")
+		assert.Contains(t, result.Message, "already has every method")
+	})
+
+	errorCases := []struct {
+		name          string
+		typeName      string
+		interfaceName string
+		language      string
+		errorMsg      string
+	}{
+		{"DifferentSignature_ShouldError", "Circle", "Sizer", "go", "already has a method Area() float64, but 'Sizer' requires Area() int"},
+		{"FieldClash_ShouldError", "Named", "Namer", "go", "has a field named Name"},
+		{"ForeignEmbed_ShouldError", "Circle", "External", "go", "embeds io.Reader"},
+		{"UnknownInterface_ShouldError", "Circle", "Missing", "go", "interface 'Missing' is not declared"},
+		{"NotInterface_ShouldError", "Circle", "Named", "go", "'Named' is not an interface type"},
+		{"TypeNotInFile_ShouldError", "Unknown", "Shape", "go", "type 'Unknown' is not declared"},
+		{"UnsupportedLanguage_ShouldError", "Circle", "Shape", "python", "unsupported language"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tf, fp := setup(t)
+			defer tf.Cleanup()
+
+			result, err := call(fp, tc.typeName, tc.interfaceName, tc.language)
+			requireImplementInterfaceResult(t, result, err, fp, implementInterfaceResultOpts{
+				ExpectError:      true,
+				ExpectedErrorMsg: tc.errorMsg,
+			})
+			requireFileContent(t, fp, shapes)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*InlineSymbolTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&InlineSymbolTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "inline_symbol",
+			Description: "Replace each reference in a Go file to a package-level constant or variable with its literal value and remove its declaration. The initializer must be a simple literal such as 42, -1, \"text\" or true, variables must never be assigned or have their address taken, and exported names are refused unless force is set because other files may use them. The result is gofmt-formatted and validated",
+			QuickHelp:   "Inline a Go constant or variable's literal value",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file declaring the symbol"),
+				RequiredLanguageProperty.Description("Programming language of the file; only 'go' is supported"),
+				SymbolNameProperty.Required().Description("Name of the package-level constant or variable to inline"),
+				ForceProperty.Description("Inline an exported symbol even though other files may use it"),
+			},
+		}),
+	})
+}
+
+// InlineSymbolTool replaces the references to a Go constant or variable
+// with its value and removes its declaration.
+type InlineSymbolTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the inline_symbol tool request and inlines the symbol.
+func (t *InlineSymbolTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var name string
+	var force bool
+	var content string
+	var inlined []byte
+	var count int
+
+	logger.Info("Tool called", "tool", "inline_symbol")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	name, err = SymbolNameProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	force, err = ForceProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "inline_symbol",
+		"path", path,
+		"language", language,
+		"name", name,
+		"force", force)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	inlined, count, err = golang.InlineSymbol(path, []byte(content), name, force)
+	if err != nil {
+		err = fmt.Errorf("cannot inline '%s' in %s: %w", name, path, err)
+		goto end
+	}
+
+	err = WriteFile(ctx, t.Config(), path, string(inlined))
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+
+	logger.Info("Tool completed", "tool", "inline_symbol", "path", path, "name", name, "replacements", count)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":      true,
+		"path":         path,
+		"name":         name,
+		"replacements": count,
+		"message":      fmt.Sprintf("Inlined %d reference(s) to %s and removed its declaration from %s", count, name, path),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,269 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const InlineSymbolDirPrefix = "inline-symbol-tool-test"
+
+// Inline symbol tool result type
+type InlineSymbolResult struct {
+	Success      bool   `json:"success"`
+	Path         string `json:"path"`
+	Name         string `json:"name"`
+	Replacements int    `json:"replacements"`
+	Message      string `json:"message"`
+}
+
+type inlineSymbolResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedReplacements int
+	ExpectedContent      string
+}
+
+func requireInlineSymbolResult(t *testing.T, result *InlineSymbolResult, err error, path string, opts inlineSymbolResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedReplacements, result.Replacements, "Replacements should match")
+	requireFileContent(t, path, opts.ExpectedContent)
+}
+
+func TestInlineSymbolTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("inline_symbol")
+	require.NotNil(t, tool, "inline_symbol tool should be registered")
+
+	const source = `package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// greeting is printed first.
+const greeting = "hello"
+
+const (
+	retries               = 3
+	offset                = -1 // applied to counts
+	delay   time.Duration = 5
+)
+
+var verbose = true
+
+var counter = 0
+
+var Version = "1.0"
+
+var started = time.Now()
+
+const (
+	first = iota
+	second
+)
+
+func main() {
+	fmt.Println(greeting, retries-offset, delay)
+	if verbose {
+		fmt.Println(Version, first, second)
+	}
+	counter++
+}
+`
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(InlineSymbolDirPrefix)
+		pf := tf.AddRepoFixture("inline-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: source})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(path, name string, force bool) (*InlineSymbolResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"language":      "go",
+			"name":          name,
+			"force":         force,
+		})
+		return mcputil.GetToolResult[InlineSymbolResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call inline_symbol")
+	}
+
+	read := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "Should read %s", path)
+		return string(content)
+	}
+
+	t.Run("StandaloneConst_ShouldInlineAndRemoveDeclaration", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "greeting", false)
+		requireInlineSymbolResult(t, result, err, fp, inlineSymbolResultOpts{
+			ExpectedReplacements: 1,
+			ExpectedContent: `package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	retries               = 3
+	offset                = -1 // applied to counts
+	delay   time.Duration = 5
+)
+
+var verbose = true
+
+var counter = 0
+
+var Version = "1.0"
+
+var started = time.Now()
+
+const (
+	first = iota
+	second
+)
+
+func main() {
+	fmt.Println("hello", retries-offset, delay)
+	if verbose {
+		fmt.Println(Version, first, second)
+	}
+	counter++
+}
+`,
+		})
+	})
+
+	t.Run("SignedConstInGroup_ShouldParenthesize", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "offset", false)
+		requireInlineSymbolResult(t, result, err, fp, inlineSymbolResultOpts{
+			ExpectedReplacements: 1,
+			ExpectedContent: `package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// greeting is printed first.
+const greeting = "hello"
+
+const (
+	retries               = 3
+	delay   time.Duration = 5
+)
+
+var verbose = true
+
+var counter = 0
+
+var Version = "1.0"
+
+var started = time.Now()
+
+const (
+	first = iota
+	second
+)
+
+func main() {
+	fmt.Println(greeting, retries-(-1), delay)
+	if verbose {
+		fmt.Println(Version, first, second)
+	}
+	counter++
+}
+`,
+		})
+	})
+
+	t.Run("TypedConst_ShouldConvert", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "delay", false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Replacements)
+		content := read(t, fp)
+		assert.Contains(t, content, "fmt.Println(greeting, retries-offset, time.Duration(5))")
+		assert.NotContains(t, content, "delay")
+	})
+
+	t.Run("UnmodifiedVar_ShouldInline", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "verbose", false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Replacements)
+		content := read(t, fp)
+		assert.Contains(t, content, "\tif true {\n")
+		assert.NotContains(t, content, "verbose")
+	})
+
+	t.Run("ExportedWithForce_ShouldInline", func(t *testing.T) {
+		tf, fp := setup(t)
+		defer tf.Cleanup()
+
+		result, err := call(fp, "Version", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Replacements)
+		assert.Contains(t, read(t, fp), `fmt.Println("1.0", first, second)`)
+	})
+
+	errorCases := []struct {
+		name     string
+		symbol   string
+		errorMsg string
+	}{
+		{"Exported_ShouldError", "Version", "is exported"},
+		{"ModifiedVar_ShouldError", "counter", "is assigned or has its address taken"},
+		{"NotLiteral_ShouldError", "started", "not a simple literal"},
+		{"RepeatedInitializer_ShouldError", "first", "repeat its initializer"},
+		{"ImplicitRepeat_ShouldError", "second", "has no initializer of its own"},
+		{"Function_ShouldError", "main", "not a package-level constant or variable"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tf, fp := setup(t)
+			defer tf.Cleanup()
+
+			result, err := call(fp, tc.symbol, false)
+			requireInlineSymbolResult(t, result, err, fp, inlineSymbolResultOpts{
+				ExpectError:      true,
+				ExpectedErrorMsg: tc.errorMsg,
+			})
+			requireFileContent(t, fp, source)
+		})
+	}
+}
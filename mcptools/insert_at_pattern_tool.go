@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/mikeschinkel/scout-mcp/mcputil"
@@ -20,6 +21,7 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "insert_at_pattern",
 			Description: "Insert content before or after a code pattern match",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				PathProperty.Required(),
@@ -28,6 +30,7 @@ func init() {
 				AfterPatternProperty,
 				PositionProperty.Description("Position relative to pattern (before/after, default: before)"),
 				RegexProperty,
+				OccurrenceProperty,
 			},
 		}),
 	})
@@ -39,13 +42,16 @@ type InsertAtPatternTool struct {
 }
 
 // Handle processes the insert_at_pattern tool request and inserts content relative to a pattern.
-func (t *InsertAtPatternTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *InsertAtPatternTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var beforePattern string
 	var afterPattern string
 	var content string
 	var position string
 	var useRegex bool
+	var occurrence int
+	var insertions int
+	var message string
 
 	logger.Info("Tool called", "tool", "insert_at_pattern")
 
@@ -75,19 +81,32 @@ func (t *InsertAtPatternTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
-	err = t.insertAtPattern(filePath, beforePattern, afterPattern, content, position, useRegex)
+	occurrence, err = getOccurrence(req)
 	if err != nil {
 		goto end
 	}
 
+	insertions, err = t.insertAtPattern(ctx, filePath, beforePattern, afterPattern, content, position, useRegex, occurrence)
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
+	message = fmt.Sprintf("Successfully inserted content at pattern in %s", filePath)
+	if insertions > 1 {
+		message = fmt.Sprintf("Successfully inserted content at %d pattern matches in %s", insertions, filePath)
+	}
+
 	result = mcputil.NewToolResultJSON(map[string]any{
-		"success":   true,
-		"file_path": filePath,
-		"pattern":   getPatternForResult(beforePattern, afterPattern),
-		"position":  position,
-		"message":   fmt.Sprintf("Successfully inserted content at pattern in %s", filePath),
+		"success":    true,
+		"file_path":  filePath,
+		"pattern":    getPatternForResult(beforePattern, afterPattern),
+		"position":   position,
+		"insertions": insertions,
+		"message":    message,
 	})
-	logger.Info("Tool completed", "tool", "insert_at_pattern", "path", filePath)
+	logger.Info("Tool completed", "tool", "insert_at_pattern", "path", filePath, "insertions", insertions)
 
 end:
 	return result, err
@@ -112,7 +131,41 @@ func (t *InsertAtPatternTool) validatePosition(position string) (err error) {
 	return RelativePosition(position).Validate()
 }
 
-func (t *InsertAtPatternTool) insertAtPattern(filePath, beforePattern, afterPattern, content, position string, useRegex bool) (err error) {
+// getOccurrence returns the occurrence parameter as a 1-based match number,
+// or 0 for "all". It accepts the number as a JSON number or a string.
+func getOccurrence(req mcputil.ToolRequest) (occurrence int, err error) {
+	var raw any
+	var value string
+
+	raw = req.CallToolRequest().GetArguments()[OccurrenceProperty.GetName()]
+	switch v := raw.(type) {
+	case nil:
+		occurrence = 1
+		goto end
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		value = strconv.Itoa(v)
+	case string:
+		value = v
+	default:
+		err = fmt.Errorf("occurrence must be a number or 'all', got %T", raw)
+		goto end
+	}
+
+	if value == "all" {
+		goto end
+	}
+	occurrence, err = strconv.Atoi(value)
+	if err != nil || occurrence < 1 {
+		err = fmt.Errorf("occurrence must be a number >= 1 or 'all', got %q", value)
+	}
+
+end:
+	return occurrence, err
+}
+
+func (t *InsertAtPatternTool) insertAtPattern(ctx context.Context, filePath, beforePattern, afterPattern, content, position string, useRegex bool, occurrence int) (insertions int, err error) {
 	var originalContent string
 	var updatedContent string
 	var pattern string
@@ -133,41 +186,57 @@ func (t *InsertAtPatternTool) insertAtPattern(filePath, beforePattern, afterPatt
 		pattern = afterPattern
 	}
 
-	updatedContent, err = t.insertContentAtPattern(originalContent, pattern, content, position, useRegex)
+	updatedContent, insertions, err = t.insertContentAtPattern(originalContent, pattern, content, position, useRegex, occurrence)
 	if err != nil {
 		goto end
 	}
 
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
 
 end:
-	return err
+	return insertions, err
 }
 
-func (t *InsertAtPatternTool) insertContentAtPattern(originalContent, pattern, content, position string, useRegex bool) (result string, err error) {
+// insertContentAtPattern inserts content relative to the occurrence-th line
+// matching pattern, or to every matching line when occurrence is 0.
+func (t *InsertAtPatternTool) insertContentAtPattern(originalContent, pattern, content, position string, useRegex bool, occurrence int) (result string, insertions int, err error) {
 	var lines []string
-	var matchLine int
-	var found bool
+	var matchLines []int
 
 	lines = strings.Split(originalContent, "\n")
 
-	matchLine, found, err = t.findPatternLine(lines, pattern, useRegex)
+	matchLines, err = t.findPatternLines(lines, pattern, useRegex)
 	if err != nil {
 		goto end
 	}
 
-	if !found {
+	if len(matchLines) == 0 {
 		err = fmt.Errorf("pattern not found: %s", pattern)
 		goto end
 	}
 
-	result = t.insertAtLineNumber(lines, matchLine, content, position)
+	if occurrence > len(matchLines) {
+		err = fmt.Errorf("occurrence %d of pattern %s not found; it matches %d lines", occurrence, pattern, len(matchLines))
+		goto end
+	}
+
+	if occurrence > 0 {
+		matchLines = matchLines[occurrence-1 : occurrence]
+	}
+
+	// Insert from the last match back so earlier line numbers stay valid
+	for i := len(matchLines) - 1; i >= 0; i-- {
+		lines = t.insertAtLineNumber(lines, matchLines[i], content, position)
+	}
+	result = strings.Join(lines, "\n")
+	insertions = len(matchLines)
 
 end:
-	return result, err
+	return result, insertions, err
 }
 
-func (t *InsertAtPatternTool) findPatternLine(lines []string, pattern string, useRegex bool) (lineNumber int, found bool, err error) {
+// findPatternLines returns the 1-based numbers of the lines matching pattern.
+func (t *InsertAtPatternTool) findPatternLines(lines []string, pattern string, useRegex bool) (lineNumbers []int, err error) {
 	var re *regexp.Regexp
 
 	if useRegex {
@@ -188,20 +257,17 @@ func (t *InsertAtPatternTool) findPatternLine(lines []string, pattern string, us
 		}
 
 		if matches {
-			lineNumber = i + 1 // Convert to 1-based
-			found = true
-			goto end
+			lineNumbers = append(lineNumbers, i+1) // Convert to 1-based
 		}
 	}
 
 end:
-	return lineNumber, found, err
+	return lineNumbers, err
 }
 
-func (t *InsertAtPatternTool) insertAtLineNumber(lines []string, lineNumber int, content, position string) (result string) {
+func (t *InsertAtPatternTool) insertAtLineNumber(lines []string, lineNumber int, content, position string) (result []string) {
 	var insertIdx int
 	var newLines []string
-	var combined []string
 
 	// Convert to 0-based indexing
 	baseIdx := lineNumber - 1
@@ -214,12 +280,10 @@ func (t *InsertAtPatternTool) insertAtLineNumber(lines []string, lineNumber int,
 
 	newLines = strings.Split(content, "\n")
 
-	combined = make([]string, 0, len(lines)+len(newLines))
-	combined = append(combined, lines[:insertIdx]...)
-	combined = append(combined, newLines...)
-	combined = append(combined, lines[insertIdx:]...)
-
-	result = strings.Join(combined, "\n")
+	result = make([]string, 0, len(lines)+len(newLines))
+	result = append(result, lines[:insertIdx]...)
+	result = append(result, newLines...)
+	result = append(result, lines[insertIdx:]...)
 
 	return result
 }
@@ -205,4 +205,111 @@ func TestInsertAtPatternTool(t *testing.T) {
 		require.NoError(t, readErr, "Should be able to read updated file")
 		assert.Contains(t, string(content), "func test()", "First function should still be there")
 	})
+
+	t.Run("Occurrence_ShouldSelectWhichMatchToInsertAt", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(InsertAtPatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("occurrence-project", nil)
+		testFile := pf.AddFileFixture("occurrence.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc one() {}\n\nfunc two() {}\n\nfunc three() {}\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           testFile.Filepath,
+			"before_pattern": "func ",
+			"new_content":    "// Second",
+			"occurrence":     2,
+		})
+
+		result, err := mcputil.GetToolResult[InsertAtPatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error inserting at second match")
+
+		requireInsertAtPatternResult(t, result, err, insertAtPatternResultOpts{
+			ExpectedFilePath:   testFile.Filepath,
+			ExpectedInsertions: 1,
+			ShouldUpdateFile:   true,
+			ExpectedContent:    "package main\n\nfunc one() {}\n\n// Second\nfunc two() {}\n\nfunc three() {}\n",
+		})
+	})
+
+	t.Run("OccurrenceAll_ShouldInsertAtEveryMatch", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(InsertAtPatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("occurrence-all-project", nil)
+		testFile := pf.AddFileFixture("occurrence_all.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc one() {}\n\nfunc two() {}\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           testFile.Filepath,
+			"before_pattern": "func ",
+			"new_content":    "// Comment",
+			"occurrence":     "all",
+		})
+
+		result, err := mcputil.GetToolResult[InsertAtPatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error inserting at every match")
+
+		requireInsertAtPatternResult(t, result, err, insertAtPatternResultOpts{
+			ExpectedFilePath:   testFile.Filepath,
+			ExpectedInsertions: 2,
+			ShouldUpdateFile:   true,
+			ExpectedContent:    "package main\n\n// Comment\nfunc one() {}\n\n// Comment\nfunc two() {}\n",
+		})
+	})
+
+	t.Run("InvalidOccurrence_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(InsertAtPatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("invalid-occurrence-project", nil)
+		testFile := pf.AddFileFixture("occurrence.txt", &fsfix.FileFixtureArgs{
+			Content: "one\ntwo\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		tests := []struct {
+			name             string
+			occurrence       any
+			expectedErrorMsg string
+		}{
+			{name: "BeyondMatches", occurrence: 3, expectedErrorMsg: "occurrence 3 of pattern o not found; it matches 2 lines"},
+			{name: "Zero", occurrence: 0, expectedErrorMsg: "occurrence must be a number >= 1 or 'all'"},
+			{name: "Word", occurrence: "every", expectedErrorMsg: "occurrence must be a number >= 1 or 'all'"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := mcputil.NewMockRequest(mcputil.Params{
+					"session_token": testToken,
+					"path":          testFile.Filepath,
+					"after_pattern": "o",
+					"new_content":   "inserted",
+					"occurrence":    tt.occurrence,
+				})
+
+				result, err := mcputil.GetToolResult[InsertAtPatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the occurrence")
+
+				requireInsertAtPatternResult(t, result, err, insertAtPatternResultOpts{
+					ExpectError:      true,
+					ExpectedErrorMsg: tt.expectedErrorMsg,
+				})
+			})
+		}
+	})
 }
@@ -16,12 +16,15 @@ func init() {
 			Name:        "insert_file_lines",
 			Description: "Insert content at a specific line number in a file",
 			QuickHelp:   "Insert content at specific lines",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				FilepathProperty.Required(),
 				NewContentProperty.Required(),
 				PositionProperty.Description("Position at which to insert").Required(),
 				LineNumberProperty.Description("Line number where to insert content").Required(),
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -33,11 +36,14 @@ type InsertFileLinesTool struct {
 }
 
 // Handle processes the insert_file_lines tool request and inserts content at the specified line.
-func (t *InsertFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *InsertFileLinesTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var lineNumber int
 	var content string
 	var position string
+	var originalContent, updatedContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "insert_file_lines")
 
@@ -66,18 +72,27 @@ func (t *InsertFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
-	err = t.insertAtLine(filePath, lineNumber, content, position)
+	diffOpts, err = getDiffOptions(req)
 	if err != nil {
 		goto end
 	}
 
-	result = mcputil.NewToolResultJSON(map[string]any{
+	originalContent, updatedContent, err = t.insertAtLine(ctx, filePath, lineNumber, content, position)
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
+	fields = map[string]any{
 		"success":     true,
 		"file_path":   filePath,
 		"line_number": lineNumber,
 		"position":    position,
 		"message":     fmt.Sprintf("Successfully inserted content %s line %d in %s", position, lineNumber, filePath),
-	})
+	}
+	diffOpts.addDiff(fields, filePath, originalContent, updatedContent)
+	result = mcputil.NewToolResultJSON(fields)
 	logger.Info("Tool completed", "tool", "insert_file_lines", "path", filePath, "line_number", lineNumber, "position", position)
 
 end:
@@ -88,10 +103,8 @@ func (t *InsertFileLinesTool) validatePosition(position string) (err error) {
 	return RelativePosition(position).Validate()
 }
 
-func (t *InsertFileLinesTool) insertAtLine(filePath string, lineNumber int, content, position string) (err error) {
-	var originalContent string
+func (t *InsertFileLinesTool) insertAtLine(ctx context.Context, filePath string, lineNumber int, content, position string) (originalContent, updatedContent string, err error) {
 	var lines []string
-	var updatedContent string
 
 	if !t.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
@@ -112,10 +125,10 @@ func (t *InsertFileLinesTool) insertAtLine(filePath string, lineNumber int, cont
 
 	updatedContent = t.insertContent(lines, lineNumber, content, position)
 
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
 
 end:
-	return err
+	return originalContent, updatedContent, err
 }
 
 func (t *InsertFileLinesTool) validateLineNumber(lines []string, lineNumber int) (err error) {
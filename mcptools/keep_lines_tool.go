@@ -0,0 +1,156 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*KeepLinesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&KeepLinesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "keep_lines",
+			Description: "Trim a file to a line number range, removing every line before and after it. The complement of delete_file_lines",
+			QuickHelp:   "Reduce a file to a range of its lines",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to trim"),
+				StartLineProperty.Required().Description("First line to keep, inclusive"),
+				EndLineProperty.Required().Description("Last line to keep, inclusive"),
+			},
+		}),
+	})
+}
+
+// KeepLinesTool trims a file down to a line number range.
+type KeepLinesTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the keep_lines tool request and removes every line outside the given range.
+func (t *KeepLinesTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var startLine, endLine int
+	var content string
+	var lines []string
+	var kept string
+	var removedBefore, removedAfter int
+
+	logger.Info("Tool called", "tool", "keep_lines")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	startLine, err = StartLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("start_line must be a valid number: %w", err)
+		goto end
+	}
+
+	endLine, err = EndLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("end_line must be a valid number: %w", err)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "keep_lines",
+		"path", path,
+		"start_line", startLine,
+		"end_line", endLine)
+
+	err = validateKeepRange(startLine, endLine)
+	if err != nil {
+		goto end
+	}
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	lines = strings.Split(content, "\n")
+	if startLine > len(lines) {
+		err = fmt.Errorf("start_line %d exceeds file length %d", startLine, len(lines))
+		goto end
+	}
+	if endLine > len(lines) {
+		err = fmt.Errorf("end_line %d exceeds file length %d", endLine, len(lines))
+		goto end
+	}
+
+	kept = keepLines(lines, startLine, endLine)
+	removedBefore = startLine - 1
+	removedAfter = len(lines) - endLine
+	if strings.HasSuffix(content, "\n") {
+		// The empty string after the final newline is not a line of the file
+		removedAfter = max(removedAfter-1, 0)
+	}
+
+	err = WriteFile(ctx, t.Config(), path, kept)
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+
+	logger.Info("Tool completed", "tool", "keep_lines",
+		"path", path,
+		"start_line", startLine,
+		"end_line", endLine,
+		"lines_removed", removedBefore+removedAfter)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":              true,
+		"path":                 path,
+		"start_line":           startLine,
+		"end_line":             endLine,
+		"lines_kept":           endLine - startLine + 1,
+		"lines_removed_before": removedBefore,
+		"lines_removed_after":  removedAfter,
+		"lines_removed":        removedBefore + removedAfter,
+		"message":              fmt.Sprintf("Kept lines %d-%d of %s", startLine, endLine, path),
+	})
+
+end:
+	return result, err
+}
+
+// validateKeepRange checks that startLine and endLine form a valid 1-based range.
+func validateKeepRange(startLine, endLine int) (err error) {
+	if startLine < 1 {
+		err = fmt.Errorf("start_line must be >= 1, got %d", startLine)
+		goto end
+	}
+
+	if endLine < startLine {
+		err = fmt.Errorf("end_line (%d) must be >= start_line (%d)", endLine, startLine)
+		goto end
+	}
+
+end:
+	return err
+}
+
+// keepLines returns lines startLine through endLine, 1-based and inclusive,
+// joined with newlines. The kept range ends with a newline unless it ends at
+// the file's unterminated last line, so a file ending in a newline still does.
+func keepLines(lines []string, startLine, endLine int) (result string) {
+	result = strings.Join(lines[startLine-1:endLine], "\n")
+	if endLine < len(lines) {
+		result += "\n"
+	}
+	return result
+}
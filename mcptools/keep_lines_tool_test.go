@@ -0,0 +1,155 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const KeepLinesDirPrefix = "keep-lines-tool-test"
+
+// Keep lines tool result type
+type KeepLinesResult struct {
+	Success            bool   `json:"success"`
+	Path               string `json:"path"`
+	StartLine          int    `json:"start_line"`
+	EndLine            int    `json:"end_line"`
+	LinesKept          int    `json:"lines_kept"`
+	LinesRemovedBefore int    `json:"lines_removed_before"`
+	LinesRemovedAfter  int    `json:"lines_removed_after"`
+	LinesRemoved       int    `json:"lines_removed"`
+}
+
+type keepLinesResultOpts struct {
+	ExpectError           bool
+	ExpectedErrorMsg      string
+	ExpectedKept          int
+	ExpectedRemovedBefore int
+	ExpectedRemovedAfter  int
+}
+
+func requireKeepLinesResult(t *testing.T, result *KeepLinesResult, err error, opts keepLinesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Operation should be successful")
+	assert.Equal(t, opts.ExpectedKept, result.LinesKept, "Lines kept should match")
+	assert.Equal(t, opts.ExpectedRemovedBefore, result.LinesRemovedBefore, "Lines removed before should match")
+	assert.Equal(t, opts.ExpectedRemovedAfter, result.LinesRemovedAfter, "Lines removed after should match")
+	assert.Equal(t, opts.ExpectedRemovedBefore+opts.ExpectedRemovedAfter, result.LinesRemoved, "Lines removed should be the sum")
+}
+
+func TestKeepLinesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("keep_lines")
+	require.NotNil(t, tool, "keep_lines tool should be registered")
+
+	setup := func(t *testing.T, content string) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(KeepLinesDirPrefix)
+		ff := tf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	t.Run("MiddleRange_ShouldKeepOnlyThoseLines", func(t *testing.T) {
+		tf, ff := setup(t, "one\ntwo\nthree\nfour\nfive\n")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"start_line":    2,
+			"end_line":      4,
+		})
+
+		result, err := mcputil.GetToolResult[KeepLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error keeping lines")
+		requireKeepLinesResult(t, result, err, keepLinesResultOpts{
+			ExpectedKept:          3,
+			ExpectedRemovedBefore: 1,
+			ExpectedRemovedAfter:  1,
+		})
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read trimmed file")
+		assert.Equal(t, "two\nthree\nfour\n", string(content), "File should hold only the kept lines")
+	})
+
+	t.Run("LastLineWithoutNewline_ShouldStayUnterminated", func(t *testing.T) {
+		tf, ff := setup(t, "one\ntwo\nthree")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"start_line":    2,
+			"end_line":      3,
+		})
+
+		result, err := mcputil.GetToolResult[KeepLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error keeping lines")
+		requireKeepLinesResult(t, result, err, keepLinesResultOpts{
+			ExpectedKept:          2,
+			ExpectedRemovedBefore: 1,
+		})
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read trimmed file")
+		assert.Equal(t, "two\nthree", string(content), "File should not gain a trailing newline")
+	})
+
+	t.Run("EndBeyondFile_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "one\ntwo\n")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"start_line":    1,
+			"end_line":      9,
+		})
+
+		result, err := mcputil.GetToolResult[KeepLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for out of range end_line")
+		requireKeepLinesResult(t, result, err, keepLinesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "end_line 9 exceeds file length",
+		})
+	})
+
+	t.Run("DryRun_ShouldPreviewWithoutWriting", func(t *testing.T) {
+		tf, ff := setup(t, "one\ntwo\nthree\n")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"start_line":    2,
+			"end_line":      2,
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing keep_lines")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, "two\n", result.Files[0].Content, "Preview should hold only the kept line")
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "one\ntwo\nthree\n", string(content), "Dry run should not modify the file")
+	})
+}
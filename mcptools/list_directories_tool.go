@@ -0,0 +1,183 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ListDirectoriesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ListDirectoriesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "list_directories",
+			Description: "List only the subdirectories of a directory, like ls -d, with each one's number of entries and whether it looks like a project root (contains .git or go.mod). Cheaper than a full file listing when choosing a directory to work in. Hidden directories are skipped",
+			QuickHelp:   "List subdirectories with project markers",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Directory whose subdirectories to list"),
+				RecursiveProperty,
+				MaxDepthProperty,
+			},
+		}),
+	})
+}
+
+// ListDirectoriesTool lists the subdirectories of a directory.
+type ListDirectoriesTool struct {
+	*mcputil.ToolBase
+}
+
+// DirectoryInfo describes a subdirectory found by list_directories.
+type DirectoryInfo struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Depth      int      `json:"depth"`
+	ChildCount int      `json:"child_count"`
+	IsProject  bool     `json:"is_project"`
+	Markers    []string `json:"markers,omitempty"`
+}
+
+// Handle processes the list_directories tool request and lists subdirectories
+// down to the requested depth.
+func (t *ListDirectoriesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var maxDepth int
+	var info os.FileInfo
+	var dirs []DirectoryInfo
+
+	logger.Info("Tool called", "tool", "list_directories")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	maxDepth, err = MaxDepthProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxDepth < 1 {
+		err = fmt.Errorf("max_depth must be at least 1, got %d", maxDepth)
+		goto end
+	}
+	if !recursive {
+		maxDepth = 1
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "list_directories",
+		"path", path,
+		"recursive", recursive,
+		"max_depth", maxDepth)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("cannot access %s: %v", path, err)
+		goto end
+	}
+	if !info.IsDir() {
+		err = fmt.Errorf("not a directory: %s", path)
+		goto end
+	}
+
+	dirs = make([]DirectoryInfo, 0)
+	err = listDirectories(path, 1, maxDepth, &dirs)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "list_directories",
+		"path", path,
+		"directory_count", len(dirs))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":            path,
+		"max_depth":       maxDepth,
+		"directories":     dirs,
+		"directory_count": len(dirs),
+	})
+
+end:
+	return result, err
+}
+
+// listDirectories appends the non-hidden subdirectories of dir to dirs in
+// depth-first order, descending until depth exceeds maxDepth.
+func listDirectories(dir string, depth, maxDepth int, dirs *[]DirectoryInfo) (err error) {
+	var entries []os.DirEntry
+	var fp string
+	var children []os.DirEntry
+	var markers []string
+
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		err = fmt.Errorf("failed to read directory %s: %v", dir, err)
+		goto end
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		fp = filepath.Join(dir, entry.Name())
+
+		children, err = os.ReadDir(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read directory %s: %v", fp, err)
+			goto end
+		}
+
+		markers = projectMarkers(fp)
+		*dirs = append(*dirs, DirectoryInfo{
+			Name:       entry.Name(),
+			Path:       fp,
+			Depth:      depth,
+			ChildCount: len(children),
+			IsProject:  len(markers) > 0,
+			Markers:    markers,
+		})
+
+		if depth < maxDepth {
+			err = listDirectories(fp, depth+1, maxDepth, dirs)
+			if err != nil {
+				goto end
+			}
+		}
+	}
+
+end:
+	return err
+}
+
+// projectMarkers returns the markers in dir that identify it as a project
+// root: a .git directory, as detect_current_project requires, or a go.mod file.
+func projectMarkers(dir string) (markers []string) {
+	var info os.FileInfo
+	var err error
+
+	if hasGitDirectory(dir) {
+		markers = append(markers, ".git")
+	}
+	info, err = os.Stat(filepath.Join(dir, "go.mod"))
+	if err == nil && info.Mode().IsRegular() {
+		markers = append(markers, "go.mod")
+	}
+	return markers
+}
@@ -0,0 +1,196 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ListDirectoriesDirPrefix = "list-directories-tool-test"
+
+// List directories tool result type
+type ListDirectoriesResult struct {
+	Path           string                   `json:"path"`
+	MaxDepth       int                      `json:"max_depth"`
+	Directories    []mcptools.DirectoryInfo `json:"directories"`
+	DirectoryCount int                      `json:"directory_count"`
+}
+
+type listDirectoriesResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedCount    int
+	ExpectedNames    []string
+}
+
+func requireListDirectoriesResult(t *testing.T, result *ListDirectoriesResult, err error, opts listDirectoriesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedCount, result.DirectoryCount, "Directory count should match")
+	require.Len(t, result.Directories, opts.ExpectedCount, "Directories list should match directory count")
+
+	if opts.ExpectedNames != nil {
+		names := make([]string, len(result.Directories))
+		for i, dir := range result.Directories {
+			names[i] = dir.Name
+		}
+		assert.Equal(t, opts.ExpectedNames, names, "Directory names should match in depth-first order")
+	}
+}
+
+func TestListDirectoriesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("list_directories")
+	require.NotNil(t, tool, "list_directories tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	// newTree adds a workspace holding a Git project, a Go module with a
+	// nested package, a plain directory and a hidden directory.
+	newTree := func(tf *fsfix.RootFixture) *fsfix.RepoFixture {
+		pf := tf.AddRepoFixture("workspace", nil)
+		pf.AddFileFixture("app/.git/HEAD", &fsfix.FileFixtureArgs{Content: "ref: refs/heads/main\n"})
+		pf.AddFileFixture("app/main.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+		pf.AddFileFixture("lib/go.mod", &fsfix.FileFixtureArgs{Content: "module example.com/lib\n"})
+		pf.AddFileFixture("lib/util/util.go", &fsfix.FileFixtureArgs{Content: "package util\n"})
+		pf.AddFileFixture("lib/util/deep/deep.go", &fsfix.FileFixtureArgs{Content: "package deep\n"})
+		pf.AddFileFixture("notes/todo.txt", &fsfix.FileFixtureArgs{Content: "todo\n"})
+		pf.AddFileFixture(".cache/data", &fsfix.FileFixtureArgs{Content: "cached\n"})
+		pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{Content: "# Workspace\n"})
+		return pf
+	}
+
+	t.Run("Recursive_ShouldListDirectoriesWithMarkers", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoriesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := newTree(tf)
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+		})
+
+		result, err := mcputil.GetToolResult[ListDirectoriesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing directories")
+		requireListDirectoriesResult(t, result, err, listDirectoriesResultOpts{
+			ExpectedCount: 5,
+			ExpectedNames: []string{"app", "lib", "util", "deep", "notes"},
+		})
+
+		byName := make(map[string]mcptools.DirectoryInfo)
+		for _, dir := range result.Directories {
+			byName[dir.Name] = dir
+		}
+
+		assert.True(t, byName["app"].IsProject, "A directory with .git should be a project")
+		assert.Equal(t, []string{".git"}, byName["app"].Markers, "Should report the .git marker")
+		assert.Equal(t, 2, byName["app"].ChildCount, "Child count should include hidden entries")
+
+		assert.True(t, byName["lib"].IsProject, "A directory with go.mod should be a project")
+		assert.Equal(t, []string{"go.mod"}, byName["lib"].Markers, "Should report the go.mod marker")
+
+		assert.False(t, byName["notes"].IsProject, "A plain directory should not be a project")
+		assert.Equal(t, 2, byName["util"].Depth, "Nested directory depth should match")
+		assert.Equal(t, 3, byName["deep"].Depth, "Nested directory depth should match")
+	})
+
+	t.Run("MaxDepth_ShouldLimitDescent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoriesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := newTree(tf)
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"max_depth":     2,
+		})
+
+		result, err := mcputil.GetToolResult[ListDirectoriesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing directories")
+		requireListDirectoriesResult(t, result, err, listDirectoriesResultOpts{
+			ExpectedCount: 4,
+			ExpectedNames: []string{"app", "lib", "util", "notes"},
+		})
+	})
+
+	t.Run("NonRecursive_ShouldListImmediateSubdirectories", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoriesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := newTree(tf)
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"recursive":     false,
+		})
+
+		result, err := mcputil.GetToolResult[ListDirectoriesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing directories")
+		requireListDirectoriesResult(t, result, err, listDirectoriesResultOpts{
+			ExpectedCount: 3,
+			ExpectedNames: []string{"app", "lib", "notes"},
+		})
+	})
+
+	t.Run("FilePath_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoriesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("file-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[ListDirectoriesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for a file path")
+		requireListDirectoriesResult(t, result, err, listDirectoriesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not a directory",
+		})
+	})
+
+	t.Run("InvalidMaxDepth_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoriesDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"max_depth":     0,
+		})
+
+		result, err := mcputil.GetToolResult[ListDirectoriesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for max_depth 0")
+		requireListDirectoriesResult(t, result, err, listDirectoriesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "max_depth",
+		})
+	})
+}
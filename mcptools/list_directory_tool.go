@@ -0,0 +1,178 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+// defaultListMaxResults caps the number of entries list_directory returns
+// when max_results is not given.
+const defaultListMaxResults = 1000
+
+var _ mcputil.Tool = (*ListDirectoryTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ListDirectoryTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "list_directory",
+			Description: "List the entries of a directory, like ls -l, with each one's name, whether it is a directory, size, mode and modification time. Only immediate entries are listed unless recursive is set. Faster and clearer than search_files when no filtering is needed",
+			QuickHelp:   "List a directory's entries with metadata",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Directory to list"),
+				ListRecursiveProperty,
+				MaxResultsProperty.Description(fmt.Sprintf("Maximum number of entries to return (default: %d)", defaultListMaxResults)),
+			},
+		}),
+	})
+}
+
+// ListDirectoryTool lists the entries of a directory with their metadata.
+type ListDirectoryTool struct {
+	*mcputil.ToolBase
+}
+
+// DirectoryEntry describes a file or directory found by list_directory.
+type DirectoryEntry struct {
+	Name        string    `json:"name"`                 // Path relative to the listed directory
+	Path        string    `json:"path"`                 // Full path of the entry
+	IsDirectory bool      `json:"is_directory"`         // Whether the entry is a directory
+	IsSymlink   bool      `json:"is_symlink,omitempty"` // Whether the entry is a symbolic link, which is not followed
+	Size        int64     `json:"size"`                 // Size in bytes
+	Mode        string    `json:"mode"`                 // Type and permissions, as ls -l shows them
+	Modified    time.Time `json:"modified"`             // Last modification time
+}
+
+// Handle processes the list_directory tool request and lists the entries of
+// the directory.
+func (t *ListDirectoryTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var maxResults int
+	var info os.FileInfo
+	var entries []DirectoryEntry
+	var truncated bool
+
+	logger.Info("Tool called", "tool", "list_directory")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = ListRecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	maxResults, err = MaxResultsProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxResults <= 0 {
+		maxResults = defaultListMaxResults
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "list_directory",
+		"path", path,
+		"recursive", recursive,
+		"max_results", maxResults)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("cannot access %s: %v", path, err)
+		goto end
+	}
+	if !info.IsDir() {
+		err = fmt.Errorf("not a directory: %s (use read_files to read a file)", path)
+		goto end
+	}
+
+	entries, truncated, err = listDirectory(path, recursive, maxResults)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "list_directory",
+		"path", path,
+		"entry_count", len(entries),
+		"truncated", truncated)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":        path,
+		"recursive":   recursive,
+		"entries":     entries,
+		"entry_count": len(entries),
+		"truncated":   truncated,
+	})
+
+end:
+	return result, err
+}
+
+// listDirectory returns the entries of dir in lexical order, descending into
+// subdirectories when recursive, and stops once maxResults entries are found.
+// Symbolic links are listed but not followed.
+func listDirectory(dir string, recursive bool, maxResults int) (entries []DirectoryEntry, truncated bool, err error) {
+	entries = make([]DirectoryEntry, 0)
+
+	err = filepath.WalkDir(dir, func(fp string, d fs.DirEntry, walkErr error) (err error) {
+		var rel string
+		var info fs.FileInfo
+
+		if walkErr != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, walkErr)
+			goto end
+		}
+		if fp == dir {
+			goto end
+		}
+		if len(entries) == maxResults {
+			truncated = true
+			err = filepath.SkipAll
+			goto end
+		}
+
+		rel, err = filepath.Rel(dir, fp)
+		if err != nil {
+			goto end
+		}
+
+		info, err = d.Info()
+		if err != nil {
+			err = fmt.Errorf("failed to stat %s: %v", fp, err)
+			goto end
+		}
+
+		entries = append(entries, DirectoryEntry{
+			Name:        filepath.ToSlash(rel),
+			Path:        fp,
+			IsDirectory: d.IsDir(),
+			IsSymlink:   d.Type()&fs.ModeSymlink != 0,
+			Size:        info.Size(),
+			Mode:        info.Mode().String(),
+			Modified:    info.ModTime(),
+		})
+
+		if d.IsDir() && !recursive {
+			err = filepath.SkipDir
+		}
+
+	end:
+		return err
+	})
+
+	return entries, truncated, err
+}
@@ -0,0 +1,167 @@
+package mcptools_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ListDirectoryDirPrefix = "list-directory-tool-test"
+
+// List directory tool result types
+type ListDirectoryEntry struct {
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	IsDirectory bool      `json:"is_directory"`
+	Size        int64     `json:"size"`
+	Mode        string    `json:"mode"`
+	Modified    time.Time `json:"modified"`
+}
+
+type ListDirectoryResult struct {
+	Path       string               `json:"path"`
+	Entries    []ListDirectoryEntry `json:"entries"`
+	EntryCount int                  `json:"entry_count"`
+	Truncated  bool                 `json:"truncated"`
+}
+
+type listDirectoryResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedNames     []string
+	ExpectedTruncated bool
+}
+
+func requireListDirectoryResult(t *testing.T, result *ListDirectoryResult, err error, opts listDirectoryResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	names := make([]string, len(result.Entries))
+	for i, entry := range result.Entries {
+		names[i] = entry.Name
+	}
+	assert.Equal(t, opts.ExpectedNames, names, "Entry names should match")
+	assert.Equal(t, len(opts.ExpectedNames), result.EntryCount, "Entry count should match")
+	assert.Equal(t, opts.ExpectedTruncated, result.Truncated, "Truncated should match")
+}
+
+func TestListDirectoryTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("list_directory")
+	require.NotNil(t, tool, "list_directory tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	listDirectory := func(params mcputil.Params) (*ListDirectoryResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[ListDirectoryResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call list_directory")
+	}
+
+	// addTree adds files under an app directory, away from the repo's .git
+	addTree := func(pf *fsfix.RepoFixture) {
+		pf.AddFileFixture("app/main.go", &fsfix.FileFixtureArgs{Content: "package main\n"})
+		pf.AddFileFixture("app/README.md", &fsfix.FileFixtureArgs{Content: "# Readme\n"})
+		pf.AddFileFixture("app/internal/util.go", &fsfix.FileFixtureArgs{Content: "package internal\n"})
+	}
+
+	t.Run("ImmediateEntries_ShouldIncludeMetadata", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("flat-project", nil)
+		addTree(pf)
+		setup(t, tf)
+		dir := filepath.Join(pf.Dir(), "app")
+
+		result, err := listDirectory(mcputil.Params{
+			"path": dir,
+		})
+		requireListDirectoryResult(t, result, err, listDirectoryResultOpts{
+			ExpectedNames: []string{"README.md", "internal", "main.go"},
+		})
+
+		subdir, file := result.Entries[1], result.Entries[2]
+		assert.True(t, subdir.IsDirectory, "internal should be a directory")
+		assert.Equal(t, "d", subdir.Mode[:1], "Directory mode should start with d")
+		assert.False(t, file.IsDirectory, "main.go should not be a directory")
+		assert.Equal(t, int64(len("package main\n")), file.Size, "Size should match the content")
+		assert.Equal(t, filepath.Join(dir, "main.go"), file.Path, "Path should be the full path")
+		assert.False(t, file.Modified.IsZero(), "Modified time should be set")
+	})
+
+	t.Run("Recursive_ShouldListNestedEntries", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("nested-project", nil)
+		addTree(pf)
+		setup(t, tf)
+		dir := filepath.Join(pf.Dir(), "app")
+
+		result, err := listDirectory(mcputil.Params{
+			"path":      dir,
+			"recursive": true,
+		})
+		requireListDirectoryResult(t, result, err, listDirectoryResultOpts{
+			ExpectedNames: []string{"README.md", "internal", "internal/util.go", "main.go"},
+		})
+	})
+
+	t.Run("MaxResults_ShouldTruncate", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("truncated-project", nil)
+		addTree(pf)
+		setup(t, tf)
+		dir := filepath.Join(pf.Dir(), "app")
+
+		result, err := listDirectory(mcputil.Params{
+			"path":        dir,
+			"max_results": 2,
+		})
+		requireListDirectoryResult(t, result, err, listDirectoryResultOpts{
+			ExpectedNames:     []string{"README.md", "internal"},
+			ExpectedTruncated: true,
+		})
+	})
+
+	t.Run("File_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListDirectoryDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("file-project", nil)
+		addTree(pf)
+		setup(t, tf)
+		dir := filepath.Join(pf.Dir(), "app")
+
+		result, err := listDirectory(mcputil.Params{
+			"path": filepath.Join(dir, "main.go"),
+		})
+		requireListDirectoryResult(t, result, err, listDirectoryResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not a directory",
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package mcptools
+
+import (
+	"context"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ListFileLocksTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ListFileLocksTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "list_file_locks",
+			Description: "List the unexpired file locks held by all sessions. Sessions are identified by a non-secret session_id; the caller's own session_id is included for comparison",
+			QuickHelp:   "List files claimed by sessions",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+			},
+		}),
+	})
+}
+
+// ListFileLocksTool reports the advisory file locks currently held.
+type ListFileLocksTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the list_file_locks tool request and returns the current locks.
+func (t *ListFileLocksTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var locks []mcputil.FileLock
+
+	logger.Info("Tool called", "tool", "list_file_locks")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "list_file_locks")
+
+	locks = mcputil.ListFileLocks()
+
+	logger.Info("Tool completed", "tool", "list_file_locks", "count", len(locks))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"session_id": mcputil.SessionID(token),
+		"locks":      locks,
+		"count":      len(locks),
+	})
+
+end:
+	return result, err
+}
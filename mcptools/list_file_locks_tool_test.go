@@ -0,0 +1,61 @@
+package mcptools_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ListFileLocksDirPrefix = "list-file-locks-tool-test"
+
+// List file locks tool result type
+type ListFileLocksResult struct {
+	SessionID string             `json:"session_id"`
+	Locks     []mcputil.FileLock `json:"locks"`
+	Count     int                `json:"count"`
+}
+
+func TestListFileLocksTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("list_file_locks")
+	require.NotNil(t, tool, "list_file_locks tool should be registered")
+
+	tf := fsfix.NewRootFixture(ListFileLocksDirPrefix)
+	defer tf.Cleanup()
+	tf.Setup(t)
+	tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+		AllowedPaths: []string{tf.TempDir()},
+	}))
+
+	defer mcputil.ClearFileLocks(testToken)
+	defer mcputil.ClearFileLocks(otherToken)
+
+	mine := filepath.Join(tf.TempDir(), "a.txt")
+	theirs := filepath.Join(tf.TempDir(), "b.txt")
+	_, err := mcputil.LockFile(testToken, mine, time.Minute)
+	require.NoError(t, err, "Should lock own file")
+	_, err = mcputil.LockFile(otherToken, theirs, time.Minute)
+	require.NoError(t, err, "Other session should lock its file")
+
+	req := mcputil.NewMockRequest(mcputil.Params{
+		"session_token": testToken,
+	})
+
+	result, err := mcputil.GetToolResult[ListFileLocksResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing file locks")
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, mcputil.SessionID(testToken), result.SessionID, "Caller's session ID should match")
+
+	held := make(map[string]string, len(result.Locks))
+	for _, lock := range result.Locks {
+		held[lock.Path] = lock.SessionID
+	}
+	assert.Equal(t, mcputil.SessionID(testToken), held[mine], "Own lock should be listed")
+	assert.Equal(t, mcputil.SessionID(otherToken), held[theirs], "Other session's lock should be listed")
+	assert.Equal(t, len(result.Locks), result.Count, "Count should match")
+}
@@ -0,0 +1,123 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ListGenerateDirectivesTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ListGenerateDirectivesTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "list_generate_directives",
+			Description: "List the //go:generate directives in the Go files under a path, with the file, line and command of each, to show the code generation steps a build relies on. Directives are only listed, never run",
+			QuickHelp:   "List //go:generate directives",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file or directory to scan"),
+				RecursiveProperty,
+			},
+		}),
+	})
+}
+
+// ListGenerateDirectivesTool reports the //go:generate directives in Go files.
+type ListGenerateDirectivesTool struct {
+	*mcputil.ToolBase
+}
+
+// GenerateDirectiveResult describes a //go:generate directive found in a file.
+type GenerateDirectiveResult struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Command string `json:"command"`
+}
+
+// Handle processes the list_generate_directives tool request and returns the
+// directives found.
+func (t *ListGenerateDirectivesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var files []string
+	var directives []GenerateDirectiveResult
+	var parseErrors []string
+
+	logger.Info("Tool called", "tool", "list_generate_directives")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "list_generate_directives",
+		"path", path,
+		"recursive", recursive)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+		Excludes:   golang.DefaultExcludes(),
+	})
+	if err != nil {
+		goto end
+	}
+
+	directives, parseErrors = listGenerateDirectives(files)
+
+	logger.Info("Tool completed", "tool", "list_generate_directives",
+		"files_scanned", len(files),
+		"directive_count", len(directives))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":            path,
+		"directives":      directives,
+		"directive_count": len(directives),
+		"files_scanned":   len(files),
+		"errors":          parseErrors,
+	})
+
+end:
+	return result, err
+}
+
+// listGenerateDirectives collects the //go:generate directives in files, in
+// file then line order. Files that fail to parse are reported in parseErrors
+// and skipped.
+func listGenerateDirectives(files []string) (directives []GenerateDirectiveResult, parseErrors []string) {
+	var content []byte
+	var found []golang.GoGenerateDirective
+	var err error
+
+	directives = make([]GenerateDirectiveResult, 0)
+	parseErrors = make([]string, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			found, err = golang.ParseGenerateDirectives(fp, content)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", fp, err))
+			continue
+		}
+		for _, d := range found {
+			directives = append(directives, GenerateDirectiveResult{
+				File:    fp,
+				Line:    d.Line,
+				Command: d.Command,
+			})
+		}
+	}
+	return directives, parseErrors
+}
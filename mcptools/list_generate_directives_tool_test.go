@@ -0,0 +1,144 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ListGenerateDirectivesDirPrefix = "list-generate-directives-tool-test"
+
+// List generate directives tool result type
+type ListGenerateDirectivesResult struct {
+	Path           string                             `json:"path"`
+	Directives     []mcptools.GenerateDirectiveResult `json:"directives"`
+	DirectiveCount int                                `json:"directive_count"`
+	FilesScanned   int                                `json:"files_scanned"`
+	Errors         []string                           `json:"errors"`
+}
+
+type listGenerateDirectivesResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedCommands     []string
+	ExpectedFilesScanned int
+	ExpectedErrorCount   int
+}
+
+func requireListGenerateDirectivesResult(t *testing.T, result *ListGenerateDirectivesResult, err error, opts listGenerateDirectivesResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	commands := make([]string, len(result.Directives))
+	for i, d := range result.Directives {
+		commands[i] = d.Command
+	}
+	if opts.ExpectedCommands == nil {
+		opts.ExpectedCommands = []string{}
+	}
+	assert.Equal(t, opts.ExpectedCommands, commands, "Commands should match")
+	assert.Equal(t, len(opts.ExpectedCommands), result.DirectiveCount, "Directive count should match")
+	assert.Equal(t, opts.ExpectedFilesScanned, result.FilesScanned, "Files scanned should match")
+	assert.Len(t, result.Errors, opts.ExpectedErrorCount, "Error count should match")
+}
+
+func TestListGenerateDirectivesTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("list_generate_directives")
+	require.NotNil(t, tool, "list_generate_directives tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	t.Run("Directives_ShouldBeListedWithLines", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListGenerateDirectivesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("generate-project", nil)
+		ff := pf.AddFileFixture("color.go", &fsfix.FileFixtureArgs{
+			Content: "package color\n\n//go:generate stringer -type=Color\n\ntype Color int\n\n" +
+				"func f() {\n\t//go:generate indented is ignored\n}\n\n" +
+				"//go:generatex not a directive\n// go:generate not a directive either\n",
+		})
+		pf.AddFileFixture("sub/mocks.go", &fsfix.FileFixtureArgs{
+			Content: "package sub\n\n//go:generate\tmockgen -source=api.go -destination=mock_api.go\n",
+		})
+		pf.AddFileFixture("README.md", &fsfix.FileFixtureArgs{
+			Content: "//go:generate not Go\n",
+		})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+		})
+
+		result, err := mcputil.GetToolResult[ListGenerateDirectivesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing directives")
+		requireListGenerateDirectivesResult(t, result, err, listGenerateDirectivesResultOpts{
+			ExpectedCommands:     []string{"stringer -type=Color", "mockgen -source=api.go -destination=mock_api.go"},
+			ExpectedFilesScanned: 2,
+		})
+		assert.Equal(t, ff.Filepath, result.Directives[0].File, "Should report the file")
+		assert.Equal(t, 3, result.Directives[0].Line, "Should report the line")
+	})
+
+	t.Run("NonRecursive_ShouldSkipSubdirectories", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListGenerateDirectivesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("generate-project", nil)
+		pf.AddFileFixture("sub/mocks.go", &fsfix.FileFixtureArgs{
+			Content: "package sub\n\n//go:generate mockgen -source=api.go\n",
+		})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"recursive":     false,
+		})
+
+		result, err := mcputil.GetToolResult[ListGenerateDirectivesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing directives")
+		requireListGenerateDirectivesResult(t, result, err, listGenerateDirectivesResultOpts{})
+	})
+
+	t.Run("InvalidGo_ShouldReportError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListGenerateDirectivesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("generate-project", nil)
+		pf.AddFileFixture("broken.go", &fsfix.FileFixtureArgs{
+			Content: "package broken\n\n//go:generate go run gen.go\n\nfunc {\n",
+		})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+		})
+
+		result, err := mcputil.GetToolResult[ListGenerateDirectivesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error for an unparsable file")
+		requireListGenerateDirectivesResult(t, result, err, listGenerateDirectivesResultOpts{
+			ExpectedFilesScanned: 1,
+			ExpectedErrorCount:   1,
+		})
+	})
+}
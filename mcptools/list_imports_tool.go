@@ -0,0 +1,155 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ListImportsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ListImportsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "list_imports",
+			Description: "List the imports of a Go file or directory, classifying each as standard library, third-party, or intra-module",
+			QuickHelp:   "List Go imports and dependencies",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+				RecursiveProperty,
+			},
+		}),
+	})
+}
+
+// ListImportsTool reports the imports of Go files without returning their full contents.
+type ListImportsTool struct {
+	*mcputil.ToolBase
+}
+
+// FileImportsResult contains the imports of a single Go file.
+type FileImportsResult struct {
+	Path    string            `json:"path"`
+	Module  string            `json:"module,omitempty"`
+	Imports []golang.GoImport `json:"imports"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// Handle processes the list_imports tool request and returns per-file imports
+// along with the unique dependency set across all files.
+func (t *ListImportsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var recursive bool
+	var files []string
+	var fileResults []FileImportsResult
+	var dependencies map[golang.GoImportKind][]string
+
+	logger.Info("Tool called", "tool", "list_imports")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "list_imports", "path", path, "recursive", recursive)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      []string{path},
+		Recursive:  recursive,
+		Extensions: []string{".go"},
+	})
+	if err != nil {
+		goto end
+	}
+
+	fileResults = t.listImports(files)
+	dependencies = aggregateImports(fileResults)
+
+	logger.Info("Tool completed", "tool", "list_imports", "file_count", len(fileResults))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":         path,
+		"files":        fileResults,
+		"file_count":   len(fileResults),
+		"dependencies": dependencies,
+	})
+
+end:
+	return result, err
+}
+
+// listImports parses the imports of each file. Parse failures are reported
+// per file so one broken file does not hide the imports of the others.
+func (t *ListImportsTool) listImports(files []string) (results []FileImportsResult) {
+	var modules map[string]string
+	var content []byte
+	var fr FileImportsResult
+	var dir string
+	var ok bool
+	var err error
+
+	modules = make(map[string]string)
+	results = make([]FileImportsResult, 0, len(files))
+	for _, fp := range files {
+		fr = FileImportsResult{Path: fp}
+
+		dir = filepath.Dir(fp)
+		fr.Module, ok = modules[dir]
+		if !ok {
+			fr.Module, _, err = golang.FindModulePath(dir)
+			if err != nil {
+				logger.Warn("Unable to determine module", "dir", dir, "error", err)
+			}
+			modules[dir] = fr.Module
+		}
+
+		content, err = os.ReadFile(fp)
+		if err == nil {
+			fr.Imports, err = golang.ParseImports(fp, content, fr.Module)
+		}
+		if err != nil {
+			fr.Error = fmt.Sprintf("failed to parse imports: %v", err)
+			fr.Imports = []golang.GoImport{}
+		}
+		results = append(results, fr)
+	}
+	return results
+}
+
+// aggregateImports returns the sorted unique import paths across all files, grouped by kind.
+func aggregateImports(results []FileImportsResult) (deps map[golang.GoImportKind][]string) {
+	var seen map[string]NULL
+	var ok bool
+
+	seen = make(map[string]NULL)
+	deps = map[golang.GoImportKind][]string{
+		golang.StdlibImport:     {},
+		golang.ThirdPartyImport: {},
+		golang.ModuleImport:     {},
+	}
+	for _, fr := range results {
+		for _, imp := range fr.Imports {
+			_, ok = seen[imp.Path]
+			if ok {
+				continue
+			}
+			seen[imp.Path] = NULL{}
+			deps[imp.Kind] = append(deps[imp.Kind], imp.Path)
+		}
+	}
+	for kind := range deps {
+		sort.Strings(deps[kind])
+	}
+	return deps
+}
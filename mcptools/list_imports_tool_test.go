@@ -0,0 +1,137 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ListImportsDirPrefix = "list-imports-tool-test"
+
+// List imports tool result type
+type ListImportsResult struct {
+	Path  string `json:"path"`
+	Files []struct {
+		Path    string `json:"path"`
+		Module  string `json:"module"`
+		Imports []struct {
+			Path  string `json:"path"`
+			Alias string `json:"alias"`
+			Kind  string `json:"kind"`
+			Line  int    `json:"line"`
+		} `json:"imports"`
+		Error string `json:"error"`
+	} `json:"files"`
+	FileCount    int                 `json:"file_count"`
+	Dependencies map[string][]string `json:"dependencies"`
+}
+
+type listImportsResultOpts struct {
+	ExpectError        bool
+	ExpectedErrorMsg   string
+	ExpectedFileCount  int
+	ExpectedStdlib     []string
+	ExpectedThirdParty []string
+	ExpectedModule     []string
+}
+
+func requireListImportsResult(t *testing.T, result *ListImportsResult, err error, opts listImportsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedFileCount, result.FileCount, "File count should match")
+	assert.Equal(t, opts.ExpectedStdlib, result.Dependencies["stdlib"], "Stdlib dependencies should match")
+	assert.Equal(t, opts.ExpectedThirdParty, result.Dependencies["third_party"], "Third-party dependencies should match")
+	assert.Equal(t, opts.ExpectedModule, result.Dependencies["module"], "Module dependencies should match")
+}
+
+func TestListImportsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("list_imports")
+	require.NotNil(t, tool, "list_imports tool should be registered")
+
+	t.Run("DirectoryWithModule_ShouldClassifyImports", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListImportsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("imports-project", nil)
+		pf.AddFileFixture("go.mod", &fsfix.FileFixtureArgs{
+			Content: "module example.com/project\n\ngo 1.24\n",
+		})
+		pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: `package main
+
+import (
+	"fmt"
+	str "strings"
+
+	"example.com/project/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+`,
+		})
+		pf.AddFileFixture("internal/util/util.go", &fsfix.FileFixtureArgs{
+			Content: "package util\n\nimport \"fmt\"\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+		})
+
+		result, err := mcputil.GetToolResult[ListImportsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error listing imports")
+		requireListImportsResult(t, result, err, listImportsResultOpts{
+			ExpectedFileCount:  2,
+			ExpectedStdlib:     []string{"fmt", "strings"},
+			ExpectedThirdParty: []string{"github.com/stretchr/testify/assert"},
+			ExpectedModule:     []string{"example.com/project/internal/util"},
+		})
+
+		for _, f := range result.Files {
+			assert.Equal(t, "example.com/project", f.Module, "Module should be detected from go.mod")
+			for _, imp := range f.Imports {
+				if imp.Path == "strings" {
+					assert.Equal(t, "str", imp.Alias, "Alias should be reported")
+				}
+			}
+		}
+	})
+
+	t.Run("PathNotAllowed_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ListImportsDirPrefix)
+		defer tf.Cleanup()
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          "/does/not/exist",
+		})
+
+		result, err := mcputil.GetToolResult[ListImportsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for missing path")
+		requireListImportsResult(t, result, err, listImportsResultOpts{
+			ExpectError: true,
+		})
+	})
+}
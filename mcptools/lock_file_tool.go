@@ -0,0 +1,78 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*LockFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&LockFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "lock_file",
+			Description: "Claim a file or directory for this session so other sessions are warned, or refused, when they modify it. Locks are released by unlock_file, when the TTL passes, or when the session ends; calling again renews the lock",
+			QuickHelp:   "Claim a file for this session",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+				TTLMinutesProperty,
+			},
+		}),
+	})
+}
+
+// LockFileTool acquires an advisory lock on a path for the calling session.
+type LockFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the lock_file tool request and returns the acquired lock.
+func (t *LockFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var path string
+	var ttlMinutes int
+	var lock mcputil.FileLock
+
+	logger.Info("Tool called", "tool", "lock_file")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	ttlMinutes, err = TTLMinutesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "lock_file", "path", path, "ttl_minutes", ttlMinutes)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	lock, err = mcputil.LockFile(token, path, time.Duration(ttlMinutes)*time.Minute)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "lock_file", "path", lock.Path, "expires_at", lock.ExpiresAt)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"locked": true,
+		"lock":   lock,
+	})
+
+end:
+	return result, err
+}
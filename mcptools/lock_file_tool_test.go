@@ -0,0 +1,159 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const LockFileDirPrefix = "lock-file-tool-test"
+
+// otherToken identifies a second session competing with testToken for locks
+const otherToken = "other-session-token"
+
+// Lock file tool result type
+type LockFileResult struct {
+	Locked bool             `json:"locked"`
+	Lock   mcputil.FileLock `json:"lock"`
+}
+
+type lockFileResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedPath     string
+}
+
+func requireLockFileResult(t *testing.T, result *LockFileResult, err error, opts lockFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Locked, "Should be locked")
+	assert.Equal(t, opts.ExpectedPath, result.Lock.Path, "Locked path should match")
+	assert.Equal(t, mcputil.SessionID(testToken), result.Lock.SessionID, "Session ID should match")
+	assert.True(t, result.Lock.ExpiresAt.After(result.Lock.AcquiredAt), "Lock should expire after it was acquired")
+}
+
+func TestLockFileTool(t *testing.T) {
+	// Get the tools
+	tool := mcputil.GetRegisteredTool("lock_file")
+	require.NotNil(t, tool, "lock_file tool should be registered")
+	updateTool := mcputil.GetRegisteredTool("update_file")
+	require.NotNil(t, updateTool, "update_file tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(LockFileDirPrefix)
+		ff := tf.AddFileFixture("shared.txt", &fsfix.FileFixtureArgs{Content: "before\n"})
+		tf.Setup(t)
+		config := mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		})
+		tool.SetConfig(config)
+		updateTool.SetConfig(config)
+		return tf, ff
+	}
+
+	cleanup := func(tf *fsfix.RootFixture) {
+		mcputil.ClearFileLocks(testToken)
+		mcputil.ClearFileLocks(otherToken)
+		require.NoError(t, mcputil.SetFileLockMode(mcputil.WarnOnLockedFile))
+		tf.Cleanup()
+	}
+
+	lockFile := func(token, path string) (*LockFileResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": token,
+			"path":          path,
+		})
+		return mcputil.GetToolResult[LockFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error locking file")
+	}
+
+	updateFile := func(token, path string) (mcputil.ToolResult, error) {
+		return mcputil.CallTool(updateTool, mcputil.NewMockRequest(mcputil.Params{
+			"session_token": token,
+			"filepath":      path,
+			"new_content":   "after\n",
+		}))
+	}
+
+	t.Run("LockAndRenew", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		result, err := lockFile(testToken, ff.Filepath)
+		requireLockFileResult(t, result, err, lockFileResultOpts{
+			ExpectedPath: ff.Filepath,
+		})
+
+		renewed, err := lockFile(testToken, ff.Filepath)
+		requireLockFileResult(t, renewed, err, lockFileResultOpts{
+			ExpectedPath: ff.Filepath,
+		})
+		assert.True(t, renewed.Lock.AcquiredAt.Equal(result.Lock.AcquiredAt), "Renewing should keep the acquired time")
+	})
+
+	t.Run("LockedByOtherSession_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		_, err := lockFile(otherToken, tf.TempDir())
+		require.NoError(t, err, "Other session should lock the directory")
+
+		result, err := lockFile(testToken, ff.Filepath)
+		requireLockFileResult(t, result, err, lockFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "locked by session " + mcputil.SessionID(otherToken),
+		})
+	})
+
+	t.Run("WarnMode_UpdateShouldWarn", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		_, err := lockFile(otherToken, ff.Filepath)
+		require.NoError(t, err, "Other session should lock the file")
+
+		result, err := mcputil.GetToolResult[map[string]any](mcputil.CallResult(updateFile(testToken, ff.Filepath)), "Should not error updating file")
+		require.NoError(t, err, "Update should proceed in warn mode")
+		require.NotNil(t, result, "Result should not be nil")
+		warnings, ok := (*result)["lock_warnings"].([]any)
+		require.True(t, ok, "Result should include lock warnings")
+		require.Len(t, warnings, 1, "Should warn once")
+		assert.Contains(t, warnings[0], ff.Filepath+" is locked by session "+mcputil.SessionID(otherToken), "Warning should name the lock owner")
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read file")
+		assert.Equal(t, "after\n", string(content), "File should be updated")
+
+		result, err = mcputil.GetToolResult[map[string]any](mcputil.CallResult(updateFile(otherToken, ff.Filepath)), "Should not error updating file")
+		require.NoError(t, err, "Lock owner should update the file")
+		assert.NotContains(t, *result, "lock_warnings", "Lock owner should not be warned")
+	})
+
+	t.Run("RefuseMode_UpdateShouldFail", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		require.NoError(t, mcputil.SetFileLockMode(mcputil.RefuseLockedFile))
+		_, err := lockFile(otherToken, ff.Filepath)
+		require.NoError(t, err, "Other session should lock the file")
+
+		_, err = updateFile(testToken, ff.Filepath)
+		require.Error(t, err, "Update should be refused")
+		assert.Contains(t, err.Error(), mcputil.ErrFileLocked.Error(), "Error should report the lock")
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err, "Should read file")
+		assert.Equal(t, "before\n", string(content), "File should be unchanged")
+	})
+}
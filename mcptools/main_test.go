@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/langutil/javascript"
+	"github.com/mikeschinkel/scout-mcp/langutil/python"
 	"github.com/mikeschinkel/scout-mcp/mcptools"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 	"github.com/mikeschinkel/scout-mcp/testutil"
 )
 
@@ -20,6 +23,9 @@ func TestMain(m *testing.M) {
 	mcptools.SetLogger(logger)
 	mcputil.SetLogger(logger)
 	golang.SetLogger(logger)
+	javascript.SetLogger(logger)
+	python.SetLogger(logger)
+	scoutcfg.SetLogger(logger)
 
 	// Run tests
 	code := m.Run()
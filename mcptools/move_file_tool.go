@@ -0,0 +1,182 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*MoveFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&MoveFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "move_file",
+			Description: "Move or rename a file within the allowed paths. The file is renamed when source and destination are on the same filesystem and otherwise copied, with its permissions and modification time, and then removed",
+			QuickHelp:   "Move or rename a file",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				SourceProperty.Required(),
+				DestinationProperty.Required(),
+				OverwriteProperty.Description("Replace the destination if it already exists"),
+				CreateDirsProperty.Description("Create the destination's parent directories if needed"),
+				ConfirmationTokenProperty,
+			},
+		}),
+	})
+}
+
+// MoveFileTool moves or renames a file within the allowed paths.
+type MoveFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the move_file tool request and moves the source file to
+// the destination.
+func (t *MoveFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var source string
+	var destination string
+	var overwrite bool
+	var createDirs bool
+	var sourceAbs, destinationAbs string
+	var existed bool
+	var info os.FileInfo
+	var op mcputil.FileOperation
+
+	logger.Info("Tool called", "tool", "move_file")
+
+	source, err = SourceProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	destination, err = DestinationProperty.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	overwrite, err = OverwriteProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	createDirs, err = CreateDirsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "move_file",
+		"source", source,
+		"destination", destination,
+		"overwrite", overwrite,
+		"create_dirs", createDirs)
+
+	if !t.IsAllowedPath(source) {
+		err = fmt.Errorf("access denied: path not allowed: %s", source)
+		goto end
+	}
+	if !t.IsAllowedPath(destination) {
+		err = fmt.Errorf("access denied: path not allowed: %s", destination)
+		goto end
+	}
+
+	sourceAbs, err = filepath.Abs(source)
+	if err != nil {
+		goto end
+	}
+	destinationAbs, err = filepath.Abs(destination)
+	if err != nil {
+		goto end
+	}
+	if sourceAbs == destinationAbs {
+		err = fmt.Errorf("source and destination are the same file: %s", source)
+		goto end
+	}
+
+	info, err = os.Stat(source)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = fmt.Errorf("source does not exist: %s", source)
+		goto end
+	case err != nil:
+		err = fmt.Errorf("error checking source: %v", err)
+		goto end
+	case info.IsDir():
+		err = fmt.Errorf("cannot move directory: %s", source)
+		goto end
+	}
+
+	info, err = os.Stat(destination)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = nil
+	case err != nil:
+		err = fmt.Errorf("error checking destination: %v", err)
+		goto end
+	case info.IsDir():
+		err = fmt.Errorf("destination is a directory: %s", destination)
+		goto end
+	case !overwrite:
+		err = fmt.Errorf("destination already exists: %s (set overwrite to replace it)", destination)
+		goto end
+	default:
+		existed = true
+	}
+
+	if existed {
+		err = mcputil.ConfirmOperation(ctx, mcputil.OverwriteOperation, destination)
+		if err != nil {
+			goto end
+		}
+	}
+
+	// Create parent directories if requested, unless only previewing
+	switch {
+	case createDirs && !mcputil.IsPreview(ctx):
+		err = os.MkdirAll(filepath.Dir(destination), 0755)
+		if err != nil {
+			err = fmt.Errorf("failed to create directories: %v", err)
+			goto end
+		}
+	case !createDirs:
+		info, err = os.Stat(filepath.Dir(destination))
+		if err != nil || !info.IsDir() {
+			err = fmt.Errorf("destination directory does not exist: %s (set create_dirs to create it)", filepath.Dir(destination))
+			goto end
+		}
+	}
+
+	err = mcputil.MoveFile(ctx, t.Config(), source, destination)
+	if err != nil {
+		err = fmt.Errorf("failed to move file: %v", err)
+		goto end
+	}
+
+	op = mcputil.CreatedFileOp
+	if existed {
+		op = mcputil.UpdatedFileOp
+	}
+	recordFileChange(ctx, req, mcputil.DeletedFileOp, source)
+	recordFileChange(ctx, req, op, destination)
+
+	logger.Info("Tool completed", "tool", "move_file",
+		"source", source,
+		"destination", destination)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":     true,
+		"source":      source,
+		"destination": destination,
+		"overwritten": existed,
+		"message":     fmt.Sprintf("Moved %s to %s", source, destination),
+	})
+
+end:
+	return result, err
+}
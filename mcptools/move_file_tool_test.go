@@ -0,0 +1,202 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const MoveFileDirPrefix = "move-file-tool-test"
+
+// Move file tool result type
+type MoveFileResult struct {
+	Success     bool   `json:"success"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Overwritten bool   `json:"overwritten"`
+	Message     string `json:"message"`
+}
+
+type moveFileResultOpts struct {
+	ExpectError         bool
+	ExpectedErrorMsg    string
+	ExpectedContent     string
+	ExpectedOverwritten bool
+}
+
+func requireMoveFileResult(t *testing.T, result *MoveFileResult, err error, opts moveFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedOverwritten, result.Overwritten, "Overwritten should match")
+
+	_, err = os.Stat(result.Source)
+	assert.True(t, os.IsNotExist(err), "Source should no longer exist")
+	requireFileContent(t, result.Destination, opts.ExpectedContent)
+}
+
+func TestMoveFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("move_file")
+	require.NotNil(t, tool, "move_file tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	move := func(params mcputil.Params) (*MoveFileResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[MoveFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call move_file")
+	}
+
+	t.Run("Rename_ShouldMoveContent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("rename-project", nil)
+		source := pf.AddFileFixture("old.txt", &fsfix.FileFixtureArgs{
+			Content: "moved content\n",
+		})
+		setup(t, tf)
+
+		result, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": filepath.Join(pf.Dir(), "new.txt"),
+		})
+		requireMoveFileResult(t, result, err, moveFileResultOpts{
+			ExpectedContent: "moved content\n",
+		})
+	})
+
+	t.Run("CreateDirs_ShouldCreateDestinationParent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("relocate-project", nil)
+		source := pf.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{
+			Content: "relocated\n",
+		})
+		setup(t, tf)
+
+		result, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": filepath.Join(pf.Dir(), "nested", "dir", "file.txt"),
+			"create_dirs": true,
+		})
+		requireMoveFileResult(t, result, err, moveFileResultOpts{
+			ExpectedContent: "relocated\n",
+		})
+	})
+
+	t.Run("MissingParent_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("no-parent-project", nil)
+		source := pf.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{
+			Content: "stays\n",
+		})
+		setup(t, tf)
+
+		result, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": filepath.Join(pf.Dir(), "missing", "file.txt"),
+		})
+		requireMoveFileResult(t, result, err, moveFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "set create_dirs to create it",
+		})
+		requireFileContent(t, source.Filepath, "stays\n")
+	})
+
+	t.Run("ExistingDestination_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("exists-project", nil)
+		source := pf.AddFileFixture("source.txt", &fsfix.FileFixtureArgs{
+			Content: "content\n",
+		})
+		target := pf.AddFileFixture("target.txt", &fsfix.FileFixtureArgs{
+			Content: "existing\n",
+		})
+		setup(t, tf)
+
+		result, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": target.Filepath,
+		})
+		requireMoveFileResult(t, result, err, moveFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "destination already exists",
+		})
+		requireFileContent(t, source.Filepath, "content\n")
+		requireFileContent(t, target.Filepath, "existing\n")
+	})
+
+	t.Run("Overwrite_ShouldReplaceDestination", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("overwrite-project", nil)
+		source := pf.AddFileFixture("source.txt", &fsfix.FileFixtureArgs{
+			Content: "new content\n",
+		})
+		target := pf.AddFileFixture("target.txt", &fsfix.FileFixtureArgs{
+			Content: "old content\n",
+		})
+		setup(t, tf)
+
+		result, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": target.Filepath,
+			"overwrite":   true,
+		})
+		requireMoveFileResult(t, result, err, moveFileResultOpts{
+			ExpectedContent:     "new content\n",
+			ExpectedOverwritten: true,
+		})
+	})
+
+	t.Run("DryRun_ShouldNotMoveFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(MoveFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("preview-project", nil)
+		source := pf.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{
+			Content: "stays\n",
+		})
+		setup(t, tf)
+
+		destination := filepath.Join(pf.Dir(), "moved.txt")
+		_, err := move(mcputil.Params{
+			"source":      source.Filepath,
+			"destination": destination,
+			"dry_run":     true,
+		})
+		require.NoError(t, err, "Should preview the move")
+		requireFileContent(t, source.Filepath, "stays\n")
+		_, err = os.Stat(destination)
+		assert.True(t, os.IsNotExist(err), "Destination should not be created")
+	})
+}
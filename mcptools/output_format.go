@@ -0,0 +1,45 @@
+package mcptools
+
+import (
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+// OutputFormat identifies how a tool encodes a list of results.
+type OutputFormat string
+
+const (
+	JSONOutput   OutputFormat = "json"   // A single JSON object containing the results and summary fields
+	NDJSONOutput OutputFormat = "ndjson" // One JSON object per result, newline-delimited
+)
+
+// Validate checks if the OutputFormat has a valid value.
+func (of OutputFormat) Validate() (err error) {
+	switch of {
+	case JSONOutput:
+	case NDJSONOutput:
+	default:
+		err = fmt.Errorf("output_format must be '%s' or '%s', got '%s'",
+			JSONOutput,
+			NDJSONOutput,
+			of,
+		)
+	}
+	return err
+}
+
+// getOutputFormat returns the validated output_format of the request.
+func getOutputFormat(req mcputil.ToolRequest) (format OutputFormat, err error) {
+	var value string
+
+	value, err = OutputFormatProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	format = OutputFormat(value)
+	err = format.Validate()
+
+end:
+	return format, err
+}
@@ -0,0 +1,198 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const PreviewDirPrefix = "preview-test"
+
+// dryRunEditResult is a previewed tool's own result with the preview merged in
+type dryRunEditResult struct {
+	mcputil.PreviewResult
+	Success  bool   `json:"success"`
+	FilePath string `json:"file_path"`
+}
+
+func TestDryRunPreview(t *testing.T) {
+	setup := func(t *testing.T, toolName string) (*fsfix.RootFixture, *fsfix.FileFixture, mcputil.Tool) {
+		tool := mcputil.GetRegisteredTool(toolName)
+		require.NotNil(t, tool, "%s tool should be registered", toolName)
+
+		tf := fsfix.NewRootFixture(PreviewDirPrefix)
+		ff := tf.AddFileFixture("sample.txt", &fsfix.FileFixtureArgs{Content: "one\ntwo\nthree\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff, tool
+	}
+
+	t.Run("ReplacePattern_ShouldReturnDiffWithoutWriting", func(t *testing.T) {
+		tf, ff, tool := setup(t, "replace_pattern")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"pattern":       "two",
+			"replacement":   "TWO",
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing replacement")
+		require.NoError(t, err, "Should not have error")
+		assert.True(t, result.DryRun, "Result should report dry run")
+		assert.Equal(t, "replace_pattern", result.Tool, "Result should name the tool")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, mcputil.UpdatedFileOp, result.Files[0].Operation, "File should be reported as updated")
+		assert.Equal(t, "one\nTWO\nthree\n", result.Files[0].Content, "Resulting content should be returned")
+		assert.Contains(t, result.Files[0].Diff, "-two\n+TWO\n", "Diff should show the replacement")
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "one\ntwo\nthree\n", string(content), "Dry run should not modify the file")
+	})
+
+	t.Run("CreateFile_ShouldNotCreateFileOrDirs", func(t *testing.T) {
+		tf, _, tool := setup(t, "create_file")
+		defer tf.Cleanup()
+
+		newFile := filepath.Join(tf.TempDir(), "sub", "new.txt")
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      newFile,
+			"new_content":   "hello\n",
+			"create_dirs":   true,
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing creation")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, mcputil.CreatedFileOp, result.Files[0].Operation, "File should be reported as created")
+		assert.Contains(t, result.Files[0].Diff, "+hello\n", "Diff should show the new content")
+
+		_, err = os.Stat(filepath.Dir(newFile))
+		assert.True(t, os.IsNotExist(err), "Dry run should not create directories")
+	})
+
+	t.Run("DeleteFiles_ShouldNotDelete", func(t *testing.T) {
+		tf, ff, tool := setup(t, "delete_files")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing deletion")
+		require.NoError(t, err, "Should not have error")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Equal(t, mcputil.DeletedFileOp, result.Files[0].Operation, "File should be reported as deleted")
+
+		_, err = os.Stat(ff.Filepath)
+		assert.NoError(t, err, "Dry run should not delete the file")
+	})
+
+	t.Run("EditTools_ShouldKeepTheirResultFields", func(t *testing.T) {
+		tests := []struct {
+			tool    string
+			params  mcputil.Params
+			content string
+		}{
+			{tool: "update_file", params: mcputil.Params{"filepath": "", "new_content": "uno\n"}, content: "uno\n"},
+			{tool: "update_file_lines", params: mcputil.Params{"filepath": "", "start_line": 2, "end_line": 2, "new_content": "dos"}, content: "one\ndos\nthree\n"},
+			{tool: "insert_file_lines", params: mcputil.Params{"filepath": "", "line_number": 1, "position": "after", "new_content": "one and a half"}, content: "one\none and a half\ntwo\nthree\n"},
+			{tool: "delete_file_lines", params: mcputil.Params{"filepath": "", "start_line": 2, "end_line": 2}, content: "one\nthree\n"},
+			{tool: "replace_pattern", params: mcputil.Params{"path": "", "pattern": "three", "replacement": "tres"}, content: "one\ntwo\ntres\n"},
+			{tool: "insert_at_pattern", params: mcputil.Params{"path": "", "before_pattern": "three", "new_content": "two and a half"}, content: "one\ntwo\ntwo and a half\nthree\n"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.tool, func(t *testing.T) {
+				tf, ff, tool := setup(t, tt.tool)
+				defer tf.Cleanup()
+
+				params := mcputil.Params{
+					"session_token": testToken,
+					"dry_run":       true,
+				}
+				for name, value := range tt.params {
+					if value == "" {
+						value = ff.Filepath
+					}
+					params[name] = value
+				}
+
+				result, err := mcputil.GetToolResult[dryRunEditResult](mcputil.CallResult(mcputil.CallTool(tool, mcputil.NewMockRequest(params))), "Should not error previewing edit")
+				require.NoError(t, err, "Should not have error")
+				assert.True(t, result.DryRun, "Result should report dry run")
+				assert.True(t, result.Success, "Tool's success field should be populated")
+				assert.Equal(t, ff.Filepath, result.FilePath, "Tool's file_path field should be populated")
+				require.Len(t, result.Files, 1, "One file should be previewed")
+				assert.Equal(t, tt.content, result.Files[0].Content, "Resulting content should be returned")
+				assert.NotEmpty(t, result.Files[0].Diff, "Diff should be returned")
+
+				requireFileContent(t, ff.Filepath, "one\ntwo\nthree\n")
+			})
+		}
+	})
+
+	t.Run("ReplaceFilePart_ShouldKeepItsResultFields", func(t *testing.T) {
+		tool := mcputil.GetRegisteredTool("replace_file_part")
+		require.NotNil(t, tool, "replace_file_part tool should be registered")
+
+		tf := fsfix.NewRootFixture(PreviewDirPrefix)
+		defer tf.Cleanup()
+		ff := tf.AddFileFixture("config.go", &fsfix.FileFixtureArgs{Content: GoFuncBodyContent})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"language":      "go",
+			"part_type":     "func",
+			"part_name":     "*Config.GetPort",
+			"new_content":   UpdatedMethod,
+			"dry_run":       true,
+		})
+
+		result, err := mcputil.GetToolResult[dryRunEditResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error previewing replacement")
+		require.NoError(t, err, "Should not have error")
+		assert.True(t, result.DryRun, "Result should report dry run")
+		assert.True(t, result.Success, "Tool's success field should be populated")
+		assert.Equal(t, ff.Filepath, result.FilePath, "Tool's file_path field should be populated")
+		require.Len(t, result.Files, 1, "One file should be previewed")
+		assert.Contains(t, result.Files[0].Diff, `+	if c.Port == "" {`, "Diff should show the replacement")
+
+		requireFileContent(t, ff.Filepath, GoFuncBodyContent)
+	})
+
+	t.Run("ToolErrors_ShouldStillBeReported", func(t *testing.T) {
+		tf, ff, tool := setup(t, "update_file_lines")
+		defer tf.Cleanup()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      ff.Filepath,
+			"start_line":    10,
+			"end_line":      12,
+			"new_content":   "x",
+			"dry_run":       true,
+		})
+
+		_, err := mcputil.GetToolResult[mcputil.PreviewResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for invalid lines")
+		require.Error(t, err, "Should have error")
+		assert.Contains(t, err.Error(), "line", "Error should come from the tool's own validation")
+	})
+}
@@ -0,0 +1,120 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ReadBinaryFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ReadBinaryFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "read_binary_file",
+			Description: "Read a file's exact bytes base64-encoded, with its size and SHA-256 hash, for inspecting or transferring non-text files such as images. Files larger than the configured max_file_size return only their leading bytes and set truncated; the hash always covers the whole file",
+			QuickHelp:   "Read a file's bytes as base64",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to read"),
+			},
+		}),
+	})
+}
+
+// ReadBinaryFileTool returns the content of a file base64-encoded.
+type ReadBinaryFileTool struct {
+	*mcputil.ToolBase
+}
+
+// BinaryFileResult holds the base64-encoded content of a file.
+type BinaryFileResult struct {
+	Path          string `json:"path"`
+	Size          int64  `json:"size"`           // Size of the whole file in bytes
+	BytesReturned int    `json:"bytes_returned"` // Number of file bytes in ContentBase64
+	Truncated     bool   `json:"truncated"`      // True when the file exceeds the max file size
+	SHA256        string `json:"sha256"`         // Hash of the whole file, not just the bytes returned
+	ContentBase64 string `json:"content_base64"`
+}
+
+// Handle processes the read_binary_file tool request and returns the file's bytes.
+func (t *ReadBinaryFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var file BinaryFileResult
+
+	logger.Info("Tool called", "tool", "read_binary_file")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "read_binary_file", "path", path)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	file, err = readBinaryFile(path, MaxFileSize())
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "read_binary_file",
+		"path", path,
+		"size", file.Size,
+		"truncated", file.Truncated)
+
+	result = mcputil.NewToolResultJSON(file)
+
+end:
+	return result, err
+}
+
+// readBinaryFile reads up to limit bytes of path and hashes the whole file.
+func readBinaryFile(path string, limit int64) (file BinaryFileResult, err error) {
+	var f *os.File
+	var info os.FileInfo
+	var buf []byte
+	var n int
+
+	f, err = os.Open(path)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(f)
+
+	info, err = f.Stat()
+	if err != nil {
+		goto end
+	}
+	if info.IsDir() {
+		err = fmt.Errorf("%s is a directory", path)
+		goto end
+	}
+
+	buf = make([]byte, min(info.Size(), limit))
+	n, err = io.ReadFull(f, buf)
+	if err != nil {
+		err = fmt.Errorf("failed to read %s: %v", path, err)
+		goto end
+	}
+
+	file = BinaryFileResult{
+		Path:          path,
+		Size:          info.Size(),
+		BytesReturned: n,
+		Truncated:     info.Size() > limit,
+		ContentBase64: base64.StdEncoding.EncodeToString(buf[:n]),
+	}
+
+	file.SHA256, err = hashFile(path)
+
+end:
+	return file, err
+}
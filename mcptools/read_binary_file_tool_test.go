@@ -0,0 +1,132 @@
+package mcptools_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ReadBinaryFileDirPrefix = "read-binary-file-tool-test"
+
+type readBinaryFileResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedContent  []byte
+	ExpectedSize     int64
+	ExpectedHash     []byte
+	ExpectTruncated  bool
+}
+
+func requireReadBinaryFileResult(t *testing.T, result *mcptools.BinaryFileResult, err error, opts readBinaryFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	content, err := base64.StdEncoding.DecodeString(result.ContentBase64)
+	require.NoError(t, err, "Content should be valid base64")
+
+	sum := sha256.Sum256(opts.ExpectedHash)
+	assert.Equal(t, opts.ExpectedContent, content, "Decoded content should match")
+	assert.Equal(t, len(opts.ExpectedContent), result.BytesReturned, "Bytes returned should match")
+	assert.Equal(t, opts.ExpectedSize, result.Size, "Size should match")
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.SHA256, "Hash should cover the whole file")
+	assert.Equal(t, opts.ExpectTruncated, result.Truncated, "Truncated should match")
+}
+
+func TestReadBinaryFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("read_binary_file")
+	require.NotNil(t, tool, "read_binary_file tool should be registered")
+
+	// Bytes a text round trip would mangle: NUL, invalid UTF-8 and CRLF
+	payload := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff, 0xfe}
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	t.Run("BinaryFile_ShouldReturnExactBytes", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("binary-project", nil)
+		ff := pf.AddFileFixture("logo.png", &fsfix.FileFixtureArgs{Content: string(payload)})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.BinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading binary file")
+		requireReadBinaryFileResult(t, result, err, readBinaryFileResultOpts{
+			ExpectedContent: payload,
+			ExpectedSize:    int64(len(payload)),
+			ExpectedHash:    payload,
+		})
+	})
+
+	t.Run("OversizedFile_ShouldTruncate", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("binary-project", nil)
+		ff := pf.AddFileFixture("logo.png", &fsfix.FileFixtureArgs{Content: string(payload)})
+		setup(t, tf)
+
+		require.NoError(t, mcptools.SetMaxFileSize(4))
+		defer func() {
+			require.NoError(t, mcptools.SetMaxFileSize(0))
+		}()
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.BinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading oversized file")
+		requireReadBinaryFileResult(t, result, err, readBinaryFileResultOpts{
+			ExpectedContent: payload[:4],
+			ExpectedSize:    int64(len(payload)),
+			ExpectedHash:    payload,
+			ExpectTruncated: true,
+		})
+	})
+
+	t.Run("Directory_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+		})
+
+		result, err := mcputil.GetToolResult[mcptools.BinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for a directory")
+		requireReadBinaryFileResult(t, result, err, readBinaryFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "is a directory",
+		})
+	})
+}
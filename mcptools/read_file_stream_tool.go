@@ -0,0 +1,190 @@
+package mcptools
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ReadFileStreamTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ReadFileStreamTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "read_file_stream",
+			Description: "Read a file sequentially in bounded chunks. Pass the returned next_offset back as offset to fetch the next chunk until has_more is false, so files of any size can be read without exceeding response limits",
+			QuickHelp:   "Read a large file in chunks",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to read"),
+				OffsetProperty,
+				ChunkSizeProperty,
+			},
+		}),
+	})
+}
+
+// ReadFileStreamTool reads a file one bounded chunk at a time.
+type ReadFileStreamTool struct {
+	*mcputil.ToolBase
+}
+
+// FileChunkResult is a single chunk of a file read by read_file_stream.
+type FileChunkResult struct {
+	Path       string `json:"path"`
+	Offset     int64  `json:"offset"`      // Byte offset of the first byte in Content
+	NextOffset int64  `json:"next_offset"` // Offset to pass to fetch the next chunk
+	ChunkSize  int    `json:"chunk_size"`  // Number of file bytes in this chunk
+	TotalSize  int64  `json:"total_size"`  // Size of the whole file in bytes
+	HasMore    bool   `json:"has_more"`    // True until the chunk ending at total_size is returned
+	Encoding   string `json:"encoding"`    // "utf-8" for text, "base64" for binary content
+	Content    string `json:"content"`
+}
+
+// Handle processes the read_file_stream tool request and returns the requested chunk.
+func (t *ReadFileStreamTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var offset int
+	var chunkSize int
+	var chunk FileChunkResult
+
+	logger.Info("Tool called", "tool", "read_file_stream")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	offset, err = OffsetProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if offset < 0 {
+		err = fmt.Errorf("offset must not be negative, got %d", offset)
+		goto end
+	}
+
+	chunkSize, err = ChunkSizeProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if chunkSize <= 0 {
+		err = fmt.Errorf("chunk_size must be positive, got %d", chunkSize)
+		goto end
+	}
+	// Keep every response within the size budget regardless of what is requested
+	chunkSize = min(chunkSize, TargetCharLimit)
+
+	logger.Info("Tool arguments parsed",
+		"tool", "read_file_stream",
+		"path", path,
+		"offset", offset,
+		"chunk_size", chunkSize)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	chunk, err = readFileChunk(path, int64(offset), chunkSize)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "read_file_stream",
+		"offset", chunk.Offset,
+		"next_offset", chunk.NextOffset,
+		"total_size", chunk.TotalSize,
+		"has_more", chunk.HasMore)
+
+	result = mcputil.NewToolResultJSON(chunk)
+
+end:
+	return result, err
+}
+
+// readFileChunk reads up to chunkSize bytes of path starting at offset. Text
+// chunks that would end partway through a UTF-8 character end before it
+// instead, so each chunk decodes on its own; content that is not valid
+// UTF-8 is returned base64-encoded in a correspondingly smaller chunk.
+func readFileChunk(path string, offset int64, chunkSize int) (chunk FileChunkResult, err error) {
+	var file *os.File
+	var info os.FileInfo
+	var buf []byte
+	var n int
+
+	file, err = os.Open(path)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(file)
+
+	info, err = file.Stat()
+	if err != nil {
+		goto end
+	}
+	if info.IsDir() {
+		err = fmt.Errorf("%s is a directory", path)
+		goto end
+	}
+	if offset > info.Size() {
+		err = fmt.Errorf("offset %d is beyond the end of %s (%d bytes)", offset, path, info.Size())
+		goto end
+	}
+
+	buf = make([]byte, min(int64(chunkSize), info.Size()-offset))
+	n, err = file.ReadAt(buf, offset)
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if err != nil {
+		goto end
+	}
+	buf = buf[:n]
+
+	chunk = FileChunkResult{
+		Path:      path,
+		Offset:    offset,
+		TotalSize: info.Size(),
+		Encoding:  "utf-8",
+	}
+	if offset+int64(n) < info.Size() {
+		buf = trimPartialRune(buf)
+	}
+	if utf8.Valid(buf) {
+		chunk.Content = string(buf)
+	} else {
+		// Base64 grows content by a third, so send fewer bytes to stay in budget
+		buf = buf[:max(1, min(len(buf), chunkSize*3/4))]
+		chunk.Encoding = "base64"
+		chunk.Content = base64.StdEncoding.EncodeToString(buf)
+	}
+	chunk.ChunkSize = len(buf)
+	chunk.NextOffset = offset + int64(len(buf))
+	chunk.HasMore = chunk.NextOffset < chunk.TotalSize
+
+end:
+	return chunk, err
+}
+
+// trimPartialRune drops a UTF-8 character cut off at the end of buf, if any.
+// Bytes that could never start a valid character are left alone.
+func trimPartialRune(buf []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(buf); i++ {
+		b := buf[len(buf)-i]
+		if !utf8.RuneStart(b) {
+			continue
+		}
+		if !utf8.FullRune(buf[len(buf)-i:]) && i < len(buf) {
+			buf = buf[:len(buf)-i]
+		}
+		break
+	}
+	return buf
+}
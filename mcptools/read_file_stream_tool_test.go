@@ -0,0 +1,185 @@
+package mcptools_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ReadFileStreamDirPrefix = "read-file-stream-tool-test"
+
+type fileChunkResultOpts struct {
+	ExpectError        bool
+	ExpectedErrorMsg   string
+	ExpectedContent    string
+	ExpectedEncoding   string
+	ExpectedNextOffset int64
+	ExpectedTotalSize  int64
+	ExpectHasMore      bool
+}
+
+func requireFileChunkResult(t *testing.T, result *mcptools.FileChunkResult, err error, opts fileChunkResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	encoding := opts.ExpectedEncoding
+	if encoding == "" {
+		encoding = "utf-8"
+	}
+	assert.Equal(t, encoding, result.Encoding, "Encoding should match")
+	assert.Equal(t, opts.ExpectedContent, result.Content, "Content should match")
+	assert.Equal(t, opts.ExpectedNextOffset, result.NextOffset, "Next offset should match")
+	assert.Equal(t, opts.ExpectedTotalSize, result.TotalSize, "Total size should match")
+	assert.Equal(t, opts.ExpectHasMore, result.HasMore, "Has more flag should match")
+	assert.Equal(t, result.NextOffset-result.Offset, int64(result.ChunkSize), "Chunk size should match bytes consumed")
+}
+
+func TestReadFileStreamTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("read_file_stream")
+	require.NotNil(t, tool, "read_file_stream tool should be registered")
+
+	setup := func(t *testing.T, content string) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(ReadFileStreamDirPrefix)
+		ff := tf.AddFileFixture("large.txt", &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	readChunk := func(params mcputil.Params) (*mcptools.FileChunkResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[mcptools.FileChunkResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading chunk")
+	}
+
+	t.Run("ReadAllChunks", func(t *testing.T) {
+		content := strings.Repeat("0123456789", 5)
+		tf, ff := setup(t, content)
+		defer tf.Cleanup()
+
+		var sb strings.Builder
+		var offset int64
+		var chunks int
+		for hasMore := true; hasMore; chunks++ {
+			require.Less(t, chunks, 10, "Should finish in a bounded number of chunks")
+			result, err := readChunk(mcputil.Params{
+				"path":       ff.Filepath,
+				"offset":     int(offset),
+				"chunk_size": 20,
+			})
+			require.NoError(t, err, "Should read chunk at offset %d", offset)
+			sb.WriteString(result.Content)
+			offset = result.NextOffset
+			hasMore = result.HasMore
+		}
+		assert.Equal(t, content, sb.String(), "Chunks should reassemble the file")
+		assert.Equal(t, int64(len(content)), offset, "Should end at the file size")
+		assert.Equal(t, 3, chunks, "Should read three chunks")
+	})
+
+	t.Run("DefaultChunk_ShouldReadSmallFile", func(t *testing.T) {
+		tf, ff := setup(t, "small file\n")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path": ff.Filepath,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectedContent:    "small file\n",
+			ExpectedNextOffset: 11,
+			ExpectedTotalSize:  11,
+		})
+	})
+
+	t.Run("MultibyteBoundary_ShouldNotSplitCharacter", func(t *testing.T) {
+		tf, ff := setup(t, "ab€cd")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path":       ff.Filepath,
+			"chunk_size": 3,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectedContent:    "ab",
+			ExpectedNextOffset: 2,
+			ExpectedTotalSize:  7,
+			ExpectHasMore:      true,
+		})
+	})
+
+	t.Run("Binary_ShouldBase64Encode", func(t *testing.T) {
+		tf, ff := setup(t, "\xff\xfe\x00\x01")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path": ff.Filepath,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectedContent:    base64.StdEncoding.EncodeToString([]byte("\xff\xfe\x00\x01")),
+			ExpectedEncoding:   "base64",
+			ExpectedNextOffset: 4,
+			ExpectedTotalSize:  4,
+		})
+	})
+
+	t.Run("OffsetAtEnd_ShouldReturnEmpty", func(t *testing.T) {
+		tf, ff := setup(t, "done")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path":   ff.Filepath,
+			"offset": 4,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectedNextOffset: 4,
+			ExpectedTotalSize:  4,
+		})
+	})
+
+	t.Run("OffsetBeyondEnd_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "done")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path":   ff.Filepath,
+			"offset": 5,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "beyond the end",
+		})
+	})
+
+	t.Run("NegativeOffset_ShouldError", func(t *testing.T) {
+		tf, ff := setup(t, "done")
+		defer tf.Cleanup()
+
+		result, err := readChunk(mcputil.Params{
+			"path":   ff.Filepath,
+			"offset": -1,
+		})
+		requireFileChunkResult(t, result, err, fileChunkResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "must not be negative",
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mikeschinkel/scout-mcp/mcputil"
 )
@@ -25,6 +26,11 @@ func init() {
 				RecursiveProperty,
 				PatternProperty.Description("Filename pattern to match (case-insensitive substring) - applies to directories only"),
 				MaxFilesProperty,
+				StartLineProperty.Description("First line of each file to return, 1-based and inclusive"),
+				EndLineProperty.Description("Last line of each file to return, inclusive (default: last line)"),
+				StartByteProperty,
+				ReadMaxBytesProperty,
+				MaxResponseBytesProperty,
 			},
 		}),
 	})
@@ -42,6 +48,11 @@ func (t *ReadFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (resu
 	var recursive bool
 	var pattern string
 	var maxFiles int
+	var contentRange ReadRange
+	var maxResponseBytes int
+	var omittedFiles int
+	var omittedBytes int64
+	var message string
 	var fileResults []FileReadResult
 	var totalSize int64
 	var errs []error
@@ -80,37 +91,73 @@ func (t *ReadFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (resu
 		goto end
 	}
 
+	contentRange, err = getReadRange(req)
+	if err != nil {
+		goto end
+	}
+
+	maxResponseBytes, err = MaxResponseBytesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxResponseBytes < 0 {
+		err = fmt.Errorf("max_response_bytes must not be negative, got %d", maxResponseBytes)
+		goto end
+	}
+
 	logger.Info("Tool arguments parsed",
 		"tool", "read_files",
 		"paths", paths,
 		"extensions", extensions,
 		"recursive", recursive,
 		"pattern", pattern,
-		"max_files", maxFiles)
+		"max_files", maxFiles,
+		"range", contentRange,
+		"max_response_bytes", maxResponseBytes)
 
 	fileResults, totalSize, errs, err = t.readMultiplePaths(paths, ReadFilesOptions{
 		Extensions: extensions,
 		Recursive:  recursive,
 		Pattern:    pattern,
 		MaxFiles:   maxFiles,
+		Range:      contentRange,
+
+		MaxResponseBytes: maxResponseBytes,
 	})
 	if err != nil {
 		goto end
 	}
 
-	logger.Info("Tool completed", "tool", "read_files", "files_read", len(fileResults), "total_size", totalSize)
+	for _, fr := range fileResults {
+		if fr.Omitted {
+			omittedFiles++
+			omittedBytes += fr.Size
+		}
+	}
+	if omittedFiles > 0 {
+		message = fmt.Sprintf(
+			"File contents limited to %d bytes by max_response_bytes; %d of %d files (%d bytes) are listed without their content. "+
+				"Read them with a further call, or narrow the request with extensions, pattern or a line range.",
+			maxResponseBytes, omittedFiles, len(fileResults), omittedBytes)
+	}
+
+	logger.Info("Tool completed", "tool", "read_files", "files_read", len(fileResults), "total_size", totalSize, "omitted_files", omittedFiles)
 
 	result = mcputil.NewToolResultJSON(map[string]any{
-		"files":       fileResults,
-		"total_files": len(fileResults),
-		"total_size":  totalSize,
-		"paths":       paths,
-		"extensions":  extensions,
-		"recursive":   recursive,
-		"pattern":     pattern,
-		"max_files":   maxFiles,
-		"truncated":   len(fileResults) >= maxFiles,
-		"errors":      errorsStringSlice(errs),
+		"files":              fileResults,
+		"total_files":        len(fileResults),
+		"total_size":         totalSize,
+		"paths":              paths,
+		"extensions":         extensions,
+		"recursive":          recursive,
+		"pattern":            pattern,
+		"max_files":          maxFiles,
+		"max_response_bytes": maxResponseBytes,
+		"truncated":          len(fileResults) >= maxFiles || omittedFiles > 0,
+		"omitted_files":      omittedFiles,
+		"omitted_bytes":      omittedBytes,
+		"message":            message,
+		"errors":             errorsStringSlice(errs),
 	})
 
 end:
@@ -122,14 +169,130 @@ type ReadFilesOptions struct {
 	Recursive  bool
 	Pattern    string
 	MaxFiles   int
+	Range      ReadRange // Part of each file to return
+
+	// MaxResponseBytes, when not zero, is the total size of the file contents
+	// to return. The file that would exceed it and every file after it are
+	// returned Omitted, without their content.
+	MaxResponseBytes int
+}
+
+// ReadRange selects the part of each file read_files returns: either lines
+// StartLine to EndLine, or up to MaxBytes bytes from StartByte. The zero
+// value selects the whole file.
+type ReadRange struct {
+	StartLine int // First line, 1-based; 0 when reading by byte
+	EndLine   int // Last line, inclusive; 0 for the last line of the file
+	StartByte int // Byte offset to start from
+	MaxBytes  int // Maximum number of bytes; 0 for the rest of the file
 }
 
 type FileReadResult struct {
-	Path    string `json:"path"`
-	Name    string `json:"name"`
-	Content string `json:"content"`
-	Size    int64  `json:"size"`
-	Error   string `json:"error,omitempty"`
+	Path       string `json:"path"`
+	Name       string `json:"name"`
+	Content    string `json:"content"`
+	Size       int64  `json:"size"`                  // Size of the whole file in bytes
+	Truncated  bool   `json:"truncated,omitempty"`   // Content is only part of the file
+	TotalLines int    `json:"total_lines,omitempty"` // Lines in the whole file, when reading a line range
+	Omitted    bool   `json:"omitted,omitempty"`     // Content left out to keep within max_response_bytes
+	Error      string `json:"error,omitempty"`
+}
+
+// getReadRange returns the line or byte range requested, rejecting a
+// request for both or a range that is out of order.
+func getReadRange(req mcputil.ToolRequest) (r ReadRange, err error) {
+	r.StartLine, err = StartLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("start_line must be a valid number: %w", err)
+		goto end
+	}
+
+	r.EndLine, err = EndLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("end_line must be a valid number: %w", err)
+		goto end
+	}
+
+	r.StartByte, err = StartByteProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	r.MaxBytes, err = ReadMaxBytesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+
+	if r.StartLine < 0 || r.EndLine < 0 || r.StartByte < 0 || r.MaxBytes < 0 {
+		err = fmt.Errorf("start_line, end_line, start_byte and max_bytes must not be negative")
+		goto end
+	}
+
+	if (r.StartLine > 0 || r.EndLine > 0) && (r.StartByte > 0 || r.MaxBytes > 0) {
+		err = fmt.Errorf("specify either start_line/end_line or start_byte/max_bytes, not both")
+		goto end
+	}
+
+	if r.StartLine == 0 && r.EndLine > 0 {
+		r.StartLine = 1
+	}
+
+	if r.EndLine > 0 && r.EndLine < r.StartLine {
+		err = fmt.Errorf("end_line (%d) must be >= start_line (%d)", r.EndLine, r.StartLine)
+		goto end
+	}
+
+end:
+	return r, err
+}
+
+// slice returns the part of content that r selects, whether that is less
+// than all of it, and, for a line range, the number of lines in content. A
+// range beyond the end of content selects nothing, and a byte range is
+// narrowed to whole UTF-8 characters.
+func (r ReadRange) slice(content []byte) (part []byte, truncated bool, totalLines int) {
+	var start, end int
+	var lineStarts []int
+
+	switch {
+	case r.StartLine > 0:
+		// Offsets of the start of each line, plus the end of the content
+		lineStarts = []int{0}
+		for i, b := range content {
+			if b == '\n' && i+1 < len(content) {
+				lineStarts = append(lineStarts, i+1)
+			}
+		}
+		totalLines = len(lineStarts)
+		if len(content) == 0 {
+			totalLines = 0
+		}
+		lineStarts = append(lineStarts, len(content))
+
+		start = lineStarts[min(r.StartLine-1, totalLines)]
+		end = len(content)
+		if r.EndLine > 0 && r.EndLine < totalLines {
+			end = lineStarts[r.EndLine]
+		}
+	case r.StartByte > 0 || r.MaxBytes > 0:
+		start = min(r.StartByte, len(content))
+		end = len(content)
+		if r.MaxBytes > 0 {
+			end = min(start+r.MaxBytes, len(content))
+		}
+		for start < end && !utf8.RuneStart(content[start]) {
+			start++
+		}
+		for end > start && end < len(content) && !utf8.RuneStart(content[end]) {
+			end--
+		}
+	default:
+		end = len(content)
+	}
+
+	part = content[start:end]
+	truncated = len(part) < len(content)
+	return part, truncated, totalLines
 }
 
 func (t *ReadFilesTool) readPath(path string, opts ReadFilesOptions) (entries []string, err error) {
@@ -169,6 +332,8 @@ end:
 func (t *ReadFilesTool) readMultiplePaths(paths []string, opts ReadFilesOptions) (results []FileReadResult, totalSize int64, errs []error, err error) {
 	var filesToRead, entries []string
 	var path string
+	var contentBytes int
+	var overBudget bool
 
 	// First pass: collect all files to read
 	for _, path = range paths {
@@ -186,11 +351,14 @@ func (t *ReadFilesTool) readMultiplePaths(paths []string, opts ReadFilesOptions)
 		}
 	}
 
-	// Second pass: read all collected files
+	// Second pass: read all collected files, until their contents exceed
+	// the response budget
 	results = make([]FileReadResult, 0, len(filesToRead))
 	for _, filePath := range filesToRead {
 		var content []byte
 		var fileInfo os.FileInfo
+		var truncated bool
+		var totalLines int
 		var err error
 
 		fileInfo, err = os.Stat(filePath)
@@ -204,23 +372,40 @@ func (t *ReadFilesTool) readMultiplePaths(paths []string, opts ReadFilesOptions)
 			continue
 		}
 
-		content, err = os.ReadFile(filePath)
-		if err != nil {
+		if !overBudget {
+			content, err = os.ReadFile(filePath)
+			if err != nil {
+				results = append(results, FileReadResult{
+					Path:  filePath,
+					Name:  filepath.Base(filePath),
+					Size:  fileInfo.Size(),
+					Error: fmt.Sprintf("cannot read file: %v", err),
+				})
+				err = nil
+				continue
+			}
+			content, truncated, totalLines = opts.Range.slice(content)
+			overBudget = opts.MaxResponseBytes > 0 && contentBytes+len(content) > opts.MaxResponseBytes
+		}
+
+		if overBudget {
 			results = append(results, FileReadResult{
-				Path:  filePath,
-				Name:  filepath.Base(filePath),
-				Size:  fileInfo.Size(),
-				Error: fmt.Sprintf("cannot read file: %v", err),
+				Path:    filePath,
+				Name:    filepath.Base(filePath),
+				Size:    fileInfo.Size(),
+				Omitted: true,
 			})
-			err = nil
 			continue
 		}
+		contentBytes += len(content)
 
 		results = append(results, FileReadResult{
-			Path:    filePath,
-			Name:    filepath.Base(filePath),
-			Content: string(content),
-			Size:    fileInfo.Size(),
+			Path:       filePath,
+			Name:       filepath.Base(filePath),
+			Content:    string(content),
+			Size:       fileInfo.Size(),
+			Truncated:  truncated,
+			TotalLines: totalLines,
 		})
 
 		totalSize += fileInfo.Size()
@@ -15,14 +15,20 @@ const ReadFilesDirPrefix = "read-files-tool-test"
 // Read files tool result type
 type ReadFilesResult struct {
 	Files []struct {
-		Path    string `json:"path"`
-		Name    string `json:"name"`
-		Size    int64  `json:"size"`
-		Content string `json:"content"`
+		Path       string `json:"path"`
+		Name       string `json:"name"`
+		Size       int64  `json:"size"`
+		Content    string `json:"content"`
+		Truncated  bool   `json:"truncated"`
+		TotalLines int    `json:"total_lines"`
+		Omitted    bool   `json:"omitted"`
 	} `json:"files"`
-	TotalFiles int    `json:"total_files"`
-	Summary    string `json:"summary"`
-	Errors     []any  `json:"errors,omitempty"`
+	TotalFiles   int    `json:"total_files"`
+	Truncated    bool   `json:"truncated"`
+	OmittedFiles int    `json:"omitted_files"`
+	OmittedBytes int64  `json:"omitted_bytes"`
+	Summary      string `json:"summary"`
+	Errors       []any  `json:"errors,omitempty"`
 }
 
 type readFilesResultOpts struct {
@@ -221,4 +227,144 @@ func TestReadFilesTool(t *testing.T) {
 			ExpectedErrorMsg:   "no such file",
 		})
 	})
+
+	t.Run("LineRange_ShouldReturnRequestedLinesOfEachFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("line-range-project", nil)
+		longFile := pf.AddFileFixture("long.txt", &fsfix.FileFixtureArgs{
+			Content: "one\ntwo\nthree\nfour\nfive\n",
+		})
+		shortFile := pf.AddFileFixture("short.txt", &fsfix.FileFixtureArgs{
+			Content: "alpha\nbeta\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         []any{longFile.Filepath, shortFile.Filepath},
+			"start_line":    2,
+			"end_line":      3,
+		})
+
+		result, err := mcputil.GetToolResult[ReadFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading line ranges")
+
+		requireReadFilesResult(t, result, err, readFilesResultOpts{
+			ExpectFiles: 2,
+		})
+		assert.Equal(t, "two\nthree\n", result.Files[0].Content, "Should return lines 2-3 of the long file")
+		assert.True(t, result.Files[0].Truncated, "Long file should be truncated")
+		assert.Equal(t, 5, result.Files[0].TotalLines, "Should report the long file's line count")
+		assert.Equal(t, int64(24), result.Files[0].Size, "Should report the long file's full size")
+		assert.Equal(t, "beta\n", result.Files[1].Content, "Should return what the short file has of the range")
+		assert.True(t, result.Files[1].Truncated, "Short file should be truncated")
+		assert.Equal(t, 2, result.Files[1].TotalLines, "Should report the short file's line count")
+	})
+
+	t.Run("ByteRange_ShouldReturnRequestedBytes", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("byte-range-project", nil)
+		testFile := pf.AddFileFixture("bytes.txt", &fsfix.FileFixtureArgs{
+			Content: "0123456789",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"paths":         []any{testFile.Filepath},
+			"start_byte":    3,
+			"max_bytes":     4,
+		})
+
+		result, err := mcputil.GetToolResult[ReadFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading a byte range")
+
+		requireReadFilesResult(t, result, err, readFilesResultOpts{
+			ExpectFiles:     1,
+			ExpectedContent: "3456",
+		})
+		assert.True(t, result.Files[0].Truncated, "File should be truncated")
+		assert.Equal(t, int64(10), result.Files[0].Size, "Should report the file's full size")
+	})
+
+	t.Run("InvalidRange_ShouldReturnError", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			params           mcputil.Params
+			expectedErrorMsg string
+		}{
+			{name: "EndBeforeStart", params: mcputil.Params{"start_line": 5, "end_line": 2}, expectedErrorMsg: "end_line (2) must be >= start_line (5)"},
+			{name: "LinesAndBytes", params: mcputil.Params{"start_line": 1, "max_bytes": 10}, expectedErrorMsg: "not both"},
+			{name: "Negative", params: mcputil.Params{"start_byte": -1}, expectedErrorMsg: "must not be negative"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				params := mcputil.Params{
+					"session_token": testToken,
+					"paths":         []any{"/tmp"},
+				}
+				for k, v := range tt.params {
+					params[k] = v
+				}
+
+				result, err := mcputil.GetToolResult[ReadFilesResult](mcputil.CallResult(mcputil.CallTool(tool, mcputil.NewMockRequest(params))), "Should reject the range")
+
+				requireReadFilesResult(t, result, err, readFilesResultOpts{
+					ExpectError:      true,
+					ExpectedErrorMsg: tt.expectedErrorMsg,
+				})
+			})
+		}
+	})
+
+	t.Run("MaxResponseBytes_ShouldListFilesOverBudgetWithoutContent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReadFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("budget-project", nil)
+		file1 := pf.AddFileFixture("a.txt", &fsfix.FileFixtureArgs{
+			Content: "0123456789",
+		})
+		file2 := pf.AddFileFixture("b.txt", &fsfix.FileFixtureArgs{
+			Content: "abcdefghij",
+		})
+		file3 := pf.AddFileFixture("c.txt", &fsfix.FileFixtureArgs{
+			Content: "xyz",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":      testToken,
+			"paths":              []any{file1.Filepath, file2.Filepath, file3.Filepath},
+			"max_response_bytes": 15,
+		})
+
+		result, err := mcputil.GetToolResult[ReadFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error reading within a response budget")
+
+		requireReadFilesResult(t, result, err, readFilesResultOpts{
+			ExpectFiles:     3,
+			ExpectedContent: "0123456789",
+		})
+		assert.True(t, result.Truncated, "Response should be truncated")
+		assert.Equal(t, 2, result.OmittedFiles, "Files after the budget is exceeded should be omitted")
+		assert.Equal(t, int64(13), result.OmittedBytes, "Should report the size of the omitted files")
+		for _, file := range result.Files[1:] {
+			assert.True(t, file.Omitted, "%s should be omitted", file.Name)
+			assert.Empty(t, file.Content, "%s should have no content", file.Name)
+		}
+	})
 }
@@ -0,0 +1,113 @@
+package mcptools
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Package-level switch for log redaction, on unless the config disables it
+var (
+	logRedaction      = true
+	logRedactionMutex sync.Mutex
+)
+
+// SetLogRedaction sets whether RedactingHandler masks secrets in log records.
+func SetLogRedaction(enabled bool) {
+	logRedactionMutex.Lock()
+	logRedaction = enabled
+	logRedactionMutex.Unlock()
+}
+
+// logRedactionEnabled returns the setting made by SetLogRedaction.
+func logRedactionEnabled() bool {
+	logRedactionMutex.Lock()
+	defer logRedactionMutex.Unlock()
+	return logRedaction
+}
+
+var _ slog.Handler = (*RedactingHandler)(nil)
+
+// RedactingHandler is a slog.Handler that masks secrets in each record's
+// message and string attributes with RedactSecrets before passing the record
+// to another handler, so file content that reaches a log does not leak the
+// secrets scan_secrets would report.
+type RedactingHandler struct {
+	handler slog.Handler
+}
+
+// NewRedactingHandler returns a RedactingHandler that writes to handler.
+func NewRedactingHandler(handler slog.Handler) *RedactingHandler {
+	return &RedactingHandler{handler: handler}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle redacts the record and passes it to the wrapped handler.
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	var redacted slog.Record
+
+	if !logRedactionEnabled() {
+		return h.handler.Handle(ctx, r)
+	}
+	redacted = slog.NewRecord(r.Time, r.Level, RedactSecrets(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+// WithAttrs returns a RedactingHandler whose wrapped handler has the attrs,
+// redacted.
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var redacted []slog.Attr
+
+	if !logRedactionEnabled() {
+		return &RedactingHandler{handler: h.handler.WithAttrs(attrs)}
+	}
+	redacted = make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &RedactingHandler{handler: h.handler.WithAttrs(redacted)}
+}
+
+// WithGroup returns a RedactingHandler whose wrapped handler has the group.
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{handler: h.handler.WithGroup(name)}
+}
+
+// redactAttr returns a with secrets masked in its string values, including
+// those of groups, errors and string slices. Other values are unchanged.
+func redactAttr(a slog.Attr) slog.Attr {
+	var attrs []slog.Attr
+	var strs []string
+
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(RedactSecrets(a.Value.String()))
+	case slog.KindGroup:
+		attrs = make([]slog.Attr, len(a.Value.Group()))
+		for i, ga := range a.Value.Group() {
+			attrs[i] = redactAttr(ga)
+		}
+		a.Value = slog.GroupValue(attrs...)
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			a.Value = slog.StringValue(RedactSecrets(v.Error()))
+		case []string:
+			strs = make([]string, len(v))
+			for i, s := range v {
+				strs[i] = RedactSecrets(s)
+			}
+			a.Value = slog.AnyValue(strs)
+		}
+	}
+	return a
+}
@@ -0,0 +1,84 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*RefreshSessionTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&RefreshSessionTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "refresh_session",
+			Description: "Extend a valid, unexpired session token so it expires later, keeping the session's changed files, file locks, confirmations and edit backups, unlike calling start_session again. With rotate_token the session gets a new token and the old one stops working",
+			QuickHelp:   "Extend the current session's token expiry",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				SessionTTLMinutesProperty.Description("Minutes from now until the session token expires, up to the server's maximum (default: the server's session TTL, normally 24 hours)"),
+				RotateTokenProperty,
+			},
+		}),
+	})
+}
+
+// RefreshSessionTool extends the expiry of the current session.
+type RefreshSessionTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the refresh_session tool request and extends the session,
+// rotating its token if requested.
+func (t *RefreshSessionTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var ttl time.Duration
+	var rotate bool
+	var session *mcputil.Session
+
+	logger.Info("Tool called", "tool", "refresh_session")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	ttl, err = mcputil.SessionTTLArg(req)
+	if err != nil {
+		goto end
+	}
+
+	rotate, err = RotateTokenProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "refresh_session",
+		"session_id", mcputil.SessionID(token),
+		"ttl", ttl,
+		"rotate_token", rotate)
+
+	session, err = mcputil.RefreshSession(token, ttl, rotate)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "refresh_session",
+		"session_id", mcputil.SessionID(session.Token),
+		"expires_at", session.ExpiresAt,
+		"rotated", rotate)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":          true,
+		"session_token":    session.Token,
+		"token_expires_at": session.ExpiresAt,
+		"rotated":          rotate,
+		"message":          fmt.Sprintf("Session extended until %s", session.ExpiresAt.Format(time.RFC3339)),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,159 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Refresh session tool result type
+type RefreshSessionResult struct {
+	Success        bool      `json:"success"`
+	SessionToken   string    `json:"session_token"`
+	TokenExpiresAt time.Time `json:"token_expires_at"`
+	Rotated        bool      `json:"rotated"`
+	Message        string    `json:"message"`
+}
+
+type refreshSessionResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectRotated    bool
+	ExpiresIn        time.Duration
+}
+
+func requireRefreshSessionResult(t *testing.T, result *RefreshSessionResult, err error, token string, opts refreshSessionResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should be successful")
+	assert.Equal(t, opts.ExpectRotated, result.Rotated, "Rotated should match")
+	if opts.ExpectRotated {
+		assert.NotEqual(t, token, result.SessionToken, "Token should be rotated")
+	} else {
+		assert.Equal(t, token, result.SessionToken, "Token should be kept")
+	}
+	if opts.ExpiresIn != 0 {
+		assert.WithinDuration(t, time.Now().Add(opts.ExpiresIn), result.TokenExpiresAt, time.Minute, "Token should expire after the requested TTL")
+	}
+}
+
+func TestRefreshSessionTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("refresh_session")
+	require.NotNil(t, tool, "refresh_session tool should be registered")
+
+	newSession := func(t *testing.T, ttl time.Duration) string {
+		session := mcputil.NewSession()
+		require.NoError(t, session.InitializeWithTTL(ttl), "Should create session")
+		return session.Token
+	}
+
+	refresh := func(params mcputil.Params) (*RefreshSessionResult, error) {
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[RefreshSessionResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error refreshing session")
+	}
+
+	t.Run("ExtendSession_ShouldSetNewExpiry", func(t *testing.T) {
+		token := newSession(t, time.Hour)
+		defer mcputil.ClearSession(token)
+
+		result, err := refresh(mcputil.Params{
+			"session_token": token,
+			"ttl_minutes":   180,
+		})
+		requireRefreshSessionResult(t, result, err, token, refreshSessionResultOpts{
+			ExpiresIn: 3 * time.Hour,
+		})
+
+		session, exists := mcputil.GetSession(token)
+		require.True(t, exists, "Session should still exist")
+		assert.Equal(t, result.TokenExpiresAt.Unix(), session.ExpiresAt.Unix(), "Session should expire when reported")
+	})
+
+	t.Run("RotateToken_ShouldCarryOverSessionState", func(t *testing.T) {
+		dir := t.TempDir()
+		store := scoutcfg.NewFileStore("test-app")
+		store.SetBaseDir(dir)
+		require.NoError(t, mcputil.SetEditBackups(store, 0), "Should enable edit backups")
+		defer func() {
+			_ = mcputil.SetEditBackups(nil, 0)
+		}()
+
+		token := newSession(t, time.Hour)
+		mcputil.RecordFileChange(token, mcputil.UpdatedFileOp, "/tmp/refreshed.go")
+		_, err := mcputil.LockFile(token, "/tmp/refreshed.go", time.Hour)
+		require.NoError(t, err, "Should lock file")
+		backup := "edit-backups/" + mcputil.SessionID(token) + "/file/00000000.json"
+		require.NoError(t, store.Save(backup, &mcputil.EditBackup{Path: "/tmp/refreshed.go"}))
+
+		result, err := refresh(mcputil.Params{
+			"session_token": token,
+			"rotate_token":  true,
+		})
+		requireRefreshSessionResult(t, result, err, token, refreshSessionResultOpts{
+			ExpectRotated: true,
+			ExpiresIn:     mcputil.SessionTTL(),
+		})
+		defer mcputil.ClearSession(result.SessionToken)
+
+		assert.ErrorIs(t, mcputil.ValidateSession(token), mcputil.ErrTokenNotFound, "Old token should stop working")
+		assert.NoError(t, mcputil.ValidateSession(result.SessionToken), "New token should be valid")
+		assert.Equal(t, []string{"/tmp/refreshed.go"}, mcputil.GetChangedFiles(result.SessionToken).Updated, "Changed files should carry over")
+		_, locked := mcputil.LockedByOtherSession(result.SessionToken, "/tmp/refreshed.go")
+		assert.False(t, locked, "Lock should carry over to the new token")
+		assert.False(t, store.Exists(backup), "Edit backups should move from the old token")
+		assert.True(t, store.Exists("edit-backups/"+mcputil.SessionID(result.SessionToken)+"/file/00000000.json"), "Edit backups should move to the new token")
+	})
+
+	t.Run("UnknownToken_ShouldError", func(t *testing.T) {
+		result, err := refresh(mcputil.Params{
+			"session_token": "unknown-session-token",
+		})
+		requireRefreshSessionResult(t, result, err, "", refreshSessionResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid or expired session token",
+		})
+	})
+
+	t.Run("ExpiredToken_ShouldError", func(t *testing.T) {
+		token := newSession(t, time.Millisecond)
+		defer mcputil.ClearSession(token)
+		time.Sleep(5 * time.Millisecond)
+
+		result, err := refresh(mcputil.Params{
+			"session_token": token,
+		})
+		requireRefreshSessionResult(t, result, err, token, refreshSessionResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid or expired session token",
+		})
+	})
+
+	t.Run("TTLOverMaximum_ShouldError", func(t *testing.T) {
+		token := newSession(t, time.Hour)
+		defer mcputil.ClearSession(token)
+
+		result, err := refresh(mcputil.Params{
+			"session_token": token,
+			"ttl_minutes":   int(mcputil.MaxSessionTTL()/time.Minute) + 1,
+		})
+		requireRefreshSessionResult(t, result, err, token, refreshSessionResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "exceeds the maximum",
+		})
+	})
+}
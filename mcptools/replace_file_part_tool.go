@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 	"go/ast"
+	"go/format"
 	"go/parser"
 	"go/token"
+	"slices"
 	"strings"
 
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	_ "github.com/mikeschinkel/scout-mcp/langutil/javascript"
+	_ "github.com/mikeschinkel/scout-mcp/langutil/python"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
 )
 
@@ -18,6 +24,7 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "replace_file_part",
 			Description: "Replace specific language constructs (functions, types, constants) by name using AST parsing",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				RequiredPathProperty,
@@ -25,6 +32,9 @@ func init() {
 				PartTypeProperty.Required(),
 				PartNameProperty.Required(),
 				RequiredNewContentProperty,
+				SkipFormatProperty,
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -36,12 +46,16 @@ type ReplaceFilePartTool struct {
 }
 
 // Handle processes the replace_file_part tool request and replaces language constructs using AST.
-func (t *ReplaceFilePartTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *ReplaceFilePartTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var language string
 	var partType string
 	var partName string
 	var newContent string
+	var skipFormat bool
+	var originalContent, updatedContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "replace_file_part")
 
@@ -70,24 +84,38 @@ func (t *ReplaceFilePartTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
+	skipFormat, err = SkipFormatProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	diffOpts, err = getDiffOptions(req)
+	if err != nil {
+		goto end
+	}
+
 	err = t.validateInputs(language, partType, newContent)
 	if err != nil {
 		goto end
 	}
 
-	err = t.replaceFilePart(filePath, language, partType, partName, newContent)
+	originalContent, updatedContent, err = t.replaceFilePart(ctx, filePath, language, partType, partName, newContent, skipFormat)
 	if err != nil {
 		goto end
 	}
 
-	result = mcputil.NewToolResultJSON(map[string]any{
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
+	fields = map[string]any{
 		"success":   true,
 		"file_path": filePath,
 		"language":  language,
 		"part_type": partType,
 		"part_name": partName,
 		"message":   fmt.Sprintf("Successfully replaced %s '%s' in %s", partType, partName, filePath),
-	})
+	}
+	diffOpts.addDiff(fields, filePath, originalContent, updatedContent)
+	result = mcputil.NewToolResultJSON(fields)
 	logger.Info("Tool completed", "tool", "replace_file_part", "path", filePath, "part_type", partType, "part_name", partName)
 
 end:
@@ -95,12 +123,12 @@ end:
 }
 
 func (t *ReplaceFilePartTool) validateInputs(language, partType, newContent string) (err error) {
-	validGoTypes := []string{"const", "var", "type", "func", "import", "package"}
+	validGoTypes := []string{"const", "var", "type", "field", "method", "func", "func_body", "import", "package"}
 	valid := false
 
-	// Validate language
+	// Languages other than Go are validated by their processor
 	if language != "go" {
-		err = fmt.Errorf("language '%s' not supported. Currently supported: go", language)
+		err = t.validateProcessorInputs(langutil.Language(language), partType, newContent)
 		goto end
 	}
 
@@ -124,6 +152,37 @@ end:
 	return err
 }
 
+func (t *ReplaceFilePartTool) validateProcessorInputs(language langutil.Language, partType, newContent string) (err error) {
+	var processor langutil.Processor
+	var languages []langutil.Language
+	var supportedTypes []langutil.PartType
+
+	processor, err = langutil.GetProcessor(language)
+	if err != nil || language == langutil.NoLanguage {
+		languages = slices.DeleteFunc(langutil.GetLanguages(), func(l langutil.Language) bool {
+			return l == langutil.NoLanguage
+		})
+		slices.Sort(languages)
+		err = fmt.Errorf("language '%s' not supported. Currently supported: %v", language, languages)
+		goto end
+	}
+
+	supportedTypes = processor.SupportedPartTypes()
+	if !slices.Contains(supportedTypes, langutil.PartType(partType)) {
+		err = fmt.Errorf("part_type '%s' not supported for language '%s'. Valid types: %v", partType, language, supportedTypes)
+		goto end
+	}
+
+	err = processor.ValidateContent(langutil.PartArgs{
+		Language: language,
+		PartType: langutil.PartType(partType),
+		Content:  newContent,
+	})
+
+end:
+	return err
+}
+
 func (t *ReplaceFilePartTool) validateGoContent(partType, content string) (err error) {
 	content = strings.TrimSpace(content)
 
@@ -136,6 +195,12 @@ func (t *ReplaceFilePartTool) validateGoContent(partType, content string) (err e
 		if !strings.HasPrefix(content, "type ") {
 			err = fmt.Errorf("type replacement must start with 'type ', got: %s", content[:min(20, len(content))])
 		}
+	case "func_body":
+		err = golang.ValidateFuncBody(content)
+	case "field":
+		err = golang.ValidateStructField(content)
+	case "method":
+		err = golang.ValidateInterfaceMethod(content)
 	case "const":
 		if !strings.Contains(content, "=") && !strings.HasPrefix(content, "const") {
 			err = fmt.Errorf("const replacement must contain '=' or start with 'const', got: %s", content[:min(20, len(content))])
@@ -157,9 +222,7 @@ func (t *ReplaceFilePartTool) validateGoContent(partType, content string) (err e
 	return err
 }
 
-func (t *ReplaceFilePartTool) replaceFilePart(filePath, language, partType, partName, newContent string) (err error) {
-	var originalContent string
-
+func (t *ReplaceFilePartTool) replaceFilePart(ctx context.Context, filePath, language, partType, partName, newContent string, skipFormat bool) (originalContent, updatedContent string, err error) {
 	if !t.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
 		goto end
@@ -170,23 +233,55 @@ func (t *ReplaceFilePartTool) replaceFilePart(filePath, language, partType, part
 		goto end
 	}
 
+	updatedContent, err = t.replacePart(filePath, originalContent, language, partType, partName, newContent, skipFormat)
+	if err != nil {
+		goto end
+	}
+
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
+
+end:
+	return originalContent, updatedContent, err
+}
+
+// replacePart returns originalContent, the content of filePath, with the
+// part replaced by newContent, without writing it.
+func (t *ReplaceFilePartTool) replacePart(filePath, originalContent, language, partType, partName, newContent string, skipFormat bool) (updatedContent string, err error) {
 	switch language {
 	case "go":
-		err = t.replaceGoPart(filePath, originalContent, partType, partName, newContent)
+		updatedContent, err = t.replaceGoPart(filePath, originalContent, partType, partName, newContent, skipFormat)
 	default:
-		err = fmt.Errorf("language %s not supported", language)
+		updatedContent, err = t.replaceProcessorPart(filePath, originalContent, langutil.Language(language), partType, partName, newContent)
 	}
+	return updatedContent, err
+}
+
+func (t *ReplaceFilePartTool) replaceProcessorPart(filePath, originalContent string, language langutil.Language, partType, partName, newContent string) (updatedContent string, err error) {
+	var processor langutil.Processor
+
+	processor, err = langutil.GetProcessor(language)
+	if err != nil {
+		goto end
+	}
+
+	updatedContent, err = processor.ReplacePart(langutil.PartArgs{
+		Language:   language,
+		Content:    originalContent,
+		PartType:   langutil.PartType(partType),
+		PartName:   partName,
+		NewContent: newContent,
+		Filepath:   filePath,
+	})
 
 end:
-	return err
+	return updatedContent, err
 }
 
-func (t *ReplaceFilePartTool) replaceGoPart(filePath, originalContent, partType, partName, newContent string) (err error) {
+func (t *ReplaceFilePartTool) replaceGoPart(filePath, originalContent, partType, partName, newContent string, skipFormat bool) (updatedContent string, err error) {
 	var fset *token.FileSet
 	var file *ast.File
 	var startPos, endPos token.Pos
 	var found bool
-	var updatedContent string
 
 	// Parse the Go file
 	fset = token.NewFileSet()
@@ -207,6 +302,10 @@ func (t *ReplaceFilePartTool) replaceGoPart(filePath, originalContent, partType,
 		goto end
 	}
 
+	if partType == "func_body" {
+		newContent = golang.FuncBodyReplacement(newContent)
+	}
+
 	// Replace the content
 	updatedContent, err = t.replaceGoContent(fset, originalContent, startPos, endPos, newContent)
 	if err != nil {
@@ -219,11 +318,18 @@ func (t *ReplaceFilePartTool) replaceGoPart(filePath, originalContent, partType,
 		goto end
 	}
 
-	// Write the updated content
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = golang.ValidateTypeParams(updatedContent)
+	if err != nil {
+		err = fmt.Errorf("replacement resulted in invalid type parameters: %w", err)
+		goto end
+	}
+
+	if !skipFormat {
+		updatedContent, err = t.formatGoContent(updatedContent)
+	}
 
 end:
-	return err
+	return updatedContent, err
 }
 
 func (t *ReplaceFilePartTool) findGoPart(file *ast.File, partType, partName string) (startPos, endPos token.Pos, found bool, err error) {
@@ -242,8 +348,14 @@ func (t *ReplaceFilePartTool) findGoPart(file *ast.File, partType, partName stri
 		startPos, endPos, found = t.findGoVar(file, partName)
 	case "type":
 		startPos, endPos, found = t.findGoType(file, partName)
+	case "field":
+		startPos, endPos, found = t.findGoField(file, partName)
+	case "method":
+		startPos, endPos, found, err = t.findGoInterfaceMethod(file, partName)
 	case "func":
 		startPos, endPos, found = t.findGoFunc(file, partName)
+	case "func_body":
+		startPos, endPos, found, err = t.findGoFuncBody(file, partName)
 	default:
 		err = fmt.Errorf("unsupported part type: %s", partType)
 	}
@@ -304,6 +416,7 @@ func (t *ReplaceFilePartTool) findGoVar(file *ast.File, varName string) (startPo
 }
 
 func (t *ReplaceFilePartTool) findGoType(file *ast.File, typeName string) (startPos, endPos token.Pos, found bool) {
+	typeName = golang.StripTypeParams(typeName)
 	for _, decl := range file.Decls {
 		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
 			for _, spec := range genDecl.Specs {
@@ -321,37 +434,52 @@ func (t *ReplaceFilePartTool) findGoType(file *ast.File, typeName string) (start
 	return
 }
 
-func (t *ReplaceFilePartTool) findGoFunc(file *ast.File, funcName string) (startPos, endPos token.Pos, found bool) {
-	for _, decl := range file.Decls {
-		if funcDecl, ok := decl.(*ast.FuncDecl); ok {
-			var name string
-
-			// Handle regular functions
-			if funcDecl.Recv == nil {
-				name = funcDecl.Name.Name
-			} else {
-				// Handle methods - format as ReceiverType.MethodName
-				if len(funcDecl.Recv.List) > 0 {
-					var recvType string
-					switch recv := funcDecl.Recv.List[0].Type.(type) {
-					case *ast.StarExpr:
-						if ident, ok := recv.X.(*ast.Ident); ok {
-							recvType = "*" + ident.Name
-						}
-					case *ast.Ident:
-						recvType = recv.Name
-					}
-					name = recvType + "." + funcDecl.Name.Name
-				}
-			}
+func (t *ReplaceFilePartTool) findGoField(file *ast.File, fieldPath string) (startPos, endPos token.Pos, found bool) {
+	field := golang.FindStructField(file, fieldPath)
+	if field != nil {
+		startPos = field.Pos()
+		endPos = field.End()
+		found = true
+	}
+	return
+}
 
-			if name == funcName {
-				startPos = funcDecl.Pos()
-				endPos = funcDecl.End()
-				found = true
-				return
-			}
-		}
+func (t *ReplaceFilePartTool) findGoInterfaceMethod(file *ast.File, methodPath string) (startPos, endPos token.Pos, found bool, err error) {
+	var field *ast.Field
+
+	field, err = golang.FindInterfaceMethod(file, methodPath)
+	if err != nil {
+		goto end
+	}
+	startPos = field.Pos()
+	endPos = field.End()
+	found = true
+
+end:
+	return startPos, endPos, found, err
+}
+
+func (t *ReplaceFilePartTool) findGoFuncBody(file *ast.File, funcName string) (startPos, endPos token.Pos, found bool, err error) {
+	var body *ast.BlockStmt
+
+	body, err = golang.FindFuncBody(file, funcName)
+	if err != nil || body == nil {
+		goto end
+	}
+	startPos = body.Lbrace + 1
+	endPos = body.Rbrace
+	found = true
+
+end:
+	return startPos, endPos, found, err
+}
+
+func (t *ReplaceFilePartTool) findGoFunc(file *ast.File, funcName string) (startPos, endPos token.Pos, found bool) {
+	funcDecl := golang.FindFunc(file, funcName)
+	if funcDecl != nil {
+		startPos = funcDecl.Pos()
+		endPos = funcDecl.End()
+		found = true
 	}
 	return
 }
@@ -369,6 +497,20 @@ func (t *ReplaceFilePartTool) replaceGoContent(fset *token.FileSet, originalCont
 	return result, err
 }
 
+func (t *ReplaceFilePartTool) formatGoContent(content string) (formatted string, err error) {
+	var out []byte
+
+	out, err = format.Source([]byte(content))
+	if err != nil {
+		err = fmt.Errorf("failed to format replacement result: %w", err)
+		goto end
+	}
+	formatted = string(out)
+
+end:
+	return formatted, err
+}
+
 func (t *ReplaceFilePartTool) validateGoSyntax(content string) (err error) {
 	var fset *token.FileSet
 
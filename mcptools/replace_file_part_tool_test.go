@@ -2,6 +2,7 @@ package mcptools_test
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/fsfix"
@@ -42,6 +43,85 @@ const (
 	DeleteUser(id string) error
 }`
 
+	GoStructFieldContent = `package main
+
+// Config holds settings.
+type Config struct {
+	// Name identifies the server.
+	Name string ` + "`json:\"name\"`" + `
+
+	// Port is where the server listens.
+	Port string ` + "`json:\"port\"`" + ` // required
+	Server struct {
+		Host string
+	}
+}
+`
+
+	GoInterfaceMethodContent = `package main
+
+import "context"
+
+// UserService manages users.
+type UserService interface {
+	// GetUser returns the user with the given ID.
+	GetUser(id string) (*User, error) // may return nil
+	DeleteUser(id string) error
+}
+`
+
+	GoGenericContent = `package main
+
+// Stack is a generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+`
+
+	PythonClassContent = `class Greeter:
+    """Greets people."""
+
+    @staticmethod
+    def default():
+        return Greeter()
+
+    def greet(self, name):
+        return f"Hello, {name}"
+
+    # farewell is still to come
+
+
+def main():
+    print(Greeter().greet("world"))
+`
+
+	TypeScriptClassContent = "import { log } from './log';\n\n" +
+		"export class Greeter {\n" +
+		"  greet(name: string): string {\n" +
+		"    return `Hello, ${name}`;\n" +
+		"  }\n\n" +
+		"  leave(): void {\n" +
+		"    log('bye');\n" +
+		"  }\n" +
+		"}\n"
+
+	GoFuncBodyContent = `package main
+
+type Config struct {
+	Port string
+}
+
+// GetPort returns the configured port.
+func (c *Config) GetPort() string {
+	return c.Port
+}
+`
+
 	UpdatedMethod = `func (c *Config) GetPort() string {
 	if c.Port == "" {
 		return "8080"
@@ -359,13 +439,565 @@ func TestReplaceFilePartTool(t *testing.T) {
 		})
 	})
 
+	t.Run("ReplaceField_ShouldUpdateOnlyThatField", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-field-project", nil)
+		testFile := pf.AddFileFixture("replace_field_test.go", &fsfix.FileFixtureArgs{
+			Content: GoStructFieldContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "field",
+			"part_name":     "Config.Port",
+			"new_content":   "Port int `json:\"port,omitempty\"`",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing field")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedPartType: "field",
+			ExpectedPartName: "Config.Port",
+			ShouldUpdateFile: true,
+			ExpectedContent: `package main
+
+// Config holds settings.
+type Config struct {
+	// Name identifies the server.
+	Name string ` + "`json:\"name\"`" + `
+
+	// Port is where the server listens.
+	Port   int ` + "`json:\"port,omitempty\"`" + ` // required
+	Server struct {
+		Host string
+	}
+}
+`,
+		})
+	})
+
+	t.Run("ReplaceNestedField_ShouldUpdateAnonymousStructField", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-nested-field-project", nil)
+		testFile := pf.AddFileFixture("replace_nested_field_test.go", &fsfix.FileFixtureArgs{
+			Content: GoStructFieldContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "field",
+			"part_name":     "Config.Server.Host",
+			"new_content":   "Host, Addr string",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing nested field")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:   true,
+			ExpectedFilePath:  testFile.Filepath,
+			ShouldUpdateFile:  true,
+			ShouldContainText: "\t\tHost, Addr string\n",
+		})
+	})
+
+	t.Run("ReplaceFieldWithTwoFields_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-two-fields-project", nil)
+		testFile := pf.AddFileFixture("replace_two_fields_test.go", &fsfix.FileFixtureArgs{
+			Content: GoStructFieldContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "field",
+			"part_name":     "Config.Port",
+			"new_content":   "Port int\n\tHost string",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject two fields")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "exactly one struct field",
+		})
+	})
+
+	t.Run("ReplaceInterfaceMethod_ShouldUpdateOnlyThatSignature", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-interface-method-project", nil)
+		testFile := pf.AddFileFixture("replace_interface_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoInterfaceMethodContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.GetUser",
+			"new_content":   "GetUser(ctx context.Context, id string) (*User, error)",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing interface method")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedPartType: "method",
+			ExpectedPartName: "UserService.GetUser",
+			ShouldUpdateFile: true,
+			ExpectedContent: `package main
+
+import "context"
+
+// UserService manages users.
+type UserService interface {
+	// GetUser returns the user with the given ID.
+	GetUser(ctx context.Context, id string) (*User, error) // may return nil
+	DeleteUser(id string) error
+}
+`,
+		})
+	})
+
+	t.Run("ReplaceMissingInterface_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-missing-interface-project", nil)
+		testFile := pf.AddFileFixture("replace_missing_interface_test.go", &fsfix.FileFixtureArgs{
+			Content: GoInterfaceMethodContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "OrderService.GetUser",
+			"new_content":   "GetUser(id int) (*User, error)",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "interface 'OrderService' is not declared",
+		})
+		requireFileContent(t, testFile.Filepath, GoInterfaceMethodContent)
+	})
+
+	t.Run("ReplaceMissingInterfaceMethod_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-missing-method-project", nil)
+		testFile := pf.AddFileFixture("replace_missing_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoInterfaceMethodContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.UpdateUser",
+			"new_content":   "UpdateUser(user *User) error",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "interface 'UserService' has no method 'UpdateUser'",
+		})
+		requireFileContent(t, testFile.Filepath, GoInterfaceMethodContent)
+	})
+
+	t.Run("ReplaceInterfaceMethodWithInvalidSignature_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-invalid-method-project", nil)
+		testFile := pf.AddFileFixture("replace_invalid_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoInterfaceMethodContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.GetUser",
+			"new_content":   "func GetUser(id string) *User",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not a valid method signature",
+		})
+		requireFileContent(t, testFile.Filepath, GoInterfaceMethodContent)
+	})
+
+	t.Run("ReplaceInterfaceMethodWithEmbed_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-embed-method-project", nil)
+		testFile := pf.AddFileFixture("replace_embed_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoInterfaceMethodContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "method",
+			"part_name":     "UserService.GetUser",
+			"new_content":   "fmt.Stringer",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "must be a method signature",
+		})
+		requireFileContent(t, testFile.Filepath, GoInterfaceMethodContent)
+	})
+
+	t.Run("ReplaceFunction_ShouldFormatResultUnlessSkipped", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-format-project", nil)
+		formattedFile := pf.AddFileFixture("replace_format_test.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+		splicedFile := pf.AddFileFixture("replace_skip_format_test.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		newContent := "func (c *Config) GetPort() string {\n  return   c.Port\n}"
+		for _, file := range []*fsfix.FileFixture{formattedFile, splicedFile} {
+			req := mcputil.NewMockRequest(mcputil.Params{
+				"session_token": testToken,
+				"path":          file.Filepath,
+				"language":      "go",
+				"part_type":     "func",
+				"part_name":     "*Config.GetPort",
+				"new_content":   newContent,
+				"skip_format":   file == splicedFile,
+			})
+			_, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing function")
+			require.NoError(t, err)
+		}
+
+		requireFileContent(t, formattedFile.Filepath, GoFuncBodyContent)
+		requireFileContent(t, splicedFile.Filepath, strings.Replace(GoFuncBodyContent, "func (c *Config) GetPort() string {\n\treturn c.Port\n}", newContent, 1))
+	})
+
+	t.Run("ReplaceFuncBody_ShouldKeepSignatureAndDocComment", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-func-body-project", nil)
+		testFile := pf.AddFileFixture("replace_func_body_test.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "func_body",
+			"part_name":     "*Config.GetPort",
+			"new_content":   "\tif c.Port == \"\" {\n\t\treturn \"8080\"\n\t}\n\treturn c.Port",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing function body")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedPartType: "func_body",
+			ExpectedPartName: "*Config.GetPort",
+			ShouldUpdateFile: true,
+			ExpectedContent: `package main
+
+type Config struct {
+	Port string
+}
+
+// GetPort returns the configured port.
+func (c *Config) GetPort() string {
+	if c.Port == "" {
+		return "8080"
+	}
+	return c.Port
+}
+`,
+		})
+	})
+
+	t.Run("ReplaceFuncBodyWithDeclaration_ShouldReturnErrorWithMessage", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-invalid-func-body-project", nil)
+		testFile := pf.AddFileFixture("replace_invalid_func_body_test.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "func_body",
+			"part_name":     "*Config.GetPort",
+			"new_content":   "return c.Port\n}\n\nfunc other() {",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "must be only the statements",
+		})
+		requireFileContent(t, testFile.Filepath, GoFuncBodyContent)
+	})
+
+	t.Run("ReplaceGenericMethod_ShouldFindByReceiverTypeParams", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-generic-method-project", nil)
+		testFile := pf.AddFileFixture("replace_generic_method_test.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "func",
+			"part_name":     "*Stack[T].Push",
+			"new_content":   "func (s *Stack[T]) Push(v T) {\n\ts.items = append([]T{v}, s.items...)\n}",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing generic method")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedPartType: "func",
+			ExpectedPartName: "*Stack[T].Push",
+			ShouldUpdateFile: true,
+			ExpectedContent: `package main
+
+// Stack is a generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append([]T{v}, s.items...)
+}
+`,
+		})
+	})
+
+	t.Run("ReplaceGenericType_ShouldRejectMismatchedReceivers", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-generic-type-project", nil)
+		testFile := pf.AddFileFixture("replace_generic_type_test.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "go",
+			"part_type":     "type",
+			"part_name":     "Stack",
+			"new_content":   "type Stack[K comparable, V any] struct {\n\titems map[K]V\n}",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the replacement")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "receiver of method Push has 1 type parameters but Stack has 2",
+		})
+		requireFileContent(t, testFile.Filepath, GoGenericContent)
+	})
+
+	t.Run("ReplacePythonMethod_ShouldReindentToClassBody", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("python-method-project", nil)
+		testFile := pf.AddFileFixture("greeter.py", &fsfix.FileFixtureArgs{
+			Content: PythonClassContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "python",
+			"part_type":     "func",
+			"part_name":     "Greeter.greet",
+			"new_content":   "def greet(self, name):\n    return f\"Hi, {name}\"",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should replace the method")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedLanguage: "python",
+			ExpectedPartType: "func",
+			ExpectedPartName: "Greeter.greet",
+		})
+		requireFileContent(t, testFile.Filepath, strings.Replace(PythonClassContent,
+			"    def greet(self, name):\n        return f\"Hello, {name}\"",
+			"    def greet(self, name):\n        return f\"Hi, {name}\"", 1))
+	})
+
+	t.Run("ReplaceTypeScriptMethod_ShouldUpdateOnlyThatMethod", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("typescript-method-project", nil)
+		testFile := pf.AddFileFixture("greeter.ts", &fsfix.FileFixtureArgs{
+			Content: TypeScriptClassContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"language":      "typescript",
+			"part_type":     "func",
+			"part_name":     "Greeter.greet",
+			"new_content":   "greet(name: string): string {\n    return `Hi, ${name}`;\n  }",
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should replace the method")
+
+		requireReplaceFilePartResult(t, result, err, replaceFilePartResultOpts{
+			ExpectedSuccess:  true,
+			ExpectedFilePath: testFile.Filepath,
+			ExpectedLanguage: "typescript",
+			ExpectedPartType: "func",
+			ExpectedPartName: "Greeter.greet",
+		})
+		requireFileContent(t, testFile.Filepath, strings.Replace(TypeScriptClassContent,
+			"return `Hello, ${name}`;", "return `Hi, ${name}`;", 1))
+	})
+
 	t.Run("UnsupportedLanguage_ShouldReturnErrorWithMessage", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(ReplaceFilePartDirPrefix)
 		defer tf.Cleanup()
 
 		pf := tf.AddRepoFixture("unsupported-lang-project", nil)
-		testFile := pf.AddFileFixture("unsupported.py", &fsfix.FileFixtureArgs{
-			Content: "def hello():\n    print('hello')",
+		testFile := pf.AddFileFixture("unsupported.rs", &fsfix.FileFixtureArgs{
+			Content: "fn hello() {\n    println!(\"hello\");\n}",
 		})
 
 		tf.Setup(t)
@@ -376,10 +1008,10 @@ func TestReplaceFilePartTool(t *testing.T) {
 		req := mcputil.NewMockRequest(mcputil.Params{
 			"session_token": testToken,
 			"path":          testFile.Filepath,
-			"language":      "python",
+			"language":      "rust",
 			"part_type":     "func",
 			"part_name":     "hello",
-			"new_content":   "def hello():\n    print('updated')",
+			"new_content":   "fn hello() {\n    println!(\"updated\");\n}",
 		})
 
 		result, err := mcputil.GetToolResult[ReplaceFilePartResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should handle unsupported language")
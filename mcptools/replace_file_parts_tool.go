@@ -0,0 +1,270 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ReplaceFilePartsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ReplaceFilePartsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "replace_file_parts",
+			Description: "Replace language constructs by name in many files in one call, as replace_file_part does, optionally writing no files unless every replacement succeeds",
+			QuickHelp:   "Batch replace_file_part across files",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				PartOperationsProperty.Required(),
+				AllOrNothingProperty,
+				SkipFormatProperty,
+			},
+		}),
+	})
+}
+
+// ReplaceFilePartsTool applies a batch of replace_file_part operations,
+// staging every file's updated content in memory before writing any of it.
+type ReplaceFilePartsTool struct {
+	*mcputil.ToolBase
+}
+
+// partOperation is a single replace_file_part operation of a batch and,
+// once applied, its outcome.
+type partOperation struct {
+	Index      int    `json:"index"`
+	Path       string `json:"path"`
+	Language   string `json:"language"`
+	PartType   string `json:"part_type"`
+	PartName   string `json:"part_name"`
+	NewContent string `json:"-"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Handle processes the replace_file_parts tool request. Operations are
+// applied in order, so that several operations on one file each see the
+// result of the ones before. With all_or_nothing, the first failing
+// operation leaves every file unwritten.
+func (t *ReplaceFilePartsTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var rawOperations []any
+	var operations []partOperation
+	var allOrNothing, skipFormat bool
+	var contents map[string]string
+	var paths, written []string
+	var failed int
+	var failedOperation *partOperation
+
+	logger.Info("Tool called", "tool", "replace_file_parts")
+
+	rawOperations, err = PartOperationsProperty.AnySlice(req)
+	if err != nil {
+		goto end
+	}
+
+	allOrNothing, err = AllOrNothingProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	skipFormat, err = SkipFormatProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	operations, err = parsePartOperations(rawOperations)
+	if err != nil {
+		goto end
+	}
+
+	contents, paths = t.stageOperations(operations, skipFormat)
+
+	failedOperation = firstFailedOperation(operations)
+	if allOrNothing && failedOperation != nil {
+		for i := range operations {
+			if operations[i].Success {
+				operations[i].Success = false
+				operations[i].Error = fmt.Sprintf("not applied because operation %d failed", failedOperation.Index)
+			}
+		}
+		paths = nil
+	}
+
+	written = t.writeFiles(ctx, operations, contents, paths)
+	for _, path := range written {
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+	}
+	if failedOperation == nil {
+		// Every replacement succeeded but a write may still have failed
+		failedOperation = firstFailedOperation(operations)
+	}
+	for _, op := range operations {
+		if !op.Success {
+			failed++
+		}
+	}
+
+	logger.Info("Tool completed", "tool", "replace_file_parts",
+		"operation_count", len(operations),
+		"failed_count", failed,
+		"files_written", len(written))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":          failed == 0,
+		"all_or_nothing":   allOrNothing,
+		"operations":       operations,
+		"failed_count":     failed,
+		"failed_operation": failedOperation,
+		"files_written":    written,
+	})
+
+end:
+	return result, err
+}
+
+// firstFailedOperation returns the first of operations that did not succeed,
+// or nil when all of them did.
+func firstFailedOperation(operations []partOperation) *partOperation {
+	for i := range operations {
+		if !operations[i].Success {
+			return &operations[i]
+		}
+	}
+	return nil
+}
+
+// stageOperations applies each operation to the staged content of its file,
+// read on first use, marking it successful or recording its error. It
+// returns the staged contents by stagedPath, and the staged paths changed by
+// at least one successful operation in the order they were first changed.
+func (t *ReplaceFilePartsTool) stageOperations(operations []partOperation, skipFormat bool) (contents map[string]string, paths []string) {
+	var part *ReplaceFilePartTool
+	var content string
+	var path string
+	var err error
+
+	part = &ReplaceFilePartTool{ToolBase: t.ToolBase}
+	contents = make(map[string]string)
+
+	for i := range operations {
+		op := &operations[i]
+		path = stagedPath(op.Path)
+		content, err = t.stagedContent(contents, path)
+		if err == nil {
+			err = part.validateInputs(op.Language, op.PartType, op.NewContent)
+		}
+		if err == nil {
+			content, err = part.replacePart(op.Path, content, op.Language, op.PartType, op.PartName, op.NewContent, skipFormat)
+		}
+		if err != nil {
+			op.Error = err.Error()
+			continue
+		}
+		op.Success = true
+		if _, changed := contents[path]; !changed {
+			paths = append(paths, path)
+		}
+		contents[path] = content
+	}
+	return contents, paths
+}
+
+// stagedPath returns path in the form staged contents are keyed by, absolute
+// and cleaned, so that operations naming one file by different paths, such as
+// "a.go" and "./a.go", are applied to the same staged content.
+func stagedPath(path string) (staged string) {
+	var err error
+
+	staged, err = filepath.Abs(path)
+	if err != nil {
+		staged = filepath.Clean(path)
+	}
+	return staged
+}
+
+// stagedContent returns the staged content of path, or its content on disk
+// when no operation has changed it yet.
+func (t *ReplaceFilePartsTool) stagedContent(contents map[string]string, path string) (content string, err error) {
+	var ok bool
+
+	content, ok = contents[path]
+	if ok {
+		goto end
+	}
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+
+end:
+	return content, err
+}
+
+// writeFiles writes the staged content of each of paths, returning those
+// written. When a write fails, the operations on that file are marked as
+// failed with the write error.
+func (t *ReplaceFilePartsTool) writeFiles(ctx context.Context, operations []partOperation, contents map[string]string, paths []string) (written []string) {
+	written = make([]string, 0, len(paths))
+	for _, path := range paths {
+		err := WriteFile(ctx, t.Config(), path, contents[path])
+		if err == nil {
+			written = append(written, path)
+			continue
+		}
+		for i := range operations {
+			if stagedPath(operations[i].Path) == path && operations[i].Success {
+				operations[i].Success = false
+				operations[i].Error = fmt.Sprintf("failed to write %s: %v", path, err)
+			}
+		}
+	}
+	return written
+}
+
+// parsePartOperations converts the raw operations parameter into
+// partOperations, rejecting operations missing a required field.
+func parsePartOperations(raw []any) (operations []partOperation, err error) {
+	var obj map[string]any
+	var ok bool
+	var fields [5]string
+	var names = [5]string{"path", "language", "part_type", "part_name", "new_content"}
+
+	if len(raw) == 0 {
+		err = fmt.Errorf("operations must contain at least one operation")
+		goto end
+	}
+
+	operations = make([]partOperation, 0, len(raw))
+	for i, item := range raw {
+		obj, ok = item.(map[string]any)
+		if !ok {
+			err = fmt.Errorf("operation %d must be an object with 'path', 'language', 'part_type', 'part_name' and 'new_content' strings", i+1)
+			goto end
+		}
+		for j, name := range names {
+			fields[j], ok = obj[name].(string)
+			if !ok || fields[j] == "" && name != "new_content" {
+				err = fmt.Errorf("operation %d must have a non-empty '%s' string", i+1, name)
+				goto end
+			}
+		}
+		operations = append(operations, partOperation{
+			Index:      i + 1,
+			Path:       fields[0],
+			Language:   fields[1],
+			PartType:   fields[2],
+			PartName:   fields[3],
+			NewContent: fields[4],
+		})
+	}
+
+end:
+	return operations, err
+}
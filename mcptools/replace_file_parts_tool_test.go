@@ -0,0 +1,285 @@
+package mcptools_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ReplaceFilePartsDirPrefix = "replace-file-parts-tool-test"
+
+// Replace file parts tool result types
+type ReplaceFilePartsOperation struct {
+	Index    int    `json:"index"`
+	Path     string `json:"path"`
+	PartType string `json:"part_type"`
+	PartName string `json:"part_name"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error"`
+}
+
+type ReplaceFilePartsResult struct {
+	Success         bool                        `json:"success"`
+	AllOrNothing    bool                        `json:"all_or_nothing"`
+	Operations      []ReplaceFilePartsOperation `json:"operations"`
+	FailedCount     int                         `json:"failed_count"`
+	FailedOperation *ReplaceFilePartsOperation  `json:"failed_operation"`
+	FilesWritten    []string                    `json:"files_written"`
+}
+
+type replaceFilePartsResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedSuccess      bool
+	ExpectedFailedCount  int
+	ExpectedFailedIndex  int
+	ExpectedFailedError  string
+	ExpectedFilesWritten []string
+}
+
+func requireReplaceFilePartsResult(t *testing.T, result *ReplaceFilePartsResult, err error, opts replaceFilePartsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedSuccess, result.Success, "Success should match expected")
+	assert.Equal(t, opts.ExpectedFailedCount, result.FailedCount, "Failed count should match expected")
+	assert.ElementsMatch(t, opts.ExpectedFilesWritten, result.FilesWritten, "Files written should match expected")
+
+	if opts.ExpectedFailedIndex == 0 {
+		assert.Nil(t, result.FailedOperation, "No operation should have failed")
+		return
+	}
+	require.NotNil(t, result.FailedOperation, "Failed operation should be reported")
+	assert.Equal(t, opts.ExpectedFailedIndex, result.FailedOperation.Index, "Failed operation index should match expected")
+	assert.Contains(t, result.FailedOperation.Error, opts.ExpectedFailedError, "Failed operation error should contain expected message")
+}
+
+func TestReplaceFilePartsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("replace_file_parts")
+	require.NotNil(t, tool, "replace_file_parts tool should be registered")
+
+	genericMethod := "func (s *Stack[T]) Push(v T) {\n\ts.items = append([]T{v}, s.items...)\n}"
+
+	t.Run("ReplaceAcrossFiles_ShouldWriteEachFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-across-files-project", nil)
+		stackFile := pf.AddFileFixture("stack.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent,
+		})
+		configFile := pf.AddFileFixture("config.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"operations": []any{
+				map[string]any{"path": stackFile.Filepath, "language": "go", "part_type": "func", "part_name": "*Stack.Push", "new_content": genericMethod},
+				map[string]any{"path": configFile.Filepath, "language": "go", "part_type": "func", "part_name": "*Config.GetPort", "new_content": UpdatedMethod},
+				map[string]any{"path": configFile.Filepath, "language": "go", "part_type": "type", "part_name": "Config", "new_content": "type Config struct {\n\tPort string\n\tHost string\n}"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing parts")
+
+		requireReplaceFilePartsResult(t, result, err, replaceFilePartsResultOpts{
+			ExpectedSuccess:      true,
+			ExpectedFilesWritten: []string{stackFile.Filepath, configFile.Filepath},
+		})
+		requireFileContent(t, stackFile.Filepath, `package main
+
+// Stack is a generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append([]T{v}, s.items...)
+}
+`)
+		requireFileContent(t, configFile.Filepath, `package main
+
+type Config struct {
+	Port string
+	Host string
+}
+
+// GetPort returns the configured port.
+func (c *Config) GetPort() string {
+	if c.Port == "" {
+		return "8080"
+	}
+	return c.Port
+}
+`)
+	})
+
+	t.Run("SameFileByDifferentPaths_ShouldApplyBoth", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("same-file-project", nil)
+		configFile := pf.AddFileFixture("config.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		unclean := pf.Dir() + string(filepath.Separator) + "." + string(filepath.Separator) + "config.go"
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"operations": []any{
+				map[string]any{"path": configFile.Filepath, "language": "go", "part_type": "func", "part_name": "*Config.GetPort", "new_content": UpdatedMethod},
+				map[string]any{"path": unclean, "language": "go", "part_type": "type", "part_name": "Config", "new_content": "type Config struct {\n\tPort string\n\tHost string\n}"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing parts")
+
+		requireReplaceFilePartsResult(t, result, err, replaceFilePartsResultOpts{
+			ExpectedSuccess:      true,
+			ExpectedFilesWritten: []string{configFile.Filepath},
+		})
+		requireFileContent(t, configFile.Filepath, `package main
+
+type Config struct {
+	Port string
+	Host string
+}
+
+// GetPort returns the configured port.
+func (c *Config) GetPort() string {
+	if c.Port == "" {
+		return "8080"
+	}
+	return c.Port
+}
+`)
+	})
+
+	t.Run("AllOrNothing_ShouldWriteNoFilesWhenAnOperationFails", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("all-or-nothing-project", nil)
+		stackFile := pf.AddFileFixture("stack.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent,
+		})
+		configFile := pf.AddFileFixture("config.go", &fsfix.FileFixtureArgs{
+			Content: GoFuncBodyContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"all_or_nothing": true,
+			"operations": []any{
+				map[string]any{"path": stackFile.Filepath, "language": "go", "part_type": "func", "part_name": "*Stack.Push", "new_content": genericMethod},
+				map[string]any{"path": configFile.Filepath, "language": "go", "part_type": "func", "part_name": "Missing", "new_content": UpdatedFunction},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should report the failed operation")
+
+		requireReplaceFilePartsResult(t, result, err, replaceFilePartsResultOpts{
+			ExpectedSuccess:      false,
+			ExpectedFailedCount:  2,
+			ExpectedFailedIndex:  2,
+			ExpectedFailedError:  "Missing",
+			ExpectedFilesWritten: []string{},
+		})
+		assert.Equal(t, "not applied because operation 2 failed", result.Operations[0].Error, "First operation should not be applied")
+		requireFileContent(t, stackFile.Filepath, GoGenericContent)
+		requireFileContent(t, configFile.Filepath, GoFuncBodyContent)
+	})
+
+	t.Run("WithoutAllOrNothing_ShouldWriteSuccessfulOperations", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceFilePartsDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("partial-project", nil)
+		stackFile := pf.AddFileFixture("stack.go", &fsfix.FileFixtureArgs{
+			Content: GoGenericContent,
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"operations": []any{
+				map[string]any{"path": stackFile.Filepath, "language": "go", "part_type": "type", "part_name": "Stack", "new_content": "type Stack[K comparable, V any] struct{}"},
+				map[string]any{"path": stackFile.Filepath, "language": "go", "part_type": "func", "part_name": "*Stack.Push", "new_content": genericMethod},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should report the failed operation")
+
+		requireReplaceFilePartsResult(t, result, err, replaceFilePartsResultOpts{
+			ExpectedSuccess:      false,
+			ExpectedFailedCount:  1,
+			ExpectedFailedIndex:  1,
+			ExpectedFailedError:  "receiver of method Push has 1 type parameters but Stack has 2",
+			ExpectedFilesWritten: []string{stackFile.Filepath},
+		})
+		requireFileContent(t, stackFile.Filepath, `package main
+
+// Stack is a generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds v to the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append([]T{v}, s.items...)
+}
+`)
+	})
+
+	t.Run("InvalidOperation_ShouldReturnError", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"operations": []any{
+				map[string]any{"path": "main.go", "language": "go", "part_type": "func", "new_content": UpdatedFunction},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceFilePartsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the operation")
+
+		requireReplaceFilePartsResult(t, result, err, replaceFilePartsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "operation 1 must have a non-empty 'part_name' string",
+		})
+	})
+}
@@ -0,0 +1,353 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ReplaceMappingsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ReplaceMappingsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "replace_mappings",
+			Description: "Replace whole words using a set of old→new mappings in a single, non-cascading pass across a file, directory or glob",
+			QuickHelp:   "Bulk rename words with an old→new mapping",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				PathProperty.Required().Description("File, directory or glob pattern (e.g., '/project/*.go') to process"),
+				MappingsProperty.Required(),
+				RecursiveProperty,
+				ExtensionsProperty,
+			},
+		}),
+	})
+}
+
+// ReplaceMappingsTool applies a set of literal whole-word replacements in a single pass.
+type ReplaceMappingsTool struct {
+	*mcputil.ToolBase
+}
+
+// wordMapping is a single literal old→new replacement.
+type wordMapping struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int    `json:"count"`
+}
+
+// mappedFileResult reports the replacements made in a single file.
+type mappedFileResult struct {
+	Path     string `json:"path"`
+	Count    int    `json:"count"`
+	original string // Content before the replacements
+	content  string // Content after the replacements
+}
+
+// Handle processes the replace_mappings tool request and applies all mappings to each file.
+func (t *ReplaceMappingsTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var rawMappings []any
+	var recursive bool
+	var extensions []string
+	var mappings []wordMapping
+	var paths []string
+	var files []string
+	var re *regexp.Regexp
+	var fileResults []mappedFileResult
+	var totalCount int
+
+	logger.Info("Tool called", "tool", "replace_mappings")
+
+	path, err = PathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	rawMappings, err = MappingsProperty.AnySlice(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	extensions, err = ExtensionsProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid extensions array: %v", err)
+		goto end
+	}
+
+	mappings, err = parseWordMappings(rawMappings)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "replace_mappings",
+		"path", path,
+		"mapping_count", len(mappings),
+		"recursive", recursive,
+		"extensions", extensions)
+
+	paths, err = expandGlobPaths([]string{path})
+	if err != nil {
+		goto end
+	}
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:      paths,
+		Recursive:  recursive,
+		Extensions: extensions,
+	})
+	if err != nil {
+		goto end
+	}
+
+	re, err = compileWordMappings(mappings)
+	if err != nil {
+		goto end
+	}
+
+	fileResults, totalCount, err = t.replaceInFiles(ctx, files, re, mappings)
+	if err != nil {
+		goto end
+	}
+
+	for _, fr := range fileResults {
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, fr.Path)
+	}
+
+	logger.Info("Tool completed", "tool", "replace_mappings",
+		"files_changed", len(fileResults),
+		"total_replacements", totalCount)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":               path,
+		"mappings":           mappings,
+		"files":              fileResults,
+		"files_scanned":      len(files),
+		"files_changed":      len(fileResults),
+		"total_replacements": totalCount,
+	})
+
+end:
+	return result, err
+}
+
+// replaceInFiles applies the compiled mappings to every text file and
+// writes back the files that changed. Every file is read, replaced and
+// syntax checked before any is written, so that a file that cannot be
+// changed leaves them all untouched, and if a write fails the files already
+// written are restored. Counts are accumulated into mappings.
+func (t *ReplaceMappingsTool) replaceInFiles(ctx context.Context, files []string, re *regexp.Regexp, mappings []wordMapping) (results []mappedFileResult, total int, err error) {
+	results, total, err = stageMappings(files, re, mappings)
+	if err != nil {
+		goto end
+	}
+
+	err = t.writeMappedFiles(ctx, results)
+
+end:
+	return results, total, err
+}
+
+// stageMappings computes the new content of each text file the mappings
+// change, failing if a file cannot be read or its new content would not be
+// valid syntax for its language.
+func stageMappings(files []string, re *regexp.Regexp, mappings []wordMapping) (results []mappedFileResult, total int, err error) {
+	var content []byte
+	var newContent string
+	var index map[string]int
+	var count int
+
+	index = make(map[string]int, len(mappings))
+	for i, m := range mappings {
+		index[m.From] = i
+	}
+
+	results = make([]mappedFileResult, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		if isBinaryContent(content) {
+			continue
+		}
+
+		count = 0
+		newContent = re.ReplaceAllStringFunc(string(content), func(match string) string {
+			i := index[match]
+			mappings[i].Count++
+			count++
+			return mappings[i].To
+		})
+		if count == 0 {
+			continue
+		}
+
+		err = validateFileSyntax(fp, newContent)
+		if err != nil {
+			err = fmt.Errorf("cannot replace in %s: %w", fp, err)
+			goto end
+		}
+		results = append(results, mappedFileResult{
+			Path:     fp,
+			Count:    count,
+			original: string(content),
+			content:  newContent,
+		})
+		total += count
+	}
+
+end:
+	return results, total, err
+}
+
+// writeMappedFiles writes the staged content of each file in turn. If one
+// fails, the files already written are restored to their original content
+// and the error says what was undone.
+func (t *ReplaceMappingsTool) writeMappedFiles(ctx context.Context, results []mappedFileResult) (err error) {
+	var written int
+	var rollbackErr error
+
+	for _, fr := range results {
+		err = mcputil.WriteFile(ctx, t.Config(), fr.Path, fr.content)
+		if err != nil {
+			err = fmt.Errorf("failed to write %s: %w", fr.Path, err)
+			goto end
+		}
+		written++
+	}
+
+end:
+	if err != nil {
+		rollbackErr = t.restoreMappedFiles(ctx, results[:written])
+		err = fmt.Errorf("%w; rolled back %d file(s) already written", err, written)
+		if rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback incomplete: %w", rollbackErr))
+		}
+	}
+	return err
+}
+
+// restoreMappedFiles restores each of results, last first, to its original
+// content.
+func (t *ReplaceMappingsTool) restoreMappedFiles(ctx context.Context, results []mappedFileResult) (err error) {
+	var errs []error
+
+	for _, fr := range slices.Backward(results) {
+		errs = append(errs, mcputil.RestoreFile(ctx, t.Config(), fr.Path, true, fr.original))
+	}
+
+	err = errors.Join(errs...)
+	return err
+}
+
+// parseWordMappings converts the raw mappings parameter into wordMappings,
+// rejecting empty or duplicate source words.
+func parseWordMappings(raw []any) (mappings []wordMapping, err error) {
+	var obj map[string]any
+	var ok bool
+	var from, to string
+	var seen map[string]NULL
+
+	if len(raw) == 0 {
+		err = fmt.Errorf("mappings must contain at least one mapping")
+		goto end
+	}
+
+	seen = make(map[string]NULL, len(raw))
+	mappings = make([]wordMapping, 0, len(raw))
+	for i, item := range raw {
+		obj, ok = item.(map[string]any)
+		if !ok {
+			err = fmt.Errorf("mapping %d must be an object with 'from' and 'to' strings", i+1)
+			goto end
+		}
+		from, ok = obj["from"].(string)
+		if !ok || from == "" {
+			err = fmt.Errorf("mapping %d must have a non-empty 'from' string", i+1)
+			goto end
+		}
+		to, ok = obj["to"].(string)
+		if !ok {
+			err = fmt.Errorf("mapping %d must have a 'to' string", i+1)
+			goto end
+		}
+		_, ok = seen[from]
+		if ok {
+			err = fmt.Errorf("mapping %d duplicates 'from' value %q", i+1, from)
+			goto end
+		}
+		seen[from] = NULL{}
+		mappings = append(mappings, wordMapping{From: from, To: to})
+	}
+
+end:
+	return mappings, err
+}
+
+// compileWordMappings builds a single alternation matching any mapped word.
+// Matching every word in one pass over the original text is what keeps the
+// replacements from cascading (e.g., A→B then B→C). Longer words are tried
+// first so that a word is never shadowed by one of its prefixes.
+func compileWordMappings(mappings []wordMapping) (re *regexp.Regexp, err error) {
+	var words []string
+	var alts []string
+
+	words = make([]string, len(mappings))
+	for i, m := range mappings {
+		words[i] = m.From
+	}
+	sort.SliceStable(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+
+	alts = make([]string, len(words))
+	for i, w := range words {
+		alts[i] = wholeWordPattern(w)
+	}
+
+	re, err = regexp.Compile(strings.Join(alts, "|"))
+
+	return re, err
+}
+
+// wholeWordPattern returns a regex matching w literally, anchored with \b on
+// each side that begins or ends with a word character.
+func wholeWordPattern(w string) (pattern string) {
+	var first, last rune
+
+	first, _ = utf8.DecodeRuneInString(w)
+	last, _ = utf8.DecodeLastRuneInString(w)
+
+	pattern = regexp.QuoteMeta(w)
+	if isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if isWordRune(last) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+// isWordRune reports whether r is a character that \b treats as a word character.
+func isWordRune(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
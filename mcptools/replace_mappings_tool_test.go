@@ -0,0 +1,236 @@
+package mcptools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ReplaceMappingsDirPrefix = "replace-mappings-tool-test"
+
+// Replace mappings tool result type
+type ReplaceMappingsResult struct {
+	Path     string `json:"path"`
+	Mappings []struct {
+		From  string `json:"from"`
+		To    string `json:"to"`
+		Count int    `json:"count"`
+	} `json:"mappings"`
+	Files []struct {
+		Path  string `json:"path"`
+		Count int    `json:"count"`
+	} `json:"files"`
+	FilesScanned      int `json:"files_scanned"`
+	FilesChanged      int `json:"files_changed"`
+	TotalReplacements int `json:"total_replacements"`
+}
+
+type replaceMappingsResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedFilesChanged int
+	ExpectedTotal        int
+	ExpectedCounts       map[string]int
+}
+
+func requireReplaceMappingsResult(t *testing.T, result *ReplaceMappingsResult, err error, opts replaceMappingsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.Equal(t, opts.ExpectedFilesChanged, result.FilesChanged, "Files changed should match")
+	assert.Equal(t, opts.ExpectedTotal, result.TotalReplacements, "Total replacements should match")
+	for _, m := range result.Mappings {
+		expected, ok := opts.ExpectedCounts[m.From]
+		if ok {
+			assert.Equal(t, expected, m.Count, "Count for mapping %s should match", m.From)
+		}
+	}
+}
+
+func TestReplaceMappingsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("replace_mappings")
+	require.NotNil(t, tool, "replace_mappings tool should be registered")
+
+	t.Run("SwapNames_ShouldNotCascade", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceMappingsDirPrefix)
+		defer tf.Cleanup()
+
+		ff := tf.AddFileFixture("colors.go", &fsfix.FileFixtureArgs{
+			Content: "package colors\n\nconst (\n\tRed = iota\n\tGreen\n\tRedish\n)\n\nvar x = Green + Red\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"mappings": []any{
+				map[string]any{"from": "Red", "to": "Green"},
+				map[string]any{"from": "Green", "to": "Red"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceMappingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing mappings")
+		requireReplaceMappingsResult(t, result, err, replaceMappingsResultOpts{
+			ExpectedFilesChanged: 1,
+			ExpectedTotal:        4,
+			ExpectedCounts:       map[string]int{"Red": 2, "Green": 2},
+		})
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "package colors\n\nconst (\n\tGreen = iota\n\tRed\n\tRedish\n)\n\nvar x = Red + Green\n", string(content),
+			"Mappings should swap without cascading and leave partial words alone")
+	})
+
+	t.Run("GlobPath_ShouldProcessMatchingFiles", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceMappingsDirPrefix)
+		defer tf.Cleanup()
+
+		tf.AddFileFixture("a.txt", &fsfix.FileFixtureArgs{Content: "old value\n"})
+		tf.AddFileFixture("b.txt", &fsfix.FileFixtureArgs{Content: "another old value\n"})
+		skipped := tf.AddFileFixture("c.md", &fsfix.FileFixtureArgs{Content: "old value\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          filepath.Join(tf.TempDir(), "*.txt"),
+			"mappings": []any{
+				map[string]any{"from": "old", "to": "new"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceMappingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing mappings")
+		requireReplaceMappingsResult(t, result, err, replaceMappingsResultOpts{
+			ExpectedFilesChanged: 2,
+			ExpectedTotal:        2,
+		})
+
+		content, err := os.ReadFile(skipped.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "old value\n", string(content), "Files not matching the glob should be unchanged")
+	})
+
+	t.Run("InvalidMapping_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceMappingsDirPrefix)
+		defer tf.Cleanup()
+
+		ff := tf.AddFileFixture("a.txt", &fsfix.FileFixtureArgs{Content: "text\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          ff.Filepath,
+			"mappings": []any{
+				map[string]any{"from": "", "to": "new"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceMappingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error on invalid mapping")
+		requireReplaceMappingsResult(t, result, err, replaceMappingsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "non-empty 'from'",
+		})
+	})
+
+	t.Run("InvalidSyntax_ShouldWriteNoFiles", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceMappingsDirPrefix)
+		defer tf.Cleanup()
+
+		txt := tf.AddFileFixture("a.txt", &fsfix.FileFixtureArgs{Content: "func value\n"})
+		goFile := tf.AddFileFixture("b.go", &fsfix.FileFixtureArgs{Content: "package b\n\nfunc value() {}\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"mappings": []any{
+				map[string]any{"from": "func", "to": "fn"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceMappingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error on invalid syntax")
+		requireReplaceMappingsResult(t, result, err, replaceMappingsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "b.go",
+		})
+
+		content, err := os.ReadFile(txt.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "func value\n", string(content), "No file should be written when any would be invalid")
+		content, err = os.ReadFile(goFile.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "package b\n\nfunc value() {}\n", string(content), "Invalid file should be unchanged")
+	})
+
+	t.Run("WriteFailure_ShouldRollBack", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplaceMappingsDirPrefix)
+		defer tf.Cleanup()
+
+		first := tf.AddFileFixture("a.txt", &fsfix.FileFixtureArgs{Content: "old value\n"})
+		locked := tf.AddFileFixture("b.txt", &fsfix.FileFixtureArgs{Content: "old value\n"})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		require.NoError(t, mcputil.SetFileLockMode(mcputil.RefuseLockedFile))
+		defer func() {
+			mcputil.ClearFileLocks(otherToken)
+			require.NoError(t, mcputil.SetFileLockMode(mcputil.WarnOnLockedFile))
+		}()
+		_, err := mcputil.LockFile(otherToken, locked.Filepath, 0)
+		require.NoError(t, err, "Other session should lock the file")
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          tf.TempDir(),
+			"mappings": []any{
+				map[string]any{"from": "old", "to": "new"},
+			},
+		})
+
+		result, err := mcputil.GetToolResult[ReplaceMappingsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error writing a locked file")
+		requireReplaceMappingsResult(t, result, err, replaceMappingsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "rolled back 1 file(s)",
+		})
+
+		content, err := os.ReadFile(first.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "old value\n", string(content), "File written before the failure should be restored")
+	})
+
+}
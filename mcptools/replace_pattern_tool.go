@@ -17,6 +17,7 @@ func init() {
 			Name:        "replace_pattern",
 			Description: "Find and replace text patterns in a file with support for regex",
 			QuickHelp:   "Find and replace text",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				PathProperty.Required(),
@@ -24,6 +25,11 @@ func init() {
 				ReplacementProperty.Required(),
 				RegexProperty,
 				AllOccurrencesProperty,
+				MaxReplacementsProperty,
+				StartLineProperty,
+				EndLineProperty,
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -35,14 +41,19 @@ type ReplacePatternTool struct {
 }
 
 // Handle processes the replace_pattern tool request and performs text replacements.
-func (t *ReplacePatternTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *ReplacePatternTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var pattern string
 	var replacement string
 	var useRegex bool
 	var allOccurrences bool
+	var maxReplacements int
+	var startLine, endLine int
 	var replacementCount int
 	var message string
+	var originalContent, updatedContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "replace_pattern")
 
@@ -71,10 +82,49 @@ func (t *ReplacePatternTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
-	replacementCount, err = t.replaceInFile(filePath, pattern, replacement, useRegex, allOccurrences)
+	maxReplacements, err = MaxReplacementsProperty.Int(req)
 	if err != nil {
 		goto end
 	}
+	if maxReplacements < 0 {
+		err = fmt.Errorf("max_replacements must not be negative, got %d", maxReplacements)
+		goto end
+	}
+
+	startLine, err = StartLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("start_line must be a valid number: %w", err)
+		goto end
+	}
+
+	endLine, err = EndLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("end_line must be a valid number: %w", err)
+		goto end
+	}
+
+	if startLine == 0 && endLine > 0 {
+		startLine = 1
+	}
+
+	err = t.validateLineRange(startLine, endLine)
+	if err != nil {
+		goto end
+	}
+
+	diffOpts, err = getDiffOptions(req)
+	if err != nil {
+		goto end
+	}
+
+	originalContent, updatedContent, replacementCount, err = t.replaceInFile(ctx, filePath, pattern, replacement, useRegex, replacementLimit(allOccurrences, maxReplacements), startLine, endLine)
+	if err != nil {
+		goto end
+	}
+
+	if replacementCount > 0 {
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+	}
 
 	if replacementCount == 0 {
 		message = fmt.Sprintf("Pattern '%s' not found in %s", pattern, filePath)
@@ -84,7 +134,7 @@ func (t *ReplacePatternTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		message = fmt.Sprintf("Successfully replaced %d occurrences of '%s' in %s", replacementCount, pattern, filePath)
 	}
 
-	result = mcputil.NewToolResultJSON(map[string]any{
+	fields = map[string]any{
 		"success":           replacementCount > 0,
 		"file_path":         filePath,
 		"pattern":           pattern,
@@ -92,8 +142,15 @@ func (t *ReplacePatternTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		"replacement_count": replacementCount,
 		"use_regex":         useRegex,
 		"all_occurrences":   allOccurrences,
+		"max_replacements":  maxReplacements,
 		"message":           message,
-	})
+	}
+	if startLine > 0 || endLine > 0 {
+		fields["start_line"] = startLine
+		fields["end_line"] = endLine
+	}
+	diffOpts.addDiff(fields, filePath, originalContent, updatedContent)
+	result = mcputil.NewToolResultJSON(fields)
 
 	logger.Info("Tool completed", "tool", "replace_pattern", "path", filePath, "replacements", replacementCount)
 
@@ -101,9 +158,29 @@ end:
 	return result, err
 }
 
-func (t *ReplacePatternTool) replaceInFile(filePath, pattern, replacement string, useRegex, allOccurrences bool) (count int, err error) {
-	var originalContent string
-	var updatedContent string
+// validateLineRange checks the optional start_line and end_line, where zero
+// means the parameter was not given.
+func (t *ReplacePatternTool) validateLineRange(startLine, endLine int) (err error) {
+	if startLine < 0 {
+		err = fmt.Errorf("start_line must be >= 1, got %d", startLine)
+		goto end
+	}
+
+	if endLine < 0 || endLine > 0 && endLine < startLine {
+		err = fmt.Errorf("end_line (%d) must be >= start_line (%d)", endLine, startLine)
+		goto end
+	}
+
+end:
+	return err
+}
+
+// replaceInFile replaces up to limit matches of pattern in filePath, only
+// within lines startLine to endLine when startLine is not zero, and writes
+// the file back.
+func (t *ReplacePatternTool) replaceInFile(ctx context.Context, filePath, pattern, replacement string, useRegex bool, limit, startLine, endLine int) (originalContent, updatedContent string, count int, err error) {
+	var lines []string
+	var before, within, after string
 
 	if !t.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
@@ -115,104 +192,131 @@ func (t *ReplacePatternTool) replaceInFile(filePath, pattern, replacement string
 		goto end
 	}
 
-	updatedContent, count, err = t.performReplacement(originalContent, pattern, replacement, useRegex, allOccurrences)
+	within = originalContent
+	if startLine > 0 {
+		lines = strings.Split(originalContent, "\n")
+		if endLine == 0 {
+			endLine = len(lines)
+		}
+		err = t.validateLineNumbers(lines, startLine, endLine)
+		if err != nil {
+			goto end
+		}
+		before, within, after = splitLineRange(lines, startLine, endLine)
+	}
+
+	updatedContent, count, err = t.performReplacement(within, pattern, replacement, useRegex, limit)
 	if err != nil {
 		goto end
 	}
+	updatedContent = before + updatedContent + after
 
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
 
 end:
-	return count, err
+	return originalContent, updatedContent, count, err
 }
 
-func (t *ReplacePatternTool) performReplacement(content, pattern, replacement string, useRegex, allOccurrences bool) (result string, count int, err error) {
-	if useRegex {
-		result, count, err = t.regexReplace(content, pattern, replacement, allOccurrences)
-	} else {
-		result, count = t.stringReplace(content, pattern, replacement, allOccurrences)
-	}
-
-	return result, count, err
-}
+func (t *ReplacePatternTool) validateLineNumbers(lines []string, startLine, endLine int) (err error) {
+	totalLines := len(lines)
 
-func (t *ReplacePatternTool) regexReplace(content, pattern, replacement string, allOccurrences bool) (result string, count int, err error) {
-	var re *regexp.Regexp
-
-	re, err = regexp.Compile(pattern)
-	if err != nil {
-		err = fmt.Errorf("invalid regex pattern: %w", err)
+	if startLine > totalLines {
+		err = fmt.Errorf("start_line %d exceeds file length %d", startLine, totalLines)
 		goto end
 	}
 
-	if allOccurrences {
-		result, count = t.regexReplaceAll(re, content, replacement)
-	} else {
-		result, count = t.regexReplaceFirst(re, content, replacement)
+	if endLine > totalLines {
+		err = fmt.Errorf("end_line %d exceeds file length %d", endLine, totalLines)
+		goto end
 	}
 
 end:
-	return result, count, err
+	return err
 }
 
-func (t *ReplacePatternTool) regexReplaceAll(re *regexp.Regexp, content, replacement string) (result string, count int) {
-	matches := re.FindAllStringIndex(content, -1)
-	count = len(matches)
-
-	if count > 0 {
-		result = re.ReplaceAllString(content, replacement)
-	} else {
-		result = content
+// splitLineRange splits lines into the text before line startLine, the
+// text of lines startLine to endLine, and the text after line endLine, such
+// that concatenating the three gives back the whole of the joined lines.
+func splitLineRange(lines []string, startLine, endLine int) (before, within, after string) {
+	before = strings.Join(lines[:startLine-1], "\n")
+	if startLine > 1 {
+		before += "\n"
 	}
-
-	return result, count
+	within = strings.Join(lines[startLine-1:endLine], "\n")
+	after = strings.Join(lines[endLine:], "\n")
+	if endLine < len(lines) {
+		after = "\n" + after
+	}
+	return before, within, after
 }
 
-func (t *ReplacePatternTool) regexReplaceFirst(re *regexp.Regexp, content, replacement string) (result string, count int) {
-	loc := re.FindStringIndex(content)
-
-	if loc != nil {
-		result = content[:loc[0]] + re.ReplaceAllString(content[loc[0]:loc[1]], replacement) + content[loc[1]:]
-		count = 1
-	} else {
-		result = content
-		count = 0
+// replacementLimit returns how many matches to replace, or -1 for all of
+// them: one unless allOccurrences, and no more than maxReplacements when it
+// is not zero.
+func replacementLimit(allOccurrences bool, maxReplacements int) (limit int) {
+	limit = -1
+	if !allOccurrences {
+		limit = 1
 	}
-
-	return result, count
+	if maxReplacements > 0 && (limit < 0 || maxReplacements < limit) {
+		limit = maxReplacements
+	}
+	return limit
 }
 
-func (t *ReplacePatternTool) stringReplace(content, pattern, replacement string, allOccurrences bool) (result string, count int) {
-	if allOccurrences {
-		result, count = t.stringReplaceAll(content, pattern, replacement)
+func (t *ReplacePatternTool) performReplacement(content, pattern, replacement string, useRegex bool, limit int) (result string, count int, err error) {
+	if useRegex {
+		result, count, err = t.regexReplace(content, pattern, replacement, limit)
 	} else {
-		result, count = t.stringReplaceFirst(content, pattern, replacement)
+		result, count = t.stringReplace(content, pattern, replacement, limit)
 	}
 
-	return result, count
+	return result, count, err
 }
 
-func (t *ReplacePatternTool) stringReplaceAll(content, pattern, replacement string) (result string, count int) {
-	count = strings.Count(content, pattern)
+func (t *ReplacePatternTool) regexReplace(content, pattern, replacement string, limit int) (result string, count int, err error) {
+	var re *regexp.Regexp
+	var matches [][]int
+	var sb strings.Builder
+	var last int
 
-	if count > 0 {
-		result = strings.ReplaceAll(content, pattern, replacement)
-	} else {
+	re, err = regexp.Compile(pattern)
+	if err != nil {
+		err = fmt.Errorf("invalid regex pattern: %w", err)
+		goto end
+	}
+
+	// Expand each match separately, rather than using ReplaceAllString, so
+	// that $1-style group references work when only some matches are replaced
+	matches = re.FindAllStringSubmatchIndex(content, limit)
+	count = len(matches)
+	if count == 0 {
 		result = content
+		goto end
 	}
 
-	return result, count
+	for _, match := range matches {
+		sb.WriteString(content[last:match[0]])
+		sb.Write(re.ExpandString(nil, replacement, content, match))
+		last = match[1]
+	}
+	sb.WriteString(content[last:])
+	result = sb.String()
+
+end:
+	return result, count, err
 }
 
-func (t *ReplacePatternTool) stringReplaceFirst(content, pattern, replacement string) (result string, count int) {
-	idx := strings.Index(content, pattern)
+func (t *ReplacePatternTool) stringReplace(content, pattern, replacement string, limit int) (result string, count int) {
+	count = strings.Count(content, pattern)
+	if limit >= 0 && count > limit {
+		count = limit
+	}
 
-	if idx != -1 {
-		result = content[:idx] + replacement + content[idx+len(pattern):]
-		count = 1
+	if count > 0 {
+		result = strings.Replace(content, pattern, replacement, count)
 	} else {
 		result = content
-		count = 0
 	}
 
 	return result, count
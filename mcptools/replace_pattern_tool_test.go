@@ -20,6 +20,7 @@ type ReplacePatternResult struct {
 	Replacement      string `json:"replacement"`
 	ReplacementCount int    `json:"replacement_count"`
 	Message          string `json:"message"`
+	Diff             string `json:"diff"`
 }
 
 type replacePatternResultOpts struct {
@@ -33,6 +34,7 @@ type replacePatternResultOpts struct {
 	ShouldUpdateFile         bool
 	ShouldContainText        string
 	ShouldNotContainText     string
+	ExpectedDiff             string
 }
 
 func requireReplacePatternResult(t *testing.T, result *ReplacePatternResult, err error, opts replacePatternResultOpts) {
@@ -67,6 +69,8 @@ func requireReplacePatternResult(t *testing.T, result *ReplacePatternResult, err
 		assert.Equal(t, opts.ExpectedReplacementCount, result.ReplacementCount, "Replacement count should match expected")
 	}
 
+	assert.Equal(t, opts.ExpectedDiff, result.Diff, "Diff should match expected")
+
 	// Check file system side effects
 	if opts.ShouldUpdateFile && opts.ExpectedFilePath != "" {
 		_, err := os.Stat(opts.ExpectedFilePath)
@@ -131,6 +135,40 @@ func TestReplacePatternTool(t *testing.T) {
 		})
 	})
 
+	t.Run("IncludeDiff_ShouldReturnDiffWithThreeLinesOfContext", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("replace-diff-project", nil)
+		testFile := pf.AddFileFixture("replace_diff_test.txt", &fsfix.FileFixtureArgs{
+			Content: "one\ntwo\nthree\nfour\nold\nsix\nseven\neight\nnine\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"pattern":       "old",
+			"replacement":   "five",
+			"include_diff":  true,
+		})
+
+		result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing with diff")
+
+		requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+			ExpectedFilePath:         testFile.Filepath,
+			ExpectedReplacementCount: 1,
+			ShouldUpdateFile:         true,
+			ExpectedContent:          "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\n",
+			ExpectedDiff: "--- a/" + testFile.Filepath + "\n+++ b/" + testFile.Filepath + "\n" +
+				"@@ -2,7 +2,7 @@\n two\n three\n four\n-old\n+five\n six\n seven\n eight\n",
+		})
+	})
+
 	t.Run("ReplaceFirstOnly_ShouldReplaceOnlyFirstOccurrence", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
 		defer tf.Cleanup()
@@ -205,6 +243,167 @@ func TestReplacePatternTool(t *testing.T) {
 		assert.Contains(t, string(content), "function functionTwo(", "Should replace functionTwo")
 	})
 
+	t.Run("MaxReplacements_ShouldReplaceOnlyFirstNOccurrences", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("max-replacements-project", nil)
+		testFile := pf.AddFileFixture("max_test.txt", &fsfix.FileFixtureArgs{
+			Content: "old old old old\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             testFile.Filepath,
+			"pattern":          "old",
+			"replacement":      "new",
+			"max_replacements": 3,
+		})
+
+		result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing capped occurrences")
+
+		requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+			ExpectedFilePath:         testFile.Filepath,
+			ExpectedReplacementCount: 3,
+			ExpectedContent:          "new new new old\n",
+			ShouldUpdateFile:         true,
+		})
+	})
+
+	t.Run("RegexMaxReplacements_ShouldExpandGroupsInCappedMatches", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("regex-max-project", nil)
+		testFile := pf.AddFileFixture("regex_max.txt", &fsfix.FileFixtureArgs{
+			Content: "func one() {}\nfunc two() {}\nfunc three() {}\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             testFile.Filepath,
+			"pattern":          "(?m)^func (\\w+)\\(",
+			"replacement":      "func ${1}Renamed(",
+			"regex":            true,
+			"max_replacements": 2,
+		})
+
+		result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error with capped regex replacement")
+
+		requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+			ExpectedFilePath:         testFile.Filepath,
+			ExpectedReplacementCount: 2,
+			ExpectedContent:          "func oneRenamed() {}\nfunc twoRenamed() {}\nfunc three() {}\n",
+			ShouldUpdateFile:         true,
+		})
+	})
+
+	t.Run("NegativeMaxReplacements_ShouldReturnError", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":    testToken,
+			"path":             "test.txt",
+			"pattern":          "old",
+			"replacement":      "new",
+			"max_replacements": -1,
+		})
+
+		result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject negative max_replacements")
+
+		requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "max_replacements must not be negative",
+		})
+	})
+
+	t.Run("LineRange_ShouldReplaceOnlyWithinRange", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("line-range-project", nil)
+		testFile := pf.AddFileFixture("range_test.txt", &fsfix.FileFixtureArgs{
+			Content: "old one\nold two\nold three\nold four\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          testFile.Filepath,
+			"pattern":       "old",
+			"replacement":   "new",
+			"start_line":    2,
+			"end_line":      3,
+		})
+
+		result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error replacing within line range")
+
+		requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+			ExpectedFilePath:         testFile.Filepath,
+			ExpectedReplacementCount: 2,
+			ExpectedContent:          "old one\nnew two\nnew three\nold four\n",
+			ShouldUpdateFile:         true,
+		})
+	})
+
+	t.Run("InvalidLineRange_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("invalid-range-project", nil)
+		testFile := pf.AddFileFixture("range_test.txt", &fsfix.FileFixtureArgs{
+			Content: "old one\nold two\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		tests := []struct {
+			name             string
+			startLine        int
+			endLine          int
+			expectedErrorMsg string
+		}{
+			{name: "EndBeforeStart", startLine: 2, endLine: 1, expectedErrorMsg: "end_line (1) must be >= start_line (2)"},
+			{name: "EndBeyondFile", startLine: 1, endLine: 9, expectedErrorMsg: "end_line 9 exceeds file length 3"},
+			{name: "StartBeyondFile", startLine: 7, endLine: 9, expectedErrorMsg: "start_line 7 exceeds file length 3"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := mcputil.NewMockRequest(mcputil.Params{
+					"session_token": testToken,
+					"path":          testFile.Filepath,
+					"pattern":       "old",
+					"replacement":   "new",
+					"start_line":    tt.startLine,
+					"end_line":      tt.endLine,
+				})
+
+				result, err := mcputil.GetToolResult[ReplacePatternResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the line range")
+
+				requireReplacePatternResult(t, result, err, replacePatternResultOpts{
+					ExpectError:      true,
+					ExpectedErrorMsg: tt.expectedErrorMsg,
+				})
+				requireFileContent(t, testFile.Filepath, "old one\nold two\n")
+			})
+		}
+	})
+
 	t.Run("InvalidRegex_ShouldReturnErrorWithMessage", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(ReplacePatternDirPrefix)
 		defer tf.Cleanup()
@@ -0,0 +1,85 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*RequestConfirmationTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&RequestConfirmationTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "request_confirmation",
+			Description: "Obtain a short-lived, single-use confirmation_token describing an exact destructive operation. In safe mode, delete_files, update_file and write_binary_file only proceed when passed a matching, unexpired confirmation_token",
+			QuickHelp:   "Confirm a destructive operation in safe mode",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				OperationProperty.Required(),
+				RequiredPathsProperty.Description("Exact paths the operation will affect"),
+			},
+		}),
+	})
+}
+
+// RequestConfirmationTool issues confirmation tokens for destructive operations.
+type RequestConfirmationTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the request_confirmation tool request and returns a confirmation token.
+func (t *RequestConfirmationTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var operation string
+	var paths []string
+	var op mcputil.ConfirmableOperation
+	var confirmation mcputil.Confirmation
+
+	logger.Info("Tool called", "tool", "request_confirmation")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	operation, err = OperationProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	op = mcputil.ConfirmableOperation(operation)
+
+	paths, err = RequiredPathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "request_confirmation", "operation", operation, "paths", paths)
+
+	for _, path := range paths {
+		if !t.IsAllowedPath(path) {
+			err = fmt.Errorf("access denied: path not allowed: %s", path)
+			goto end
+		}
+	}
+
+	confirmation, err = mcputil.RequestConfirmation(token, op, paths)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "request_confirmation", "operation", operation, "expires_at", confirmation.ExpiresAt)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"confirmation_token": confirmation.Token,
+		"operation":          confirmation.Operation,
+		"paths":              confirmation.Paths,
+		"description":        confirmation.Description,
+		"expires_at":         confirmation.ExpiresAt,
+		"required":           mcputil.RequiresConfirmation(op),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,203 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const RequestConfirmationDirPrefix = "request-confirmation-tool-test"
+
+// Request confirmation tool result type
+type RequestConfirmationResult struct {
+	ConfirmationToken string   `json:"confirmation_token"`
+	Operation         string   `json:"operation"`
+	Paths             []string `json:"paths"`
+	Description       string   `json:"description"`
+	Required          bool     `json:"required"`
+}
+
+type requestConfirmationResultOpts struct {
+	ExpectError         bool
+	ExpectedErrorMsg    string
+	ExpectedOperation   string
+	ExpectedDescription string
+	ExpectRequired      bool
+}
+
+func requireRequestConfirmationResult(t *testing.T, result *RequestConfirmationResult, err error, opts requestConfirmationResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.NotEmpty(t, result.ConfirmationToken, "Should issue a confirmation token")
+	assert.Equal(t, opts.ExpectedOperation, result.Operation, "Operation should match")
+	assert.Equal(t, opts.ExpectedDescription, result.Description, "Description should match")
+	assert.Equal(t, opts.ExpectRequired, result.Required, "Required flag should match")
+}
+
+func TestRequestConfirmationTool(t *testing.T) {
+	// Get the tools
+	tool := mcputil.GetRegisteredTool("request_confirmation")
+	require.NotNil(t, tool, "request_confirmation tool should be registered")
+	deleteTool := mcputil.GetRegisteredTool("delete_files")
+	require.NotNil(t, deleteTool, "delete_files tool should be registered")
+	updateTool := mcputil.GetRegisteredTool("update_file")
+	require.NotNil(t, updateTool, "update_file tool should be registered")
+
+	setup := func(t *testing.T, ops ...mcputil.ConfirmableOperation) (*fsfix.RootFixture, *fsfix.FileFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(RequestConfirmationDirPrefix)
+		doomed := tf.AddFileFixture("doomed.txt", &fsfix.FileFixtureArgs{Content: "doomed\n"})
+		other := tf.AddFileFixture("other.txt", &fsfix.FileFixtureArgs{Content: "other\n"})
+		tf.Setup(t)
+		config := mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		})
+		tool.SetConfig(config)
+		deleteTool.SetConfig(config)
+		updateTool.SetConfig(config)
+		require.NoError(t, mcputil.SetSafeMode(true, ops), "Should enable safe mode")
+		return tf, doomed, other
+	}
+
+	cleanup := func(tf *fsfix.RootFixture) {
+		require.NoError(t, mcputil.SetSafeMode(false, nil))
+		mcputil.ClearConfirmations(testToken)
+		tf.Cleanup()
+	}
+
+	requestConfirmation := func(operation string, paths ...string) (*RequestConfirmationResult, error) {
+		anyPaths := make([]any, len(paths))
+		for i, p := range paths {
+			anyPaths[i] = p
+		}
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"operation":     operation,
+			"paths":         anyPaths,
+		})
+		return mcputil.GetToolResult[RequestConfirmationResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error requesting confirmation")
+	}
+
+	deleteFile := func(path string, params mcputil.Params) error {
+		params["session_token"] = testToken
+		params["path"] = path
+		_, err := mcputil.CallTool(deleteTool, mcputil.NewMockRequest(params))
+		return err
+	}
+
+	t.Run("DeleteWithoutToken_ShouldFail", func(t *testing.T) {
+		tf, doomed, _ := setup(t)
+		defer cleanup(tf)
+
+		err := deleteFile(doomed.Filepath, mcputil.Params{})
+		require.ErrorIs(t, err, mcputil.ErrConfirmationRequired, "Delete should require confirmation")
+		assert.FileExists(t, doomed.Filepath, "File should not be deleted")
+	})
+
+	t.Run("DeleteWithToken_ShouldSucceedOnce", func(t *testing.T) {
+		tf, doomed, _ := setup(t)
+		defer cleanup(tf)
+
+		result, err := requestConfirmation("delete", doomed.Filepath)
+		requireRequestConfirmationResult(t, result, err, requestConfirmationResultOpts{
+			ExpectedOperation:   "delete",
+			ExpectedDescription: "Delete " + doomed.Filepath,
+			ExpectRequired:      true,
+		})
+
+		err = deleteFile(doomed.Filepath, mcputil.Params{"confirmation_token": result.ConfirmationToken})
+		require.NoError(t, err, "Delete should proceed with confirmation")
+		assert.NoFileExists(t, doomed.Filepath, "File should be deleted")
+
+		require.NoError(t, os.WriteFile(doomed.Filepath, []byte("again\n"), 0644))
+		err = deleteFile(doomed.Filepath, mcputil.Params{"confirmation_token": result.ConfirmationToken})
+		require.ErrorIs(t, err, mcputil.ErrConfirmationRequired, "Confirmation should be single use")
+	})
+
+	t.Run("MismatchedPath_ShouldFail", func(t *testing.T) {
+		tf, doomed, other := setup(t)
+		defer cleanup(tf)
+
+		result, err := requestConfirmation("delete", other.Filepath)
+		require.NoError(t, err, "Should issue confirmation")
+
+		err = deleteFile(doomed.Filepath, mcputil.Params{"confirmation_token": result.ConfirmationToken})
+		require.ErrorIs(t, err, mcputil.ErrConfirmationRequired, "Confirmation for another path should not apply")
+		assert.FileExists(t, doomed.Filepath, "File should not be deleted")
+	})
+
+	t.Run("RecursiveDelete_ShouldNeedMatchingOperation", func(t *testing.T) {
+		tf, _, _ := setup(t)
+		defer cleanup(tf)
+
+		dir := tf.TempDir()
+		result, err := requestConfirmation("delete", dir)
+		require.NoError(t, err, "Should issue confirmation")
+
+		err = deleteFile(dir, mcputil.Params{"recursive": true, "confirmation_token": result.ConfirmationToken})
+		require.ErrorIs(t, err, mcputil.ErrConfirmationRequired, "A delete confirmation should not cover a recursive delete")
+		assert.DirExists(t, dir, "Directory should not be deleted")
+	})
+
+	t.Run("DryRun_ShouldNotNeedToken", func(t *testing.T) {
+		tf, doomed, _ := setup(t)
+		defer cleanup(tf)
+
+		err := deleteFile(doomed.Filepath, mcputil.Params{"dry_run": true})
+		require.NoError(t, err, "Dry runs should not require confirmation")
+		assert.FileExists(t, doomed.Filepath, "File should not be deleted")
+	})
+
+	t.Run("OverwriteOnly_ShouldNotConfirmDelete", func(t *testing.T) {
+		tf, doomed, other := setup(t, mcputil.OverwriteOperation)
+		defer cleanup(tf)
+
+		_, err := mcputil.CallTool(updateTool, mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      other.Filepath,
+			"new_content":   "replaced\n",
+		}))
+		require.Error(t, err, "Overwrite should require confirmation")
+		assert.Contains(t, err.Error(), mcputil.ErrConfirmationRequired.Error(), "Error should report confirmation is required")
+
+		err = deleteFile(doomed.Filepath, mcputil.Params{})
+		require.NoError(t, err, "Delete should not require confirmation when not configured")
+	})
+
+	t.Run("SafeModeOff_ShouldReportNotRequired", func(t *testing.T) {
+		tf, doomed, _ := setup(t)
+		defer cleanup(tf)
+		require.NoError(t, mcputil.SetSafeMode(false, nil))
+
+		result, err := requestConfirmation("overwrite", doomed.Filepath)
+		requireRequestConfirmationResult(t, result, err, requestConfirmationResultOpts{
+			ExpectedOperation:   "overwrite",
+			ExpectedDescription: "Overwrite the entire content of " + doomed.Filepath,
+			ExpectRequired:      false,
+		})
+	})
+
+	t.Run("InvalidOperation_ShouldError", func(t *testing.T) {
+		tf, doomed, _ := setup(t)
+		defer cleanup(tf)
+
+		result, err := requestConfirmation("truncate", doomed.Filepath)
+		requireRequestConfirmationResult(t, result, err, requestConfirmationResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "truncate",
+		})
+	})
+}
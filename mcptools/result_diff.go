@@ -0,0 +1,56 @@
+package mcptools
+
+import (
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+// diffOptions holds a request's include_diff and diff_context parameters,
+// which ask a file editing tool to return a unified diff of its change.
+type diffOptions struct {
+	Include bool // Whether to add a "diff" field to the result
+	Context int  // Unchanged lines shown around each change
+}
+
+// getDiffOptions returns the validated include_diff and diff_context of the
+// request.
+func getDiffOptions(req mcputil.ToolRequest) (opts diffOptions, err error) {
+	opts.Include, err = IncludeDiffProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	opts.Context, err = DiffContextProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if opts.Context < 0 {
+		err = fmt.Errorf("diff_context must not be negative, got %d", opts.Context)
+		goto end
+	}
+
+end:
+	return opts, err
+}
+
+// addDiff sets fields["diff"] to the unified diff of path from before to
+// after when the request set include_diff. The diff is empty when nothing
+// changed. As the file has already been written, a diff that cannot be
+// produced is reported in fields["diff_error"] rather than as an error.
+func (opts diffOptions) addDiff(fields map[string]any, path, before, after string) {
+	var diff string
+	var err error
+
+	if !opts.Include {
+		goto end
+	}
+	diff, err = mcputil.UnifiedDiffContext("a/"+path, "b/"+path, before, after, opts.Context)
+	if err != nil {
+		fields["diff_error"] = err.Error()
+		goto end
+	}
+	fields["diff"] = diff
+
+end:
+}
@@ -0,0 +1,78 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*RevokeSessionTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&RevokeSessionTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "revoke_session",
+			Description: "Revoke a session token before it expires, such as to log out or to cut off a session running somewhere no longer trusted. The token stops working immediately, including for a server restart, and the session's changed files, file locks, confirmations and edit backups are discarded. Revokes this session's own token unless another is given",
+			QuickHelp:   "Revoke a session token immediately",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RevokeTokenProperty,
+			},
+		}),
+	})
+}
+
+// RevokeSessionTool ends a session before its token expires.
+type RevokeSessionTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the revoke_session tool request and revokes the token.
+func (t *RevokeSessionTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var sessionToken string
+	var token string
+	var exists bool
+
+	logger.Info("Tool called", "tool", "revoke_session")
+
+	sessionToken, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	token, err = RevokeTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if token == "" {
+		token = sessionToken
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "revoke_session",
+		"session_id", mcputil.SessionID(token),
+		"own_session", token == sessionToken)
+
+	// Look the session up first so that tokens persisted by another server
+	// sharing the session store are found too
+	_, exists = mcputil.GetSession(token)
+	if !exists || !mcputil.ClearSession(token) {
+		err = fmt.Errorf("%w: %w", mcputil.ErrInvalidSessionToken, mcputil.ErrTokenNotFound)
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "revoke_session",
+		"session_id", mcputil.SessionID(token))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":     true,
+		"revoked":     true,
+		"session_id":  mcputil.SessionID(token),
+		"own_session": token == sessionToken,
+		"message":     fmt.Sprintf("Revoked session %s", mcputil.SessionID(token)),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,108 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Revoke session tool result type
+type RevokeSessionResult struct {
+	Success    bool   `json:"success"`
+	Revoked    bool   `json:"revoked"`
+	SessionID  string `json:"session_id"`
+	OwnSession bool   `json:"own_session"`
+	Message    string `json:"message"`
+}
+
+type revokeSessionResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedSessionID string
+	ExpectOwnSession  bool
+}
+
+func requireRevokeSessionResult(t *testing.T, result *RevokeSessionResult, err error, opts revokeSessionResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should be successful")
+	assert.True(t, result.Revoked, "Should be revoked")
+	assert.Equal(t, opts.ExpectedSessionID, result.SessionID, "Session ID should match")
+	assert.Equal(t, opts.ExpectOwnSession, result.OwnSession, "Own session should match")
+}
+
+func TestRevokeSessionTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("revoke_session")
+	require.NotNil(t, tool, "revoke_session tool should be registered")
+
+	newSession := func(t *testing.T) string {
+		session := mcputil.NewSession()
+		require.NoError(t, session.Initialize(), "Should create session")
+		return session.Token
+	}
+
+	revoke := func(params mcputil.Params) (*RevokeSessionResult, error) {
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[RevokeSessionResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error revoking session")
+	}
+
+	t.Run("OwnSession_ShouldStopWorkingImmediately", func(t *testing.T) {
+		token := newSession(t)
+		mcputil.RecordFileChange(token, mcputil.UpdatedFileOp, "/tmp/revoked.go")
+
+		result, err := revoke(mcputil.Params{
+			"session_token": token,
+		})
+		requireRevokeSessionResult(t, result, err, revokeSessionResultOpts{
+			ExpectedSessionID: mcputil.SessionID(token),
+			ExpectOwnSession:  true,
+		})
+
+		assert.ErrorIs(t, mcputil.ValidateSession(token), mcputil.ErrTokenNotFound, "Revoked token should fail validation")
+		assert.Equal(t, 0, mcputil.GetChangedFiles(token).Count(), "Session state should be discarded")
+	})
+
+	t.Run("OtherSession_ShouldRevokeOnlyThatToken", func(t *testing.T) {
+		token := newSession(t)
+		defer mcputil.ClearSession(token)
+		other := newSession(t)
+
+		result, err := revoke(mcputil.Params{
+			"session_token": token,
+			"token":         other,
+		})
+		requireRevokeSessionResult(t, result, err, revokeSessionResultOpts{
+			ExpectedSessionID: mcputil.SessionID(other),
+		})
+
+		assert.ErrorIs(t, mcputil.ValidateSession(other), mcputil.ErrTokenNotFound, "Revoked token should fail validation")
+		assert.NoError(t, mcputil.ValidateSession(token), "Revoking session should stay valid")
+	})
+
+	t.Run("UnknownToken_ShouldError", func(t *testing.T) {
+		token := newSession(t)
+		defer mcputil.ClearSession(token)
+
+		result, err := revoke(mcputil.Params{
+			"session_token": token,
+			"token":         "unknown-session-token",
+		})
+		requireRevokeSessionResult(t, result, err, revokeSessionResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "invalid or expired session token",
+		})
+	})
+}
@@ -0,0 +1,197 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+)
+
+var _ mcputil.Tool = (*RotateFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&RotateFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "rotate_file",
+			Description: "Rotate an append-only file such as a log once it grows past max_bytes: the file is renamed to path.1, older copies shift to path.2 up to path.<keep>, the oldest copy is discarded, and a new empty file takes its place. The file must be within the allowed paths, or be a .log file in Scout's config directory. Reports whether rotation occurred",
+			QuickHelp:   "Rotate a log file that grew too large",
+			Mutating:    true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to rotate"),
+				MaxBytesProperty,
+				KeepProperty,
+			},
+		}),
+	})
+}
+
+// RotateFileTool renames a file that has grown too large to numbered backups
+// and replaces it with an empty file.
+type RotateFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the rotate_file tool request and rotates the file when it
+// exceeds the size limit.
+func (t *RotateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var maxBytes int
+	var keep int
+	var size int64
+	var rotated bool
+	var message string
+
+	logger.Info("Tool called", "tool", "rotate_file")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	maxBytes, err = MaxBytesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if maxBytes < 0 {
+		err = fmt.Errorf("max_bytes must be >= 0, got %d", maxBytes)
+		goto end
+	}
+
+	keep, err = KeepProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if keep < 1 {
+		err = fmt.Errorf("keep must be >= 1, got %d", keep)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "rotate_file",
+		"path", path,
+		"max_bytes", maxBytes,
+		"keep", keep)
+
+	if !t.IsAllowedPath(path) && !isConfigDirLog(t.Config(), path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	size, rotated, err = rotateFile(path, int64(maxBytes), keep)
+	if err != nil {
+		goto end
+	}
+
+	message = fmt.Sprintf("%s is %d bytes, within max_bytes %d; not rotated", path, size, maxBytes)
+	if rotated {
+		message = fmt.Sprintf("Rotated %s (%d bytes) to %s.1", path, size, path)
+	}
+
+	logger.Info("Tool completed", "tool", "rotate_file", "path", path, "size", size, "rotated", rotated)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":      path,
+		"size":      size,
+		"max_bytes": maxBytes,
+		"keep":      keep,
+		"rotated":   rotated,
+		"message":   message,
+	})
+
+end:
+	return result, err
+}
+
+// rotateFile rotates path with scoutcfg.RotateLog when it is larger than
+// maxBytes, keeping at most keep numbered backups, and returns the file's
+// size before rotation. The new empty file gets the permissions of the
+// rotated one.
+func rotateFile(path string, maxBytes int64, keep int) (size int64, rotated bool, err error) {
+	var info os.FileInfo
+	var f *os.File
+
+	info, err = os.Stat(path)
+	if err != nil {
+		goto end
+	}
+	if info.IsDir() {
+		err = fmt.Errorf("%s is a directory", path)
+		goto end
+	}
+	size = info.Size()
+
+	rotated, err = scoutcfg.RotateLog(scoutcfg.NewOSFS(filepath.Dir(path)), filepath.Base(path), 0, scoutcfg.RotationPolicy{
+		MaxBytes: maxBytes,
+		Keep:     keep,
+	})
+	if err != nil || !rotated {
+		goto end
+	}
+
+	f, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		err = fmt.Errorf("rotated %s but could not create a new empty file: %w", path, err)
+		goto end
+	}
+	err = f.Close()
+
+end:
+	return size, rotated, err
+}
+
+// configDirStateDirs are the directories within Scout's config directory
+// holding session tokens and edit backups, which rotate_file must never touch.
+var configDirStateDirs = []string{"tokens", "edit-backups"}
+
+// isConfigDirLog reports whether path is a log file, one with a .log
+// extension, inside the directory holding cfg's configuration file, where
+// Scout keeps its own logs. The configuration file itself, session tokens
+// and edit backups are never logs.
+func isConfigDirLog(cfg mcputil.Config, path string) (isLog bool) {
+	var configDir string
+	var absPath string
+	var rel string
+	var err error
+
+	if cfg.Path() == "" {
+		goto end
+	}
+
+	if filepath.Ext(path) != ".log" {
+		goto end
+	}
+
+	configDir, err = filepath.Abs(filepath.Dir(cfg.Path()))
+	if err != nil {
+		goto end
+	}
+
+	absPath, err = filepath.Abs(path)
+	if err != nil {
+		goto end
+	}
+
+	rel, err = filepath.Rel(configDir, absPath)
+	if err != nil {
+		goto end
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		goto end
+	}
+
+	if slices.Contains(configDirStateDirs, strings.SplitN(rel, string(filepath.Separator), 2)[0]) {
+		goto end
+	}
+
+	isLog = true
+
+end:
+	return isLog
+}
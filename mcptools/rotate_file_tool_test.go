@@ -0,0 +1,164 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const RotateFileDirPrefix = "rotate-file-tool-test"
+
+// Rotate file tool result type
+type RotateFileResult struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	MaxBytes int    `json:"max_bytes"`
+	Keep     int    `json:"keep"`
+	Rotated  bool   `json:"rotated"`
+	Message  string `json:"message"`
+}
+
+type rotateFileResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectRotated    bool
+	ExpectedSize     int64
+}
+
+func requireRotateFileResult(t *testing.T, result *RotateFileResult, err error, opts rotateFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, opts.ExpectRotated, result.Rotated, "Rotated should match")
+	assert.Equal(t, opts.ExpectedSize, result.Size, "Size should match")
+}
+
+// requireFileContent asserts that path holds content.
+func requireFileContent(t *testing.T, path, content string) {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err, "Should read %s", path)
+	assert.Equal(t, content, string(b), "Content of %s should match", path)
+}
+
+func TestRotateFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("rotate_file")
+	require.NotNil(t, tool, "rotate_file tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.RepoFixture) {
+		tf := fsfix.NewRootFixture(RotateFileDirPrefix)
+		pf := tf.AddRepoFixture("logs", nil)
+		return tf, pf
+	}
+
+	configure := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	call := func(path string, maxBytes, keep int) (*RotateFileResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"max_bytes":     maxBytes,
+			"keep":          keep,
+		})
+		return mcputil.GetToolResult[RotateFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call rotate_file")
+	}
+
+	t.Run("UnderLimit_ShouldNotRotate", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("activity.log", &fsfix.FileFixtureArgs{Content: "short\n"})
+		configure(t, tf)
+
+		result, err := call(ff.Filepath, 100, 3)
+		requireRotateFileResult(t, result, err, rotateFileResultOpts{
+			ExpectedSize: 6,
+		})
+		requireFileContent(t, ff.Filepath, "short\n")
+		assert.NoFileExists(t, ff.Filepath+".1", "No backup should be created")
+	})
+
+	t.Run("OverLimit_ShouldRotateAndShiftBackups", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("activity.log", &fsfix.FileFixtureArgs{Content: "current entries\n"})
+		pf.AddFileFixture("activity.log.1", &fsfix.FileFixtureArgs{Content: "first backup\n"})
+		pf.AddFileFixture("activity.log.2", &fsfix.FileFixtureArgs{Content: "oldest backup\n"})
+		configure(t, tf)
+
+		result, err := call(ff.Filepath, 10, 2)
+		requireRotateFileResult(t, result, err, rotateFileResultOpts{
+			ExpectRotated: true,
+			ExpectedSize:  16,
+		})
+		requireFileContent(t, ff.Filepath, "")
+		requireFileContent(t, ff.Filepath+".1", "current entries\n")
+		requireFileContent(t, ff.Filepath+".2", "first backup\n")
+		assert.NoFileExists(t, ff.Filepath+".3", "Backups beyond keep should be discarded")
+	})
+
+	t.Run("MissingBackups_ShouldRotate", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("activity.log", &fsfix.FileFixtureArgs{Content: "current entries\n"})
+		configure(t, tf)
+
+		result, err := call(ff.Filepath, 0, 5)
+		requireRotateFileResult(t, result, err, rotateFileResultOpts{
+			ExpectRotated: true,
+			ExpectedSize:  16,
+		})
+		requireFileContent(t, ff.Filepath, "")
+		requireFileContent(t, ff.Filepath+".1", "current entries\n")
+	})
+
+	t.Run("InvalidKeep_ShouldError", func(t *testing.T) {
+		tf, pf := setup(t)
+		defer tf.Cleanup()
+
+		ff := pf.AddFileFixture("activity.log", &fsfix.FileFixtureArgs{Content: "current entries\n"})
+		configure(t, tf)
+
+		result, err := call(ff.Filepath, 10, 0)
+		requireRotateFileResult(t, result, err, rotateFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "keep must be >= 1",
+		})
+		requireFileContent(t, ff.Filepath, "current entries\n")
+	})
+
+	t.Run("Directory_ShouldError", func(t *testing.T) {
+		tf, _ := setup(t)
+		defer tf.Cleanup()
+
+		configure(t, tf)
+
+		result, err := call(tf.TempDir(), 0, 1)
+		requireRotateFileResult(t, result, err, rotateFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "is a directory",
+		})
+	})
+}
@@ -0,0 +1,200 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ScanSecretsTool)(nil)
+
+// secretRevealLen is the number of leading characters of a match shown in
+// its redacted form.
+const secretRevealLen = 4
+
+func init() {
+	mcputil.RegisterTool(&ScanSecretsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "scan_secrets",
+			Description: "Scan text files for likely secrets such as AWS keys, private key headers, API tokens and high-entropy strings before they are committed. Reports the file, line and rule of each match with the secret redacted. Binary files, dependency lock files and default excludes such as vendor and node_modules are skipped. Rules can be extended or overridden with the secret_rules config setting",
+			QuickHelp:   "Detect secrets and credentials in files",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathsProperty,
+				RecursiveProperty,
+				ExcludeProperty.Description("Glob patterns of files or directories to exclude in addition to the defaults (e.g., ['testdata', '*.pem'])"),
+			},
+		}),
+	})
+}
+
+// ScanSecretsTool reports likely secrets and credentials found in text files.
+type ScanSecretsTool struct {
+	*mcputil.ToolBase
+}
+
+// SecretMatchResult describes a likely secret found in a file. Only a
+// redacted form of the matched text is reported.
+type SecretMatchResult struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Rule     string `json:"rule"`
+	Redacted string `json:"redacted"`
+}
+
+// Handle processes the scan_secrets tool request and scans the given paths.
+func (t *ScanSecretsTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var paths []string
+	var recursive bool
+	var excludes []string
+	var files []string
+	var rules []SecretRule
+	var matches []SecretMatchResult
+	var binaryCount int
+	var ruleNames []string
+
+	logger.Info("Tool called", "tool", "scan_secrets")
+
+	paths, err = RequiredPathsProperty.StringSlice(req)
+	if err != nil {
+		goto end
+	}
+
+	recursive, err = RecursiveProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "scan_secrets",
+		"paths", paths,
+		"recursive", recursive,
+		"exclude", excludes)
+
+	files, err = collectTreeFiles(t.Config(), treeScanArgs{
+		Paths:     paths,
+		Recursive: recursive,
+		Excludes:  append(defaultSecretExcludes(), excludes...),
+	})
+	if err != nil {
+		goto end
+	}
+
+	rules = currentSecretRules()
+	matches, binaryCount, err = scanSecrets(files, rules)
+	if err != nil {
+		goto end
+	}
+
+	ruleNames = make([]string, len(rules))
+	for i, rule := range rules {
+		ruleNames[i] = rule.Name
+	}
+
+	logger.Info("Tool completed", "tool", "scan_secrets",
+		"files_scanned", len(files)-binaryCount,
+		"match_count", len(matches))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"paths":          paths,
+		"matches":        matches,
+		"match_count":    len(matches),
+		"files_scanned":  len(files) - binaryCount,
+		"binary_skipped": binaryCount,
+		"rules":          ruleNames,
+	})
+
+end:
+	return result, err
+}
+
+// defaultSecretExcludes returns the glob patterns scan_secrets always skips:
+// the default directory excludes plus dependency lock files, whose checksums
+// would otherwise be reported as high-entropy strings.
+func defaultSecretExcludes() []string {
+	return append(golang.DefaultExcludes(),
+		"go.sum",
+		"package-lock.json",
+		"yarn.lock",
+		"pnpm-lock.yaml",
+	)
+}
+
+// scanSecrets applies rules to each line of the text files in files and
+// returns the matches, along with the number of binary files skipped. Text
+// matched by more than one rule is reported only for the first of them.
+func scanSecrets(files []string, rules []SecretRule) (matches []SecretMatchResult, binaryCount int, err error) {
+	var content []byte
+
+	matches = make([]SecretMatchResult, 0)
+	for _, fp := range files {
+		content, err = os.ReadFile(fp)
+		if err != nil {
+			err = fmt.Errorf("failed to read %s: %v", fp, err)
+			goto end
+		}
+		if isBinaryContent(content) {
+			binaryCount++
+			continue
+		}
+		for i, line := range strings.Split(string(content), "\n") {
+			matches = append(matches, scanSecretLine(fp, i+1, line, rules)...)
+		}
+	}
+
+end:
+	return matches, binaryCount, err
+}
+
+// scanSecretLine returns the matches of rules in a single line of fp,
+// skipping any match overlapping one already found by an earlier rule.
+func scanSecretLine(fp string, lineNum int, line string, rules []SecretRule) (matches []SecretMatchResult) {
+	var found [][]int
+
+	for _, rule := range rules {
+		for _, m := range rule.FindAll(line) {
+			if overlapsAny(m, found) {
+				continue
+			}
+			found = append(found, m)
+			matches = append(matches, SecretMatchResult{
+				File:     fp,
+				Line:     lineNum,
+				Column:   m[0] + 1,
+				Rule:     rule.Name,
+				Redacted: redactSecret(line[m[0]:m[1]]),
+			})
+		}
+	}
+	return matches
+}
+
+// overlapsAny reports whether the byte range m overlaps any range in ranges.
+func overlapsAny(m []int, ranges [][]int) bool {
+	for _, r := range ranges {
+		if m[0] < r[1] && r[0] < m[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecret masks all but the first few characters of secret, so a match
+// can be recognized without the secret itself appearing in the result.
+func redactSecret(secret string) string {
+	if len(secret) <= secretRevealLen*2 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:secretRevealLen] + strings.Repeat("*", 8)
+}
@@ -1,18 +1,25 @@
 package mcptools
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
 )
 
 var _ mcputil.Tool = (*SearchFilesTool)(nil)
 
+// DefaultSearchExcludes are the glob patterns of directories search_files
+// skips unless exclude_mode is 'replace'.
+var DefaultSearchExcludes = []string{".git", "node_modules", "vendor"}
+
 // FileSearchResult represents information about a file found during search.
 type FileSearchResult struct {
 	Path     string `json:"path"`         // Full path to the file
@@ -20,6 +27,14 @@ type FileSearchResult struct {
 	Size     int64  `json:"size"`         // File size in bytes
 	Modified string `json:"modified"`     // Last modified time
 	IsDir    bool   `json:"is_directory"` // Whether it's a directory
+
+	Matches []ContentMatch `json:"matches,omitempty"` // Lines matching content_pattern, when given
+}
+
+// ContentMatch is a line of a file matching the content_pattern of a search.
+type ContentMatch struct {
+	Line int    `json:"line"` // 1-based line number
+	Text string `json:"text"` // Text of the line without its line ending
 }
 
 func init() {
@@ -33,11 +48,16 @@ func init() {
 				RequiredPathProperty,
 				RecursiveProperty,
 				ExtensionsProperty,
+				ExcludeProperty.Description("Glob patterns of files or directories to skip, matched against their name or path relative to path (e.g., ['testdata', '*.min.js'])"),
+				ExcludeModeProperty,
 				PatternProperty.Description("Name pattern to match (case-insensitive substring)"),
 				NamePatternProperty,
 				FilesOnlyProperty,
 				DirsOnlyProperty,
+				ContentPatternProperty,
+				RegexProperty.Description("Whether to treat content_pattern as a regular expression"),
 				MaxResultsProperty,
+				OutputFormatProperty,
 			},
 		}),
 	})
@@ -58,7 +78,13 @@ func (t *SearchFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (re
 	var dirsOnly bool
 	var maxResults int
 	var extensions []string
+	var excludes []string
+	var excludeMode golang.ExcludeMode
+	var contentPattern string
+	var useRegex bool
+	var contentRegexp *regexp.Regexp
 	var results []FileSearchResult
+	var format OutputFormat
 
 	logger.Info("Tool called", "tool", "search_files")
 
@@ -103,6 +129,40 @@ func (t *SearchFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (re
 		goto end
 	}
 
+	excludes, err = ExcludeProperty.StringSlice(req)
+	if err != nil {
+		err = fmt.Errorf("invalid exclude array: %v", err)
+		goto end
+	}
+
+	excludeMode, err = getExcludeMode(req, excludes)
+	if err != nil {
+		goto end
+	}
+	excludes = searchExcludes(excludes, excludeMode)
+
+	contentPattern, err = ContentPatternProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	useRegex, err = RegexProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	format, err = getOutputFormat(req)
+	if err != nil {
+		goto end
+	}
+
+	if contentPattern != "" {
+		contentRegexp, err = compileContentPattern(contentPattern, useRegex)
+		if err != nil {
+			goto end
+		}
+	}
+
 	logger.Info("Tool arguments parsed",
 		"tool", "search_files",
 		"path", searchPath,
@@ -112,7 +172,11 @@ func (t *SearchFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (re
 		"files_only", filesOnly,
 		"dirs_only", dirsOnly,
 		"extensions", extensions,
-		"max_results", maxResults)
+		"exclude", excludes,
+		"content_pattern", contentPattern,
+		"regex", useRegex,
+		"max_results", maxResults,
+		"output_format", format)
 
 	// Check path is allowed
 	if !t.IsAllowedPath(searchPath) {
@@ -121,13 +185,15 @@ func (t *SearchFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (re
 	}
 
 	results, err = t.searchFiles(searchPath, SearchFilesOptions{
-		Recursive:   recursive,
-		Pattern:     pattern,
-		NamePattern: namePattern,
-		Extensions:  extensions,
-		FilesOnly:   filesOnly,
-		DirsOnly:    dirsOnly,
-		MaxResults:  maxResults,
+		Recursive:      recursive,
+		Pattern:        pattern,
+		NamePattern:    namePattern,
+		Extensions:     extensions,
+		Excludes:       excludes,
+		FilesOnly:      filesOnly,
+		DirsOnly:       dirsOnly,
+		MaxResults:     maxResults,
+		ContentPattern: contentRegexp,
 	})
 	if err != nil {
 		goto end
@@ -135,19 +201,27 @@ func (t *SearchFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (re
 
 	logger.Info("Tool completed", "tool", "search_files", "results_count", len(results))
 
+	if format == NDJSONOutput {
+		result = mcputil.NewToolResultNDJSON(results)
+		goto end
+	}
+
 	// Convert results to JSON using mcputil
 	result = mcputil.NewToolResultJSON(map[string]any{
-		"search_path":  searchPath,
-		"results":      results,
-		"count":        len(results),
-		"recursive":    recursive,
-		"pattern":      pattern,
-		"name_pattern": namePattern,
-		"extensions":   extensions,
-		"files_only":   filesOnly,
-		"dirs_only":    dirsOnly,
-		"max_results":  maxResults,
-		"truncated":    len(results) >= maxResults,
+		"search_path":     searchPath,
+		"results":         results,
+		"count":           len(results),
+		"recursive":       recursive,
+		"pattern":         pattern,
+		"name_pattern":    namePattern,
+		"extensions":      extensions,
+		"exclude":         excludes,
+		"files_only":      filesOnly,
+		"dirs_only":       dirsOnly,
+		"content_pattern": contentPattern,
+		"regex":           useRegex,
+		"max_results":     maxResults,
+		"truncated":       len(results) >= maxResults,
 	})
 
 end:
@@ -159,9 +233,71 @@ type SearchFilesOptions struct {
 	Pattern     string
 	NamePattern string
 	Extensions  []string
+	Excludes    []string // Glob patterns of files and directories to prune
 	FilesOnly   bool
 	DirsOnly    bool
 	MaxResults  int
+
+	// ContentPattern, when not nil, limits results to files with a line it
+	// matches, and has the matching lines reported with each file.
+	ContentPattern *regexp.Regexp
+}
+
+// getExcludeMode returns how the exclude patterns relate to
+// DefaultSearchExcludes: added to them unless exclude_mode is 'replace', and
+// the defaults alone when no patterns are given.
+func getExcludeMode(req mcputil.ToolRequest, excludes []string) (mode golang.ExcludeMode, err error) {
+	var value string
+
+	value, err = ExcludeModeProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	switch value {
+	case "replace":
+		mode = golang.ReplaceDefaults
+	case "add", "":
+		mode = golang.AddToDefaults
+		if len(excludes) == 0 {
+			mode = golang.UseDefaults
+		}
+	default:
+		err = fmt.Errorf("exclude_mode must be 'add' or 'replace', got '%s'", value)
+	}
+
+end:
+	return mode, err
+}
+
+// searchExcludes returns the effective exclude patterns for the given
+// patterns and mode, as golang.TraverseArgs.GetEffectiveExcludes does for
+// DefaultSearchExcludes.
+func searchExcludes(excludes []string, mode golang.ExcludeMode) (effective []string) {
+	switch mode {
+	case golang.AddToDefaults:
+		effective = make([]string, 0, len(DefaultSearchExcludes)+len(excludes))
+		effective = append(effective, DefaultSearchExcludes...)
+		effective = append(effective, excludes...)
+	case golang.ReplaceDefaults:
+		effective = excludes
+	default:
+		effective = DefaultSearchExcludes
+	}
+	return effective
+}
+
+// compileContentPattern compiles the content_pattern of a search, quoting it
+// when it is not a regular expression.
+func compileContentPattern(pattern string, useRegex bool) (re *regexp.Regexp, err error) {
+	if !useRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	re, err = regexp.Compile(pattern)
+	if err != nil {
+		err = fmt.Errorf("invalid content_pattern regex: %w", err)
+	}
+	return re, err
 }
 
 func (t *SearchFilesTool) searchFiles(searchPath string, opts SearchFilesOptions) (results []FileSearchResult, err error) {
@@ -180,6 +316,7 @@ func (t *SearchFilesTool) searchFiles(searchPath string, opts SearchFilesOptions
 	err = filepath.Walk(searchDir, func(path string, info os.FileInfo, walkErr error) (err error) {
 		var shouldInclude bool
 		var result FileSearchResult
+		var rel string
 
 		if walkErr != nil {
 			// Log but continue walking
@@ -187,6 +324,20 @@ func (t *SearchFilesTool) searchFiles(searchPath string, opts SearchFilesOptions
 			goto end
 		}
 
+		// Prune excluded directories so that their trees are never walked
+		if path != searchDir {
+			rel, err = filepath.Rel(searchDir, path)
+			if err != nil {
+				goto end
+			}
+			if matchesAnyGlob(filepath.ToSlash(rel), opts.Excludes) {
+				if info.IsDir() {
+					err = filepath.SkipDir
+				}
+				goto end
+			}
+		}
+
 		// Stop if we've hit the max results
 		if 0 < opts.MaxResults && len(results) >= opts.MaxResults {
 			err = filepath.SkipDir
@@ -221,6 +372,16 @@ func (t *SearchFilesTool) searchFiles(searchPath string, opts SearchFilesOptions
 			IsDir:    info.IsDir(),
 		}
 
+		if opts.ContentPattern != nil {
+			if info.IsDir() {
+				goto end
+			}
+			result.Matches = t.findContentMatches(path, opts.ContentPattern)
+			if len(result.Matches) == 0 {
+				goto end
+			}
+		}
+
 		results = append(results, result)
 
 	end:
@@ -231,6 +392,35 @@ end:
 	return results, err
 }
 
+// findContentMatches returns the lines of the file at path that re matches.
+// Files that cannot be read or look binary have no matches.
+func (t *SearchFilesTool) findContentMatches(path string, re *regexp.Regexp) (matches []ContentMatch) {
+	var content []byte
+	var err error
+
+	content, err = os.ReadFile(path)
+	if err != nil {
+		logger.Info("Unable to read file for content search", "path", path, "error", err)
+		goto end
+	}
+	if isBinaryContent(content) {
+		goto end
+	}
+
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if re.Match(line) {
+			matches = append(matches, ContentMatch{
+				Line: i + 1,
+				Text: string(line),
+			})
+		}
+	}
+
+end:
+	return matches
+}
+
 func (t *SearchFilesTool) matchesFilters(fileName string, opts SearchFilesOptions) (matches bool) {
 	// Pattern matching (case-insensitive substring)
 	if opts.Pattern != "" {
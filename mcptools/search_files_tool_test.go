@@ -1,6 +1,8 @@
 package mcptools_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/fsfix"
@@ -19,6 +21,11 @@ type SearchFilesResult struct {
 		Name  string `json:"name"`
 		Size  int64  `json:"size"`
 		IsDir bool   `json:"is_directory"`
+
+		Matches []struct {
+			Line int    `json:"line"`
+			Text string `json:"text"`
+		} `json:"matches"`
 	} `json:"results"`
 	Count       int      `json:"count"`
 	Recursive   bool     `json:"recursive"`
@@ -142,4 +149,226 @@ func TestSearchFilesTool(t *testing.T) {
 			ExpectFiles: 2, // Should find only main.go and utils.go
 		})
 	})
+	t.Run("NDJSONOutput_ShouldReturnOneResultPerLine", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("ndjson-project", nil)
+		pf.AddFileFixtures(t, &fsfix.FileFixtureArgs{}, "main.go", "utils.go", "config.yaml")
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"extensions":    []any{".go"},
+			"output_format": "ndjson",
+		})
+
+		result, err := mcputil.CallTool(tool, req)
+		require.NoError(t, err, "Should not error searching with NDJSON output")
+
+		lines := strings.Split(strings.TrimSuffix(result.Value(), "\n"), "\n")
+		require.Len(t, lines, 2, "Should return one line per matching file")
+		for _, line := range lines {
+			var entry struct {
+				Name string `json:"name"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry), "Each line should be a JSON object")
+			assert.True(t, strings.HasSuffix(entry.Name, ".go"), "Each line should describe a .go file")
+		}
+	})
+
+	t.Run("InvalidOutputFormat_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("format-project", nil)
+		pf.AddFileFixtures(t, &fsfix.FileFixtureArgs{}, "main.go")
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"output_format": "xml",
+		})
+
+		_, err := mcputil.CallTool(tool, req)
+		require.Error(t, err, "Should error for unknown output format")
+		assert.Contains(t, err.Error(), "output_format must be", "Error should name the option")
+	})
+
+	t.Run("ContentPattern_ShouldReturnFilesWithMatchingLines", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("content-project", nil)
+		mainFile := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\n// TODO: handle errors\nfunc main() {}\n",
+		})
+		pf.AddFileFixture("util.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc util() {}\n",
+		})
+		pf.AddFileFixture("notes.md", &fsfix.FileFixtureArgs{
+			Content: "TODO: write docs\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"extensions":      []any{".go"},
+			"content_pattern": "TODO:",
+		})
+
+		result, err := mcputil.GetToolResult[SearchFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error searching file contents")
+
+		requireSearchFilesResult(t, result, err, searchFilesResultOpts{
+			ExpectFiles: 1, // Only main.go has a TODO among the .go files
+		})
+		assert.Equal(t, mainFile.Filepath, result.Results[0].Path, "Should find main.go")
+		require.Len(t, result.Results[0].Matches, 1, "Should report the matching line")
+		assert.Equal(t, 3, result.Results[0].Matches[0].Line, "Should report the line number")
+		assert.Equal(t, "// TODO: handle errors", result.Results[0].Matches[0].Text, "Should report the line text")
+	})
+
+	t.Run("ContentPatternRegex_ShouldMatchUsingRegularExpression", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("content-regex-project", nil)
+		pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc main() {}\n\nfunc helper() {}\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            pf.Dir(),
+			"content_pattern": "^func \\w+\\(",
+			"regex":           true,
+		})
+
+		result, err := mcputil.GetToolResult[SearchFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error searching with a content regex")
+
+		requireSearchFilesResult(t, result, err, searchFilesResultOpts{
+			ExpectFiles: 1,
+		})
+		require.Len(t, result.Results[0].Matches, 2, "Should report both functions")
+		assert.Equal(t, 3, result.Results[0].Matches[0].Line, "Should report main's line")
+		assert.Equal(t, 5, result.Results[0].Matches[1].Line, "Should report helper's line")
+	})
+
+	t.Run("InvalidContentRegex_ShouldError", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":   testToken,
+			"path":            "/tmp",
+			"content_pattern": "[invalid",
+			"regex":           true,
+		})
+
+		_, err := mcputil.CallTool(tool, req)
+		require.Error(t, err, "Should error for an invalid content regex")
+		assert.Contains(t, err.Error(), "invalid content_pattern regex", "Error should name the parameter")
+	})
+
+	t.Run("DefaultExcludes_ShouldSkipVendorAndNodeModules", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("exclude-project", nil)
+		pf.AddFileFixtures(t, &fsfix.FileFixtureArgs{}, "main.go", "vendor/lib/lib.go", "node_modules/pkg/index.js", "internal/util.go")
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          pf.Dir(),
+			"recursive":     true,
+			"files_only":    true,
+		})
+
+		result, err := mcputil.GetToolResult[SearchFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error searching with default excludes")
+
+		requireSearchFilesResult(t, result, err, searchFilesResultOpts{
+			ExpectFiles: 2, // Only main.go and internal/util.go
+		})
+		for _, r := range result.Results {
+			assert.NotContains(t, r.Path, "vendor", "Should not walk vendor")
+			assert.NotContains(t, r.Path, "node_modules", "Should not walk node_modules")
+		}
+	})
+
+	t.Run("ExcludeModes_ShouldAddToOrReplaceDefaults", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(SearchFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("exclude-mode-project", nil)
+		pf.AddFileFixtures(t, &fsfix.FileFixtureArgs{}, "main.go", "vendor/lib/lib.go", "testdata/fixture.go", "internal/util.go")
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		tests := []struct {
+			name        string
+			excludeMode string
+			expectFiles int
+		}{
+			{name: "Add", excludeMode: "add", expectFiles: 2},         // main.go and internal/util.go
+			{name: "Replace", excludeMode: "replace", expectFiles: 3}, // vendor/lib/lib.go is searched too
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := mcputil.NewMockRequest(mcputil.Params{
+					"session_token": testToken,
+					"path":          pf.Dir(),
+					"recursive":     true,
+					"extensions":    []any{".go"},
+					"exclude":       []any{"testdata"},
+					"exclude_mode":  tt.excludeMode,
+				})
+
+				result, err := mcputil.GetToolResult[SearchFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error searching with excludes")
+
+				requireSearchFilesResult(t, result, err, searchFilesResultOpts{
+					ExpectFiles: tt.expectFiles,
+				})
+				for _, r := range result.Results {
+					assert.NotContains(t, r.Path, "testdata", "Should not walk testdata")
+				}
+			})
+		}
+	})
+
+	t.Run("InvalidExcludeMode_ShouldError", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          "/tmp",
+			"exclude_mode":  "merge",
+		})
+
+		_, err := mcputil.CallTool(tool, req)
+		require.Error(t, err, "Should error for an unknown exclude mode")
+		assert.Contains(t, err.Error(), "exclude_mode must be 'add' or 'replace'", "Error should name the option")
+	})
 }
@@ -0,0 +1,172 @@
+package mcptools
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RedactedSecret replaces each secret that RedactSecrets masks.
+const RedactedSecret = "***"
+
+// SecretRule describes one kind of secret for scan_secrets to detect and for
+// RedactSecrets to mask. Rules come from DefaultSecretRules and the
+// "secret_rules" config setting.
+type SecretRule struct {
+	Name       string  `json:"name"`                  // Identifier reported with each match
+	Pattern    string  `json:"pattern"`               // Regular expression matching the secret
+	MinEntropy float64 `json:"min_entropy,omitempty"` // If set, matches need at least this Shannon entropy in bits per character
+	re         *regexp.Regexp
+}
+
+// DefaultSecretRules returns the built-in secret detection rules.
+func DefaultSecretRules() []SecretRule {
+	return []SecretRule{
+		{Name: "aws_access_key_id", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+		{Name: "aws_secret_access_key", Pattern: `(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`},
+		{Name: "private_key", Pattern: `-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`},
+		{Name: "github_token", Pattern: `\bgh[pousr]_[A-Za-z0-9]{36,}\b`},
+		{Name: "slack_token", Pattern: `\bxox[abprs]-[A-Za-z0-9-]{10,}`},
+		{Name: "high_entropy_string", Pattern: `[A-Za-z0-9+/_=-]{32,}`, MinEntropy: 4.5},
+	}
+}
+
+// Package-level secret rules used by scan_secrets and RedactSecrets
+var (
+	secretRules      = mustCompileSecretRules(DefaultSecretRules())
+	secretRulesMutex sync.Mutex
+)
+
+// SetSecretRules sets the rules scan_secrets applies to DefaultSecretRules
+// plus rules. A rule with the same name as a built-in rule replaces it, and
+// one with an empty pattern removes it.
+func SetSecretRules(rules []SecretRule) (err error) {
+	var merged []SecretRule
+	var index map[string]int
+	var i int
+	var ok bool
+
+	merged = DefaultSecretRules()
+	index = make(map[string]int, len(merged))
+	for i, rule := range merged {
+		index[rule.Name] = i
+	}
+	for _, rule := range rules {
+		if rule.Name == "" {
+			err = fmt.Errorf("secret rule with pattern %q has no name", rule.Pattern)
+			goto end
+		}
+		i, ok = index[rule.Name]
+		if ok {
+			merged[i] = rule
+			continue
+		}
+		index[rule.Name] = len(merged)
+		merged = append(merged, rule)
+	}
+
+	merged, err = compileSecretRules(merged)
+	if err != nil {
+		goto end
+	}
+
+	secretRulesMutex.Lock()
+	secretRules = merged
+	secretRulesMutex.Unlock()
+
+end:
+	return err
+}
+
+// currentSecretRules returns the compiled rules set by SetSecretRules.
+func currentSecretRules() []SecretRule {
+	secretRulesMutex.Lock()
+	defer secretRulesMutex.Unlock()
+	return secretRules
+}
+
+// compileSecretRules compiles the pattern of each rule, dropping rules whose
+// pattern is empty.
+func compileSecretRules(rules []SecretRule) (compiled []SecretRule, err error) {
+	compiled = make([]SecretRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Pattern == "" {
+			continue
+		}
+		rule.re, err = regexp.Compile(rule.Pattern)
+		if err != nil {
+			err = fmt.Errorf("invalid pattern for secret rule %s: %w", rule.Name, err)
+			goto end
+		}
+		compiled = append(compiled, rule)
+	}
+
+end:
+	return compiled, err
+}
+
+// mustCompileSecretRules compiles the built-in rules, which are known valid.
+func mustCompileSecretRules(rules []SecretRule) []SecretRule {
+	compiled, err := compileSecretRules(rules)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+// FindAll returns the byte ranges in line that the rule matches, skipping
+// matches below the rule's minimum entropy.
+func (r SecretRule) FindAll(line string) (matches [][]int) {
+	for _, m := range r.re.FindAllStringIndex(line, -1) {
+		if r.MinEntropy > 0 && shannonEntropy(line[m[0]:m[1]]) < r.MinEntropy {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) (entropy float64) {
+	var counts map[rune]int
+	var n float64
+	var p float64
+
+	counts = make(map[rune]int)
+	for _, c := range s {
+		counts[c]++
+		n++
+	}
+	for _, count := range counts {
+		p = float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// RedactSecrets returns s with every match of the current secret rules
+// replaced by RedactedSecret.
+func RedactSecrets(s string) string {
+	var matches [][]int
+	var b strings.Builder
+	var last int
+
+	for _, rule := range currentSecretRules() {
+		matches = rule.FindAll(s)
+		if len(matches) == 0 {
+			continue
+		}
+		b.Reset()
+		last = 0
+		for _, m := range matches {
+			b.WriteString(s[last:m[0]])
+			b.WriteString(RedactedSecret)
+			last = m[1]
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
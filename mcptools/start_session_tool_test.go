@@ -1,6 +1,7 @@
 package mcptools_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/fsfix"
@@ -232,6 +233,103 @@ func TestSessionTokenRequirements(t *testing.T) {
 	}
 }
 
+// TestMutatingTools validates which tools modify files, and so are refused to
+// read-only sessions
+func TestMutatingTools(t *testing.T) {
+	var tool mcputil.Tool
+	var toolName string
+
+	mutating := []string{
+		"create_file", "update_file", "delete_files", "replace_pattern",
+		"replace_file_part", "update_file_lines", "delete_file_lines",
+		"insert_file_lines", "insert_at_pattern", "move_file", "copy_file",
+		"append_to_file", "write_binary_file", "create_directory",
+		"rotate_file", "undo_edit", "convert_line_endings",
+		"find_no_final_newline",
+	}
+	readOnly := []string{
+		"read_files", "search_files", "check_docs", "validate_files",
+		"find_file_part", "list_directory", "get_changed_files",
+		"refresh_session", "revoke_session",
+	}
+
+	for _, toolName = range mutating {
+		tool = mcputil.GetRegisteredTool(toolName)
+		require.NotNil(t, tool, "tool %s must be registered", toolName)
+		assert.True(t, tool.Options().Mutating, "tool %s should be mutating", toolName)
+	}
+	for _, toolName = range readOnly {
+		tool = mcputil.GetRegisteredTool(toolName)
+		require.NotNil(t, tool, "tool %s must be registered", toolName)
+		assert.False(t, tool.Options().Mutating, "tool %s should not be mutating", toolName)
+	}
+}
+
+// TestReadOnlySession verifies that a read-only session is refused mutating
+// tools, while tools that only read, dry runs and requests that only report
+// still work
+func TestReadOnlySession(t *testing.T) {
+	createTool := mcputil.GetRegisteredTool("create_file")
+	require.NotNil(t, createTool, "create_file tool should be registered")
+	readTool := mcputil.GetRegisteredTool("read_files")
+	require.NotNil(t, readTool, "read_files tool should be registered")
+	newlineTool := mcputil.GetRegisteredTool("find_no_final_newline")
+	require.NotNil(t, newlineTool, "find_no_final_newline tool should be registered")
+
+	tf := fsfix.NewRootFixture(StartSessionDirPrefix)
+	defer tf.Cleanup()
+	ff := tf.AddFileFixture("existing.txt", &fsfix.FileFixtureArgs{Content: "content\n"})
+	noNewline := tf.AddFileFixture("no-newline.txt", &fsfix.FileFixtureArgs{Content: "content"})
+	tf.Setup(t)
+	config := mcputil.NewMockConfig(mcputil.MockConfigArgs{
+		AllowedPaths: []string{tf.TempDir()},
+	})
+	createTool.SetConfig(config)
+	readTool.SetConfig(config)
+	newlineTool.SetConfig(config)
+
+	session := mcputil.NewSession()
+	session.ReadOnly = true
+	require.NoError(t, session.Initialize(), "Should create session")
+	defer mcputil.ClearSession(session.Token)
+
+	newFile := filepath.Join(tf.TempDir(), "new.txt")
+	create := func(dryRun bool) error {
+		_, err := mcputil.CallTool(createTool, mcputil.NewMockRequest(mcputil.Params{
+			"session_token": session.Token,
+			"filepath":      newFile,
+			"new_content":   "new\n",
+			"dry_run":       dryRun,
+		}))
+		return err
+	}
+
+	err := create(false)
+	require.ErrorIs(t, err, mcputil.ErrReadOnlySession, "Mutating tool should be refused")
+	assert.Contains(t, err.Error(), "session is read-only", "Error should say the session is read-only")
+
+	assert.NoError(t, create(true), "Dry run preview should be allowed")
+	assert.NoFileExists(t, newFile, "File should not be created")
+
+	_, err = mcputil.CallTool(readTool, mcputil.NewMockRequest(mcputil.Params{
+		"session_token": session.Token,
+		"paths":         []any{ff.Filepath},
+	}))
+	assert.NoError(t, err, "Reading tool should still work")
+
+	findNoNewline := func(fix bool) error {
+		_, err := mcputil.CallTool(newlineTool, mcputil.NewMockRequest(mcputil.Params{
+			"session_token": session.Token,
+			"paths":         []any{tf.TempDir()},
+			"fix":           fix,
+		}))
+		return err
+	}
+	assert.NoError(t, findNoNewline(false), "Reporting without fix should be allowed")
+	assert.ErrorIs(t, findNoNewline(true), mcputil.ErrReadOnlySession, "Fixing should be refused")
+	requireFileContent(t, noNewline.Filepath, "content")
+}
+
 // TestToolRegistrationOrder verifies that tools are registered in a predictable order
 func TestToolRegistrationOrder(t *testing.T) {
 	var toolNames []string
@@ -0,0 +1,108 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*StripCommentsTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&StripCommentsTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "strip_comments",
+			Description: "Remove the comments from a Go file. Comments are located by the parser rather than by pattern, so code and string literals are never altered. By default //go: directives, // +build constraints and the cgo preamble are kept because removing them changes how the file builds. The result is gofmt-formatted and validated",
+			QuickHelp:   "Remove comments from a Go file",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("Go file to strip comments from"),
+				RequiredLanguageProperty.Description("Programming language of the file; only 'go' is supported"),
+				KeepDirectivesProperty,
+			},
+		}),
+	})
+}
+
+// StripCommentsTool removes the comments from a Go file.
+type StripCommentsTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the strip_comments tool request and removes the comments.
+func (t *StripCommentsTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var keepDirectives bool
+	var content string
+	var stripped []byte
+	var removed, kept int
+
+	logger.Info("Tool called", "tool", "strip_comments")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	if langutil.Language(language) != langutil.GoLanguage {
+		err = fmt.Errorf("unsupported language '%s': only '%s' is supported", language, langutil.GoLanguage)
+		goto end
+	}
+
+	keepDirectives, err = KeepDirectivesProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "strip_comments",
+		"path", path,
+		"language", language,
+		"keep_directives", keepDirectives)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	stripped, removed, kept, err = golang.StripComments(path, []byte(content), keepDirectives)
+	if err != nil {
+		err = fmt.Errorf("cannot strip comments from %s: %w", path, err)
+		goto end
+	}
+
+	if removed > 0 {
+		err = WriteFile(ctx, t.Config(), path, string(stripped))
+		if err != nil {
+			goto end
+		}
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+	}
+
+	logger.Info("Tool completed", "tool", "strip_comments", "path", path, "removed", removed, "kept", kept)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":          true,
+		"path":             path,
+		"comments_removed": removed,
+		"comments_kept":    kept,
+		"message":          fmt.Sprintf("Removed %d comment(s) from %s, keeping %d", removed, path, kept),
+	})
+
+end:
+	return result, err
+}
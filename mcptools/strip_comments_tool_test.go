@@ -0,0 +1,193 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const StripCommentsDirPrefix = "strip-comments-tool-test"
+
+// Strip comments tool result type
+type StripCommentsResult struct {
+	Success         bool   `json:"success"`
+	Path            string `json:"path"`
+	CommentsRemoved int    `json:"comments_removed"`
+	CommentsKept    int    `json:"comments_kept"`
+	Message         string `json:"message"`
+}
+
+type stripCommentsResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectedRemoved  int
+	ExpectedKept     int
+	ExpectedContent  string
+}
+
+func requireStripCommentsResult(t *testing.T, result *StripCommentsResult, err error, path string, opts stripCommentsResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedRemoved, result.CommentsRemoved, "Removed count should match")
+	assert.Equal(t, opts.ExpectedKept, result.CommentsKept, "Kept count should match")
+	requireFileContent(t, path, opts.ExpectedContent)
+}
+
+func TestStripCommentsTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("strip_comments")
+	require.NotNil(t, tool, "strip_comments tool should be registered")
+
+	const source = `//go:build linux
+
+// Package main prints a greeting.
+package main
+
+import "fmt"
+
+//go:generate stringer -type=Mode
+
+/*
+Mode selects the output.
+*/
+type Mode int
+
+const url = "http://example.com" // not a comment: "//"
+
+func main() {
+	// Say hello
+	fmt.Println("hello /* world */", url) /* trailing */
+	x := 1 /* inline */ + 2
+	fmt.Println(x)
+}
+`
+
+	setup := func(t *testing.T, content string) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(StripCommentsDirPrefix)
+		pf := tf.AddRepoFixture("strip-project", nil)
+		ff := pf.AddFileFixture("main.go", &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(params mcputil.Params) (*StripCommentsResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[StripCommentsResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call strip_comments")
+	}
+
+	t.Run("Default_ShouldKeepDirectives", func(t *testing.T) {
+		tf, fp := setup(t, source)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "go"})
+		requireStripCommentsResult(t, result, err, fp, stripCommentsResultOpts{
+			ExpectedRemoved: 6,
+			ExpectedKept:    2,
+			ExpectedContent: `//go:build linux
+
+package main
+
+import "fmt"
+
+//go:generate stringer -type=Mode
+
+type Mode int
+
+const url = "http://example.com"
+
+func main() {
+	fmt.Println("hello /* world */", url)
+	x := 1 + 2
+	fmt.Println(x)
+}
+`,
+		})
+	})
+
+	t.Run("KeepDirectivesFalse_ShouldRemoveAll", func(t *testing.T) {
+		tf, fp := setup(t, source)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "go", "keep_directives": false})
+		requireStripCommentsResult(t, result, err, fp, stripCommentsResultOpts{
+			ExpectedRemoved: 8,
+			ExpectedContent: `package main
+
+import "fmt"
+
+type Mode int
+
+const url = "http://example.com"
+
+func main() {
+	fmt.Println("hello /* world */", url)
+	x := 1 + 2
+	fmt.Println(x)
+}
+`,
+		})
+	})
+
+	t.Run("CgoPreamble_ShouldBeKept", func(t *testing.T) {
+		tf, fp := setup(t, `package main
+
+// #include <stdio.h>
+import "C"
+
+// main does nothing.
+func main() {}
+`)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "go"})
+		requireStripCommentsResult(t, result, err, fp, stripCommentsResultOpts{
+			ExpectedRemoved: 1,
+			ExpectedKept:    1,
+			ExpectedContent: `package main
+
+// #include <stdio.h>
+import "C"
+
+func main() {}
+`,
+		})
+	})
+
+	t.Run("DryRun_ShouldNotWrite", func(t *testing.T) {
+		tf, fp := setup(t, source)
+		defer tf.Cleanup()
+
+		_, err := call(mcputil.Params{"path": fp, "language": "go", "dry_run": true})
+		require.NoError(t, err, "Dry run should not error")
+		requireFileContent(t, fp, source)
+	})
+
+	t.Run("UnsupportedLanguage_ShouldError", func(t *testing.T) {
+		tf, fp := setup(t, source)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "python"})
+		requireStripCommentsResult(t, result, err, fp, stripCommentsResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "unsupported language",
+		})
+	})
+}
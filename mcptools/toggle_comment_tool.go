@@ -0,0 +1,241 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/langutil"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*ToggleCommentTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&ToggleCommentTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "toggle_comment",
+			Description: "Comment out or uncomment a range of lines using the language's line comment marker ('//' for Go, C, C++, Java, JavaScript, TypeScript and Rust; '#' for Python, YAML and shell), preserving indentation. The 'toggle' action uncomments the range when every non-blank line in it is already commented and comments it otherwise. Go files must still parse afterwards. Returns a diff of the change",
+			QuickHelp:   "Comment out or uncomment a range of lines",
+			Previewable: true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File containing the lines"),
+				RequiredLanguageProperty.Description("Language whose line comment marker to use, such as 'go', 'python' or 'yaml'"),
+				StartLineProperty.Required(),
+				EndLineProperty.Required(),
+				CommentActionProperty,
+			},
+		}),
+	})
+}
+
+// lineCommentMarkers maps each supported language to its line comment marker.
+var lineCommentMarkers = map[langutil.Language]string{
+	langutil.GoLanguage:          "//",
+	langutil.CLanguage:           "//",
+	langutil.CPPLanguage:         "//",
+	langutil.JavaLanguage:        "//",
+	langutil.JavasScriptLanguage: "//",
+	langutil.TypeScriptLanguage:  "//",
+	langutil.RustLanguage:        "//",
+	langutil.PythonLanguage:      "#",
+	"yaml":                       "#",
+	"shell":                      "#",
+}
+
+// ToggleCommentTool comments out or uncomments a range of lines.
+type ToggleCommentTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the toggle_comment tool request and comments or
+// uncomments the line range.
+func (t *ToggleCommentTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var language string
+	var marker string
+	var ok bool
+	var startLine, endLine int
+	var action string
+	var content string
+	var lines []string
+	var changed int
+	var updated string
+	var diff string
+
+	logger.Info("Tool called", "tool", "toggle_comment")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	language, err = RequiredLanguageProperty.String(req)
+	if err != nil {
+		goto end
+	}
+	marker, ok = lineCommentMarkers[langutil.Language(strings.ToLower(language))]
+	if !ok {
+		err = fmt.Errorf("unsupported language '%s': no line comment marker is known for it", language)
+		goto end
+	}
+
+	startLine, err = StartLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("start_line must be a valid number: %w", err)
+		goto end
+	}
+
+	endLine, err = EndLineProperty.Int(req)
+	if err != nil {
+		err = fmt.Errorf("end_line must be a valid number: %w", err)
+		goto end
+	}
+
+	action, err = CommentActionProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "toggle_comment",
+		"path", path,
+		"language", language,
+		"start_line", startLine,
+		"end_line", endLine,
+		"action", action)
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	content, err = ReadFile(t.Config(), path)
+	if err != nil {
+		goto end
+	}
+
+	lines = strings.Split(content, "\n")
+	if startLine < 1 || endLine < startLine || endLine > len(lines) {
+		err = fmt.Errorf("invalid line range %d-%d: file has %d lines", startLine, endLine, len(lines))
+		goto end
+	}
+
+	if action == "toggle" {
+		action = "comment"
+		if isCommentedRange(lines[startLine-1:endLine], marker) {
+			action = "uncomment"
+		}
+	}
+
+	if action == "comment" {
+		changed = commentLines(lines[startLine-1:endLine], marker)
+	} else {
+		changed = uncommentLines(lines[startLine-1:endLine], marker)
+	}
+
+	updated = strings.Join(lines, "\n")
+	if changed > 0 {
+		if langutil.DetectLanguage(path) == langutil.GoLanguage {
+			// Refuse to leave Go that no longer parses, such as a commented-out opening brace
+			err = WriteFile(ctx, t.Config(), path, updated)
+		} else {
+			err = mcputil.WriteFile(ctx, t.Config(), path, updated)
+		}
+		if err != nil {
+			goto end
+		}
+		recordFileChange(ctx, req, mcputil.UpdatedFileOp, path)
+	}
+
+	diff, err = mcputil.UnifiedDiff("a/"+path, "b/"+path, content, updated)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "toggle_comment", "path", path, "action", action, "lines_changed", changed)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":       true,
+		"path":          path,
+		"action":        action,
+		"start_line":    startLine,
+		"end_line":      endLine,
+		"lines_changed": changed,
+		"diff":          diff,
+	})
+
+end:
+	return result, err
+}
+
+// isCommentedRange reports whether every non-blank line in lines starts with
+// marker after its indentation. A range of only blank lines is not commented.
+func isCommentedRange(lines []string, marker string) (commented bool) {
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(strings.TrimLeft(line, " \t"), marker) {
+			commented = false
+			goto end
+		}
+		commented = true
+	}
+
+end:
+	return commented
+}
+
+// commentLines inserts marker and a space into each non-blank line at the
+// smallest indentation among them, so the lines stay aligned and keep their
+// relative indentation. It returns the number of lines changed.
+func commentLines(lines []string, marker string) (changed int) {
+	var indent int
+
+	indent = -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indent < 0 || leadingWhitespace(line) < indent {
+			indent = leadingWhitespace(line)
+		}
+	}
+
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines[i] = line[:indent] + marker + " " + line[indent:]
+		changed++
+	}
+	return changed
+}
+
+// uncommentLines removes marker, and a single space following it, from the
+// start of each line that begins with it after its indentation. Other lines
+// are left unchanged. It returns the number of lines changed.
+func uncommentLines(lines []string, marker string) (changed int) {
+	var indent int
+	var rest string
+	var ok bool
+
+	for i, line := range lines {
+		indent = leadingWhitespace(line)
+		rest, ok = strings.CutPrefix(line[indent:], marker)
+		if !ok {
+			continue
+		}
+		rest = strings.TrimPrefix(rest, " ")
+		lines[i] = line[:indent] + rest
+		changed++
+	}
+	return changed
+}
+
+// leadingWhitespace returns the number of leading spaces and tabs in line.
+func leadingWhitespace(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
@@ -0,0 +1,184 @@
+package mcptools_test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ToggleCommentDirPrefix = "toggle-comment-tool-test"
+
+// Toggle comment tool result type
+type ToggleCommentResult struct {
+	Success      bool   `json:"success"`
+	Path         string `json:"path"`
+	Action       string `json:"action"`
+	StartLine    int    `json:"start_line"`
+	EndLine      int    `json:"end_line"`
+	LinesChanged int    `json:"lines_changed"`
+	Diff         string `json:"diff"`
+}
+
+type toggleCommentResultOpts struct {
+	ExpectError          bool
+	ExpectedErrorMsg     string
+	ExpectedAction       string
+	ExpectedLinesChanged int
+	ExpectedContent      string
+}
+
+func requireToggleCommentResult(t *testing.T, result *ToggleCommentResult, err error, path string, opts toggleCommentResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, opts.ExpectedAction, result.Action, "Action should match")
+	assert.Equal(t, opts.ExpectedLinesChanged, result.LinesChanged, "Lines changed should match")
+	requireFileContent(t, path, opts.ExpectedContent)
+}
+
+func TestToggleCommentTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("toggle_comment")
+	require.NotNil(t, tool, "toggle_comment tool should be registered")
+
+	const goSource = `package main
+
+func main() {
+	if debug {
+		println("debug")
+
+	}
+	run()
+}
+`
+
+	setup := func(t *testing.T, name, content string) (*fsfix.RootFixture, string) {
+		tf := fsfix.NewRootFixture(ToggleCommentDirPrefix)
+		pf := tf.AddRepoFixture("toggle-project", nil)
+		ff := pf.AddFileFixture(name, &fsfix.FileFixtureArgs{Content: content})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff.Filepath
+	}
+
+	call := func(params mcputil.Params) (*ToggleCommentResult, error) {
+		params["session_token"] = testToken
+		req := mcputil.NewMockRequest(params)
+		return mcputil.GetToolResult[ToggleCommentResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call toggle_comment")
+	}
+
+	t.Run("Comment_ShouldPrefixAtCommonIndent", func(t *testing.T) {
+		tf, fp := setup(t, "main.go", goSource)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "go", "start_line": 4, "end_line": 7, "action": "comment"})
+		requireToggleCommentResult(t, result, err, fp, toggleCommentResultOpts{
+			ExpectedAction:       "comment",
+			ExpectedLinesChanged: 3,
+			ExpectedContent: `package main
+
+func main() {
+	// if debug {
+	// 	println("debug")
+
+	// }
+	run()
+}
+`,
+		})
+		assert.Contains(t, result.Diff, "+\t// if debug {", "Diff should show the commented line")
+	})
+
+	t.Run("Toggle_ShouldUncommentCommentedRange", func(t *testing.T) {
+		tf, fp := setup(t, "main.go", `package main
+
+func main() {
+	// if debug {
+	// 	println("debug")
+	// }
+	run()
+}
+`)
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "go", "start_line": 4, "end_line": 6})
+		requireToggleCommentResult(t, result, err, fp, toggleCommentResultOpts{
+			ExpectedAction:       "uncomment",
+			ExpectedLinesChanged: 3,
+			ExpectedContent: `package main
+
+func main() {
+	if debug {
+		println("debug")
+	}
+	run()
+}
+`,
+		})
+	})
+
+	t.Run("Toggle_ShouldCommentPartlyCommentedRange", func(t *testing.T) {
+		tf, fp := setup(t, "config.yaml", "server:\n  # port: 80\n  host: localhost\n")
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "yaml", "start_line": 2, "end_line": 3})
+		requireToggleCommentResult(t, result, err, fp, toggleCommentResultOpts{
+			ExpectedAction:       "comment",
+			ExpectedLinesChanged: 2,
+			ExpectedContent:      "server:\n  # # port: 80\n  # host: localhost\n",
+		})
+	})
+
+	t.Run("Uncomment_ShouldSkipUncommentedLines", func(t *testing.T) {
+		tf, fp := setup(t, "script.py", "#print(1)\nprint(2)\n    # print(3)\n")
+		defer tf.Cleanup()
+
+		result, err := call(mcputil.Params{"path": fp, "language": "python", "start_line": 1, "end_line": 3, "action": "uncomment"})
+		requireToggleCommentResult(t, result, err, fp, toggleCommentResultOpts{
+			ExpectedAction:       "uncomment",
+			ExpectedLinesChanged: 2,
+			ExpectedContent:      "print(1)\nprint(2)\n    print(3)\n",
+		})
+	})
+
+	errorCases := []struct {
+		name      string
+		language  string
+		startLine int
+		endLine   int
+		errorMsg  string
+	}{
+		{"UnsupportedLanguage_ShouldError", "markdown", 1, 2, "unsupported language"},
+		{"EndBeforeStart_ShouldError", "go", 5, 4, "invalid line range"},
+		{"PastEndOfFile_ShouldError", "go", 1, 99, "invalid line range"},
+		{"UnbalancedGo_ShouldError", "go", 4, 4, "validation failed"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tf, fp := setup(t, "main.go", goSource)
+			defer tf.Cleanup()
+
+			result, err := call(mcputil.Params{"path": fp, "language": tc.language, "start_line": tc.startLine, "end_line": tc.endLine})
+			requireToggleCommentResult(t, result, err, fp, toggleCommentResultOpts{
+				ExpectError:      true,
+				ExpectedErrorMsg: tc.errorMsg,
+			})
+			requireFileContent(t, fp, goSource)
+		})
+	}
+}
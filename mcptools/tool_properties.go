@@ -16,30 +16,103 @@ var (
 
 // Property definitions for MCP tool parameters with descriptions and defaults.
 var (
-	AllOccurrencesProperty = mcputil.Bool("all_occurrences", "Whether to replace all occurrences (default: true)", mcputil.DefaultBool{true})
-	CreateDirsProperty     = mcputil.Bool("create_dirs", "Create parent directories if needed")
-	DirsOnlyProperty       = mcputil.Bool("dirs_only", "Return only directories, not files")
-	EndLineProperty        = mcputil.Number("end_line", "Last line to handle, inclusive")
-	ExtensionsProperty     = mcputil.Array("extensions", "Filter by file extensions (e.g., ['.go', '.txt'])")
-	FilepathProperty       = mcputil.String("filepath", "File path to use for this tool")
-	FilesOnlyProperty      = mcputil.Bool("files_only", "Return only files, not directories")
-	FilesProperty          = mcputil.Array("files", "List of files to process")
-	IgnoreGitProperty      = mcputil.Bool("ignore_git_requirement", "If true, don't require .git directory to consider a directory a project (default: false)")
-	LanguageProperty       = mcputil.String("language", "Programming language of file(s) to process")
-	LineNumberProperty     = mcputil.Number("line_number", "Line number to use with this tool")
-	MaxFilesProperty       = mcputil.Number("max_files", "Maximum number of files to read (default: 100)", mcputil.DefaultInt{100})
-	MaxProjectsProperty    = mcputil.Number("max_projects", "Maximum number of recent projects to track (default: 5)", mcputil.DefaultInt{5})
-	MaxResultsProperty     = mcputil.Number("max_results", "Maximum number of results to return")
-	NamePatternProperty    = mcputil.String("name_pattern", "Exact filename pattern to match")
-	NewContentProperty     = mcputil.String("new_content", "New file content to use with this tool")
-	PartNameProperty       = mcputil.String("part_name", "Name for the part to process")
-	PartTypeProperty       = mcputil.String("part_type", "Type of the part of the programming language to process")
-	PathProperty           = mcputil.String("path", "File or directory path to use with this tool")
-	PathsProperty          = mcputil.Array("paths", "File or directory paths to use with this tool")
-	PatternProperty        = mcputil.String("pattern", "Text pattern to find")
-	PositionProperty       = mcputil.String("position", "Position to use with this tool")
-	RecursiveProperty      = mcputil.Bool("recursive", "Process directories recursively", mcputil.DefaultTrue{})
-	RegexProperty          = mcputil.Bool("regex", "Whether to treat pattern as regular expression")
-	ReplacementProperty    = mcputil.String("replacement", "Text to replace the pattern with")
-	StartLineProperty      = mcputil.Number("start_line", "First line to handle, inclusive")
+	AcronymsProperty          = mcputil.Array("acronyms", "Initialisms to enforce in addition to the defaults such as HTTP, ID and URL (e.g., ['GRPC', 'SDK'])")
+	AllOrNothingProperty      = mcputil.Bool("all_or_nothing", "Write no files unless every operation succeeds")
+	AllOccurrencesProperty    = mcputil.Bool("all_occurrences", "Whether to replace all occurrences (default: true)", mcputil.DefaultBool{true})
+	AppendContentProperty     = mcputil.String("content", "Text to append to the end of the file")
+	CheckOnlyProperty         = mcputil.Bool("check_only", "Only report whether the file is already formatted, without modifying it")
+	ChunkSizeProperty         = mcputil.Number("chunk_size", "Maximum number of bytes to return per chunk (default and maximum: 75000)", mcputil.DefaultInt{TargetCharLimit})
+	CommentActionProperty     = mcputil.String("action", "What to do with the lines: 'comment', 'uncomment' or 'toggle', which uncomments them if all are commented and comments them otherwise (default: 'toggle')", mcputil.Enum{"comment", "uncomment", "toggle"}, mcputil.DefaultString{"toggle"})
+	ConfirmationTokenProperty = mcputil.ConfirmationTokenProperty
+	ContentBase64Property     = mcputil.String("content_base64", "File content encoded as standard base64")
+	ContentPatternProperty    = mcputil.String("content_pattern", "Only return files containing this text, with their matching lines")
+	CopyRecursiveProperty     = mcputil.Bool("recursive", "Copy a directory and everything in it")
+	CreateDirsProperty        = mcputil.Bool("create_dirs", "Create parent directories if needed")
+	CreateProperty            = mcputil.Bool("create", "Create the file if it does not exist")
+	DefaultsProperty          = mcputil.String("defaults", "JSON object of default values; the config is merged over it")
+	DestinationProperty       = mcputil.String("destination", "Path to move the file to")
+	DiffContextProperty       = mcputil.Number("diff_context", "Number of unchanged lines to show around each change in the diff (default: 3)", mcputil.DefaultInt{mcputil.DiffContextLines})
+	DirsOnlyProperty          = mcputil.Bool("dirs_only", "Return only directories, not files")
+	DryRunProperty            = mcputil.DryRunProperty
+	EndLineProperty           = mcputil.Number("end_line", "Last line to handle, inclusive")
+	ExcludeModeProperty       = mcputil.String("exclude_mode", "How exclude relates to the default excludes: 'add' to skip both, or 'replace' to skip only the exclude patterns (default: 'add')", mcputil.Enum{"add", "replace"}, mcputil.DefaultString{"add"})
+	ExcludeProperty           = mcputil.Array("exclude", "Glob patterns of files or directories to exclude (e.g., ['vendor', '*.log'])")
+	ExpectedSymbolsProperty   = mcputil.Array("expected_symbols", "Top-level symbol names the file should declare; methods are qualified by receiver as Type.Method")
+	ExportedOnlyProperty      = mcputil.Bool("exported_only", "Check only exported identifiers, as Go convention requires doc comments only on those")
+	ExtensionsProperty        = mcputil.Array("extensions", "Filter by file extensions (e.g., ['.go', '.txt'])")
+	FilepathProperty          = mcputil.String("filepath", "File path to use for this tool")
+	FilesOnlyProperty         = mcputil.Bool("files_only", "Return only files, not directories")
+	FilesProperty             = mcputil.Array("files", "List of files to process")
+	FixProperty               = mcputil.Bool("fix", "Apply fixes instead of only reporting problems")
+	ForceProperty             = mcputil.Bool("force", "Proceed even though the change may affect code outside the file")
+	FuzzyQueryProperty        = mcputil.String("query", "Approximate file name or path to match; its characters must appear in order in the file's relative path (e.g., 'usrctl' matches 'user_controller.go')")
+	HeaderMarkerProperty      = mcputil.String("marker", "Text identifying an existing header to replace when it differs from the template (default: 'Copyright')", mcputil.DefaultString{"Copyright"})
+	HeaderTemplateProperty    = mcputil.String("header_template", "Header text, including comment markers, to place at the top of each file; {year} is replaced by the current year")
+	IgnoreGitProperty         = mcputil.Bool("ignore_git_requirement", "If true, don't require .git directory to consider a directory a project (default: false)")
+	IgnorePatternProperty     = mcputil.String("ignore_pattern", "Regular expression; lines matching it are not reported")
+	IgnoreURLsProperty        = mcputil.Bool("ignore_urls", "Do not report lines containing a URL, which usually cannot be wrapped")
+	IncludeDiffProperty       = mcputil.Bool("include_diff", "Include a unified diff of the file's old and new content in the result")
+	IncludeDiffsProperty      = mcputil.Bool("include_diffs", "Include unified diffs for changed text files")
+	IssueTypesProperty        = mcputil.Array("issue_types", "Issue types to report: 'readme', 'package', 'file', 'func', 'type', 'const', 'var' or 'group' (default: all)")
+	IndentFromProperty        = mcputil.String("from", "Current indentation unit: 'tabs' or 'spaces'", mcputil.Enum{"tabs", "spaces"})
+	IndentToProperty          = mcputil.String("to", "Target indentation unit: 'tabs' or 'spaces'", mcputil.Enum{"tabs", "spaces"})
+	IndentWidthProperty       = mcputil.Number("width", "Number of spaces per indentation level (default: 4)", mcputil.DefaultInt{4})
+	InterfaceNameProperty     = mcputil.String("interface_name", "Name of the interface to implement, declared in the same package")
+	KeepProperty              = mcputil.Number("keep", "Number of rotated copies to keep as path.1 through path.<keep> (default: 5)", mcputil.DefaultInt{5})
+	KeepDirectivesProperty    = mcputil.Bool("keep_directives", "Keep //go: directives, // +build constraints and the cgo preamble, which affect how the file builds (default: true)", mcputil.DefaultTrue{})
+	LanguageProperty          = mcputil.String("language", "Programming language of file(s) to process")
+	LineEndingProperty        = mcputil.String("to", "Target line ending: 'lf' or 'crlf'", mcputil.Enum{"lf", "crlf"})
+	LineNumberProperty        = mcputil.Number("line_number", "Line number to use with this tool")
+	ListRecursiveProperty     = mcputil.Bool("recursive", "Also list the entries of subdirectories, and of theirs in turn")
+	MappingsProperty          = mcputil.Array("mappings", "List of {\"from\": \"old\", \"to\": \"new\"} replacement objects")
+	MaxBytesProperty          = mcputil.Number("max_bytes", "Rotate only when the file is larger than this many bytes (default: 10485760)", mcputil.DefaultInt{10 << 20})
+	MaxCyclomaticProperty     = mcputil.Number("max_cyclomatic", "Also report functions whose cyclomatic complexity exceeds this value")
+	MaxDepthProperty          = mcputil.Number("max_depth", "Maximum depth of subdirectories to list when recursive; 1 lists only immediate subdirectories (default: 3)", mcputil.DefaultInt{3})
+	MaxFilesProperty          = mcputil.Number("max_files", "Maximum number of files to read (default: 100)", mcputil.DefaultInt{100})
+	MaxLengthProperty         = mcputil.Number("max_length", "Maximum allowed line length in characters (default: 100)", mcputil.DefaultInt{100})
+	MaxProjectsProperty       = mcputil.Number("max_projects", "Maximum number of recent projects to track (default: 5)", mcputil.DefaultInt{5})
+	MaxReplacementsProperty   = mcputil.Number("max_replacements", "Maximum number of matches to replace, in order; 0 replaces them all (default: 0)", mcputil.DefaultInt{0})
+	MaxResponseBytesProperty  = mcputil.Number("max_response_bytes", "Stop including file contents once they would exceed this many bytes in total; 0 for no limit (default: 0)", mcputil.DefaultInt{0})
+	MaxResultsProperty        = mcputil.Number("max_results", "Maximum number of results to return")
+	MinBlockLinesProperty     = mcputil.Number("min_lines", "Minimum number of non-blank lines in a duplicated block (default: 5)", mcputil.DefaultInt{5})
+	MinLengthProperty         = mcputil.Number("min_length", "Minimum length in characters of values to include (default: 1)", mcputil.DefaultInt{1})
+	MinLinesProperty          = mcputil.Number("min_lines", "Minimum number of lines for a function to be reported (default: 50)", mcputil.DefaultInt{50})
+	NamePatternProperty       = mcputil.String("name_pattern", "Exact filename pattern to match")
+	NewFuncNameProperty       = mcputil.String("new_func_name", "Name of the function to create")
+	NewContentProperty        = mcputil.String("new_content", "New file content to use with this tool")
+	OccurrenceProperty        = mcputil.String("occurrence", "Which match to use: a 1-based number, or 'all' for every match (default: 1)", mcputil.DefaultString{"1"})
+	OffsetProperty            = mcputil.Number("offset", "Byte offset to read from, as returned in next_offset (default: 0)")
+	OperationProperty         = mcputil.String("operation", "Operation to confirm: 'delete', 'recursive_delete' or 'overwrite'", mcputil.Enum{"delete", "recursive_delete", "overwrite"})
+	OutputFormatProperty      = mcputil.String("output_format", "Result encoding: 'json' for a single object, or 'ndjson' for one result object per line without summary fields (default: 'json')", mcputil.Enum{"json", "ndjson"}, mcputil.DefaultString{"json"})
+	OverwriteProperty         = mcputil.Bool("overwrite", "Replace the file if it already exists")
+	PartNameProperty          = mcputil.String("part_name", "Name for the part to process")
+	PartTypeProperty          = mcputil.String("part_type", "Type of the part of the programming language to process")
+	PartOperationsProperty    = mcputil.Array("operations", "List of {\"path\", \"language\", \"part_type\", \"part_name\", \"new_content\"} replacement objects")
+	PathAProperty             = mcputil.String("path_a", "First directory to compare")
+	PathBProperty             = mcputil.String("path_b", "Second directory to compare")
+	PathProperty              = mcputil.String("path", "File or directory path to use with this tool")
+	PathsProperty             = mcputil.Array("paths", "File or directory paths to use with this tool")
+	PatternProperty           = mcputil.String("pattern", "Text pattern to find")
+	PerFilePackageDocProperty = mcputil.Bool("per_file_package_doc", "Require a package comment in every file rather than in one file per package")
+	PermissionsProperty       = mcputil.String("permissions", "Permissions for the directory as an octal string (default: '0755')", mcputil.DefaultString{"0755"})
+	PositionProperty          = mcputil.String("position", "Position to use with this tool")
+	RecursiveProperty         = mcputil.Bool("recursive", "Process directories recursively", mcputil.DefaultTrue{})
+	RegexProperty             = mcputil.Bool("regex", "Whether to treat pattern as regular expression")
+	RequiredFieldsProperty    = mcputil.Array("required_fields", "Dotted paths of fields that must be present once defaults are applied (e.g., ['server.port'])")
+	ReadMaxBytesProperty      = mcputil.Number("max_bytes", "Maximum number of bytes to return from each file, from start_byte; 0 returns the rest of the file (default: 0)", mcputil.DefaultInt{0})
+	ReplacementProperty       = mcputil.String("replacement", "Text to replace the pattern with")
+	RevokeTokenProperty       = mcputil.String("token", "Session token to revoke (default: this session's session_token)")
+	RotateTokenProperty       = mcputil.Bool("rotate_token", "Replace the session token with a new one carrying over the session's state; the old token stops working")
+	SessionTTLMinutesProperty = mcputil.SessionTTLMinutesProperty
+	SkipFormatProperty        = mcputil.Bool("skip_format", "Write the result as spliced instead of formatting it with gofmt")
+	SkipImportsProperty       = mcputil.Bool("skip_imports", "Skip import paths (default: true)", mcputil.DefaultTrue{})
+	SkipStructTagsProperty    = mcputil.Bool("skip_struct_tags", "Skip struct field tags (default: true)", mcputil.DefaultTrue{})
+	SourceProperty            = mcputil.String("source", "File to move")
+	StartByteProperty         = mcputil.Number("start_byte", "Byte offset in each file to start returning content from (default: 0)", mcputil.DefaultInt{0})
+	StartLineProperty         = mcputil.Number("start_line", "First line to handle, inclusive")
+	SymbolKindProperty        = mcputil.String("kind", "Kind of declaration to match: 'func', 'type', 'const' or 'var' (default: any)", mcputil.Enum{"func", "type", "const", "var"})
+	SymbolNameProperty        = mcputil.String("name", "Symbol name to find; methods may be qualified by receiver as Type.Method")
+	TTLMinutesProperty        = mcputil.Number("ttl_minutes", "Minutes until the lock expires unless renewed; never outlives the session (default: 30)", mcputil.DefaultInt{30})
+	TypeNameProperty          = mcputil.String("type_name", "Name of the type to add methods to")
+	WorkersProperty           = mcputil.Number("workers", "Number of files to process at once (default: GOMAXPROCS, the number of CPUs Go uses)", mcputil.DefaultInt{0})
 )
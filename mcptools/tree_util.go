@@ -0,0 +1,161 @@
+package mcptools
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+// binarySniffLen is the number of leading bytes inspected when deciding
+// whether a file is binary, matching the heuristic used by git.
+const binarySniffLen = 8000
+
+// treeScanArgs contains the arguments for collecting files across one or more paths.
+type treeScanArgs struct {
+	Paths      []string // Files or directories to scan
+	Recursive  bool     // Whether to descend into subdirectories
+	Extensions []string // Optional extension filter (e.g., ".go" or "go")
+	Excludes   []string // Optional glob patterns of files or directories to skip
+}
+
+// collectTreeFiles returns every regular file found under args.Paths that
+// matches the extension filter. Each path must be allowed by cfg. Hidden
+// subdirectories such as .git are skipped, as are entries matching
+// args.Excludes; explicitly named files are always included regardless of
+// the extension and exclude filters.
+func collectTreeFiles(cfg mcputil.Config, args treeScanArgs) (files []string, err error) {
+	var info os.FileInfo
+
+	for _, path := range args.Paths {
+		if !cfg.IsAllowedPath(path) {
+			err = fmt.Errorf("access denied: path not allowed: %s", path)
+			goto end
+		}
+
+		info, err = os.Stat(path)
+		if err != nil {
+			err = fmt.Errorf("cannot access %s: %v", path, err)
+			goto end
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(fp string, d fs.DirEntry, walkErr error) (err error) {
+			var rel string
+
+			if walkErr != nil {
+				err = walkErr
+				goto end
+			}
+
+			if fp == path {
+				goto end
+			}
+
+			rel, err = filepath.Rel(path, fp)
+			if err != nil {
+				goto end
+			}
+
+			if d.IsDir() {
+				if !args.Recursive || strings.HasPrefix(d.Name(), ".") || matchesAnyGlob(filepath.ToSlash(rel), args.Excludes) {
+					err = filepath.SkipDir
+				}
+				goto end
+			}
+
+			if matchesAnyGlob(filepath.ToSlash(rel), args.Excludes) {
+				goto end
+			}
+
+			if !d.Type().IsRegular() {
+				goto end
+			}
+
+			if !matchesExtensions(fp, args.Extensions) {
+				goto end
+			}
+
+			files = append(files, fp)
+
+		end:
+			return err
+		})
+		if err != nil {
+			goto end
+		}
+	}
+
+end:
+	return files, err
+}
+
+// matchesExtensions reports whether fp has one of the given extensions,
+// compared case-insensitively. An empty extension list matches every file.
+func matchesExtensions(fp string, extensions []string) (matches bool) {
+	var ext string
+
+	if len(extensions) == 0 {
+		matches = true
+		goto end
+	}
+
+	ext = strings.ToLower(filepath.Ext(fp))
+	for _, e := range extensions {
+		if !strings.HasPrefix(e, ".") {
+			e = "." + e
+		}
+		if strings.ToLower(e) == ext {
+			matches = true
+			goto end
+		}
+	}
+
+end:
+	return matches
+}
+
+// isBinaryContent reports whether content looks like binary data, which is
+// assumed when a NUL byte appears within the first binarySniffLen bytes.
+func isBinaryContent(content []byte) bool {
+	if len(content) > binarySniffLen {
+		content = content[:binarySniffLen]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// expandGlobPaths expands any path containing glob metacharacters into the
+// paths it matches, leaving other paths unchanged. A glob matching nothing
+// is reported as an error so callers don't silently operate on no files.
+func expandGlobPaths(paths []string) (expanded []string, err error) {
+	var matches []string
+
+	expanded = make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !strings.ContainsAny(path, "*?[") {
+			expanded = append(expanded, path)
+			continue
+		}
+		matches, err = filepath.Glob(path)
+		if err != nil {
+			err = fmt.Errorf("invalid glob pattern %s: %v", path, err)
+			goto end
+		}
+		if len(matches) == 0 {
+			err = fmt.Errorf("no files match %s", path)
+			goto end
+		}
+		expanded = append(expanded, matches...)
+	}
+
+end:
+	return expanded, err
+}
@@ -0,0 +1,83 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*UndoEditTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&UndoEditTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "undo_edit",
+			Description: "Restore a file to its content before the most recent change this session made to it, removing it if that change created it. Repeat to step further back through the session's bounded history of the file",
+			QuickHelp:   "Revert this session's last change to a file",
+			Mutating:    true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+			},
+		}),
+	})
+}
+
+// UndoEditTool restores files from the backups taken before each change a
+// session makes to them.
+type UndoEditTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the undo_edit tool request and restores the newest backup
+// of the file taken in the calling session.
+func (t *UndoEditTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var path string
+	var backup mcputil.EditBackup
+	var remaining int
+	var op mcputil.FileOperation
+	var message string
+
+	logger.Info("Tool called", "tool", "undo_edit")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "undo_edit", "path", path)
+
+	backup, remaining, err = mcputil.UndoEdit(ctx, t.Config(), token, path)
+	if err != nil {
+		goto end
+	}
+
+	op = mcputil.UpdatedFileOp
+	message = fmt.Sprintf("Restored %s to its content from %s", path, backup.SavedAt.Format("15:04:05"))
+	if !backup.Existed {
+		op = mcputil.DeletedFileOp
+		message = fmt.Sprintf("Removed %s, which did not exist before it was changed", path)
+	}
+	recordFileChange(ctx, req, op, path)
+
+	logger.Info("Tool completed", "tool", "undo_edit", "path", path, "remaining_backups", remaining)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":           true,
+		"path":              path,
+		"removed":           !backup.Existed,
+		"restored_from":     backup.SavedAt,
+		"remaining_backups": remaining,
+		"message":           message,
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,216 @@
+package mcptools_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const UndoEditDirPrefix = "undo-edit-tool-test"
+
+// Undo edit tool result type
+type UndoEditResult struct {
+	Success          bool   `json:"success"`
+	Path             string `json:"path"`
+	Removed          bool   `json:"removed"`
+	RemainingBackups int    `json:"remaining_backups"`
+	Message          string `json:"message"`
+}
+
+type undoEditResultOpts struct {
+	ExpectError       bool
+	ExpectedErrorMsg  string
+	ExpectedRemoved   bool
+	ExpectedRemaining int
+}
+
+func requireUndoEditResult(t *testing.T, result *UndoEditResult, err error, opts undoEditResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	assert.True(t, result.Success, "Undo should succeed")
+	assert.Equal(t, opts.ExpectedRemoved, result.Removed, "Removed should match expected")
+	assert.Equal(t, opts.ExpectedRemaining, result.RemainingBackups, "Remaining backups should match expected")
+}
+
+// setEditBackups stores edit backups under dir, keeping count per file, for
+// the rest of the test.
+func setEditBackups(t *testing.T, dir string, count int) {
+	t.Helper()
+
+	store := scoutcfg.NewFileStore("scout-mcp-test")
+	store.SetBaseDir(dir)
+	require.NoError(t, mcputil.SetEditBackups(store, count), "Should enable edit backups")
+	t.Cleanup(func() {
+		_ = mcputil.SetEditBackups(nil, 0)
+	})
+}
+
+func TestUndoEditTool(t *testing.T) {
+	// Get the tools
+	tool := mcputil.GetRegisteredTool("undo_edit")
+	require.NotNil(t, tool, "undo_edit tool should be registered")
+	replaceTool := mcputil.GetRegisteredTool("replace_pattern")
+	require.NotNil(t, replaceTool, "replace_pattern tool should be registered")
+	createTool := mcputil.GetRegisteredTool("create_file")
+	require.NotNil(t, createTool, "create_file tool should be registered")
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		setEditBackups(t, t.TempDir(), 2)
+		config := mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		})
+		tool.SetConfig(config)
+		replaceTool.SetConfig(config)
+		createTool.SetConfig(config)
+	}
+
+	replace := func(t *testing.T, path, pattern, replacement string) {
+		t.Helper()
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+			"pattern":       pattern,
+			"replacement":   replacement,
+		})
+		_, err := mcputil.CallTool(replaceTool, req)
+		require.NoError(t, err, "Should replace %q", pattern)
+	}
+
+	undo := func(path string) (*UndoEditResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"path":          path,
+		})
+		return mcputil.GetToolResult[UndoEditResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should call undo_edit")
+	}
+
+	t.Run("UndoAfterReplace_ShouldRestorePriorContent", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UndoEditDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("undo-project", nil)
+		testFile := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+			Content: "alpha beta gamma\n",
+		})
+		setup(t, tf)
+
+		replace(t, testFile.Filepath, "beta", "BETA")
+		requireFileContent(t, testFile.Filepath, "alpha BETA gamma\n")
+
+		result, err := undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{})
+		requireFileContent(t, testFile.Filepath, "alpha beta gamma\n")
+	})
+
+	t.Run("UndoAfterReplace_ShouldRestorePriorMode", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UndoEditDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("mode-project", nil)
+		testFile := pf.AddFileFixture("run.txt", &fsfix.FileFixtureArgs{
+			Content: "echo alpha\n",
+		})
+		setup(t, tf)
+		require.NoError(t, os.Chmod(testFile.Filepath, 0750), "Should make file executable")
+
+		replace(t, testFile.Filepath, "alpha", "beta")
+		require.NoError(t, os.Remove(testFile.Filepath), "Should remove file")
+
+		result, err := undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{})
+		requireFileContent(t, testFile.Filepath, "echo alpha\n")
+
+		info, err := os.Stat(testFile.Filepath)
+		require.NoError(t, err, "Should stat restored file")
+		assert.Equal(t, os.FileMode(0750), info.Mode().Perm(), "Restored file should keep its mode")
+	})
+
+	t.Run("RepeatedUndo_ShouldStepBackThroughBoundedHistory", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UndoEditDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("history-project", nil)
+		testFile := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+			Content: "one\n",
+		})
+		setup(t, tf)
+
+		replace(t, testFile.Filepath, "one", "two")
+		replace(t, testFile.Filepath, "two", "three")
+		replace(t, testFile.Filepath, "three", "four")
+
+		result, err := undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{ExpectedRemaining: 1})
+		requireFileContent(t, testFile.Filepath, "three\n")
+
+		result, err = undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{ExpectedRemaining: 0})
+		requireFileContent(t, testFile.Filepath, "two\n")
+
+		// Only two backups are kept, so the original content is gone
+		result, err = undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "no edit to undo",
+		})
+		requireFileContent(t, testFile.Filepath, "two\n")
+	})
+
+	t.Run("UndoCreate_ShouldRemoveFile", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UndoEditDirPrefix)
+		defer tf.Cleanup()
+
+		newFile := tf.AddFileFixture("created.txt", &fsfix.FileFixtureArgs{
+			Pending: true,
+		})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      newFile.Filepath,
+			"new_content":   "created content",
+		})
+		_, err := mcputil.CallTool(createTool, req)
+		require.NoError(t, err, "Should create file")
+
+		result, err := undo(newFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{ExpectedRemoved: true})
+		_, err = os.Stat(newFile.Filepath)
+		assert.True(t, os.IsNotExist(err), "Created file should be removed")
+	})
+
+	t.Run("NoEdits_ShouldReturnError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UndoEditDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("untouched-project", nil)
+		testFile := pf.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+			Content: "untouched\n",
+		})
+		setup(t, tf)
+
+		result, err := undo(testFile.Filepath)
+		requireUndoEditResult(t, result, err, undoEditResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "no edit to undo",
+		})
+		requireFileContent(t, testFile.Filepath, "untouched\n")
+	})
+}
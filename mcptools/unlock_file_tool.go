@@ -0,0 +1,61 @@
+package mcptools
+
+import (
+	"context"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*UnlockFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&UnlockFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "unlock_file",
+			Description: "Release a lock this session acquired with lock_file. Locks held by other sessions cannot be released",
+			QuickHelp:   "Release a file claimed by this session",
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty,
+			},
+		}),
+	})
+}
+
+// UnlockFileTool releases an advisory lock held by the calling session.
+type UnlockFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the unlock_file tool request and reports whether a lock was released.
+func (t *UnlockFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var token string
+	var path string
+	var unlocked bool
+
+	logger.Info("Tool called", "tool", "unlock_file")
+
+	token, err = RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "unlock_file", "path", path)
+
+	unlocked = mcputil.UnlockFile(token, path)
+
+	logger.Info("Tool completed", "tool", "unlock_file", "path", path, "unlocked", unlocked)
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"path":     path,
+		"unlocked": unlocked,
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,126 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const UnlockFileDirPrefix = "unlock-file-tool-test"
+
+// Unlock file tool result type
+type UnlockFileResult struct {
+	Path     string `json:"path"`
+	Unlocked bool   `json:"unlocked"`
+}
+
+type unlockFileResultOpts struct {
+	ExpectError      bool
+	ExpectedErrorMsg string
+	ExpectUnlocked   bool
+	ExpectStillHeld  bool
+}
+
+func requireUnlockFileResult(t *testing.T, result *UnlockFileResult, err error, path string, opts unlockFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+	assert.Equal(t, path, result.Path, "Path should match")
+	assert.Equal(t, opts.ExpectUnlocked, result.Unlocked, "Unlocked flag should match")
+
+	_, held := mcputil.LockedByOtherSession("", path)
+	assert.Equal(t, opts.ExpectStillHeld, held, "Whether the lock is still held should match")
+}
+
+func TestUnlockFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("unlock_file")
+	require.NotNil(t, tool, "unlock_file tool should be registered")
+
+	setup := func(t *testing.T) (*fsfix.RootFixture, *fsfix.FileFixture) {
+		tf := fsfix.NewRootFixture(UnlockFileDirPrefix)
+		ff := tf.AddFileFixture("claimed.txt", &fsfix.FileFixtureArgs{Content: "claimed\n"})
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+		return tf, ff
+	}
+
+	cleanup := func(tf *fsfix.RootFixture) {
+		mcputil.ClearFileLocks(testToken)
+		mcputil.ClearFileLocks(otherToken)
+		tf.Cleanup()
+	}
+
+	unlockFile := func(token, path string) (*UnlockFileResult, error) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": token,
+			"path":          path,
+		})
+		return mcputil.GetToolResult[UnlockFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error unlocking file")
+	}
+
+	t.Run("OwnLock_ShouldUnlock", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		_, err := mcputil.LockFile(testToken, ff.Filepath, time.Minute)
+		require.NoError(t, err, "Should lock file")
+
+		result, err := unlockFile(testToken, ff.Filepath)
+		requireUnlockFileResult(t, result, err, ff.Filepath, unlockFileResultOpts{
+			ExpectUnlocked: true,
+		})
+	})
+
+	t.Run("OtherSessionLock_ShouldNotUnlock", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		_, err := mcputil.LockFile(otherToken, ff.Filepath, time.Minute)
+		require.NoError(t, err, "Other session should lock file")
+
+		result, err := unlockFile(testToken, ff.Filepath)
+		requireUnlockFileResult(t, result, err, ff.Filepath, unlockFileResultOpts{
+			ExpectUnlocked:  false,
+			ExpectStillHeld: true,
+		})
+	})
+
+	t.Run("NotLocked_ShouldReportFalse", func(t *testing.T) {
+		tf, ff := setup(t)
+		defer cleanup(tf)
+
+		result, err := unlockFile(testToken, ff.Filepath)
+		requireUnlockFileResult(t, result, err, ff.Filepath, unlockFileResultOpts{
+			ExpectUnlocked: false,
+		})
+	})
+
+	t.Run("MissingPath_ShouldError", func(t *testing.T) {
+		tf, _ := setup(t)
+		defer cleanup(tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+		})
+		result, err := mcputil.GetToolResult[UnlockFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error without path")
+		requireUnlockFileResult(t, result, err, "", unlockFileResultOpts{
+			ExpectError: true,
+		})
+	})
+}
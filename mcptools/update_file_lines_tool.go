@@ -16,12 +16,15 @@ func init() {
 			Name:        "update_file_lines",
 			Description: "Update specific lines in a file by line number range",
 			QuickHelp:   "Edit specific line ranges safely",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				FilepathProperty.Required(),
 				NewContentProperty.Required(),
 				StartLineProperty.Required(),
 				EndLineProperty.Required(),
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -33,10 +36,13 @@ type UpdateFileLinesTool struct {
 }
 
 // Handle processes the update_file_lines tool request and replaces the specified line range.
-func (t *UpdateFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *UpdateFileLinesTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var startLine, endLine int
 	var newContent string
+	var originalContent, updatedContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "update_file_lines")
 
@@ -67,18 +73,27 @@ func (t *UpdateFileLinesTool) Handle(_ context.Context, req mcputil.ToolRequest)
 		goto end
 	}
 
-	err = t.updateFileLines(filePath, startLine, endLine, newContent)
+	diffOpts, err = getDiffOptions(req)
 	if err != nil {
 		goto end
 	}
 
-	result = mcputil.NewToolResultJSON(map[string]any{
+	originalContent, updatedContent, err = t.updateFileLines(ctx, filePath, startLine, endLine, newContent)
+	if err != nil {
+		goto end
+	}
+
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
+	fields = map[string]any{
 		"success":    true,
 		"file_path":  filePath,
 		"start_line": startLine,
 		"end_line":   endLine,
 		"message":    fmt.Sprintf("Successfully updated lines %d-%d in %s", startLine, endLine, filePath),
-	})
+	}
+	diffOpts.addDiff(fields, filePath, originalContent, updatedContent)
+	result = mcputil.NewToolResultJSON(fields)
 	logger.Info("Tool completed", "tool", "update_file_lines", "path", filePath, "start_line", startLine, "end_line", endLine)
 
 end:
@@ -100,10 +115,8 @@ end:
 	return err
 }
 
-func (t *UpdateFileLinesTool) updateFileLines(filePath string, startLine, endLine int, newContent string) (err error) {
-	var originalContent string
+func (t *UpdateFileLinesTool) updateFileLines(ctx context.Context, filePath string, startLine, endLine int, newContent string) (originalContent, updatedContent string, err error) {
 	var lines []string
-	var updatedContent string
 
 	if !t.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
@@ -124,10 +137,10 @@ func (t *UpdateFileLinesTool) updateFileLines(filePath string, startLine, endLin
 
 	updatedContent = t.replaceLines(lines, startLine, endLine, newContent)
 
-	err = WriteFile(t.Config(), filePath, updatedContent)
+	err = WriteFile(ctx, t.Config(), filePath, updatedContent)
 
 end:
-	return err
+	return originalContent, updatedContent, err
 }
 
 func (t *UpdateFileLinesTool) validateLineNumbers(lines []string, startLine, endLine int) (err error) {
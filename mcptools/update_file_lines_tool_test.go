@@ -20,6 +20,7 @@ type UpdateFileLinesResult struct {
 	EndLine      int    `json:"end_line"`
 	LinesUpdated int    `json:"lines_updated"`
 	Message      string `json:"message"`
+	Diff         string `json:"diff"`
 }
 
 type updateFileLinesResultOpts struct {
@@ -33,6 +34,7 @@ type updateFileLinesResultOpts struct {
 	ShouldUpdateFile     bool
 	ShouldContainText    string
 	ShouldNotContainText string
+	ExpectedDiff         string
 }
 
 func requireUpdateFileLinesResult(t *testing.T, result *UpdateFileLinesResult, err error, opts updateFileLinesResultOpts) {
@@ -67,6 +69,8 @@ func requireUpdateFileLinesResult(t *testing.T, result *UpdateFileLinesResult, e
 		assert.Equal(t, opts.ExpectedLinesUpdated, result.LinesUpdated, "Lines updated should match expected")
 	}
 
+	assert.Equal(t, opts.ExpectedDiff, result.Diff, "Diff should match expected")
+
 	// Check file system side effects
 	if opts.ShouldUpdateFile && opts.ExpectedFilePath != "" {
 		_, err := os.Stat(opts.ExpectedFilePath)
@@ -163,6 +167,74 @@ func TestUpdateFileLinesTool(t *testing.T) {
 		})
 	})
 
+	t.Run("IncludeDiff_ShouldReturnDiffWithRequestedContext", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UpdateFileLinesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("update-diff-project", nil)
+		testFile := pf.AddFileFixture("update_diff_test.txt", &fsfix.FileFixtureArgs{
+			Content: "Line 1\nLine 2\nLine 3\nLine 4\nLine 5\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      testFile.Filepath,
+			"start_line":    "3",
+			"end_line":      "3",
+			"new_content":   "Updated Line 3",
+			"include_diff":  true,
+			"diff_context":  1,
+		})
+
+		result, err := mcputil.GetToolResult[UpdateFileLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error updating with diff")
+
+		requireUpdateFileLinesResult(t, result, err, updateFileLinesResultOpts{
+			ExpectedFilePath: testFile.Filepath,
+			ShouldUpdateFile: true,
+			ExpectedContent:  "Line 1\nLine 2\nUpdated Line 3\nLine 4\nLine 5\n",
+			ExpectedDiff: "--- a/" + testFile.Filepath + "\n+++ b/" + testFile.Filepath + "\n" +
+				"@@ -2,3 +2,3 @@\n Line 2\n-Line 3\n+Updated Line 3\n Line 4\n",
+		})
+	})
+
+	t.Run("NegativeDiffContext_ShouldReturnErrorWithoutWriting", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(UpdateFileLinesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("update-diff-error-project", nil)
+		testFile := pf.AddFileFixture("update_diff_error_test.txt", &fsfix.FileFixtureArgs{
+			Content: "Line 1\nLine 2\n",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"filepath":      testFile.Filepath,
+			"start_line":    "1",
+			"end_line":      "1",
+			"new_content":   "Updated Line 1",
+			"include_diff":  true,
+			"diff_context":  -1,
+		})
+
+		result, err := mcputil.GetToolResult[UpdateFileLinesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should reject the diff context")
+
+		requireUpdateFileLinesResult(t, result, err, updateFileLinesResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "diff_context must not be negative",
+		})
+		requireFileContent(t, testFile.Filepath, "Line 1\nLine 2\n")
+	})
+
 	t.Run("UpdateInvalidLineRange_ShouldReturnErrorWithMessage", func(t *testing.T) {
 		tf := fsfix.NewRootFixture(UpdateFileLinesDirPrefix)
 		defer tf.Cleanup()
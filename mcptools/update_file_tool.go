@@ -15,10 +15,14 @@ func init() {
 		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
 			Name:        "update_file",
 			Description: "Update existing file in allowed directories",
+			Previewable: true,
 			Properties: []mcputil.Property{
 				RequiredSessionTokenProperty,
 				FilepathProperty.Required(),
 				NewContentProperty.Required(),
+				ConfirmationTokenProperty,
+				IncludeDiffProperty,
+				DiffContextProperty,
 			},
 		}),
 	})
@@ -30,11 +34,14 @@ type UpdateFileTool struct {
 }
 
 // Handle processes the update_file tool request and replaces the entire file content.
-func (t *UpdateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+func (t *UpdateFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var filePath string
 	var content string
 	var fileInfo os.FileInfo
 	var oldSize int64
+	var oldContent string
+	var diffOpts diffOptions
+	var fields map[string]any
 
 	logger.Info("Tool called", "tool", "update_file")
 
@@ -48,6 +55,11 @@ func (t *UpdateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (res
 		goto end
 	}
 
+	diffOpts, err = getDiffOptions(req)
+	if err != nil {
+		goto end
+	}
+
 	logger.Info("Tool arguments parsed", "tool", "update_file", "path", filePath, "content_length", len(content))
 
 	// Check path is allowed
@@ -75,21 +87,37 @@ func (t *UpdateFileTool) Handle(_ context.Context, req mcputil.ToolRequest) (res
 
 	oldSize = fileInfo.Size()
 
+	if diffOpts.Include {
+		oldContent, err = ReadFile(t.Config(), filePath)
+		if err != nil {
+			goto end
+		}
+	}
+
+	err = mcputil.ConfirmOperation(ctx, mcputil.OverwriteOperation, filePath)
+	if err != nil {
+		goto end
+	}
+
 	// Update the file
-	err = os.WriteFile(filePath, []byte(content), fileInfo.Mode())
+	err = mcputil.WriteFile(ctx, t.Config(), filePath, content)
 	if err != nil {
 		err = fmt.Errorf("failed to update file: %v", err)
 		goto end
 	}
 
+	recordFileChange(ctx, req, mcputil.UpdatedFileOp, filePath)
+
 	logger.Info("Tool completed", "tool", "update_file", "success", true, "path", filePath)
-	result = mcputil.NewToolResultJSON(map[string]any{
+	fields = map[string]any{
 		"success":   true,
 		"file_path": filePath,
 		"old_size":  oldSize,
 		"new_size":  len(content),
 		"message":   fmt.Sprintf("File updated successfully: %s (%d -> %d bytes)", filePath, oldSize, len(content)),
-	})
+	}
+	diffOpts.addDiff(fields, filePath, oldContent, content)
+	result = mcputil.NewToolResultJSON(fields)
 end:
 	return result, err
 }
@@ -1,9 +1,12 @@
 package mcptools
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/mikeschinkel/scout-mcp/mcputil"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -49,3 +52,26 @@ func checkFileExists(fp string) (err error) {
 	}
 	return err
 }
+
+// mustClose closes an io.Closer and logs any error, for use in defer statements.
+func mustClose(c io.Closer) {
+	err := c.Close()
+	if err != nil {
+		logger.Error("Failed to close resource", "error", err)
+	}
+}
+
+// recordFileChange records paths as changed by the session making req so
+// they can later be reported by get_changed_files. Previewed changes are
+// not recorded since nothing was written.
+func recordFileChange(ctx context.Context, req mcputil.ToolRequest, op mcputil.FileOperation, paths ...string) {
+	if mcputil.IsPreview(ctx) {
+		return
+	}
+	token, err := RequiredSessionTokenProperty.String(req)
+	if err != nil {
+		logger.Warn("Unable to record file change without session token", "error", err)
+		return
+	}
+	mcputil.RecordFileChange(token, op, paths...)
+}
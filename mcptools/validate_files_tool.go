@@ -20,9 +20,11 @@ func init() {
 				RequiredSessionTokenProperty,
 				FilesProperty,
 				PathsProperty,
-				LanguageProperty,
+				LanguageProperty.Description("Language to validate every file as, or 'auto' to validate each file as the language of its extension, skipping files with no language processor"),
 				RecursiveProperty,
 				ExtensionsProperty.Description("Extensions of files to process for this tool"),
+				WorkersProperty,
+				OutputFormatProperty,
 			},
 			Requires: []mcputil.Requirement{
 				mcputil.RequiresOneOf{
@@ -50,7 +52,9 @@ type ValidationSummary struct {
 	TotalFiles   int                `json:"total_files"`
 	ValidFiles   int                `json:"valid_files"`
 	InvalidFiles int                `json:"invalid_files"`
+	SkippedFiles int                `json:"skipped_files,omitempty"`
 	Results      []ValidationResult `json:"results"`
+	Skipped      []string           `json:"skipped,omitempty"`
 	OverallValid bool               `json:"overall_valid"`
 }
 
@@ -82,9 +86,12 @@ func (t *ValidateFilesTool) parseFilesOrPaths(req mcputil.ToolRequest) (files []
 func (t *ValidateFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
 	var files []string
 	var language string
+	var workers int
 	var results []langutil.ValidationResult
+	var skipped []string
 	var summary ValidationSummary
 	var ffArgs fileutil.FindFileArgs
+	var format OutputFormat
 
 	logger.Info("Tool called", "tool", "validate_files")
 
@@ -108,17 +115,40 @@ func (t *ValidateFilesTool) Handle(_ context.Context, req mcputil.ToolRequest) (
 		goto end
 	}
 
+	workers, err = WorkersProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	if workers < 0 {
+		err = fmt.Errorf("workers must not be negative, got %d", workers)
+		goto end
+	}
+
+	format, err = getOutputFormat(req)
+	if err != nil {
+		goto end
+	}
+
 	// Validate files
 	if len(ffArgs.Paths) > 0 {
 		files, err = fileutil.FindFiles(ffArgs)
 	}
 
-	// Errors returned ValidateFilesAs by SHOULD be ignored.
+	// Errors returned by ValidateFiles SHOULD be ignored.
 	// Teh MCP Server should get errors as information, not as an error
-	results, _ = langutil.ValidateFilesAs(files, langutil.Language(language))
+	results, skipped, _ = langutil.ValidateFiles(files, langutil.ValidateFilesArgs{
+		Language: langutil.Language(language),
+		Workers:  workers,
+	})
 	summary = generateValidationSummary(results)
-	result = mcputil.NewToolResultJSON(summary)
-	logger.Info("Tool completed", "tool", "validate_files", "total_files", summary.TotalFiles, "valid_files", summary.ValidFiles, "invalid_files", summary.InvalidFiles)
+	summary.SkippedFiles = len(skipped)
+	summary.Skipped = skipped
+	if format == NDJSONOutput {
+		result = mcputil.NewToolResultNDJSON(summary.Results)
+	} else {
+		result = mcputil.NewToolResultJSON(summary)
+	}
+	logger.Info("Tool completed", "tool", "validate_files", "total_files", summary.TotalFiles, "valid_files", summary.ValidFiles, "invalid_files", summary.InvalidFiles, "skipped_files", summary.SkippedFiles)
 
 end:
 	return result, err
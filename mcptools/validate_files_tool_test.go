@@ -1,6 +1,8 @@
 package mcptools_test
 
 import (
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/fsfix"
@@ -16,8 +18,10 @@ type ValidateFilesResult struct {
 	TotalFiles   int                `json:"total_files"`
 	ValidFiles   int                `json:"valid_files"`
 	InvalidFiles int                `json:"invalid_files"`
+	SkippedFiles int                `json:"skipped_files"`
 	OverallValid bool               `json:"overall_valid"`
 	Results      []ValidationResult `json:"results"`
+	Skipped      []string           `json:"skipped"`
 }
 
 type ValidationResult struct {
@@ -207,8 +211,8 @@ func TestValidateFilesTool(t *testing.T) {
 		defer tf.Cleanup()
 
 		pf := tf.AddRepoFixture("unsupported-validate-project", nil)
-		testFile := pf.AddFileFixture("test.py", &fsfix.FileFixtureArgs{
-			Content: "print('hello')",
+		testFile := pf.AddFileFixture("test.rs", &fsfix.FileFixtureArgs{
+			Content: "fn main() {}",
 		})
 
 		tf.Setup(t)
@@ -220,7 +224,7 @@ func TestValidateFilesTool(t *testing.T) {
 			"session_token": testToken,
 			"files":         []any{testFile.Filepath},
 			//"paths":         []any{tf.TempDir()},
-			"language": "python",
+			"language": "rust",
 		})
 
 		result, err := mcputil.GetToolResult[ValidateFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should handle unsupported language gracefully")
@@ -233,4 +237,99 @@ func TestValidateFilesTool(t *testing.T) {
 			CheckValidationErrors: true,
 		})
 	})
+	t.Run("AutoLanguage_ShouldValidateEachFileByExtension", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(ValidateFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("validate-mixed-project", nil)
+		goFile := pf.AddFileFixture("valid.go", &fsfix.FileFixtureArgs{
+			Content: GoTestContent,
+		})
+		badFile := pf.AddFileFixture("invalid.go", &fsfix.FileFixtureArgs{
+			Content: "package main\n\nfunc main() { invalid",
+		})
+		pyFile := pf.AddFileFixture("script.py", &fsfix.FileFixtureArgs{
+			Content: "def main():\n    return 1\n",
+		})
+		rsFile := pf.AddFileFixture("main.rs", &fsfix.FileFixtureArgs{
+			Content: "fn main() {}",
+		})
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token": testToken,
+			"files":         []any{goFile.Filepath, badFile.Filepath, pyFile.Filepath, rsFile.Filepath},
+			"language":      "auto",
+		})
+
+		result, err := mcputil.GetToolResult[ValidateFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error validating mixed files")
+
+		requireValidateFilesResult(t, result, err, validateFilesResultOpts{
+			ExpectedTotalFiles:    3,
+			ExpectedValidFiles:    2,
+			ExpectedInvalidFiles:  1,
+			ExpectedOverallValid:  false,
+			ExpectedValidation:    true,
+			CheckValidationErrors: true,
+		})
+		assert.Equal(t, 1, result.SkippedFiles, "The Rust file should be skipped")
+		assert.Equal(t, []string{rsFile.Filepath}, result.Skipped, "Skipped should list the Rust file")
+		assert.Equal(t, "python", result.Results[2].Language, "The Python file should be validated as Python")
+	})
+	t.Run("ManyFiles_ShouldKeepResultsInInputOrder", func(t *testing.T) {
+		const fileCount = 300
+
+		tf := fsfix.NewRootFixture(ValidateFilesDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("validate-many-project", nil)
+		for i := range fileCount {
+			content := GoTestContent
+			if i%7 == 0 {
+				content = "package main\n\nfunc main() { invalid"
+			}
+			pf.AddFileFixture(fmt.Sprintf("file%03d.go", i), &fsfix.FileFixtureArgs{
+				Content: content,
+			})
+		}
+
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+
+		// List the files out of lexical order so ordering is not incidental
+		files := make([]any, 0, fileCount)
+		for i := fileCount - 1; i >= 0; i-- {
+			files = append(files, filepath.Join(pf.Dir(), fmt.Sprintf("file%03d.go", i)))
+		}
+
+		for range 3 {
+			req := mcputil.NewMockRequest(mcputil.Params{
+				"session_token": testToken,
+				"files":         files,
+				"language":      "go",
+				"workers":       8,
+			})
+
+			result, err := mcputil.GetToolResult[ValidateFilesResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error validating many files")
+			require.NoError(t, err, "Should not have error")
+			require.Len(t, result.Results, fileCount, "Should have a result per file")
+
+			invalid := 0
+			for i, fileResult := range result.Results {
+				n := fileCount - 1 - i
+				assert.Equal(t, files[i], fileResult.FilePath, "Result %d should be for the file in the same position", i)
+				assert.Equal(t, n%7 != 0, fileResult.Valid, "Validity of file%03d.go should match its content", n)
+				if !fileResult.Valid {
+					invalid++
+				}
+			}
+			assert.Equal(t, invalid, result.InvalidFiles, "Invalid files should match the invalid results")
+		}
+	})
 }
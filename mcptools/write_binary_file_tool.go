@@ -0,0 +1,169 @@
+package mcptools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+)
+
+var _ mcputil.Tool = (*WriteBinaryFileTool)(nil)
+
+func init() {
+	mcputil.RegisterTool(&WriteBinaryFileTool{
+		ToolBase: mcputil.NewToolBase(mcputil.ToolOptions{
+			Name:        "write_binary_file",
+			Description: "Write a file from a base64 payload, byte for byte, for binary assets such as images or compiled fixtures that text tools would mangle. The file is replaced atomically, and the payload may not exceed the configured max_file_size",
+			QuickHelp:   "Write exact bytes from a base64 payload",
+			Mutating:    true,
+			Properties: []mcputil.Property{
+				RequiredSessionTokenProperty,
+				RequiredPathProperty.Description("File to write"),
+				ContentBase64Property.Required(),
+				OverwriteProperty,
+				CreateDirsProperty,
+				ConfirmationTokenProperty,
+			},
+		}),
+	})
+}
+
+// WriteBinaryFileTool writes decoded base64 content to a file atomically.
+type WriteBinaryFileTool struct {
+	*mcputil.ToolBase
+}
+
+// Handle processes the write_binary_file tool request and writes the decoded bytes.
+func (t *WriteBinaryFileTool) Handle(ctx context.Context, req mcputil.ToolRequest) (result mcputil.ToolResult, err error) {
+	var path string
+	var encoded string
+	var overwrite bool
+	var createDirs bool
+	var data []byte
+	var limit int64
+	var existed bool
+	var info os.FileInfo
+	var hash string
+	var op mcputil.FileOperation
+
+	logger.Info("Tool called", "tool", "write_binary_file")
+
+	path, err = RequiredPathProperty.String(req)
+	if err != nil {
+		goto end
+	}
+
+	encoded, err = ContentBase64Property.Required().String(req)
+	if err != nil {
+		goto end
+	}
+
+	overwrite, err = OverwriteProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	createDirs, err = CreateDirsProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed",
+		"tool", "write_binary_file",
+		"path", path,
+		"overwrite", overwrite,
+		"create_dirs", createDirs,
+		"encoded_length", len(encoded))
+
+	if !t.IsAllowedPath(path) {
+		err = fmt.Errorf("access denied: path not allowed: %s", path)
+		goto end
+	}
+
+	// Reject oversized payloads before decoding them into memory
+	limit = MaxFileSize()
+	if int64(base64.StdEncoding.DecodedLen(len(encoded))) > limit+2 {
+		err = fmt.Errorf("content exceeds max file size of %d bytes", limit)
+		goto end
+	}
+
+	data, err = base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		err = fmt.Errorf("content_base64 is not valid base64: %v", err)
+		goto end
+	}
+	if int64(len(data)) > limit {
+		err = fmt.Errorf("content of %d bytes exceeds max file size of %d bytes", len(data), limit)
+		goto end
+	}
+
+	info, err = os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		err = nil
+	case err != nil:
+		err = fmt.Errorf("error checking file: %v", err)
+		goto end
+	case info.IsDir():
+		err = fmt.Errorf("cannot write directory: %s", path)
+		goto end
+	case !overwrite:
+		err = fmt.Errorf("file already exists: %s (set overwrite to replace it)", path)
+		goto end
+	default:
+		existed = true
+	}
+
+	if existed {
+		err = mcputil.ConfirmOperation(ctx, mcputil.OverwriteOperation, path)
+		if err != nil {
+			goto end
+		}
+	}
+
+	if createDirs {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			err = fmt.Errorf("failed to create directories: %v", err)
+			goto end
+		}
+	}
+
+	err = mcputil.WriteFileAtomic(ctx, t.Config(), path, string(data))
+	if err != nil {
+		err = fmt.Errorf("failed to write file: %v", err)
+		goto end
+	}
+
+	op = mcputil.CreatedFileOp
+	if existed {
+		op = mcputil.UpdatedFileOp
+	}
+	recordFileChange(ctx, req, op, path)
+
+	hash, err = hashReader(bytes.NewReader(data))
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool completed", "tool", "write_binary_file",
+		"path", path,
+		"bytes_written", len(data))
+
+	result = mcputil.NewToolResultJSON(map[string]any{
+		"success":       true,
+		"path":          path,
+		"bytes_written": len(data),
+		"sha256":        hash,
+		"overwritten":   existed,
+		"message":       fmt.Sprintf("Wrote %d bytes to %s", len(data), path),
+	})
+
+end:
+	return result, err
+}
@@ -0,0 +1,187 @@
+package mcptools_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+	"github.com/mikeschinkel/scout-mcp/mcptools"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const WriteBinaryFileDirPrefix = "write-binary-file-tool-test"
+
+// Write binary file tool result type
+type WriteBinaryFileResult struct {
+	Success      bool   `json:"success"`
+	Path         string `json:"path"`
+	BytesWritten int    `json:"bytes_written"`
+	SHA256       string `json:"sha256"`
+	Overwritten  bool   `json:"overwritten"`
+	Message      string `json:"message"`
+}
+
+type writeBinaryFileResultOpts struct {
+	ExpectError         bool
+	ExpectedErrorMsg    string
+	ExpectedContent     []byte
+	ExpectedOverwritten bool
+}
+
+func requireWriteBinaryFileResult(t *testing.T, result *WriteBinaryFileResult, err error, opts writeBinaryFileResultOpts) {
+	t.Helper()
+
+	if opts.ExpectError {
+		require.Error(t, err, "Should have error")
+		if opts.ExpectedErrorMsg != "" {
+			assert.Contains(t, err.Error(), opts.ExpectedErrorMsg, "Error should contain expected message")
+		}
+		return
+	}
+
+	require.NoError(t, err, "Should not have error")
+	require.NotNil(t, result, "Result should not be nil")
+
+	sum := sha256.Sum256(opts.ExpectedContent)
+	assert.True(t, result.Success, "Should succeed")
+	assert.Equal(t, len(opts.ExpectedContent), result.BytesWritten, "Byte count should match")
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.SHA256, "Hash should match the content")
+	assert.Equal(t, opts.ExpectedOverwritten, result.Overwritten, "Overwritten should match")
+
+	content, err := os.ReadFile(result.Path)
+	require.NoError(t, err, "Should read the written file")
+	assert.Equal(t, opts.ExpectedContent, content, "File should hold the exact bytes")
+}
+
+func TestWriteBinaryFileTool(t *testing.T) {
+	// Get the tool
+	tool := mcputil.GetRegisteredTool("write_binary_file")
+	require.NotNil(t, tool, "write_binary_file tool should be registered")
+
+	// Bytes a text round trip would mangle: NUL, invalid UTF-8 and CRLF
+	payload := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff, 0xfe}
+
+	setup := func(t *testing.T, tf *fsfix.RootFixture) {
+		tf.Setup(t)
+		tool.SetConfig(mcputil.NewMockConfig(mcputil.MockConfigArgs{
+			AllowedPaths: []string{tf.TempDir()},
+		}))
+	}
+
+	t.Run("NewFile_ShouldWriteExactBytes", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(WriteBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           filepath.Join(tf.TempDir(), "assets", "logo.png"),
+			"content_base64": base64.StdEncoding.EncodeToString(payload),
+			"create_dirs":    true,
+		})
+
+		result, err := mcputil.GetToolResult[WriteBinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error writing binary file")
+		requireWriteBinaryFileResult(t, result, err, writeBinaryFileResultOpts{
+			ExpectedContent: payload,
+		})
+	})
+
+	t.Run("ExistingFileWithoutOverwrite_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(WriteBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("binary-project", nil)
+		ff := pf.AddFileFixture("data.bin", &fsfix.FileFixtureArgs{Content: "old"})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           ff.Filepath,
+			"content_base64": base64.StdEncoding.EncodeToString(payload),
+		})
+
+		result, err := mcputil.GetToolResult[WriteBinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for an existing file")
+		requireWriteBinaryFileResult(t, result, err, writeBinaryFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "file already exists",
+		})
+
+		content, err := os.ReadFile(ff.Filepath)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content), "Existing file should be untouched")
+	})
+
+	t.Run("ExistingFileWithOverwrite_ShouldReplace", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(WriteBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		pf := tf.AddRepoFixture("binary-project", nil)
+		ff := pf.AddFileFixture("data.bin", &fsfix.FileFixtureArgs{Content: "old"})
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           ff.Filepath,
+			"content_base64": base64.StdEncoding.EncodeToString(payload),
+			"overwrite":      true,
+		})
+
+		result, err := mcputil.GetToolResult[WriteBinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should not error overwriting binary file")
+		requireWriteBinaryFileResult(t, result, err, writeBinaryFileResultOpts{
+			ExpectedContent:     payload,
+			ExpectedOverwritten: true,
+		})
+	})
+
+	t.Run("InvalidBase64_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(WriteBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           filepath.Join(tf.TempDir(), "bad.bin"),
+			"content_base64": "not base64!",
+		})
+
+		result, err := mcputil.GetToolResult[WriteBinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for invalid base64")
+		requireWriteBinaryFileResult(t, result, err, writeBinaryFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "not valid base64",
+		})
+	})
+
+	t.Run("OversizedPayload_ShouldError", func(t *testing.T) {
+		tf := fsfix.NewRootFixture(WriteBinaryFileDirPrefix)
+		defer tf.Cleanup()
+
+		setup(t, tf)
+
+		require.NoError(t, mcptools.SetMaxFileSize(int64(len(payload)-1)))
+		defer func() {
+			require.NoError(t, mcptools.SetMaxFileSize(0))
+		}()
+
+		fp := filepath.Join(tf.TempDir(), "big.bin")
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"session_token":  testToken,
+			"path":           fp,
+			"content_base64": base64.StdEncoding.EncodeToString(payload),
+		})
+
+		result, err := mcputil.GetToolResult[WriteBinaryFileResult](mcputil.CallResult(mcputil.CallTool(tool, req)), "Should error for an oversized payload")
+		requireWriteBinaryFileResult(t, result, err, writeBinaryFileResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "exceeds max file size",
+		})
+		assert.NoFileExists(t, fp, "Oversized payload should not be written")
+	})
+}
@@ -0,0 +1,134 @@
+package mcputil
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileOperation identifies how a file was changed during a session.
+type FileOperation string
+
+const (
+	CreatedFileOp FileOperation = "created" // File did not exist before the session changed it
+	UpdatedFileOp FileOperation = "updated" // Existing file content was modified
+	DeletedFileOp FileOperation = "deleted" // File or directory was removed
+)
+
+// ChangedFiles groups the paths a session has touched by operation.
+// Each path appears in at most one group, reflecting its net change.
+type ChangedFiles struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+}
+
+// Count returns the total number of changed paths across all groups.
+func (cf ChangedFiles) Count() int {
+	return len(cf.Created) + len(cf.Updated) + len(cf.Deleted)
+}
+
+// Package-level changed files storage, keyed by session token
+var (
+	changedFiles      = make(map[string]map[string]FileOperation)
+	changedFilesMutex sync.Mutex
+)
+
+// RecordFileChange records that the session identified by token changed the
+// given paths. Repeated changes to the same path are collapsed into a single
+// net operation so that, for example, a file created and then updated is
+// still reported as created, and a file created and then deleted is dropped.
+func RecordFileChange(token string, op FileOperation, paths ...string) {
+	var files map[string]FileOperation
+	var prior FileOperation
+	var ok bool
+
+	if token == "" {
+		goto end
+	}
+
+	changedFilesMutex.Lock()
+	defer changedFilesMutex.Unlock()
+
+	files, ok = changedFiles[token]
+	if !ok {
+		files = make(map[string]FileOperation)
+		changedFiles[token] = files
+	}
+
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		prior, ok = files[path]
+		switch {
+		case !ok:
+			files[path] = op
+		case prior == CreatedFileOp && op == DeletedFileOp:
+			delete(files, path)
+		case prior == CreatedFileOp:
+			// Still a new file from the client's point of view
+		case prior == DeletedFileOp && op == CreatedFileOp:
+			files[path] = UpdatedFileOp
+		default:
+			files[path] = op
+		}
+	}
+
+end:
+	return
+}
+
+// GetChangedFiles returns the paths changed by the session identified by
+// token, grouped by operation and sorted within each group.
+func GetChangedFiles(token string) (cf ChangedFiles) {
+	cf = ChangedFiles{
+		Created: []string{},
+		Updated: []string{},
+		Deleted: []string{},
+	}
+
+	changedFilesMutex.Lock()
+	for path, op := range changedFiles[token] {
+		switch op {
+		case CreatedFileOp:
+			cf.Created = append(cf.Created, path)
+		case UpdatedFileOp:
+			cf.Updated = append(cf.Updated, path)
+		case DeletedFileOp:
+			cf.Deleted = append(cf.Deleted, path)
+		}
+	}
+	changedFilesMutex.Unlock()
+
+	sort.Strings(cf.Created)
+	sort.Strings(cf.Updated)
+	sort.Strings(cf.Deleted)
+
+	return cf
+}
+
+// ClearChangedFiles discards the changed files tracked for the session
+// identified by token. It is called whenever a session ends.
+func ClearChangedFiles(token string) {
+	changedFilesMutex.Lock()
+	delete(changedFiles, token)
+	changedFilesMutex.Unlock()
+}
+
+// rekeyChangedFiles moves the changed files tracked for the session identified
+// by oldToken to newToken when the session's token is rotated.
+func rekeyChangedFiles(oldToken, newToken string) {
+	changedFilesMutex.Lock()
+	files, ok := changedFiles[oldToken]
+	if ok {
+		changedFiles[newToken] = files
+		delete(changedFiles, oldToken)
+	}
+	changedFilesMutex.Unlock()
+}
+
+// clearAllChangedFiles discards the changed files tracked for every session.
+func clearAllChangedFiles() {
+	changedFilesMutex.Lock()
+	changedFiles = make(map[string]map[string]FileOperation)
+	changedFilesMutex.Unlock()
+}
@@ -0,0 +1,19 @@
+package mcputil
+
+// ConfigSource identifies where the effective value of a config setting came from.
+type ConfigSource string
+
+const (
+	DefaultConfigSource     ConfigSource = "default"      // Built-in default; the setting was not given
+	FileConfigSource        ConfigSource = "file"         // Set in the config file
+	CommandLineConfigSource ConfigSource = "command_line" // Given as a command-line argument
+)
+
+// SourcedConfig is implemented by configs that can report where each of
+// their settings came from. Keys are the setting names used in the config
+// file, such as "safe_mode".
+type SourcedConfig interface {
+	Config
+	Source(key string) ConfigSource
+	PathSource(path string) ConfigSource
+}
@@ -0,0 +1,310 @@
+package mcputil
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfirmableOperation names a destructive operation that safe mode can
+// require a confirmation token for.
+type ConfirmableOperation string
+
+const (
+	// DeleteOperation removes a single file.
+	DeleteOperation ConfirmableOperation = "delete"
+
+	// RecursiveDeleteOperation removes a directory and everything in it.
+	RecursiveDeleteOperation ConfirmableOperation = "recursive_delete"
+
+	// OverwriteOperation replaces the entire content of an existing file.
+	OverwriteOperation ConfirmableOperation = "overwrite"
+)
+
+// ConfirmableOperations lists every operation safe mode can require confirmation for.
+var ConfirmableOperations = []ConfirmableOperation{
+	DeleteOperation,
+	RecursiveDeleteOperation,
+	OverwriteOperation,
+}
+
+// ConfirmationTTL is how long a confirmation token remains usable.
+const ConfirmationTTL = 5 * time.Minute
+
+// ErrConfirmationRequired is returned when safe mode requires a confirmation
+// token for an operation and none, or a non-matching one, was provided.
+var ErrConfirmationRequired = errors.New("confirmation required")
+
+// Validate checks if the ConfirmableOperation has a valid value.
+func (op ConfirmableOperation) Validate() (err error) {
+	if slices.Contains(ConfirmableOperations, op) {
+		goto end
+	}
+	err = fmt.Errorf("confirmable operation must be '%s', '%s' or '%s', got '%s'",
+		DeleteOperation,
+		RecursiveDeleteOperation,
+		OverwriteOperation,
+		op,
+	)
+end:
+	return err
+}
+
+// Describe returns a human-readable description of performing op on paths.
+func (op ConfirmableOperation) Describe(paths []string) (desc string) {
+	list := strings.Join(paths, ", ")
+	switch op {
+	case DeleteOperation:
+		desc = fmt.Sprintf("Delete %s", list)
+	case RecursiveDeleteOperation:
+		desc = fmt.Sprintf("Recursively delete %s and everything in it", list)
+	case OverwriteOperation:
+		desc = fmt.Sprintf("Overwrite the entire content of %s", list)
+	default:
+		desc = fmt.Sprintf("%s %s", op, list)
+	}
+	return desc
+}
+
+// Confirmation authorizes a single destructive operation on an exact set of
+// paths for the session that requested it.
+type Confirmation struct {
+	Token        string               `json:"confirmation_token"`
+	Operation    ConfirmableOperation `json:"operation"`
+	Paths        []string             `json:"paths"`
+	Description  string               `json:"description"`
+	ExpiresAt    time.Time            `json:"expires_at"`
+	sessionToken string               // Session the confirmation was issued to
+}
+
+// Package-level confirmation storage, keyed by confirmation token
+var (
+	confirmations      = make(map[string]Confirmation)
+	safeModeOperations = make(map[ConfirmableOperation]bool)
+	confirmationsMutex sync.Mutex
+)
+
+// SetSafeMode enables or disables safe mode. When enabled, the operations in
+// ops, or every ConfirmableOperation if ops is empty, require a confirmation
+// token from RequestConfirmation.
+func SetSafeMode(enabled bool, ops []ConfirmableOperation) (err error) {
+	var required map[ConfirmableOperation]bool
+
+	required = make(map[ConfirmableOperation]bool)
+	if !enabled {
+		goto end
+	}
+	if len(ops) == 0 {
+		ops = ConfirmableOperations
+	}
+	for _, op := range ops {
+		err = op.Validate()
+		if err != nil {
+			goto end
+		}
+		required[op] = true
+	}
+
+end:
+	if err == nil {
+		confirmationsMutex.Lock()
+		safeModeOperations = required
+		confirmationsMutex.Unlock()
+	}
+	return err
+}
+
+// SafeModeOperations returns the operations that currently require a
+// confirmation token, in the order of ConfirmableOperations. It is empty when
+// safe mode is off.
+func SafeModeOperations() (ops []ConfirmableOperation) {
+	confirmationsMutex.Lock()
+	defer confirmationsMutex.Unlock()
+	ops = make([]ConfirmableOperation, 0, len(safeModeOperations))
+	for _, op := range ConfirmableOperations {
+		if safeModeOperations[op] {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// RequiresConfirmation reports whether safe mode requires a confirmation token for op.
+func RequiresConfirmation(op ConfirmableOperation) bool {
+	confirmationsMutex.Lock()
+	defer confirmationsMutex.Unlock()
+	return safeModeOperations[op]
+}
+
+// RequestConfirmation issues a single-use confirmation token allowing the
+// session identified by sessionToken to perform op on exactly paths.
+func RequestConfirmation(sessionToken string, op ConfirmableOperation, paths []string) (c Confirmation, err error) {
+	var tokenBytes []byte
+
+	if sessionToken == "" {
+		err = ErrTokenNotFound
+		goto end
+	}
+	err = op.Validate()
+	if err != nil {
+		goto end
+	}
+	if len(paths) == 0 {
+		err = fmt.Errorf("at least one path is required to request confirmation")
+		goto end
+	}
+
+	tokenBytes = make([]byte, 16)
+	_, err = rand.Read(tokenBytes)
+	if err != nil {
+		goto end
+	}
+
+	c = Confirmation{
+		Token:        hex.EncodeToString(tokenBytes),
+		Operation:    op,
+		Paths:        slices.Clone(paths),
+		Description:  op.Describe(paths),
+		ExpiresAt:    time.Now().Add(ConfirmationTTL),
+		sessionToken: sessionToken,
+	}
+
+	confirmationsMutex.Lock()
+	removeExpiredConfirmations(time.Now())
+	confirmations[c.Token] = c
+	confirmationsMutex.Unlock()
+
+end:
+	return c, err
+}
+
+// ConfirmOperation must be called by tools before performing op on paths.
+// When safe mode requires confirmation for op it consumes the confirmation
+// token passed with the request, failing with ErrConfirmationRequired unless
+// the token was issued to the calling session for exactly op and paths and
+// has not expired. Previews never require confirmation.
+func ConfirmOperation(ctx context.Context, op ConfirmableOperation, paths ...string) (err error) {
+	var token string
+	var c Confirmation
+	var ok bool
+
+	if IsPreview(ctx) || !RequiresConfirmation(op) {
+		goto end
+	}
+
+	token, _ = ctx.Value(confirmationTokenContextKey{}).(string)
+	if token == "" {
+		err = fmt.Errorf("%w: %s requires a confirmation_token in safe mode; call request_confirmation with operation '%s' and paths %q, then retry with the returned confirmation_token",
+			ErrConfirmationRequired,
+			op.Describe(paths),
+			op,
+			paths,
+		)
+		goto end
+	}
+
+	confirmationsMutex.Lock()
+	defer confirmationsMutex.Unlock()
+
+	removeExpiredConfirmations(time.Now())
+	c, ok = confirmations[token]
+	if !ok || c.sessionToken != sessionTokenFromContext(ctx) {
+		err = fmt.Errorf("%w: confirmation_token is invalid, expired, or already used", ErrConfirmationRequired)
+		goto end
+	}
+	if c.Operation != op || !samePaths(c.Paths, paths) {
+		err = fmt.Errorf("%w: confirmation_token was issued to %s, not to %s",
+			ErrConfirmationRequired,
+			lowerFirst(c.Description),
+			lowerFirst(op.Describe(paths)),
+		)
+		goto end
+	}
+
+	// Confirmations are single use
+	delete(confirmations, token)
+
+end:
+	return err
+}
+
+// ClearConfirmations discards the confirmations issued to the session
+// identified by token. It is called whenever a session ends.
+func ClearConfirmations(token string) {
+	confirmationsMutex.Lock()
+	for key, c := range confirmations {
+		if c.sessionToken == token {
+			delete(confirmations, key)
+		}
+	}
+	confirmationsMutex.Unlock()
+}
+
+// rekeyConfirmations transfers the confirmations issued to the session
+// identified by oldToken to newToken when the session's token is rotated.
+func rekeyConfirmations(oldToken, newToken string) {
+	confirmationsMutex.Lock()
+	for key, c := range confirmations {
+		if c.sessionToken == oldToken {
+			c.sessionToken = newToken
+			confirmations[key] = c
+		}
+	}
+	confirmationsMutex.Unlock()
+}
+
+// clearAllConfirmations discards the confirmations issued to every session.
+func clearAllConfirmations() {
+	confirmationsMutex.Lock()
+	confirmations = make(map[string]Confirmation)
+	confirmationsMutex.Unlock()
+}
+
+// removeExpiredConfirmations drops expired confirmations. Callers must hold confirmationsMutex.
+func removeExpiredConfirmations(now time.Time) {
+	for key, c := range confirmations {
+		if now.After(c.ExpiresAt) {
+			delete(confirmations, key)
+		}
+	}
+}
+
+// samePaths reports whether a and b name the same set of paths.
+func samePaths(a, b []string) bool {
+	var as, bs []string
+
+	as = make([]string, len(a))
+	for i, p := range a {
+		as[i] = lockPath(p)
+	}
+	bs = make([]string, len(b))
+	for i, p := range b {
+		bs[i] = lockPath(p)
+	}
+	slices.Sort(as)
+	slices.Sort(bs)
+	return slices.Equal(slices.Compact(as), slices.Compact(bs))
+}
+
+// lowerFirst lowercases the first letter of s.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// confirmationTokenContextKey is the context key under which the request's confirmation token is stored.
+type confirmationTokenContextKey struct{}
+
+// withConfirmationToken returns a copy of ctx carrying the confirmation token sent with req, if any.
+func withConfirmationToken(ctx context.Context, req ToolRequest) context.Context {
+	token, _ := ConfirmationTokenProperty.String(req)
+	return context.WithValue(ctx, confirmationTokenContextKey{}, token)
+}
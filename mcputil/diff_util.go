@@ -0,0 +1,178 @@
+package mcputil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the size of the LCS table used by UnifiedDiff so that
+// diffing two very large files cannot exhaust memory.
+const maxDiffCells = 4_000_000
+
+// DiffContextLines is the number of unchanged lines UnifiedDiff shows around
+// each change.
+const DiffContextLines = 3
+
+// diffOp is a single line-level edit produced by diffLines.
+type diffOp struct {
+	Kind byte   // ' ' for unchanged, '-' for removed, '+' for added
+	Line string // Line content without its trailing newline
+}
+
+// UnifiedDiff returns a unified diff between before and after, labelled with
+// nameA and nameB. It returns an empty string when the contents are equal and
+// an error when the inputs are too large to diff within maxDiffCells.
+func UnifiedDiff(nameA, nameB, before, after string) (diff string, err error) {
+	return UnifiedDiffContext(nameA, nameB, before, after, DiffContextLines)
+}
+
+// UnifiedDiffContext is UnifiedDiff with contextLines unchanged lines shown
+// around each change instead of DiffContextLines.
+func UnifiedDiffContext(nameA, nameB, before, after string, contextLines int) (diff string, err error) {
+	var a, b []string
+	var ops []diffOp
+	var sb strings.Builder
+
+	if before == after {
+		goto end
+	}
+
+	a = splitDiffLines(before)
+	b = splitDiffLines(after)
+	if len(a)*len(b) > maxDiffCells {
+		err = fmt.Errorf("files too large to diff (%d x %d lines)", len(a), len(b))
+		goto end
+	}
+
+	ops = diffLines(a, b)
+
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", nameA, nameB))
+	writeDiffHunks(&sb, ops, max(0, contextLines))
+	diff = sb.String()
+
+end:
+	return diff, err
+}
+
+// splitDiffLines splits s into lines without their trailing newlines.
+func splitDiffLines(s string) (lines []string) {
+	if s == "" {
+		goto end
+	}
+	lines = strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+end:
+	return lines
+}
+
+// diffLines computes a minimal line edit script from a to b using a
+// longest-common-subsequence table.
+func diffLines(a, b []string) (ops []diffOp) {
+	var lcs [][]int
+	var i, j int
+
+	lcs = make([][]int, len(a)+1)
+	for i = range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i = len(a) - 1; i >= 0; i-- {
+		for j = len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j = 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{Kind: ' ', Line: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{Kind: '-', Line: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{Kind: '+', Line: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{Kind: '-', Line: a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{Kind: '+', Line: b[j]})
+	}
+	return ops
+}
+
+// writeDiffHunks groups ops into hunks with contextLines of surrounding
+// context and writes them to sb in unified diff format.
+func writeDiffHunks(sb *strings.Builder, ops []diffOp, contextLines int) {
+	var start, end, last, k int
+	var lineA, lineB []int
+
+	// Precompute the 1-based line numbers in a and b at each op index
+	lineA = make([]int, len(ops)+1)
+	lineB = make([]int, len(ops)+1)
+	lineA[0], lineB[0] = 1, 1
+	for i, op := range ops {
+		lineA[i+1], lineB[i+1] = lineA[i], lineB[i]
+		if op.Kind != '+' {
+			lineA[i+1]++
+		}
+		if op.Kind != '-' {
+			lineB[i+1]++
+		}
+	}
+
+	k = 0
+	for k < len(ops) {
+		if ops[k].Kind == ' ' {
+			k++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough that the
+		// context windows of the two changes would touch
+		start = max(0, k-contextLines)
+		last = k
+		for j := k; j < len(ops); j++ {
+			if ops[j].Kind != ' ' {
+				last = j
+				continue
+			}
+			if j-last > 2*contextLines {
+				break
+			}
+		}
+		end = min(len(ops), last+1+contextLines)
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(lineA[start], lineA[end]-lineA[start]),
+			hunkRange(lineB[start], lineB[end]-lineB[start]),
+		))
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.Kind)
+			sb.WriteString(op.Line)
+			sb.WriteByte('\n')
+		}
+		k = end
+	}
+}
+
+// hunkRange formats a unified diff range, using the conventions that a
+// single line omits its count and an empty range refers to the prior line.
+func hunkRange(start, count int) (s string) {
+	switch count {
+	case 0:
+		s = fmt.Sprintf("%d,0", start-1)
+	case 1:
+		s = fmt.Sprintf("%d", start)
+	default:
+		s = fmt.Sprintf("%d,%d", start, count)
+	}
+	return s
+}
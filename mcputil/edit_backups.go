@@ -0,0 +1,383 @@
+package mcputil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+)
+
+// DefaultEditBackupCount is how many backups of each file a session keeps
+// when no count is configured.
+const DefaultEditBackupCount = 10
+
+// editBackupsDir is the directory of the backup store holding edit backups,
+// with a subdirectory per session and, within it, one per file.
+const editBackupsDir = "edit-backups"
+
+// ErrNoEditBackup is returned by UndoEdit when the session has no backup of
+// the file left to restore.
+var ErrNoEditBackup = errors.New("no edit to undo")
+
+// EditBackup is the content a file had before a tool changed it, kept so that
+// the change can be undone.
+type EditBackup struct {
+	Path    string      `json:"path"`           // File that was changed
+	Existed bool        `json:"existed"`        // Whether the file existed before the change
+	Content []byte      `json:"content"`        // Content of the file before the change
+	Mode    fs.FileMode `json:"mode,omitempty"` // Permissions of the file before the change
+	SavedAt time.Time   `json:"saved_at"`       // When the backup was taken
+}
+
+// Package-level edit backup settings
+var (
+	editBackupStore  *scoutcfg.FileStore
+	editBackupCount  = DefaultEditBackupCount
+	editBackupsMutex sync.Mutex
+)
+
+// SetEditBackups sets the store in which WriteFile, WriteFileAtomic and
+// RemoveFile keep the prior content of the files a session changes, and how
+// many backups of each file a session keeps. A nil store disables backups,
+// and a count of zero selects DefaultEditBackupCount.
+func SetEditBackups(store *scoutcfg.FileStore, count int) (err error) {
+	if count < 0 {
+		err = fmt.Errorf("edit_backup_count must not be negative, got %d", count)
+		goto end
+	}
+	if count == 0 {
+		count = DefaultEditBackupCount
+	}
+
+	editBackupsMutex.Lock()
+	editBackupStore = store
+	editBackupCount = count
+	editBackupsMutex.Unlock()
+
+end:
+	return err
+}
+
+// EditBackupCount returns how many backups of each file a session keeps.
+func EditBackupCount() int {
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+	return editBackupCount
+}
+
+// editBackupDir returns the store directory holding the backups of filePath
+// taken for the session identified by token.
+func editBackupDir(token, filePath string) string {
+	sum := sha256.Sum256([]byte(filePath))
+	return path.Join(editBackupsDir, SessionID(token), hex.EncodeToString(sum[:8]))
+}
+
+// backupFile saves the current content of filePath, or that it does not yet
+// exist, as the newest backup of the file for the session making the change
+// with ctx, discarding the oldest backups beyond EditBackupCount. Nothing is
+// saved when backups are disabled, ctx carries no session, or filePath is a
+// directory. Backups hold a file's full content, so they are saved with
+// permissions 0600 in directories created with 0700.
+func backupFile(ctx context.Context, filePath string) (err error) {
+	_, err = backupFileBeforeWrite(ctx, filePath, nil)
+	return err
+}
+
+// backupFileBeforeWrite is backupFile for a change that writes content to
+// filePath, saving nothing when content is not nil and matches the file's
+// current content so that no-op edits do not fill the undo history. It
+// returns the backup saved, or nil if none was.
+func backupFileBeforeWrite(ctx context.Context, filePath string, content *string) (saved *EditBackup, err error) {
+	var token, dir string
+	var names []string
+	var info os.FileInfo
+	var backup EditBackup
+	var seq int
+
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+
+	if editBackupStore == nil || ctx == nil || ctx.Value(noEditBackupContextKey{}) != nil {
+		goto end
+	}
+	token = sessionTokenFromContext(ctx)
+	if token == "" {
+		goto end
+	}
+
+	backup.Path, err = filepath.Abs(filePath)
+	if err != nil {
+		goto end
+	}
+	backup.SavedAt = time.Now()
+
+	info, err = os.Stat(backup.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		err = nil
+	case err != nil:
+		goto end
+	case info.IsDir():
+		goto end
+	default:
+		backup.Existed = true
+		backup.Mode = info.Mode().Perm()
+		backup.Content, err = os.ReadFile(backup.Path)
+		if err != nil {
+			goto end
+		}
+		if content != nil && string(backup.Content) == *content {
+			goto end
+		}
+	}
+
+	dir = editBackupDir(token, backup.Path)
+	names, err = editBackupStore.List(dir)
+	if err != nil {
+		goto end
+	}
+	if len(names) > 0 {
+		seq = editBackupSeq(names[len(names)-1]) + 1
+	}
+
+	err = editBackupStore.SaveWithPerm(path.Join(dir, fmt.Sprintf("%08d.json", seq)), &backup, 0600)
+	if err != nil {
+		goto end
+	}
+
+	saved = &backup
+
+	// Keep the newest editBackupCount backups, the one just saved included
+	for len(names) >= editBackupCount {
+		err = editBackupStore.Delete(path.Join(dir, names[0]))
+		if err != nil {
+			goto end
+		}
+		names = names[1:]
+	}
+
+end:
+	if err != nil {
+		err = fmt.Errorf("failed to back up %s: %w", filePath, err)
+	}
+	return saved, err
+}
+
+// discardUnusedEditBackup discards backup, the newest backup of filePath for
+// the session making the change with ctx, if the file is still as backed up,
+// such as after the write it was taken for failed without changing the file.
+func discardUnusedEditBackup(ctx context.Context, filePath string, backup *EditBackup) (err error) {
+	var content []byte
+
+	content, err = os.ReadFile(backup.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && !backup.Existed:
+	case err != nil:
+		goto end
+	case !backup.Existed || string(content) != string(backup.Content):
+		goto end
+	}
+	err = discardNewestEditBackup(ctx, filePath)
+
+end:
+	return err
+}
+
+// noEditBackupContextKey is the context key marking changes that are not
+// backed up, such as those made by RestoreFile.
+type noEditBackupContextKey struct{}
+
+// discardNewestEditBackup discards the newest backup the session making the
+// change with ctx has of filePath, if backups are enabled and there is one.
+func discardNewestEditBackup(ctx context.Context, filePath string) (err error) {
+	var token, absPath, dir string
+	var names []string
+
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+
+	if editBackupStore == nil {
+		goto end
+	}
+	token = sessionTokenFromContext(ctx)
+	if token == "" {
+		goto end
+	}
+
+	absPath, err = filepath.Abs(filePath)
+	if err != nil {
+		goto end
+	}
+
+	dir = editBackupDir(token, absPath)
+	names, err = editBackupStore.List(dir)
+	if err != nil || len(names) == 0 {
+		goto end
+	}
+	err = editBackupStore.Delete(path.Join(dir, names[len(names)-1]))
+
+end:
+	return err
+}
+
+// editBackupSeq returns the sequence number of the backup file name.
+func editBackupSeq(name string) int {
+	seq, _ := strconv.Atoi(strings.TrimSuffix(name, ".json"))
+	return seq
+}
+
+// UndoEdit restores filePath from the newest backup the session identified by
+// token has of it, removing the file if it did not exist before the change,
+// and then discards that backup so that a further undo restores the one before
+// it. It returns the backup restored and how many remain, and fails with
+// ErrNoEditBackup when none remain. The restore is itself not backed up.
+func UndoEdit(ctx context.Context, c Config, token, filePath string) (backup EditBackup, remaining int, err error) {
+	var absPath, dir, name string
+	var names []string
+
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+
+	if !c.IsAllowedPath(filePath) {
+		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
+		goto end
+	}
+
+	if editBackupStore == nil {
+		err = fmt.Errorf("%w for %s: edit backups are disabled", ErrNoEditBackup, filePath)
+		goto end
+	}
+
+	absPath, err = filepath.Abs(filePath)
+	if err != nil {
+		goto end
+	}
+
+	dir = editBackupDir(token, absPath)
+	names, err = editBackupStore.List(dir)
+	if err != nil {
+		goto end
+	}
+	if len(names) == 0 {
+		err = fmt.Errorf("%w for %s in this session", ErrNoEditBackup, filePath)
+		goto end
+	}
+	name = path.Join(dir, names[len(names)-1])
+
+	err = editBackupStore.Load(name, &backup)
+	if err != nil {
+		goto end
+	}
+
+	err = checkFileLock(ctx, absPath)
+	if err != nil {
+		goto end
+	}
+
+	if backup.Existed {
+		err = restoreEditBackup(absPath, backup)
+	} else {
+		err = os.Remove(absPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		goto end
+	}
+
+	err = editBackupStore.Delete(name)
+	if err != nil {
+		goto end
+	}
+	remaining = len(names) - 1
+
+end:
+	return backup, remaining, err
+}
+
+// restoreEditBackup writes the content of backup to absPath with the
+// permissions the file had when backed up, or 0644 for backups saved before
+// permissions were recorded.
+func restoreEditBackup(absPath string, backup EditBackup) (err error) {
+	var mode fs.FileMode
+
+	mode = backup.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+
+	err = os.WriteFile(absPath, backup.Content, mode)
+	if err != nil {
+		goto end
+	}
+
+	// WriteFile only applies mode when it creates the file
+	err = os.Chmod(absPath, mode)
+
+end:
+	return err
+}
+
+// ClearEditBackups discards the edit backups kept for the session identified
+// by token. It is called whenever a session ends.
+func ClearEditBackups(token string) {
+	clearEditBackups(path.Join(editBackupsDir, SessionID(token)))
+}
+
+// rekeyEditBackups moves the edit backups kept for the session identified by
+// oldToken to newToken when the session's token is rotated, so that undo_edit
+// keeps working with the new token.
+func rekeyEditBackups(oldToken, newToken string) (err error) {
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+
+	if editBackupStore == nil {
+		goto end
+	}
+	err = editBackupStore.Rename(
+		path.Join(editBackupsDir, SessionID(oldToken)),
+		path.Join(editBackupsDir, SessionID(newToken)),
+	)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	}
+
+end:
+	return err
+}
+
+// clearAllEditBackups discards the edit backups kept for every session.
+func clearAllEditBackups() {
+	clearEditBackups(editBackupsDir)
+}
+
+// clearEditBackups removes dir from the backup store, if backups are enabled
+// and it exists.
+func clearEditBackups(dir string) {
+	var err error
+
+	editBackupsMutex.Lock()
+	defer editBackupsMutex.Unlock()
+
+	if editBackupStore == nil {
+		goto end
+	}
+	err = editBackupStore.DeleteAll(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("Failed to clear edit backups", "dir", dir, "error", err)
+	}
+
+end:
+}
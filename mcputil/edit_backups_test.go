@@ -0,0 +1,90 @@
+package mcputil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile_ShouldBackUpPrivatelyAndSkipNoOpEdits(t *testing.T) {
+	backupDir := t.TempDir()
+	store := scoutcfg.NewFileStore("scout-mcp-test")
+	store.SetBaseDir(backupDir)
+	require.NoError(t, SetEditBackups(store, 0), "Should enable edit backups")
+	defer func() {
+		_ = SetEditBackups(nil, 0)
+	}()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(filePath, []byte("SECRET=1\n"), 0600), "Should write file")
+
+	token := "backup-test-token"
+	ctx, _ := withFileLockContext(context.Background(), token)
+	config := NewMockConfig(MockConfigArgs{AllowedPaths: []string{dir}})
+	backups := editBackupDir(token, filePath)
+	defer ClearEditBackups(token)
+
+	require.NoError(t, WriteFile(ctx, config, filePath, "SECRET=1\n"), "Should write unchanged content")
+	names, err := store.List(backups)
+	require.NoError(t, err, "Should list backups")
+	assert.Empty(t, names, "An edit that changes nothing should not be backed up")
+
+	require.NoError(t, WriteFile(ctx, config, filePath, "SECRET=2\n"), "Should write new content")
+	names, err = store.List(backups)
+	require.NoError(t, err, "Should list backups")
+	require.Len(t, names, 1, "A change should be backed up")
+
+	info, err := os.Stat(filepath.Join(backupDir, filepath.FromSlash(backups), names[0]))
+	require.NoError(t, err, "Should stat backup")
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Backup should only be readable by its owner")
+
+	info, err = os.Stat(filepath.Join(backupDir, editBackupsDir))
+	require.NoError(t, err, "Should stat backup directory")
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm(), "Backup directory should only be accessible by its owner")
+}
+
+func TestRestoreFile_ShouldDiscardBackupOfRolledBackWrite(t *testing.T) {
+	backupDir := t.TempDir()
+	store := scoutcfg.NewFileStore("scout-mcp-test")
+	store.SetBaseDir(backupDir)
+	require.NoError(t, SetEditBackups(store, 0), "Should enable edit backups")
+	defer func() {
+		_ = SetEditBackups(nil, 0)
+	}()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	created := filepath.Join(dir, "created.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("before\n"), 0600), "Should write file")
+
+	token := "restore-test-token"
+	ctx, _ := withFileLockContext(context.Background(), token)
+	config := NewMockConfig(MockConfigArgs{AllowedPaths: []string{dir}})
+	defer ClearEditBackups(token)
+
+	require.NoError(t, WriteFile(ctx, config, existing, "after\n"), "Should write existing file")
+	require.NoError(t, WriteFile(ctx, config, created, "new\n"), "Should write new file")
+
+	require.NoError(t, RestoreFile(ctx, config, existing, true, "before\n"), "Should restore existing file")
+	require.NoError(t, RestoreFile(ctx, config, created, false, ""), "Should remove created file")
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err, "Should read restored file")
+	assert.Equal(t, "before\n", string(content), "Existing file should be restored")
+	info, err := os.Stat(existing)
+	require.NoError(t, err, "Should stat restored file")
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Restored file should keep its permissions")
+	assert.NoFileExists(t, created, "Created file should be removed")
+
+	for _, filePath := range []string{existing, created} {
+		names, err := store.List(editBackupDir(token, filePath))
+		require.NoError(t, err, "Should list backups")
+		assert.Empty(t, names, "Backups of a rolled back write should be discarded")
+	}
+}
@@ -0,0 +1,386 @@
+package mcputil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileLockMode controls how file writes react to a path locked by another session.
+type FileLockMode string
+
+const (
+	// WarnOnLockedFile lets the write proceed but reports a warning in the tool result.
+	WarnOnLockedFile FileLockMode = "warn"
+
+	// RefuseLockedFile fails the write with ErrFileLocked.
+	RefuseLockedFile FileLockMode = "refuse"
+)
+
+// DefaultFileLockTTL is how long a lock is held when no TTL is requested.
+const DefaultFileLockTTL = 30 * time.Minute
+
+// ErrFileLocked is returned when a path is locked by another session and
+// the file lock mode is RefuseLockedFile, or when locking such a path.
+var ErrFileLocked = errors.New("file is locked by another session")
+
+// Validate checks if the FileLockMode has a valid value.
+func (m FileLockMode) Validate() (err error) {
+	switch m {
+	case WarnOnLockedFile:
+	case RefuseLockedFile:
+	default:
+		err = fmt.Errorf("file lock mode must be '%s' or '%s', got '%s'",
+			WarnOnLockedFile,
+			RefuseLockedFile,
+			m,
+		)
+	}
+	return err
+}
+
+// FileLock is an advisory claim by a session on a file or directory. A lock
+// on a directory also covers everything beneath it.
+type FileLock struct {
+	Path       string    `json:"path"`        // Locked path
+	SessionID  string    `json:"session_id"`  // Non-secret identifier of the owning session
+	AcquiredAt time.Time `json:"acquired_at"` // When the lock was first acquired
+	ExpiresAt  time.Time `json:"expires_at"`  // When the lock lapses unless renewed
+	token      string    // Session token of the owner
+}
+
+// Package-level file lock storage, keyed by absolute path
+var (
+	fileLocks      = make(map[string]FileLock)
+	fileLockMode   = WarnOnLockedFile
+	fileLocksMutex sync.Mutex
+)
+
+// SetFileLockMode sets how writes react to paths locked by other sessions.
+// An empty mode selects WarnOnLockedFile.
+func SetFileLockMode(mode FileLockMode) (err error) {
+	if mode == "" {
+		mode = WarnOnLockedFile
+	}
+	err = mode.Validate()
+	if err != nil {
+		goto end
+	}
+	fileLocksMutex.Lock()
+	fileLockMode = mode
+	fileLocksMutex.Unlock()
+end:
+	return err
+}
+
+// CurrentFileLockMode returns the mode set by SetFileLockMode.
+func CurrentFileLockMode() FileLockMode {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	return fileLockMode
+}
+
+// SessionID returns a stable, non-secret identifier for the session token so
+// that sessions can be told apart without revealing their tokens.
+func SessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:6])
+}
+
+// LockFile acquires or renews the lock on path for the session identified by
+// token. The lock lapses after ttl, or when the session expires if sooner.
+// It fails with ErrFileLocked if another session holds a conflicting lock.
+func LockFile(token, path string, ttl time.Duration) (lock FileLock, err error) {
+	var now time.Time
+	var session *Session
+	var exists bool
+	var other FileLock
+	var locked bool
+
+	if token == "" {
+		err = ErrTokenNotFound
+		goto end
+	}
+	if ttl <= 0 {
+		ttl = DefaultFileLockTTL
+	}
+	path = lockPath(path)
+	now = time.Now()
+
+	// Look up the session before taking fileLocksMutex; ClearSession holds
+	// sessionsMutex while it calls ClearFileLocks
+	session, exists = GetSession(token)
+
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+
+	other, locked = conflictingLock(token, path, now)
+	if locked {
+		err = fmt.Errorf("%w: %s is locked by session %s until %s",
+			ErrFileLocked,
+			other.Path,
+			other.SessionID,
+			other.ExpiresAt.Format(time.RFC3339),
+		)
+		goto end
+	}
+
+	lock, locked = fileLocks[path]
+	if !locked || lock.token != token {
+		lock = FileLock{
+			Path:       path,
+			SessionID:  SessionID(token),
+			AcquiredAt: now,
+			token:      token,
+		}
+	}
+	lock.ExpiresAt = now.Add(ttl)
+	if exists && session.ExpiresAt.Before(lock.ExpiresAt) {
+		lock.ExpiresAt = session.ExpiresAt
+	}
+	fileLocks[path] = lock
+
+end:
+	return lock, err
+}
+
+// UnlockFile releases the lock held on path by the session identified by
+// token. It reports whether such a lock was found.
+func UnlockFile(token, path string) (found bool) {
+	var lock FileLock
+
+	path = lockPath(path)
+
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+
+	lock, found = fileLocks[path]
+	if !found || lock.token != token {
+		found = false
+		goto end
+	}
+	delete(fileLocks, path)
+
+end:
+	return found
+}
+
+// ListFileLocks returns the unexpired locks sorted by path.
+func ListFileLocks() (locks []FileLock) {
+	var now time.Time
+
+	now = time.Now()
+
+	fileLocksMutex.Lock()
+	removeExpiredLocks(now)
+	locks = make([]FileLock, 0, len(fileLocks))
+	for _, lock := range fileLocks {
+		locks = append(locks, lock)
+	}
+	fileLocksMutex.Unlock()
+
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].Path < locks[j].Path
+	})
+	return locks
+}
+
+// LockedByOtherSession returns the unexpired lock, if any, held by a session
+// other than the one identified by token that covers path or lies beneath it.
+func LockedByOtherSession(token, path string) (lock FileLock, locked bool) {
+	fileLocksMutex.Lock()
+	defer fileLocksMutex.Unlock()
+	return conflictingLock(token, lockPath(path), time.Now())
+}
+
+// ClearFileLocks releases every lock held by the session identified by
+// token. It is called whenever a session ends.
+func ClearFileLocks(token string) {
+	fileLocksMutex.Lock()
+	for path, lock := range fileLocks {
+		if lock.token == token {
+			delete(fileLocks, path)
+		}
+	}
+	fileLocksMutex.Unlock()
+}
+
+// rekeyFileLocks transfers the locks held by the session identified by
+// oldToken to newToken when the session's token is rotated.
+func rekeyFileLocks(oldToken, newToken string) {
+	fileLocksMutex.Lock()
+	for path, lock := range fileLocks {
+		if lock.token == oldToken {
+			lock.token = newToken
+			lock.SessionID = SessionID(newToken)
+			fileLocks[path] = lock
+		}
+	}
+	fileLocksMutex.Unlock()
+}
+
+// clearAllFileLocks releases the locks held by every session.
+func clearAllFileLocks() {
+	fileLocksMutex.Lock()
+	fileLocks = make(map[string]FileLock)
+	fileLocksMutex.Unlock()
+}
+
+// conflictingLock finds a lock held by another session on path, an ancestor
+// of path, or a descendant of path. Callers must hold fileLocksMutex.
+func conflictingLock(token, path string, now time.Time) (lock FileLock, locked bool) {
+	removeExpiredLocks(now)
+	for _, l := range fileLocks {
+		if l.token == token {
+			continue
+		}
+		if !pathsOverlap(l.Path, path) {
+			continue
+		}
+		lock, locked = l, true
+		goto end
+	}
+end:
+	return lock, locked
+}
+
+// removeExpiredLocks drops locks whose TTL has passed. Callers must hold fileLocksMutex.
+func removeExpiredLocks(now time.Time) {
+	for path, lock := range fileLocks {
+		if now.After(lock.ExpiresAt) {
+			delete(fileLocks, path)
+		}
+	}
+}
+
+// lockPath returns the absolute form of path under which its lock is stored.
+func lockPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = filepath.Clean(path)
+	}
+	return abs
+}
+
+// pathsOverlap reports whether a and b are the same path or one contains the other.
+func pathsOverlap(a, b string) bool {
+	sep := string(filepath.Separator)
+	return a == b ||
+		strings.HasPrefix(b, strings.TrimSuffix(a, sep)+sep) ||
+		strings.HasPrefix(a, strings.TrimSuffix(b, sep)+sep)
+}
+
+// sessionTokenContextKey is the context key under which the calling session's token is stored.
+type sessionTokenContextKey struct{}
+
+// sessionTokenFromContext returns the calling session's token stored by handleTool.
+func sessionTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(sessionTokenContextKey{}).(string)
+	return token
+}
+
+// lockWarningsContextKey is the context key under which *lockWarnings is stored.
+type lockWarningsContextKey struct{}
+
+// lockWarnings collects warnings about writes to paths locked by other sessions.
+type lockWarnings struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+// withFileLockContext returns a copy of ctx that carries the calling
+// session's token and a collector for lock warnings.
+func withFileLockContext(ctx context.Context, token string) (context.Context, *lockWarnings) {
+	lw := &lockWarnings{}
+	ctx = context.WithValue(ctx, sessionTokenContextKey{}, token)
+	ctx = context.WithValue(ctx, lockWarningsContextKey{}, lw)
+	return ctx, lw
+}
+
+// checkFileLock enforces the file lock mode for a write to path made with
+// ctx, returning ErrFileLocked in refuse mode or recording a warning in warn mode.
+func checkFileLock(ctx context.Context, path string) (err error) {
+	var token string
+	var lock FileLock
+	var locked bool
+	var mode FileLockMode
+	var lw *lockWarnings
+	var ok bool
+	var msg string
+
+	if ctx == nil {
+		goto end
+	}
+	token = sessionTokenFromContext(ctx)
+
+	lock, locked = LockedByOtherSession(token, path)
+	if !locked {
+		goto end
+	}
+
+	msg = fmt.Sprintf("%s is locked by session %s until %s",
+		lock.Path,
+		lock.SessionID,
+		lock.ExpiresAt.Format(time.RFC3339),
+	)
+
+	fileLocksMutex.Lock()
+	mode = fileLockMode
+	fileLocksMutex.Unlock()
+
+	if mode == RefuseLockedFile {
+		err = fmt.Errorf("%w: %s", ErrFileLocked, msg)
+		goto end
+	}
+
+	if logger != nil {
+		logger.Warn("Writing to file locked by another session", "path", path, "lock_path", lock.Path, "session_id", lock.SessionID)
+	}
+	lw, ok = ctx.Value(lockWarningsContextKey{}).(*lockWarnings)
+	if !ok {
+		goto end
+	}
+	lw.mutex.Lock()
+	lw.messages = append(lw.messages, msg)
+	lw.mutex.Unlock()
+
+end:
+	return err
+}
+
+// addLockWarnings adds any collected lock warnings to a JSON object result
+// under "lock_warnings". Results that are not JSON objects are returned as-is.
+func addLockWarnings(result ToolResult, lw *lockWarnings) ToolResult {
+	var jr *jsonResult
+	var m map[string]any
+	var ok bool
+	var err error
+
+	lw.mutex.Lock()
+	defer lw.mutex.Unlock()
+
+	if len(lw.messages) == 0 {
+		goto end
+	}
+	jr, ok = result.(*jsonResult)
+	if !ok {
+		goto end
+	}
+	err = json.Unmarshal([]byte(jr.json), &m)
+	if err != nil || m == nil {
+		goto end
+	}
+	m["lock_warnings"] = lw.messages
+	result = NewToolResultJSON(m)
+
+end:
+	return result
+}
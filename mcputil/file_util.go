@@ -1,26 +1,363 @@
 package mcputil
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"syscall"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 )
 
 // WriteFile writes content to a file after validating the path is allowed.
 // This function provides secure file writing with path validation against the server's
 // allowed paths configuration to prevent unauthorized file system access.
-func WriteFile(c Config, filePath string, content string) (err error) {
+// When ctx carries a Preview the write is recorded there instead of persisted.
+// Writing to a path locked by another session warns or fails per the file lock mode.
+// The file's prior content is backed up for UndoEdit when edit backups are enabled.
+func WriteFile(ctx context.Context, c Config, filePath string, content string) (err error) {
+	return writeFile(ctx, c, filePath, content, os.WriteFile)
+}
+
+// WriteFileAtomic is like WriteFile but replaces the file atomically using
+// scoutcfg.WriteFileAtomic, so readers never observe a partially written file.
+func WriteFileAtomic(ctx context.Context, c Config, filePath string, content string) (err error) {
+	return writeFile(ctx, c, filePath, content, scoutcfg.WriteFileAtomic)
+}
+
+// writeFile performs the checks shared by WriteFile and WriteFileAtomic and
+// then persists content with write, unless ctx carries a Preview.
+func writeFile(ctx context.Context, c Config, filePath string, content string, write func(string, []byte, os.FileMode) error) (err error) {
+	var preview *Preview
+	var ok bool
+	var backup *EditBackup
 
 	if !c.IsAllowedPath(filePath) {
 		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
 		goto end
 	}
 
-	err = os.WriteFile(filePath, []byte(content), 0644)
+	err = checkFileLock(ctx, filePath)
+	if err != nil {
+		goto end
+	}
+
+	preview, ok = GetPreview(ctx)
+	if ok {
+		err = preview.recordWrite(filePath, content)
+		goto end
+	}
+
+	backup, err = backupFileBeforeWrite(ctx, filePath, &content)
+	if err != nil {
+		goto end
+	}
+
+	err = write(filePath, []byte(content), 0644)
+	if err != nil && backup != nil {
+		// A failed write that left the file as it was has nothing to undo
+		_ = discardUnusedEditBackup(ctx, filePath, backup)
+	}
 
 end:
 	return err
 }
 
+// RemoveFile removes a file, or a directory and everything in it when
+// recursive is true, after validating the path is allowed. When ctx carries
+// a Preview the removal is recorded there instead of performed. Removing a
+// path locked by another session warns or fails per the file lock mode. A
+// removed file, but not a directory, is backed up for UndoEdit when edit
+// backups are enabled.
+func RemoveFile(ctx context.Context, c Config, filePath string, recursive bool) (err error) {
+	var preview *Preview
+	var ok bool
+
+	if !c.IsAllowedPath(filePath) {
+		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
+		goto end
+	}
+
+	err = checkFileLock(ctx, filePath)
+	if err != nil {
+		goto end
+	}
+
+	preview, ok = GetPreview(ctx)
+	if ok {
+		preview.recordRemove(filePath)
+		goto end
+	}
+
+	err = backupFile(ctx, filePath)
+	if err != nil {
+		goto end
+	}
+
+	if recursive {
+		err = os.RemoveAll(filePath)
+		goto end
+	}
+	err = os.Remove(filePath)
+
+end:
+	return err
+}
+
+// RestoreFile rolls back a write made with ctx to filePath by a batch of
+// changes that then failed, writing original back when the file existed
+// before the batch and otherwise removing it, with the path, lock and preview
+// handling of WriteFileAtomic and RemoveFile. The rollback is not backed up,
+// and the edit backup taken by the batch's write is discarded, so that
+// UndoEdit does not restore the content that was rolled back. A file still
+// holding original is left alone, as writing it changed nothing.
+func RestoreFile(ctx context.Context, c Config, filePath string, existed bool, original string) (err error) {
+	var current []byte
+	var previewing bool
+
+	_, previewing = GetPreview(ctx)
+	if existed && !previewing {
+		current, err = os.ReadFile(filePath)
+		if err == nil && string(current) == original {
+			goto end
+		}
+		err = nil
+	}
+
+	ctx = context.WithValue(ctx, noEditBackupContextKey{}, true)
+	if existed {
+		err = WriteFileAtomic(ctx, c, filePath, original)
+	} else {
+		err = RemoveFile(ctx, c, filePath, false)
+	}
+	if err != nil || previewing {
+		goto end
+	}
+
+	err = discardNewestEditBackup(ctx, filePath)
+
+end:
+	return err
+}
+
+// AppendFile appends content to the end of a file after validating the path
+// is allowed, creating the file when create is true and it does not exist,
+// and returns the file's resulting size. The file is opened with O_APPEND so
+// that concurrent appends are not lost. When ctx carries a Preview the append
+// is recorded there as a write of the resulting content instead of performed.
+// Appending to a path locked by another session warns or fails per the file
+// lock mode. The file's prior content is backed up for UndoEdit when edit
+// backups are enabled.
+func AppendFile(ctx context.Context, c Config, filePath string, content string, create bool) (size int64, err error) {
+	var preview *Preview
+	var ok bool
+	var data []byte
+	var flags int
+	var f *os.File
+	var info os.FileInfo
+
+	if !c.IsAllowedPath(filePath) {
+		err = fmt.Errorf("access denied: path not allowed: %s", filePath)
+		goto end
+	}
+
+	err = checkFileLock(ctx, filePath)
+	if err != nil {
+		goto end
+	}
+
+	preview, ok = GetPreview(ctx)
+	if ok {
+		data, err = os.ReadFile(filePath)
+		if errors.Is(err, os.ErrNotExist) && create {
+			err = nil
+		}
+		if err != nil {
+			goto end
+		}
+		data = append(data, content...)
+		size = int64(len(data))
+		err = preview.recordWrite(filePath, string(data))
+		goto end
+	}
+
+	err = backupFile(ctx, filePath)
+	if err != nil {
+		goto end
+	}
+
+	flags = os.O_WRONLY | os.O_APPEND
+	if create {
+		flags |= os.O_CREATE
+	}
+	f, err = os.OpenFile(filePath, flags, 0644)
+	if err != nil {
+		goto end
+	}
+
+	_, err = f.WriteString(content)
+	if err == nil {
+		info, err = f.Stat()
+	}
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		goto end
+	}
+	size = info.Size()
+
+end:
+	return size, err
+}
+
+// MoveFile moves the file at source to destination after validating both
+// paths are allowed, replacing any file already at destination. The file is
+// renamed when both paths are on the same filesystem and otherwise copied,
+// with its permissions and modification time, and then removed. When ctx
+// carries a Preview the move is recorded there as a write of destination and
+// a removal of source instead of performed. Moving a path locked by another
+// session warns or fails per the file lock mode. Both files are backed up for
+// UndoEdit when edit backups are enabled.
+func MoveFile(ctx context.Context, c Config, source, destination string) (err error) {
+	var preview *Preview
+	var ok bool
+	var data []byte
+
+	for _, filePath := range []string{source, destination} {
+		if !c.IsAllowedPath(filePath) {
+			err = fmt.Errorf("access denied: path not allowed: %s", filePath)
+			goto end
+		}
+		err = checkFileLock(ctx, filePath)
+		if err != nil {
+			goto end
+		}
+	}
+
+	preview, ok = GetPreview(ctx)
+	if ok {
+		data, err = os.ReadFile(source)
+		if err != nil {
+			goto end
+		}
+		err = preview.recordWrite(destination, string(data))
+		if err != nil {
+			goto end
+		}
+		preview.recordRemove(source)
+		goto end
+	}
+
+	err = backupFile(ctx, source)
+	if err != nil {
+		goto end
+	}
+	err = backupFile(ctx, destination)
+	if err != nil {
+		goto end
+	}
+
+	err = os.Rename(source, destination)
+	if errors.Is(err, syscall.EXDEV) {
+		_, err = copyFile(source, destination)
+		if err != nil {
+			goto end
+		}
+		err = os.Remove(source)
+	}
+
+end:
+	return err
+}
+
+// CopyFile copies the file at source to destination after validating both
+// paths are allowed, replacing any file already at destination and giving
+// the copy the source's permissions, and returns the number of bytes copied.
+// When ctx carries a Preview the copy is recorded there as a write of
+// destination instead of performed. Writing to a path locked by another
+// session warns or fails per the file lock mode. The destination's prior
+// content is backed up for UndoEdit when edit backups are enabled.
+func CopyFile(ctx context.Context, c Config, source, destination string) (n int64, err error) {
+	var preview *Preview
+	var ok bool
+	var data []byte
+
+	for _, filePath := range []string{source, destination} {
+		if !c.IsAllowedPath(filePath) {
+			err = fmt.Errorf("access denied: path not allowed: %s", filePath)
+			goto end
+		}
+	}
+
+	err = checkFileLock(ctx, destination)
+	if err != nil {
+		goto end
+	}
+
+	preview, ok = GetPreview(ctx)
+	if ok {
+		data, err = os.ReadFile(source)
+		if err != nil {
+			goto end
+		}
+		n = int64(len(data))
+		err = preview.recordWrite(destination, string(data))
+		goto end
+	}
+
+	err = backupFile(ctx, destination)
+	if err != nil {
+		goto end
+	}
+
+	n, err = copyFile(source, destination)
+
+end:
+	return n, err
+}
+
+// copyFile copies the file at source to destination, giving the copy the
+// source's permissions and modification time, and returns the number of
+// bytes copied. A partially written copy is removed.
+func copyFile(source, destination string) (n int64, err error) {
+	var info os.FileInfo
+	var in, out *os.File
+
+	in, err = os.Open(source)
+	if err != nil {
+		goto end
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	info, err = in.Stat()
+	if err != nil {
+		goto end
+	}
+
+	out, err = os.OpenFile(destination, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		goto end
+	}
+
+	n, err = io.Copy(out, in)
+	err = errors.Join(err, out.Close())
+	if err == nil {
+		err = os.Chmod(destination, info.Mode().Perm())
+	}
+	if err == nil {
+		err = os.Chtimes(destination, info.ModTime(), info.ModTime())
+	}
+	if err != nil {
+		_ = os.Remove(destination)
+	}
+
+end:
+	return n, err
+}
+
 // ReadFile reads content from a file after validating the path is allowed.
 // This function provides secure file reading with path validation against the server's
 // allowed paths configuration to prevent unauthorized file system access.
@@ -0,0 +1,34 @@
+package mcputil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFile_ShouldPreserveModeAndModTime(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.sh")
+	destination := filepath.Join(dir, "destination.sh")
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, os.WriteFile(source, []byte("#!/bin/sh\n"), 0755), "Should write source")
+	require.NoError(t, os.Chtimes(source, modTime, modTime), "Should set source times")
+
+	n, err := copyFile(source, destination)
+	require.NoError(t, err, "Should copy file")
+	assert.Equal(t, int64(len("#!/bin/sh\n")), n, "Should report the bytes copied")
+
+	content, err := os.ReadFile(destination)
+	require.NoError(t, err, "Should read copy")
+	assert.Equal(t, "#!/bin/sh\n", string(content), "Copy should hold the source content")
+
+	info, err := os.Stat(destination)
+	require.NoError(t, err, "Should stat copy")
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), "Copy should keep the source permissions")
+	assert.True(t, info.ModTime().Equal(modTime), "Copy should keep the source modification time")
+}
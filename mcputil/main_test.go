@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 	"github.com/mikeschinkel/scout-mcp/testutil"
 )
 
@@ -12,7 +13,9 @@ func TestMain(m *testing.M) {
 	// Setup code here if needed
 	// For example: initialize test data, mock services, etc.
 
-	mcputil.SetLogger(testutil.NewTestLogger())
+	logger := testutil.NewTestLogger()
+	mcputil.SetLogger(logger)
+	scoutcfg.SetLogger(logger)
 
 	// Need to register for standalone testing
 	// When paired with a app-specific tools package this will be registered.
@@ -136,7 +136,7 @@ func (s *mcpServer) AddTool(tool Tool) (err error) {
 		}
 
 		// Call user handler
-		result, err = tool.Handle(ctx, wrappedReq)
+		result, err = handleTool(ctx, tool, wrappedReq)
 		if err != nil {
 			var internalError *InternalError
 			if errors.As(err, &internalError) {
@@ -0,0 +1,265 @@
+package mcputil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// previewContextKey is the context key under which the active *Preview is stored.
+type previewContextKey struct{}
+
+// PreviewFile describes a change a tool would have made to a single path.
+type PreviewFile struct {
+	Path      string        `json:"path"`                 // Path that would have been changed
+	Operation FileOperation `json:"operation"`            // Whether the path would be created, updated, or deleted
+	Content   string        `json:"content,omitempty"`    // Resulting content for created and updated files
+	Diff      string        `json:"diff,omitempty"`       // Unified diff from the current to the resulting content
+	DiffError string        `json:"diff_error,omitempty"` // Why a diff could not be produced
+	before    string        // Content on disk before the change
+}
+
+// PreviewResult is returned for a previewable tool called with dry_run set.
+// Its fields are merged into the tool's normal result, which is populated as
+// if the files had been written, and take precedence over fields of the same
+// name.
+type PreviewResult struct {
+	DryRun    bool          `json:"dry_run"`
+	Tool      string        `json:"tool"`
+	Files     []PreviewFile `json:"files"`
+	FileCount int           `json:"file_count"`
+	Summary   string        `json:"summary"`
+}
+
+// Preview collects the file changes intercepted by WriteFile and RemoveFile
+// while a tool runs with dry_run set, so the tool's logic runs unchanged but
+// nothing is persisted.
+type Preview struct {
+	mutex sync.Mutex
+	files []PreviewFile
+	index map[string]int
+}
+
+// NewPreview creates an empty Preview.
+func NewPreview() *Preview {
+	return &Preview{
+		files: make([]PreviewFile, 0),
+		index: make(map[string]int),
+	}
+}
+
+// WithPreview returns a copy of ctx in which file writes are captured by p
+// instead of being persisted.
+func WithPreview(ctx context.Context, p *Preview) context.Context {
+	return context.WithValue(ctx, previewContextKey{}, p)
+}
+
+// GetPreview returns the Preview active in ctx, if any.
+func GetPreview(ctx context.Context) (p *Preview, ok bool) {
+	if ctx == nil {
+		goto end
+	}
+	p, ok = ctx.Value(previewContextKey{}).(*Preview)
+end:
+	return p, ok
+}
+
+// IsPreview reports whether file changes made with ctx are only being previewed.
+func IsPreview(ctx context.Context) bool {
+	_, ok := GetPreview(ctx)
+	return ok
+}
+
+// Files returns the intercepted changes in the order they were first made.
+func (p *Preview) Files() []PreviewFile {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]PreviewFile(nil), p.files...)
+}
+
+// Result builds the PreviewResult reported for toolName.
+func (p *Preview) Result(toolName string) (r PreviewResult) {
+	r = PreviewResult{
+		DryRun: true,
+		Tool:   toolName,
+		Files:  p.Files(),
+	}
+	r.FileCount = len(r.Files)
+	r.Summary = fmt.Sprintf("Dry run: %s would change %d file(s); nothing was written", toolName, r.FileCount)
+	return r
+}
+
+// MergeResult returns result, the JSON object a tool returned while being
+// previewed, with the fields of the PreviewResult for toolName added, so that
+// clients see the tool's usual success and metadata fields alongside the
+// changes it would have made. Where both have a field, the PreviewResult's
+// value is kept. A result that is not a JSON object is replaced by the
+// PreviewResult.
+func (p *Preview) MergeResult(toolName string, result ToolResult) (merged ToolResult) {
+	var jr *jsonResult
+	var fields, previewFields map[string]any
+	var data []byte
+	var ok bool
+	var err error
+
+	pr := p.Result(toolName)
+	merged = NewToolResultJSON(pr)
+
+	jr, ok = result.(*jsonResult)
+	if !ok {
+		goto end
+	}
+	err = json.Unmarshal([]byte(jr.json), &fields)
+	if err != nil || fields == nil {
+		goto end
+	}
+	data, err = json.Marshal(pr)
+	if err != nil {
+		goto end
+	}
+	err = json.Unmarshal(data, &previewFields)
+	if err != nil {
+		goto end
+	}
+	for key, value := range previewFields {
+		fields[key] = value
+	}
+	merged = NewToolResultJSON(fields)
+
+end:
+	return merged
+}
+
+// recordWrite captures a write of content to filePath. The first change to a
+// path determines whether it is created or updated; later writes to the same
+// path replace the resulting content but keep diffing against the original.
+func (p *Preview) recordWrite(filePath string, content string) (err error) {
+	var pf PreviewFile
+	var i int
+	var ok bool
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	i, ok = p.index[filePath]
+	if !ok {
+		pf, err = newPreviewFile(filePath)
+		if err != nil {
+			goto end
+		}
+		i = len(p.files)
+		p.index[filePath] = i
+		p.files = append(p.files, pf)
+	}
+
+	pf = p.files[i]
+	if pf.Operation == DeletedFileOp {
+		pf.Operation = UpdatedFileOp
+	}
+	pf.Content = content
+	pf.DiffError = ""
+	pf.Diff, err = UnifiedDiff("a/"+filePath, "b/"+filePath, pf.before, content)
+	if err != nil {
+		pf.DiffError = err.Error()
+		err = nil
+	}
+	p.files[i] = pf
+
+end:
+	return err
+}
+
+// recordRemove captures the removal of filePath.
+func (p *Preview) recordRemove(filePath string) {
+	var i int
+	var ok bool
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	i, ok = p.index[filePath]
+	if !ok {
+		i = len(p.files)
+		p.index[filePath] = i
+		p.files = append(p.files, PreviewFile{Path: filePath})
+	}
+	p.files[i].Operation = DeletedFileOp
+	p.files[i].Content = ""
+	p.files[i].Diff = ""
+	p.files[i].DiffError = ""
+}
+
+// newPreviewFile returns a PreviewFile for filePath holding its current
+// content, or marked as created when it does not yet exist.
+func newPreviewFile(filePath string) (pf PreviewFile, err error) {
+	var data []byte
+
+	pf = PreviewFile{
+		Path:      filePath,
+		Operation: UpdatedFileOp,
+	}
+	data, err = os.ReadFile(filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		pf.Operation = CreatedFileOp
+		err = nil
+	case err != nil:
+		err = fmt.Errorf("failed to read %s for preview: %w", filePath, err)
+	default:
+		pf.before = string(data)
+	}
+	return pf, err
+}
+
+// handleTool calls tool.Handle, running it as a preview when the tool is
+// previewable and the request sets dry_run. In that case a PreviewResult
+// describing the intercepted changes is merged into the tool's own result.
+// Warnings about writes to files locked by other sessions are added to the
+// result as "lock_warnings". Mutating tools fail with ErrReadOnlySession when
+// called by a read-only session, unless the request only reports.
+func handleTool(ctx context.Context, tool Tool, req ToolRequest) (result ToolResult, err error) {
+	var dryRun bool
+	var preview *Preview
+	var token string
+	var warnings *lockWarnings
+
+	token, _ = RequiredSessionTokenProperty.String(req)
+	err = ensureSessionCanCall(token, tool, req)
+	if err != nil {
+		goto end
+	}
+
+	ctx, warnings = withFileLockContext(ctx, token)
+	ctx = withConfirmationToken(ctx, req)
+
+	if !tool.Options().Previewable {
+		result, err = tool.Handle(ctx, req)
+		goto end
+	}
+
+	dryRun, err = DryRunProperty.Bool(req)
+	if err != nil {
+		goto end
+	}
+	if !dryRun {
+		result, err = tool.Handle(ctx, req)
+		goto end
+	}
+
+	preview = NewPreview()
+	result, err = tool.Handle(WithPreview(ctx, preview), req)
+	if err != nil {
+		goto end
+	}
+
+	result = preview.MergeResult(tool.Name(), result)
+
+end:
+	if err == nil {
+		result = addLockWarnings(result, warnings)
+	}
+	return result, err
+}
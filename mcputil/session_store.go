@@ -0,0 +1,161 @@
+package mcputil
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+)
+
+// sessionTokensDir is the directory of the session store holding a file per
+// issued session token.
+const sessionTokensDir = "tokens"
+
+// sessionStore persists issued session tokens so that sessions survive a
+// server restart. It is guarded by sessionsMutex, and nil keeps sessions in
+// memory only.
+var sessionStore *scoutcfg.FileStore
+
+// SetSessionStore sets the store in which issued session tokens are persisted,
+// with their expiry, under tokens/, and loads the tokens already persisted
+// there so that sessions started before a restart remain valid until they
+// expire. Expired tokens are pruned as they are loaded, together with the
+// changed files, file locks, confirmations and edit backups kept for their
+// sessions. Token files are created with permissions 0600 as they grant
+// access to the server's tools. A nil store keeps sessions in memory only.
+func SetSessionStore(store *scoutcfg.FileStore) (err error) {
+	var names []string
+	var session *Session
+	var now time.Time
+
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+
+	sessionStore = store
+	if store == nil {
+		goto end
+	}
+
+	names, err = store.List(sessionTokensDir)
+	if err != nil {
+		goto end
+	}
+
+	now = time.Now()
+	for _, name := range names {
+		session = &Session{}
+		err = store.Load(path.Join(sessionTokensDir, name), session)
+		if err != nil {
+			logger.Warn("Skipping unreadable session token file", "file", name, "error", err)
+			err = nil
+			continue
+		}
+		if !now.After(session.ExpiresAt) && session.Token != "" {
+			// Keep sessions already in memory, such as on a config reload
+			if _, ok := sessions[session.Token]; !ok {
+				sessions[session.Token] = session
+			}
+			continue
+		}
+		// Prune the expired token along with what was kept for its session
+		err = store.Delete(path.Join(sessionTokensDir, name))
+		if err != nil {
+			logger.Warn("Failed to delete session token file", "file", name, "error", err)
+			err = nil
+		}
+		if session.Token != "" {
+			clearSessionState(session.Token)
+		}
+	}
+
+end:
+	return err
+}
+
+// sessionTokenFile returns the path within the session store of the file
+// persisting the session identified by token. Files are named by SessionID so
+// that tokens never appear in file names.
+func sessionTokenFile(token string) string {
+	return path.Join(sessionTokensDir, SessionID(token)+".json")
+}
+
+// saveStoredSession persists session, if a session store is set. The caller
+// must hold sessionsMutex.
+func saveStoredSession(session *Session) (err error) {
+	if sessionStore == nil {
+		goto end
+	}
+	err = sessionStore.SaveWithPerm(sessionTokenFile(session.Token), session, 0600)
+
+end:
+	return err
+}
+
+// loadStoredSession looks up the session identified by token in the session
+// store, such as one started by another server sharing the store, and adds it
+// to the sessions in memory unless it has expired, in which case its file and
+// session state are pruned. The caller must hold sessionsMutex.
+func loadStoredSession(token string) (session *Session, exists bool) {
+	var err error
+
+	if sessionStore == nil {
+		goto end
+	}
+	if !sessionStore.Exists(sessionTokenFile(token)) {
+		goto end
+	}
+
+	session = &Session{}
+	err = sessionStore.Load(sessionTokenFile(token), session)
+	if err != nil || session.Token != token {
+		session = nil
+		goto end
+	}
+	if time.Now().After(session.ExpiresAt) {
+		deleteStoredSession(token)
+		clearSessionState(token)
+		session = nil
+		goto end
+	}
+
+	sessions[token] = session
+	exists = true
+
+end:
+	return session, exists
+}
+
+// deleteStoredSession removes the file persisting the session identified by
+// token, if a session store is set and the file exists. The caller must hold
+// sessionsMutex.
+func deleteStoredSession(token string) {
+	var err error
+
+	if sessionStore == nil {
+		goto end
+	}
+	err = sessionStore.Delete(sessionTokenFile(token))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("Failed to delete session token file", "session_id", SessionID(token), "error", err)
+	}
+
+end:
+}
+
+// deleteAllStoredSessions removes the files persisting every session, if a
+// session store is set. The caller must hold sessionsMutex.
+func deleteAllStoredSessions() {
+	var err error
+
+	if sessionStore == nil {
+		goto end
+	}
+	err = sessionStore.DeleteAll(sessionTokensDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("Failed to delete session token files", "error", err)
+	}
+
+end:
+}
@@ -15,8 +15,9 @@ import (
 type Session struct {
 	Token     string    `json:"token"`      // Cryptographically secure session token
 	CreatedAt time.Time `json:"created_at"` // When the session was created
-	ExpiresAt time.Time `json:"expires_at"` // When the session expires (24 hours from creation)
+	ExpiresAt time.Time `json:"expires_at"` // When the session expires (SessionTTL from creation unless requested otherwise)
 	LastUsed  time.Time `json:"last_used"`  // When the session was last accessed
+	ReadOnly  bool      `json:"read_only"`  // Whether the session is barred from mutating tools
 }
 
 // Payload defines the interface for session payload data that can be
@@ -35,6 +36,7 @@ var _ PayloadCarrier = (*StartSessionResult)(nil)
 type StartSessionResult struct {
 	SessionToken    string    `json:"session_token"`    // Generated session token for authentication
 	TokenExpiresAt  time.Time `json:"token_expires_at"` // When the token expires
+	ReadOnly        bool      `json:"read_only"`        // Whether tools that modify files are barred
 	Instructions    string    `json:"instructions"`     // User instructions for using MCP tools
 	PayloadTypeName string    `json:"payload_type"`     // Type name of the payload for deserialization
 	Message         string    `json:"message"`          // Success message for the user
@@ -53,20 +55,104 @@ func (ssr *StartSessionResult) GetPayloadTypeName() string {
 	return ssr.PayloadTypeName
 }
 
+// DefaultSessionTTL is how long session tokens remain valid when no TTL is
+// configured or requested.
+const DefaultSessionTTL = 24 * time.Hour
+
+// DefaultMaxSessionTTL is the longest TTL start_session may request when no
+// maximum is configured.
+const DefaultMaxSessionTTL = 7 * 24 * time.Hour
+
 // Package-level session storage
 var (
 	sessions      = make(map[string]*Session)
 	sessionsMutex sync.RWMutex
 )
 
+// Package-level session TTL settings
+var (
+	sessionTTL      = DefaultSessionTTL
+	maxSessionTTL   = DefaultMaxSessionTTL
+	sessionTTLMutex sync.RWMutex
+)
+
+// SetSessionTTL sets how long session tokens remain valid unless
+// start_session requests otherwise, and the longest TTL it may request. Zero
+// selects DefaultSessionTTL or DefaultMaxSessionTTL respectively, and ttl must
+// not exceed maxTTL.
+func SetSessionTTL(ttl, maxTTL time.Duration) (err error) {
+	if ttl < 0 {
+		err = fmt.Errorf("session_ttl_minutes must not be negative, got %d", int(ttl/time.Minute))
+		goto end
+	}
+	if maxTTL < 0 {
+		err = fmt.Errorf("max_session_ttl_minutes must not be negative, got %d", int(maxTTL/time.Minute))
+		goto end
+	}
+	if ttl == 0 {
+		ttl = DefaultSessionTTL
+	}
+	if maxTTL == 0 {
+		maxTTL = DefaultMaxSessionTTL
+	}
+	if ttl > maxTTL {
+		err = fmt.Errorf("session_ttl_minutes of %d exceeds max_session_ttl_minutes of %d", int(ttl/time.Minute), int(maxTTL/time.Minute))
+		goto end
+	}
+
+	sessionTTLMutex.Lock()
+	sessionTTL = ttl
+	maxSessionTTL = maxTTL
+	sessionTTLMutex.Unlock()
+
+end:
+	return err
+}
+
+// SessionTTL returns how long session tokens remain valid unless start_session
+// requests otherwise.
+func SessionTTL() time.Duration {
+	sessionTTLMutex.RLock()
+	defer sessionTTLMutex.RUnlock()
+	return sessionTTL
+}
+
+// MaxSessionTTL returns the longest TTL start_session may request.
+func MaxSessionTTL() time.Duration {
+	sessionTTLMutex.RLock()
+	defer sessionTTLMutex.RUnlock()
+	return maxSessionTTL
+}
+
+// ValidateSessionTTL checks that ttl, as requested of start_session, is
+// positive and no longer than MaxSessionTTL.
+func ValidateSessionTTL(ttl time.Duration) (err error) {
+	var maxTTL time.Duration
+
+	if ttl <= 0 {
+		err = fmt.Errorf("%w: must be positive, got %s", ErrInvalidSessionTTL, ttl)
+		goto end
+	}
+	maxTTL = MaxSessionTTL()
+	if ttl > maxTTL {
+		err = fmt.Errorf("%w: %s exceeds the maximum of %s", ErrInvalidSessionTTL, ttl, maxTTL)
+		goto end
+	}
+
+end:
+	return err
+}
+
 // NewSession creates a new session and returns the Session instance.
 // This function creates an uninitialized session that must be initialized before use.
 func NewSession() (session *Session) {
 	return &Session{}
 }
 
-// GetSession retrieves a session by token from the session store.
-// Returns nil and false if the token is empty or not found in the store.
+// GetSession retrieves a session by token from the session store, falling back
+// to the tokens persisted by SetSessionStore when it is not held in memory.
+// Returns nil and false if the token is empty, not found in the store, or its
+// persisted session has expired.
 func GetSession(token string) (session *Session, exists bool) {
 	if token == "" {
 		goto end
@@ -75,6 +161,17 @@ func GetSession(token string) (session *Session, exists bool) {
 	sessionsMutex.RLock()
 	session, exists = sessions[token]
 	sessionsMutex.RUnlock()
+	if exists {
+		goto end
+	}
+
+	// Fall back to tokens persisted by a server sharing the session store
+	sessionsMutex.Lock()
+	session, exists = sessions[token]
+	if !exists {
+		session, exists = loadStoredSession(token)
+	}
+	sessionsMutex.Unlock()
 
 end:
 	return session, exists
@@ -82,41 +179,150 @@ end:
 
 // Initialize initializes the session with a cryptographically secure token and timestamps.
 // This method generates a random 32-byte token, sets creation and expiration times,
-// and stores the session in the global session store.
+// and stores the session in the global session store. The session expires after
+// SessionTTL.
 func (s *Session) Initialize() (err error) {
-	var tokenBytes []byte
+	return s.InitializeWithTTL(SessionTTL())
+}
+
+// InitializeWithTTL initializes the session as Initialize does, except that it
+// expires after ttl, which must be positive and no longer than MaxSessionTTL.
+func (s *Session) InitializeWithTTL(ttl time.Duration) (err error) {
+	var token string
+	var now time.Time
+	var ok bool
 
 	sessionsMutex.Lock()
 	defer sessionsMutex.Unlock()
 
-	_, ok := sessions[s.Token]
+	_, ok = sessions[s.Token]
 	if ok {
 		goto end
 	}
 
-	// Generate random token
-	tokenBytes = make([]byte, 32)
-	_, err = rand.Read(tokenBytes)
+	err = ValidateSessionTTL(ttl)
 	if err != nil {
 		goto end
 	}
 
-	s.Token = hex.EncodeToString(tokenBytes)
-	s.CreatedAt = time.Now()
-	s.ExpiresAt = time.Now().Add(24 * time.Hour)
-	s.LastUsed = time.Now()
+	token, err = newSessionToken()
+	if err != nil {
+		goto end
+	}
+
+	now = time.Now()
+	s.Token = token
+	s.CreatedAt = now
+	s.ExpiresAt = now.Add(ttl)
+	s.LastUsed = now
 
-	// Store session info
+	// Store session info, persisting it to survive a restart
 	sessions[s.Token] = s
+	err = saveStoredSession(s)
+	if err != nil {
+		delete(sessions, s.Token)
+		err = fmt.Errorf("failed to persist session token: %w", err)
+		goto end
+	}
 
 end:
 	return err
 }
 
+// newSessionToken generates a random 32-byte session token, hex encoded.
+func newSessionToken() (token string, err error) {
+	var tokenBytes []byte
+
+	tokenBytes = make([]byte, 32)
+	_, err = rand.Read(tokenBytes)
+	if err != nil {
+		goto end
+	}
+	token = hex.EncodeToString(tokenBytes)
+
+end:
+	return token, err
+}
+
+// RefreshSession extends the unexpired session identified by token so that it
+// expires ttl from now, keeping its changed files, file locks, confirmations
+// and edit backups. With rotate the session is also given a new token, to
+// which that state is carried over, and the old token stops working. Unknown
+// and expired tokens fail with ErrInvalidSessionToken.
+func RefreshSession(token string, ttl time.Duration, rotate bool) (session *Session, err error) {
+	var refreshed Session
+	var exists bool
+	var now time.Time
+
+	sessionsMutex.Lock()
+	defer sessionsMutex.Unlock()
+
+	session, exists = sessions[token]
+	if !exists && token != "" {
+		session, exists = loadStoredSession(token)
+	}
+	if !exists {
+		err = fmt.Errorf("%w: %w", ErrInvalidSessionToken, ErrTokenNotFound)
+		goto end
+	}
+
+	now = time.Now()
+	if now.After(session.ExpiresAt) {
+		err = fmt.Errorf("%w: %w", ErrInvalidSessionToken, ErrTokenExpired)
+		goto end
+	}
+
+	err = ValidateSessionTTL(ttl)
+	if err != nil {
+		goto end
+	}
+
+	refreshed = *session
+	refreshed.ExpiresAt = now.Add(ttl)
+	refreshed.LastUsed = now
+	if rotate {
+		refreshed.Token, err = newSessionToken()
+		if err != nil {
+			goto end
+		}
+	}
+
+	err = saveStoredSession(&refreshed)
+	if err != nil {
+		err = fmt.Errorf("failed to persist session token: %w", err)
+		goto end
+	}
+
+	if rotate {
+		err = rekeyEditBackups(token, refreshed.Token)
+		if err != nil {
+			deleteStoredSession(refreshed.Token)
+			err = fmt.Errorf("failed to move edit backups to the new token: %w", err)
+			goto end
+		}
+		rekeyChangedFiles(token, refreshed.Token)
+		rekeyFileLocks(token, refreshed.Token)
+		rekeyConfirmations(token, refreshed.Token)
+		deleteStoredSession(token)
+		delete(sessions, token)
+		sessions[refreshed.Token] = session
+	}
+	*session = refreshed
+
+end:
+	if err != nil {
+		session = nil
+	}
+	return session, err
+}
+
 var (
-	ErrTokenNotFound = errors.New("token not found")
-	ErrTokenExpired  = errors.New("token expired")
-	ErrNoPayloadType = errors.New("unable to get payload type; you might need to call mcputil.RegisterPayloadType() first")
+	ErrInvalidSessionToken = errors.New("invalid or expired session token")
+	ErrTokenNotFound       = errors.New("token not found")
+	ErrTokenExpired        = errors.New("token expired")
+	ErrInvalidSessionTTL   = errors.New("invalid session TTL")
+	ErrReadOnlySession     = errors.New("session is read-only")
+	ErrNoPayloadType       = errors.New("unable to get payload type; you might need to call mcputil.RegisterPayloadType() first")
 )
 
 // Validate checks if this session is valid and updates last used time.
@@ -138,7 +344,12 @@ func (s *Session) Validate() (err error) {
 		// Remove expired token
 		sessionsMutex.Lock()
 		delete(sessions, s.Token)
+		deleteStoredSession(s.Token)
 		sessionsMutex.Unlock()
+		ClearChangedFiles(s.Token)
+		ClearFileLocks(s.Token)
+		ClearConfirmations(s.Token)
+		ClearEditBackups(s.Token)
 		err = ErrTokenExpired
 		goto end
 	}
@@ -152,6 +363,54 @@ end:
 	return err
 }
 
+// ensureSessionCanCall checks that the session identified by token may make
+// req to tool, failing with ErrReadOnlySession if the session is read-only,
+// the tool is mutating and req does more than report. Unknown tokens are left
+// to session validation.
+func ensureSessionCanCall(token string, tool Tool, req ToolRequest) (err error) {
+	var session *Session
+	var exists bool
+
+	if !tool.Options().Mutating || isReportOnlyRequest(tool, req) {
+		goto end
+	}
+	session, exists = GetSession(token)
+	if !exists {
+		goto end
+	}
+
+	sessionsMutex.RLock()
+	if session.ReadOnly {
+		err = fmt.Errorf("%w: %s modifies files", ErrReadOnlySession, tool.Name())
+	}
+	sessionsMutex.RUnlock()
+
+end:
+	return err
+}
+
+// isReportOnlyRequest reports whether req to tool only reports, either as a
+// dry run of a Previewable tool or per the tool's ReportOnly option.
+func isReportOnlyRequest(tool Tool, req ToolRequest) (reportOnly bool) {
+	var options ToolOptions
+	var dryRun bool
+	var err error
+
+	options = tool.Options()
+	if options.ReportOnly != nil && options.ReportOnly(req) {
+		reportOnly = true
+		goto end
+	}
+	if !options.Previewable {
+		goto end
+	}
+	dryRun, err = DryRunProperty.Bool(req)
+	reportOnly = err == nil && dryRun
+
+end:
+	return reportOnly
+}
+
 // SessionClearType specifies which sessions to clear from the session store.
 // This is used with the ClearSessions function to control session cleanup behavior.
 type SessionClearType int
@@ -175,23 +434,43 @@ func ClearSessions(which SessionClearType) (err error) {
 	case AllSessions:
 		sessionsMutex.Lock()
 		sessions = make(map[string]*Session)
+		deleteAllStoredSessions()
 		sessionsMutex.Unlock()
+		clearAllChangedFiles()
+		clearAllFileLocks()
+		clearAllConfirmations()
+		clearAllEditBackups()
 	default:
 		err = fmt.Errorf("unsupported session clear type '%d'", which)
 	}
 	return err
 }
 
+// ClearSession ends a single session, removing it along with any changed
+// files tracked, file locks held, confirmations issued, and edit backups kept
+// for it. It reports whether the session was found.
 func ClearSession(session string) (found bool) {
 	sessionsMutex.Lock()
 	defer sessionsMutex.Unlock()
 	_, found = sessions[session]
 	if found {
 		delete(sessions, session)
+		deleteStoredSession(session)
 	}
+	clearSessionState(session)
 	return found
 }
 
+// clearSessionState discards the changed files tracked, file locks held,
+// confirmations issued, and edit backups kept for the session identified by
+// token.
+func clearSessionState(token string) {
+	ClearChangedFiles(token)
+	ClearFileLocks(token)
+	ClearConfirmations(token)
+	ClearEditBackups(token)
+}
+
 // clearExpiredSessions removes all expired sessions from the session store.
 // This is an internal function called by ClearSessions when ExpiredSessions is specified.
 // It safely removes sessions that have passed their expiration timestamp.
@@ -214,6 +493,8 @@ func clearExpiredSessions() {
 		sessionsMutex.Lock()
 		for _, token := range expiredTokens {
 			delete(sessions, token)
+			deleteStoredSession(token)
+			clearSessionState(token)
 		}
 		sessionsMutex.Unlock()
 	}
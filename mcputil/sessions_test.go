@@ -1,10 +1,13 @@
 package mcputil_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -155,3 +158,158 @@ func TestSessions_ConcurrentCreation(t *testing.T) {
 
 	assert.Equal(t, 100, len(tokens), "Should have created 100 tokens")
 }
+
+func TestSessions_ChangedFiles(t *testing.T) {
+	session := mcputil.NewSession()
+	err := session.Initialize()
+	require.NoError(t, err, "Failed to create session")
+
+	mcputil.RecordFileChange(session.Token, mcputil.CreatedFileOp, "/tmp/new.go", "/tmp/temp.go")
+	mcputil.RecordFileChange(session.Token, mcputil.UpdatedFileOp, "/tmp/new.go", "/tmp/existing.go")
+	mcputil.RecordFileChange(session.Token, mcputil.DeletedFileOp, "/tmp/temp.go", "/tmp/old.go")
+
+	cf := mcputil.GetChangedFiles(session.Token)
+	assert.Equal(t, []string{"/tmp/new.go"}, cf.Created, "Created then updated should remain created")
+	assert.Equal(t, []string{"/tmp/existing.go"}, cf.Updated, "Updated files should be listed")
+	assert.Equal(t, []string{"/tmp/old.go"}, cf.Deleted, "Created then deleted should be dropped")
+	assert.Equal(t, 3, cf.Count(), "Count should include all groups")
+
+	found := mcputil.ClearSession(session.Token)
+	require.True(t, found, "Session should be found")
+	assert.Equal(t, 0, mcputil.GetChangedFiles(session.Token).Count(), "Changed files should be cleared with the session")
+}
+
+func TestSessions_FileLocks(t *testing.T) {
+	owner := mcputil.NewSession()
+	err := owner.Initialize()
+	require.NoError(t, err, "Failed to create owner session")
+	other := mcputil.NewSession()
+	err = other.Initialize()
+	require.NoError(t, err, "Failed to create other session")
+	defer mcputil.ClearSession(other.Token)
+
+	lock, err := mcputil.LockFile(owner.Token, "/tmp/locked-dir", time.Hour)
+	require.NoError(t, err, "Owner should acquire lock")
+	assert.False(t, lock.ExpiresAt.After(owner.ExpiresAt), "Lock should not outlive its session")
+
+	_, locked := mcputil.LockedByOtherSession(other.Token, "/tmp/locked-dir/file.go")
+	assert.True(t, locked, "Lock on a directory should cover files beneath it")
+	_, locked = mcputil.LockedByOtherSession(owner.Token, "/tmp/locked-dir/file.go")
+	assert.False(t, locked, "A session should not conflict with its own lock")
+	_, locked = mcputil.LockedByOtherSession(other.Token, "/tmp/locked-dir-sibling")
+	assert.False(t, locked, "Sibling paths sharing a prefix should not conflict")
+
+	_, err = mcputil.LockFile(other.Token, "/tmp/locked-dir/file.go", 0)
+	assert.ErrorIs(t, err, mcputil.ErrFileLocked, "Other session should not lock beneath a locked directory")
+
+	found := mcputil.ClearSession(owner.Token)
+	require.True(t, found, "Session should be found")
+	_, locked = mcputil.LockedByOtherSession(other.Token, "/tmp/locked-dir/file.go")
+	assert.False(t, locked, "Locks should be released with the session")
+}
+
+func TestSessions_PersistedTokens(t *testing.T) {
+	dir := t.TempDir()
+	store := scoutcfg.NewFileStore("test-app")
+	store.SetBaseDir(dir)
+	require.NoError(t, mcputil.SetSessionStore(store), "Should set session store")
+	t.Cleanup(func() {
+		_ = mcputil.SetSessionStore(nil)
+	})
+
+	session := mcputil.NewSession()
+	err := session.Initialize()
+	require.NoError(t, err, "Failed to create session")
+
+	files, err := filepath.Glob(filepath.Join(dir, "tokens", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1, "Token should be persisted")
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Token file should only be readable by its owner")
+
+	expired := mcputil.Session{
+		Token:     "expired-token",
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+		ExpiresAt: time.Now().Add(-24 * time.Hour),
+	}
+	require.NoError(t, store.Save("tokens/expired.json", &expired))
+
+	// Keep an edit backup for the expired session to check it is pruned too
+	require.NoError(t, mcputil.SetEditBackups(store, 0), "Should enable edit backups")
+	t.Cleanup(func() {
+		_ = mcputil.SetEditBackups(nil, 0)
+	})
+	expiredBackups := filepath.Join(dir, "edit-backups", mcputil.SessionID(expired.Token))
+	require.NoError(t, os.MkdirAll(expiredBackups, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(expiredBackups, "00000001.json"), []byte("{}"), 0600))
+
+	// Simulate a restart by dropping the sessions held in memory
+	require.NoError(t, mcputil.SetSessionStore(nil))
+	require.NoError(t, mcputil.ClearSessions(mcputil.AllSessions))
+	require.NoError(t, mcputil.SetSessionStore(store), "Should reload session store")
+
+	err = mcputil.ValidateSession(session.Token)
+	assert.NoError(t, err, "Persisted token should survive a restart")
+	assert.NoFileExists(t, filepath.Join(dir, "tokens", "expired.json"), "Expired token should be pruned on load")
+	assert.NoDirExists(t, expiredBackups, "Edit backups of an expired session should be pruned on load")
+	err = mcputil.ValidateSession(expired.Token)
+	assert.ErrorIs(t, err, mcputil.ErrTokenNotFound, "Expired token should not be valid")
+
+	found := mcputil.ClearSession(session.Token)
+	require.True(t, found, "Session should be found")
+	assert.NoFileExists(t, files[0], "Token file should be deleted with the session")
+	err = mcputil.ValidateSession(session.Token)
+	assert.ErrorIs(t, err, mcputil.ErrTokenNotFound, "Cleared token should not be valid")
+}
+
+func TestSessions_ClearAllSessionsRemovesPersistedTokens(t *testing.T) {
+	dir := t.TempDir()
+	store := scoutcfg.NewFileStore("test-app")
+	store.SetBaseDir(dir)
+	require.NoError(t, mcputil.SetSessionStore(store), "Should set session store")
+	t.Cleanup(func() {
+		_ = mcputil.SetSessionStore(nil)
+	})
+
+	for range 2 {
+		session := mcputil.NewSession()
+		require.NoError(t, session.Initialize(), "Failed to create session")
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "tokens", "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "Tokens should be persisted")
+
+	require.NoError(t, mcputil.ClearSessions(mcputil.AllSessions))
+
+	files, err = filepath.Glob(filepath.Join(dir, "tokens", "*.json"))
+	require.NoError(t, err)
+	assert.Empty(t, files, "Token files should be deleted with the sessions")
+
+	// A restart should find no sessions to reload
+	require.NoError(t, mcputil.SetSessionStore(store), "Should reload session store")
+	assert.Equal(t, 0, mcputil.GetSessionCount(), "Cleared sessions should not be reloaded")
+}
+
+func TestSessions_SetSessionTTL(t *testing.T) {
+	t.Cleanup(func() {
+		_ = mcputil.SetSessionTTL(0, 0)
+	})
+
+	require.NoError(t, mcputil.SetSessionTTL(0, 0), "Zero should select the defaults")
+	assert.Equal(t, mcputil.DefaultSessionTTL, mcputil.SessionTTL())
+	assert.Equal(t, mcputil.DefaultMaxSessionTTL, mcputil.MaxSessionTTL())
+
+	require.NoError(t, mcputil.SetSessionTTL(time.Hour, 2*time.Hour), "Should set session TTL")
+	session := mcputil.NewSession()
+	require.NoError(t, session.Initialize(), "Failed to create session")
+	assert.WithinDuration(t, session.CreatedAt.Add(time.Hour), session.ExpiresAt, time.Second, "Session should expire after the configured TTL")
+
+	err := mcputil.SetSessionTTL(3*time.Hour, 2*time.Hour)
+	assert.ErrorContains(t, err, "exceeds max_session_ttl_minutes", "TTL over the maximum should error")
+	err = mcputil.SetSessionTTL(-time.Hour, 0)
+	assert.ErrorContains(t, err, "must not be negative", "Negative TTL should error")
+
+	err = mcputil.NewSession().InitializeWithTTL(3 * time.Hour)
+	assert.ErrorIs(t, err, mcputil.ErrInvalidSessionTTL, "Session TTL over the maximum should error")
+}
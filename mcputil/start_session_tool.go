@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // payloadTypes contains a registry of payload types; we pick 3 as most we'll need
@@ -54,7 +55,10 @@ func NewStartSessionTool(p Payload) *StartSessionTool {
 		ToolBase: NewToolBase(ToolOptions{
 			Name:        "start_session",
 			Description: "Start an MCP session and get comprehensive instructions for the MCP server effectively",
-			Properties:  []Property{},
+			Properties: []Property{
+				SessionTTLMinutesProperty,
+				ReadOnlyProperty,
+			},
 		}),
 	}
 }
@@ -66,7 +70,7 @@ type StartSessionTool struct {
 
 var instructions = `🎯 MCP Session Started Successfully!
 
-Your session token is valid for 24 hours and will be REQUIRED for all subsequent tool calls.
+Your session token is valid until token_expires_at and will be REQUIRED for all subsequent tool calls.
 
 ON ANY SCOUT MCP TOOL ERROR
 	1. You MUST IMMEDIATELY STOP and report the error. Provide the user with:
@@ -78,7 +82,8 @@ ON ANY SCOUT MCP TOOL ERROR
 
 IMPORTANT INSTRUCTIONS:
 1. **Session Token Required**: All tools (except start_session) require session_token parameter
-2. **Token Expiration**: Tokens expire after 24 hours or when server restarts
+2. **Token Expiration**: Tokens expire at token_expires_at, 24 hours after start_session unless ttl_minutes or the server config says otherwise, and survive server restarts; call refresh_session to extend a token without losing session state
+3. **Read-Only Sessions**: Sessions started with read_only fail any tool call that would modify files with "session is read-only"; dry_run previews and calls that only check, such as fix set to false, still work
 
 `
 
@@ -92,12 +97,28 @@ func (t *StartSessionTool) EnsurePreconditions(context.Context, ToolRequest) (er
 func (t *StartSessionTool) Handle(_ context.Context, tr ToolRequest) (result ToolResult, err error) {
 	var response StartSessionResult
 	var ptn string
+	var session *Session
+	var ttl time.Duration
+	var readOnly bool
 
 	logger.Info("Tool called", "tool", "start_session")
 
+	ttl, err = SessionTTLArg(tr)
+	if err != nil {
+		goto end
+	}
+
+	readOnly, err = ReadOnlyProperty.Bool(tr)
+	if err != nil {
+		goto end
+	}
+
+	logger.Info("Tool arguments parsed", "tool", "start_session", "ttl", ttl, "read_only", readOnly)
+
 	// Create new session
-	session := NewSession()
-	err = session.Initialize()
+	session = NewSession()
+	session.ReadOnly = readOnly
+	err = session.InitializeWithTTL(ttl)
 	if err != nil {
 		result = NewToolResultError(fmt.Errorf("failed to create session: %v", err))
 		goto end
@@ -116,6 +137,7 @@ func (t *StartSessionTool) Handle(_ context.Context, tr ToolRequest) (result Too
 	response = StartSessionResult{
 		SessionToken:    session.Token,
 		TokenExpiresAt:  session.ExpiresAt,
+		ReadOnly:        session.ReadOnly,
 		Instructions:    instructions,
 		PayloadTypeName: ptn,
 		Payload:         t.Payload,
@@ -133,6 +155,34 @@ end:
 	return result, err
 }
 
+// SessionTTLArg returns the session TTL requested with the ttl_minutes
+// argument of req, validated with ValidateSessionTTL, or SessionTTL when none
+// is given.
+func SessionTTLArg(req ToolRequest) (ttl time.Duration, err error) {
+	var ttlMinutes int
+	var requested bool
+
+	ttl = SessionTTL()
+	_, requested = req.CallToolRequest().GetArguments()[SessionTTLMinutesProperty.GetName()]
+	if !requested {
+		goto end
+	}
+
+	ttlMinutes, err = SessionTTLMinutesProperty.Int(req)
+	if err != nil {
+		goto end
+	}
+	ttl = time.Duration(ttlMinutes) * time.Minute
+	err = ValidateSessionTTL(ttl)
+	if err != nil {
+		err = fmt.Errorf("ttl_minutes %d is not allowed: %w", ttlMinutes, err)
+		goto end
+	}
+
+end:
+	return ttl, err
+}
+
 // generateQuickStartList creates a list of essential tools with their quick help descriptions
 func (t *StartSessionTool) generateQuickStartList() []string {
 	var tools []Tool
@@ -19,6 +19,7 @@ type startSessionResultOpts struct {
 	ShouldHaveToken       bool
 	ShouldHaveMessage     bool
 	TokenExpiresIn24Hours bool
+	TokenExpiresIn        time.Duration
 }
 
 func requireStartSessionResult(t *testing.T, result *mcputil.StartSessionResult, err error, opts startSessionResultOpts) {
@@ -61,6 +62,10 @@ func requireStartSessionResult(t *testing.T, result *mcputil.StartSessionResult,
 		assert.True(t, result.TokenExpiresAt.After(expectedExpiry.Add(-tolerance)), "Token should expire within 24 hours - tolerance")
 	}
 
+	if opts.TokenExpiresIn != 0 {
+		assert.WithinDuration(t, time.Now().Add(opts.TokenExpiresIn), result.TokenExpiresAt, time.Minute, "Token should expire after the requested TTL")
+	}
+
 }
 
 func TestStartSessionTool(t *testing.T) {
@@ -145,4 +150,95 @@ func TestStartSessionTool(t *testing.T) {
 		})
 
 	})
+	t.Run("RequestedTTL_ShouldSetTokenExpiration", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"ttl_minutes": 90,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.StartSessionResult](
+			mcputil.CallResult(mcputil.CallTool(tool, req)),
+			"Should not error creating session with a TTL",
+		)
+
+		requireStartSessionResult(t, result, err, startSessionResultOpts{
+			ShouldHaveToken: true,
+			TokenExpiresIn:  90 * time.Minute,
+		})
+	})
+
+	t.Run("ConfiguredTTL_ShouldBeDefault", func(t *testing.T) {
+		require.NoError(t, mcputil.SetSessionTTL(2*time.Hour, 4*time.Hour), "Should set session TTL")
+		t.Cleanup(func() {
+			_ = mcputil.SetSessionTTL(0, 0)
+		})
+
+		req := mcputil.NewMockRequest(mcputil.Params{})
+
+		result, err := mcputil.GetToolResult[mcputil.StartSessionResult](
+			mcputil.CallResult(mcputil.CallTool(tool, req)),
+			"Should not error creating session",
+		)
+
+		requireStartSessionResult(t, result, err, startSessionResultOpts{
+			ShouldHaveToken: true,
+			TokenExpiresIn:  2 * time.Hour,
+		})
+	})
+
+	t.Run("NonPositiveTTL_ShouldError", func(t *testing.T) {
+		for _, minutes := range []int{0, -5} {
+			req := mcputil.NewMockRequest(mcputil.Params{
+				"ttl_minutes": minutes,
+			})
+
+			result, err := mcputil.GetToolResult[mcputil.StartSessionResult](
+				mcputil.CallResult(mcputil.CallTool(tool, req)),
+				"Should error creating session with a non-positive TTL",
+			)
+
+			requireStartSessionResult(t, result, err, startSessionResultOpts{
+				ExpectError:      true,
+				ExpectedErrorMsg: "must be positive",
+			})
+		}
+	})
+
+	t.Run("TTLOverMaximum_ShouldError", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"ttl_minutes": int(mcputil.MaxSessionTTL()/time.Minute) + 1,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.StartSessionResult](
+			mcputil.CallResult(mcputil.CallTool(tool, req)),
+			"Should error creating session with a TTL over the maximum",
+		)
+
+		requireStartSessionResult(t, result, err, startSessionResultOpts{
+			ExpectError:      true,
+			ExpectedErrorMsg: "exceeds the maximum",
+		})
+	})
+	t.Run("ReadOnly_ShouldMarkSession", func(t *testing.T) {
+		req := mcputil.NewMockRequest(mcputil.Params{
+			"read_only": true,
+		})
+
+		result, err := mcputil.GetToolResult[mcputil.StartSessionResult](
+			mcputil.CallResult(mcputil.CallTool(tool, req)),
+			"Should not error creating read-only session",
+		)
+
+		requireStartSessionResult(t, result, err, startSessionResultOpts{
+			ShouldHaveToken: true,
+		})
+		assert.True(t, result.ReadOnly, "Result should report the session is read-only")
+		session, exists := mcputil.GetSession(result.SessionToken)
+		require.True(t, exists, "Session should exist")
+		assert.True(t, session.ReadOnly, "Session should be read-only")
+
+		session, err = mcputil.RefreshSession(result.SessionToken, time.Hour, true)
+		require.NoError(t, err, "Should refresh session")
+		assert.True(t, session.ReadOnly, "Refreshed session should stay read-only")
+		mcputil.ClearSession(session.Token)
+	})
 }
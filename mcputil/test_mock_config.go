@@ -8,14 +8,16 @@ import (
 // It provides a simplified configuration that allows specified paths
 // and returns default values for other configuration settings.
 type MockConfig struct {
-	allowedPaths []string // Paths that tools are allowed to access
+	allowedPaths []string                // Paths that tools are allowed to access
+	sources      map[string]ConfigSource // Sources reported for settings, by key
 }
 
 // MockConfigArgs contains the arguments for creating a MockConfig instance.
 // This struct allows tests to specify which paths should be allowed
 // for file operations during testing.
 type MockConfigArgs struct {
-	AllowedPaths []string // List of paths that should be allowed for testing
+	AllowedPaths []string                // List of paths that should be allowed for testing
+	Sources      map[string]ConfigSource // Sources to report for settings; others report DefaultConfigSource
 }
 
 // NewMockConfig creates a mock config with specified allowed paths.
@@ -23,6 +25,7 @@ type MockConfigArgs struct {
 func NewMockConfig(args MockConfigArgs) Config {
 	return &MockConfig{
 		allowedPaths: args.AllowedPaths,
+		sources:      args.Sources,
 	}
 }
 
@@ -84,3 +87,21 @@ func (m *MockConfig) ToMap() (map[string]any, error) {
 		"allowedOrigins": m.AllowedOrigins(),
 	}, nil
 }
+
+// Source returns the source given for key in MockConfigArgs.Sources, or
+// DefaultConfigSource. This method implements the SourcedConfig interface
+// for testing purposes.
+func (m *MockConfig) Source(key string) ConfigSource {
+	source, ok := m.sources[key]
+	if !ok {
+		source = DefaultConfigSource
+	}
+	return source
+}
+
+// PathSource returns the source of the "allowed_paths" setting for every
+// path. This method implements the SourcedConfig interface for testing
+// purposes.
+func (m *MockConfig) PathSource(string) ConfigSource {
+	return m.Source("allowed_paths")
+}
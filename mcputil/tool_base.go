@@ -3,6 +3,7 @@ package mcputil
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 )
 
@@ -15,8 +16,13 @@ type ToolBase struct {
 
 // NewToolBase creates a new ToolBase instance with the specified options.
 // This constructor initializes the tool with common functionality for MCP tools.
+// Previewable tools automatically get the dry_run property and are mutating.
 func NewToolBase(options ToolOptions) *ToolBase {
 	options.Name = strings.ToLower(options.Name)
+	if options.Previewable {
+		options.Properties = append(options.Properties, DryRunProperty)
+		options.Mutating = true
+	}
 	return &ToolBase{
 		options: options,
 	}
@@ -117,6 +123,7 @@ func (b *ToolBase) EnsurePreconditions(ctx context.Context, req ToolRequest) (er
 	}
 	err = ValidateSession(sessionToken)
 	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidSessionToken, err)
 		goto end
 	}
 
@@ -7,6 +7,10 @@ var (
 
 // Common property definitions used across multiple MCP tools.
 var (
-	ToolProperty         = String("tool", "Tool name for help documentation")
-	SessionTokenProperty = String("session_token", "Session token from start_session")
+	ToolProperty              = String("tool", "Tool name for help documentation")
+	SessionTokenProperty      = String("session_token", "Session token from start_session")
+	DryRunProperty            = Bool("dry_run", "Report what would change without modifying any files")
+	ConfirmationTokenProperty = String("confirmation_token", "Token from request_confirmation authorizing this operation when safe mode is enabled")
+	ReadOnlyProperty          = Bool("read_only", "Start a read-only session, in which tools that modify files fail with 'session is read-only' while tools that only read, dry_run previews and check-only calls still work")
+	SessionTTLMinutesProperty = Number("ttl_minutes", "Minutes until the session token expires, up to the server's maximum (default: the server's session TTL, normally 24 hours)")
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ToolHandler is the function signature for tool handlers
@@ -39,8 +40,26 @@ type ToolOptions struct {
 	Name        string
 	Description string
 	Properties  []Property
-	Requires    []Requirement // Complex parameter requirements
-	QuickHelp   string        // Short description for quick help list (empty = not included)
+	Requires    []Requirement  // Complex parameter requirements
+	QuickHelp   string         // Short description for quick help list (empty = not included)
+	Previewable bool           // Adds dry_run, which previews file changes instead of writing them
+	Mutating    bool           // Modifies files, so read-only sessions cannot call it; implied by Previewable
+	ReportOnly  ReportOnlyFunc // Identifies requests to a Mutating tool that only report, which read-only sessions may make
+}
+
+// ReportOnlyFunc reports whether a request to a Mutating tool only reports
+// and writes nothing, such as a request to check rather than fix. Requests
+// with dry_run set are report-only for every Previewable tool without one.
+type ReportOnlyFunc func(ToolRequest) bool
+
+// WhenBool returns a ReportOnlyFunc for requests whose boolean property p is
+// value, such as a fix property that is false. Requests for which p cannot
+// be read are not report-only.
+func WhenBool(p Property, value bool) ReportOnlyFunc {
+	return func(req ToolRequest) bool {
+		b, err := p.Bool(req)
+		return err == nil && b == value
+	}
 }
 
 // Requirement interface for declarative parameter requirements
@@ -72,6 +91,19 @@ func NewToolResultJSON(data any) ToolResult {
 	return &jsonResult{json: string(jsonData)}
 }
 
+// NewToolResultNDJSON creates a newline-delimited JSON result for a tool call.
+// Each item is serialized as a JSON object on its own line, so clients can
+// process large result sets incrementally instead of parsing a single array.
+func NewToolResultNDJSON[T any](items []T) ToolResult {
+	var sb strings.Builder
+	for _, item := range items {
+		jsonData, _ := json.Marshal(item)
+		sb.Write(jsonData)
+		sb.WriteByte('\n')
+	}
+	return &jsonResult{json: sb.String()}
+}
+
 // ToolResult implements the ToolResult interface marker method.
 func (*jsonResult) ToolResult() {}
 
@@ -14,8 +14,11 @@ import (
 	"github.com/mikeschinkel/scout-mcp/cliutil"
 	"github.com/mikeschinkel/scout-mcp/langutil"
 	"github.com/mikeschinkel/scout-mcp/langutil/golang"
+	"github.com/mikeschinkel/scout-mcp/langutil/javascript"
+	"github.com/mikeschinkel/scout-mcp/langutil/python"
 	"github.com/mikeschinkel/scout-mcp/mcptools"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 )
 
 // ConfigProvider provides access to CLI commands and configuration
@@ -137,9 +140,9 @@ func CreateJSONLogger() (logger *slog.Logger, err error) {
 	}
 	defer mustClose(logFile)
 
-	logger = slog.New(slog.NewJSONHandler(logFile, &slog.HandlerOptions{
+	logger = slog.New(mcptools.NewRedactingHandler(slog.NewJSONHandler(logFile, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	}))
+	})))
 
 	logger.Info("Logger initialized", "log_file", logFilePath)
 
@@ -159,6 +162,9 @@ func initializeLoggers(logger *slog.Logger) {
 	cliutil.SetLogger(logger)
 	langutil.SetLogger(logger)
 	golang.SetLogger(logger)
+	javascript.SetLogger(logger)
+	python.SetLogger(logger)
+	scoutcfg.SetLogger(logger)
 }
 
 func ShowUsageError(err error) {
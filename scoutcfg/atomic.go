@@ -0,0 +1,116 @@
+package scoutcfg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+)
+
+var (
+	// ErrCreateTempFile is returned, wrapping the cause, when WriteFileAtomic
+	// cannot create its temporary file. The target file has not been touched.
+	ErrCreateTempFile = errors.New("cannot create temporary file")
+
+	// ErrRenameTempFile is returned, wrapping the cause, when WriteFileAtomic
+	// has written its temporary file but cannot rename it over the target.
+	// The temporary file is removed and the target left as it was.
+	ErrRenameTempFile = errors.New("cannot rename temporary file into place")
+)
+
+// WriteFileAtomic writes data to the named file so that readers only ever
+// observe either the previous contents or the complete new contents, never
+// a partially written file. The data is written and synced to a temporary
+// file in the same directory, which is then renamed over the target.
+//
+// When the target already exists its permissions are preserved; otherwise
+// the new file is created with perm. The temporary file is removed if any
+// step fails.
+//
+// Parameters:
+//   - filename: The full path of the file to write. Its directory must exist.
+//   - data: The complete new contents of the file.
+//   - perm: The permissions to use when creating a new file.
+//
+// Returns an error if the temporary file cannot be created, written, synced
+// or closed, or if the rename fails. Failures to create the temporary file
+// wrap ErrCreateTempFile and failures to rename it wrap ErrRenameTempFile,
+// so callers can tell them apart with errors.Is.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	return writeFileAtomic(NewOSFS(filepath.Dir(filename)), filepath.Base(filename), data, perm)
+}
+
+// writeFileAtomic implements WriteFileAtomic for the named file in fsys.
+func writeFileAtomic(fsys FS, name string, data []byte, perm fs.FileMode) (err error) {
+	var tmp File
+	var tmpName string
+	var info fs.FileInfo
+
+	info, err = fsys.Stat(name)
+	if err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, tmpName, err = createTemp(fsys, name)
+	if err != nil {
+		err = fmt.Errorf("%w for %s: %w", ErrCreateTempFile, name, err)
+		goto end
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = fsys.Remove(tmpName)
+		}
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		goto end
+	}
+
+	err = tmp.Chmod(perm)
+	if err != nil {
+		goto end
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		goto end
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		goto end
+	}
+
+	err = fsys.Rename(tmpName, name)
+	if err != nil {
+		err = fmt.Errorf("%w %s: %w", ErrRenameTempFile, name, err)
+	}
+
+end:
+	return err
+}
+
+// createTemp creates a new temporary file beside the named file in fsys, as
+// os.CreateTemp would with the pattern ".<base>.tmp-*", and returns it open
+// for writing along with its name.
+func createTemp(fsys FS, name string) (file File, tmpName string, err error) {
+	var prefix string
+
+	prefix = path.Join(path.Dir(name), "."+path.Base(name)+".tmp-")
+	for range 10000 {
+		tmpName = prefix + strconv.FormatUint(uint64(rand.Uint32()), 10)
+		file, err = fsys.OpenFile(tmpName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if !errors.Is(err, fs.ErrExist) {
+			goto end
+		}
+	}
+
+end:
+	return file, tmpName, err
+}
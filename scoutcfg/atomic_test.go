@@ -0,0 +1,71 @@
+package scoutcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteFileAtomic verifies that a new file is created with the requested
+// permissions, that replacing an existing file preserves its permissions,
+// and that no temporary files are left behind.
+func TestWriteFileAtomic(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "config.json")
+
+	err = scoutcfg.WriteFileAtomic(fp, []byte(`{"a":1}`), 0600)
+	require.NoError(t, err)
+	content, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(content))
+	info, err := os.Stat(fp)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	require.NoError(t, os.Chmod(fp, 0640))
+	err = scoutcfg.WriteFileAtomic(fp, []byte(`{"a":2}`), 0600)
+	require.NoError(t, err)
+	content, err = os.ReadFile(fp)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":2}`, string(content))
+	info, err = os.Stat(fp)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm(), "Existing permissions should be preserved")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "No temporary files should remain")
+}
+
+// TestWriteFileAtomic_Errors verifies that failing to create the temporary
+// file and failing to rename it over the target are reported as distinct
+// errors, and that a failed rename leaves no temporary file behind.
+func TestWriteFileAtomic_Errors(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+
+	err = scoutcfg.WriteFileAtomic(filepath.Join(dir, "missing", "config.json"), []byte(`{}`), 0644)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scoutcfg.ErrCreateTempFile)
+	assert.ErrorIs(t, err, os.ErrNotExist, "The cause should be wrapped")
+
+	// Renaming a file over a non-empty directory fails
+	target := filepath.Join(dir, "config.json")
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "child"), 0755))
+	err = scoutcfg.WriteFileAtomic(target, []byte(`{}`), 0644)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scoutcfg.ErrRenameTempFile)
+	assert.NotErrorIs(t, err, scoutcfg.ErrCreateTempFile)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "The temporary file should be removed")
+	assert.DirExists(t, filepath.Join(target, "child"), "The target should be left intact")
+}
@@ -0,0 +1,151 @@
+package scoutcfg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BackupTimestampFormat is the layout of the timestamp in the names of
+// rotating backups, such as config.json.20260102T150405.000000000Z.bak. It is
+// fixed-width UTC, so backups sort by name in the order they were made.
+const BackupTimestampFormat = "20060102T150405.000000000Z"
+
+// ErrBackupFile is returned, wrapping the cause, when Save cannot back up or
+// prune the backups of the file it is about to replace. The file has not
+// been touched.
+var ErrBackupFile = errors.New("cannot back up file")
+
+// BackupPolicy controls whether Save keeps the previous version of a file it
+// overwrites, and how many previous versions to retain.
+//
+// With Generations set to 1 the previous version is kept as <file>.bak, which
+// each Save replaces. With a larger value each Save keeps the previous version
+// as <file>.<timestamp>.bak, named using BackupTimestampFormat, and the oldest
+// of these are removed so that at most Generations remain. The zero value
+// makes no backups.
+type BackupPolicy struct {
+	Generations int // Number of previous versions to keep; 0 disables backups
+}
+
+// enabled reports whether the policy makes backups.
+func (p BackupPolicy) enabled() bool {
+	return p.Generations > 0
+}
+
+// backupFile copies the current contents of the named file in fsys, if it
+// exists, to a backup as directed by policy, then prunes rotating backups
+// beyond the retention count. The backup is written atomically, as by
+// WriteFileAtomic, before the file itself is replaced, so a failed write can
+// never destroy both copies.
+//
+// Parameters:
+//   - fsys: The filesystem holding the file.
+//   - name: The name within fsys of the file about to be overwritten.
+//   - policy: The backup policy to apply. Must be enabled.
+//   - now: The time used to name a rotating backup.
+//
+// Returns an error wrapping ErrBackupFile if the file cannot be read, the
+// backup cannot be written or an old backup cannot be removed. A file that
+// does not exist yet needs no backup and is not an error.
+func backupFile(fsys FS, name string, policy BackupPolicy, now time.Time) (err error) {
+	var content []byte
+	var info fs.FileInfo
+	var backup string
+
+	info, err = fsys.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+		goto end
+	}
+	if err != nil {
+		goto end
+	}
+
+	content, err = readFile(fsys, name)
+	if err != nil {
+		goto end
+	}
+
+	backup = name + ".bak"
+	if policy.Generations > 1 {
+		backup = name + "." + now.UTC().Format(BackupTimestampFormat) + ".bak"
+	}
+
+	err = writeFileAtomic(fsys, backup, content, info.Mode().Perm())
+	if err != nil {
+		goto end
+	}
+
+	if policy.Generations > 1 {
+		err = pruneBackups(fsys, name, policy.Generations)
+	}
+
+end:
+	if err != nil {
+		err = fmt.Errorf("%w %s: %w", ErrBackupFile, name, err)
+	}
+	return err
+}
+
+// pruneBackups removes the oldest rotating backups of the named file so that
+// at most keep remain. Files beside it that merely resemble a backup, such as
+// config.json.old.bak, are left alone.
+func pruneBackups(fsys FS, name string, keep int) (err error) {
+	var backups []string
+
+	backups, err = listBackups(fsys, name)
+	if err != nil {
+		goto end
+	}
+
+	for len(backups) > keep {
+		err = fsys.Remove(backups[0])
+		if err != nil {
+			goto end
+		}
+		backups = backups[1:]
+	}
+
+end:
+	return err
+}
+
+// listBackups returns the names within fsys of the rotating backups of the
+// named file, oldest first.
+func listBackups(fsys FS, name string) (backups []string, err error) {
+	var entries []fs.DirEntry
+	var prefix, stamp string
+	var ok bool
+
+	entries, err = fsys.ReadDir(path.Dir(name))
+	if err != nil {
+		goto end
+	}
+
+	prefix = path.Base(name) + "."
+	for _, entry := range entries {
+		stamp, ok = strings.CutPrefix(entry.Name(), prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		stamp, ok = strings.CutSuffix(stamp, ".bak")
+		if !ok {
+			continue
+		}
+		_, err = time.Parse(BackupTimestampFormat, stamp)
+		if err != nil {
+			err = nil
+			continue
+		}
+		backups = append(backups, path.Join(path.Dir(name), entry.Name()))
+	}
+	slices.Sort(backups)
+
+end:
+	return backups, err
+}
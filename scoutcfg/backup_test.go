@@ -0,0 +1,94 @@
+package scoutcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileStore_SaveBackup verifies that with a single-generation policy
+// Save copies the previous contents to <file>.bak, that the first Save of a
+// file makes no backup, and that without a policy no backup is made.
+func TestFileStore_SaveBackup(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.Save("plain.json", testData{Name: "first"}))
+	require.NoError(t, s.Save("plain.json", testData{Name: "second"}))
+	assert.NoFileExists(t, filepath.Join(dir, "plain.json.bak"), "No backup without a policy")
+
+	s.SetBackupPolicy(scoutcfg.BackupPolicy{Generations: 1})
+
+	require.NoError(t, s.Save("config.json", testData{Name: "first", Age: 1}))
+	assert.NoFileExists(t, filepath.Join(dir, "config.json.bak"), "A new file has nothing to back up")
+
+	require.NoError(t, s.Save("config.json", testData{Name: "second", Age: 2}))
+	require.NoError(t, s.Save("config.json", testData{Name: "third", Age: 3}))
+
+	var loaded testData
+	require.NoError(t, s.Load("config.json.bak", &loaded))
+	assert.Equal(t, testData{Name: "second", Age: 2}, loaded, "The backup should hold the previous version")
+
+	require.NoError(t, s.Load("config.json", &loaded))
+	assert.Equal(t, testData{Name: "third", Age: 3}, loaded)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "Only the files and a single backup should exist")
+}
+
+// TestFileStore_SaveBackupRotation verifies that with several generations
+// Save keeps timestamped backups, pruning the oldest beyond the retention
+// count while leaving unrelated files alone.
+func TestFileStore_SaveBackupRotation(t *testing.T) {
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+	s.SetBackupPolicy(scoutcfg.BackupPolicy{Generations: 2})
+
+	unrelated := filepath.Join(dir, "config.json.old.bak")
+	require.NoError(t, os.WriteFile(unrelated, []byte(`{}`), 0644))
+
+	for age := 1; age <= 4; age++ {
+		require.NoError(t, s.Save("config.json", testData{Name: "v", Age: age}))
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "config.json.*Z.bak"))
+	require.NoError(t, err)
+	require.Len(t, matches, 2, "Only the newest generations should be kept")
+
+	var loaded testData
+	for i, age := range []int{2, 3} {
+		require.NoError(t, s.Load(filepath.Base(matches[i]), &loaded))
+		assert.Equal(t, age, loaded.Age, "Backups should hold the most recent previous versions")
+	}
+	assert.FileExists(t, unrelated, "Files that are not rotating backups should be kept")
+}
+
+// TestFileStore_SaveBackupFailure verifies that when the backup cannot be
+// written Save fails with ErrBackupFile and leaves the file unchanged.
+func TestFileStore_SaveBackupFailure(t *testing.T) {
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+	s.SetBackupPolicy(scoutcfg.BackupPolicy{Generations: 1})
+
+	require.NoError(t, s.Save("config.json", testData{Name: "original"}))
+
+	// A non-empty directory at the backup path makes the backup fail
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config.json.bak", "child"), 0755))
+	err := s.Save("config.json", testData{Name: "replacement"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scoutcfg.ErrBackupFile)
+
+	content, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "original", "The file should be left unchanged")
+}
@@ -0,0 +1,95 @@
+package scoutcfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Codec serializes the data FileStore saves and loads. Save marshals through
+// the store's codec and Load unmarshals through it, while path validation,
+// atomic writes and backups work the same for every format.
+type Codec interface {
+	// Marshal returns the encoding of v.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes data into the value pointed to by v.
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// JSONCodec encodes JSON indented with two spaces, the FileStore default.
+	// Field names follow json struct tags.
+	JSONCodec Codec = jsonCodec{}
+
+	// YAMLCodec encodes YAML. Field names follow yaml struct tags and
+	// otherwise default to the lowercased Go field name.
+	YAMLCodec Codec = yamlCodec{}
+
+	// TOMLCodec encodes TOML. Field names follow toml struct tags and
+	// otherwise default to the Go field name, matched case-insensitively
+	// when loading. The value saved must be a struct or map, as TOML
+	// documents are tables.
+	TOMLCodec Codec = tomlCodec{}
+)
+
+// CodecForFile returns the codec matching the extension of filename: YAML
+// for .yaml and .yml, TOML for .toml, and JSON for .json and any other
+// extension. Extensions are matched case-insensitively.
+func CodecForFile(filename string) (codec Codec) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		codec = YAMLCodec
+	case ".toml":
+		codec = TOMLCodec
+	default:
+		codec = JSONCodec
+	}
+	return codec
+}
+
+// jsonCodec implements Codec with encoding/json.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// yamlCodec implements Codec with gopkg.in/yaml.v3.
+type yamlCodec struct{}
+
+func (yamlCodec) Marshal(v any) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+func (yamlCodec) Unmarshal(data []byte, v any) error {
+	return yaml.Unmarshal(data, v)
+}
+
+// tomlCodec implements Codec with github.com/BurntSushi/toml.
+type tomlCodec struct{}
+
+func (tomlCodec) Marshal(v any) (data []byte, err error) {
+	var buf bytes.Buffer
+
+	err = toml.NewEncoder(&buf).Encode(v)
+	if err != nil {
+		goto end
+	}
+	data = buf.Bytes()
+
+end:
+	return data, err
+}
+
+func (tomlCodec) Unmarshal(data []byte, v any) error {
+	return toml.Unmarshal(data, v)
+}
@@ -0,0 +1,127 @@
+package scoutcfg_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codecData is a configuration with nested values, tagged for every
+// built-in codec.
+type codecData struct {
+	Name    string            `json:"name" yaml:"name" toml:"name"`
+	Port    int               `json:"port" yaml:"port" toml:"port"`
+	Tags    []string          `json:"tags" yaml:"tags" toml:"tags"`
+	Options map[string]string `json:"options" yaml:"options" toml:"options"`
+}
+
+// TestCodecForFile verifies that codecs are inferred from file extensions,
+// case-insensitively, with JSON as the fallback.
+func TestCodecForFile(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected scoutcfg.Codec
+	}{
+		{"config.json", scoutcfg.JSONCodec},
+		{"config.yaml", scoutcfg.YAMLCodec},
+		{"nested/config.YML", scoutcfg.YAMLCodec},
+		{"config.toml", scoutcfg.TOMLCodec},
+		{"config", scoutcfg.JSONCodec},
+		{"notes.txt", scoutcfg.JSONCodec},
+	}
+	for _, tc := range tests {
+		t.Run(tc.filename, func(t *testing.T) {
+			assert.Equal(t, tc.expected, scoutcfg.CodecForFile(tc.filename))
+		})
+	}
+}
+
+// TestFileStore_SaveLoadFormats verifies that Save and Load round-trip data
+// in the format inferred from each file's extension, and that the files
+// written are in that format.
+func TestFileStore_SaveLoadFormats(t *testing.T) {
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	data := codecData{
+		Name:    "scout",
+		Port:    8080,
+		Tags:    []string{"a", "b"},
+		Options: map[string]string{"mode": "fast"},
+	}
+
+	tests := []struct {
+		filename string
+		contains string
+	}{
+		{"config.json", `"port": 8080`},
+		{"config.yaml", "port: 8080\n"},
+		{"config.yml", "name: scout\n"},
+		{"config.toml", "port = 8080\n"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.filename, func(t *testing.T) {
+			require.NoError(t, s.Save(tc.filename, &data))
+
+			content, err := os.ReadFile(filepath.Join(dir, tc.filename))
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tc.contains)
+
+			var loaded codecData
+			require.NoError(t, s.Load(tc.filename, &loaded))
+			assert.Equal(t, data, loaded)
+		})
+	}
+}
+
+// TestFileStore_SetCodec verifies that an explicit codec overrides the
+// extension, including a custom Codec implementation, and that JSON5
+// leniency applies only to files decoded as JSON.
+func TestFileStore_SetCodec(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	s.SetCodec(scoutcfg.YAMLCodec)
+	require.NoError(t, s.Save("settings.conf", codecData{Name: "yaml", Port: 1}))
+	content, err := os.ReadFile(filepath.Join(dir, "settings.conf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: yaml\n")
+
+	s.SetCodec(upperCodec{})
+	require.NoError(t, s.Save("custom.json", codecData{Name: "custom"}))
+	content, err = os.ReadFile(filepath.Join(dir, "custom.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"NAME":"CUSTOM"`)
+	var loaded codecData
+	require.NoError(t, s.Load("custom.json", &loaded))
+	assert.Equal(t, "custom", loaded.Name)
+
+	s.SetCodec(nil)
+	s.SetJSON5(true)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lenient.yaml"), []byte("name: lenient # comment\nport: 2\n"), 0644))
+	err = s.Load("lenient.yaml", &loaded)
+	require.NoError(t, err, "YAML should not be normalized as JSON5")
+	assert.Equal(t, "lenient", loaded.Name)
+}
+
+// upperCodec is a custom Codec storing JSON in upper case.
+type upperCodec struct{}
+
+func (upperCodec) Marshal(v any) (data []byte, err error) {
+	data, err = json.Marshal(v)
+	return []byte(strings.ToUpper(string(data))), err
+}
+
+func (upperCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal([]byte(strings.ToLower(string(data))), v)
+}
@@ -0,0 +1,131 @@
+package scoutcfg
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergeDefaults deep-merges a loaded configuration over a set of default
+// values and returns the completed configuration. The defaults act as the
+// base: every value present in config wins, and a default is only used where
+// config has no value for its key. This supports first-run configuration
+// creation where users supply only the settings they care about, in the same
+// spirit as falling back to a DefaultConfig() when no file exists.
+//
+// Merge rules:
+//   - Nested objects are merged key by key, recursively
+//   - Arrays and scalar values in config replace the default outright
+//   - A key whose value in config is null is treated as missing
+//
+// Neither input map is modified; nested default objects are copied before
+// being placed in the result.
+//
+// Parameters:
+//   - defaults: The default configuration, typically decoded from JSON.
+//   - config: The loaded, possibly partial, configuration. May be nil.
+//
+// Returns:
+//   - The completed configuration
+//   - The dotted paths (e.g. "server.port") of the defaults that were
+//     applied, sorted. When an entire object was missing only its own path
+//     is reported, not each of its keys.
+func MergeDefaults(defaults, config map[string]any) (merged map[string]any, applied []string) {
+	applied = make([]string, 0)
+	merged = mergeDefaults(defaults, config, "", &applied)
+	sort.Strings(applied)
+	return merged, applied
+}
+
+// mergeDefaults implements MergeDefaults for the object at prefix, recording
+// the paths of applied defaults in applied.
+func mergeDefaults(defaults, config map[string]any, prefix string, applied *[]string) (merged map[string]any) {
+	var path string
+	var value any
+	var ok bool
+	var defObj, cfgObj map[string]any
+	var isDefObj, isCfgObj bool
+
+	merged = make(map[string]any, len(defaults)+len(config))
+	for key, v := range config {
+		if v != nil {
+			merged[key] = v
+		}
+	}
+	for key, def := range defaults {
+		path = key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		value, ok = merged[key]
+		if !ok {
+			merged[key] = copyValue(def)
+			*applied = append(*applied, path)
+			continue
+		}
+		defObj, isDefObj = def.(map[string]any)
+		cfgObj, isCfgObj = value.(map[string]any)
+		if isDefObj && isCfgObj {
+			merged[key] = mergeDefaults(defObj, cfgObj, path, applied)
+		}
+	}
+	return merged
+}
+
+// MissingFields returns those of the required dotted field paths that have
+// no non-null value in config, in the order given. A path such as
+// "server.port" requires every object along it to exist.
+//
+// Parameters:
+//   - config: The configuration to check, typically after MergeDefaults.
+//   - required: Dotted paths of the fields that must be present.
+//
+// Returns the missing paths, or an empty slice if all are present.
+func MissingFields(config map[string]any, required []string) (missing []string) {
+	var obj map[string]any
+	var value any
+	var ok bool
+
+	missing = make([]string, 0)
+	for _, field := range required {
+		obj = config
+		ok = false
+		for _, key := range strings.Split(field, ".") {
+			if obj == nil {
+				ok = false
+				break
+			}
+			value, ok = obj[key]
+			if !ok || value == nil {
+				ok = false
+				break
+			}
+			obj, _ = value.(map[string]any)
+		}
+		if !ok {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// copyValue returns a deep copy of a decoded JSON value so that results
+// of MergeDefaults never share nested objects or arrays with their inputs.
+func copyValue(value any) (copied any) {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[key] = copyValue(item)
+		}
+		copied = m
+	case []any:
+		s := make([]any, len(v))
+		for i, item := range v {
+			s[i] = copyValue(item)
+		}
+		copied = s
+	default:
+		copied = v
+	}
+	return copied
+}
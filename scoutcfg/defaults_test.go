@@ -0,0 +1,83 @@
+package scoutcfg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeJSON is a test helper that decodes a JSON object literal, failing
+// the test if it is invalid.
+func decodeJSON(t *testing.T, content string) (m map[string]any) {
+	t.Helper()
+	require.NoError(t, json.Unmarshal([]byte(content), &m))
+	return m
+}
+
+// TestMergeDefaults verifies that a partial configuration is deep-merged
+// over its defaults, that configured values always win, that null values
+// are treated as missing, and that the applied defaults are reported by
+// their dotted paths without modifying either input.
+func TestMergeDefaults(t *testing.T) {
+	defaults := decodeJSON(t, `{
+  "name": "scout",
+  "port": 8080,
+  "paths": ["/tmp"],
+  "server": {"host": "localhost", "timeout": 30},
+  "logging": {"level": "info"}
+}`)
+	config := decodeJSON(t, `{
+  "name": "custom",
+  "paths": [],
+  "port": null,
+  "server": {"host": "example.com"}
+}`)
+
+	merged, applied := scoutcfg.MergeDefaults(defaults, config)
+
+	assert.Equal(t, decodeJSON(t, `{
+  "name": "custom",
+  "port": 8080,
+  "paths": [],
+  "server": {"host": "example.com", "timeout": 30},
+  "logging": {"level": "info"}
+}`), merged)
+	assert.Equal(t, []string{"logging", "port", "server.timeout"}, applied)
+
+	merged["logging"].(map[string]any)["level"] = "debug"
+	assert.Equal(t, "info", defaults["logging"].(map[string]any)["level"], "Defaults should not share nested objects with the result")
+	_, ok := config["logging"]
+	assert.False(t, ok, "Config should not be modified")
+}
+
+// TestMergeDefaults_NilConfig verifies that a missing configuration is
+// completed entirely from the defaults, as on first run.
+func TestMergeDefaults_NilConfig(t *testing.T) {
+	defaults := decodeJSON(t, `{"a": 1, "b": {"c": true}}`)
+
+	merged, applied := scoutcfg.MergeDefaults(defaults, nil)
+
+	assert.Equal(t, defaults, merged)
+	assert.Equal(t, []string{"a", "b"}, applied)
+}
+
+// TestMissingFields verifies that required dotted paths are reported when
+// absent or null, including when an intermediate object is missing or is
+// not an object.
+func TestMissingFields(t *testing.T) {
+	config := decodeJSON(t, `{"name": "x", "empty": null, "server": {"port": 1}, "flat": "s"}`)
+
+	missing := scoutcfg.MissingFields(config, []string{
+		"name",
+		"empty",
+		"server.port",
+		"server.host",
+		"client.id",
+		"flat.value",
+	})
+
+	assert.Equal(t, []string{"empty", "server.host", "client.id", "flat.value"}, missing)
+}
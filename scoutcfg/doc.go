@@ -49,6 +49,19 @@
 //	│   └── logs/           # Log files and append operations
 //	│       └── activity.log
 //
+// # Serialization Formats
+//
+// Files are JSON by default, but the format Save and Load use is chosen by
+// file extension: config.yaml and config.yml are stored as YAML and
+// config.toml as TOML, through the YAMLCodec and TOMLCodec implementations
+// of the Codec interface. SetCodec forces one codec for every file, and any
+// type with Marshal and Unmarshal methods can be used to add a format. Path
+// validation, atomic writes and backups are the same whatever the format.
+//
+//	store.Save("config.yaml", &config) // written as YAML
+//	store.SetCodec(scoutcfg.TOMLCodec)
+//	store.Save("settings", &config)    // written as TOML
+//
 // # Usage Patterns
 //
 // ## Basic Configuration Management
@@ -101,6 +114,27 @@
 //		require.NoError(t, err)
 //	}
 //
+// ## Testing with an In-Memory Filesystem
+//
+// Every FileStore operation goes through the FS interface, so tests can
+// replace the disk entirely with SetFS. The memfs subpackage provides an
+// in-memory FS that records each change, for asserting on exactly what a
+// store writes:
+//
+//	func TestSaveConfig(t *testing.T) {
+//		mem := memfs.New()
+//		store := scoutcfg.NewFileStore("test-app")
+//		store.SetFS(mem)
+//
+//		err := store.Save("config.json", &MyConfig{Value: "test"})
+//		require.NoError(t, err)
+//		content, err := mem.ReadFile("config.json")
+//		require.NoError(t, err)
+//		for _, op := range mem.Ops() {
+//			t.Log(op) // create, write, chmod and rename of a temporary file
+//		}
+//	}
+//
 // # Error Handling Patterns
 //
 // The package provides specific error types for common scenarios:
@@ -147,9 +181,27 @@
 // The underlying filesystem operations are atomic where supported by the OS,
 // and the package does not maintain mutable state that could cause race conditions.
 //
+// Read-modify-write sequences, such as loading a file, changing it and saving
+// it back, can be serialized across goroutines and separate processes sharing
+// the configuration directory with WithLock, which holds an OS advisory lock
+// (flock on Unix, LockFileEx on Windows) on a lockfile beside the named file:
+//
+//	err := store.WithLock("config.json", func() error {
+//		var config AppConfig
+//		err := store.Load("config.json", &config)
+//		if err != nil {
+//			return err
+//		}
+//		config.Runs++
+//		return store.Save("config.json", &config)
+//	})
+//
+// WithLock gives up with ErrLockTimeout if another holder keeps the lock for
+// longer than the timeout set with SetLockTimeout (DefaultLockTimeout unless
+// changed), so a stuck process cannot block the others forever.
+//
 // However, applications should implement their own synchronization for complex
 // scenarios like:
-//   - Read-modify-write operations across multiple files
 //   - Coordinated updates to related configuration files
 //   - Cache invalidation in multi-instance applications
 //
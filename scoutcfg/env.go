@@ -0,0 +1,169 @@
+package scoutcfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvTag is the struct tag naming the environment variable, less its prefix,
+// that LoadWithEnvOverrides applies to a field.
+const EnvTag = "env"
+
+// ErrInvalidEnvOverride is returned by LoadWithEnvOverrides when a set
+// environment variable cannot be parsed into its field, or tags a field of
+// an unsupported type.
+var ErrInvalidEnvOverride = errors.New("invalid environment override")
+
+// LoadWithEnvOverrides reads the specified file like Load and then lets
+// environment variables override individual fields, so that a deployment can
+// change a setting such as the port without editing the configuration file.
+//
+// Fields opt in with an env struct tag naming the variable without its
+// prefix; the prefix and the tag are joined with an underscore. With the
+// prefix "SCOUT", a field tagged `env:"PORT"` is overridden by SCOUT_PORT.
+// Only variables that are set are applied, so an unset variable leaves the
+// loaded value alone while one set to the empty string clears a string or
+// []string field. Nested structs, and non-nil pointers to structs, are
+// walked as well.
+//
+// Supported field types and their environment formats:
+//   - string: used as is
+//   - int, int8, int16, int32, int64: a base-10 integer
+//   - bool: any value accepted by strconv.ParseBool, such as true, false, 1 or 0
+//   - []string: comma-separated values with surrounding spaces trimmed
+//
+// Parameters:
+//   - filename: The relative path within the configuration directory to read.
+//   - prefix: The prefix of the environment variable names, such as "SCOUT".
+//     A trailing underscore is optional, and an empty prefix uses the tag
+//     names alone.
+//   - data: A pointer to the struct to unmarshal into.
+//
+// Returns an error if:
+//   - data is not a non-nil pointer to a struct
+//   - The file cannot be read or parsed (see Load)
+//   - A set variable cannot be parsed into its field's type, or tags a field
+//     of an unsupported type (wraps ErrInvalidEnvOverride, naming the
+//     variable and the field)
+//
+// Example usage:
+//
+//	type Config struct {
+//		Port  int      `json:"port" env:"PORT"`
+//		Debug bool     `json:"debug" env:"DEBUG"`
+//		Paths []string `json:"paths" env:"PATHS"`
+//	}
+//
+//	var config Config
+//	err := store.LoadWithEnvOverrides("config.json", "SCOUT", &config)
+func (s *FileStore) LoadWithEnvOverrides(filename string, prefix string, data any) (err error) {
+	var rv reflect.Value
+
+	rv = reflect.ValueOf(data)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		err = fmt.Errorf("loading %s with environment overrides: data must be a non-nil pointer to a struct, got %T", filename, data)
+		goto end
+	}
+
+	err = s.Load(filename, data)
+	if err != nil {
+		goto end
+	}
+
+	if prefix != "" {
+		prefix = strings.TrimSuffix(prefix, "_") + "_"
+	}
+	err = applyEnvOverrides(rv.Elem(), prefix)
+
+end:
+	return err
+}
+
+// applyEnvOverrides sets each env-tagged field of the struct rv whose
+// variable, prefix followed by the tag, is set in the environment.
+func applyEnvOverrides(rv reflect.Value, prefix string) (err error) {
+	var field reflect.StructField
+	var fv reflect.Value
+	var name, value string
+	var ok bool
+
+	for i := 0; i < rv.NumField(); i++ {
+		field = rv.Type().Field(i)
+		fv = rv.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok = field.Tag.Lookup(EnvTag)
+		if !ok || name == "" || name == "-" {
+			switch {
+			case fv.Kind() == reflect.Struct:
+				err = applyEnvOverrides(fv, prefix)
+			case fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct:
+				err = applyEnvOverrides(fv.Elem(), prefix)
+			}
+			if err != nil {
+				goto end
+			}
+			continue
+		}
+
+		value, ok = os.LookupEnv(prefix + name)
+		if !ok {
+			continue
+		}
+		err = setEnvField(fv, value)
+		if err != nil {
+			err = fmt.Errorf("%w: %s=%q for field %s.%s: %v",
+				ErrInvalidEnvOverride, prefix+name, value, rv.Type().Name(), field.Name, err)
+			goto end
+		}
+	}
+
+end:
+	return err
+}
+
+// setEnvField parses value into fv according to fv's type.
+func setEnvField(fv reflect.Value, value string) (err error) {
+	var n int64
+	var b bool
+	var items []string
+
+	switch {
+	case fv.Kind() == reflect.String:
+		fv.SetString(value)
+	case fv.Kind() >= reflect.Int && fv.Kind() <= reflect.Int64:
+		n, err = strconv.ParseInt(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			err = fmt.Errorf("not a valid %s", fv.Type())
+			goto end
+		}
+		fv.SetInt(n)
+	case fv.Kind() == reflect.Bool:
+		b, err = strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			err = errors.New("not a valid bool")
+			goto end
+		}
+		fv.SetBool(b)
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		items = make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+		fv.Set(reflect.ValueOf(items).Convert(fv.Type()))
+	default:
+		err = fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+end:
+	return err
+}
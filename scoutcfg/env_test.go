@@ -0,0 +1,102 @@
+package scoutcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envConfig is the configuration overridden in TestFileStore_LoadWithEnvOverrides.
+type envConfig struct {
+	Name   string   `json:"name" env:"NAME"`
+	Port   int      `json:"port" env:"PORT"`
+	Debug  bool     `json:"debug" env:"DEBUG"`
+	Paths  []string `json:"paths" env:"PATHS"`
+	Mode   string   `json:"mode"`
+	Server struct {
+		Host string `json:"host" env:"HOST"`
+	} `json:"server"`
+}
+
+// TestFileStore_LoadWithEnvOverrides verifies that set variables override
+// tagged fields, including nested ones, that unset variables and untagged
+// fields keep their loaded values, and that unparsable values are reported.
+func TestFileStore_LoadWithEnvOverrides(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	content := `{"name": "scout", "port": 8080, "debug": false, "paths": ["a"], "mode": "fast", "server": {"host": "localhost"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(content), 0644))
+
+	t.Run("SetVariables_ShouldOverride", func(t *testing.T) {
+		t.Setenv("SCOUT_PORT", "9090")
+		t.Setenv("SCOUT_DEBUG", "true")
+		t.Setenv("SCOUT_PATHS", " x, y ,z")
+		t.Setenv("SCOUT_HOST", "example.com")
+		t.Setenv("SCOUT_MODE", "safe")
+
+		var config envConfig
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT", &config)
+		require.NoError(t, err)
+		assert.Equal(t, "scout", config.Name, "Unset variable should keep loaded value")
+		assert.Equal(t, 9090, config.Port)
+		assert.True(t, config.Debug)
+		assert.Equal(t, []string{"x", "y", "z"}, config.Paths)
+		assert.Equal(t, "example.com", config.Server.Host, "Nested field should be overridden")
+		assert.Equal(t, "fast", config.Mode, "Untagged field should not be overridden")
+	})
+
+	t.Run("PrefixWithUnderscore_ShouldMatchSameVariables", func(t *testing.T) {
+		t.Setenv("SCOUT_NAME", "")
+
+		var config envConfig
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT_", &config)
+		require.NoError(t, err)
+		assert.Equal(t, "", config.Name, "Empty variable should clear the string")
+	})
+
+	t.Run("InvalidValue_ShouldError", func(t *testing.T) {
+		t.Setenv("SCOUT_PORT", "eighty")
+
+		var config envConfig
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT", &config)
+		require.ErrorIs(t, err, scoutcfg.ErrInvalidEnvOverride)
+		assert.Contains(t, err.Error(), `SCOUT_PORT="eighty"`)
+		assert.Contains(t, err.Error(), "envConfig.Port")
+		assert.Contains(t, err.Error(), "not a valid int")
+	})
+
+	t.Run("InvalidBool_ShouldError", func(t *testing.T) {
+		t.Setenv("SCOUT_DEBUG", "maybe")
+
+		var config envConfig
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT", &config)
+		require.ErrorIs(t, err, scoutcfg.ErrInvalidEnvOverride)
+		assert.Contains(t, err.Error(), "not a valid bool")
+	})
+
+	t.Run("UnsupportedType_ShouldError", func(t *testing.T) {
+		t.Setenv("SCOUT_RATIO", "0.5")
+
+		var config struct {
+			Ratio float64 `json:"ratio" env:"RATIO"`
+		}
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT", &config)
+		require.ErrorIs(t, err, scoutcfg.ErrInvalidEnvOverride)
+		assert.Contains(t, err.Error(), "unsupported field type float64")
+	})
+
+	t.Run("NotStructPointer_ShouldError", func(t *testing.T) {
+		var config envConfig
+		err = s.LoadWithEnvOverrides("config.json", "SCOUT", config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a non-nil pointer to a struct")
+	})
+}
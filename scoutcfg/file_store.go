@@ -6,10 +6,20 @@
 // The package provides a FileStore abstraction that manages configuration
 // persistence with automatic directory creation, path validation, and JSON
 // serialization. It supports common configuration operations including:
-//   - Loading and saving JSON configuration files
-//   - Appending to log files
+//   - Loading and saving JSON configuration files (with opt-in JSON5 loading)
+//   - Storing YAML or TOML instead, by file extension or codec (SetCodec)
+//   - Validating files against a JSON Schema as they load (LoadWithSchema)
+//   - Overriding loaded fields from environment variables (LoadWithEnvOverrides)
+//   - Appending to log files, optionally rotating them by size (AppendWithRotation)
 //   - Checking file existence
+//   - Listing the files in a subdirectory (List)
+//   - Deleting files and subdirectories (Delete, DeleteAll)
 //   - Creating nested directory structures
+//   - Completing partial configurations from defaults (MergeDefaults)
+//   - Writing files atomically (WriteFileAtomic)
+//   - Keeping previous versions of saved files (SetBackupPolicy)
+//   - Serializing read-modify-write sequences across processes (WithLock)
+//   - Substituting an in-memory filesystem in tests (SetFS, memfs)
 //
 // Security considerations:
 //   - All file paths are validated using fs.ValidPath to prevent directory traversal
@@ -33,11 +43,14 @@
 package scoutcfg
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sync"
+	"time"
 )
 
 // ConfigBaseDirName is the standard directory name for configuration files
@@ -60,9 +73,14 @@ const ConfigBaseDirName = ".config"
 // comprehensive error handling for common filesystem scenarios including
 // permission errors, disk space issues, and invalid JSON data.
 type FileStore struct {
-	appName   string // Name of the application used for directory naming
-	configDir string // Cached path to the configuration directory
-	fs        fs.FS  // File system interface for reading files (allows testing)
+	appName     string        // Name of the application used for directory naming
+	configDir   string        // Cached path to the configuration directory
+	fsys        FS            // File system all files are accessed through (allows testing, see SetFS)
+	locks       sync.Map      // In-process locks WithLock takes when fsys is not the OS filesystem
+	json5       bool          // Accept JSON5 syntax when loading (Save still writes strict JSON)
+	backup      BackupPolicy  // Whether and how Save keeps previous versions of files
+	codec       Codec         // Format for Save and Load (nil infers it from the file extension)
+	lockTimeout time.Duration // How long WithLock waits for a held lock (0 means DefaultLockTimeout)
 }
 
 // NewFileStore creates a new FileStore instance for the specified application name.
@@ -95,7 +113,7 @@ func NewFileStore(appName string) *FileStore {
 //
 // If the user's home directory cannot be determined (rare on modern systems),
 // an error is returned. The directory itself is not created by this method;
-// creation happens during save operations via ensureFilepath.
+// creation happens during save operations via ensureDir.
 //
 // Returns:
 //   - The full path to the configuration directory
@@ -120,8 +138,9 @@ end:
 }
 
 // getFS returns the filesystem interface for this FileStore, initializing it
-// on first access. The filesystem is based on the configuration directory
-// and provides a sandboxed view for file operations.
+// on first access. Unless SetFS has been called, the filesystem is the
+// operating system's, rooted at the configuration directory, and provides a
+// sandboxed view for file operations.
 //
 // This method uses lazy initialization to avoid filesystem access during
 // FileStore creation. The filesystem interface allows for easier testing
@@ -132,10 +151,10 @@ end:
 //   - An error if the configuration directory cannot be determined
 //
 // The filesystem interface is cached after first initialization.
-func (s *FileStore) getFS() (_ fs.FS, err error) {
+func (s *FileStore) getFS() (_ FS, err error) {
 	var dir string
 
-	if s.fs != nil {
+	if s.fsys != nil {
 		goto end
 	}
 
@@ -144,15 +163,16 @@ func (s *FileStore) getFS() (_ fs.FS, err error) {
 		goto end
 	}
 
-	s.fs = os.DirFS(dir)
+	s.fsys = NewOSFS(dir)
 
 end:
-	return s.fs, err
+	return s.fsys, err
 }
 
-// ensureFilepath returns the full filesystem path for a given filename and
-// ensures that all parent directories exist. This method is used internally
-// before write operations to guarantee that the target location is accessible.
+// ensureDir validates a filename and ensures that all of its parent
+// directories exist, returning the filesystem to access it through. This
+// method is used internally before write operations to guarantee that the
+// target location is accessible.
 //
 // The method handles nested paths within the configuration directory (e.g.,
 // "tokens/user@domain.com.json") by creating all necessary intermediate
@@ -163,28 +183,40 @@ end:
 //     May include subdirectories separated by forward slashes.
 //
 // Returns:
-//   - The full filesystem path to the file
+//   - The filesystem interface the file is accessed through
 //   - An error if path validation fails or directory creation fails
 //
 // Directory creation is idempotent - existing directories are not modified.
-func (s *FileStore) ensureFilepath(filename string) (fp string, err error) {
-	fp, err = s.getFilepath(filename)
+func (s *FileStore) ensureDir(filename string) (fsys FS, err error) {
+	return s.ensureDirWithPerm(filename, 0755)
+}
+
+// ensureDirWithPerm is ensureDir creating missing directories with
+// permissions perm rather than 0755.
+func (s *FileStore) ensureDirWithPerm(filename string, perm fs.FileMode) (fsys FS, err error) {
+	fsys, err = s.getFSFor(filename)
 	if err != nil {
 		goto end
 	}
 	// Create parent directories as needed for nested paths like tokens/token-bill@microsoft.com.json
-	err = os.MkdirAll(filepath.Dir(fp), 0755)
+	err = fsys.MkdirAll(path.Dir(filename), perm)
 	if err != nil {
 		goto end
 	}
 end:
-	return fp, err
+	return fsys, err
 }
 
-// getFilepath validates a filename and returns the full filesystem path
-// without creating any directories. This method performs security validation
-// to prevent directory traversal attacks and ensures the filename is valid
-// for filesystem use.
+// dirPerm returns the permissions for a directory holding files created with
+// filePerm: filePerm plus search permission for each class it lets read.
+func dirPerm(filePerm fs.FileMode) fs.FileMode {
+	return filePerm | (filePerm&0444)>>2
+}
+
+// getFSFor validates a filename and returns the filesystem to access it
+// through, without creating any directories. This method performs security
+// validation to prevent directory traversal attacks and ensures the filename
+// is valid for filesystem use.
 //
 // Parameters:
 //   - filename: The relative filename within the configuration directory.
@@ -192,97 +224,120 @@ end:
 //     absolute paths, or invalid characters).
 //
 // Returns:
-//   - The full filesystem path to the file
+//   - The filesystem interface the file is accessed through
 //   - An error if the filename is invalid or configuration directory unavailable
 //
-// This method is used internally by both read and write operations to
-// ensure consistent path handling and security validation.
-func (s *FileStore) getFilepath(filename string) (fp string, err error) {
-	var dir string
-
-	dir, err = s.ConfigDir()
-	if err != nil {
-		goto end
-	}
-
+// This method is used internally by write and delete operations to ensure
+// consistent path handling and security validation.
+func (s *FileStore) getFSFor(filename string) (fsys FS, err error) {
 	if !fs.ValidPath(filename) {
-		err = fmt.Errorf("path %s is not valid for use in %s", filename, dir)
+		err = fmt.Errorf("path %s is not valid for use in the configuration directory", filename)
 		goto end
 	}
 
-	fp = filepath.Join(s.configDir, filename)
+	fsys, err = s.getFS()
 
 end:
-	return fp, err
+	return fsys, err
 }
 
-// Save marshals the provided data to JSON and saves it to the specified
-// filename in the configuration directory. The data is serialized with
-// indentation for human readability and the file is written atomically
-// where possible.
+// Save marshals the provided data and saves it to the specified filename in
+// the configuration directory. The data is serialized with the store's codec
+// (see SetCodec), which by default is chosen by the file's extension: YAML
+// for .yaml and .yml, TOML for .toml, and indented JSON otherwise. The file
+// is written atomically using WriteFileAtomic: the encoded data is written
+// and synced to a temporary file
+// beside the target, in the same directory and so on the same filesystem,
+// which is then renamed over it. A failed Save therefore never leaves the
+// file truncated or half-written; the previous contents remain intact and
+// the temporary file is removed.
 //
 // The method automatically creates any necessary parent directories and
-// validates the file path for security. The JSON is formatted with 2-space
+// validates the file path for security. JSON is formatted with 2-space
 // indentation to maintain readability for manual configuration editing.
+// New files are created with permissions 0644; existing files keep theirs.
+//
+// When a backup policy has been set with SetBackupPolicy and the file already
+// exists, its current bytes are copied to a backup before the new write
+// begins, and old backups beyond the retention count are pruned. If the
+// backup fails the file is left untouched.
 //
 // Parameters:
 //   - filename: The relative path within the configuration directory where
 //     the file should be saved. May include subdirectories.
-//   - data: The data structure to serialize to JSON. Must be JSON-serializable.
+//   - data: The data structure to serialize. Must be serializable by the codec.
 //
 // Returns an error if:
-//   - The data cannot be marshaled to JSON
+//   - The data cannot be marshaled by the codec
 //   - The file path is invalid or cannot be created
-//   - File write operations fail due to permissions or disk space
+//   - The temporary file cannot be created (wraps ErrCreateTempFile)
+//   - Writing or syncing the temporary file fails due to permissions or disk space
+//   - The temporary file cannot be renamed over the target (wraps ErrRenameTempFile)
+//   - The existing file cannot be backed up (wraps ErrBackupFile)
 //   - The logger has not been initialized with SetLogger
-//
-// The save operation is atomic at the filesystem level where supported.
 func (s *FileStore) Save(filename string, data any) (err error) {
-	var jsonData []byte
-	var file *os.File
-	var fullPath string
+	return s.SaveWithPerm(filename, data, 0644)
+}
+
+// SaveWithPerm saves data to filename exactly as Save does, except that a new
+// file is created with permissions perm rather than 0644, such as 0600 for
+// files holding secrets that only the owner should read. Missing parent
+// directories are created with perm plus search permission wherever perm
+// grants read permission, so 0700 for 0600. As with Save, an existing file
+// or directory keeps its permissions.
+func (s *FileStore) SaveWithPerm(filename string, data any, perm fs.FileMode) (err error) {
+	var encoded []byte
+	var fsys FS
 
 	ensureLogger()
 
-	jsonData, err = json.MarshalIndent(data, "", "  ")
+	encoded, err = s.codecFor(filename).Marshal(data)
 	if err != nil {
 		goto end
 	}
 
-	fullPath, err = s.ensureFilepath(filename)
+	fsys, err = s.ensureDirWithPerm(filename, dirPerm(perm))
 	if err != nil {
 		goto end
 	}
 
-	file, err = os.Create(fullPath)
-	if err != nil {
-		goto end
+	if s.backup.enabled() {
+		err = backupFile(fsys, filename, s.backup, time.Now())
+		if err != nil {
+			goto end
+		}
 	}
-	defer mustClose(file)
 
-	_, err = file.Write(jsonData)
+	err = writeFileAtomic(fsys, filename, encoded, perm)
 
 end:
 	return err
 }
 
-// Load reads JSON data from the specified filename and unmarshals it into
-// the provided data structure. The file is read from the configuration
-// directory using the filesystem interface.
+// Load reads data from the specified filename and unmarshals it into the
+// provided data structure. The file is read from the configuration
+// directory using the filesystem interface and decoded with the same codec
+// Save would use for it: the one set with SetCodec, or else the one matching
+// the file's extension (see CodecForFile).
 //
-// The method handles JSON parsing errors gracefully and provides clear
-// error messages for common issues like malformed JSON or type mismatches.
+// The method handles parsing errors gracefully and provides clear error
+// messages for common issues like malformed data or type mismatches.
 //
 // Parameters:
 //   - filename: The relative path within the configuration directory to read.
 //     Must be a valid path that exists in the configuration directory.
-//   - data: A pointer to the data structure where the JSON should be unmarshaled.
-//     Must be compatible with the JSON structure in the file.
+//   - data: A pointer to the data structure where the data should be unmarshaled.
+//     Must be compatible with the structure of the data in the file.
+//
+// When JSON5 loading has been enabled with SetJSON5 and the file is decoded
+// as JSON, the file contents are
+// first normalized to strict JSON, allowing comments, trailing commas,
+// single-quoted strings and unquoted keys. See SetJSON5 for details.
 //
 // Returns an error if:
 //   - The file does not exist or cannot be read
-//   - The file contains invalid JSON
-//   - The JSON structure doesn't match the provided data type
+//   - The file contains invalid JSON, YAML or TOML, as the codec expects
+//   - The data structure doesn't match the provided data type
 //   - The configuration directory cannot be accessed
 //
 // Example usage:
@@ -293,20 +348,30 @@ end:
 //		log.Printf("Failed to load config: %v", err)
 //	}
 func (s *FileStore) Load(filename string, data any) (err error) {
-	var jsonData []byte
-	var fsys fs.FS
+	var encoded []byte
+	var codec Codec
+	var fsys FS
 
 	fsys, err = s.getFS()
 	if err != nil {
 		goto end
 	}
 
-	jsonData, err = fs.ReadFile(fsys, filename)
+	encoded, err = readFile(fsys, filename)
 	if err != nil {
 		goto end
 	}
 
-	err = json.Unmarshal(jsonData, data)
+	codec = s.codecFor(filename)
+	if s.json5 && codec == JSONCodec {
+		encoded, err = normalizeJSON5(encoded)
+		if err != nil {
+			err = fmt.Errorf("parsing %s as JSON5: %w", filename, err)
+			goto end
+		}
+	}
+
+	err = codec.Unmarshal(encoded, data)
 
 end:
 	return err
@@ -334,16 +399,19 @@ end:
 //
 // The file is opened with permissions 0644 (readable by owner and group,
 // writable by owner only) when created.
+//
+// Logs appended to indefinitely grow without bound; use AppendWithRotation
+// to archive them once they reach a size limit.
 func (s *FileStore) Append(filename string, content []byte) (err error) {
-	var file *os.File
-	var fullPath string
+	var file File
+	var fsys FS
 
-	fullPath, err = s.ensureFilepath(filename)
+	fsys, err = s.ensureDir(filename)
 	if err != nil {
 		goto end
 	}
 
-	file, err = os.OpenFile(fullPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	file, err = fsys.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		goto end
 	}
@@ -383,13 +451,191 @@ func (s *FileStore) Exists(filename string) (exists bool) {
 	if err != nil {
 		goto end
 	}
-	_, err = fs.Stat(fsys, filename)
+	_, err = fsys.Stat(filename)
 	exists = err == nil
 
 end:
 	return exists
 }
 
+// List returns the base names, sorted, of the regular files directly within
+// the specified subdirectory of the configuration directory, such as the
+// per-user token files in tokens/. Subdirectories, symbolic links and other
+// special files are skipped, and subdirectories are not descended into.
+//
+// The directory is validated with fs.ValidPath and resolved under the
+// configuration directory exactly as for Load, so nothing outside it can be
+// listed. Use "." to list the configuration directory itself.
+//
+// Parameters:
+//   - dir: The relative path within the configuration directory to list.
+//
+// Returns an empty slice, not an error, when the directory does not exist
+// yet. Returns an error if:
+//   - The path is invalid or escapes the configuration directory
+//   - The path exists but is not a directory, or cannot be read
+//
+// Example usage:
+//
+//	names, err := store.List("tokens")
+//	for _, name := range names {
+//		err = store.Load(path.Join("tokens", name), &token)
+//	}
+func (s *FileStore) List(dir string) (names []string, err error) {
+	var fsys FS
+	var entries []fs.DirEntry
+
+	if !fs.ValidPath(dir) {
+		err = fmt.Errorf("path %s is not valid for use in the configuration directory", dir)
+		goto end
+	}
+
+	fsys, err = s.getFS()
+	if err != nil {
+		goto end
+	}
+
+	names = make([]string, 0)
+	entries, err = fsys.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+		goto end
+	}
+	if err != nil {
+		err = fmt.Errorf("listing %s: %w", dir, err)
+		goto end
+	}
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+
+end:
+	return names, err
+}
+
+// Delete removes the specified file from the configuration directory. The
+// filename is validated with fs.ValidPath and resolved relative to the
+// configuration directory exactly as for Save and Load, so a file outside
+// the directory can never be removed. Directories are only removed when
+// empty; use DeleteAll to remove a directory and its contents.
+//
+// Parameters:
+//   - filename: The relative path within the configuration directory of the
+//     file to remove. May include subdirectories.
+//
+// Returns an error if:
+//   - The file path is invalid or escapes the configuration directory
+//   - The file does not exist (wraps fs.ErrNotExist)
+//   - The file cannot be removed, or is a non-empty directory
+//
+// Example usage:
+//
+//	err := store.Delete("tokens/user@domain.com.json")
+//	if errors.Is(err, fs.ErrNotExist) {
+//		// Already gone
+//	}
+func (s *FileStore) Delete(filename string) (err error) {
+	var fsys FS
+
+	fsys, err = s.getFSFor(filename)
+	if err != nil {
+		goto end
+	}
+
+	err = fsys.Remove(filename)
+	if err != nil {
+		err = fmt.Errorf("deleting %s: %w", filename, err)
+	}
+
+end:
+	return err
+}
+
+// Rename moves the specified file or subdirectory of the configuration
+// directory to a new name within it, creating the new name's parent
+// directories as needed, such as to re-key a directory of per-session files.
+// Both names are validated and resolved exactly as for Delete. A file at the
+// new name is replaced.
+//
+// Parameters:
+//   - oldname: The relative path within the configuration directory of the
+//     file or subdirectory to move.
+//   - newname: The relative path within the configuration directory to move
+//     it to.
+//
+// Returns an error if:
+//   - Either path is invalid or escapes the configuration directory
+//   - Nothing exists at oldname (wraps fs.ErrNotExist)
+//   - The parent directories of newname cannot be created
+//   - The rename fails, such as when newname is a non-empty directory
+func (s *FileStore) Rename(oldname, newname string) (err error) {
+	var fsys FS
+
+	fsys, err = s.getFSFor(oldname)
+	if err != nil {
+		goto end
+	}
+
+	fsys, err = s.ensureDir(newname)
+	if err != nil {
+		goto end
+	}
+
+	err = fsys.Rename(oldname, newname)
+	if err != nil {
+		err = fmt.Errorf("renaming %s to %s: %w", oldname, newname, err)
+	}
+
+end:
+	return err
+}
+
+// DeleteAll removes the specified file or subdirectory of the configuration
+// directory along with everything it contains, such as a tokens/ directory
+// of stale token files. The path is validated and resolved exactly as for
+// Delete. Symbolic links are removed rather than followed, so nothing
+// outside the configuration directory is touched, and the configuration
+// directory itself (".") cannot be removed.
+//
+// Parameters:
+//   - dir: The relative path within the configuration directory of the
+//     subdirectory or file to remove.
+//
+// Returns an error if:
+//   - The path is invalid, escapes the configuration directory or is "."
+//   - Nothing exists at the path (wraps fs.ErrNotExist)
+//   - Any part of it cannot be removed
+func (s *FileStore) DeleteAll(dir string) (err error) {
+	var fsys FS
+
+	if dir == "." {
+		err = fmt.Errorf("deleting %s: refusing to delete the configuration directory itself", dir)
+		goto end
+	}
+
+	fsys, err = s.getFSFor(dir)
+	if err != nil {
+		goto end
+	}
+
+	_, err = fsys.Stat(dir)
+	if err != nil {
+		err = fmt.Errorf("deleting %s: %w", dir, err)
+		goto end
+	}
+
+	err = fsys.RemoveAll(dir)
+	if err != nil {
+		err = fmt.Errorf("deleting %s: %w", dir, err)
+	}
+
+end:
+	return err
+}
+
 // SetBaseDir overrides the default configuration directory with a custom
 // path. This method is primarily used for testing scenarios where
 // configuration files need to be stored in a temporary or controlled
@@ -412,5 +658,75 @@ end:
 // resolution and should only be used in testing or specialized scenarios.
 func (s *FileStore) SetBaseDir(dir string) {
 	s.configDir = dir
-	s.fs = os.DirFS(dir)
-}
\ No newline at end of file
+	s.fsys = NewOSFS(dir)
+}
+
+// SetFS replaces the filesystem the store reads and writes through, so that
+// tests can run against an in-memory filesystem, such as the one in the
+// memfs subpackage, and assert on exactly what the store writes without
+// touching disk. All operations then resolve filenames within fsys rather
+// than the configuration directory, which ConfigDir still reports.
+//
+// Parameters:
+//   - fsys: The filesystem to use. nil restores the operating system's
+//     filesystem rooted at the configuration directory.
+//
+// Example usage:
+//
+//	mem := memfs.New()
+//	store := scoutcfg.NewFileStore("test-app")
+//	store.SetFS(mem)
+//	err := store.Save("config.json", &config)
+func (s *FileStore) SetFS(fsys FS) {
+	s.fsys = fsys
+}
+
+// SetJSON5 enables or disables lenient JSON5 parsing for Load. When enabled,
+// Load accepts the JSON5 conveniences humans tend to use when hand-editing
+// configuration files: // and /* */ comments, trailing commas, single-quoted
+// strings and unquoted object keys.
+//
+// This leniency applies to loading only. Save always emits strict, indented
+// JSON, so any file that is round-tripped through Load and Save will lose its
+// comments and be rewritten in canonical JSON form.
+//
+// Parameters:
+//   - enabled: true to accept JSON5 input in Load, false (the default) to
+//     require strict JSON.
+func (s *FileStore) SetJSON5(enabled bool) {
+	s.json5 = enabled
+}
+
+// SetBackupPolicy controls whether Save keeps the previous version of a file
+// it overwrites. Backups are written beside the file, as <file>.bak when
+// policy.Generations is 1, or as rotating <file>.<timestamp>.bak files when
+// it is larger, of which at most policy.Generations are retained. See
+// BackupPolicy for details.
+//
+// Parameters:
+//   - policy: The backup policy to apply to subsequent saves. The zero value
+//     (the default) disables backups.
+func (s *FileStore) SetBackupPolicy(policy BackupPolicy) {
+	s.backup = policy
+}
+
+// SetCodec sets the format Save and Load use for every file, overriding the
+// default of inferring it from each file's extension with CodecForFile.
+// Implement Codec to store configuration in a format not built in.
+//
+// Parameters:
+//   - codec: The codec to use, such as JSONCodec, YAMLCodec or TOMLCodec,
+//     or nil (the default) to infer the format from the file extension.
+func (s *FileStore) SetCodec(codec Codec) {
+	s.codec = codec
+}
+
+// codecFor returns the codec to use for filename: the one set with SetCodec,
+// or else the one matching its extension.
+func (s *FileStore) codecFor(filename string) (codec Codec) {
+	codec = s.codec
+	if codec == nil {
+		codec = CodecForFile(filename)
+	}
+	return codec
+}
@@ -5,6 +5,8 @@
 package scoutcfg_test
 
 import (
+	"encoding/json"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -121,6 +123,62 @@ func TestFileStore_SaveInvalidJSON(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// TestFileStore_SaveAtomic verifies that Save replaces an existing file
+// without leaving temporary files in the configuration directory, and that
+// when the final rename fails the temporary file is removed and the error
+// identifies the rename as the failed step.
+func TestFileStore_SaveAtomic(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.Save("config.json", testData{Name: "first", Age: 1}))
+	require.NoError(t, s.Save("config.json", testData{Name: "second", Age: 2}))
+
+	var loaded testData
+	require.NoError(t, s.Load("config.json", &loaded))
+	assert.Equal(t, testData{Name: "second", Age: 2}, loaded)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "No temporary files should remain")
+
+	// A non-empty directory at the target path makes the rename fail
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "blocked.json", "child"), 0755))
+	err = s.Save("blocked.json", testData{Name: "blocked"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scoutcfg.ErrRenameTempFile)
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "The temporary file should be removed")
+}
+
+// TestFileStore_SaveWithPerm verifies that SaveWithPerm creates a new file
+// with the given permissions, and its directory to match, and that saving
+// over it keeps them.
+func TestFileStore_SaveWithPerm(t *testing.T) {
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.SaveWithPerm("tokens/secret.json", testData{Name: "first"}, 0600))
+	info, err := os.Stat(filepath.Join(dir, "tokens", "secret.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dir, "tokens"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm(), "A new directory should only be accessible by the owner")
+
+	require.NoError(t, s.Save("tokens/secret.json", testData{Name: "second"}))
+	info, err = os.Stat(filepath.Join(dir, "tokens", "secret.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "An existing file should keep its permissions")
+}
+
 // TestFileStore_ConfigDir validates the configuration directory path
 // computation and caching functionality. This test ensures that the
 // FileStore correctly determines and caches the configuration directory
@@ -135,6 +193,165 @@ func TestFileStore_ConfigDir(t *testing.T) {
 	assert.Equal(t, dir, cfgDir)
 }
 
+// TestFileStore_LoadJSON5 verifies that hand-edited configuration files
+// using JSON5 conveniences load once JSON5 parsing is enabled, that the
+// same files are rejected under the default strict JSON parsing, and that
+// Save continues to write strict JSON.
+func TestFileStore_LoadJSON5(t *testing.T) {
+	var err error
+	var loaded testData
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	content := `{
+  // The user's name
+  Name: 'Alice "Al" O\'Neil',
+  /* Age in years */
+  'Age': 42,
+}
+`
+	err = os.WriteFile(filepath.Join(dir, "config.json"), []byte(content), 0644)
+	require.NoError(t, err)
+
+	err = s.Load("config.json", &loaded)
+	assert.Error(t, err, "Strict JSON parsing should reject JSON5 syntax")
+
+	s.SetJSON5(true)
+	err = s.Load("config.json", &loaded)
+	require.NoError(t, err)
+	assert.Equal(t, testData{Name: `Alice "Al" O'Neil`, Age: 42}, loaded)
+
+	err = s.Save("saved.json", &loaded)
+	require.NoError(t, err)
+	saved, err := os.ReadFile(filepath.Join(dir, "saved.json"))
+	require.NoError(t, err)
+	assert.True(t, json.Valid(saved), "Save should emit strict JSON")
+}
+
+// TestFileStore_List verifies that List returns the sorted names of the
+// regular files in a subdirectory, skipping subdirectories and symbolic
+// links, returns an empty slice for a missing directory, and rejects
+// invalid paths.
+func TestFileStore_List(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	names, err := s.List("tokens")
+	require.NoError(t, err)
+	assert.NotNil(t, names)
+	assert.Empty(t, names, "A missing directory should list as empty")
+
+	require.NoError(t, s.Save("tokens/bob@example.com.json", testData{Name: "bob"}))
+	require.NoError(t, s.Save("tokens/alice@example.com.json", testData{Name: "alice"}))
+	require.NoError(t, s.Save("tokens/archive/old.json", testData{Name: "old"}))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "tokens", "bob@example.com.json"), filepath.Join(dir, "tokens", "link.json")))
+
+	names, err = s.List("tokens")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com.json", "bob@example.com.json"}, names)
+
+	names, err = s.List(".")
+	require.NoError(t, err)
+	assert.Empty(t, names, "Only the tokens directory exists at the top level")
+
+	_, err = s.List("../")
+	assert.Error(t, err, "Paths escaping the configuration directory should be rejected")
+
+	_, err = s.List("tokens/alice@example.com.json")
+	assert.Error(t, err, "Listing a file should fail")
+}
+
+// TestFileStore_Delete verifies that Delete removes a file within the
+// configuration directory, reports a missing file with an error wrapping
+// fs.ErrNotExist, and rejects paths escaping the directory.
+func TestFileStore_Delete(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.Save("tokens/stale.json", testData{Name: "stale"}))
+	require.NoError(t, s.Save("tokens/fresh.json", testData{Name: "fresh"}))
+
+	err = s.Delete("tokens/stale.json")
+	require.NoError(t, err)
+	assert.False(t, s.Exists("tokens/stale.json"))
+	assert.True(t, s.Exists("tokens/fresh.json"), "Other files should be kept")
+
+	err = s.Delete("tokens/stale.json")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	err = s.Delete("../outside.json")
+	assert.Error(t, err, "Paths escaping the configuration directory should be rejected")
+
+	err = s.Delete("tokens")
+	assert.Error(t, err, "Non-empty directories should not be removed")
+	assert.True(t, s.Exists("tokens/fresh.json"))
+}
+
+// TestFileStore_DeleteAll verifies that DeleteAll removes a subdirectory
+// and its contents, reports a missing path, and refuses to remove the
+// configuration directory itself.
+func TestFileStore_DeleteAll(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.Save("tokens/a.json", testData{Name: "a"}))
+	require.NoError(t, s.Save("tokens/nested/b.json", testData{Name: "b"}))
+	require.NoError(t, s.Save("config.json", testData{Name: "config"}))
+
+	err = s.DeleteAll("tokens")
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dir, "tokens"))
+	assert.True(t, s.Exists("config.json"), "Files outside the subdirectory should be kept")
+
+	err = s.DeleteAll("tokens")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	err = s.DeleteAll(".")
+	assert.Error(t, err)
+	err = s.DeleteAll("..")
+	assert.Error(t, err)
+	assert.FileExists(t, filepath.Join(dir, "config.json"))
+}
+
+// TestFileStore_Rename verifies that Rename moves a subdirectory to a new
+// name, creating its parents, and reports a missing path.
+func TestFileStore_Rename(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	require.NoError(t, s.Save("sessions/old/a.json", testData{Name: "a"}))
+
+	err = s.Rename("sessions/old", "archive/sessions/new")
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dir, "sessions", "old"))
+	var loaded testData
+	require.NoError(t, s.Load("archive/sessions/new/a.json", &loaded))
+	assert.Equal(t, "a", loaded.Name)
+
+	err = s.Rename("sessions/old", "sessions/other")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	err = s.Rename("archive", "../escaped")
+	assert.Error(t, err)
+}
+
 // must is a test helper function that logs errors during test cleanup
 // operations. It uses the test logger to report cleanup errors without
 // failing tests, since cleanup errors are typically not critical to
@@ -0,0 +1,221 @@
+package scoutcfg
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// File is an open file in an FS. *os.File implements it.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Closer
+
+	// Stat returns the file's metadata.
+	Stat() (fs.FileInfo, error)
+
+	// Sync commits the file's contents to stable storage.
+	Sync() error
+
+	// Chmod changes the file's permissions.
+	Chmod(mode fs.FileMode) error
+}
+
+// FS is the filesystem a FileStore reads and writes through. Names are
+// slash-separated paths relative to the configuration directory, valid
+// according to fs.ValidPath, such as "config.json" or "tokens/user.json".
+//
+// By default a FileStore uses NewOSFS rooted at its configuration directory.
+// Tests can supply another implementation with SetFS, such as the in-memory
+// filesystem in the memfs subpackage, to run without touching disk and to
+// observe exactly what a FileStore writes.
+//
+// The methods behave like their namesakes in package os, and errors for
+// missing files must wrap fs.ErrNotExist.
+type FS interface {
+	// Open opens the named file for reading.
+	Open(name string) (File, error)
+
+	// Create creates or truncates the named file for writing, with
+	// permissions 0666 before umask if it is created.
+	Create(name string) (File, error)
+
+	// OpenFile opens the named file with the os.O_* flags in flag, using
+	// perm if the file is created.
+	OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+
+	// Stat returns the named file's metadata.
+	Stat(name string) (fs.FileInfo, error)
+
+	// ReadDir returns the entries of the named directory, sorted by name.
+	ReadDir(name string) ([]fs.DirEntry, error)
+
+	// MkdirAll creates the named directory and any missing parents.
+	MkdirAll(name string, perm fs.FileMode) error
+
+	// Rename renames oldname to newname, replacing newname if it is a file.
+	Rename(oldname, newname string) error
+
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+
+	// RemoveAll removes the named file or directory and everything it
+	// contains, without following symbolic links. It returns nil if the
+	// name does not exist.
+	RemoveAll(name string) error
+}
+
+// NewOSFS returns an FS for the operating system's filesystem rooted at dir,
+// the FS a FileStore uses unless SetFS is called. Names that are not valid
+// according to fs.ValidPath are rejected with an error wrapping
+// fs.ErrInvalid, so nothing outside dir can be reached by a ".." element.
+//
+// Parameters:
+//   - dir: The directory that names are resolved against.
+func NewOSFS(dir string) FS {
+	return osFS{dir: dir}
+}
+
+// osFS implements FS with package os.
+type osFS struct {
+	dir string
+}
+
+// path returns the operating system path of name, or an error for op if
+// name is not valid.
+func (o osFS) path(op, name string) (fp string, err error) {
+	if !fs.ValidPath(name) {
+		err = &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+		goto end
+	}
+	fp = filepath.Join(o.dir, filepath.FromSlash(name))
+
+end:
+	return fp, err
+}
+
+func (o osFS) Open(name string) (File, error) {
+	return o.OpenFile(name, os.O_RDONLY, 0)
+}
+
+func (o osFS) Create(name string) (File, error) {
+	return o.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (o osFS) OpenFile(name string, flag int, perm fs.FileMode) (file File, err error) {
+	var fp string
+	var f *os.File
+
+	fp, err = o.path("open", name)
+	if err != nil {
+		goto end
+	}
+	f, err = os.OpenFile(fp, flag, perm)
+	if err != nil {
+		goto end
+	}
+	file = f
+
+end:
+	return file, err
+}
+
+func (o osFS) Stat(name string) (info fs.FileInfo, err error) {
+	var fp string
+
+	fp, err = o.path("stat", name)
+	if err != nil {
+		goto end
+	}
+	info, err = os.Stat(fp)
+
+end:
+	return info, err
+}
+
+func (o osFS) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	var fp string
+
+	fp, err = o.path("readdir", name)
+	if err != nil {
+		goto end
+	}
+	entries, err = os.ReadDir(fp)
+
+end:
+	return entries, err
+}
+
+func (o osFS) MkdirAll(name string, perm fs.FileMode) (err error) {
+	var fp string
+
+	fp, err = o.path("mkdir", name)
+	if err != nil {
+		goto end
+	}
+	err = os.MkdirAll(fp, perm)
+
+end:
+	return err
+}
+
+func (o osFS) Rename(oldname, newname string) (err error) {
+	var oldPath, newPath string
+
+	oldPath, err = o.path("rename", oldname)
+	if err != nil {
+		goto end
+	}
+	newPath, err = o.path("rename", newname)
+	if err != nil {
+		goto end
+	}
+	err = os.Rename(oldPath, newPath)
+
+end:
+	return err
+}
+
+func (o osFS) Remove(name string) (err error) {
+	var fp string
+
+	fp, err = o.path("remove", name)
+	if err != nil {
+		goto end
+	}
+	err = os.Remove(fp)
+
+end:
+	return err
+}
+
+func (o osFS) RemoveAll(name string) (err error) {
+	var fp string
+
+	fp, err = o.path("removeall", name)
+	if err != nil {
+		goto end
+	}
+	err = os.RemoveAll(fp)
+
+end:
+	return err
+}
+
+// readFile returns the contents of the named file in fsys.
+func readFile(fsys FS, name string) (content []byte, err error) {
+	var file File
+
+	file, err = fsys.Open(name)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(file)
+
+	content, err = io.ReadAll(file)
+
+end:
+	return content, err
+}
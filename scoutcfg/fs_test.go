@@ -0,0 +1,58 @@
+package scoutcfg_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg/memfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileStore_SetFS verifies that a FileStore given an in-memory
+// filesystem saves, loads, lists, locks and deletes through it, and that
+// Save writes to a temporary file which is then renamed into place.
+func TestFileStore_SetFS(t *testing.T) {
+	var err error
+
+	mem := memfs.New()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetFS(mem)
+
+	err = s.Save("tokens/user.json", testData{Name: "user", Age: 1})
+	require.NoError(t, err)
+
+	ops := mem.Ops()
+	require.Len(t, ops, 5)
+	assert.Equal(t, memfs.Op{Kind: memfs.MkdirOp, Name: "tokens"}, ops[0])
+	assert.Equal(t, memfs.CreateOp, ops[1].Kind)
+	tmpName := ops[1].Name
+	assert.True(t, strings.HasPrefix(tmpName, "tokens/.user.json.tmp-"), "Save should write a temporary file first: %s", tmpName)
+	assert.Equal(t, memfs.Op{Kind: memfs.WriteOp, Name: tmpName, Size: len("{\n  \"name\": \"user\",\n  \"age\": 1\n}")}, ops[2])
+	assert.Equal(t, memfs.Op{Kind: memfs.ChmodOp, Name: tmpName}, ops[3])
+	assert.Equal(t, memfs.Op{Kind: memfs.RenameOp, Name: tmpName, NewName: "tokens/user.json"}, ops[4])
+
+	var loaded testData
+	require.NoError(t, s.Load("tokens/user.json", &loaded))
+	assert.Equal(t, testData{Name: "user", Age: 1}, loaded)
+	assert.True(t, s.Exists("tokens/user.json"))
+
+	names, err := s.List("tokens")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user.json"}, names, "No temporary files should remain")
+
+	err = s.WithLock("tokens/user.json", func() error {
+		return s.Append("tokens/log.txt", []byte("entry\n"))
+	})
+	require.NoError(t, err)
+	content, err := mem.ReadFile("tokens/log.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "entry\n", string(content))
+
+	require.NoError(t, s.DeleteAll("tokens"))
+	assert.False(t, s.Exists("tokens/user.json"))
+	err = s.Delete("tokens/user.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
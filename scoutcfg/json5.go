@@ -0,0 +1,181 @@
+package scoutcfg
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// normalizeJSON5 converts the lenient JSON5 subset commonly found in
+// hand-edited configuration files into strict JSON that encoding/json can
+// parse. It is only ever applied when loading; Save always writes strict
+// JSON, so files written by FileStore remain canonical.
+//
+// The supported JSON5 extensions are:
+//   - Line (//) and block (/* */) comments, which are removed
+//   - Trailing commas before a closing } or ], which are dropped
+//   - Single-quoted strings, which are rewritten as double-quoted strings
+//   - Unquoted object keys made of letters, digits, '_' and '$'
+//
+// Other JSON5 features such as hexadecimal numbers, Infinity/NaN or
+// multi-line strings are not supported and are passed through unchanged,
+// which means encoding/json will report them as syntax errors.
+//
+// Parameters:
+//   - data: The raw file contents to normalize.
+//
+// Returns:
+//   - The normalized strict JSON content
+//   - An error if a string or block comment is not terminated
+func normalizeJSON5(data []byte) (out []byte, err error) {
+	var buf bytes.Buffer
+	var pendingComma bool
+	var i int
+	var c byte
+	var closeIdx int
+
+	buf.Grow(len(data))
+
+	for i = 0; i < len(data); i++ {
+		c = data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			buf.WriteByte(c)
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				buf.WriteByte('\n')
+			}
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			closeIdx = bytes.Index(data[i+2:], []byte("*/"))
+			if closeIdx < 0 {
+				err = fmt.Errorf("unterminated block comment at offset %d", i)
+				goto end
+			}
+			i += closeIdx + 3
+			buf.WriteByte(' ')
+
+		case c == ',':
+			if pendingComma {
+				// Let encoding/json report the doubled comma
+				buf.WriteByte(',')
+			}
+			pendingComma = true
+
+		default:
+			if pendingComma && c != '}' && c != ']' {
+				buf.WriteByte(',')
+			}
+			pendingComma = false
+
+			switch {
+			case c == '"' || c == '\'':
+				i, err = writeJSON5String(&buf, data, i)
+				if err != nil {
+					goto end
+				}
+			case c == '-' || (c >= '0' && c <= '9'):
+				// Copy numbers whole so exponents aren't taken for keys
+				for i+1 < len(data) && isJSON5NumberPart(data[i+1]) {
+					buf.WriteByte(data[i])
+					i++
+				}
+				buf.WriteByte(data[i])
+			case isJSON5IdentStart(c):
+				i = writeJSON5Ident(&buf, data, i)
+			default:
+				buf.WriteByte(c)
+			}
+		}
+	}
+
+	if pendingComma {
+		buf.WriteByte(',')
+	}
+	out = buf.Bytes()
+
+end:
+	return out, err
+}
+
+// writeJSON5String writes the string literal starting at data[start] to buf
+// as a double-quoted JSON string and returns the index of its closing quote.
+// Single-quoted strings have embedded double quotes escaped and \' escapes
+// unescaped; double-quoted strings are copied verbatim.
+func writeJSON5String(buf *bytes.Buffer, data []byte, start int) (i int, err error) {
+	var quote byte
+	var c byte
+
+	quote = data[start]
+	buf.WriteByte('"')
+
+	for i = start + 1; i < len(data); i++ {
+		c = data[i]
+		switch {
+		case c == '\\' && i+1 < len(data):
+			i++
+			if data[i] == '\'' {
+				buf.WriteByte('\'')
+				continue
+			}
+			buf.WriteByte('\\')
+			buf.WriteByte(data[i])
+		case c == quote:
+			buf.WriteByte('"')
+			goto end
+		case c == '"':
+			buf.WriteString(`\"`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	err = fmt.Errorf("unterminated string starting at offset %d", start)
+
+end:
+	return i, err
+}
+
+// writeJSON5Ident writes the bare identifier starting at data[start] to buf
+// and returns the index of its last byte. The JSON literals true, false and
+// null are written as-is; any other identifier is treated as an unquoted
+// object key and written as a quoted string.
+func writeJSON5Ident(buf *bytes.Buffer, data []byte, start int) (i int) {
+	var ident []byte
+
+	i = start
+	for i+1 < len(data) && isJSON5IdentPart(data[i+1]) {
+		i++
+	}
+	ident = data[start : i+1]
+
+	switch string(ident) {
+	case "true", "false", "null":
+		buf.Write(ident)
+	default:
+		buf.WriteByte('"')
+		buf.Write(ident)
+		buf.WriteByte('"')
+	}
+
+	return i
+}
+
+// isJSON5IdentStart reports whether c may begin an unquoted object key.
+func isJSON5IdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isJSON5IdentPart reports whether c may appear after the first character
+// of an unquoted object key.
+func isJSON5IdentPart(c byte) bool {
+	return isJSON5IdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// isJSON5NumberPart reports whether c may appear after the first character
+// of a numeric literal.
+func isJSON5NumberPart(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
@@ -0,0 +1,160 @@
+package scoutcfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultLockTimeout is how long WithLock waits for a lock held by another
+// process before giving up, unless changed with SetLockTimeout.
+const DefaultLockTimeout = 10 * time.Second
+
+// lockRetryInterval is how often WithLock retries a lock that is held.
+const lockRetryInterval = 25 * time.Millisecond
+
+var (
+	// ErrLockTimeout is returned by WithLock when the lock is still held by
+	// another holder once the lock timeout has elapsed.
+	ErrLockTimeout = errors.New("timed out waiting for lock")
+
+	// ErrLockUnsupported is returned by WithLock on platforms without
+	// advisory file locking.
+	ErrLockUnsupported = errors.New("file locking is not supported on this platform")
+)
+
+// WithLock runs fn while holding an exclusive OS advisory lock on a lockfile
+// beside the named configuration file, serializing read-modify-write
+// sequences such as Load, modify and Save across goroutines and across
+// separate processes sharing the configuration directory. The lock is taken
+// with flock on Unix and LockFileEx on Windows, and is released when fn
+// returns, even if it panics.
+//
+// The lockfile is named <filename>.lock and is created, along with any parent
+// directories, if needed. It is left in place afterwards, since removing it
+// would let a waiting process lock a file that a newer process has already
+// replaced. The named file itself need not exist.
+//
+// Advisory locks only exclude other callers of WithLock (or of flock or
+// LockFileEx on the same lockfile); they do not prevent plain reads and
+// writes of the configuration file.
+//
+// When a filesystem other than the operating system's has been set with
+// SetFS, its files cannot be seen by other processes, so WithLock instead
+// takes an in-process lock that excludes other callers of WithLock on the
+// same FileStore. The lockfile is still created in that filesystem.
+//
+// Parameters:
+//   - filename: The relative path of the configuration file to lock, within
+//     the configuration directory.
+//   - fn: The function to run while the lock is held.
+//
+// Returns the error returned by fn, or an error if:
+//   - The file path is invalid or the lockfile cannot be created
+//   - The lock is still held elsewhere after the lock timeout (wraps ErrLockTimeout)
+//   - The platform has no advisory file locking (wraps ErrLockUnsupported)
+//   - Releasing the lock fails
+//
+// Example usage:
+//
+//	err := store.WithLock("config.json", func() error {
+//		var config MyConfig
+//		err := store.Load("config.json", &config)
+//		if err != nil {
+//			return err
+//		}
+//		config.Count++
+//		return store.Save("config.json", &config)
+//	})
+func (s *FileStore) WithLock(filename string, fn func() error) (err error) {
+	var fsys FS
+	var file File
+	var osFile *os.File
+	var isOS bool
+	var mu *sync.Mutex
+	var tryLock func() (bool, error)
+	var unlock func() error
+
+	fsys, err = s.ensureDir(filename + ".lock")
+	if err != nil {
+		goto end
+	}
+
+	file, err = fsys.OpenFile(filename+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		goto end
+	}
+	defer mustClose(file)
+
+	osFile, isOS = file.(*os.File)
+	if isOS {
+		tryLock = func() (bool, error) { return tryLockFile(osFile) }
+		unlock = func() error { return unlockFile(osFile) }
+	} else {
+		mu = s.processLock(filename)
+		tryLock = func() (bool, error) { return mu.TryLock(), nil }
+		unlock = func() error { mu.Unlock(); return nil }
+	}
+
+	err = acquireLock(tryLock, s.lockTimeout)
+	if err != nil {
+		err = fmt.Errorf("locking %s: %w", filename, err)
+		goto end
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	err = fn()
+
+end:
+	return err
+}
+
+// SetLockTimeout sets how long WithLock waits for a lock held by another
+// goroutine or process before returning an error wrapping ErrLockTimeout, so
+// that a stuck holder cannot block callers forever.
+//
+// Parameters:
+//   - timeout: The maximum time to wait. Zero or less restores
+//     DefaultLockTimeout.
+func (s *FileStore) SetLockTimeout(timeout time.Duration) {
+	s.lockTimeout = timeout
+}
+
+// processLock returns the in-process lock WithLock takes for filename when
+// the store's filesystem is not the operating system's.
+func (s *FileStore) processLock(filename string) *sync.Mutex {
+	mu, _ := s.locks.LoadOrStore(filename, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// acquireLock takes an exclusive lock with tryLock, retrying while another
+// holder has it until timeout, or DefaultLockTimeout if timeout is not
+// positive, has elapsed.
+func acquireLock(tryLock func() (bool, error), timeout time.Duration) (err error) {
+	var deadline time.Time
+	var locked bool
+
+	if timeout <= 0 {
+		timeout = DefaultLockTimeout
+	}
+	deadline = time.Now().Add(timeout)
+
+	for {
+		locked, err = tryLock()
+		if err != nil || locked {
+			goto end
+		}
+		if time.Now().After(deadline) {
+			err = fmt.Errorf("%w after %s", ErrLockTimeout, timeout)
+			goto end
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+end:
+	return err
+}
@@ -0,0 +1,18 @@
+//go:build !unix && !windows
+
+package scoutcfg
+
+import (
+	"os"
+)
+
+// tryLockFile reports ErrLockUnsupported, as this platform has no advisory
+// file locking.
+func tryLockFile(*os.File) (bool, error) {
+	return false, ErrLockUnsupported
+}
+
+// unlockFile does nothing, as no lock can have been taken.
+func unlockFile(*os.File) error {
+	return nil
+}
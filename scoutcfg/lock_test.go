@@ -0,0 +1,132 @@
+package scoutcfg_test
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileStore_WithLock verifies that concurrent read-modify-write
+// sequences run under WithLock are serialized, so that no update is lost and
+// no two holders ever run at once. Each goroutine uses its own FileStore and
+// so its own lockfile descriptor, as separate processes would.
+func TestFileStore_WithLock(t *testing.T) {
+	const workers = 8
+
+	dir := t.TempDir()
+	setup := scoutcfg.NewFileStore("test-app")
+	setup.SetBaseDir(dir)
+	require.NoError(t, setup.Save("counter.json", testData{Name: "counter"}))
+
+	var holders, maxHolders atomic.Int32
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := scoutcfg.NewFileStore("test-app")
+			s.SetBaseDir(dir)
+			errs <- s.WithLock("counter.json", func() error {
+				n := holders.Add(1)
+				defer holders.Add(-1)
+				if n > maxHolders.Load() {
+					maxHolders.Store(n)
+				}
+				var data testData
+				err := s.Load("counter.json", &data)
+				if err != nil {
+					return err
+				}
+				time.Sleep(5 * time.Millisecond)
+				data.Age++
+				return s.Save("counter.json", &data)
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var loaded testData
+	require.NoError(t, setup.Load("counter.json", &loaded))
+	assert.Equal(t, workers, loaded.Age, "No update should be lost")
+	assert.Equal(t, int32(1), maxHolders.Load(), "Only one holder should run at a time")
+	assert.FileExists(t, filepath.Join(dir, "counter.json.lock"))
+}
+
+// TestFileStore_WithLockTimeout verifies that WithLock gives up with
+// ErrLockTimeout while another holder keeps the lock, without running fn,
+// and succeeds once the lock is released.
+func TestFileStore_WithLockTimeout(t *testing.T) {
+	dir := t.TempDir()
+	holder := scoutcfg.NewFileStore("test-app")
+	holder.SetBaseDir(dir)
+	waiter := scoutcfg.NewFileStore("test-app")
+	waiter.SetBaseDir(dir)
+	waiter.SetLockTimeout(50 * time.Millisecond)
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- holder.WithLock("config.json", func() error {
+			close(locked)
+			<-release
+			return nil
+		})
+	}()
+	<-locked
+
+	ran := false
+	err := waiter.WithLock("config.json", func() error {
+		ran = true
+		return nil
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, scoutcfg.ErrLockTimeout)
+	assert.False(t, ran, "fn should not run without the lock")
+
+	close(release)
+	require.NoError(t, <-done)
+
+	err = waiter.WithLock("config.json", func() error {
+		ran = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+// TestFileStore_WithLockError verifies that the error returned by fn is
+// passed through and that the lock is released afterwards.
+func TestFileStore_WithLockError(t *testing.T) {
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(t.TempDir())
+	s.SetLockTimeout(50 * time.Millisecond)
+
+	errFailed := errors.New("update failed")
+	err := s.WithLock("nested/config.json", func() error {
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	err = s.WithLock("nested/config.json", func() error {
+		return nil
+	})
+	assert.NoError(t, err, "The lock should have been released")
+
+	err = s.WithLock("../config.json", func() error {
+		return nil
+	})
+	assert.Error(t, err, "Paths outside the configuration directory should be rejected")
+}
@@ -0,0 +1,29 @@
+//go:build unix
+
+package scoutcfg
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLockFile takes an exclusive flock on file without waiting, reporting
+// whether it was taken; false with a nil error means another holder has it.
+func tryLockFile(file *os.File) (locked bool, err error) {
+	err = unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if errors.Is(err, unix.EWOULDBLOCK) {
+		err = nil
+		goto end
+	}
+	locked = err == nil
+
+end:
+	return locked, err
+}
+
+// unlockFile releases the flock held on file.
+func unlockFile(file *os.File) error {
+	return unix.Flock(int(file.Fd()), unix.LOCK_UN)
+}
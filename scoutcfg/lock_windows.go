@@ -0,0 +1,36 @@
+//go:build windows
+
+package scoutcfg
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockAllBytes is the length, in both halves of a LockFileEx range, of a
+// lock covering the whole file whatever its size.
+const lockAllBytes = ^uint32(0)
+
+// tryLockFile takes an exclusive LockFileEx lock on file without waiting,
+// reporting whether it was taken; false with a nil error means another
+// holder has it.
+func tryLockFile(file *os.File) (locked bool, err error) {
+	err = windows.LockFileEx(windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0, lockAllBytes, lockAllBytes, new(windows.Overlapped))
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		err = nil
+		goto end
+	}
+	locked = err == nil
+
+end:
+	return locked, err
+}
+
+// unlockFile releases the LockFileEx lock held on file.
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, lockAllBytes, lockAllBytes, new(windows.Overlapped))
+}
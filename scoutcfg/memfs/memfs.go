@@ -0,0 +1,558 @@
+// Package memfs provides an in-memory implementation of scoutcfg.FS for
+// tests. A FileStore given a MemFS with SetFS never touches disk, and the
+// MemFS records every change made through it, so tests can assert on the
+// exact sequence of writes a FileStore performs.
+//
+// Example usage:
+//
+//	mem := memfs.New()
+//	store := scoutcfg.NewFileStore("test-app")
+//	store.SetFS(mem)
+//
+//	err := store.Save("config.json", &config)
+//	content, err := mem.ReadFile("config.json")
+//	for _, op := range mem.Ops() {
+//		t.Log(op)
+//	}
+package memfs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+)
+
+var _ scoutcfg.FS = (*MemFS)(nil)
+
+// OpKind identifies the kind of change recorded in an Op.
+type OpKind string
+
+const (
+	CreateOp    OpKind = "create"    // A file was created or truncated
+	WriteOp     OpKind = "write"     // Bytes were written to a file
+	ChmodOp     OpKind = "chmod"     // A file's permissions were changed
+	MkdirOp     OpKind = "mkdir"     // A directory was created
+	RenameOp    OpKind = "rename"    // A file or directory was renamed
+	RemoveOp    OpKind = "remove"    // A file or empty directory was removed
+	RemoveAllOp OpKind = "removeall" // A file or directory tree was removed
+)
+
+// Op is a single change made through a MemFS.
+type Op struct {
+	Kind    OpKind // What kind of change was made
+	Name    string // The file or directory changed
+	NewName string // The new name, for RenameOp
+	Size    int    // The number of bytes written, for WriteOp
+}
+
+// String describes the change, such as "write config.json (12 bytes)".
+func (op Op) String() (s string) {
+	switch op.Kind {
+	case RenameOp:
+		s = fmt.Sprintf("%s %s %s", op.Kind, op.Name, op.NewName)
+	case WriteOp:
+		s = fmt.Sprintf("%s %s (%d bytes)", op.Kind, op.Name, op.Size)
+	default:
+		s = fmt.Sprintf("%s %s", op.Kind, op.Name)
+	}
+	return s
+}
+
+// MemFS is an in-memory filesystem implementing scoutcfg.FS. Names follow
+// the scoutcfg.FS rules, and errors wrap the fs.Err* values os would, so
+// code behaves as it would on disk. It is safe for concurrent use.
+type MemFS struct {
+	mu    sync.Mutex
+	nodes map[string]*node // Files and directories by name; "." is the root
+	ops   []Op             // Changes in the order they were made
+}
+
+// node is a file or directory in a MemFS.
+type node struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// New returns an empty MemFS containing only its root directory.
+func New() *MemFS {
+	return &MemFS{
+		nodes: map[string]*node{
+			".": {mode: fs.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+// Ops returns a copy of the changes made through the MemFS so far, oldest
+// first. Reads are not recorded.
+func (m *MemFS) Ops() []Op {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.ops)
+}
+
+// ResetOps forgets the changes recorded so far, so that a test can record
+// only the operation it is interested in.
+func (m *MemFS) ResetOps() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ops = nil
+}
+
+// ReadFile returns a copy of the contents of the named file.
+func (m *MemFS) ReadFile(name string) (content []byte, err error) {
+	var n *node
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err = m.lookup("open", name)
+	if err != nil {
+		goto end
+	}
+	if n.mode.IsDir() {
+		err = &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+		goto end
+	}
+	content = slices.Clone(n.data)
+
+end:
+	return content, err
+}
+
+// WriteFile creates or replaces the named file with content and perm,
+// creating any missing parent directories, as a convenience for setting up
+// test fixtures. It is recorded like any other change.
+func (m *MemFS) WriteFile(name string, content []byte, perm fs.FileMode) (err error) {
+	var f scoutcfg.File
+
+	err = m.MkdirAll(path.Dir(name), 0755)
+	if err != nil {
+		goto end
+	}
+	f, err = m.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		goto end
+	}
+	_, err = f.Write(content)
+	err = errors.Join(err, f.Close())
+
+end:
+	return err
+}
+
+// Open opens the named file for reading.
+func (m *MemFS) Open(name string) (scoutcfg.File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// Create creates or truncates the named file for reading and writing.
+func (m *MemFS) Create(name string) (scoutcfg.File, error) {
+	return m.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// OpenFile opens the named file with the os.O_* flags in flag, creating it
+// with perm if os.O_CREATE is set and it does not exist. Its parent
+// directory must exist.
+func (m *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (file scoutcfg.File, err error) {
+	var n, parent *node
+	var ok bool
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !fs.ValidPath(name) {
+		err = &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+		goto end
+	}
+
+	n, ok = m.nodes[name]
+	switch {
+	case ok && flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL:
+		err = &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+		goto end
+	case ok && n.mode.IsDir() && flag&(os.O_WRONLY|os.O_RDWR) != 0:
+		err = &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+		goto end
+	case !ok && flag&os.O_CREATE == 0:
+		err = &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		goto end
+	case !ok:
+		parent, ok = m.nodes[path.Dir(name)]
+		if !ok || !parent.mode.IsDir() {
+			err = &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+			goto end
+		}
+		n = &node{mode: perm.Perm(), modTime: time.Now()}
+		m.nodes[name] = n
+		m.record(Op{Kind: CreateOp, Name: name})
+	case flag&os.O_TRUNC != 0 && flag&(os.O_WRONLY|os.O_RDWR) != 0:
+		n.data = nil
+		n.modTime = time.Now()
+		m.record(Op{Kind: CreateOp, Name: name})
+	}
+
+	file = &memFile{
+		fs:       m,
+		name:     name,
+		node:     n,
+		readable: flag&os.O_WRONLY == 0,
+		writable: flag&(os.O_WRONLY|os.O_RDWR) != 0,
+		append:   flag&os.O_APPEND != 0,
+	}
+
+end:
+	return file, err
+}
+
+// Stat returns the named file's metadata.
+func (m *MemFS) Stat(name string) (info fs.FileInfo, err error) {
+	var n *node
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err = m.lookup("stat", name)
+	if err != nil {
+		goto end
+	}
+	info = fileInfo{name: path.Base(name), size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+
+end:
+	return info, err
+}
+
+// ReadDir returns the entries of the named directory, sorted by name.
+func (m *MemFS) ReadDir(name string) (entries []fs.DirEntry, err error) {
+	var n *node
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err = m.lookup("readdir", name)
+	if err != nil {
+		goto end
+	}
+	if !n.mode.IsDir() {
+		err = &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+		goto end
+	}
+
+	entries = make([]fs.DirEntry, 0)
+	for child, cn := range m.nodes {
+		if child == "." || path.Dir(child) != name {
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(fileInfo{
+			name:    path.Base(child),
+			size:    int64(len(cn.data)),
+			mode:    cn.mode,
+			modTime: cn.modTime,
+		}))
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+end:
+	return entries, err
+}
+
+// MkdirAll creates the named directory and any missing parents.
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) (err error) {
+	var dirs []string
+	var n *node
+	var ok bool
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !fs.ValidPath(name) {
+		err = &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+		goto end
+	}
+
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range slices.Backward(dirs) {
+		n, ok = m.nodes[dir]
+		if ok && !n.mode.IsDir() {
+			err = &fs.PathError{Op: "mkdir", Path: dir, Err: errors.New("not a directory")}
+			goto end
+		}
+		if !ok {
+			m.nodes[dir] = &node{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+			m.record(Op{Kind: MkdirOp, Name: dir})
+		}
+	}
+
+end:
+	return err
+}
+
+// Rename renames oldname to newname, moving the contents of a directory
+// with it. An existing file at newname is replaced, but an existing
+// directory must be empty and oldname must then be a directory too.
+func (m *MemFS) Rename(oldname, newname string) (err error) {
+	var src, dst, parent *node
+	var children []string
+	var ok bool
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	src, err = m.lookup("rename", oldname)
+	if err != nil || newname == oldname {
+		goto end
+	}
+	if !fs.ValidPath(newname) || newname == "." || strings.HasPrefix(newname, oldname+"/") {
+		err = &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: fs.ErrInvalid}
+		goto end
+	}
+	parent, ok = m.nodes[path.Dir(newname)]
+	if !ok || !parent.mode.IsDir() {
+		err = &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: fs.ErrNotExist}
+		goto end
+	}
+	dst, ok = m.nodes[newname]
+	switch {
+	case ok && dst.mode.IsDir() && (!src.mode.IsDir() || m.hasChildren(newname)):
+		err = &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: fs.ErrExist}
+		goto end
+	case ok && !dst.mode.IsDir() && src.mode.IsDir():
+		err = &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: errors.New("not a directory")}
+		goto end
+	}
+
+	for child := range m.nodes {
+		if strings.HasPrefix(child, oldname+"/") {
+			children = append(children, child)
+		}
+	}
+	for _, child := range children {
+		m.nodes[newname+strings.TrimPrefix(child, oldname)] = m.nodes[child]
+		delete(m.nodes, child)
+	}
+	delete(m.nodes, oldname)
+	m.nodes[newname] = src
+	m.record(Op{Kind: RenameOp, Name: oldname, NewName: newname})
+
+end:
+	return err
+}
+
+// Remove removes the named file or empty directory.
+func (m *MemFS) Remove(name string) (err error) {
+	var n *node
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n, err = m.lookup("remove", name)
+	if err != nil {
+		goto end
+	}
+	if name == "." || n.mode.IsDir() && m.hasChildren(name) {
+		err = &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+		goto end
+	}
+	delete(m.nodes, name)
+	m.record(Op{Kind: RemoveOp, Name: name})
+
+end:
+	return err
+}
+
+// RemoveAll removes the named file or directory and everything it
+// contains. It returns nil if the name does not exist.
+func (m *MemFS) RemoveAll(name string) (err error) {
+	var ok bool
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !fs.ValidPath(name) || name == "." {
+		err = &fs.PathError{Op: "removeall", Path: name, Err: fs.ErrInvalid}
+		goto end
+	}
+	_, ok = m.nodes[name]
+	if !ok {
+		goto end
+	}
+	delete(m.nodes, name)
+	for child := range m.nodes {
+		if strings.HasPrefix(child, name+"/") {
+			delete(m.nodes, child)
+		}
+	}
+	m.record(Op{Kind: RemoveAllOp, Name: name})
+
+end:
+	return err
+}
+
+// lookup returns the named node, or an error for op if it is invalid or
+// does not exist. The caller must hold m.mu.
+func (m *MemFS) lookup(op, name string) (n *node, err error) {
+	var ok bool
+
+	if !fs.ValidPath(name) {
+		err = &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+		goto end
+	}
+	n, ok = m.nodes[name]
+	if !ok {
+		err = &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+end:
+	return n, err
+}
+
+// hasChildren reports whether the named directory has any entries. The
+// caller must hold m.mu.
+func (m *MemFS) hasChildren(name string) bool {
+	for child := range m.nodes {
+		if child != "." && path.Dir(child) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// record appends op to the change log. The caller must hold m.mu.
+func (m *MemFS) record(op Op) {
+	m.ops = append(m.ops, op)
+}
+
+// memFile is an open file in a MemFS.
+type memFile struct {
+	fs       *MemFS
+	name     string
+	node     *node
+	offset   int
+	readable bool
+	writable bool
+	append   bool
+	closed   bool
+}
+
+func (f *memFile) Read(p []byte) (n int, err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	switch {
+	case f.closed:
+		err = &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	case !f.readable:
+		err = &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrPermission}
+	case f.node.mode.IsDir():
+		err = &fs.PathError{Op: "read", Path: f.name, Err: errors.New("is a directory")}
+	case f.offset >= len(f.node.data):
+		err = io.EOF
+	default:
+		n = copy(p, f.node.data[f.offset:])
+		f.offset += n
+	}
+	return n, err
+}
+
+func (f *memFile) Write(p []byte) (n int, err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	switch {
+	case f.closed:
+		err = &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrClosed}
+	case !f.writable:
+		err = &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+	default:
+		if f.append {
+			f.offset = len(f.node.data)
+		}
+		if f.offset+len(p) > len(f.node.data) {
+			f.node.data = append(f.node.data, make([]byte, f.offset+len(p)-len(f.node.data))...)
+		}
+		copy(f.node.data[f.offset:], p)
+		f.offset += len(p)
+		f.node.modTime = time.Now()
+		n = len(p)
+		f.fs.record(Op{Kind: WriteOp, Name: f.name, Size: n})
+	}
+	return n, err
+}
+
+func (f *memFile) Close() (err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if f.closed {
+		err = &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return err
+}
+
+func (f *memFile) Stat() (info fs.FileInfo, err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if f.closed {
+		err = &fs.PathError{Op: "stat", Path: f.name, Err: fs.ErrClosed}
+		goto end
+	}
+	info = fileInfo{name: path.Base(f.name), size: int64(len(f.node.data)), mode: f.node.mode, modTime: f.node.modTime}
+
+end:
+	return info, err
+}
+
+func (f *memFile) Sync() (err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if f.closed {
+		err = &fs.PathError{Op: "sync", Path: f.name, Err: fs.ErrClosed}
+	}
+	return err
+}
+
+func (f *memFile) Chmod(mode fs.FileMode) (err error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+
+	if f.closed {
+		err = &fs.PathError{Op: "chmod", Path: f.name, Err: fs.ErrClosed}
+		goto end
+	}
+	f.node.mode = f.node.mode&^fs.ModePerm | mode.Perm()
+	f.fs.record(Op{Kind: ChmodOp, Name: f.name})
+
+end:
+	return err
+}
+
+// fileInfo implements fs.FileInfo for a node.
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi fileInfo) Sys() any           { return nil }
@@ -0,0 +1,88 @@
+package memfs_test
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg/memfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemFS verifies that files and directories behave as they would on
+// disk, with the same errors, and that every change is recorded in order.
+func TestMemFS(t *testing.T) {
+	var err error
+
+	mem := memfs.New()
+
+	_, err = mem.Create("missing/config.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist, "The parent directory must exist")
+
+	require.NoError(t, mem.MkdirAll("a/b", 0755))
+	f, err := mem.Create("a/b/config.json")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = mem.OpenFile("a/b/config.json", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	assert.ErrorIs(t, err, fs.ErrExist)
+
+	f, err = mem.OpenFile("a/b/config.json", os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte(" world"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	f, err = mem.Open("a/b/config.json")
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+	_, err = f.Write([]byte("x"))
+	assert.ErrorIs(t, err, fs.ErrPermission, "Files opened for reading cannot be written")
+	require.NoError(t, f.Close())
+
+	info, err := mem.Stat("a/b/config.json")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("hello world")), info.Size())
+	assert.Equal(t, fs.FileMode(0666), info.Mode())
+
+	require.NoError(t, mem.Rename("a/b", "a/c"))
+	entries, err := mem.ReadDir("a/c")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "config.json", entries[0].Name())
+	_, err = mem.Stat("a/b/config.json")
+	assert.ErrorIs(t, err, fs.ErrNotExist, "Renaming a directory should move its files")
+
+	err = mem.Remove("a")
+	assert.Error(t, err, "Non-empty directories cannot be removed")
+	require.NoError(t, mem.RemoveAll("a"))
+	require.NoError(t, mem.RemoveAll("a"), "Removing a missing tree is not an error")
+	err = mem.Remove("a")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = mem.Open("../escape")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+
+	var ops []string
+	for _, op := range mem.Ops() {
+		ops = append(ops, op.String())
+	}
+	assert.Equal(t, []string{
+		"mkdir a",
+		"mkdir a/b",
+		"create a/b/config.json",
+		"write a/b/config.json (5 bytes)",
+		"write a/b/config.json (6 bytes)",
+		"rename a/b a/c",
+		"removeall a",
+	}, ops)
+
+	mem.ResetOps()
+	assert.Empty(t, mem.Ops())
+}
@@ -0,0 +1,145 @@
+package scoutcfg
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// RotationPolicy controls when AppendWithRotation rotates a log file and how
+// many archives it keeps.
+type RotationPolicy struct {
+	MaxBytes int64 // Rotate before an append would grow the file beyond this size
+	Keep     int   // Number of archives to keep as <file>.1 (newest) through <file>.<Keep>
+}
+
+// AppendWithRotation appends content to the specified file like Append, but
+// first rotates the file when the append would grow it beyond
+// policy.MaxBytes: <file>.<Keep> is removed, each <file>.<n> is renamed to
+// <file>.<n+1>, and the file itself becomes <file>.1, so that content starts
+// a new file. With Keep set to 0 the old contents are discarded instead.
+// Only the current size is checked, using the file's metadata, so the log is
+// never read into memory. An empty file is never rotated, so content larger
+// than MaxBytes is still written, to a file of its own.
+//
+// The size check, rotation and append all happen while holding the file's
+// WithLock lock, so concurrent callers of AppendWithRotation, in this or
+// other processes, never lose or duplicate lines across a rotation. Writers
+// using plain Append do not take the lock and are not protected.
+//
+// Parameters:
+//   - filename: The relative path of the log within the configuration
+//     directory. Parent directories are created if they don't exist.
+//   - content: The bytes to append. No newline is added.
+//   - policy: When to rotate and how many archives to keep. MaxBytes must
+//     be positive and Keep must not be negative.
+//
+// Returns an error if:
+//   - The policy is invalid
+//   - The file path is invalid or cannot be created
+//   - The lock cannot be taken (see WithLock)
+//   - An archive cannot be removed or renamed
+//   - Writing or syncing the file fails
+//
+// Example usage:
+//
+//	err := store.AppendWithRotation("logs/activity.log", []byte(entry), scoutcfg.RotationPolicy{
+//		MaxBytes: 10 << 20,
+//		Keep:     5,
+//	})
+func (s *FileStore) AppendWithRotation(filename string, content []byte, policy RotationPolicy) (err error) {
+	var fsys FS
+
+	if policy.MaxBytes <= 0 || policy.Keep < 0 {
+		err = fmt.Errorf("invalid rotation policy for %s: MaxBytes must be positive and Keep not negative", filename)
+		goto end
+	}
+
+	fsys, err = s.ensureDir(filename)
+	if err != nil {
+		goto end
+	}
+
+	err = s.WithLock(filename, func() error {
+		return s.rotateAndAppend(fsys, filename, content, policy)
+	})
+
+end:
+	return err
+}
+
+// rotateAndAppend rotates the log per policy and then appends content; the
+// caller must hold the log's WithLock lock.
+func (s *FileStore) rotateAndAppend(fsys FS, filename string, content []byte, policy RotationPolicy) (err error) {
+	_, err = RotateLog(fsys, filename, int64(len(content)), policy)
+	if err != nil {
+		err = fmt.Errorf("rotating %s: %w", filename, err)
+		goto end
+	}
+
+	err = s.Append(filename, content)
+
+end:
+	return err
+}
+
+// RotateLog rotates the named log in fsys, if it exists and is not empty,
+// when appending incoming bytes would grow it beyond policy.MaxBytes. It is
+// the rotation AppendWithRotation performs, for callers rotating logs that
+// are not appended through a FileStore. No new file is created in place of
+// the rotated one.
+//
+// Parameters:
+//   - fsys: The filesystem holding the log, such as NewOSFS of its directory.
+//   - name: The name of the log within fsys.
+//   - incoming: The number of bytes about to be appended, or 0 to rotate
+//     only a log already larger than policy.MaxBytes.
+//   - policy: When to rotate and how many archives to keep.
+//
+// Returns whether the log was rotated, and an error if:
+//   - The log's metadata cannot be read
+//   - An archive cannot be removed or renamed
+func RotateLog(fsys FS, name string, incoming int64, policy RotationPolicy) (rotated bool, err error) {
+	var info fs.FileInfo
+
+	info, err = fsys.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+		goto end
+	}
+	if err != nil {
+		goto end
+	}
+	if info.Size() == 0 || info.Size()+incoming <= policy.MaxBytes {
+		goto end
+	}
+
+	if policy.Keep == 0 {
+		err = fsys.Remove(name)
+		rotated = err == nil
+		goto end
+	}
+
+	err = fsys.Remove(archiveName(name, policy.Keep))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		goto end
+	}
+
+	for n := policy.Keep - 1; n >= 1; n-- {
+		err = fsys.Rename(archiveName(name, n), archiveName(name, n+1))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			goto end
+		}
+	}
+
+	err = fsys.Rename(name, archiveName(name, 1))
+	rotated = err == nil
+
+end:
+	return rotated, err
+}
+
+// archiveName returns the name of the nth archive of the named log.
+func archiveName(name string, n int) string {
+	return fmt.Sprintf("%s.%d", name, n)
+}
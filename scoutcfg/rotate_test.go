@@ -0,0 +1,132 @@
+package scoutcfg_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileStore_AppendWithRotation verifies that the log is rotated before
+// an append would exceed MaxBytes, that archives shift from .1 upwards and
+// are pruned beyond Keep, and that content larger than MaxBytes still gets
+// written to a file of its own.
+func TestFileStore_AppendWithRotation(t *testing.T) {
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+	policy := scoutcfg.RotationPolicy{MaxBytes: 10, Keep: 2}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, "logs", name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		require.NoError(t, s.AppendWithRotation("logs/activity.log", []byte(line), policy))
+	}
+
+	assert.Equal(t, "gggg\n", read("activity.log"))
+	assert.Equal(t, "eeee\nffff\n", read("activity.log.1"))
+	assert.Equal(t, "cccc\ndddd\n", read("activity.log.2"))
+	assert.NoFileExists(t, filepath.Join(dir, "logs", "activity.log.3"), "Archives beyond Keep should be removed")
+
+	require.NoError(t, s.AppendWithRotation("logs/activity.log", []byte("a line longer than ten bytes\n"), policy))
+	assert.Equal(t, "a line longer than ten bytes\n", read("activity.log"))
+	assert.Equal(t, "gggg\n", read("activity.log.1"))
+
+	require.NoError(t, s.AppendWithRotation("logs/activity.log", []byte("x\n"), policy))
+	assert.Equal(t, "x\n", read("activity.log"), "An oversized file should be rotated on the next append")
+
+	discard := scoutcfg.RotationPolicy{MaxBytes: 5, Keep: 0}
+	require.NoError(t, s.AppendWithRotation("logs/debug.log", []byte("1234\n"), discard))
+	require.NoError(t, s.AppendWithRotation("logs/debug.log", []byte("5678\n"), discard))
+	assert.Equal(t, "5678\n", read("debug.log"))
+	assert.NoFileExists(t, filepath.Join(dir, "logs", "debug.log.1"), "Keep 0 should discard old contents")
+
+	err := s.AppendWithRotation("logs/activity.log", []byte("x\n"), scoutcfg.RotationPolicy{})
+	assert.Error(t, err, "A zero MaxBytes should be rejected")
+}
+
+// TestRotateLog verifies that RotateLog reports whether it rotated and that,
+// with no incoming bytes, only a log larger than MaxBytes is rotated.
+func TestRotateLog(t *testing.T) {
+	dir := t.TempDir()
+	fsys := scoutcfg.NewOSFS(dir)
+	policy := scoutcfg.RotationPolicy{MaxBytes: 10, Keep: 1}
+
+	rotated, err := scoutcfg.RotateLog(fsys, "missing.log", 0, policy)
+	require.NoError(t, err)
+	assert.False(t, rotated, "A missing log should not be rotated")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.log"), []byte("0123456789"), 0600))
+	rotated, err = scoutcfg.RotateLog(fsys, "app.log", 0, policy)
+	require.NoError(t, err)
+	assert.False(t, rotated, "A log at MaxBytes should not be rotated")
+
+	rotated, err = scoutcfg.RotateLog(fsys, "app.log", 1, policy)
+	require.NoError(t, err)
+	assert.True(t, rotated, "A log that would exceed MaxBytes should be rotated")
+	assert.NoFileExists(t, filepath.Join(dir, "app.log"), "No new log should be created")
+	assert.FileExists(t, filepath.Join(dir, "app.log.1"))
+}
+
+// TestFileStore_AppendWithRotationConcurrent verifies that concurrent
+// appends across rotations neither lose nor duplicate lines.
+func TestFileStore_AppendWithRotationConcurrent(t *testing.T) {
+	const writers = 8
+	const linesPerWriter = 25
+
+	dir := t.TempDir()
+	policy := scoutcfg.RotationPolicy{MaxBytes: 200, Keep: 100}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*linesPerWriter)
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := scoutcfg.NewFileStore("test-app")
+			s.SetBaseDir(dir)
+			for i := range linesPerWriter {
+				errs <- s.AppendWithRotation("activity.log", fmt.Appendf(nil, "writer %d line %02d\n", w, i), policy)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "activity.log*"))
+	require.NoError(t, err)
+	var lines []string
+	for _, fp := range matches {
+		if strings.HasSuffix(fp, ".lock") {
+			continue
+		}
+		content, err := os.ReadFile(fp)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(content), int(policy.MaxBytes), "No file should exceed MaxBytes")
+		lines = append(lines, strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")...)
+	}
+	sort.Strings(lines)
+
+	var expected []string
+	for w := range writers {
+		for i := range linesPerWriter {
+			expected = append(expected, fmt.Sprintf("writer %d line %02d", w, i))
+		}
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, lines, "Every line should be written exactly once")
+}
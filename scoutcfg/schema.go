@@ -0,0 +1,178 @@
+package scoutcfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaResourceURL is the name the schema passed to LoadWithSchema is
+// compiled under; $ref values without a host resolve against it.
+const schemaResourceURL = "scoutcfg://schema.json"
+
+// SchemaViolation is a single way in which a configuration file fails to
+// match its JSON Schema.
+type SchemaViolation struct {
+	Path    string // JSON Pointer to the offending value, such as /server/port, or / for the whole document
+	Message string // What is wrong, such as "missing properties: 'name'"
+}
+
+// SchemaValidationError is returned by LoadWithSchema when a file does not
+// match its schema. It lists every violation, so users can fix them all at
+// once; use errors.As to inspect them.
+type SchemaValidationError struct {
+	Filename   string            // The file that was validated
+	Violations []SchemaViolation // Each violation, sorted by path
+}
+
+// Error lists the file and each violation, one per line.
+func (e *SchemaValidationError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s does not match its schema:", e.Filename)
+	for _, v := range e.Violations {
+		fmt.Fprintf(&sb, "\n  %s: %s", v.Path, v.Message)
+	}
+	return sb.String()
+}
+
+// LoadWithSchema reads the specified file like Load, but validates its
+// contents against a JSON Schema before unmarshaling them into data, so that
+// a hand-edited file that parses but is semantically wrong, such as one with
+// a missing required field or an unknown enum value, is rejected with
+// precise feedback rather than failing later inside the application.
+//
+// The file is decoded with the same codec Load would use, so YAML and TOML
+// files are validated as the equivalent JSON document, and JSON5 leniency
+// applies when enabled with SetJSON5. data is not modified when validation
+// fails.
+//
+// Parameters:
+//   - filename: The relative path within the configuration directory to read.
+//   - schema: The JSON Schema document. Drafts 4 through 2020-12 are
+//     supported; the draft is taken from $schema, defaulting to 2020-12.
+//   - data: A pointer to the data structure to unmarshal into.
+//
+// Returns an error if:
+//   - The file cannot be read or parsed (see Load)
+//   - The schema is not valid JSON or not a valid JSON Schema
+//   - The file does not match the schema (a *SchemaValidationError listing
+//     each violating path)
+//   - The data structure doesn't match the file's contents
+//
+// Example usage:
+//
+//	var config MyConfig
+//	err := store.LoadWithSchema("config.json", configSchema, &config)
+//	var invalid *scoutcfg.SchemaValidationError
+//	if errors.As(err, &invalid) {
+//		for _, v := range invalid.Violations {
+//			log.Printf("config.json%s: %s", v.Path, v.Message)
+//		}
+//	}
+func (s *FileStore) LoadWithSchema(filename string, schema []byte, data any) (err error) {
+	var encoded, document []byte
+	var codec Codec
+	var fsys FS
+	var compiled *jsonschema.Schema
+	var compiler *jsonschema.Compiler
+	var instance any
+	var decoder *json.Decoder
+	var invalid *jsonschema.ValidationError
+
+	compiler = jsonschema.NewCompiler()
+	err = compiler.AddResource(schemaResourceURL, bytes.NewReader(schema))
+	if err == nil {
+		compiled, err = compiler.Compile(schemaResourceURL)
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid schema for %s: %w", filename, err)
+		goto end
+	}
+
+	fsys, err = s.getFS()
+	if err != nil {
+		goto end
+	}
+
+	encoded, err = readFile(fsys, filename)
+	if err != nil {
+		goto end
+	}
+
+	codec = s.codecFor(filename)
+	if s.json5 && codec == JSONCodec {
+		encoded, err = normalizeJSON5(encoded)
+		if err != nil {
+			err = fmt.Errorf("parsing %s as JSON5: %w", filename, err)
+			goto end
+		}
+	}
+
+	// Validate the document as JSON whatever its codec, keeping numbers exact
+	document = encoded
+	if codec != JSONCodec {
+		err = codec.Unmarshal(encoded, &instance)
+		if err != nil {
+			goto end
+		}
+		document, err = json.Marshal(instance)
+		if err != nil {
+			err = fmt.Errorf("converting %s to JSON for validation: %w", filename, err)
+			goto end
+		}
+	}
+	decoder = json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	err = decoder.Decode(&instance)
+	if err != nil {
+		goto end
+	}
+
+	err = compiled.Validate(instance)
+	if errors.As(err, &invalid) {
+		err = &SchemaValidationError{
+			Filename:   filename,
+			Violations: schemaViolations(invalid),
+		}
+	}
+	if err != nil {
+		goto end
+	}
+
+	err = codec.Unmarshal(encoded, data)
+
+end:
+	return err
+}
+
+// schemaViolations returns the leaf causes of a validation error, which
+// describe what is actually wrong, sorted by path and then message.
+func schemaViolations(ve *jsonschema.ValidationError) (violations []SchemaViolation) {
+	var collect func(*jsonschema.ValidationError)
+
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				collect(cause)
+			}
+			return
+		}
+		path := e.InstanceLocation
+		if path == "" {
+			path = "/"
+		}
+		violations = append(violations, SchemaViolation{Path: path, Message: e.Message})
+	}
+	collect(ve)
+
+	slices.SortStableFunc(violations, func(a, b SchemaViolation) int {
+		return strings.Compare(a.Path+"\x00"+a.Message, b.Path+"\x00"+b.Message)
+	})
+	return slices.Compact(violations)
+}
@@ -0,0 +1,89 @@
+package scoutcfg_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaConfig is the configuration validated by testSchema.
+type schemaConfig struct {
+	Name  string `json:"name" yaml:"name"`
+	Mode  string `json:"mode" yaml:"mode"`
+	Port  int    `json:"port" yaml:"port"`
+	Hosts []struct {
+		Addr string `json:"addr" yaml:"addr"`
+	} `json:"hosts" yaml:"hosts"`
+}
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["name", "port"],
+	"properties": {
+		"name": {"type": "string"},
+		"mode": {"enum": ["fast", "safe"]},
+		"port": {"type": "integer", "minimum": 1},
+		"hosts": {
+			"type": "array",
+			"items": {"type": "object", "required": ["addr"]}
+		}
+	}
+}`
+
+// TestFileStore_LoadWithSchema verifies that a valid file is loaded, that
+// an invalid one is rejected with every violating path listed and data left
+// untouched, that YAML files are validated too, and that an invalid schema
+// is reported.
+func TestFileStore_LoadWithSchema(t *testing.T) {
+	var err error
+
+	dir := t.TempDir()
+	s := scoutcfg.NewFileStore("test-app")
+	s.SetBaseDir(dir)
+
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	write("valid.json", `{"name": "scout", "mode": "fast", "port": 8080, "hosts": [{"addr": "a"}]}`)
+	var config schemaConfig
+	err = s.LoadWithSchema("valid.json", []byte(testSchema), &config)
+	require.NoError(t, err)
+	assert.Equal(t, "scout", config.Name)
+	assert.Equal(t, 8080, config.Port)
+
+	write("invalid.json", `{"mode": "slow", "port": 0, "hosts": [{"addr": "a"}, {}]}`)
+	var untouched schemaConfig
+	err = s.LoadWithSchema("invalid.json", []byte(testSchema), &untouched)
+	require.Error(t, err)
+	var invalid *scoutcfg.SchemaValidationError
+	require.True(t, errors.As(err, &invalid), "Should return a SchemaValidationError")
+	assert.Equal(t, "invalid.json", invalid.Filename)
+	paths := make([]string, 0, len(invalid.Violations))
+	for _, v := range invalid.Violations {
+		paths = append(paths, v.Path)
+	}
+	assert.Equal(t, []string{"/", "/hosts/1", "/mode", "/port"}, paths)
+	assert.Contains(t, err.Error(), "invalid.json does not match its schema:")
+	assert.Contains(t, err.Error(), "/: missing properties: 'name'")
+	assert.Equal(t, schemaConfig{}, untouched, "Data should not be modified when validation fails")
+
+	write("invalid.yaml", "name: scout\nport: \"8080\"\n")
+	err = s.LoadWithSchema("invalid.yaml", []byte(testSchema), &config)
+	require.True(t, errors.As(err, &invalid), "YAML should be validated as JSON")
+	require.Len(t, invalid.Violations, 1)
+	assert.Equal(t, "/port", invalid.Violations[0].Path)
+
+	err = s.LoadWithSchema("valid.json", []byte(`{"type": 42}`), &config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schema for valid.json")
+
+	err = s.LoadWithSchema("missing.json", []byte(testSchema), &config)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
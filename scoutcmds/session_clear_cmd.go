@@ -32,6 +32,11 @@ func init() {
 }
 
 func (c *SessionClearCmd) Handle(ctx context.Context, config cliutil.Config, args []string) (err error) {
+	err = useSessionStores()
+	if err != nil {
+		goto end
+	}
+
 	switch *sessionTokenArg {
 	case "all":
 		err = mcputil.ClearSessions(mcputil.AllSessions)
@@ -25,6 +25,11 @@ func (c *SessionListCmd) Handle(ctx context.Context, config cliutil.Config, args
 	var err error
 	var sessions []mcputil.Session
 
+	err = useSessionStores()
+	if err != nil {
+		goto end
+	}
+
 	sessions = mcputil.ListSessions()
 
 	if len(sessions) == 0 {
@@ -25,6 +25,11 @@ func (c *SessionNewCmd) Handle(ctx context.Context, config cliutil.Config, args
 	var err error
 	var session *mcputil.Session
 
+	err = useSessionStores()
+	if err != nil {
+		goto end
+	}
+
 	session = mcputil.NewSession()
 	err = session.Initialize()
 	if err != nil {
@@ -36,6 +36,11 @@ func (c *SessionShowCmd) Handle(ctx context.Context, config cliutil.Config, args
 	var session *mcputil.Session
 	var exists bool
 
+	err = useSessionStores()
+	if err != nil {
+		goto end
+	}
+
 	session, exists = mcputil.GetSession(*showTokenArg)
 	if !exists {
 		cliutil.Printf("Session not found: %s\n", *showTokenArg)
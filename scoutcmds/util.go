@@ -6,6 +6,8 @@ import (
 
 	"github.com/mikeschinkel/scout-mcp"
 	"github.com/mikeschinkel/scout-mcp/cliutil"
+	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 )
 
 // convertConfig converts CLI config to Scout domain config
@@ -26,6 +28,20 @@ end:
 	return opts, err
 }
 
+// useSessionStores points the session commands at the stores the MCP server
+// persists session tokens and edit backups in, so that they see and clear the
+// sessions the server started.
+func useSessionStores() (err error) {
+	err = mcputil.SetEditBackups(scoutcfg.NewFileStore(scout.AppName), 0)
+	if err != nil {
+		goto end
+	}
+	err = mcputil.SetSessionStore(scoutcfg.NewFileStore(scout.AppName))
+
+end:
+	return err
+}
+
 func fprintf(w io.Writer, format string, args ...any) {
 	_, _ = fmt.Fprintf(w, format, args...)
 }
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// apiReadinessArgs represents arguments for the api_readiness tool.
+type apiReadinessArgs struct {
+	Path string `json:"path"`
+}
+
+// TestAPIReadinessToolWithJSONRPC tests the api_readiness tool via JSON-RPC.
+func TestAPIReadinessToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("api-readiness-jsonrpc-test")
+
+	fixture.AddFileFixture("calc.go", &fsfix.FileFixtureArgs{
+		Content: "// Package calc does arithmetic.\npackage calc\n\n// Add returns a plus b.\nfunc Add(a, b int) int { return a + b }\n\nfunc Sub(a, b int) int { return a - b }\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "api_readiness",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Package": {
+				{
+					arguments: apiReadinessArgs{
+						Path: "calc.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|identifier_count":      2,
+						"result.content.0.text|json()|identifiers.0.name":    "Sub",
+						"result.content.0.text|json()|identifiers.0.has_doc": false,
+						"result.content.0.text|json()|identifiers.1.score":   50,
+					},
+				},
+			},
+		},
+	})
+}
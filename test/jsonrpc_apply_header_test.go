@@ -0,0 +1,54 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// applyHeaderArgs represents arguments for the apply_header tool.
+type applyHeaderArgs struct {
+	Path           string   `json:"path"`
+	Extensions     []string `json:"extensions"`
+	HeaderTemplate string   `json:"header_template"`
+	DryRun         bool     `json:"dry_run,omitempty"`
+}
+
+// TestApplyHeaderToolWithJSONRPC tests the apply_header tool via JSON-RPC.
+func TestApplyHeaderToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("apply-header-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n",
+	})
+	fixture.AddFileFixture("util.go", &fsfix.FileFixtureArgs{
+		Content: "// Copyright 2019 Acme Inc.\n\npackage main\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "apply_header",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"InsertAndUpdate": {
+				{
+					arguments: applyHeaderArgs{
+						Path:           ".",
+						Extensions:     []string{".go"},
+						HeaderTemplate: "// Copyright 2024 Acme Inc.",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|inserted_count": 1,
+						"result.content.0.text|json()|updated_count":  1,
+					},
+				},
+			},
+		},
+	})
+}
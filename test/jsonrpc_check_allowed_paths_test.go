@@ -0,0 +1,25 @@
+package test
+
+import "testing"
+
+// TestCheckAllowedPathsToolWithJSONRPC tests the check_allowed_paths tool via JSON-RPC.
+func TestCheckAllowedPathsToolWithJSONRPC(t *testing.T) {
+	RunJSONRPCTest(t, nil, test{
+		name: "check_allowed_paths",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|path_count|exists()": true,
+			"result.content.0.text|json()|summary|exists()":    true,
+		},
+		subtests: map[string][]subtest{
+			GoFile: {
+				{
+					arguments: sessionTokenArgs{},
+					expected:  nil,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkGoModuleArgs represents arguments for the check_go_module tool.
+type checkGoModuleArgs struct {
+	Path string `json:"path"`
+}
+
+// TestCheckGoModuleToolWithJSONRPC tests the check_go_module tool via JSON-RPC.
+func TestCheckGoModuleToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-go-module-jsonrpc-test")
+
+	fixture.AddFileFixture("go.mod", &fsfix.FileFixtureArgs{
+		Content: "module example.com/app\n\ngo 1.24.0\n\nrequire github.com/pkg/errors v0.9.1\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_go_module",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"GoMod": {
+				{
+					arguments: checkGoModuleArgs{
+						Path: "go.mod",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|module":     "example.com/app",
+						"result.content.0.text|json()|go_version": "1.24.0",
+						"result.content.0.text|json()|valid":      true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkGofmtArgs represents arguments for the check_gofmt tool.
+type checkGofmtArgs struct {
+	Path string `json:"path"`
+}
+
+// TestCheckGofmtToolWithJSONRPC tests the check_gofmt tool via JSON-RPC.
+func TestCheckGofmtToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-gofmt-jsonrpc-test")
+
+	fixture.AddFileFixture("clean.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nfunc main() {}\n",
+	})
+	fixture.AddFileFixture("messy.go", &fsfix.FileFixtureArgs{
+		Content: "package main\nvar x=1\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_gofmt",
+		arguments: checkGofmtArgs{
+			Path: ".",
+		},
+		expected: map[string]any{
+			"jsonrpc":                                        "2.0",
+			"result.content.#":                               1,
+			"result.content.0.type":                          "text",
+			"result.content.0.text|json()|clean":             false,
+			"result.content.0.text|json()|unformatted_count": 1,
+			"result.content.0.text|json()|files_checked":     2,
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkImportOrderArgs represents arguments for the check_import_order tool.
+type checkImportOrderArgs struct {
+	Path string `json:"path"`
+	Fix  bool   `json:"fix,omitempty"`
+}
+
+// TestCheckImportOrderToolWithJSONRPC tests the check_import_order tool via JSON-RPC.
+func TestCheckImportOrderToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-import-order-jsonrpc-test")
+
+	fixture.AddFileFixture("go.mod", &fsfix.FileFixtureArgs{
+		Content: "module example.com/app\n\ngo 1.24\n",
+	})
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport (\n\t\"os\"\n\t\"fmt\"\n)\n\nfunc main() { fmt.Println(os.Args) }\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_import_order",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Unsorted": {
+				{
+					arguments: checkImportOrderArgs{
+						Path: "main.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|file_count":               1,
+						"result.content.0.text|json()|files.0.issues.0.line":    5,
+						"result.content.0.text|json()|files.0.issues.0.message": `import "fmt" should sort before "os"`,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkJSONConsistencyArgs represents arguments for the check_json_consistency tool.
+type checkJSONConsistencyArgs struct {
+	Paths []string `json:"paths"`
+}
+
+// TestCheckJSONConsistencyToolWithJSONRPC tests the check_json_consistency tool via JSON-RPC.
+func TestCheckJSONConsistencyToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-json-consistency-jsonrpc-test")
+
+	fixture.AddFileFixture("dev.json", &fsfix.FileFixtureArgs{
+		Content: `{"port": 8080, "debug": true, "db": {"host": "localhost"}}`,
+	})
+	fixture.AddFileFixture("prod.json", &fsfix.FileFixtureArgs{
+		Content: `{"port": 443, "db": {"host": "db.example.com"}}`,
+	})
+
+	fixture.AddFileFixture("staging.json", &fsfix.FileFixtureArgs{
+		Content: `{"db": {"host": "staging.example.com"}, "port": 8443}`,
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_json_consistency",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"DriftedKeys": {
+				{
+					arguments: checkJSONConsistencyArgs{
+						Paths: []string{"dev.json", "prod.json"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|consistent":                 false,
+						"result.content.0.text|json()|difference_count":           1,
+						"result.content.0.text|json()|differences.0.key":          "debug",
+						"result.content.0.text|json()|differences.0.present_in.#": 1,
+					},
+				},
+			},
+			"SameShape": {
+				{
+					arguments: checkJSONConsistencyArgs{
+						Paths: []string{"prod.json", "staging.json"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|consistent":       true,
+						"result.content.0.text|json()|difference_count": 0,
+					},
+				},
+			},
+		},
+	})
+}
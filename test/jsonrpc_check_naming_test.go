@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkNamingArgs represents arguments for the check_naming tool.
+type checkNamingArgs struct {
+	Path     string   `json:"path"`
+	Language string   `json:"language"`
+	Acronyms []string `json:"acronyms,omitempty"`
+}
+
+// TestCheckNamingToolWithJSONRPC tests the check_naming tool via JSON-RPC.
+func TestCheckNamingToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-naming-jsonrpc-test")
+
+	fixture.AddFileFixture("client.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\ntype HttpClient struct {\n\tGrpcAddr string\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_naming",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"DefaultAcronyms": {
+				{
+					arguments: checkNamingArgs{
+						Path:     "client.go",
+						Language: "go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|issue_count":   1,
+						"result.content.0.text|json()|issues.0.line": 3,
+						"result.content.0.text|json()|issues.0.name": "HttpClient",
+						"result.content.0.text|json()|issues.0.rule": "acronym",
+					},
+				},
+			},
+			"CustomAcronyms": {
+				{
+					arguments: checkNamingArgs{
+						Path:     "client.go",
+						Language: "go",
+						Acronyms: []string{"GRPC"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|issue_count":   2,
+						"result.content.0.text|json()|issues.1.name": "GrpcAddr",
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// checkStructTagsArgs represents arguments for the check_struct_tags tool.
+type checkStructTagsArgs struct {
+	Path string `json:"path"`
+}
+
+// TestCheckStructTagsToolWithJSONRPC tests the check_struct_tags tool via JSON-RPC.
+func TestCheckStructTagsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("check-struct-tags-jsonrpc-test")
+
+	fixture.AddFileFixture("user.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\ntype User struct {\n\tName string `json:\"name\" json:\"full_name\"`\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "check_struct_tags",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"DuplicateKey": {
+				{
+					arguments: checkStructTagsArgs{
+						Path: "user.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|issue_count":    1,
+						"result.content.0.text|json()|issues.0.line":  4,
+						"result.content.0.text|json()|issues.0.field": "Name",
+						"result.content.0.text|json()|issues.0.key":   "json",
+					},
+				},
+			},
+		},
+	})
+}
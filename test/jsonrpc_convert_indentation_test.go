@@ -0,0 +1,52 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// convertIndentationArgs represents arguments for the convert_indentation tool.
+type convertIndentationArgs struct {
+	Path  string `json:"path"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Width int    `json:"width,omitempty"`
+}
+
+// TestConvertIndentationToolWithJSONRPC tests the convert_indentation tool via JSON-RPC.
+func TestConvertIndentationToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("convert-indentation-jsonrpc-test")
+
+	fixture.AddFileFixture("config.yaml", &fsfix.FileFixtureArgs{
+		Content: "root:\n\tchild: true\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "convert_indentation",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"TabsToSpaces": {
+				{
+					arguments: convertIndentationArgs{
+						Path:  "config.yaml",
+						From:  "tabs",
+						To:    "spaces",
+						Width: 2,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|lines_changed":   1,
+						"result.content.0.text|json()|changed_lines.0": 2,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// convertLineEndingsArgs represents arguments for the convert_line_endings tool.
+type convertLineEndingsArgs struct {
+	Path   string `json:"path"`
+	To     string `json:"to"`
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+// TestConvertLineEndingsToolWithJSONRPC tests the convert_line_endings tool via JSON-RPC.
+func TestConvertLineEndingsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("convert-line-endings-jsonrpc-test")
+
+	fixture.AddFileFixture("windows.txt", &fsfix.FileFixtureArgs{
+		Content: "line one\r\nline two\r\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "convert_line_endings",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"DryRunToLF": {
+				{
+					arguments: convertLineEndingsArgs{
+						Path:   "windows.txt",
+						To:     "lf",
+						DryRun: true,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|changed_count": 1,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// createFilesArgs represents arguments for the create_files tool.
+type createFilesArgs struct {
+	Files      []createFilesEntry `json:"files"`
+	CreateDirs bool               `json:"create_dirs"`
+}
+
+// createFilesEntry is a single file for the create_files tool.
+type createFilesEntry struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// TestCreateFilesToolWithJSONRPC tests the create_files tool via JSON-RPC.
+func TestCreateFilesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("create-files-jsonrpc-test")
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "create_files",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Scaffold": {
+				{
+					arguments: createFilesArgs{
+						Files: []createFilesEntry{
+							{Path: filepath.Join(fixture.TempDir(), "go.mod"), Content: "module example\n"},
+							{Path: filepath.Join(fixture.TempDir(), "cmd", "main.go"), Content: "package main\n"},
+						},
+						CreateDirs: true,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|success":    true,
+						"result.content.0.text|json()|file_count": 2,
+					},
+				},
+			},
+		},
+	})
+}
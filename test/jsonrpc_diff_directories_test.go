@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// diffDirectoriesArgs represents arguments for the diff_directories tool.
+type diffDirectoriesArgs struct {
+	PathA        string `json:"path_a"`
+	PathB        string `json:"path_b"`
+	IncludeDiffs bool   `json:"include_diffs,omitempty"`
+}
+
+// TestDiffDirectoriesToolWithJSONRPC tests the diff_directories tool via JSON-RPC.
+func TestDiffDirectoriesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("diff-directories-jsonrpc-test")
+
+	a := fixture.AddDirFixture("a", nil)
+	a.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{Content: "before\n"})
+	b := fixture.AddDirFixture("b", nil)
+	b.AddFileFixture("file.txt", &fsfix.FileFixtureArgs{Content: "after\n"})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "diff_directories",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"ChangedFile": {
+				{
+					arguments: diffDirectoriesArgs{
+						PathA:        "a",
+						PathB:        "b",
+						IncludeDiffs: true,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|changed.0.path": "file.txt",
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// diffSymbolsArgs represents arguments for the diff_symbols tool.
+type diffSymbolsArgs struct {
+	Path            string   `json:"path"`
+	Language        string   `json:"language"`
+	ExpectedSymbols []string `json:"expected_symbols"`
+}
+
+// TestDiffSymbolsToolWithJSONRPC tests the diff_symbols tool via JSON-RPC.
+func TestDiffSymbolsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("diff-symbols-jsonrpc-test")
+
+	fixture.AddFileFixture("shapes.go", &fsfix.FileFixtureArgs{
+		Content: "package shapes\n\ntype Circle struct{}\n\nfunc (Circle) Area() float64 { return 0 }\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "diff_symbols",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Unchanged": {
+				{
+					arguments: diffSymbolsArgs{
+						Path:            "shapes.go",
+						Language:        "go",
+						ExpectedSymbols: []string{"Circle", "Circle.Area"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|matches": true,
+					},
+				},
+			},
+			"Renamed": {
+				{
+					arguments: diffSymbolsArgs{
+						Path:            "shapes.go",
+						Language:        "go",
+						ExpectedSymbols: []string{"Circle", "Circle.Size"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|matches":        false,
+						"result.content.0.text|json()|renamed.0.from": "Circle.Size",
+						"result.content.0.text|json()|renamed.0.to":   "Circle.Area",
+					},
+				},
+			},
+		},
+	})
+}
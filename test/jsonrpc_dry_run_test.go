@@ -0,0 +1,43 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// dryRunReplacePatternArgs represents replace_pattern arguments with dry_run set.
+type dryRunReplacePatternArgs struct {
+	Path        string `json:"path"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+	DryRun      bool   `json:"dry_run"`
+}
+
+// TestDryRunPreviewWithJSONRPC tests previewing a mutating tool via JSON-RPC.
+func TestDryRunPreviewWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("dry-run-jsonrpc-test")
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nfunc main() {\n\tprintln(\"Hello\")\n}\n",
+	})
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "replace_pattern",
+		arguments: dryRunReplacePatternArgs{
+			Path:        "main.go",
+			Pattern:     "Hello",
+			Replacement: "Goodbye",
+			DryRun:      true,
+		},
+		expected: map[string]any{
+			"jsonrpc":                                        "2.0",
+			"result.content.#":                               1,
+			"result.content.0.type":                          "text",
+			"result.content.0.text|json()|dry_run":           true,
+			"result.content.0.text|json()|file_count":        1,
+			"result.content.0.text|json()|files.0.operation": "updated",
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// extractFunctionArgs represents arguments for the extract_function tool.
+type extractFunctionArgs struct {
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	NewFuncName string `json:"new_func_name"`
+}
+
+// TestExtractFunctionToolWithJSONRPC tests the extract_function tool via JSON-RPC.
+func TestExtractFunctionToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("extract-function-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n\tfmt.Println(\"world\")\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "extract_function",
+		arguments: extractFunctionArgs{
+			Path:        "main.go",
+			StartLine:   6,
+			EndLine:     7,
+			NewFuncName: "greet",
+		},
+		expected: map[string]any{
+			"jsonrpc":                                    "2.0",
+			"result.content.#":                           1,
+			"result.content.0.type":                      "text",
+			"result.content.0.text|json()|success":       true,
+			"result.content.0.text|json()|new_func_name": "greet",
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// extractStringsArgs represents arguments for the extract_strings tool.
+type extractStringsArgs struct {
+	Path string `json:"path"`
+}
+
+// TestExtractStringsToolWithJSONRPC tests the extract_strings tool via JSON-RPC.
+func TestExtractStringsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("extract-strings-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello\")\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "extract_strings",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"SingleFile": {
+				{
+					arguments: extractStringsArgs{
+						Path: "main.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|count":           1,
+						"result.content.0.text|json()|strings.0.value": "Hello",
+						"result.content.0.text|json()|strings.0.line":  6,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// fileCallGraphArgs represents arguments for the file_call_graph tool.
+type fileCallGraphArgs struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+}
+
+// TestFileCallGraphToolWithJSONRPC tests the file_call_graph tool via JSON-RPC.
+func TestFileCallGraphToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("file-call-graph-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nfunc main() {\n\tgreet()\n}\n\nfunc greet() {}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "file_call_graph",
+		arguments: fileCallGraphArgs{
+			Path:     "main.go",
+			Language: "go",
+		},
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|function_count":             2,
+			"result.content.0.text|json()|functions.0.calls.0.callee": "greet",
+			"result.content.0.text|json()|functions.0.calls.0.line":   4,
+		},
+	})
+}
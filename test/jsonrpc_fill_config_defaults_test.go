@@ -0,0 +1,51 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// fillConfigDefaultsArgs represents arguments for the fill_config_defaults tool.
+type fillConfigDefaultsArgs struct {
+	Path           string   `json:"path"`
+	Defaults       string   `json:"defaults"`
+	RequiredFields []string `json:"required_fields,omitempty"`
+}
+
+// TestFillConfigDefaultsToolWithJSONRPC tests the fill_config_defaults tool via JSON-RPC.
+func TestFillConfigDefaultsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("fill-config-defaults-jsonrpc-test")
+
+	fixture.AddFileFixture("config.json", &fsfix.FileFixtureArgs{
+		Content: `{"name": "custom"}`,
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "fill_config_defaults",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"FillDefaults": {
+				{
+					arguments: fillConfigDefaultsArgs{
+						Path:           "config.json",
+						Defaults:       `{"name": "scout", "port": 8080}`,
+						RequiredFields: []string{"name"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|written":            true,
+						"result.content.0.text|json()|applied_defaults.0": "port",
+						"result.content.0.text|json()|applied_count":      1,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findDuplicateBlocksArgs represents arguments for the find_duplicate_blocks tool.
+type findDuplicateBlocksArgs struct {
+	Path     string `json:"path"`
+	MinLines int    `json:"min_lines,omitempty"`
+}
+
+// TestFindDuplicateBlocksToolWithJSONRPC tests the find_duplicate_blocks tool via JSON-RPC.
+func TestFindDuplicateBlocksToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-duplicate-blocks-jsonrpc-test")
+
+	fixture.AddFileFixture("deploy.sh", &fsfix.FileFixtureArgs{
+		Content: "build app\ntest app\npush app\n\necho done\n\nbuild app\ntest app\npush app\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_duplicate_blocks",
+		arguments: findDuplicateBlocksArgs{
+			Path:     "deploy.sh",
+			MinLines: 3,
+		},
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|duplicate_count":                       1,
+			"result.content.0.text|json()|duplicates.0.lines":                    3,
+			"result.content.0.text|json()|duplicates.0.occurrences.1.start_line": 7,
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findLargeFunctionsArgs represents arguments for the find_large_functions tool.
+type findLargeFunctionsArgs struct {
+	Path     string `json:"path"`
+	MinLines int    `json:"min_lines,omitempty"`
+}
+
+// TestFindLargeFunctionsToolWithJSONRPC tests the find_large_functions tool via JSON-RPC.
+func TestFindLargeFunctionsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-large-functions-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println()\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_large_functions",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"SingleFile": {
+				{
+					arguments: findLargeFunctionsArgs{
+						Path:     "main.go",
+						MinLines: 3,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|function_count":    1,
+						"result.content.0.text|json()|functions.0.func":  "main",
+						"result.content.0.text|json()|functions.0.lines": 3,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findLongLinesArgs represents arguments for the find_long_lines tool.
+type findLongLinesArgs struct {
+	Paths      []string `json:"paths"`
+	MaxLength  int      `json:"max_length,omitempty"`
+	IgnoreURLs bool     `json:"ignore_urls,omitempty"`
+}
+
+// TestFindLongLinesToolWithJSONRPC tests the find_long_lines tool via JSON-RPC.
+func TestFindLongLinesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-long-lines-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\n// See https://example.com/docs\nfunc main() {}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_long_lines",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"MaxLength": {
+				{
+					arguments: findLongLinesArgs{
+						Paths:     []string{"."},
+						MaxLength: 14,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|line_count":     1,
+						"result.content.0.text|json()|lines.0.line":   3,
+						"result.content.0.text|json()|lines.0.length": 31,
+					},
+				},
+			},
+			"IgnoreURLs": {
+				{
+					arguments: findLongLinesArgs{
+						Paths:      []string{"."},
+						MaxLength:  14,
+						IgnoreURLs: true,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|line_count": 0,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findNoFinalNewlineArgs represents arguments for the find_no_final_newline tool.
+type findNoFinalNewlineArgs struct {
+	Paths      []string `json:"paths"`
+	Extensions []string `json:"extensions,omitempty"`
+	Fix        bool     `json:"fix,omitempty"`
+}
+
+// TestFindNoFinalNewlineToolWithJSONRPC tests the find_no_final_newline tool via JSON-RPC.
+func TestFindNoFinalNewlineToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-no-final-newline-jsonrpc-test")
+
+	fixture.AddFileFixture("good.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n",
+	})
+	fixture.AddFileFixture("bad.go", &fsfix.FileFixtureArgs{
+		Content: "package main",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_no_final_newline",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"ReportOnly": {
+				{
+					arguments: findNoFinalNewlineArgs{
+						Paths:      []string{"."},
+						Extensions: []string{".go"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|missing_count": 1,
+						"result.content.0.text|json()|fixed_count":   0,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findSymbolArgs represents arguments for the find_symbol tool.
+type findSymbolArgs struct {
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Kind     string `json:"kind,omitempty"`
+}
+
+// TestFindSymbolToolWithJSONRPC tests the find_symbol tool via JSON-RPC.
+func TestFindSymbolToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-symbol-jsonrpc-test")
+
+	fixture.AddFileFixture("shapes/shape.go", &fsfix.FileFixtureArgs{
+		Content: "package shapes\n\ntype Shape interface {\n\tArea() float64\n}\n",
+	})
+	fixture.AddFileFixture("draw/draw.go", &fsfix.FileFixtureArgs{
+		Content: "package draw\n\nfunc Shape() {}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_symbol",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"TypeOnly": {
+				{
+					arguments: findSymbolArgs{
+						Name:     "Shape",
+						Language: "go",
+						Kind:     "type",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|total":          1,
+						"result.content.0.text|json()|matches.0.kind": "type",
+						"result.content.0.text|json()|matches.0.line": 3,
+						"result.content.0.text|json()|has_more":       false,
+					},
+				},
+			},
+		},
+	})
+}
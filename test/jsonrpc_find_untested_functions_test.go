@@ -0,0 +1,50 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// findUntestedFunctionsArgs represents arguments for the find_untested_functions tool.
+type findUntestedFunctionsArgs struct {
+	Path string `json:"path"`
+}
+
+// TestFindUntestedFunctionsToolWithJSONRPC tests the find_untested_functions tool via JSON-RPC.
+func TestFindUntestedFunctionsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("find-untested-functions-jsonrpc-test")
+
+	fixture.AddFileFixture("calc.go", &fsfix.FileFixtureArgs{
+		Content: "package calc\n\nfunc Add(a, b int) int { return a + b }\n\nfunc Sub(a, b int) int { return a - b }\n",
+	})
+	fixture.AddFileFixture("calc_test.go", &fsfix.FileFixtureArgs{
+		Content: "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "find_untested_functions",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Package": {
+				{
+					arguments: findUntestedFunctionsArgs{
+						Path: "calc.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|untested_count":  1,
+						"result.content.0.text|json()|untested.0.func": "Sub",
+						"result.content.0.text|json()|untested.0.line": 5,
+					},
+				},
+			},
+		},
+	})
+}
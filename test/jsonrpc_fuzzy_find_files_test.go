@@ -0,0 +1,44 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// fuzzyFindFilesArgs represents arguments for the fuzzy_find_files tool.
+type fuzzyFindFilesArgs struct {
+	Path       string `json:"path"`
+	Query      string `json:"query"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// TestFuzzyFindFilesToolWithJSONRPC tests the fuzzy_find_files tool via JSON-RPC.
+func TestFuzzyFindFilesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("fuzzy-find-files-jsonrpc-test")
+
+	fixture.AddFileFixture("handlers/user_controller.go", &fsfix.FileFixtureArgs{
+		Content: "package handlers\n",
+	})
+	fixture.AddFileFixture("models/user.go", &fsfix.FileFixtureArgs{
+		Content: "package models\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "fuzzy_find_files",
+		arguments: fuzzyFindFilesArgs{
+			Path:  ".",
+			Query: "usrctl",
+		},
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|match_count":    1,
+			"result.content.0.text|json()|matches.0.path": "handlers/user_controller.go",
+		},
+	})
+}
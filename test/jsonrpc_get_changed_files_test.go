@@ -0,0 +1,25 @@
+package test
+
+import "testing"
+
+// TestGetChangedFilesToolWithJSONRPC tests the get_changed_files tool via JSON-RPC.
+func TestGetChangedFilesToolWithJSONRPC(t *testing.T) {
+	RunJSONRPCTest(t, nil, test{
+		name: "get_changed_files",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"NewSession": {
+				{
+					arguments: sessionTokenArgs{},
+					expected: map[string]any{
+						"result.content.0.text|json()|count": 0,
+					},
+				},
+			},
+		},
+	})
+}
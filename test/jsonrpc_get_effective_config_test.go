@@ -0,0 +1,25 @@
+package test
+
+import "testing"
+
+// TestGetEffectiveConfigToolWithJSONRPC tests the get_effective_config tool via JSON-RPC.
+func TestGetEffectiveConfigToolWithJSONRPC(t *testing.T) {
+	RunJSONRPCTest(t, nil, test{
+		name: "get_effective_config",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			GoFile: {
+				{
+					arguments: sessionTokenArgs{},
+					expected: map[string]any{
+						"result.content.0.text|json()|settings.max_file_size.source": "default",
+					},
+				},
+			},
+		},
+	})
+}
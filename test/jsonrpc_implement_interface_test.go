@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// implementInterfaceArgs represents arguments for the implement_interface tool.
+type implementInterfaceArgs struct {
+	Path          string `json:"path"`
+	TypeName      string `json:"type_name"`
+	InterfaceName string `json:"interface_name"`
+	Language      string `json:"language"`
+}
+
+// TestImplementInterfaceToolWithJSONRPC tests the implement_interface tool via JSON-RPC.
+func TestImplementInterfaceToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("implement-interface-jsonrpc-test")
+
+	fixture.AddFileFixture("store.go", &fsfix.FileFixtureArgs{
+		Content: "package store\n\n// Store saves values.\ntype Store interface {\n\tGet(key string) (string, error)\n\tSet(key, value string) error\n}\n\n// Memory keeps values in memory.\ntype Memory struct {\n\tvalues map[string]string\n}\n\n// Get returns the value for key.\nfunc (m *Memory) Get(key string) (string, error) {\n\treturn m.values[key], nil\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "implement_interface",
+		arguments: implementInterfaceArgs{
+			Path:          "store.go",
+			TypeName:      "Memory",
+			InterfaceName: "Store",
+			Language:      "go",
+		},
+		expected: map[string]any{
+			"jsonrpc":                                      "2.0",
+			"result.content.#":                             1,
+			"result.content.0.type":                        "text",
+			"result.content.0.text|json()|success":         true,
+			"result.content.0.text|json()|methods_added.#": 1,
+			"result.content.0.text|json()|methods_added.0": "Set",
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// inlineSymbolArgs represents arguments for the inline_symbol tool.
+type inlineSymbolArgs struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+// TestInlineSymbolToolWithJSONRPC tests the inline_symbol tool via JSON-RPC.
+func TestInlineSymbolToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("inline-symbol-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport \"fmt\"\n\nconst greeting = \"hello\"\n\nfunc main() {\n\tfmt.Println(greeting)\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "inline_symbol",
+		arguments: inlineSymbolArgs{
+			Path:     "main.go",
+			Language: "go",
+			Name:     "greeting",
+		},
+		expected: map[string]any{
+			"jsonrpc":                                   "2.0",
+			"result.content.#":                          1,
+			"result.content.0.type":                     "text",
+			"result.content.0.text|json()|success":      true,
+			"result.content.0.text|json()|replacements": 1,
+		},
+	})
+}
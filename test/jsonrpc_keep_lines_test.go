@@ -0,0 +1,41 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// keepLinesArgs represents arguments for the keep_lines tool.
+type keepLinesArgs struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+// TestKeepLinesToolWithJSONRPC tests the keep_lines tool via JSON-RPC.
+func TestKeepLinesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("keep-lines-jsonrpc-test")
+	fixture.AddFileFixture("notes.txt", &fsfix.FileFixtureArgs{
+		Content: "header\nkeep me\nand me\nfooter\n",
+	})
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "keep_lines",
+		arguments: keepLinesArgs{
+			Path:      "notes.txt",
+			StartLine: 2,
+			EndLine:   3,
+		},
+		expected: map[string]any{
+			"jsonrpc":                                    "2.0",
+			"result.content.#":                           1,
+			"result.content.0.type":                      "text",
+			"result.content.0.text|json()|success":       true,
+			"result.content.0.text|json()|lines_kept":    2,
+			"result.content.0.text|json()|lines_removed": 2,
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// listDirectoriesArgs represents arguments for the list_directories tool.
+type listDirectoriesArgs struct {
+	Path     string `json:"path"`
+	MaxDepth int    `json:"max_depth,omitempty"`
+}
+
+// TestListDirectoriesToolWithJSONRPC tests the list_directories tool via JSON-RPC.
+func TestListDirectoriesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("list-directories-jsonrpc-test")
+
+	fixture.AddFileFixture("lib/go.mod", &fsfix.FileFixtureArgs{
+		Content: "module example.com/lib\n",
+	})
+	fixture.AddFileFixture("lib/util/util.go", &fsfix.FileFixtureArgs{
+		Content: "package util\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "list_directories",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"ImmediateOnly": {
+				{
+					arguments: listDirectoriesArgs{
+						Path:     ".",
+						MaxDepth: 1,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|directory_count":          1,
+						"result.content.0.text|json()|directories.0.name":       "lib",
+						"result.content.0.text|json()|directories.0.is_project": true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,23 @@
+package test
+
+import "testing"
+
+// TestListFileLocksToolWithJSONRPC tests the list_file_locks tool via JSON-RPC.
+func TestListFileLocksToolWithJSONRPC(t *testing.T) {
+	RunJSONRPCTest(t, nil, test{
+		name: "list_file_locks",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"ListLocks": {
+				{
+					arguments: sessionTokenArgs{},
+					expected:  nil,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// listGenerateDirectivesArgs represents arguments for the list_generate_directives tool.
+type listGenerateDirectivesArgs struct {
+	Path string `json:"path"`
+}
+
+// TestListGenerateDirectivesToolWithJSONRPC tests the list_generate_directives tool via JSON-RPC.
+func TestListGenerateDirectivesToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("list-generate-directives-jsonrpc-test")
+
+	fixture.AddFileFixture("color.go", &fsfix.FileFixtureArgs{
+		Content: "package color\n\n//go:generate stringer -type=Color\n\ntype Color int\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "list_generate_directives",
+		arguments: listGenerateDirectivesArgs{
+			Path: ".",
+		},
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|directive_count":      1,
+			"result.content.0.text|json()|directives.0.line":    3,
+			"result.content.0.text|json()|directives.0.command": "stringer -type=Color",
+		},
+	})
+}
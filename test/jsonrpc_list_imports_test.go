@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// listImportsArgs represents arguments for the list_imports tool.
+type listImportsArgs struct {
+	Path      string `json:"path"`
+	Recursive bool   `json:"recursive,omitempty"`
+}
+
+// TestListImportsToolWithJSONRPC tests the list_imports tool via JSON-RPC.
+func TestListImportsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("list-imports-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println()\n}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "list_imports",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"SingleFile": {
+				{
+					arguments: listImportsArgs{
+						Path: "main.go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|file_count":            1,
+						"result.content.0.text|json()|dependencies.stdlib.0": "fmt",
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// lockFileArgs represents arguments for the lock_file tool.
+type lockFileArgs struct {
+	Path       string `json:"path"`
+	TTLMinutes int    `json:"ttl_minutes,omitempty"`
+}
+
+// TestLockFileToolWithJSONRPC tests the lock_file tool via JSON-RPC.
+func TestLockFileToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("lock-file-jsonrpc-test")
+
+	fixture.AddFileFixture("claimed.txt", &fsfix.FileFixtureArgs{
+		Content: "claimed\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "lock_file",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"LockFile": {
+				{
+					arguments: lockFileArgs{
+						Path:       "claimed.txt",
+						TTLMinutes: 5,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|locked": true,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// readBinaryFileArgs represents arguments for the read_binary_file tool.
+type readBinaryFileArgs struct {
+	Path string `json:"path"`
+}
+
+// TestReadBinaryFileToolWithJSONRPC tests the read_binary_file tool via JSON-RPC.
+func TestReadBinaryFileToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("read-binary-file-jsonrpc-test")
+
+	fixture.AddFileFixture("blob.bin", &fsfix.FileFixtureArgs{
+		Content: "\x00\x01\xff\xfe",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "read_binary_file",
+		arguments: readBinaryFileArgs{
+			Path: "blob.bin",
+		},
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+			"result.content.0.text|json()|content_base64": "AAH//g==",
+			"result.content.0.text|json()|size":           4,
+			"result.content.0.text|json()|truncated":      false,
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// readFileStreamArgs represents arguments for the read_file_stream tool.
+type readFileStreamArgs struct {
+	Path      string `json:"path"`
+	Offset    int    `json:"offset,omitempty"`
+	ChunkSize int    `json:"chunk_size,omitempty"`
+}
+
+// TestReadFileStreamToolWithJSONRPC tests the read_file_stream tool via JSON-RPC.
+func TestReadFileStreamToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("read-file-stream-jsonrpc-test")
+
+	fixture.AddFileFixture("large.txt", &fsfix.FileFixtureArgs{
+		Content: "0123456789abcdef",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "read_file_stream",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"FirstChunk": {
+				{
+					arguments: readFileStreamArgs{
+						Path:      "large.txt",
+						ChunkSize: 10,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|content":     "0123456789",
+						"result.content.0.text|json()|next_offset": 10,
+						"result.content.0.text|json()|total_size":  16,
+						"result.content.0.text|json()|has_more":    true,
+					},
+				},
+			},
+			"LastChunk": {
+				{
+					arguments: readFileStreamArgs{
+						Path:      "large.txt",
+						Offset:    10,
+						ChunkSize: 10,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|content":  "abcdef",
+						"result.content.0.text|json()|has_more": false,
+					},
+				},
+			},
+		},
+	})
+}
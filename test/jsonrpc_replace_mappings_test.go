@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// wordMappingArg represents a single from/to pair for the replace_mappings tool.
+type wordMappingArg struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// replaceMappingsArgs represents arguments for the replace_mappings tool.
+type replaceMappingsArgs struct {
+	Path     string           `json:"path"`
+	Mappings []wordMappingArg `json:"mappings"`
+}
+
+// TestReplaceMappingsToolWithJSONRPC tests the replace_mappings tool via JSON-RPC.
+func TestReplaceMappingsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("replace-mappings-jsonrpc-test")
+
+	fixture.AddFileFixture("colors.txt", &fsfix.FileFixtureArgs{
+		Content: "Red Green Blue\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "replace_mappings",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"Swap": {
+				{
+					arguments: replaceMappingsArgs{
+						Path: "colors.txt",
+						Mappings: []wordMappingArg{
+							{From: "Red", To: "Green"},
+							{From: "Green", To: "Red"},
+						},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|total_replacements": 2,
+					},
+				},
+			},
+		},
+	})
+}
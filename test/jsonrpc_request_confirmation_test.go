@@ -0,0 +1,48 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// requestConfirmationArgs represents arguments for the request_confirmation tool.
+type requestConfirmationArgs struct {
+	Operation string   `json:"operation"`
+	Paths     []string `json:"paths"`
+}
+
+// TestRequestConfirmationToolWithJSONRPC tests the request_confirmation tool via JSON-RPC.
+func TestRequestConfirmationToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("request-confirmation-jsonrpc-test")
+
+	fixture.AddFileFixture("doomed.txt", &fsfix.FileFixtureArgs{
+		Content: "doomed\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "request_confirmation",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"RequestDeleteConfirmation": {
+				{
+					arguments: requestConfirmationArgs{
+						Operation: "delete",
+						Paths:     []string{"doomed.txt"},
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|operation": "delete",
+						"result.content.0.text|json()|paths.#":   1,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// rotateFileArgs represents arguments for the rotate_file tool.
+type rotateFileArgs struct {
+	Path     string `json:"path"`
+	MaxBytes int    `json:"max_bytes"`
+	Keep     int    `json:"keep"`
+}
+
+// TestRotateFileToolWithJSONRPC tests the rotate_file tool via JSON-RPC.
+func TestRotateFileToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("rotate-file-jsonrpc-test")
+
+	fixture.AddFileFixture("activity.log", &fsfix.FileFixtureArgs{
+		Content: "first entry\nsecond entry\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "rotate_file",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"UnderLimit": {
+				{
+					arguments: rotateFileArgs{
+						Path:     "activity.log",
+						MaxBytes: 1024,
+						Keep:     2,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|rotated": false,
+					},
+				},
+			},
+			"OverLimit": {
+				{
+					arguments: rotateFileArgs{
+						Path:     "activity.log",
+						MaxBytes: 10,
+						Keep:     2,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|rotated": true,
+						"result.content.0.text|json()|size":    25,
+					},
+				},
+			},
+		},
+	})
+}
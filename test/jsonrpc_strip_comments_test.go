@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// stripCommentsArgs represents arguments for the strip_comments tool.
+type stripCommentsArgs struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	DryRun   bool   `json:"dry_run,omitempty"`
+}
+
+// TestStripCommentsToolWithJSONRPC tests the strip_comments tool via JSON-RPC.
+func TestStripCommentsToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("strip-comments-jsonrpc-test")
+
+	fixture.AddFileFixture("main.go", &fsfix.FileFixtureArgs{
+		Content: "//go:build linux\n\npackage main\n\n// main does nothing.\nfunc main() {}\n",
+	})
+
+	fixture.AddFileFixture("preview.go", &fsfix.FileFixtureArgs{
+		Content: "//go:build linux\n\npackage main\n\n// main does nothing.\nfunc main() {}\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "strip_comments",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"DryRun": {
+				{
+					arguments: stripCommentsArgs{
+						Path:     "preview.go",
+						Language: "go",
+						DryRun:   true,
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|dry_run":           true,
+						"result.content.0.text|json()|file_count":        1,
+						"result.content.0.text|json()|files.0.content":   "//go:build linux\n\npackage main\n\nfunc main() {}\n",
+						"result.content.0.text|json()|files.0.operation": "updated",
+					},
+				},
+			},
+			"Strip": {
+				{
+					arguments: stripCommentsArgs{
+						Path:     "main.go",
+						Language: "go",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|success":          true,
+						"result.content.0.text|json()|comments_removed": 1,
+						"result.content.0.text|json()|comments_kept":    1,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// toggleCommentArgs represents arguments for the toggle_comment tool.
+type toggleCommentArgs struct {
+	Path      string `json:"path"`
+	Language  string `json:"language"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Action    string `json:"action,omitempty"`
+}
+
+// TestToggleCommentToolWithJSONRPC tests the toggle_comment tool via JSON-RPC.
+func TestToggleCommentToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("toggle-comment-jsonrpc-test")
+
+	fixture.AddFileFixture("script.py", &fsfix.FileFixtureArgs{
+		Content: "def main():\n    setup()\n    run()\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "toggle_comment",
+		arguments: toggleCommentArgs{
+			Path:      "script.py",
+			Language:  "python",
+			StartLine: 2,
+			EndLine:   2,
+			Action:    "comment",
+		},
+		expected: map[string]any{
+			"jsonrpc":                                    "2.0",
+			"result.content.#":                           1,
+			"result.content.0.type":                      "text",
+			"result.content.0.text|json()|success":       true,
+			"result.content.0.text|json()|action":        "comment",
+			"result.content.0.text|json()|lines_changed": 1,
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// unlockFileArgs represents arguments for the unlock_file tool.
+type unlockFileArgs struct {
+	Path string `json:"path"`
+}
+
+// TestUnlockFileToolWithJSONRPC tests the unlock_file tool via JSON-RPC.
+func TestUnlockFileToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("unlock-file-jsonrpc-test")
+
+	fixture.AddFileFixture("unclaimed.txt", &fsfix.FileFixtureArgs{
+		Content: "unclaimed\n",
+	})
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "unlock_file",
+		expected: map[string]any{
+			"jsonrpc":               "2.0",
+			"result.content.#":      1,
+			"result.content.0.type": "text",
+		},
+		subtests: map[string][]subtest{
+			"NotLocked": {
+				{
+					arguments: unlockFileArgs{
+						Path: "unclaimed.txt",
+					},
+					expected: map[string]any{
+						"result.content.0.text|json()|unlocked": false,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mikeschinkel/scout-mcp/fsfix"
+)
+
+// writeBinaryFileArgs represents arguments for the write_binary_file tool.
+type writeBinaryFileArgs struct {
+	Path          string `json:"path"`
+	ContentBase64 string `json:"content_base64"`
+	Overwrite     bool   `json:"overwrite,omitempty"`
+	CreateDirs    bool   `json:"create_dirs,omitempty"`
+}
+
+// TestWriteBinaryFileToolWithJSONRPC tests the write_binary_file tool via JSON-RPC.
+func TestWriteBinaryFileToolWithJSONRPC(t *testing.T) {
+	fixture := fsfix.NewRootFixture("write-binary-file-jsonrpc-test")
+
+	fixture.Setup(t)
+	defer fixture.Cleanup()
+
+	RunJSONRPCTest(t, fixture, test{
+		name: "write_binary_file",
+		arguments: writeBinaryFileArgs{
+			Path:          "assets/blob.bin",
+			ContentBase64: "AAH//g==",
+			CreateDirs:    true,
+		},
+		expected: map[string]any{
+			"jsonrpc":                                    "2.0",
+			"result.content.#":                           1,
+			"result.content.0.type":                      "text",
+			"result.content.0.text|json()|success":       true,
+			"result.content.0.text|json()|bytes_written": 4,
+		},
+	})
+}
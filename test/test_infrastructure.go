@@ -11,6 +11,7 @@ import (
 	"github.com/mikeschinkel/scout-mcp"
 	"github.com/mikeschinkel/scout-mcp/mcptools"
 	"github.com/mikeschinkel/scout-mcp/mcputil"
+	"github.com/mikeschinkel/scout-mcp/scoutcfg"
 	"github.com/mikeschinkel/scout-mcp/testutil"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +34,7 @@ func NewDirectServerTestEnv(t *testing.T) *DirectServerTestEnv {
 	scout.SetLogger(testLogger)
 	mcptools.SetLogger(testLogger)
 	mcputil.SetLogger(testLogger)
+	scoutcfg.SetLogger(testLogger)
 
 	// Create temporary test directory
 	testDir, err := os.MkdirTemp("", "scout-mcp-direct-test-")